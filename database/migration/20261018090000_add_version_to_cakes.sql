@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE cakes ADD COLUMN version INT NOT NULL DEFAULT 1 AFTER image;
+
+-- +goose Down
+ALTER TABLE cakes DROP COLUMN version;
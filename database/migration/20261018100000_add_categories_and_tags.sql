@@ -0,0 +1,39 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS categories (
+  id INT AUTO_INCREMENT PRIMARY KEY,
+  name VARCHAR(60) NOT NULL,
+  slug VARCHAR(60) NOT NULL UNIQUE,
+  description TEXT NOT NULL,
+  created_at timestamp NOT NULL DEFAULT NOW(),
+  updated_at timestamp NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS tags (
+  id INT AUTO_INCREMENT PRIMARY KEY,
+  name VARCHAR(60) NOT NULL,
+  slug VARCHAR(60) NOT NULL UNIQUE,
+  created_at timestamp NOT NULL DEFAULT NOW(),
+  updated_at timestamp NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS cake_categories (
+  cake_id INT NOT NULL,
+  category_id INT NOT NULL,
+  PRIMARY KEY (cake_id, category_id),
+  FOREIGN KEY (cake_id) REFERENCES cakes(id) ON DELETE CASCADE,
+  FOREIGN KEY (category_id) REFERENCES categories(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS cake_tags (
+  cake_id INT NOT NULL,
+  tag_id INT NOT NULL,
+  PRIMARY KEY (cake_id, tag_id),
+  FOREIGN KEY (cake_id) REFERENCES cakes(id) ON DELETE CASCADE,
+  FOREIGN KEY (tag_id) REFERENCES tags(id) ON DELETE CASCADE
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS cake_tags;
+DROP TABLE IF EXISTS cake_categories;
+DROP TABLE IF EXISTS tags;
+DROP TABLE IF EXISTS categories;
@@ -0,0 +1,16 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS cake_variants (
+  id INT AUTO_INCREMENT PRIMARY KEY,
+  cake_id INT NOT NULL,
+  size VARCHAR(30) NOT NULL,
+  servings INT NOT NULL,
+  price BIGINT NOT NULL,
+  sku VARCHAR(64) NOT NULL UNIQUE,
+  active BOOLEAN NOT NULL DEFAULT TRUE,
+  created_at timestamp NOT NULL DEFAULT NOW(),
+  updated_at timestamp NOT NULL DEFAULT NOW(),
+  FOREIGN KEY (cake_id) REFERENCES cakes(id) ON DELETE CASCADE
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS cake_variants;
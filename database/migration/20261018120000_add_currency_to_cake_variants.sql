@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE cake_variants ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'IDR' AFTER price;
+
+-- +goose Down
+ALTER TABLE cake_variants DROP COLUMN currency;
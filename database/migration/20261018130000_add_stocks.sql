@@ -0,0 +1,27 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS stocks (
+  id INT AUTO_INCREMENT PRIMARY KEY,
+  cake_id INT NOT NULL,
+  variant_id INT NOT NULL DEFAULT 0,
+  on_hand INT NOT NULL DEFAULT 0,
+  low_stock_threshold INT NOT NULL DEFAULT 0,
+  updated_at timestamp NOT NULL DEFAULT NOW(),
+  UNIQUE KEY stocks_item (cake_id, variant_id),
+  FOREIGN KEY (cake_id) REFERENCES cakes(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS stock_adjustments (
+  id INT AUTO_INCREMENT PRIMARY KEY,
+  cake_id INT NOT NULL,
+  variant_id INT NOT NULL DEFAULT 0,
+  delta INT NOT NULL,
+  reason VARCHAR(20) NOT NULL,
+  note VARCHAR(255) NOT NULL DEFAULT '',
+  created_at timestamp NOT NULL DEFAULT NOW(),
+  INDEX stock_adjustments_item (cake_id, variant_id),
+  FOREIGN KEY (cake_id) REFERENCES cakes(id) ON DELETE CASCADE
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS stock_adjustments;
+DROP TABLE IF EXISTS stocks;
@@ -0,0 +1,33 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS orders (
+  id INT AUTO_INCREMENT PRIMARY KEY,
+  customer_name VARCHAR(100) NOT NULL,
+  customer_phone VARCHAR(30) NOT NULL,
+  fulfillment VARCHAR(20) NOT NULL,
+  status VARCHAR(20) NOT NULL,
+  note VARCHAR(255) NOT NULL DEFAULT '',
+  total BIGINT NOT NULL,
+  currency CHAR(3) NOT NULL,
+  created_at timestamp NOT NULL DEFAULT NOW(),
+  updated_at timestamp NOT NULL DEFAULT NOW(),
+  INDEX idx_orders_status (status, id)
+);
+
+-- order items keep no foreign key to cakes so the orders survive a purged cake
+CREATE TABLE IF NOT EXISTS order_items (
+  id INT AUTO_INCREMENT PRIMARY KEY,
+  order_id INT NOT NULL,
+  cake_id INT NOT NULL,
+  variant_id INT NOT NULL,
+  title VARCHAR(255) NOT NULL,
+  size VARCHAR(30) NOT NULL,
+  sku VARCHAR(64) NOT NULL,
+  quantity INT NOT NULL,
+  unit_price BIGINT NOT NULL,
+  currency CHAR(3) NOT NULL,
+  FOREIGN KEY (order_id) REFERENCES orders(id) ON DELETE CASCADE
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS order_items;
+DROP TABLE IF EXISTS orders;
@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE order_items ADD COLUMN message VARCHAR(100) NOT NULL DEFAULT '' AFTER quantity;
+
+-- +goose Down
+ALTER TABLE order_items DROP COLUMN message;
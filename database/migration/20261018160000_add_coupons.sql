@@ -0,0 +1,40 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS coupons (
+  id INT AUTO_INCREMENT PRIMARY KEY,
+  code VARCHAR(40) NOT NULL,
+  type VARCHAR(20) NOT NULL,
+  value BIGINT NOT NULL DEFAULT 0,
+  buy_quantity INT NOT NULL DEFAULT 0,
+  get_quantity INT NOT NULL DEFAULT 0,
+  category_id INT NOT NULL DEFAULT 0,
+  min_subtotal BIGINT NOT NULL DEFAULT 0,
+  starts_at timestamp NULL,
+  ends_at timestamp NULL,
+  usage_limit INT NOT NULL DEFAULT 0,
+  stackable BOOLEAN NOT NULL DEFAULT FALSE,
+  active BOOLEAN NOT NULL DEFAULT TRUE,
+  created_at timestamp NOT NULL DEFAULT NOW(),
+  updated_at timestamp NOT NULL DEFAULT NOW(),
+  UNIQUE KEY uq_coupons_code (code)
+);
+
+ALTER TABLE orders
+  ADD COLUMN subtotal BIGINT NOT NULL DEFAULT 0 AFTER note,
+  ADD COLUMN discount BIGINT NOT NULL DEFAULT 0 AFTER subtotal;
+UPDATE orders SET subtotal = total;
+
+-- order discounts keep the code so the orders survive a deleted coupon
+CREATE TABLE IF NOT EXISTS order_discounts (
+  id INT AUTO_INCREMENT PRIMARY KEY,
+  order_id INT NOT NULL,
+  coupon_id INT NOT NULL,
+  code VARCHAR(40) NOT NULL,
+  amount BIGINT NOT NULL,
+  currency CHAR(3) NOT NULL,
+  FOREIGN KEY (order_id) REFERENCES orders(id) ON DELETE CASCADE
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS order_discounts;
+ALTER TABLE orders DROP COLUMN discount, DROP COLUMN subtotal;
+DROP TABLE IF EXISTS coupons;
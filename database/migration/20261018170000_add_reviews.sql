@@ -0,0 +1,24 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS reviews (
+  id INT AUTO_INCREMENT PRIMARY KEY,
+  cake_id INT NOT NULL,
+  score TINYINT NOT NULL,
+  text TEXT NOT NULL,
+  author VARCHAR(60) NOT NULL,
+  created_at timestamp NOT NULL DEFAULT NOW(),
+  updated_at timestamp NOT NULL DEFAULT NOW(),
+  INDEX idx_reviews_cake (cake_id, id),
+  FOREIGN KEY (cake_id) REFERENCES cakes(id) ON DELETE CASCADE
+);
+
+ALTER TABLE cakes
+  ADD COLUMN rating_mean FLOAT NOT NULL DEFAULT 0 AFTER rating,
+  ADD COLUMN rating_count INT NOT NULL DEFAULT 0 AFTER rating_mean,
+  ADD COLUMN legacy_rating FLOAT NULL AFTER rating_count;
+-- the typed in ratings are kept until a review of the cake is approved, and saved to be restored on down
+UPDATE cakes SET legacy_rating = rating;
+
+-- +goose Down
+UPDATE cakes SET rating = legacy_rating WHERE legacy_rating IS NOT NULL;
+ALTER TABLE cakes DROP COLUMN legacy_rating, DROP COLUMN rating_count, DROP COLUMN rating_mean;
+DROP TABLE IF EXISTS reviews;
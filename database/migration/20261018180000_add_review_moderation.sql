@@ -0,0 +1,23 @@
+-- +goose Up
+ALTER TABLE reviews
+  ADD COLUMN status VARCHAR(20) NOT NULL DEFAULT 'pending' AFTER author,
+  ADD COLUMN screening VARCHAR(255) NOT NULL DEFAULT '' AFTER status,
+  ADD COLUMN moderation_note VARCHAR(500) NOT NULL DEFAULT '' AFTER screening,
+  ADD COLUMN moderated_at timestamp NULL AFTER moderation_note,
+  ADD COLUMN fingerprint CHAR(64) NOT NULL DEFAULT '' AFTER moderated_at,
+  ADD INDEX idx_reviews_status (status, id),
+  ADD INDEX idx_reviews_fingerprint (cake_id, fingerprint),
+  ADD INDEX idx_reviews_author (author, created_at);
+-- the reviews written before moderation were already public
+UPDATE reviews SET status = 'approved';
+
+-- +goose Down
+ALTER TABLE reviews
+  DROP INDEX idx_reviews_author,
+  DROP INDEX idx_reviews_fingerprint,
+  DROP INDEX idx_reviews_status,
+  DROP COLUMN fingerprint,
+  DROP COLUMN moderated_at,
+  DROP COLUMN moderation_note,
+  DROP COLUMN screening,
+  DROP COLUMN status;
@@ -0,0 +1,30 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS ingredients (
+  id INT AUTO_INCREMENT PRIMARY KEY,
+  name VARCHAR(60) NOT NULL,
+  allergens VARCHAR(255) NOT NULL DEFAULT '',
+  energy_kcal DECIMAL(8,2) NOT NULL DEFAULT 0,
+  fat DECIMAL(8,2) NOT NULL DEFAULT 0,
+  carbohydrate DECIMAL(8,2) NOT NULL DEFAULT 0,
+  sugar DECIMAL(8,2) NOT NULL DEFAULT 0,
+  protein DECIMAL(8,2) NOT NULL DEFAULT 0,
+  salt DECIMAL(8,2) NOT NULL DEFAULT 0,
+  created_at timestamp NOT NULL DEFAULT NOW(),
+  updated_at timestamp NOT NULL DEFAULT NOW(),
+  UNIQUE KEY uq_ingredients_name (name)
+);
+
+-- an ingredient cannot be deleted while a cake list it, so the declared allergens never silently change
+CREATE TABLE IF NOT EXISTS cake_ingredients (
+  cake_id INT NOT NULL,
+  ingredient_id INT NOT NULL,
+  quantity DECIMAL(10,2) NOT NULL,
+  PRIMARY KEY (cake_id, ingredient_id),
+  INDEX idx_cake_ingredients_ingredient (ingredient_id),
+  FOREIGN KEY (cake_id) REFERENCES cakes(id) ON DELETE CASCADE,
+  FOREIGN KEY (ingredient_id) REFERENCES ingredients(id) ON DELETE RESTRICT
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS cake_ingredients;
+DROP TABLE IF EXISTS ingredients;
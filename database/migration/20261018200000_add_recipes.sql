@@ -0,0 +1,55 @@
+-- +goose Up
+-- density in g/ml convert between mass and volume units, zero when unknown
+ALTER TABLE ingredients ADD COLUMN density DECIMAL(6,3) NOT NULL DEFAULT 0 AFTER allergens;
+
+CREATE TABLE IF NOT EXISTS recipes (
+  id INT AUTO_INCREMENT PRIMARY KEY,
+  cake_id INT NOT NULL,
+  yield INT NOT NULL,
+  created_at timestamp NOT NULL DEFAULT NOW(),
+  updated_at timestamp NOT NULL DEFAULT NOW(),
+  UNIQUE KEY uq_recipes_cake (cake_id),
+  FOREIGN KEY (cake_id) REFERENCES cakes(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS recipe_steps (
+  recipe_id INT NOT NULL,
+  position INT NOT NULL,
+  instruction TEXT NOT NULL,
+  minutes INT NOT NULL DEFAULT 0,
+  PRIMARY KEY (recipe_id, position),
+  FOREIGN KEY (recipe_id) REFERENCES recipes(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS recipe_ingredients (
+  recipe_id INT NOT NULL,
+  position INT NOT NULL,
+  ingredient_id INT NOT NULL,
+  quantity DECIMAL(12,3) NOT NULL,
+  unit VARCHAR(8) NOT NULL,
+  PRIMARY KEY (recipe_id, position),
+  INDEX idx_recipe_ingredients_ingredient (ingredient_id),
+  FOREIGN KEY (recipe_id) REFERENCES recipes(id) ON DELETE CASCADE,
+  FOREIGN KEY (ingredient_id) REFERENCES ingredients(id) ON DELETE RESTRICT
+);
+
+-- the price a supplier asks for a quantity of an ingredient, e.g. IDR 15000 for 1 kg
+CREATE TABLE IF NOT EXISTS supplier_prices (
+  id INT AUTO_INCREMENT PRIMARY KEY,
+  ingredient_id INT NOT NULL,
+  supplier VARCHAR(100) NOT NULL,
+  price BIGINT NOT NULL,
+  currency CHAR(3) NOT NULL,
+  quantity DECIMAL(12,3) NOT NULL,
+  unit VARCHAR(8) NOT NULL,
+  updated_at timestamp NOT NULL DEFAULT NOW(),
+  UNIQUE KEY uq_supplier_prices_supplier (ingredient_id, supplier),
+  FOREIGN KEY (ingredient_id) REFERENCES ingredients(id) ON DELETE CASCADE
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS supplier_prices;
+DROP TABLE IF EXISTS recipe_ingredients;
+DROP TABLE IF EXISTS recipe_steps;
+DROP TABLE IF EXISTS recipes;
+ALTER TABLE ingredients DROP COLUMN density;
@@ -0,0 +1,29 @@
+-- +goose Up
+-- on_hand may go below zero when the confirmed orders consume more than was counted
+CREATE TABLE IF NOT EXISTS ingredient_stocks (
+  ingredient_id INT PRIMARY KEY,
+  on_hand DECIMAL(14,3) NOT NULL DEFAULT 0,
+  unit VARCHAR(8) NOT NULL,
+  reorder_point DECIMAL(14,3) NOT NULL DEFAULT 0,
+  reorder_quantity DECIMAL(14,3) NOT NULL DEFAULT 0,
+  updated_at timestamp NOT NULL DEFAULT NOW(),
+  FOREIGN KEY (ingredient_id) REFERENCES ingredients(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS ingredient_stock_adjustments (
+  id INT AUTO_INCREMENT PRIMARY KEY,
+  ingredient_id INT NOT NULL,
+  order_id INT NOT NULL DEFAULT 0,
+  delta DECIMAL(14,3) NOT NULL,
+  unit VARCHAR(8) NOT NULL,
+  reason VARCHAR(20) NOT NULL,
+  note VARCHAR(255) NOT NULL DEFAULT '',
+  created_at timestamp NOT NULL DEFAULT NOW(),
+  INDEX ingredient_stock_adjustments_ingredient (ingredient_id),
+  INDEX ingredient_stock_adjustments_order (order_id),
+  FOREIGN KEY (ingredient_id) REFERENCES ingredients(id) ON DELETE CASCADE
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS ingredient_stock_adjustments;
+DROP TABLE IF EXISTS ingredient_stocks;
@@ -0,0 +1,21 @@
+-- +goose Up
+-- the orders placed before the pickup date existed are picked up the day they were placed
+ALTER TABLE orders ADD COLUMN pickup_date DATE NULL AFTER note;
+UPDATE orders SET pickup_date = DATE(created_at);
+ALTER TABLE orders
+  MODIFY COLUMN pickup_date DATE NOT NULL,
+  ADD INDEX idx_orders_pickup_date (pickup_date, status);
+
+-- batch_size is the number of cakes baked together in one oven load
+CREATE TABLE IF NOT EXISTS cake_production (
+  cake_id INT PRIMARY KEY,
+  prep_minutes INT NOT NULL DEFAULT 0,
+  bake_minutes INT NOT NULL,
+  batch_size INT NOT NULL DEFAULT 1,
+  updated_at timestamp NOT NULL DEFAULT NOW(),
+  FOREIGN KEY (cake_id) REFERENCES cakes(id) ON DELETE CASCADE
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS cake_production;
+ALTER TABLE orders DROP INDEX idx_orders_pickup_date, DROP COLUMN pickup_date;
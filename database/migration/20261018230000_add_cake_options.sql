@@ -0,0 +1,50 @@
+-- +goose Up
+-- the option groups of a custom cake, e.g. flavor, filling, frosting, size or inscription
+CREATE TABLE IF NOT EXISTS option_groups (
+  id INT AUTO_INCREMENT PRIMARY KEY,
+  cake_id INT NOT NULL,
+  code VARCHAR(40) NOT NULL,
+  name VARCHAR(100) NOT NULL,
+  kind VARCHAR(10) NOT NULL,
+  required TINYINT(1) NOT NULL DEFAULT 0,
+  max_choices INT NOT NULL DEFAULT 0,
+  max_length INT NOT NULL DEFAULT 0,
+  price_modifier BIGINT NOT NULL DEFAULT 0,
+  currency CHAR(3) NOT NULL,
+  position INT NOT NULL,
+  updated_at timestamp NOT NULL DEFAULT NOW(),
+  UNIQUE KEY uq_option_groups_code (cake_id, code),
+  FOREIGN KEY (cake_id) REFERENCES cakes(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS option_choices (
+  id INT AUTO_INCREMENT PRIMARY KEY,
+  group_id INT NOT NULL,
+  code VARCHAR(40) NOT NULL,
+  name VARCHAR(100) NOT NULL,
+  price_modifier BIGINT NOT NULL DEFAULT 0,
+  currency CHAR(3) NOT NULL,
+  value DECIMAL(12,3) NOT NULL DEFAULT 0,
+  position INT NOT NULL,
+  UNIQUE KEY uq_option_choices_code (group_id, code),
+  FOREIGN KEY (group_id) REFERENCES option_groups(id) ON DELETE CASCADE
+);
+
+-- a choice is only allowed when a choice of the group with a value in the range is selected, a null bound is open
+CREATE TABLE IF NOT EXISTS option_constraints (
+  id INT AUTO_INCREMENT PRIMARY KEY,
+  choice_id INT NOT NULL,
+  group_code VARCHAR(40) NOT NULL,
+  min_value DECIMAL(12,3) NULL,
+  max_value DECIMAL(12,3) NULL,
+  FOREIGN KEY (choice_id) REFERENCES option_choices(id) ON DELETE CASCADE
+);
+
+-- the selected options of a custom cake, kept as they were when ordered
+ALTER TABLE order_items ADD COLUMN options JSON NULL AFTER message;
+
+-- +goose Down
+ALTER TABLE order_items DROP COLUMN options;
+DROP TABLE IF EXISTS option_constraints;
+DROP TABLE IF EXISTS option_choices;
+DROP TABLE IF EXISTS option_groups;
@@ -0,0 +1,26 @@
+-- +goose Up
+-- the weekly opening hours, weekday 0 is sunday, the store is closed on the weekdays without a row
+CREATE TABLE IF NOT EXISTS opening_hours (
+  weekday TINYINT NOT NULL PRIMARY KEY,
+  opens_at TIME NOT NULL,
+  closes_at TIME NOT NULL,
+  slot_capacity INT NOT NULL,
+  updated_at timestamp NOT NULL DEFAULT NOW()
+);
+
+-- the dates the store is closed, e.g. the holidays
+CREATE TABLE IF NOT EXISTS closures (
+  date DATE NOT NULL PRIMARY KEY,
+  reason VARCHAR(255) NOT NULL DEFAULT '',
+  created_at timestamp NOT NULL DEFAULT NOW()
+);
+
+-- the start of the time slot the order is picked up or delivered in, null when the order has no slot
+ALTER TABLE orders ADD COLUMN pickup_slot DATETIME NULL AFTER pickup_date;
+CREATE INDEX idx_orders_pickup_slot ON orders (pickup_slot);
+
+-- +goose Down
+DROP INDEX idx_orders_pickup_slot ON orders;
+ALTER TABLE orders DROP COLUMN pickup_slot;
+DROP TABLE IF EXISTS closures;
+DROP TABLE IF EXISTS opening_hours;
@@ -0,0 +1,56 @@
+-- +goose Up
+-- the bakery branches, the existing opening hours, closures and orders belong to the main store
+CREATE TABLE IF NOT EXISTS stores (
+  id INT AUTO_INCREMENT PRIMARY KEY,
+  code VARCHAR(40) NOT NULL,
+  name VARCHAR(100) NOT NULL,
+  address VARCHAR(255) NOT NULL DEFAULT '',
+  phone VARCHAR(30) NOT NULL DEFAULT '',
+  active TINYINT(1) NOT NULL DEFAULT 1,
+  created_at timestamp NOT NULL DEFAULT NOW(),
+  updated_at timestamp NOT NULL DEFAULT NOW(),
+  UNIQUE KEY uq_stores_code (code)
+);
+
+INSERT INTO stores(id, code, name) VALUES (1, 'main', 'Main Store');
+
+-- the availability of a cake in a store, a cake without a row is sold in every store
+CREATE TABLE IF NOT EXISTS store_cakes (
+  store_id INT NOT NULL,
+  cake_id INT NOT NULL,
+  available TINYINT(1) NOT NULL DEFAULT 1,
+  updated_at timestamp NOT NULL DEFAULT NOW(),
+  PRIMARY KEY (store_id, cake_id),
+  FOREIGN KEY (store_id) REFERENCES stores(id) ON DELETE CASCADE,
+  FOREIGN KEY (cake_id) REFERENCES cakes(id) ON DELETE CASCADE
+);
+
+-- the price of a variant in a store, overriding the variant price
+CREATE TABLE IF NOT EXISTS store_prices (
+  store_id INT NOT NULL,
+  variant_id INT NOT NULL,
+  price BIGINT NOT NULL,
+  currency CHAR(3) NOT NULL,
+  PRIMARY KEY (store_id, variant_id),
+  FOREIGN KEY (store_id) REFERENCES stores(id) ON DELETE CASCADE,
+  FOREIGN KEY (variant_id) REFERENCES cake_variants(id) ON DELETE CASCADE
+);
+
+ALTER TABLE opening_hours ADD COLUMN store_id INT NOT NULL DEFAULT 1 FIRST, DROP PRIMARY KEY, ADD PRIMARY KEY (store_id, weekday);
+ALTER TABLE closures ADD COLUMN store_id INT NOT NULL DEFAULT 1 FIRST, DROP PRIMARY KEY, ADD PRIMARY KEY (store_id, date);
+
+ALTER TABLE orders ADD COLUMN store_id INT NOT NULL DEFAULT 1 AFTER id;
+DROP INDEX idx_orders_pickup_slot ON orders;
+CREATE INDEX idx_orders_store_pickup_slot ON orders (store_id, pickup_slot);
+
+-- +goose Down
+DROP INDEX idx_orders_store_pickup_slot ON orders;
+CREATE INDEX idx_orders_pickup_slot ON orders (pickup_slot);
+ALTER TABLE orders DROP COLUMN store_id;
+DELETE FROM closures WHERE store_id <> 1;
+ALTER TABLE closures DROP PRIMARY KEY, DROP COLUMN store_id, ADD PRIMARY KEY (date);
+DELETE FROM opening_hours WHERE store_id <> 1;
+ALTER TABLE opening_hours DROP PRIMARY KEY, DROP COLUMN store_id, ADD PRIMARY KEY (weekday);
+DROP TABLE IF EXISTS store_prices;
+DROP TABLE IF EXISTS store_cakes;
+DROP TABLE IF EXISTS stores;
@@ -0,0 +1,6 @@
+-- +goose Up
+-- the location of the stores, a store without one is never the nearest store
+ALTER TABLE stores ADD COLUMN latitude DOUBLE NULL AFTER phone, ADD COLUMN longitude DOUBLE NULL AFTER latitude;
+
+-- +goose Down
+ALTER TABLE stores DROP COLUMN longitude, DROP COLUMN latitude;
@@ -0,0 +1,34 @@
+-- +goose Up
+-- the attempts to pay the orders, a declined or failed attempt is kept,
+-- live_order_id is set on the live payments only so an order has at most one live payment
+CREATE TABLE IF NOT EXISTS payments (
+  id INT AUTO_INCREMENT PRIMARY KEY,
+  order_id INT NOT NULL,
+  provider VARCHAR(40) NOT NULL,
+  reference VARCHAR(100) NOT NULL,
+  status VARCHAR(20) NOT NULL,
+  amount BIGINT NOT NULL,
+  currency CHAR(3) NOT NULL,
+  reason VARCHAR(255) NOT NULL DEFAULT '',
+  created_at timestamp NOT NULL DEFAULT NOW(),
+  updated_at timestamp NOT NULL DEFAULT NOW(),
+  live_order_id INT AS (IF(status IN ('authorized', 'capturing', 'settling', 'captured', 'refunding'), order_id, NULL)) VIRTUAL,
+  UNIQUE KEY uq_payments_reference (provider, reference),
+  UNIQUE KEY uq_payments_live (live_order_id),
+  FOREIGN KEY (order_id) REFERENCES orders(id) ON DELETE CASCADE
+);
+
+-- the status changes of the payments, the first event of a payment has an empty from status
+CREATE TABLE IF NOT EXISTS payment_events (
+  id INT AUTO_INCREMENT PRIMARY KEY,
+  payment_id INT NOT NULL,
+  from_status VARCHAR(20) NOT NULL DEFAULT '',
+  to_status VARCHAR(20) NOT NULL,
+  reason VARCHAR(255) NOT NULL DEFAULT '',
+  created_at timestamp NOT NULL DEFAULT NOW(),
+  FOREIGN KEY (payment_id) REFERENCES payments(id) ON DELETE CASCADE
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS payment_events;
+DROP TABLE IF EXISTS payments;
@@ -0,0 +1,44 @@
+package auth
+
+import (
+	"cake-store/src/constant"
+	"crypto/subtle"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	// HeaderAdminToken carry the admin token of the request
+	HeaderAdminToken = "X-Admin-Token"
+	// ContextKeyAdmin is the echo context key marking an admin request
+	ContextKeyAdmin = "admin"
+)
+
+// Admin mark the request as admin when it carry the configured admin token, an empty token disable the admin access
+func Admin(token string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			reqToken := c.Request().Header.Get(HeaderAdminToken)
+			if token != "" && subtle.ConstantTimeCompare([]byte(reqToken), []byte(token)) == 1 {
+				c.Set(ContextKeyAdmin, true)
+			}
+			return next(c)
+		}
+	}
+}
+
+// RequireAdmin reject the request not marked as admin
+func RequireAdmin(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if !IsAdmin(c) {
+			return constant.ErrForbidden
+		}
+		return next(c)
+	}
+}
+
+// IsAdmin report whether the request is marked as admin
+func IsAdmin(c echo.Context) bool {
+	admin, _ := c.Get(ContextKeyAdmin).(bool)
+	return admin
+}
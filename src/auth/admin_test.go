@@ -0,0 +1,55 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdmin(t *testing.T) {
+	handler := Admin("secret")(RequireAdmin(func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	}))
+
+	t.Run("ok", func(t *testing.T) {
+		ec := echo.New()
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/cakes/trash", nil)
+		req.Header.Set(HeaderAdminToken, "secret")
+		ectx := ec.NewContext(req, rec)
+
+		err := handler(ectx)
+		require.NoError(t, err)
+		require.True(t, IsAdmin(ectx))
+		require.EqualValues(t, http.StatusOK, rec.Result().StatusCode)
+	})
+
+	t.Run("wrong token", func(t *testing.T) {
+		ec := echo.New()
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/cakes/trash", nil)
+		req.Header.Set(HeaderAdminToken, "other")
+		ectx := ec.NewContext(req, rec)
+
+		err := handler(ectx)
+		ec.DefaultHTTPErrorHandler(err, ectx)
+		require.False(t, IsAdmin(ectx))
+		require.EqualValues(t, http.StatusForbidden, rec.Result().StatusCode)
+	})
+
+	t.Run("admin disabled", func(t *testing.T) {
+		ec := echo.New()
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/cakes/trash", nil)
+		ectx := ec.NewContext(req, rec)
+
+		err := Admin("")(RequireAdmin(func(c echo.Context) error {
+			return c.NoContent(http.StatusOK)
+		}))(ectx)
+		ec.DefaultHTTPErrorHandler(err, ectx)
+		require.EqualValues(t, http.StatusForbidden, rec.Result().StatusCode)
+	})
+}
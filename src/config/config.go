@@ -3,6 +3,7 @@ package config
 import (
 	"cake-store/src/helper"
 	"fmt"
+	"strings"
 	"time"
 
 	log "github.com/sirupsen/logrus"
@@ -14,6 +15,8 @@ func InitConfig() {
 	viper.AddConfigPath("./../..")
 	viper.SetConfigName("config")
 
+	// a nested key is read from the environment with its dots as underscores, cursor.secret from CURSOR_SECRET
+	viper.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
 	viper.AutomaticEnv()
 	if err := viper.ReadInConfig(); err != nil {
 		log.Warningf("%v", err)
@@ -80,3 +83,184 @@ func RedisExp() time.Duration {
 	time := viper.GetString("redis.exp")
 	return helper.ParseTimeDuration(time, DefaultRedisExpiredDuration)
 }
+
+// CursorSecret is the secret the pagination cursors are signed with, it has no default so a deployment can not run
+// with a known secret
+func CursorSecret() string {
+	return viper.GetString("cursor.secret")
+}
+
+func AdminToken() string {
+	return viper.GetString("admin.token")
+}
+
+func RetentionDeletedCakes() time.Duration {
+	time := viper.GetString("retention.deletedCakes")
+	return helper.ParseTimeDuration(time, DefaultRetentionDeletedCakes)
+}
+
+func RetentionBatchSize() int {
+	if !viper.IsSet("retention.batchSize") {
+		return DefaultRetentionBatchSize
+	}
+	return viper.GetInt("retention.batchSize")
+}
+
+func RetentionLockTTL() time.Duration {
+	time := viper.GetString("retention.lockTTL")
+	return helper.ParseTimeDuration(time, DefaultRetentionLockTTL)
+}
+
+// BaseCurrency is the currency of the prices without an explicit currency
+func BaseCurrency() string {
+	if !viper.IsSet("currency.base") {
+		return DefaultBaseCurrency
+	}
+	return viper.GetString("currency.base")
+}
+
+func ExchangeRatesFile() string {
+	return viper.GetString("currency.ratesFile")
+}
+
+// StockReservationTTL is how long a stock reservation is held when the request does not say
+func StockReservationTTL() time.Duration {
+	time := viper.GetString("stock.reservationTTL")
+	return helper.ParseTimeDuration(time, DefaultStockReservationTTL)
+}
+
+// CartTTL is how long an untouched cart is kept, every read or change of the cart restart it
+func CartTTL() time.Duration {
+	time := viper.GetString("cart.ttl")
+	return helper.ParseTimeDuration(time, DefaultCartTTL)
+}
+
+// RatingPriorWeight is the number of virtual reviews scored at the prior mean blended into each cake rating,
+// zero rate the cakes by the plain mean of their reviews
+func RatingPriorWeight() int {
+	if !viper.IsSet("rating.priorWeight") {
+		return DefaultRatingPriorWeight
+	}
+	return viper.GetInt("rating.priorWeight")
+}
+
+// RatingPriorMean is the score of the virtual reviews of the bayesian rating
+func RatingPriorMean() float64 {
+	if !viper.IsSet("rating.priorMean") {
+		return DefaultRatingPriorMean
+	}
+	return viper.GetFloat64("rating.priorMean")
+}
+
+// ReviewBannedWords is the words that get a review rejected, matched as whole words ignoring case
+func ReviewBannedWords() []string {
+	return viper.GetStringSlice("review.bannedWords")
+}
+
+// ReviewAuthorLimit is the number of reviews an author can write in the window before the next are rejected,
+// zero disable the limit
+func ReviewAuthorLimit() int {
+	if !viper.IsSet("review.authorLimit") {
+		return DefaultReviewAuthorLimit
+	}
+	return viper.GetInt("review.authorLimit")
+}
+
+func ReviewAuthorWindow() time.Duration {
+	time := viper.GetString("review.authorWindow")
+	return helper.ParseTimeDuration(time, DefaultReviewAuthorWindow)
+}
+
+// ProductionDayStart is when the bakers start, as the time since midnight, e.g. "6h30m"
+func ProductionDayStart() time.Duration {
+	time := viper.GetString("production.dayStart")
+	return helper.ParseTimeDuration(time, DefaultProductionDayStart)
+}
+
+// ProductionPrepMinutes is the preparation time of the cakes without production settings
+func ProductionPrepMinutes() int {
+	if !viper.IsSet("production.prepMinutes") {
+		return DefaultPrepMinutes
+	}
+	return viper.GetInt("production.prepMinutes")
+}
+
+// ProductionBakeMinutes is the baking time of the cakes without production settings
+func ProductionBakeMinutes() int {
+	if !viper.IsSet("production.bakeMinutes") {
+		return DefaultBakeMinutes
+	}
+	return viper.GetInt("production.bakeMinutes")
+}
+
+// ProductionBatchSize is the number of cakes without production settings baked together
+func ProductionBatchSize() int {
+	if !viper.IsSet("production.batchSize") {
+		return DefaultBatchSize
+	}
+	return viper.GetInt("production.batchSize")
+}
+
+// SlotLength is the length of the pickup and delivery time slots
+func SlotLength() time.Duration {
+	time := viper.GetString("slots.length")
+	return helper.ParseTimeDuration(time, DefaultSlotLength)
+}
+
+// SlotBookingTTL is how long the booking counter of a slot is kept after the slot ended
+func SlotBookingTTL() time.Duration {
+	time := viper.GetString("slots.bookingTTL")
+	return helper.ParseTimeDuration(time, DefaultSlotBookingTTL)
+}
+
+// SlotReconcileGrace is how long after a booking or a release the reconcile leave the counter of the slot as high as
+// it is, the checkout may not have saved its order yet
+func SlotReconcileGrace() time.Duration {
+	time := viper.GetString("slots.reconcileGrace")
+	return helper.ParseTimeDuration(time, DefaultSlotReconcileGrace)
+}
+
+// DefaultStoreId is the store of the orders, carts and slots that do not name one
+func DefaultStoreId() int {
+	if !viper.IsSet("stores.default") {
+		return DefaultStore
+	}
+	return viper.GetInt("stores.default")
+}
+
+// DeliveryTier is the fee of the deliveries up to the distance
+type DeliveryTier struct {
+	UpToKm float64 `mapstructure:"upToKm"`
+	Fee    int64   `mapstructure:"fee"`
+}
+
+// DeliverySettings is the delivery fee policy, "flat" or "tiered", the fees and the free delivery threshold are in
+// the minor units of the base currency, a zero threshold never deliver for free
+type DeliverySettings struct {
+	Policy    string         `mapstructure:"policy"`
+	FlatFee   int64          `mapstructure:"flatFee"`
+	Tiers     []DeliveryTier `mapstructure:"tiers"`
+	FreeAbove int64          `mapstructure:"freeAbove"`
+}
+
+func Delivery() DeliverySettings {
+	settings := DeliverySettings{Policy: DefaultDeliveryPolicy}
+	if err := viper.UnmarshalKey("delivery", &settings); err != nil {
+		log.Warningf("%v", err)
+	}
+	return settings
+}
+
+// PaymentProvider is the gateway the orders are paid through, only the "fake" gateway simulating the payments is built in
+func PaymentProvider() string {
+	if !viper.IsSet("payment.provider") {
+		return DefaultPaymentProvider
+	}
+	return viper.GetString("payment.provider")
+}
+
+// PaymentWebhookSecret is the secret the webhooks of the gateway are signed with, it has no default so a deployment
+// can not run with a known secret
+func PaymentWebhookSecret() string {
+	return viper.GetString("payment.webhookSecret")
+}
@@ -4,9 +4,41 @@ import "time"
 
 // default const
 const (
-	DefaultConnMaxLifeTime      time.Duration = 1 * time.Hour
-	DefaultConnMaxIdleTime      time.Duration = 15 * time.Minute
-	DefaultAccessTokenDuration  time.Duration = 1 * time.Hour
-	DefaultRefreshTokenDuration time.Duration = 24 * time.Hour * 7 // 7 days
-	DefaultRedisExpiredDuration time.Duration = 5 * time.Minute
+	DefaultConnMaxLifeTime       time.Duration = 1 * time.Hour
+	DefaultConnMaxIdleTime       time.Duration = 15 * time.Minute
+	DefaultAccessTokenDuration   time.Duration = 1 * time.Hour
+	DefaultRefreshTokenDuration  time.Duration = 24 * time.Hour * 7 // 7 days
+	DefaultRedisExpiredDuration  time.Duration = 5 * time.Minute
+	DefaultRetentionDeletedCakes time.Duration = 24 * time.Hour * 30 // 30 days
+	DefaultRetentionLockTTL      time.Duration = 10 * time.Minute
+	DefaultStockReservationTTL   time.Duration = 15 * time.Minute
+	DefaultCartTTL               time.Duration = 72 * time.Hour
+	DefaultReviewAuthorWindow    time.Duration = 24 * time.Hour
+	DefaultProductionDayStart    time.Duration = 6 * time.Hour
+	DefaultSlotLength            time.Duration = 1 * time.Hour
+	DefaultSlotBookingTTL        time.Duration = 48 * time.Hour
+	DefaultSlotReconcileGrace    time.Duration = 2 * time.Minute
+)
+
+// default int const
+const (
+	DefaultRetentionBatchSize int = 500
+	DefaultRatingPriorWeight  int = 0
+	DefaultReviewAuthorLimit  int = 3
+	DefaultPrepMinutes        int = 30
+	DefaultBakeMinutes        int = 45
+	DefaultBatchSize          int = 1
+	DefaultStore              int = 1
+)
+
+// default float const
+const (
+	DefaultRatingPriorMean float64 = 5.5
+)
+
+// default string const
+const (
+	DefaultBaseCurrency    string = "IDR"
+	DefaultDeliveryPolicy  string = "flat"
+	DefaultPaymentProvider string = "fake"
 )
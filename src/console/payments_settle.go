@@ -0,0 +1,70 @@
+package console
+
+import (
+	"cake-store/src/config"
+	"cake-store/src/database"
+	"cake-store/src/model"
+	"cake-store/src/payment"
+	"cake-store/src/repository"
+	"cake-store/src/service"
+	"context"
+	"encoding/json"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var paymentsSettleCmd = &cobra.Command{
+	Use:   "payments-settle",
+	Short: "settle a payment of the fake gateway",
+	Long:  "Send the settlement webhook of a settling payment of the fake gateway, as the gateway would once the capture is settled, so the delayed settlements can be simulated offline",
+	Run:   paymentsSettle,
+}
+
+func init() {
+	paymentsSettleCmd.PersistentFlags().String("reference", "", "reference of the payment at the gateway")
+	paymentsSettleCmd.PersistentFlags().Bool("fail", false, "fail the settlement instead of capturing the payment")
+	RootCmd.AddCommand(paymentsSettleCmd)
+}
+
+func paymentsSettle(cmd *cobra.Command, args []string) {
+	reference, _ := cmd.Flags().GetString("reference")
+	if reference == "" {
+		log.Fatal("The reference of the payment is required")
+	}
+
+	if config.PaymentProvider() != payment.FakeProvider {
+		log.Fatal("Only the payments of the fake gateway can be settled")
+	}
+
+	event := model.GatewayEvent{Reference: reference, Status: model.PaymentStatusCaptured}
+	if fail, _ := cmd.Flags().GetBool("fail"); fail {
+		event.Status, event.Reason = model.PaymentStatusFailed, "settlement failed"
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		log.Fatal("Failed to write the webhook: ", err)
+	}
+
+	gateway, err := payment.NewGateway(config.PaymentProvider(), config.PaymentWebhookSecret())
+	if err != nil {
+		log.Fatal("Failed to load the payment gateway: ", err)
+	}
+
+	db := database.NewDB()
+	defer db.Close()
+
+	paymentService := service.NewPaymentService(repository.NewPaymentRepository(db), repository.NewOrderRepository(db), gateway)
+
+	settled, err := paymentService.HandleWebhook(context.Background(), payload, payment.Sign(config.PaymentWebhookSecret(), payload))
+	if err != nil {
+		log.Fatal("Failed to settle the payment: ", err)
+	}
+
+	log.WithFields(log.Fields{
+		"payment": settled.Id,
+		"order":   settled.OrderId,
+		"status":  settled.Status,
+	}).Info("Success settled the payment")
+}
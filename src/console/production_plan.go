@@ -0,0 +1,89 @@
+package console
+
+import (
+	"cake-store/src/config"
+	"cake-store/src/database"
+	"cake-store/src/model"
+	"cake-store/src/report"
+	"cake-store/src/repository"
+	"cake-store/src/service"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var productionPlanCmd = &cobra.Command{
+	Use:   "production-plan",
+	Short: "print the bake schedule of a day",
+	Long:  "Print or export the batches to bake for the confirmed orders picked up on a day",
+	Run:   productionPlan,
+}
+
+func init() {
+	productionPlanCmd.PersistentFlags().String("date", "", "day to plan as YYYY-MM-DD, today when empty")
+	productionPlanCmd.PersistentFlags().String("format", "csv", "output format, one of csv, html or json")
+	productionPlanCmd.PersistentFlags().String("output", "", "file to export the plan to, the standard output when empty")
+	RootCmd.AddCommand(productionPlanCmd)
+}
+
+func productionPlan(cmd *cobra.Command, args []string) {
+	date, _ := cmd.Flags().GetString("date")
+	format, _ := cmd.Flags().GetString("format")
+	output, _ := cmd.Flags().GetString("output")
+
+	write, ok := productionPlanWriters[format]
+	if !ok {
+		log.Fatalf("Unknown plan format %q, expected csv, html or json", format)
+	}
+
+	db := database.NewDB()
+	defer db.Close()
+
+	redisConn := database.NewRedisConn(config.RedisHost())
+	defer redisConn.Close()
+
+	productionService := service.NewProductionService(repository.NewProductionRepository(db), repository.NewOrderRepository(db),
+		repository.NewStockRepository(db, redisConn), repository.NewCakeRepository(db, redisConn))
+
+	plan, err := productionService.Plan(context.Background(), model.ProductionQuery{Date: date})
+	if err != nil {
+		log.Fatal("Failed to plan the production: ", err)
+	}
+
+	var out io.Writer = os.Stdout
+	if output != "" {
+		file, err := os.Create(output)
+		if err != nil {
+			log.Fatal("Failed to create the plan file: ", err)
+		}
+		defer file.Close()
+		out = file
+	}
+
+	if err = write(out, plan); err != nil {
+		log.Error("Failed to write the production plan: ", err)
+		return
+	}
+
+	if output != "" {
+		log.WithFields(log.Fields{
+			"date":     plan.Date,
+			"batches":  len(plan.Batches),
+			"finishAt": plan.FinishAt.Format("15:04"),
+		}).Info("Success exported the production plan to ", output)
+	}
+}
+
+var productionPlanWriters = map[string]func(io.Writer, *model.ProductionPlan) error{
+	model.ProductionFormatCSV:  report.ProductionCSV,
+	model.ProductionFormatHTML: report.ProductionHTML,
+	model.ProductionFormatJSON: func(out io.Writer, plan *model.ProductionPlan) error {
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(plan)
+	},
+}
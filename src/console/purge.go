@@ -0,0 +1,96 @@
+package console
+
+import (
+	"cake-store/src/config"
+	"cake-store/src/database"
+	"cake-store/src/model"
+	"cake-store/src/repository"
+	"cake-store/src/service"
+	"context"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+const purgeLockKey = "lock:purge-deleted-cakes"
+
+var purgeCmd = &cobra.Command{
+	Use:   "purge",
+	Short: "purge soft deleted cakes",
+	Long:  "Permanently delete the cakes soft deleted longer than the retention period",
+	Run:   purge,
+}
+
+func init() {
+	purgeCmd.PersistentFlags().Bool("dry-run", false, "only count the cakes to purge")
+	purgeCmd.PersistentFlags().Int("batch-size", 0, "max cakes deleted per batch, 0 to use retention.batchSize")
+	purgeCmd.PersistentFlags().Int("max-batches", 0, "max batches per run, 0 for unlimited")
+	RootCmd.AddCommand(purgeCmd)
+}
+
+func purge(cmd *cobra.Command, args []string) {
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	batchSize, _ := cmd.Flags().GetInt("batch-size")
+	maxBatches, _ := cmd.Flags().GetInt("max-batches")
+	if batchSize <= 0 {
+		batchSize = config.RetentionBatchSize()
+	}
+
+	db := database.NewDB()
+	defer db.Close()
+
+	redisConn := database.NewRedisConn(config.RedisHost())
+	defer redisConn.Close()
+
+	ctx := context.Background()
+
+	// Only one replica purge at a time
+	lock, err := database.ObtainLock(ctx, redisConn, purgeLockKey, config.RetentionLockTTL())
+	if err == database.ErrLockNotObtained {
+		log.Info("Purge is already running on another replica, skipped")
+		return
+	}
+	if err != nil {
+		log.Fatal("Failed to obtain purge lock: ", err)
+	}
+	defer func() {
+		if err := lock.Release(ctx); err != nil {
+			log.Error("Failed to release purge lock: ", err)
+		}
+	}()
+
+	cakeRepository := repository.NewCakeRepository(db, redisConn)
+	cakeService := service.NewCakeService(cakeRepository, nil, nil)
+
+	// the lock is held again after each batch, the run stop when it expired as another replica may purge now
+	summary, err := cakeService.PurgeExpired(ctx, model.PurgeOption{
+		Retention:  config.RetentionDeletedCakes(),
+		BatchSize:  batchSize,
+		MaxBatches: maxBatches,
+		DryRun:     dryRun,
+		AfterBatch: func(ctx context.Context) error {
+			return lock.Refresh(ctx, config.RetentionLockTTL())
+		},
+	})
+	fields := log.Fields{
+		"retention":  config.RetentionDeletedCakes().String(),
+		"batchSize":  batchSize,
+		"maxBatches": maxBatches,
+	}
+	if summary != nil {
+		fields["deletedBefore"] = summary.DeletedBefore
+		fields["matched"] = summary.Matched
+		fields["purged"] = summary.Purged
+		fields["batches"] = summary.Batches
+		fields["dryRun"] = summary.DryRun
+	}
+	if err != nil {
+		// Fatal exit without running the deferred release
+		if releaseErr := lock.Release(ctx); releaseErr != nil {
+			log.Error("Failed to release purge lock: ", releaseErr)
+		}
+		log.WithFields(fields).Fatal("Failed to purge deleted cakes: ", err)
+	}
+
+	log.WithFields(fields).Info("Success purged deleted cakes!")
+}
@@ -0,0 +1,160 @@
+package console
+
+import (
+	"cake-store/src/config"
+	"cake-store/src/database"
+	"cake-store/src/exchange"
+	"cake-store/src/model"
+	"cake-store/src/repository"
+	"cake-store/src/service"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var reorderReportCmd = &cobra.Command{
+	Use:   "reorder-report",
+	Short: "report the ingredients to reorder",
+	Long:  "Print or export the purchases suggested for the low ingredients grouped by supplier",
+	Run:   reorderReport,
+}
+
+func init() {
+	reorderReportCmd.PersistentFlags().String("format", "text", "output format, one of text, csv or json")
+	reorderReportCmd.PersistentFlags().String("output", "", "file to export the report to, the standard output when empty")
+	reorderReportCmd.PersistentFlags().String("currency", "", "currency of the costs, the base currency when empty")
+	RootCmd.AddCommand(reorderReportCmd)
+}
+
+func reorderReport(cmd *cobra.Command, args []string) {
+	format, _ := cmd.Flags().GetString("format")
+	output, _ := cmd.Flags().GetString("output")
+	currency, _ := cmd.Flags().GetString("currency")
+
+	write, ok := reorderReportWriters[format]
+	if !ok {
+		log.Fatalf("Unknown report format %q, expected text, csv or json", format)
+	}
+
+	db := database.NewDB()
+	defer db.Close()
+
+	exchangeRate, err := exchange.NewStaticProvider(config.ExchangeRatesFile())
+	if err != nil {
+		log.Fatalf("Error loading the exchange rates: %v", err)
+	}
+
+	ingredientRepository := repository.NewIngredientRepository(db)
+	inventoryService := service.NewInventoryService(repository.NewInventoryRepository(db), ingredientRepository,
+		repository.NewRecipeRepository(db), repository.NewVariantRepository(db), exchangeRate)
+
+	report, err := inventoryService.ReorderReport(context.Background(), model.ReorderQuery{Currency: currency})
+	if err != nil {
+		log.Fatal("Failed to build the reorder report: ", err)
+	}
+
+	var out io.Writer = os.Stdout
+	if output != "" {
+		file, err := os.Create(output)
+		if err != nil {
+			log.Fatal("Failed to create the report file: ", err)
+		}
+		defer file.Close()
+		out = file
+	}
+
+	if err = write(out, report); err != nil {
+		log.Error("Failed to write the reorder report: ", err)
+		return
+	}
+
+	if output != "" {
+		log.WithFields(log.Fields{
+			"suppliers": len(report.Suppliers),
+			"unsourced": len(report.Unsourced),
+			"total":     report.Total.String(),
+		}).Info("Success exported the reorder report to ", output)
+	}
+}
+
+var reorderReportWriters = map[string]func(io.Writer, *model.ReorderReport) error{
+	"text": writeReorderText,
+	"csv":  writeReorderCSV,
+	"json": writeReorderJSON,
+}
+
+func writeReorderText(out io.Writer, report *model.ReorderReport) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "Reorder report %s\n", report.GeneratedAt.Format("2006-01-02 15:04"))
+
+	for _, supplier := range report.Suppliers {
+		fmt.Fprintf(w, "\n%s\n", supplier.Supplier)
+		fmt.Fprintln(w, "INGREDIENT\tON HAND\tBUY\tPACKS\tCOST")
+		for _, line := range supplier.Lines {
+			fmt.Fprintf(w, "%s\t%s %s\t%s %s\t%d x %s %s\t%s\n", line.Name, formatQuantity(line.OnHand), line.Unit,
+				formatQuantity(line.Quantity), line.Unit, line.Packs, formatQuantity(line.PackQuantity), line.PackUnit, line.Cost)
+		}
+		fmt.Fprintf(w, "\t\t\tTotal\t%s\n", supplier.Total)
+	}
+
+	if len(report.Unsourced) > 0 {
+		fmt.Fprintln(w, "\nNo supplier")
+		fmt.Fprintln(w, "INGREDIENT\tON HAND\tBUY")
+		for _, line := range report.Unsourced {
+			fmt.Fprintf(w, "%s\t%s %s\t%s %s\n", line.Name, formatQuantity(line.OnHand), line.Unit, formatQuantity(line.Quantity), line.Unit)
+		}
+	}
+
+	fmt.Fprintf(w, "\nTotal\t%s\n", report.Total)
+	return w.Flush()
+}
+
+// writeReorderCSV write a row per ingredient, the unsourced ingredients have an empty supplier and cost
+func writeReorderCSV(out io.Writer, report *model.ReorderReport) error {
+	w := csv.NewWriter(out)
+	w.Write([]string{"supplier", "ingredient_id", "ingredient", "on_hand", "reorder_point", "quantity", "unit", "packs",
+		"pack_quantity", "pack_unit", "cost", "currency"})
+
+	row := func(line *model.ReorderLine) []string {
+		record := []string{line.Supplier, strconv.Itoa(line.IngredientId), line.Name, formatQuantity(line.OnHand),
+			formatQuantity(line.ReorderPoint), formatQuantity(line.Quantity), line.Unit, "", "", "", "", ""}
+		if line.Cost != nil {
+			record[7] = strconv.Itoa(line.Packs)
+			record[8] = formatQuantity(line.PackQuantity)
+			record[9] = line.PackUnit
+			record[10] = strconv.FormatInt(line.Cost.Amount, 10)
+			record[11] = line.Cost.Currency
+		}
+		return record
+	}
+
+	for _, supplier := range report.Suppliers {
+		for _, line := range supplier.Lines {
+			w.Write(row(line))
+		}
+	}
+	for _, line := range report.Unsourced {
+		w.Write(row(line))
+	}
+
+	w.Flush()
+	return w.Error()
+}
+
+func writeReorderJSON(out io.Writer, report *model.ReorderReport) error {
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(report)
+}
+
+func formatQuantity(quantity float64) string {
+	return strconv.FormatFloat(quantity, 'f', -1, 64)
+}
@@ -1,11 +1,17 @@
 package console
 
 import (
+	"cake-store/src/auth"
 	"cake-store/src/config"
 	"cake-store/src/controller"
 	"cake-store/src/database"
+	"cake-store/src/delivery"
+	"cake-store/src/exchange"
+	"cake-store/src/model"
+	"cake-store/src/payment"
 	"cake-store/src/repository"
 	"cake-store/src/router"
+	"cake-store/src/screening"
 	"cake-store/src/service"
 
 	"context"
@@ -33,6 +39,10 @@ func init() {
 }
 
 func server(cmd *cobra.Command, args []string) {
+	if config.CursorSecret() == "" {
+		log.Fatal("Error loading the cursor secret: cursor.secret is not set")
+	}
+
 	// Initiate DB
 	db := database.NewDB()
 	defer db.Close()
@@ -48,10 +58,99 @@ func server(cmd *cobra.Command, args []string) {
 
 	// Depedency Injection
 	cakeRepository := repository.NewCakeRepository(db, redisConn)
-	cakeService := service.NewCakeService(cakeRepository)
-	cakeController := controller.NewCakeController(cakeService)
+	categoryRepository := repository.NewTaxonomyRepository(db, model.Categories)
+	tagRepository := repository.NewTaxonomyRepository(db, model.Tags)
+	variantRepository := repository.NewVariantRepository(db)
+	stockRepository := repository.NewStockRepository(db, redisConn)
+	orderRepository := repository.NewOrderRepository(db)
+	cartRepository := repository.NewCartRepository(redisConn)
+	couponRepository := repository.NewCouponRepository(db, redisConn)
+	reviewRepository := repository.NewReviewRepository(db)
+	ingredientRepository := repository.NewIngredientRepository(db)
+	recipeRepository := repository.NewRecipeRepository(db)
+	inventoryRepository := repository.NewInventoryRepository(db)
+	productionRepository := repository.NewProductionRepository(db)
+	optionRepository := repository.NewOptionRepository(db)
+	slotRepository := repository.NewSlotRepository(db, redisConn)
+	storeRepository := repository.NewStoreRepository(db, redisConn)
+	paymentRepository := repository.NewPaymentRepository(db)
+
+	exchangeRate, err := exchange.NewStaticProvider(config.ExchangeRatesFile())
+	if err != nil {
+		log.Fatalf("Error loading the exchange rates: %v", err)
+	}
+
+	deliveryPolicy, err := delivery.NewPolicy(config.Delivery(), config.BaseCurrency())
+	if err != nil {
+		log.Fatalf("Error loading the delivery fee policy: %v", err)
+	}
+
+	paymentGateway, err := payment.NewGateway(config.PaymentProvider(), config.PaymentWebhookSecret())
+	if err != nil {
+		log.Fatalf("Error loading the payment gateway: %v", err)
+	}
 
-	router.RouteService(httpServer.Group("/api"), cakeController)
+	cakeService := service.NewCakeService(cakeRepository, storeRepository, exchangeRate, categoryRepository, tagRepository, variantRepository, ingredientRepository)
+	categoryService := service.NewTaxonomyService(model.Categories, categoryRepository, cakeRepository)
+	tagService := service.NewTaxonomyService(model.Tags, tagRepository, cakeRepository)
+	variantService := service.NewVariantService(variantRepository, cakeRepository)
+	stockService := service.NewStockService(stockRepository, cakeRepository, variantRepository)
+	couponService := service.NewCouponService(couponRepository, categoryRepository, cakeService, storeRepository, exchangeRate)
+	inventoryService := service.NewInventoryService(inventoryRepository, ingredientRepository, recipeRepository, variantRepository, exchangeRate)
+	optionService := service.NewOptionService(optionRepository, cakeRepository, variantRepository, storeRepository, exchangeRate)
+	slotService := service.NewSlotService(slotRepository, storeRepository)
+	orderService := service.NewOrderService(orderRepository, storeRepository, cakeRepository, variantRepository, couponService, inventoryService, optionService, slotService, exchangeRate)
+	cartService := service.NewCartService(cartRepository, cakeService, storeRepository, orderService, couponService, exchangeRate)
+	reviewService := service.NewReviewService(reviewRepository, cakeRepository,
+		screening.NewBannedWords(config.ReviewBannedWords()),
+		screening.NewLinks(),
+		screening.NewDuplicates(reviewRepository),
+		screening.NewAuthorRate(reviewRepository, config.ReviewAuthorLimit(), config.ReviewAuthorWindow()),
+	)
+
+	ingredientService := service.NewIngredientService(ingredientRepository, cakeRepository)
+	recipeService := service.NewRecipeService(recipeRepository, ingredientRepository, cakeRepository, exchangeRate)
+	productionService := service.NewProductionService(productionRepository, orderRepository, stockRepository, cakeRepository)
+	storeService := service.NewStoreService(storeRepository, cakeRepository, variantRepository, deliveryPolicy, exchangeRate)
+	paymentService := service.NewPaymentService(paymentRepository, orderRepository, paymentGateway)
+
+	cakeController := controller.NewCakeController(cakeService)
+	categoryController := controller.NewTaxonomyController(categoryService)
+	tagController := controller.NewTaxonomyController(tagService)
+	variantController := controller.NewVariantController(variantService)
+	stockController := controller.NewStockController(stockService)
+	orderController := controller.NewOrderController(orderService)
+	cartController := controller.NewCartController(cartService)
+	couponController := controller.NewCouponController(couponService)
+	reviewController := controller.NewReviewController(reviewService)
+	ingredientController := controller.NewIngredientController(ingredientService)
+	recipeController := controller.NewRecipeController(recipeService)
+	inventoryController := controller.NewInventoryController(inventoryService)
+	productionController := controller.NewProductionController(productionService)
+	optionController := controller.NewOptionController(optionService)
+	slotController := controller.NewSlotController(slotService)
+	storeController := controller.NewStoreController(storeService)
+	paymentController := controller.NewPaymentController(paymentService)
+
+	router.RouteService(httpServer.Group("/api", auth.Admin(config.AdminToken())), router.Controllers{
+		CakeController:       cakeController,
+		CategoryController:   categoryController,
+		TagController:        tagController,
+		VariantController:    variantController,
+		StockController:      stockController,
+		OrderController:      orderController,
+		CartController:       cartController,
+		CouponController:     couponController,
+		ReviewController:     reviewController,
+		IngredientController: ingredientController,
+		RecipeController:     recipeController,
+		InventoryController:  inventoryController,
+		ProductionController: productionController,
+		OptionController:     optionController,
+		SlotController:       slotController,
+		StoreController:      storeController,
+		PaymentController:    paymentController,
+	})
 
 	// Graceful Shutdown
 	// Catch Signal
@@ -0,0 +1,67 @@
+package console
+
+import (
+	"cake-store/src/config"
+	"cake-store/src/database"
+	"cake-store/src/repository"
+	"cake-store/src/service"
+	"context"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var slotsReconcileCmd = &cobra.Command{
+	Use:   "slots-reconcile",
+	Short: "reset the slot booking counters to the orders",
+	Long:  "Reset the booking counters of the coming pickup slots of the active stores to the cakes of the orders placed in them, a slot booked within the reconcile grace is only raised",
+	Run:   slotsReconcile,
+}
+
+func init() {
+	slotsReconcileCmd.PersistentFlags().Int("days", 7, "number of days to reconcile from today")
+	RootCmd.AddCommand(slotsReconcileCmd)
+}
+
+func slotsReconcile(cmd *cobra.Command, args []string) {
+	days, _ := cmd.Flags().GetInt("days")
+	if days <= 0 {
+		log.Fatal("The number of days must be positive")
+	}
+
+	db := database.NewDB()
+	defer db.Close()
+
+	redisConn := database.NewRedisConn(config.RedisHost())
+	defer redisConn.Close()
+
+	storeRepository := repository.NewStoreRepository(db, redisConn)
+	slotService := service.NewSlotService(repository.NewSlotRepository(db, redisConn), storeRepository)
+
+	stores, err := storeRepository.FindAll(context.Background())
+	if err != nil {
+		log.Fatal("Failed to find the stores: ", err)
+	}
+
+	total := 0
+	now := time.Now()
+	for _, store := range stores {
+		if !store.Active {
+			continue
+		}
+		for day := 0; day < days; day++ {
+			slots, err := slotService.Reconcile(context.Background(), store.Id, now.AddDate(0, 0, day))
+			if err != nil {
+				log.Fatal("Failed to reconcile the slots: ", err)
+			}
+			total += slots
+		}
+	}
+
+	log.WithFields(log.Fields{
+		"days":   days,
+		"stores": len(stores),
+		"slots":  total,
+	}).Info("Success reconciled the slot booking counters")
+}
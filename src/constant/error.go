@@ -11,13 +11,48 @@ import (
 
 // http errors
 var (
-	ErrInvalidArgument = echo.NewHTTPError(http.StatusBadRequest, "invalid argument")
-	ErrAlreadyDeleted  = echo.NewHTTPError(http.StatusBadRequest, "record already deleted")
-	ErrNotFound        = echo.NewHTTPError(http.StatusNotFound, "record not found")
-	ErrInternal        = echo.NewHTTPError(http.StatusInternalServerError, "internal system error")
-	ErrFieldEmpty      = echo.NewHTTPError(http.StatusBadRequest, "requirement field empty")
+	ErrInvalidArgument     = echo.NewHTTPError(http.StatusBadRequest, "invalid argument")
+	ErrAlreadyDeleted      = echo.NewHTTPError(http.StatusBadRequest, "record already deleted")
+	ErrNotFound            = echo.NewHTTPError(http.StatusNotFound, "record not found")
+	ErrInternal            = echo.NewHTTPError(http.StatusInternalServerError, "internal system error")
+	ErrFieldEmpty          = echo.NewHTTPError(http.StatusBadRequest, "requirement field empty")
+	ErrInvalidCursor       = echo.NewHTTPError(http.StatusBadRequest, "invalid cursor")
+	ErrInvalidPatch        = echo.NewHTTPError(http.StatusBadRequest, "invalid patch document")
+	ErrPatchConflict       = echo.NewHTTPError(http.StatusConflict, "patch test operation failed")
+	ErrUnsupportedType     = echo.NewHTTPError(http.StatusUnsupportedMediaType, "unsupported media type")
+	ErrVersionConflict     = echo.NewHTTPError(http.StatusConflict, "record modified concurrently")
+	ErrPrecondition        = echo.NewHTTPError(http.StatusPreconditionFailed, "precondition failed")
+	ErrForbidden           = echo.NewHTTPError(http.StatusForbidden, "forbidden")
+	ErrNotDeleted          = echo.NewHTTPError(http.StatusBadRequest, "record is not deleted")
+	ErrAlreadyExists       = echo.NewHTTPError(http.StatusConflict, "record already exists")
+	ErrUnsupportedCurrency = echo.NewHTTPError(http.StatusBadRequest, "unsupported currency")
+	ErrInsufficientStock   = echo.NewHTTPError(http.StatusConflict, "insufficient stock")
+	ErrInvalidTransition   = echo.NewHTTPError(http.StatusConflict, "invalid status transition")
+	ErrInUse               = echo.NewHTTPError(http.StatusConflict, "record is still in use")
+	ErrSlotUnavailable     = echo.NewHTTPError(http.StatusBadRequest, "time slot is not available")
+	ErrSlotFull            = echo.NewHTTPError(http.StatusConflict, "time slot is fully booked")
+	ErrCakeUnavailable     = echo.NewHTTPError(http.StatusBadRequest, "cake is not available in the store")
+	ErrOutOfDeliveryRange  = echo.NewHTTPError(http.StatusBadRequest, "address is out of the delivery range")
+	ErrInvalidSignature    = echo.NewHTTPError(http.StatusUnauthorized, "invalid signature")
+	ErrAlreadyPaid         = echo.NewHTTPError(http.StatusConflict, "order already has a payment")
+	ErrPaymentGateway      = echo.NewHTTPError(http.StatusBadGateway, "payment gateway error")
 )
 
+// CouponRejectedErr return the bad request error explaining why the coupon code is rejected
+func CouponRejectedErr(code string, reason string) error {
+	return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("coupon %s rejected: %s", code, reason))
+}
+
+// OptionsRejectedErr return the bad request error explaining why the cake options are rejected
+func OptionsRejectedErr(reason string) error {
+	return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("options rejected: %s", reason))
+}
+
+// OpeningHoursRejectedErr return the bad request error explaining why the opening hours are rejected
+func OpeningHoursRejectedErr(reason string) error {
+	return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("opening hours rejected: %s", reason))
+}
+
 // httpValidationOrInternalErr return valdiation or internal error
 func HttpValidationOrInternalErr(err error) error {
 	switch t := err.(type) {
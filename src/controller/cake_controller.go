@@ -1,11 +1,15 @@
 package controller
 
 import (
+	"cake-store/src/auth"
 	"cake-store/src/constant"
 	"cake-store/src/model"
+	"io"
+	"mime"
 	"net/http"
 
 	"strconv"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	log "github.com/sirupsen/logrus"
@@ -35,6 +39,7 @@ func (cC *cakeController) HandleCreate() echo.HandlerFunc {
 			return err
 		}
 
+		c.Response().Header().Set(headerETag, create.ETag())
 		return c.JSON(http.StatusOK, model.ResponseSuccess{
 			Success: true,
 			Data:    create,
@@ -44,19 +49,54 @@ func (cC *cakeController) HandleCreate() echo.HandlerFunc {
 
 func (cC *cakeController) HandleFindAll() echo.HandlerFunc {
 	return func(c echo.Context) error {
-		cakes, err := cC.cakeService.FindAll(c.Request().Context())
-		if err != nil {
+		query := model.CakeQuery{}
+		if err := c.Bind(&query); err != nil {
 			log.Error(err)
-			return err
+			return constant.ErrInvalidArgument
 		}
 
-		return c.JSON(http.StatusOK, model.ResponseSuccess{
-			Success: true,
-			Data:    cakes,
-		})
+		if query.IncludeDeleted && !auth.IsAdmin(c) {
+			log.Error(constant.ErrForbidden)
+			return constant.ErrForbidden
+		}
+
+		return cC.findAll(c, query)
 	}
 }
 
+func (cC *cakeController) HandleFindTrash() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		query := model.CakeQuery{}
+		if err := c.Bind(&query); err != nil {
+			log.Error(err)
+			return constant.ErrInvalidArgument
+		}
+
+		query.Trashed = true
+		return cC.findAll(c, query)
+	}
+}
+
+func (cC *cakeController) findAll(c echo.Context, query model.CakeQuery) error {
+	cakes, pagination, err := cC.cakeService.FindAll(c.Request().Context(), query)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	// a list has no Last-Modified, a cake deleted or dropped off the page would not move it, only the ETag validate it
+	if notModified(c, model.CakeListETag(cakes, pagination), time.Time{}) {
+		return c.NoContent(http.StatusNotModified)
+	}
+
+	setPaginationLinks(c, pagination)
+	return c.JSON(http.StatusOK, model.ResponseSuccess{
+		Success: true,
+		Data:    cakes,
+		Meta:    pagination,
+	})
+}
+
 func (cC *cakeController) HandleFindById() echo.HandlerFunc {
 	return func(c echo.Context) error {
 		idStr := c.Param("id")
@@ -66,12 +106,42 @@ func (cC *cakeController) HandleFindById() echo.HandlerFunc {
 			return constant.ErrInternal
 		}
 
-		cake, err := cC.cakeService.FindById(c.Request().Context(), id)
+		// The ?store_id= view carry the availability and the prices of the store
+		storeId := 0
+		if storeIdStr := c.QueryParam("store_id"); storeIdStr != "" {
+			if storeId, err = strconv.Atoi(storeIdStr); err != nil {
+				log.Error(err)
+				return constant.ErrInvalidArgument
+			}
+		}
+
+		// a revalidation is answered from the cached cake, before its relations are loaded from MySQL
+		if conditional(c) {
+			current, err := cC.cakeService.FindVersion(c.Request().Context(), id)
+			if err != nil {
+				log.Error(err)
+				return err
+			}
+
+			if notModified(c, current.ETag(), current.UpdatedAt) {
+				return c.NoContent(http.StatusNotModified)
+			}
+		}
+
+		var cake *model.Cake
+		if storeId != 0 {
+			cake, err = cC.cakeService.FindByIdInStore(c.Request().Context(), id, storeId)
+		} else {
+			cake, err = cC.cakeService.FindById(c.Request().Context(), id)
+		}
 		if err != nil {
 			log.Error(err)
 			return err
 		}
 
+		if notModified(c, cake.ETag(), cake.UpdatedAt) {
+			return c.NoContent(http.StatusNotModified)
+		}
 		return c.JSON(http.StatusOK, model.ResponseSuccess{
 			Success: true,
 			Data:    cake,
@@ -95,12 +165,67 @@ func (cC *cakeController) HandleUpdate() echo.HandlerFunc {
 			return constant.ErrInternal
 		}
 
-		update, err := cC.cakeService.Update(c.Request().Context(), req, id)
+		ifMatch, err := ifMatchVersion(c)
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+
+		update, err := cC.cakeService.Update(c.Request().Context(), req, id, ifMatch)
 		if err != nil {
 			log.Error(err)
 			return err
 		}
 
+		c.Response().Header().Set(headerETag, update.ETag())
+		return c.JSON(http.StatusOK, model.ResponseSuccess{
+			Success: true,
+			Data:    update,
+		})
+	}
+}
+
+func (cC *cakeController) HandlePatch() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		idStr := c.Param("id")
+		id, err := strconv.Atoi(idStr)
+		if err != nil {
+			log.Error(err)
+			return constant.ErrInternal
+		}
+
+		patchType, _, err := mime.ParseMediaType(c.Request().Header.Get(echo.HeaderContentType))
+		if err != nil {
+			log.Error(err)
+			return constant.ErrUnsupportedType
+		}
+		// plain json body is treated as merge patch
+		if patchType == echo.MIMEApplicationJSON {
+			patchType = model.MergePatchType
+		}
+
+		patch, err := io.ReadAll(c.Request().Body)
+		if err != nil {
+			log.Error(err)
+			return constant.ErrInternal
+		}
+
+		ifMatch, err := ifMatchVersion(c)
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+
+		update, err := cC.cakeService.Patch(c.Request().Context(), model.PatchRequest{
+			Type:  patchType,
+			Patch: patch,
+		}, id, ifMatch)
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+
+		c.Response().Header().Set(headerETag, update.ETag())
 		return c.JSON(http.StatusOK, model.ResponseSuccess{
 			Success: true,
 			Data:    update,
@@ -117,7 +242,30 @@ func (cC *cakeController) HandleDelete() echo.HandlerFunc {
 			return constant.ErrInternal
 		}
 
-		delete, err := cC.cakeService.Delete(c.Request().Context(), id)
+		ifMatch, err := ifMatchVersion(c)
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+
+		hard := false
+		if hardStr := c.QueryParam("hard"); hardStr != "" {
+			if hard, err = strconv.ParseBool(hardStr); err != nil {
+				log.Error(err)
+				return constant.ErrInvalidArgument
+			}
+		}
+
+		var delete *model.Cake
+		if hard {
+			if !auth.IsAdmin(c) {
+				log.Error(constant.ErrForbidden)
+				return constant.ErrForbidden
+			}
+			delete, err = cC.cakeService.Purge(c.Request().Context(), id, ifMatch)
+		} else {
+			delete, err = cC.cakeService.Delete(c.Request().Context(), id, ifMatch)
+		}
 		if err != nil {
 			log.Error(err)
 			return err
@@ -129,3 +277,32 @@ func (cC *cakeController) HandleDelete() echo.HandlerFunc {
 		})
 	}
 }
+
+func (cC *cakeController) HandleRestore() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		idStr := c.Param("id")
+		id, err := strconv.Atoi(idStr)
+		if err != nil {
+			log.Error(err)
+			return constant.ErrInternal
+		}
+
+		ifMatch, err := ifMatchVersion(c)
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+
+		restore, err := cC.cakeService.Restore(c.Request().Context(), id, ifMatch)
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+
+		c.Response().Header().Set(headerETag, restore.ETag())
+		return c.JSON(http.StatusOK, model.ResponseSuccess{
+			Success: true,
+			Data:    restore,
+		})
+	}
+}
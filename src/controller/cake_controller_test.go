@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"cake-store/src/auth"
 	"cake-store/src/constant"
 	"cake-store/src/model"
 	"cake-store/src/model/mock"
@@ -43,19 +44,17 @@ func TestHTTP_handleCreate(t *testing.T) {
 		{
             "title":"Kue Test",
             "description" :"Desc test",
-            "rating": 5.5,
             "image":"test image"
 		}`,
 		))
 		req.Header.Set("Content-Type", "application/json")
 		rec := httptest.NewRecorder()
 		ectx := ec.NewContext(req, rec)
-		ctx := context.TODO()
+		ctx := context.Background()
 
 		mockCakeService.EXPECT().Create(ctx, model.CreateUpdateRequest{
 			Title:       cake.Title,
 			Description: cake.Description,
-			Rating:      cake.Rating,
 			Image:       cake.Image,
 		}).Times(1).Return(cake, nil)
 
@@ -74,14 +73,13 @@ func TestHTTP_handleCreate(t *testing.T) {
 		{
             "title":"K",
             "description" :"Desc test",
-            "rating": 5.5,
             "image":"test image"
 		}`,
 		))
 		req.Header.Set("Content-Type", "application/json")
 		rec := httptest.NewRecorder()
 		ectx := ec.NewContext(req, rec)
-		ctx := context.TODO()
+		ctx := context.Background()
 		cake := &model.Cake{
 			Title:       "K",
 			Description: "Desc test",
@@ -95,7 +93,6 @@ func TestHTTP_handleCreate(t *testing.T) {
 		mockCakeService.EXPECT().Create(ctx, model.CreateUpdateRequest{
 			Title:       cake.Title,
 			Description: cake.Description,
-			Rating:      cake.Rating,
 			Image:       cake.Image,
 		}).Times(1).Return(nil, constant.ErrInvalidArgument)
 
@@ -110,14 +107,13 @@ func TestHTTP_handleCreate(t *testing.T) {
 		{
             "title":"Kaaaa",
             "description" :"Desc test",
-            "rating": 5.5,
             "image":"test image"
 		}`,
 		))
 		req.Header.Set("Content-Type", "application/json")
 		rec := httptest.NewRecorder()
 		ectx := ec.NewContext(req, rec)
-		ctx := context.TODO()
+		ctx := context.Background()
 		cake := &model.Cake{
 			Title:       "Kaaaa",
 			Description: "Desc test",
@@ -131,7 +127,6 @@ func TestHTTP_handleCreate(t *testing.T) {
 		mockCakeService.EXPECT().Create(ctx, model.CreateUpdateRequest{
 			Title:       cake.Title,
 			Description: cake.Description,
-			Rating:      cake.Rating,
 			Image:       cake.Image,
 		}).Times(1).Return(nil, constant.ErrInternal)
 
@@ -164,12 +159,11 @@ func TestHTTP_handleUpdate(t *testing.T) {
 	t.Run("ok", func(t *testing.T) {
 		ec := echo.New()
 		rec := httptest.NewRecorder()
-		ctx := context.TODO()
+		ctx := context.Background()
 		req := httptest.NewRequest(http.MethodPut, "/cakes", strings.NewReader(`
 		{
             "title":"Kue Test",
             "description" :"Desc test",
-            "rating": 5.5,
             "image":"test image"
 		}`,
 		))
@@ -180,9 +174,8 @@ func TestHTTP_handleUpdate(t *testing.T) {
 		mockCakeService.EXPECT().Update(ctx, model.CreateUpdateRequest{
 			Title:       cake.Title,
 			Description: cake.Description,
-			Rating:      cake.Rating,
 			Image:       cake.Image,
-		}, cake.Id).Times(1).Return(cake, nil)
+		}, cake.Id, nil).Times(1).Return(cake, nil)
 
 		err := cakeController.HandleUpdate()(ectx)
 		require.NoError(t, err)
@@ -193,13 +186,89 @@ func TestHTTP_handleUpdate(t *testing.T) {
 		require.EqualValues(t, http.StatusOK, rec.Result().StatusCode)
 	})
 
+	t.Run("ok - if match", func(t *testing.T) {
+		ec := echo.New()
+		rec := httptest.NewRecorder()
+		ctx := context.Background()
+		req := httptest.NewRequest(http.MethodPut, "/cakes", strings.NewReader(`
+		{
+            "title":"Kue Test",
+            "description" :"Desc test",
+            "image":"test image"
+		}`,
+		))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("If-Match", `"3"`)
+		ectx := ec.NewContext(req, rec)
+		ectx.SetParamNames("id")
+		ectx.SetParamValues(strconv.Itoa(cake.Id))
+		updated := *cake
+		updated.Version = 4
+		mockCakeService.EXPECT().Update(ctx, model.CreateUpdateRequest{
+			Title:       cake.Title,
+			Description: cake.Description,
+			Image:       cake.Image,
+		}, cake.Id, model.IfMatch{3}).Times(1).Return(&updated, nil)
+
+		err := cakeController.HandleUpdate()(ectx)
+		require.NoError(t, err)
+		require.EqualValues(t, http.StatusOK, rec.Result().StatusCode)
+		require.Equal(t, `"4"`, rec.Header().Get("ETag"))
+	})
+
+	t.Run("handle error - precondition failed", func(t *testing.T) {
+		ec := echo.New()
+		rec := httptest.NewRecorder()
+		ctx := context.Background()
+		req := httptest.NewRequest(http.MethodPut, "/cakes", strings.NewReader(`{"title":"Kue Test"}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("If-Match", `"2"`)
+		ectx := ec.NewContext(req, rec)
+		ectx.SetParamNames("id")
+		ectx.SetParamValues(strconv.Itoa(cake.Id))
+		mockCakeService.EXPECT().Update(ctx, gomock.Any(), cake.Id, model.IfMatch{2}).Times(1).Return(nil, constant.ErrPrecondition)
+
+		err := cakeController.HandleUpdate()(ectx)
+		ec.DefaultHTTPErrorHandler(err, ectx)
+		require.EqualValues(t, http.StatusPreconditionFailed, rec.Result().StatusCode)
+	})
+
+	t.Run("handle error - weak if match", func(t *testing.T) {
+		ec := echo.New()
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPut, "/cakes", strings.NewReader(`{"title":"Kue Test"}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("If-Match", `W/"2"`)
+		ectx := ec.NewContext(req, rec)
+		ectx.SetParamNames("id")
+		ectx.SetParamValues(strconv.Itoa(cake.Id))
+
+		err := cakeController.HandleUpdate()(ectx)
+		ec.DefaultHTTPErrorHandler(err, ectx)
+		require.EqualValues(t, http.StatusPreconditionFailed, rec.Result().StatusCode)
+	})
+
+	t.Run("handle error - malformed if match", func(t *testing.T) {
+		ec := echo.New()
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPut, "/cakes", strings.NewReader(`{"title":"Kue Test"}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("If-Match", `2`)
+		ectx := ec.NewContext(req, rec)
+		ectx.SetParamNames("id")
+		ectx.SetParamValues(strconv.Itoa(cake.Id))
+
+		err := cakeController.HandleUpdate()(ectx)
+		ec.DefaultHTTPErrorHandler(err, ectx)
+		require.EqualValues(t, http.StatusBadRequest, rec.Result().StatusCode)
+	})
+
 	t.Run("handle error - validate", func(t *testing.T) {
 		ec := echo.New()
 		req := httptest.NewRequest(http.MethodPut, "/cakes", strings.NewReader(`
 		{
             "title":"K",
             "description" :"Desc test",
-            "rating": 5.5,
             "image":"test image"
 		}`,
 		))
@@ -208,7 +277,7 @@ func TestHTTP_handleUpdate(t *testing.T) {
 		ectx := ec.NewContext(req, rec)
 		ectx.SetParamNames("id")
 		ectx.SetParamValues(strconv.Itoa(cake.Id))
-		ctx := context.TODO()
+		ctx := context.Background()
 		cake := &model.Cake{
 			Id:          1,
 			Title:       "K",
@@ -223,9 +292,8 @@ func TestHTTP_handleUpdate(t *testing.T) {
 		mockCakeService.EXPECT().Update(ctx, model.CreateUpdateRequest{
 			Title:       cake.Title,
 			Description: cake.Description,
-			Rating:      cake.Rating,
 			Image:       cake.Image,
-		}, cake.Id).Times(1).Return(nil, constant.ErrInvalidArgument)
+		}, cake.Id, nil).Times(1).Return(nil, constant.ErrInvalidArgument)
 
 		err := cakeController.HandleUpdate()(ectx)
 		ec.DefaultHTTPErrorHandler(err, ectx)
@@ -238,7 +306,6 @@ func TestHTTP_handleUpdate(t *testing.T) {
 		{
             "title":"Kaaaa",
             "description" :"Desc test",
-            "rating": 5.5,
             "image":"test image"
 		}`,
 		))
@@ -247,7 +314,7 @@ func TestHTTP_handleUpdate(t *testing.T) {
 		ectx := ec.NewContext(req, rec)
 		ectx.SetParamNames("id")
 		ectx.SetParamValues(strconv.Itoa(cake.Id))
-		ctx := context.TODO()
+		ctx := context.Background()
 		cake := &model.Cake{
 			Id:          1,
 			Title:       "Kaaaa",
@@ -262,9 +329,8 @@ func TestHTTP_handleUpdate(t *testing.T) {
 		mockCakeService.EXPECT().Update(ctx, model.CreateUpdateRequest{
 			Title:       cake.Title,
 			Description: cake.Description,
-			Rating:      cake.Rating,
 			Image:       cake.Image,
-		}, cake.Id).Times(1).Return(nil, constant.ErrInternal)
+		}, cake.Id, nil).Times(1).Return(nil, constant.ErrInternal)
 
 		err := cakeController.HandleUpdate()(ectx)
 		ec.DefaultHTTPErrorHandler(err, ectx)
@@ -272,6 +338,115 @@ func TestHTTP_handleUpdate(t *testing.T) {
 	})
 }
 
+func TestHTTP_handlePatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCakeService := mock.NewMockCakeService(ctrl)
+	cakeController := &cakeController{
+		cakeService: mockCakeService,
+	}
+
+	cake := &model.Cake{
+		Id:          1,
+		Title:       "Kue Test",
+		Description: "Desc test",
+		Rating:      8,
+		Image:       "test image",
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+		DeletedAt:   nil,
+	}
+
+	t.Run("ok - merge patch", func(t *testing.T) {
+		ec := echo.New()
+		rec := httptest.NewRecorder()
+		ctx := context.Background()
+		req := httptest.NewRequest(http.MethodPatch, "/cakes", strings.NewReader(`{"image": "new image"}`))
+		req.Header.Set("Content-Type", "application/merge-patch+json; charset=utf-8")
+		ectx := ec.NewContext(req, rec)
+		ectx.SetParamNames("id")
+		ectx.SetParamValues(strconv.Itoa(cake.Id))
+		mockCakeService.EXPECT().Patch(ctx, model.PatchRequest{
+			Type:  model.MergePatchType,
+			Patch: []byte(`{"image": "new image"}`),
+		}, cake.Id, nil).Times(1).Return(cake, nil)
+
+		err := cakeController.HandlePatch()(ectx)
+		require.NoError(t, err)
+		require.EqualValues(t, http.StatusOK, rec.Result().StatusCode)
+	})
+
+	t.Run("ok - plain json as merge patch", func(t *testing.T) {
+		ec := echo.New()
+		rec := httptest.NewRecorder()
+		ctx := context.Background()
+		req := httptest.NewRequest(http.MethodPatch, "/cakes", strings.NewReader(`{"image": "new image"}`))
+		req.Header.Set("Content-Type", "application/json")
+		ectx := ec.NewContext(req, rec)
+		ectx.SetParamNames("id")
+		ectx.SetParamValues(strconv.Itoa(cake.Id))
+		mockCakeService.EXPECT().Patch(ctx, model.PatchRequest{
+			Type:  model.MergePatchType,
+			Patch: []byte(`{"image": "new image"}`),
+		}, cake.Id, nil).Times(1).Return(cake, nil)
+
+		err := cakeController.HandlePatch()(ectx)
+		require.NoError(t, err)
+		require.EqualValues(t, http.StatusOK, rec.Result().StatusCode)
+	})
+
+	t.Run("ok - json patch", func(t *testing.T) {
+		ec := echo.New()
+		rec := httptest.NewRecorder()
+		ctx := context.Background()
+		body := `[{"op":"replace","path":"/image","value":"new image"}]`
+		req := httptest.NewRequest(http.MethodPatch, "/cakes", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json-patch+json")
+		ectx := ec.NewContext(req, rec)
+		ectx.SetParamNames("id")
+		ectx.SetParamValues(strconv.Itoa(cake.Id))
+		mockCakeService.EXPECT().Patch(ctx, model.PatchRequest{
+			Type:  model.JSONPatchType,
+			Patch: []byte(body),
+		}, cake.Id, nil).Times(1).Return(cake, nil)
+
+		err := cakeController.HandlePatch()(ectx)
+		require.NoError(t, err)
+		require.EqualValues(t, http.StatusOK, rec.Result().StatusCode)
+	})
+
+	t.Run("handle error - missing content type", func(t *testing.T) {
+		ec := echo.New()
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPatch, "/cakes", strings.NewReader(`{"image": "new image"}`))
+		ectx := ec.NewContext(req, rec)
+		ectx.SetParamNames("id")
+		ectx.SetParamValues(strconv.Itoa(cake.Id))
+
+		err := cakeController.HandlePatch()(ectx)
+		ec.DefaultHTTPErrorHandler(err, ectx)
+		require.EqualValues(t, http.StatusUnsupportedMediaType, rec.Result().StatusCode)
+	})
+
+	t.Run("handle error - conflict", func(t *testing.T) {
+		ec := echo.New()
+		rec := httptest.NewRecorder()
+		ctx := context.Background()
+		body := `[{"op":"test","path":"/title","value":"Kue Lain"}]`
+		req := httptest.NewRequest(http.MethodPatch, "/cakes", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json-patch+json")
+		ectx := ec.NewContext(req, rec)
+		ectx.SetParamNames("id")
+		ectx.SetParamValues(strconv.Itoa(cake.Id))
+		mockCakeService.EXPECT().Patch(ctx, gomock.Any(), cake.Id, nil).Times(1).Return(nil, constant.ErrPatchConflict)
+
+		err := cakeController.HandlePatch()(ectx)
+		ec.DefaultHTTPErrorHandler(err, ectx)
+		require.EqualValues(t, http.StatusConflict, rec.Result().StatusCode)
+	})
+}
+
 func TestHTTP_handleDelete(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -295,13 +470,13 @@ func TestHTTP_handleDelete(t *testing.T) {
 	t.Run("ok", func(t *testing.T) {
 		ec := echo.New()
 		rec := httptest.NewRecorder()
-		ctx := context.TODO()
+		ctx := context.Background()
 		req := httptest.NewRequest(http.MethodDelete, "/cakes", nil)
 		req.Header.Set("Content-Type", "application/json")
 		ectx := ec.NewContext(req, rec)
 		ectx.SetParamNames("id")
 		ectx.SetParamValues(strconv.Itoa(cake.Id))
-		mockCakeService.EXPECT().Delete(ctx, cake.Id).Times(1).Return(cake, nil)
+		mockCakeService.EXPECT().Delete(ctx, cake.Id, nil).Times(1).Return(cake, nil)
 
 		err := cakeController.HandleDelete()(ectx)
 		require.NoError(t, err)
@@ -312,17 +487,75 @@ func TestHTTP_handleDelete(t *testing.T) {
 		require.EqualValues(t, http.StatusOK, rec.Result().StatusCode)
 	})
 
+	t.Run("ok - if match", func(t *testing.T) {
+		ec := echo.New()
+		rec := httptest.NewRecorder()
+		ctx := context.Background()
+		req := httptest.NewRequest(http.MethodDelete, "/cakes", nil)
+		req.Header.Set("If-Match", `"3", W/"2", "1"`)
+		ectx := ec.NewContext(req, rec)
+		ectx.SetParamNames("id")
+		ectx.SetParamValues(strconv.Itoa(cake.Id))
+		mockCakeService.EXPECT().Delete(ctx, cake.Id, model.IfMatch{3, 1}).Times(1).Return(cake, nil)
+
+		err := cakeController.HandleDelete()(ectx)
+		require.NoError(t, err)
+		require.EqualValues(t, http.StatusOK, rec.Result().StatusCode)
+	})
+
+	t.Run("ok - hard delete", func(t *testing.T) {
+		ec := echo.New()
+		rec := httptest.NewRecorder()
+		ctx := context.Background()
+		req := httptest.NewRequest(http.MethodDelete, "/cakes?hard=true", nil)
+		ectx := ec.NewContext(req, rec)
+		ectx.SetParamNames("id")
+		ectx.SetParamValues(strconv.Itoa(cake.Id))
+		ectx.Set(auth.ContextKeyAdmin, true)
+		mockCakeService.EXPECT().Purge(ctx, cake.Id, nil).Times(1).Return(cake, nil)
+
+		err := cakeController.HandleDelete()(ectx)
+		require.NoError(t, err)
+		require.EqualValues(t, http.StatusOK, rec.Result().StatusCode)
+	})
+
+	t.Run("handle error - hard delete by non admin", func(t *testing.T) {
+		ec := echo.New()
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodDelete, "/cakes?hard=true", nil)
+		ectx := ec.NewContext(req, rec)
+		ectx.SetParamNames("id")
+		ectx.SetParamValues(strconv.Itoa(cake.Id))
+
+		err := cakeController.HandleDelete()(ectx)
+		ec.DefaultHTTPErrorHandler(err, ectx)
+		require.EqualValues(t, http.StatusForbidden, rec.Result().StatusCode)
+	})
+
+	t.Run("handle error - invalid hard", func(t *testing.T) {
+		ec := echo.New()
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodDelete, "/cakes?hard=maybe", nil)
+		ectx := ec.NewContext(req, rec)
+		ectx.SetParamNames("id")
+		ectx.SetParamValues(strconv.Itoa(cake.Id))
+
+		err := cakeController.HandleDelete()(ectx)
+		ec.DefaultHTTPErrorHandler(err, ectx)
+		require.EqualValues(t, http.StatusBadRequest, rec.Result().StatusCode)
+	})
+
 	t.Run("handle error - not found", func(t *testing.T) {
 		ec := echo.New()
 		rec := httptest.NewRecorder()
-		ctx := context.TODO()
+		ctx := context.Background()
 		req := httptest.NewRequest(http.MethodDelete, "/cakes", nil)
 		req.Header.Set("Content-Type", "application/json")
 		ectx := ec.NewContext(req, rec)
 		ectx.SetParamNames("id")
 		ectx.SetParamValues(strconv.Itoa(cake.Id))
 
-		mockCakeService.EXPECT().Delete(ctx, cake.Id).Times(1).Return(nil, constant.ErrNotFound)
+		mockCakeService.EXPECT().Delete(ctx, cake.Id, nil).Times(1).Return(nil, constant.ErrNotFound)
 
 		err := cakeController.HandleDelete()(ectx)
 		ec.DefaultHTTPErrorHandler(err, ectx)
@@ -332,14 +565,14 @@ func TestHTTP_handleDelete(t *testing.T) {
 	t.Run("handle error - internal", func(t *testing.T) {
 		ec := echo.New()
 		rec := httptest.NewRecorder()
-		ctx := context.TODO()
+		ctx := context.Background()
 		req := httptest.NewRequest(http.MethodDelete, "/cakes", nil)
 		req.Header.Set("Content-Type", "application/json")
 		ectx := ec.NewContext(req, rec)
 		ectx.SetParamNames("id")
 		ectx.SetParamValues(strconv.Itoa(cake.Id))
 
-		mockCakeService.EXPECT().Delete(ctx, cake.Id).Times(1).Return(nil, constant.ErrInternal)
+		mockCakeService.EXPECT().Delete(ctx, cake.Id, nil).Times(1).Return(nil, constant.ErrInternal)
 
 		err := cakeController.HandleDelete()(ectx)
 		ec.DefaultHTTPErrorHandler(err, ectx)
@@ -380,12 +613,12 @@ func TestHTTP_handleFindAll(t *testing.T) {
 	t.Run("ok", func(t *testing.T) {
 		ec := echo.New()
 		rec := httptest.NewRecorder()
-		ctx := context.TODO()
+		ctx := context.Background()
 		req := httptest.NewRequest(http.MethodGet, "/cakes", nil)
 		req.Header.Set("Content-Type", "application/json")
 		ectx := ec.NewContext(req, rec)
 
-		mockCakeService.EXPECT().FindAll(ctx).Times(1).Return(cakes, nil)
+		mockCakeService.EXPECT().FindAll(ctx, model.CakeQuery{}).Times(1).Return(cakes, &model.Pagination{Total: 2, Page: 1, Limit: 10}, nil)
 
 		err := cakeController.HandleFindAll()(ectx)
 		require.NoError(t, err)
@@ -396,16 +629,167 @@ func TestHTTP_handleFindAll(t *testing.T) {
 		require.EqualValues(t, http.StatusOK, rec.Result().StatusCode)
 	})
 
+	t.Run("ok - paginate", func(t *testing.T) {
+		ec := echo.New()
+		rec := httptest.NewRecorder()
+		ctx := context.Background()
+		req := httptest.NewRequest(http.MethodGet, "/cakes?page=2&limit=1&sort_by=title", nil)
+		ectx := ec.NewContext(req, rec)
+
+		mockCakeService.EXPECT().FindAll(ctx, model.CakeQuery{
+			Page:   2,
+			Limit:  1,
+			SortBy: "title",
+		}).Times(1).Return(cakes[1:], &model.Pagination{Total: 3, Page: 2, Limit: 1}, nil)
+
+		err := cakeController.HandleFindAll()(ectx)
+		require.NoError(t, err)
+
+		resBody := model.ResponseSuccess{}
+		err = json.NewDecoder(rec.Result().Body).Decode(&resBody)
+		require.NoError(t, err)
+		require.EqualValues(t, http.StatusOK, rec.Result().StatusCode)
+		require.NotNil(t, resBody.Meta)
+		require.EqualValues(t, 3, resBody.Meta.Total)
+		require.Equal(t, "/cakes?limit=1&page=3&sort_by=title", resBody.Meta.Next)
+		require.Equal(t, "/cakes?limit=1&page=1&sort_by=title", resBody.Meta.Prev)
+	})
+
+	t.Run("ok - filter by category and tag", func(t *testing.T) {
+		ec := echo.New()
+		rec := httptest.NewRecorder()
+		ctx := context.Background()
+		req := httptest.NewRequest(http.MethodGet, "/cakes?category=birthday&tag=vegan", nil)
+		ectx := ec.NewContext(req, rec)
+
+		mockCakeService.EXPECT().FindAll(ctx, model.CakeQuery{
+			Category: "birthday",
+			Tag:      "vegan",
+		}).Times(1).Return(cakes, &model.Pagination{Total: 2, Page: 1, Limit: 10}, nil)
+
+		err := cakeController.HandleFindAll()(ectx)
+		require.NoError(t, err)
+		require.EqualValues(t, http.StatusOK, rec.Result().StatusCode)
+	})
+
+	t.Run("ok - cursor", func(t *testing.T) {
+		ec := echo.New()
+		rec := httptest.NewRecorder()
+		ctx := context.Background()
+		req := httptest.NewRequest(http.MethodGet, "/cakes?paginate=cursor&limit=1", nil)
+		ectx := ec.NewContext(req, rec)
+
+		mockCakeService.EXPECT().FindAll(ctx, model.CakeQuery{
+			Limit:    1,
+			Paginate: model.PaginateCursor,
+		}).Times(1).Return(cakes[:1], &model.Pagination{Total: 2, Limit: 1, NextCursor: "abc.def"}, nil)
+
+		err := cakeController.HandleFindAll()(ectx)
+		require.NoError(t, err)
+
+		resBody := model.ResponseSuccess{}
+		err = json.NewDecoder(rec.Result().Body).Decode(&resBody)
+		require.NoError(t, err)
+		require.Equal(t, "abc.def", resBody.Meta.NextCursor)
+		require.Equal(t, "/cakes?cursor=abc.def&limit=1&paginate=cursor", resBody.Meta.Next)
+		require.Empty(t, resBody.Meta.Prev)
+	})
+
+	t.Run("ok - not modified", func(t *testing.T) {
+		ec := echo.New()
+		rec := httptest.NewRecorder()
+		ctx := context.Background()
+		pagination := &model.Pagination{Total: 2, Page: 1, Limit: 10}
+		req := httptest.NewRequest(http.MethodGet, "/cakes", nil)
+		req.Header.Set("If-None-Match", model.CakeListETag(cakes, pagination))
+		ectx := ec.NewContext(req, rec)
+
+		mockCakeService.EXPECT().FindAll(ctx, model.CakeQuery{}).Times(1).Return(cakes, pagination, nil)
+
+		err := cakeController.HandleFindAll()(ectx)
+		require.NoError(t, err)
+		require.EqualValues(t, http.StatusNotModified, rec.Result().StatusCode)
+	})
+
+	t.Run("ok - list changed", func(t *testing.T) {
+		ec := echo.New()
+		rec := httptest.NewRecorder()
+		ctx := context.Background()
+		req := httptest.NewRequest(http.MethodGet, "/cakes", nil)
+		req.Header.Set("If-None-Match", model.CakeListETag(cakes, &model.Pagination{Total: 2, Page: 1, Limit: 10}))
+		ectx := ec.NewContext(req, rec)
+
+		mockCakeService.EXPECT().FindAll(ctx, model.CakeQuery{}).Times(1).Return(cakes, &model.Pagination{Total: 3, Page: 1, Limit: 10}, nil)
+
+		err := cakeController.HandleFindAll()(ectx)
+		require.NoError(t, err)
+		require.EqualValues(t, http.StatusOK, rec.Result().StatusCode)
+		require.NotEmpty(t, rec.Header().Get("ETag"))
+		require.Empty(t, rec.Header().Get("Last-Modified"))
+	})
+
+	t.Run("ok - if modified since ignored", func(t *testing.T) {
+		ec := echo.New()
+		rec := httptest.NewRecorder()
+		ctx := context.Background()
+		req := httptest.NewRequest(http.MethodGet, "/cakes", nil)
+		req.Header.Set("If-Modified-Since", time.Now().Add(time.Hour).UTC().Format(http.TimeFormat))
+		ectx := ec.NewContext(req, rec)
+
+		mockCakeService.EXPECT().FindAll(ctx, model.CakeQuery{}).Times(1).Return(cakes, &model.Pagination{Total: 1, Page: 1, Limit: 10}, nil)
+
+		err := cakeController.HandleFindAll()(ectx)
+		require.NoError(t, err)
+		require.EqualValues(t, http.StatusOK, rec.Result().StatusCode)
+	})
+
+	t.Run("ok - include deleted by admin", func(t *testing.T) {
+		ec := echo.New()
+		rec := httptest.NewRecorder()
+		ctx := context.Background()
+		req := httptest.NewRequest(http.MethodGet, "/cakes?include_deleted=true", nil)
+		ectx := ec.NewContext(req, rec)
+		ectx.Set(auth.ContextKeyAdmin, true)
+
+		mockCakeService.EXPECT().FindAll(ctx, model.CakeQuery{IncludeDeleted: true}).Times(1).Return(cakes, &model.Pagination{Total: 2, Page: 1, Limit: 10}, nil)
+
+		err := cakeController.HandleFindAll()(ectx)
+		require.NoError(t, err)
+		require.EqualValues(t, http.StatusOK, rec.Result().StatusCode)
+	})
+
+	t.Run("handle error - include deleted by non admin", func(t *testing.T) {
+		ec := echo.New()
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/cakes?include_deleted=true", nil)
+		ectx := ec.NewContext(req, rec)
+
+		err := cakeController.HandleFindAll()(ectx)
+		ec.DefaultHTTPErrorHandler(err, ectx)
+		require.EqualValues(t, http.StatusForbidden, rec.Result().StatusCode)
+	})
+
+	t.Run("handle error - invalid query", func(t *testing.T) {
+		ec := echo.New()
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/cakes?page=abc", nil)
+		ectx := ec.NewContext(req, rec)
+
+		err := cakeController.HandleFindAll()(ectx)
+		ec.DefaultHTTPErrorHandler(err, ectx)
+		require.EqualValues(t, http.StatusBadRequest, rec.Result().StatusCode)
+	})
+
 	t.Run("handle not found", func(t *testing.T) {
 		ec := echo.New()
 		rec := httptest.NewRecorder()
-		ctx := context.TODO()
+		ctx := context.Background()
 		cakes := make([]*model.Cake, 0)
 		req := httptest.NewRequest(http.MethodGet, "/cakes", nil)
 		req.Header.Set("Content-Type", "application/json")
 		ectx := ec.NewContext(req, rec)
 
-		mockCakeService.EXPECT().FindAll(ctx).Times(1).Return(cakes, nil)
+		mockCakeService.EXPECT().FindAll(ctx, model.CakeQuery{}).Times(1).Return(cakes, &model.Pagination{Page: 1, Limit: 10}, nil)
 
 		err := cakeController.HandleFindAll()(ectx)
 		require.NoError(t, err)
@@ -419,12 +803,12 @@ func TestHTTP_handleFindAll(t *testing.T) {
 	t.Run("handle error - internal", func(t *testing.T) {
 		ec := echo.New()
 		rec := httptest.NewRecorder()
-		ctx := context.TODO()
+		ctx := context.Background()
 		req := httptest.NewRequest(http.MethodGet, "/cakes", nil)
 		req.Header.Set("Content-Type", "application/json")
 		ectx := ec.NewContext(req, rec)
 
-		mockCakeService.EXPECT().FindAll(ctx).Times(1).Return(nil, constant.ErrInternal)
+		mockCakeService.EXPECT().FindAll(ctx, model.CakeQuery{}).Times(1).Return(nil, nil, constant.ErrInternal)
 
 		err := cakeController.HandleFindAll()(ectx)
 		ec.DefaultHTTPErrorHandler(err, ectx)
@@ -455,7 +839,7 @@ func TestHTTP_handleFindById(t *testing.T) {
 	t.Run("ok", func(t *testing.T) {
 		ec := echo.New()
 		rec := httptest.NewRecorder()
-		ctx := context.TODO()
+		ctx := context.Background()
 		req := httptest.NewRequest(http.MethodGet, "/cakes", nil)
 		req.Header.Set("Content-Type", "application/json")
 		ectx := ec.NewContext(req, rec)
@@ -466,6 +850,7 @@ func TestHTTP_handleFindById(t *testing.T) {
 
 		err := cakeController.HandleFindById()(ectx)
 		require.NoError(t, err)
+		require.Equal(t, cake.ETag(), rec.Header().Get("ETag"))
 
 		resBody := map[string]interface{}{}
 		err = json.NewDecoder(rec.Result().Body).Decode(&resBody)
@@ -473,10 +858,103 @@ func TestHTTP_handleFindById(t *testing.T) {
 		require.EqualValues(t, http.StatusOK, rec.Result().StatusCode)
 	})
 
+	t.Run("ok - not modified by etag", func(t *testing.T) {
+		ec := echo.New()
+		rec := httptest.NewRecorder()
+		ctx := context.Background()
+		req := httptest.NewRequest(http.MethodGet, "/cakes", nil)
+		req.Header.Set("If-None-Match", `"99", W/"`+strconv.Itoa(cake.Version)+`"`)
+		ectx := ec.NewContext(req, rec)
+		ectx.SetParamNames("id")
+		ectx.SetParamValues(strconv.Itoa(cake.Id))
+
+		mockCakeService.EXPECT().FindVersion(ctx, cake.Id).Times(1).Return(cake, nil)
+		mockCakeService.EXPECT().FindById(gomock.Any(), gomock.Any()).Times(0)
+
+		err := cakeController.HandleFindById()(ectx)
+		require.NoError(t, err)
+		require.EqualValues(t, http.StatusNotModified, rec.Result().StatusCode)
+		require.Equal(t, cake.ETag(), rec.Header().Get("ETag"))
+		require.Empty(t, rec.Body.String())
+	})
+
+	t.Run("ok - modified etag", func(t *testing.T) {
+		ec := echo.New()
+		rec := httptest.NewRecorder()
+		ctx := context.Background()
+		req := httptest.NewRequest(http.MethodGet, "/cakes", nil)
+		req.Header.Set("If-None-Match", `"99"`)
+		// If-Modified-Since is ignored when If-None-Match is sent
+		req.Header.Set("If-Modified-Since", time.Now().Add(time.Hour).UTC().Format(http.TimeFormat))
+		ectx := ec.NewContext(req, rec)
+		ectx.SetParamNames("id")
+		ectx.SetParamValues(strconv.Itoa(cake.Id))
+
+		mockCakeService.EXPECT().FindVersion(ctx, cake.Id).Times(1).Return(cake, nil)
+		mockCakeService.EXPECT().FindById(ctx, cake.Id).Times(1).Return(cake, nil)
+
+		err := cakeController.HandleFindById()(ectx)
+		require.NoError(t, err)
+		require.EqualValues(t, http.StatusOK, rec.Result().StatusCode)
+	})
+
+	t.Run("ok - not modified since", func(t *testing.T) {
+		ec := echo.New()
+		rec := httptest.NewRecorder()
+		ctx := context.Background()
+		req := httptest.NewRequest(http.MethodGet, "/cakes", nil)
+		req.Header.Set("If-Modified-Since", cake.UpdatedAt.UTC().Format(http.TimeFormat))
+		ectx := ec.NewContext(req, rec)
+		ectx.SetParamNames("id")
+		ectx.SetParamValues(strconv.Itoa(cake.Id))
+
+		mockCakeService.EXPECT().FindVersion(ctx, cake.Id).Times(1).Return(cake, nil)
+		mockCakeService.EXPECT().FindById(gomock.Any(), gomock.Any()).Times(0)
+
+		err := cakeController.HandleFindById()(ectx)
+		require.NoError(t, err)
+		require.EqualValues(t, http.StatusNotModified, rec.Result().StatusCode)
+		require.Equal(t, cake.UpdatedAt.UTC().Format(http.TimeFormat), rec.Header().Get("Last-Modified"))
+	})
+
+	t.Run("ok - modified since", func(t *testing.T) {
+		ec := echo.New()
+		rec := httptest.NewRecorder()
+		ctx := context.Background()
+		req := httptest.NewRequest(http.MethodGet, "/cakes", nil)
+		req.Header.Set("If-Modified-Since", cake.UpdatedAt.Add(-time.Minute).UTC().Format(http.TimeFormat))
+		ectx := ec.NewContext(req, rec)
+		ectx.SetParamNames("id")
+		ectx.SetParamValues(strconv.Itoa(cake.Id))
+
+		mockCakeService.EXPECT().FindVersion(ctx, cake.Id).Times(1).Return(cake, nil)
+		mockCakeService.EXPECT().FindById(ctx, cake.Id).Times(1).Return(cake, nil)
+
+		err := cakeController.HandleFindById()(ectx)
+		require.NoError(t, err)
+		require.EqualValues(t, http.StatusOK, rec.Result().StatusCode)
+	})
+
+	t.Run("handle not found - conditional", func(t *testing.T) {
+		ec := echo.New()
+		rec := httptest.NewRecorder()
+		ctx := context.Background()
+		req := httptest.NewRequest(http.MethodGet, "/cakes", nil)
+		req.Header.Set("If-None-Match", cake.ETag())
+		ectx := ec.NewContext(req, rec)
+		ectx.SetParamNames("id")
+		ectx.SetParamValues(strconv.Itoa(cake.Id))
+
+		mockCakeService.EXPECT().FindVersion(ctx, cake.Id).Times(1).Return(nil, constant.ErrNotFound)
+
+		err := cakeController.HandleFindById()(ectx)
+		require.Equal(t, constant.ErrNotFound, err)
+	})
+
 	t.Run("handle not found", func(t *testing.T) {
 		ec := echo.New()
 		rec := httptest.NewRecorder()
-		ctx := context.TODO()
+		ctx := context.Background()
 		req := httptest.NewRequest(http.MethodGet, "/cakes", nil)
 		req.Header.Set("Content-Type", "application/json")
 		ectx := ec.NewContext(req, rec)
@@ -493,7 +971,7 @@ func TestHTTP_handleFindById(t *testing.T) {
 	t.Run("handle error - internal", func(t *testing.T) {
 		ec := echo.New()
 		rec := httptest.NewRecorder()
-		ctx := context.TODO()
+		ctx := context.Background()
 		req := httptest.NewRequest(http.MethodGet, "/cakes", nil)
 		req.Header.Set("Content-Type", "application/json")
 		ectx := ec.NewContext(req, rec)
@@ -507,3 +985,101 @@ func TestHTTP_handleFindById(t *testing.T) {
 		require.EqualValues(t, http.StatusInternalServerError, rec.Result().StatusCode)
 	})
 }
+
+func TestHTTP_handleFindTrash(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCakeService := mock.NewMockCakeService(ctrl)
+	cakeController := &cakeController{
+		cakeService: mockCakeService,
+	}
+
+	deletedAt := time.Now()
+	cakes := []*model.Cake{
+		{
+			Id:        1,
+			Title:     "Kue Test",
+			Version:   2,
+			DeletedAt: &deletedAt,
+		},
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		ec := echo.New()
+		rec := httptest.NewRecorder()
+		ctx := context.Background()
+		req := httptest.NewRequest(http.MethodGet, "/cakes/trash?limit=5", nil)
+		ectx := ec.NewContext(req, rec)
+
+		mockCakeService.EXPECT().FindAll(ctx, model.CakeQuery{Limit: 5, Trashed: true}).Times(1).Return(cakes, &model.Pagination{Total: 1, Page: 1, Limit: 5}, nil)
+
+		err := cakeController.HandleFindTrash()(ectx)
+		require.NoError(t, err)
+		require.EqualValues(t, http.StatusOK, rec.Result().StatusCode)
+	})
+
+	t.Run("handle error - internal", func(t *testing.T) {
+		ec := echo.New()
+		rec := httptest.NewRecorder()
+		ctx := context.Background()
+		req := httptest.NewRequest(http.MethodGet, "/cakes/trash", nil)
+		ectx := ec.NewContext(req, rec)
+
+		mockCakeService.EXPECT().FindAll(ctx, model.CakeQuery{Trashed: true}).Times(1).Return(nil, nil, constant.ErrInternal)
+
+		err := cakeController.HandleFindTrash()(ectx)
+		ec.DefaultHTTPErrorHandler(err, ectx)
+		require.EqualValues(t, http.StatusInternalServerError, rec.Result().StatusCode)
+	})
+}
+
+func TestHTTP_handleRestore(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCakeService := mock.NewMockCakeService(ctrl)
+	cakeController := &cakeController{
+		cakeService: mockCakeService,
+	}
+
+	cake := &model.Cake{
+		Id:      1,
+		Title:   "Kue Test",
+		Version: 3,
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		ec := echo.New()
+		rec := httptest.NewRecorder()
+		ctx := context.Background()
+		req := httptest.NewRequest(http.MethodPost, "/cakes/1/restore", nil)
+		req.Header.Set("If-Match", `"2"`)
+		ectx := ec.NewContext(req, rec)
+		ectx.SetParamNames("id")
+		ectx.SetParamValues(strconv.Itoa(cake.Id))
+
+		mockCakeService.EXPECT().Restore(ctx, cake.Id, model.IfMatch{2}).Times(1).Return(cake, nil)
+
+		err := cakeController.HandleRestore()(ectx)
+		require.NoError(t, err)
+		require.EqualValues(t, http.StatusOK, rec.Result().StatusCode)
+		require.Equal(t, `"3"`, rec.Header().Get("ETag"))
+	})
+
+	t.Run("handle error - not deleted", func(t *testing.T) {
+		ec := echo.New()
+		rec := httptest.NewRecorder()
+		ctx := context.Background()
+		req := httptest.NewRequest(http.MethodPost, "/cakes/1/restore", nil)
+		ectx := ec.NewContext(req, rec)
+		ectx.SetParamNames("id")
+		ectx.SetParamValues(strconv.Itoa(cake.Id))
+
+		mockCakeService.EXPECT().Restore(ctx, cake.Id, nil).Times(1).Return(nil, constant.ErrNotDeleted)
+
+		err := cakeController.HandleRestore()(ectx)
+		ec.DefaultHTTPErrorHandler(err, ectx)
+		require.EqualValues(t, http.StatusBadRequest, rec.Result().StatusCode)
+	})
+}
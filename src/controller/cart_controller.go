@@ -0,0 +1,196 @@
+package controller
+
+import (
+	"cake-store/src/constant"
+	"cake-store/src/model"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	log "github.com/sirupsen/logrus"
+)
+
+type cartController struct {
+	cartService model.CartService
+}
+
+func NewCartController(cartService model.CartService) model.CartController {
+	return &cartController{
+		cartService: cartService,
+	}
+}
+
+func (cC *cartController) HandleCreate() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		cart, err := cC.cartService.Create(c.Request().Context())
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+
+		return c.JSON(http.StatusOK, model.ResponseSuccess{
+			Success: true,
+			Data:    cart,
+		})
+	}
+}
+
+func (cC *cartController) HandleFindById() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		cart, err := cC.cartService.FindById(c.Request().Context(), c.Param("cartId"))
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+
+		return c.JSON(http.StatusOK, model.ResponseSuccess{
+			Success: true,
+			Data:    cart,
+		})
+	}
+}
+
+func (cC *cartController) HandleAddLine() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req := model.AddCartLineRequest{}
+		if err := c.Bind(&req); err != nil {
+			log.Error(err)
+			return constant.ErrInvalidArgument
+		}
+
+		cart, err := cC.cartService.AddLine(c.Request().Context(), req, c.Param("cartId"))
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+
+		return c.JSON(http.StatusOK, model.ResponseSuccess{
+			Success: true,
+			Data:    cart,
+		})
+	}
+}
+
+func (cC *cartController) HandleUpdateLine() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req := model.UpdateCartLineRequest{}
+		if err := c.Bind(&req); err != nil {
+			log.Error(err)
+			return constant.ErrInvalidArgument
+		}
+
+		lineId, err := strconv.Atoi(c.Param("lineId"))
+		if err != nil {
+			log.Error(err)
+			return constant.ErrInvalidArgument
+		}
+
+		cart, err := cC.cartService.UpdateLine(c.Request().Context(), req, c.Param("cartId"), lineId)
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+
+		return c.JSON(http.StatusOK, model.ResponseSuccess{
+			Success: true,
+			Data:    cart,
+		})
+	}
+}
+
+func (cC *cartController) HandleRemoveLine() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		lineId, err := strconv.Atoi(c.Param("lineId"))
+		if err != nil {
+			log.Error(err)
+			return constant.ErrInvalidArgument
+		}
+
+		cart, err := cC.cartService.RemoveLine(c.Request().Context(), c.Param("cartId"), lineId)
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+
+		return c.JSON(http.StatusOK, model.ResponseSuccess{
+			Success: true,
+			Data:    cart,
+		})
+	}
+}
+
+func (cC *cartController) HandleDelete() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if err := cC.cartService.Delete(c.Request().Context(), c.Param("cartId")); err != nil {
+			log.Error(err)
+			return err
+		}
+
+		return c.JSON(http.StatusOK, model.ResponseSuccess{
+			Success: true,
+		})
+	}
+}
+
+func (cC *cartController) HandleSetCoupons() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req := model.SetCartCouponsRequest{}
+		if err := c.Bind(&req); err != nil {
+			log.Error(err)
+			return constant.ErrInvalidArgument
+		}
+
+		cart, err := cC.cartService.SetCoupons(c.Request().Context(), req, c.Param("cartId"))
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+
+		return c.JSON(http.StatusOK, model.ResponseSuccess{
+			Success: true,
+			Data:    cart,
+		})
+	}
+}
+
+func (cC *cartController) HandleSetStore() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req := model.SetCartStoreRequest{}
+		if err := c.Bind(&req); err != nil {
+			log.Error(err)
+			return constant.ErrInvalidArgument
+		}
+
+		cart, err := cC.cartService.SetStore(c.Request().Context(), req, c.Param("cartId"))
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+
+		return c.JSON(http.StatusOK, model.ResponseSuccess{
+			Success: true,
+			Data:    cart,
+		})
+	}
+}
+
+func (cC *cartController) HandleCheckout() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req := model.CheckoutCartRequest{}
+		if err := c.Bind(&req); err != nil {
+			log.Error(err)
+			return constant.ErrInvalidArgument
+		}
+
+		order, err := cC.cartService.Checkout(c.Request().Context(), req, c.Param("cartId"))
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+
+		return c.JSON(http.StatusOK, model.ResponseSuccess{
+			Success: true,
+			Data:    order,
+		})
+	}
+}
@@ -0,0 +1,252 @@
+package controller
+
+import (
+	"cake-store/src/constant"
+	"cake-store/src/model"
+	"cake-store/src/model/mock"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTP_handleAddCartLine(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCartService := mock.NewMockCartService(ctrl)
+	cartController := &cartController{
+		cartService: mockCartService,
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodPost, "/carts/abc/lines", strings.NewReader(`
+		{
+            "cake_id": 1,
+            "variant_id": 5,
+            "quantity": 2,
+            "message": "Happy Birthday"
+		}`,
+		))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ectx.SetParamNames("cartId")
+		ectx.SetParamValues("abc")
+		ctx := context.Background()
+
+		mockCartService.EXPECT().AddLine(ctx, model.AddCartLineRequest{CakeId: 1, VariantId: 5, Quantity: 2, Message: "Happy Birthday"}, "abc").
+			Times(1).Return(&model.Cart{Id: "abc"}, nil)
+
+		err := cartController.HandleAddLine()(ectx)
+		require.NoError(t, err)
+
+		resBody := map[string]interface{}{}
+		err = json.NewDecoder(rec.Result().Body).Decode(&resBody)
+		require.NoError(t, err)
+		require.EqualValues(t, http.StatusOK, rec.Result().StatusCode)
+	})
+
+	t.Run("handle error - deleted cake", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodPost, "/carts/abc/lines", strings.NewReader(`{"cake_id":2,"variant_id":5,"quantity":1}`))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ectx.SetParamNames("cartId")
+		ectx.SetParamValues("abc")
+		ctx := context.Background()
+
+		mockCartService.EXPECT().AddLine(ctx, model.AddCartLineRequest{CakeId: 2, VariantId: 5, Quantity: 1}, "abc").
+			Times(1).Return(nil, constant.ErrNotFound)
+
+		err := cartController.HandleAddLine()(ectx)
+		require.Equal(t, constant.ErrNotFound, err)
+	})
+}
+
+func TestHTTP_handleUpdateCartLine(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCartService := mock.NewMockCartService(ctrl)
+	cartController := &cartController{
+		cartService: mockCartService,
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodPut, "/carts/abc/lines/1", strings.NewReader(`{"quantity":3}`))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ectx.SetParamNames("cartId", "lineId")
+		ectx.SetParamValues("abc", "1")
+		ctx := context.Background()
+
+		mockCartService.EXPECT().UpdateLine(ctx, model.UpdateCartLineRequest{Quantity: 3}, "abc", 1).
+			Times(1).Return(&model.Cart{Id: "abc"}, nil)
+
+		err := cartController.HandleUpdateLine()(ectx)
+		require.NoError(t, err)
+		require.EqualValues(t, http.StatusOK, rec.Result().StatusCode)
+	})
+
+	t.Run("handle error - invalid line id", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodDelete, "/carts/abc/lines/x", nil)
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ectx.SetParamNames("cartId", "lineId")
+		ectx.SetParamValues("abc", "x")
+
+		err := cartController.HandleRemoveLine()(ectx)
+		require.Equal(t, constant.ErrInvalidArgument, err)
+	})
+}
+
+func TestHTTP_handleSetCartCoupons(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCartService := mock.NewMockCartService(ctrl)
+	cartController := &cartController{
+		cartService: mockCartService,
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodPut, "/carts/abc/coupons", strings.NewReader(`{"codes":["HEMAT10"]}`))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ectx.SetParamNames("cartId")
+		ectx.SetParamValues("abc")
+		ctx := context.Background()
+
+		mockCartService.EXPECT().SetCoupons(ctx, model.SetCartCouponsRequest{Codes: []string{"HEMAT10"}}, "abc").
+			Times(1).Return(&model.Cart{Id: "abc", Coupons: []string{"HEMAT10"}}, nil)
+
+		err := cartController.HandleSetCoupons()(ectx)
+		require.NoError(t, err)
+		require.EqualValues(t, http.StatusOK, rec.Result().StatusCode)
+	})
+
+	t.Run("handle error - rejected coupon", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodPut, "/carts/abc/coupons", strings.NewReader(`{"codes":["OLD"]}`))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ectx.SetParamNames("cartId")
+		ectx.SetParamValues("abc")
+		ctx := context.Background()
+		rejected := constant.CouponRejectedErr("OLD", model.CouponReasonExpired)
+
+		mockCartService.EXPECT().SetCoupons(ctx, model.SetCartCouponsRequest{Codes: []string{"OLD"}}, "abc").Times(1).Return(nil, rejected)
+
+		err := cartController.HandleSetCoupons()(ectx)
+		require.Equal(t, rejected, err)
+	})
+}
+
+func TestHTTP_handleSetCartStore(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCartService := mock.NewMockCartService(ctrl)
+	cartController := &cartController{
+		cartService: mockCartService,
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodPut, "/carts/abc/store", strings.NewReader(`{"store_id":2}`))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ectx.SetParamNames("cartId")
+		ectx.SetParamValues("abc")
+		ctx := context.Background()
+
+		mockCartService.EXPECT().SetStore(ctx, model.SetCartStoreRequest{StoreId: 2}, "abc").
+			Times(1).Return(&model.Cart{Id: "abc", StoreId: 2}, nil)
+
+		err := cartController.HandleSetStore()(ectx)
+		require.NoError(t, err)
+		require.EqualValues(t, http.StatusOK, rec.Result().StatusCode)
+	})
+
+	t.Run("handle error - store not found", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodPut, "/carts/abc/store", strings.NewReader(`{"store_id":9}`))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ectx.SetParamNames("cartId")
+		ectx.SetParamValues("abc")
+		ctx := context.Background()
+
+		mockCartService.EXPECT().SetStore(ctx, model.SetCartStoreRequest{StoreId: 9}, "abc").Times(1).Return(nil, constant.ErrNotFound)
+
+		err := cartController.HandleSetStore()(ectx)
+		require.Equal(t, constant.ErrNotFound, err)
+	})
+}
+
+func TestHTTP_handleCheckoutCart(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCartService := mock.NewMockCartService(ctrl)
+	cartController := &cartController{
+		cartService: mockCartService,
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodPost, "/carts/abc/checkout", strings.NewReader(`
+		{
+            "customer_name": "Budi",
+            "customer_phone": "0812",
+            "fulfillment": "delivery"
+		}`,
+		))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ectx.SetParamNames("cartId")
+		ectx.SetParamValues("abc")
+		ctx := context.Background()
+
+		mockCartService.EXPECT().Checkout(ctx, model.CheckoutCartRequest{CustomerName: "Budi", CustomerPhone: "0812", Fulfillment: model.FulfillmentDelivery}, "abc").
+			Times(1).Return(&model.Order{Id: 3, Status: model.OrderStatusPending}, nil)
+
+		err := cartController.HandleCheckout()(ectx)
+		require.NoError(t, err)
+		require.EqualValues(t, http.StatusOK, rec.Result().StatusCode)
+	})
+
+	t.Run("handle error - cart not found", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodPost, "/carts/gone/checkout", strings.NewReader(`{}`))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ectx.SetParamNames("cartId")
+		ectx.SetParamValues("gone")
+		ctx := context.Background()
+
+		mockCartService.EXPECT().Checkout(ctx, model.CheckoutCartRequest{}, "gone").Times(1).Return(nil, constant.ErrNotFound)
+
+		err := cartController.HandleCheckout()(ectx)
+		require.Equal(t, constant.ErrNotFound, err)
+	})
+}
@@ -0,0 +1,148 @@
+package controller
+
+import (
+	"cake-store/src/constant"
+	"cake-store/src/model"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	log "github.com/sirupsen/logrus"
+)
+
+type couponController struct {
+	couponService model.CouponService
+}
+
+func NewCouponController(couponService model.CouponService) model.CouponController {
+	return &couponController{
+		couponService: couponService,
+	}
+}
+
+func (cC *couponController) HandleCreate() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req := model.CreateUpdateCouponRequest{}
+		if err := c.Bind(&req); err != nil {
+			log.Error(err)
+			return constant.ErrInvalidArgument
+		}
+
+		create, err := cC.couponService.Create(c.Request().Context(), req)
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+
+		return c.JSON(http.StatusOK, model.ResponseSuccess{
+			Success: true,
+			Data:    create,
+		})
+	}
+}
+
+func (cC *couponController) HandleFindAll() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		coupons, err := cC.couponService.FindAll(c.Request().Context())
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+
+		return c.JSON(http.StatusOK, model.ResponseSuccess{
+			Success: true,
+			Data:    coupons,
+		})
+	}
+}
+
+func (cC *couponController) HandleFindById() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			log.Error(err)
+			return constant.ErrInvalidArgument
+		}
+
+		coupon, err := cC.couponService.FindById(c.Request().Context(), id)
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+
+		return c.JSON(http.StatusOK, model.ResponseSuccess{
+			Success: true,
+			Data:    coupon,
+		})
+	}
+}
+
+func (cC *couponController) HandleUpdate() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req := model.CreateUpdateCouponRequest{}
+		if err := c.Bind(&req); err != nil {
+			log.Error(err)
+			return constant.ErrInvalidArgument
+		}
+
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			log.Error(err)
+			return constant.ErrInvalidArgument
+		}
+
+		update, err := cC.couponService.Update(c.Request().Context(), req, id)
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+
+		return c.JSON(http.StatusOK, model.ResponseSuccess{
+			Success: true,
+			Data:    update,
+		})
+	}
+}
+
+func (cC *couponController) HandleDelete() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			log.Error(err)
+			return constant.ErrInvalidArgument
+		}
+
+		coupon, err := cC.couponService.Delete(c.Request().Context(), id)
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+
+		return c.JSON(http.StatusOK, model.ResponseSuccess{
+			Success: true,
+			Data:    coupon,
+		})
+	}
+}
+
+// HandleValidate apply the codes to the items without redeeming them, a rejected code is explained in the response
+func (cC *couponController) HandleValidate() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req := model.ValidateCouponRequest{}
+		if err := c.Bind(&req); err != nil {
+			log.Error(err)
+			return constant.ErrInvalidArgument
+		}
+
+		discount, err := cC.couponService.Validate(c.Request().Context(), req)
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+
+		return c.JSON(http.StatusOK, model.ResponseSuccess{
+			Success: true,
+			Data:    discount,
+		})
+	}
+}
@@ -0,0 +1,164 @@
+package controller
+
+import (
+	"cake-store/src/constant"
+	"cake-store/src/model"
+	"cake-store/src/model/mock"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTP_handleCreateCoupon(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCouponService := mock.NewMockCouponService(ctrl)
+	couponController := &couponController{
+		couponService: mockCouponService,
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodPost, "/coupons", strings.NewReader(`
+		{
+            "code": "HEMAT10",
+            "type": "percentage",
+            "value": 10,
+            "usage_limit": 100,
+            "stackable": true
+		}`,
+		))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ctx := context.Background()
+
+		mockCouponService.EXPECT().Create(ctx, model.CreateUpdateCouponRequest{Code: "HEMAT10", Type: model.CouponTypePercentage, Value: 10, UsageLimit: 100, Stackable: true}).
+			Times(1).Return(&model.Coupon{Id: 7, Code: "HEMAT10"}, nil)
+
+		err := couponController.HandleCreate()(ectx)
+		require.NoError(t, err)
+
+		resBody := map[string]interface{}{}
+		err = json.NewDecoder(rec.Result().Body).Decode(&resBody)
+		require.NoError(t, err)
+		require.EqualValues(t, http.StatusOK, rec.Result().StatusCode)
+	})
+
+	t.Run("handle error - already exists", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodPost, "/coupons", strings.NewReader(`{"code":"HEMAT10","type":"fixed","value":5000}`))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ctx := context.Background()
+
+		mockCouponService.EXPECT().Create(ctx, model.CreateUpdateCouponRequest{Code: "HEMAT10", Type: model.CouponTypeFixed, Value: 5000}).
+			Times(1).Return(nil, constant.ErrAlreadyExists)
+
+		err := couponController.HandleCreate()(ectx)
+		require.Equal(t, constant.ErrAlreadyExists, err)
+	})
+}
+
+func TestHTTP_handleFindCouponById(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCouponService := mock.NewMockCouponService(ctrl)
+	couponController := &couponController{
+		couponService: mockCouponService,
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodGet, "/coupons/7", nil)
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ectx.SetParamNames("id")
+		ectx.SetParamValues("7")
+		ctx := context.Background()
+
+		mockCouponService.EXPECT().FindById(ctx, 7).Times(1).Return(&model.Coupon{Id: 7, Code: "HEMAT10", Used: 3}, nil)
+
+		err := couponController.HandleFindById()(ectx)
+		require.NoError(t, err)
+		require.EqualValues(t, http.StatusOK, rec.Result().StatusCode)
+	})
+
+	t.Run("handle error - invalid id", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodGet, "/coupons/x", nil)
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ectx.SetParamNames("id")
+		ectx.SetParamValues("x")
+
+		err := couponController.HandleFindById()(ectx)
+		require.Equal(t, constant.ErrInvalidArgument, err)
+	})
+}
+
+func TestHTTP_handleValidateCoupon(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCouponService := mock.NewMockCouponService(ctrl)
+	couponController := &couponController{
+		couponService: mockCouponService,
+	}
+
+	t.Run("ok - rejected code explained", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodPost, "/coupons/validate", strings.NewReader(`
+		{
+            "codes": ["OLD"],
+            "items": [{"cake_id": 1, "variant_id": 5, "quantity": 2}]
+		}`,
+		))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ctx := context.Background()
+
+		mockCouponService.EXPECT().Validate(ctx, model.ValidateCouponRequest{
+			Codes: []string{"OLD"},
+			Items: []model.CreateOrderItemRequest{{CakeId: 1, VariantId: 5, Quantity: 2}},
+		}).Times(1).Return(&model.Discount{
+			Subtotal: model.NewMoney(500000, "IDR"),
+			Amount:   model.NewMoney(0, "IDR"),
+			Total:    model.NewMoney(500000, "IDR"),
+			Coupons:  []*model.AppliedCoupon{{Code: "OLD", Reason: model.CouponReasonExpired, Discount: model.NewMoney(0, "IDR")}},
+		}, nil)
+
+		err := couponController.HandleValidate()(ectx)
+		require.NoError(t, err)
+
+		resBody := struct {
+			Data model.Discount `json:"data"`
+		}{}
+		err = json.NewDecoder(rec.Result().Body).Decode(&resBody)
+		require.NoError(t, err)
+		require.EqualValues(t, http.StatusOK, rec.Result().StatusCode)
+		require.Equal(t, model.CouponReasonExpired, resBody.Data.Coupons[0].Reason)
+	})
+
+	t.Run("handle error - bind", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodPost, "/coupons/validate", strings.NewReader(`{"codes":"OLD"}`))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+
+		err := couponController.HandleValidate()(ectx)
+		require.Equal(t, constant.ErrInvalidArgument, err)
+	})
+}
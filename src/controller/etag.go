@@ -0,0 +1,91 @@
+package controller
+
+import (
+	"cake-store/src/constant"
+	"cake-store/src/model"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// conditional request headers, missing from echo header constants
+const (
+	headerETag        = "ETag"
+	headerIfMatch     = "If-Match"
+	headerIfNoneMatch = "If-None-Match"
+)
+
+// ifMatchVersion parse the If-Match header to the expected cake versions, empty when the header is absent or "*".
+// The request proceed when any of the listed tags match, weak entity tags never match on If-Match.
+func ifMatchVersion(c echo.Context) (model.IfMatch, error) {
+	header := strings.TrimSpace(c.Request().Header.Get(headerIfMatch))
+	if header == "" || header == "*" {
+		return nil, nil
+	}
+
+	ifMatch := model.IfMatch{}
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if strings.HasPrefix(tag, "W/") {
+			continue
+		}
+
+		v, err := strconv.Atoi(strings.Trim(tag, `"`))
+		if err != nil || v < 1 || len(tag) < 2 || tag[0] != '"' || tag[len(tag)-1] != '"' {
+			return nil, constant.ErrInvalidArgument
+		}
+		ifMatch = append(ifMatch, v)
+	}
+
+	// only weak tags, nothing can match
+	if len(ifMatch) == 0 {
+		return nil, constant.ErrPrecondition
+	}
+
+	return ifMatch, nil
+}
+
+// notModified set the validators of the response and report whether the request conditions allow to answer 304.
+// If-None-Match use the weak comparison and take precedence over If-Modified-Since.
+func notModified(c echo.Context, etag string, lastModified time.Time) bool {
+	header := c.Response().Header()
+	header.Set(headerETag, etag)
+	if !lastModified.IsZero() {
+		header.Set(echo.HeaderLastModified, lastModified.UTC().Format(http.TimeFormat))
+	}
+
+	if ifNoneMatch := strings.TrimSpace(c.Request().Header.Get(headerIfNoneMatch)); ifNoneMatch != "" {
+		if ifNoneMatch == "*" {
+			return true
+		}
+		for _, tag := range strings.Split(ifNoneMatch, ",") {
+			if weakTag(tag) == weakTag(etag) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if ifModifiedSince := c.Request().Header.Get(echo.HeaderIfModifiedSince); ifModifiedSince != "" && !lastModified.IsZero() {
+		since, err := http.ParseTime(ifModifiedSince)
+		if err != nil {
+			return false
+		}
+		return !lastModified.Truncate(time.Second).After(since)
+	}
+
+	return false
+}
+
+// conditional report whether the request carry a validator notModified may answer 304 to
+func conditional(c echo.Context) bool {
+	header := c.Request().Header
+	return header.Get(headerIfNoneMatch) != "" || header.Get(echo.HeaderIfModifiedSince) != ""
+}
+
+func weakTag(tag string) string {
+	return strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+}
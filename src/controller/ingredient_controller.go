@@ -0,0 +1,201 @@
+package controller
+
+import (
+	"cake-store/src/constant"
+	"cake-store/src/model"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	log "github.com/sirupsen/logrus"
+)
+
+type ingredientController struct {
+	ingredientService model.IngredientService
+}
+
+func NewIngredientController(ingredientService model.IngredientService) model.IngredientController {
+	return &ingredientController{
+		ingredientService: ingredientService,
+	}
+}
+
+func (iC *ingredientController) HandleCreate() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req := model.CreateUpdateIngredientRequest{}
+		if err := c.Bind(&req); err != nil {
+			log.Error(err)
+			return constant.ErrInvalidArgument
+		}
+
+		create, err := iC.ingredientService.Create(c.Request().Context(), req)
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+
+		return c.JSON(http.StatusOK, model.ResponseSuccess{
+			Success: true,
+			Data:    create,
+		})
+	}
+}
+
+func (iC *ingredientController) HandleFindAll() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ingredients, err := iC.ingredientService.FindAll(c.Request().Context())
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+
+		return c.JSON(http.StatusOK, model.ResponseSuccess{
+			Success: true,
+			Data:    ingredients,
+		})
+	}
+}
+
+func (iC *ingredientController) HandleFindById() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			log.Error(err)
+			return constant.ErrInvalidArgument
+		}
+
+		ingredient, err := iC.ingredientService.FindById(c.Request().Context(), id)
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+
+		return c.JSON(http.StatusOK, model.ResponseSuccess{
+			Success: true,
+			Data:    ingredient,
+		})
+	}
+}
+
+func (iC *ingredientController) HandleUpdate() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req := model.CreateUpdateIngredientRequest{}
+		if err := c.Bind(&req); err != nil {
+			log.Error(err)
+			return constant.ErrInvalidArgument
+		}
+
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			log.Error(err)
+			return constant.ErrInvalidArgument
+		}
+
+		update, err := iC.ingredientService.Update(c.Request().Context(), req, id)
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+
+		return c.JSON(http.StatusOK, model.ResponseSuccess{
+			Success: true,
+			Data:    update,
+		})
+	}
+}
+
+func (iC *ingredientController) HandleDelete() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			log.Error(err)
+			return constant.ErrInvalidArgument
+		}
+
+		ingredient, err := iC.ingredientService.Delete(c.Request().Context(), id)
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+
+		return c.JSON(http.StatusOK, model.ResponseSuccess{
+			Success: true,
+			Data:    ingredient,
+		})
+	}
+}
+
+func (iC *ingredientController) HandleSetCakeIngredients() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req := model.SetCakeIngredientsRequest{}
+		if err := c.Bind(&req); err != nil {
+			log.Error(err)
+			return constant.ErrInvalidArgument
+		}
+
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			log.Error(err)
+			return constant.ErrInvalidArgument
+		}
+
+		cake, err := iC.ingredientService.SetCakeIngredients(c.Request().Context(), req, id)
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+
+		return c.JSON(http.StatusOK, model.ResponseSuccess{
+			Success: true,
+			Data:    cake,
+		})
+	}
+}
+
+func (iC *ingredientController) HandleFindPrices() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			log.Error(err)
+			return constant.ErrInvalidArgument
+		}
+
+		prices, err := iC.ingredientService.FindPrices(c.Request().Context(), id)
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+
+		return c.JSON(http.StatusOK, model.ResponseSuccess{
+			Success: true,
+			Data:    prices,
+		})
+	}
+}
+
+func (iC *ingredientController) HandleSetPrices() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req := model.SetSupplierPricesRequest{}
+		if err := c.Bind(&req); err != nil {
+			log.Error(err)
+			return constant.ErrInvalidArgument
+		}
+
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			log.Error(err)
+			return constant.ErrInvalidArgument
+		}
+
+		prices, err := iC.ingredientService.SetPrices(c.Request().Context(), req, id)
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+
+		return c.JSON(http.StatusOK, model.ResponseSuccess{
+			Success: true,
+			Data:    prices,
+		})
+	}
+}
@@ -0,0 +1,331 @@
+package controller
+
+import (
+	"cake-store/src/constant"
+	"cake-store/src/model"
+	"cake-store/src/model/mock"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTP_handleCreateIngredient(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockIngredientService := mock.NewMockIngredientService(ctrl)
+	ingredientController := &ingredientController{
+		ingredientService: mockIngredientService,
+	}
+
+	ingredient := &model.Ingredient{
+		Id:        1,
+		Name:      "Butter",
+		Allergens: []string{model.AllergenDairy},
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	createReq := model.CreateUpdateIngredientRequest{
+		Name:      "Butter",
+		Allergens: []string{"dairy"},
+		Nutrition: model.NutritionFacts{EnergyKcal: 717, Fat: 81},
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodPost, "/ingredients",
+			strings.NewReader(`{"name":"Butter","allergens":["dairy"],"nutrition":{"energy_kcal":717,"fat":81}}`))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ctx := context.Background()
+
+		mockIngredientService.EXPECT().Create(ctx, createReq).Times(1).Return(ingredient, nil)
+
+		err := ingredientController.HandleCreate()(ectx)
+		require.NoError(t, err)
+
+		resBody := map[string]interface{}{}
+		err = json.NewDecoder(rec.Result().Body).Decode(&resBody)
+		require.NoError(t, err)
+		require.EqualValues(t, http.StatusOK, rec.Result().StatusCode)
+	})
+
+	t.Run("handle error - already exists", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodPost, "/ingredients",
+			strings.NewReader(`{"name":"Butter","allergens":["dairy"],"nutrition":{"energy_kcal":717,"fat":81}}`))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ctx := context.Background()
+
+		mockIngredientService.EXPECT().Create(ctx, createReq).Times(1).Return(nil, constant.ErrAlreadyExists)
+
+		err := ingredientController.HandleCreate()(ectx)
+		require.Equal(t, constant.ErrAlreadyExists, err)
+	})
+}
+
+func TestHTTP_handleUpdateIngredient(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockIngredientService := mock.NewMockIngredientService(ctrl)
+	ingredientController := &ingredientController{
+		ingredientService: mockIngredientService,
+	}
+
+	ingredient := &model.Ingredient{Id: 1, Name: "Salted Butter"}
+
+	t.Run("ok", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodPut, "/ingredients/1", strings.NewReader(`{"name":"Salted Butter"}`))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ectx.SetParamNames("id")
+		ectx.SetParamValues("1")
+		ctx := context.Background()
+
+		mockIngredientService.EXPECT().Update(ctx, model.CreateUpdateIngredientRequest{Name: "Salted Butter"}, 1).Times(1).Return(ingredient, nil)
+
+		err := ingredientController.HandleUpdate()(ectx)
+		require.NoError(t, err)
+		require.EqualValues(t, http.StatusOK, rec.Result().StatusCode)
+	})
+
+	t.Run("handle error - invalid id", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodPut, "/ingredients/x", strings.NewReader(`{"name":"Butter"}`))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ectx.SetParamNames("id")
+		ectx.SetParamValues("x")
+
+		err := ingredientController.HandleUpdate()(ectx)
+		require.Equal(t, constant.ErrInvalidArgument, err)
+	})
+}
+
+func TestHTTP_handleDeleteIngredient(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockIngredientService := mock.NewMockIngredientService(ctrl)
+	ingredientController := &ingredientController{
+		ingredientService: mockIngredientService,
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodDelete, "/ingredients/1", nil)
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ectx.SetParamNames("id")
+		ectx.SetParamValues("1")
+		ctx := context.Background()
+
+		mockIngredientService.EXPECT().Delete(ctx, 1).Times(1).Return(&model.Ingredient{Id: 1}, nil)
+
+		err := ingredientController.HandleDelete()(ectx)
+		require.NoError(t, err)
+		require.EqualValues(t, http.StatusOK, rec.Result().StatusCode)
+	})
+
+	t.Run("handle error - in use", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodDelete, "/ingredients/2", nil)
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ectx.SetParamNames("id")
+		ectx.SetParamValues("2")
+		ctx := context.Background()
+
+		mockIngredientService.EXPECT().Delete(ctx, 2).Times(1).Return(nil, constant.ErrInUse)
+
+		err := ingredientController.HandleDelete()(ectx)
+		require.Equal(t, constant.ErrInUse, err)
+	})
+}
+
+func TestHTTP_handleFindIngredient(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockIngredientService := mock.NewMockIngredientService(ctrl)
+	ingredientController := &ingredientController{
+		ingredientService: mockIngredientService,
+	}
+
+	ingredients := []*model.Ingredient{{Id: 1, Name: "Butter", Allergens: []string{model.AllergenDairy}}}
+
+	t.Run("ok - find all", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodGet, "/ingredients", nil)
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ctx := context.Background()
+
+		mockIngredientService.EXPECT().FindAll(ctx).Times(1).Return(ingredients, nil)
+
+		err := ingredientController.HandleFindAll()(ectx)
+		require.NoError(t, err)
+
+		resBody := map[string]interface{}{}
+		err = json.NewDecoder(rec.Result().Body).Decode(&resBody)
+		require.NoError(t, err)
+		require.Len(t, resBody["data"], 1)
+	})
+
+	t.Run("ok - find by id", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodGet, "/ingredients/1", nil)
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ectx.SetParamNames("id")
+		ectx.SetParamValues("1")
+		ctx := context.Background()
+
+		mockIngredientService.EXPECT().FindById(ctx, 1).Times(1).Return(ingredients[0], nil)
+
+		err := ingredientController.HandleFindById()(ectx)
+		require.NoError(t, err)
+		require.EqualValues(t, http.StatusOK, rec.Result().StatusCode)
+	})
+}
+
+func TestHTTP_handleSetCakeIngredients(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockIngredientService := mock.NewMockIngredientService(ctrl)
+	ingredientController := &ingredientController{
+		ingredientService: mockIngredientService,
+	}
+
+	cake := &model.Cake{Id: 3, Title: "Brownies"}
+	cake.SetIngredients([]*model.CakeIngredient{{IngredientId: 1, Name: "Butter", Quantity: 250, Allergens: []string{model.AllergenDairy}}})
+	setReq := model.SetCakeIngredientsRequest{Ingredients: []model.CakeIngredientRequest{{IngredientId: 1, Quantity: 250}}}
+
+	t.Run("ok", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodPut, "/cakes/3/ingredients", strings.NewReader(`{"ingredients":[{"ingredient_id":1,"quantity":250}]}`))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ectx.SetParamNames("id")
+		ectx.SetParamValues("3")
+		ctx := context.Background()
+
+		mockIngredientService.EXPECT().SetCakeIngredients(ctx, setReq, 3).Times(1).Return(cake, nil)
+
+		err := ingredientController.HandleSetCakeIngredients()(ectx)
+		require.NoError(t, err)
+		require.EqualValues(t, http.StatusOK, rec.Result().StatusCode)
+
+		resBody := struct {
+			Data struct {
+				Allergens []string `json:"allergens"`
+			} `json:"data"`
+		}{}
+		err = json.NewDecoder(rec.Result().Body).Decode(&resBody)
+		require.NoError(t, err)
+		require.Equal(t, []string{model.AllergenDairy}, resBody.Data.Allergens)
+	})
+
+	t.Run("handle error - unknown ingredient", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodPut, "/cakes/3/ingredients", strings.NewReader(`{"ingredients":[{"ingredient_id":1,"quantity":250}]}`))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ectx.SetParamNames("id")
+		ectx.SetParamValues("3")
+		ctx := context.Background()
+
+		mockIngredientService.EXPECT().SetCakeIngredients(ctx, setReq, 3).Times(1).Return(nil, constant.ErrInvalidArgument)
+
+		err := ingredientController.HandleSetCakeIngredients()(ectx)
+		require.Equal(t, constant.ErrInvalidArgument, err)
+	})
+}
+
+func TestHTTP_handleIngredientPrices(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockIngredientService := mock.NewMockIngredientService(ctrl)
+	ingredientController := &ingredientController{
+		ingredientService: mockIngredientService,
+	}
+
+	prices := []*model.SupplierPrice{{Id: 1, IngredientId: 2, Supplier: "Toko A", Price: model.NewMoney(1500000, "IDR"), Quantity: 1, Unit: "kg"}}
+
+	t.Run("ok - find", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodGet, "/ingredients/2/prices", nil)
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ectx.SetParamNames("id")
+		ectx.SetParamValues("2")
+		ctx := context.Background()
+
+		mockIngredientService.EXPECT().FindPrices(ctx, 2).Times(1).Return(prices, nil)
+
+		err := ingredientController.HandleFindPrices()(ectx)
+		require.NoError(t, err)
+
+		resBody := map[string]interface{}{}
+		err = json.NewDecoder(rec.Result().Body).Decode(&resBody)
+		require.NoError(t, err)
+		require.Len(t, resBody["data"], 1)
+	})
+
+	t.Run("ok - set", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodPut, "/ingredients/2/prices",
+			strings.NewReader(`{"prices":[{"supplier":"Toko A","price":{"amount":1500000,"currency":"IDR"},"quantity":1,"unit":"kg"}]}`))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ectx.SetParamNames("id")
+		ectx.SetParamValues("2")
+		ctx := context.Background()
+
+		setReq := model.SetSupplierPricesRequest{Prices: []model.SupplierPriceRequest{
+			{Supplier: "Toko A", Price: model.NewMoney(1500000, "IDR"), Quantity: 1, Unit: "kg"},
+		}}
+		mockIngredientService.EXPECT().SetPrices(ctx, setReq, 2).Times(1).Return(prices, nil)
+
+		err := ingredientController.HandleSetPrices()(ectx)
+		require.NoError(t, err)
+		require.EqualValues(t, http.StatusOK, rec.Result().StatusCode)
+	})
+
+	t.Run("handle error - invalid prices", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodPut, "/ingredients/2/prices", strings.NewReader(`{"prices":[]}`))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ectx.SetParamNames("id")
+		ectx.SetParamValues("2")
+		ctx := context.Background()
+
+		mockIngredientService.EXPECT().SetPrices(ctx, model.SetSupplierPricesRequest{Prices: []model.SupplierPriceRequest{}}, 2).Times(1).
+			Return(nil, constant.ErrInvalidArgument)
+
+		err := ingredientController.HandleSetPrices()(ectx)
+		require.Equal(t, constant.ErrInvalidArgument, err)
+	})
+}
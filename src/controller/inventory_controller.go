@@ -0,0 +1,147 @@
+package controller
+
+import (
+	"cake-store/src/constant"
+	"cake-store/src/model"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	log "github.com/sirupsen/logrus"
+)
+
+type inventoryController struct {
+	inventoryService model.InventoryService
+}
+
+func NewInventoryController(inventoryService model.InventoryService) model.InventoryController {
+	return &inventoryController{
+		inventoryService: inventoryService,
+	}
+}
+
+func (iC *inventoryController) HandleFindAll() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		stocks, err := iC.inventoryService.FindAll(c.Request().Context())
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+
+		return c.JSON(http.StatusOK, model.ResponseSuccess{
+			Success: true,
+			Data:    stocks,
+		})
+	}
+}
+
+func (iC *inventoryController) HandleFindLow() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		stocks, err := iC.inventoryService.FindLow(c.Request().Context())
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+
+		return c.JSON(http.StatusOK, model.ResponseSuccess{
+			Success: true,
+			Data:    stocks,
+		})
+	}
+}
+
+func (iC *inventoryController) HandleAdjust() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req := model.AdjustInventoryRequest{}
+		if err := c.Bind(&req); err != nil {
+			log.Error(err)
+			return constant.ErrInvalidArgument
+		}
+
+		ingredientId, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			log.Error(err)
+			return constant.ErrInvalidArgument
+		}
+
+		stock, err := iC.inventoryService.Adjust(c.Request().Context(), req, ingredientId)
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+
+		return c.JSON(http.StatusOK, model.ResponseSuccess{
+			Success: true,
+			Data:    stock,
+		})
+	}
+}
+
+func (iC *inventoryController) HandleSetLevels() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req := model.SetInventoryLevelsRequest{}
+		if err := c.Bind(&req); err != nil {
+			log.Error(err)
+			return constant.ErrInvalidArgument
+		}
+
+		ingredientId, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			log.Error(err)
+			return constant.ErrInvalidArgument
+		}
+
+		stock, err := iC.inventoryService.SetLevels(c.Request().Context(), req, ingredientId)
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+
+		return c.JSON(http.StatusOK, model.ResponseSuccess{
+			Success: true,
+			Data:    stock,
+		})
+	}
+}
+
+func (iC *inventoryController) HandleFindAdjustments() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ingredientId, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			log.Error(err)
+			return constant.ErrInvalidArgument
+		}
+
+		adjustments, err := iC.inventoryService.FindAdjustments(c.Request().Context(), ingredientId)
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+
+		return c.JSON(http.StatusOK, model.ResponseSuccess{
+			Success: true,
+			Data:    adjustments,
+		})
+	}
+}
+
+func (iC *inventoryController) HandleReorderReport() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		query := model.ReorderQuery{}
+		if err := c.Bind(&query); err != nil {
+			log.Error(err)
+			return constant.ErrInvalidArgument
+		}
+
+		report, err := iC.inventoryService.ReorderReport(c.Request().Context(), query)
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+
+		return c.JSON(http.StatusOK, model.ResponseSuccess{
+			Success: true,
+			Data:    report,
+		})
+	}
+}
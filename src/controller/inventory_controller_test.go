@@ -0,0 +1,280 @@
+package controller
+
+import (
+	"cake-store/src/constant"
+	"cake-store/src/model"
+	"cake-store/src/model/mock"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTP_handleFindIngredientStock(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockInventoryService := mock.NewMockInventoryService(ctrl)
+	inventoryController := &inventoryController{
+		inventoryService: mockInventoryService,
+	}
+
+	stocks := []*model.IngredientStock{{IngredientId: 2, Name: "Flour", OnHand: 200, Unit: model.UnitGram, ReorderPoint: 500, LowStock: true}}
+
+	t.Run("ok", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodGet, "/ingredients/stock", nil)
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ctx := context.Background()
+
+		mockInventoryService.EXPECT().FindAll(ctx).Times(1).Return(stocks, nil)
+
+		err := inventoryController.HandleFindAll()(ectx)
+		require.NoError(t, err)
+
+		resBody := map[string]interface{}{}
+		err = json.NewDecoder(rec.Result().Body).Decode(&resBody)
+		require.NoError(t, err)
+		require.Len(t, resBody["data"], 1)
+	})
+
+	t.Run("ok - low", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodGet, "/ingredients/stock/low", nil)
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ctx := context.Background()
+
+		mockInventoryService.EXPECT().FindLow(ctx).Times(1).Return(stocks, nil)
+
+		err := inventoryController.HandleFindLow()(ectx)
+		require.NoError(t, err)
+
+		resBody := map[string]interface{}{}
+		err = json.NewDecoder(rec.Result().Body).Decode(&resBody)
+		require.NoError(t, err)
+		require.Equal(t, true, resBody["data"].([]interface{})[0].(map[string]interface{})["low_stock"])
+	})
+
+	t.Run("handle error - service", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodGet, "/ingredients/stock", nil)
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ctx := context.Background()
+
+		mockInventoryService.EXPECT().FindAll(ctx).Times(1).Return(nil, errors.New("err db"))
+
+		err := inventoryController.HandleFindAll()(ectx)
+		require.Error(t, err)
+	})
+}
+
+func TestHTTP_handleAdjustIngredientStock(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockInventoryService := mock.NewMockInventoryService(ctrl)
+	inventoryController := &inventoryController{
+		inventoryService: mockInventoryService,
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodPost, "/ingredients/2/stock/adjustments", strings.NewReader(`{"delta":1.5,"unit":"kg","reason":"purchase"}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ectx.SetParamNames("id")
+		ectx.SetParamValues("2")
+		ctx := context.Background()
+
+		adjustReq := model.AdjustInventoryRequest{Delta: 1.5, Unit: "kg", Reason: model.InventoryReasonPurchase}
+		mockInventoryService.EXPECT().Adjust(ctx, adjustReq, 2).Times(1).
+			Return(&model.IngredientStock{IngredientId: 2, OnHand: 1700, Unit: model.UnitGram}, nil)
+
+		err := inventoryController.HandleAdjust()(ectx)
+		require.NoError(t, err)
+
+		resBody := map[string]interface{}{}
+		err = json.NewDecoder(rec.Result().Body).Decode(&resBody)
+		require.NoError(t, err)
+		require.EqualValues(t, 1700, resBody["data"].(map[string]interface{})["on_hand"])
+	})
+
+	t.Run("handle error - insufficient stock", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodPost, "/ingredients/2/stock/adjustments", strings.NewReader(`{"delta":-9000,"reason":"waste"}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ectx.SetParamNames("id")
+		ectx.SetParamValues("2")
+		ctx := context.Background()
+
+		adjustReq := model.AdjustInventoryRequest{Delta: -9000, Reason: model.InventoryReasonWaste}
+		mockInventoryService.EXPECT().Adjust(ctx, adjustReq, 2).Times(1).Return(nil, constant.ErrInsufficientStock)
+
+		err := inventoryController.HandleAdjust()(ectx)
+		require.Equal(t, constant.ErrInsufficientStock, err)
+	})
+
+	t.Run("handle error - invalid id", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodPost, "/ingredients/flour/stock/adjustments", strings.NewReader(`{"delta":1,"reason":"purchase"}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ectx.SetParamNames("id")
+		ectx.SetParamValues("flour")
+
+		err := inventoryController.HandleAdjust()(ectx)
+		require.Equal(t, constant.ErrInvalidArgument, err)
+	})
+}
+
+func TestHTTP_handleSetIngredientStockLevels(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockInventoryService := mock.NewMockInventoryService(ctrl)
+	inventoryController := &inventoryController{
+		inventoryService: mockInventoryService,
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodPut, "/ingredients/2/stock", strings.NewReader(`{"unit":"kg","reorder_point":0.5,"reorder_quantity":2}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ectx.SetParamNames("id")
+		ectx.SetParamValues("2")
+		ctx := context.Background()
+
+		levelsReq := model.SetInventoryLevelsRequest{Unit: "kg", ReorderPoint: 0.5, ReorderQuantity: 2}
+		mockInventoryService.EXPECT().SetLevels(ctx, levelsReq, 2).Times(1).
+			Return(&model.IngredientStock{IngredientId: 2, Unit: model.UnitKilogram, ReorderPoint: 0.5, ReorderQuantity: 2}, nil)
+
+		err := inventoryController.HandleSetLevels()(ectx)
+		require.NoError(t, err)
+
+		resBody := map[string]interface{}{}
+		err = json.NewDecoder(rec.Result().Body).Decode(&resBody)
+		require.NoError(t, err)
+		require.Equal(t, "kg", resBody["data"].(map[string]interface{})["unit"])
+	})
+
+	t.Run("handle error - bad body", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodPut, "/ingredients/2/stock", strings.NewReader(`{"unit":`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ectx.SetParamNames("id")
+		ectx.SetParamValues("2")
+
+		err := inventoryController.HandleSetLevels()(ectx)
+		require.Equal(t, constant.ErrInvalidArgument, err)
+	})
+}
+
+func TestHTTP_handleFindIngredientStockAdjustments(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockInventoryService := mock.NewMockInventoryService(ctrl)
+	inventoryController := &inventoryController{
+		inventoryService: mockInventoryService,
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodGet, "/ingredients/2/stock/adjustments", nil)
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ectx.SetParamNames("id")
+		ectx.SetParamValues("2")
+		ctx := context.Background()
+
+		adjustments := []*model.InventoryAdjustment{{Id: 8, IngredientId: 2, OrderId: 3, Delta: -250, Unit: model.UnitGram, Reason: model.InventoryReasonConsumption}}
+		mockInventoryService.EXPECT().FindAdjustments(ctx, 2).Times(1).Return(adjustments, nil)
+
+		err := inventoryController.HandleFindAdjustments()(ectx)
+		require.NoError(t, err)
+
+		resBody := map[string]interface{}{}
+		err = json.NewDecoder(rec.Result().Body).Decode(&resBody)
+		require.NoError(t, err)
+		require.EqualValues(t, 3, resBody["data"].([]interface{})[0].(map[string]interface{})["order_id"])
+	})
+
+	t.Run("handle error - not found", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodGet, "/ingredients/9/stock/adjustments", nil)
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ectx.SetParamNames("id")
+		ectx.SetParamValues("9")
+		ctx := context.Background()
+
+		mockInventoryService.EXPECT().FindAdjustments(ctx, 9).Times(1).Return(nil, constant.ErrNotFound)
+
+		err := inventoryController.HandleFindAdjustments()(ectx)
+		require.Equal(t, constant.ErrNotFound, err)
+	})
+}
+
+func TestHTTP_handleReorderReport(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockInventoryService := mock.NewMockInventoryService(ctrl)
+	inventoryController := &inventoryController{
+		inventoryService: mockInventoryService,
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodGet, "/ingredients/stock/reorder?currency=USD", nil)
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ctx := context.Background()
+
+		report := &model.ReorderReport{
+			Suppliers: []*model.SupplierOrder{{Supplier: "Toko B", Total: model.NewMoney(175, "USD")}},
+			Unsourced: []*model.ReorderLine{},
+			Total:     model.NewMoney(175, "USD"),
+		}
+		mockInventoryService.EXPECT().ReorderReport(ctx, model.ReorderQuery{Currency: "USD"}).Times(1).Return(report, nil)
+
+		err := inventoryController.HandleReorderReport()(ectx)
+		require.NoError(t, err)
+
+		resBody := map[string]interface{}{}
+		err = json.NewDecoder(rec.Result().Body).Decode(&resBody)
+		require.NoError(t, err)
+		require.Equal(t, "Toko B", resBody["data"].(map[string]interface{})["suppliers"].([]interface{})[0].(map[string]interface{})["supplier"])
+	})
+
+	t.Run("handle error - invalid currency", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodGet, "/ingredients/stock/reorder?currency=XYZ", nil)
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ctx := context.Background()
+
+		mockInventoryService.EXPECT().ReorderReport(ctx, model.ReorderQuery{Currency: "XYZ"}).Times(1).Return(nil, constant.ErrInvalidArgument)
+
+		err := inventoryController.HandleReorderReport()(ectx)
+		require.Equal(t, constant.ErrInvalidArgument, err)
+	})
+}
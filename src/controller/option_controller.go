@@ -0,0 +1,97 @@
+package controller
+
+import (
+	"cake-store/src/constant"
+	"cake-store/src/model"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	log "github.com/sirupsen/logrus"
+)
+
+type optionController struct {
+	optionService model.OptionService
+}
+
+func NewOptionController(optionService model.OptionService) model.OptionController {
+	return &optionController{
+		optionService: optionService,
+	}
+}
+
+func (oC *optionController) HandleSave() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req := model.SaveOptionsRequest{}
+		if err := c.Bind(&req); err != nil {
+			log.Error(err)
+			return constant.ErrInvalidArgument
+		}
+
+		cakeId, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			log.Error(err)
+			return constant.ErrInvalidArgument
+		}
+
+		groups, err := oC.optionService.Save(c.Request().Context(), req, cakeId)
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+
+		return c.JSON(http.StatusOK, model.ResponseSuccess{
+			Success: true,
+			Data:    groups,
+		})
+	}
+}
+
+func (oC *optionController) HandleFindByCakeId() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		cakeId, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			log.Error(err)
+			return constant.ErrInvalidArgument
+		}
+
+		groups, err := oC.optionService.FindByCakeId(c.Request().Context(), cakeId)
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+
+		return c.JSON(http.StatusOK, model.ResponseSuccess{
+			Success: true,
+			Data:    groups,
+		})
+	}
+}
+
+// HandleQuote price a custom cake, a selection the options do not allow is rejected with the reason
+func (oC *optionController) HandleQuote() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req := model.QuoteOptionsRequest{}
+		if err := c.Bind(&req); err != nil {
+			log.Error(err)
+			return constant.ErrInvalidArgument
+		}
+
+		cakeId, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			log.Error(err)
+			return constant.ErrInvalidArgument
+		}
+
+		quote, err := oC.optionService.Quote(c.Request().Context(), req, cakeId)
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+
+		return c.JSON(http.StatusOK, model.ResponseSuccess{
+			Success: true,
+			Data:    quote,
+		})
+	}
+}
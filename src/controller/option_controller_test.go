@@ -0,0 +1,168 @@
+package controller
+
+import (
+	"cake-store/src/constant"
+	"cake-store/src/model"
+	"cake-store/src/model/mock"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTP_handleSaveOptions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockOptionService := mock.NewMockOptionService(ctrl)
+	optionController := &optionController{
+		optionService: mockOptionService,
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		ec := echo.New()
+		body := `{"groups":[{"code":"size","name":"Size","kind":"choice","required":true,"choices":[{"code":"24cm","name":"24 cm","value":24}]}]}`
+		req := httptest.NewRequest(http.MethodPut, "/cakes/1/options", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ectx.SetParamNames("id")
+		ectx.SetParamValues("1")
+		ctx := context.Background()
+
+		mockOptionService.EXPECT().Save(ctx, gomock.Any(), 1).Times(1).
+			DoAndReturn(func(_ context.Context, req model.SaveOptionsRequest, _ int) ([]*model.OptionGroup, error) {
+				require.Len(t, req.Groups, 1)
+				require.Equal(t, 24.0, req.Groups[0].Choices[0].Value)
+				return []*model.OptionGroup{{Id: 3, CakeId: 1, Code: "size", Name: "Size", Kind: model.OptionKindChoice}}, nil
+			})
+
+		err := optionController.HandleSave()(ectx)
+		require.NoError(t, err)
+
+		resBody := map[string]interface{}{}
+		err = json.NewDecoder(rec.Result().Body).Decode(&resBody)
+		require.NoError(t, err)
+		require.Len(t, resBody["data"], 1)
+	})
+
+	t.Run("invalid id", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodPut, "/cakes/x/options", strings.NewReader(`{}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ectx.SetParamNames("id")
+		ectx.SetParamValues("x")
+
+		err := optionController.HandleSave()(ectx)
+		require.Equal(t, constant.ErrInvalidArgument, err)
+	})
+}
+
+func TestHTTP_handleFindOptions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockOptionService := mock.NewMockOptionService(ctrl)
+	optionController := &optionController{
+		optionService: mockOptionService,
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodGet, "/cakes/1/options", nil)
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ectx.SetParamNames("id")
+		ectx.SetParamValues("1")
+		ctx := context.Background()
+
+		mockOptionService.EXPECT().FindByCakeId(ctx, 1).Times(1).
+			Return([]*model.OptionGroup{{Id: 3, CakeId: 1, Code: "size"}, {Id: 4, CakeId: 1, Code: "frosting"}}, nil)
+
+		err := optionController.HandleFindByCakeId()(ectx)
+		require.NoError(t, err)
+
+		resBody := map[string]interface{}{}
+		err = json.NewDecoder(rec.Result().Body).Decode(&resBody)
+		require.NoError(t, err)
+		require.Len(t, resBody["data"], 2)
+	})
+
+	t.Run("handle error - service", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodGet, "/cakes/9/options", nil)
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ectx.SetParamNames("id")
+		ectx.SetParamValues("9")
+		ctx := context.Background()
+
+		mockOptionService.EXPECT().FindByCakeId(ctx, 9).Times(1).Return(nil, constant.ErrNotFound)
+
+		err := optionController.HandleFindByCakeId()(ectx)
+		require.Equal(t, constant.ErrNotFound, err)
+	})
+}
+
+func TestHTTP_handleQuoteOptions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockOptionService := mock.NewMockOptionService(ctrl)
+	optionController := &optionController{
+		optionService: mockOptionService,
+	}
+
+	body := `{"variant_id":5,"quantity":1,"options":[{"group":"size","choices":["24cm"]},{"group":"inscription","text":"Happy 30"}]}`
+
+	t.Run("ok", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodPost, "/cakes/1/options/quote", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ectx.SetParamNames("id")
+		ectx.SetParamValues("1")
+		ctx := context.Background()
+
+		expected := model.QuoteOptionsRequest{VariantId: 5, Quantity: 1, Options: []model.OptionSelection{
+			{Group: "size", Choices: []string{"24cm"}},
+			{Group: "inscription", Text: "Happy 30"},
+		}}
+		mockOptionService.EXPECT().Quote(ctx, expected, 1).Times(1).
+			Return(&model.OptionQuote{CakeId: 1, VariantId: 5, Quantity: 1, UnitPrice: model.NewMoney(315000, "IDR"), Total: model.NewMoney(315000, "IDR")}, nil)
+
+		err := optionController.HandleQuote()(ectx)
+		require.NoError(t, err)
+
+		resBody := map[string]interface{}{}
+		err = json.NewDecoder(rec.Result().Body).Decode(&resBody)
+		require.NoError(t, err)
+		require.Equal(t, float64(315000), resBody["data"].(map[string]interface{})["total"].(map[string]interface{})["amount"])
+	})
+
+	t.Run("handle error - service", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodPost, "/cakes/1/options/quote", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ectx.SetParamNames("id")
+		ectx.SetParamValues("1")
+		ctx := context.Background()
+
+		mockOptionService.EXPECT().Quote(ctx, gomock.Any(), 1).Times(1).Return(nil, errors.New("err db"))
+
+		err := optionController.HandleQuote()(ectx)
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,113 @@
+package controller
+
+import (
+	"cake-store/src/constant"
+	"cake-store/src/model"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	log "github.com/sirupsen/logrus"
+)
+
+type orderController struct {
+	orderService model.OrderService
+}
+
+func NewOrderController(orderService model.OrderService) model.OrderController {
+	return &orderController{
+		orderService: orderService,
+	}
+}
+
+func (oC *orderController) HandleCreate() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req := model.CreateOrderRequest{}
+		if err := c.Bind(&req); err != nil {
+			log.Error(err)
+			return constant.ErrInvalidArgument
+		}
+
+		create, err := oC.orderService.Create(c.Request().Context(), req)
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+
+		return c.JSON(http.StatusOK, model.ResponseSuccess{
+			Success: true,
+			Data:    create,
+		})
+	}
+}
+
+func (oC *orderController) HandleTransition() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req := model.TransitionOrderRequest{}
+		if err := c.Bind(&req); err != nil {
+			log.Error(err)
+			return constant.ErrInvalidArgument
+		}
+
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			log.Error(err)
+			return constant.ErrInvalidArgument
+		}
+
+		order, err := oC.orderService.Transition(c.Request().Context(), req, id)
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+
+		return c.JSON(http.StatusOK, model.ResponseSuccess{
+			Success: true,
+			Data:    order,
+		})
+	}
+}
+
+func (oC *orderController) HandleFindById() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			log.Error(err)
+			return constant.ErrInvalidArgument
+		}
+
+		order, err := oC.orderService.FindById(c.Request().Context(), id)
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+
+		return c.JSON(http.StatusOK, model.ResponseSuccess{
+			Success: true,
+			Data:    order,
+		})
+	}
+}
+
+func (oC *orderController) HandleFindAll() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		query := model.OrderQuery{}
+		if err := c.Bind(&query); err != nil {
+			log.Error(err)
+			return constant.ErrInvalidArgument
+		}
+
+		orders, pagination, err := oC.orderService.FindAll(c.Request().Context(), query)
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+
+		setPaginationLinks(c, pagination)
+		return c.JSON(http.StatusOK, model.ResponseSuccess{
+			Success: true,
+			Data:    orders,
+			Meta:    pagination,
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package controller
+
+import (
+	"cake-store/src/constant"
+	"cake-store/src/model"
+	"cake-store/src/model/mock"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTP_handleCreateOrder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockOrderService := mock.NewMockOrderService(ctrl)
+	orderController := &orderController{
+		orderService: mockOrderService,
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader(`
+		{
+            "customer_name": "Budi",
+            "customer_phone": "0812",
+            "fulfillment": "pickup",
+            "items": [{"cake_id": 1, "variant_id": 5, "quantity": 2}]
+		}`,
+		))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ctx := context.Background()
+
+		mockOrderService.EXPECT().Create(ctx, model.CreateOrderRequest{
+			CustomerName:  "Budi",
+			CustomerPhone: "0812",
+			Fulfillment:   model.FulfillmentPickup,
+			Items:         []model.CreateOrderItemRequest{{CakeId: 1, VariantId: 5, Quantity: 2}},
+		}).Times(1).Return(&model.Order{Id: 3, Status: model.OrderStatusPending}, nil)
+
+		err := orderController.HandleCreate()(ectx)
+		require.NoError(t, err)
+
+		resBody := map[string]interface{}{}
+		err = json.NewDecoder(rec.Result().Body).Decode(&resBody)
+		require.NoError(t, err)
+		require.EqualValues(t, http.StatusOK, rec.Result().StatusCode)
+	})
+
+	t.Run("handle error - invalid body", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader(`{"items": "many"}`))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+
+		err := orderController.HandleCreate()(ectx)
+		require.Equal(t, constant.ErrInvalidArgument, err)
+	})
+}
+
+func TestHTTP_handleTransitionOrder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockOrderService := mock.NewMockOrderService(ctrl)
+	orderController := &orderController{
+		orderService: mockOrderService,
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodPost, "/orders/3/status", strings.NewReader(`{"status":"confirmed"}`))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ectx.SetParamNames("id")
+		ectx.SetParamValues("3")
+		ctx := context.Background()
+
+		mockOrderService.EXPECT().Transition(ctx, model.TransitionOrderRequest{Status: model.OrderStatusConfirmed}, 3).
+			Times(1).Return(&model.Order{Id: 3, Status: model.OrderStatusConfirmed}, nil)
+
+		err := orderController.HandleTransition()(ectx)
+		require.NoError(t, err)
+		require.EqualValues(t, http.StatusOK, rec.Result().StatusCode)
+	})
+
+	t.Run("handle error - illegal transition", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodPost, "/orders/3/status", strings.NewReader(`{"status":"ready"}`))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ectx.SetParamNames("id")
+		ectx.SetParamValues("3")
+		ctx := context.Background()
+
+		mockOrderService.EXPECT().Transition(ctx, model.TransitionOrderRequest{Status: model.OrderStatusReady}, 3).
+			Times(1).Return(nil, constant.ErrInvalidTransition)
+
+		err := orderController.HandleTransition()(ectx)
+		require.Equal(t, constant.ErrInvalidTransition, err)
+	})
+}
+
+func TestHTTP_handleFindOrders(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockOrderService := mock.NewMockOrderService(ctrl)
+	orderController := &orderController{
+		orderService: mockOrderService,
+	}
+
+	t.Run("find all", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodGet, "/orders?status=ready&page=1", nil)
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ctx := context.Background()
+
+		mockOrderService.EXPECT().FindAll(ctx, model.OrderQuery{Page: 1, Status: model.OrderStatusReady}).
+			Times(1).Return([]*model.Order{{Id: 3}}, &model.Pagination{Total: 11, Page: 1, Limit: 10}, nil)
+
+		err := orderController.HandleFindAll()(ectx)
+		require.NoError(t, err)
+
+		resBody := model.ResponseSuccess{}
+		err = json.NewDecoder(rec.Result().Body).Decode(&resBody)
+		require.NoError(t, err)
+		require.Equal(t, "/orders?page=2&status=ready", resBody.Meta.Next)
+	})
+
+	t.Run("find by id - not found", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodGet, "/orders/4", nil)
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ectx.SetParamNames("id")
+		ectx.SetParamValues("4")
+		ctx := context.Background()
+
+		mockOrderService.EXPECT().FindById(ctx, 4).Times(1).Return(nil, constant.ErrNotFound)
+
+		err := orderController.HandleFindById()(ectx)
+		require.Equal(t, constant.ErrNotFound, err)
+	})
+}
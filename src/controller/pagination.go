@@ -0,0 +1,38 @@
+package controller
+
+import (
+	"cake-store/src/model"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+// setPaginationLinks fill the next and prev links of the pagination based on the current request
+func setPaginationLinks(c echo.Context, pagination *model.Pagination) {
+	if pagination == nil {
+		return
+	}
+
+	if pagination.NextCursor != "" {
+		pagination.Next = link(c, "cursor", pagination.NextCursor)
+		return
+	}
+
+	if pagination.Page < 1 {
+		return
+	}
+	if int64(pagination.Page*pagination.Limit) < pagination.Total {
+		pagination.Next = link(c, "page", strconv.Itoa(pagination.Page+1))
+	}
+	if pagination.Page > 1 {
+		pagination.Prev = link(c, "page", strconv.Itoa(pagination.Page-1))
+	}
+}
+
+func link(c echo.Context, key, value string) string {
+	u := *c.Request().URL
+	q := u.Query()
+	q.Set(key, value)
+	u.RawQuery = q.Encode()
+	return u.RequestURI()
+}
@@ -0,0 +1,157 @@
+package controller
+
+import (
+	"cake-store/src/constant"
+	"cake-store/src/model"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	log "github.com/sirupsen/logrus"
+)
+
+type paymentController struct {
+	paymentService model.PaymentService
+}
+
+func NewPaymentController(paymentService model.PaymentService) model.PaymentController {
+	return &paymentController{
+		paymentService: paymentService,
+	}
+}
+
+// HandleAuthorize pay the order :id, a declined payment is returned with its reason
+func (pC *paymentController) HandleAuthorize() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req := model.AuthorizePaymentRequest{}
+		if err := c.Bind(&req); err != nil {
+			log.Error(err)
+			return constant.ErrInvalidArgument
+		}
+
+		orderId, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			log.Error(err)
+			return constant.ErrInvalidArgument
+		}
+
+		payment, err := pC.paymentService.Authorize(c.Request().Context(), req, orderId)
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+
+		return c.JSON(http.StatusOK, model.ResponseSuccess{
+			Success: true,
+			Data:    payment,
+		})
+	}
+}
+
+func (pC *paymentController) HandleCapture() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			log.Error(err)
+			return constant.ErrInvalidArgument
+		}
+
+		payment, err := pC.paymentService.Capture(c.Request().Context(), id)
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+
+		return c.JSON(http.StatusOK, model.ResponseSuccess{
+			Success: true,
+			Data:    payment,
+		})
+	}
+}
+
+func (pC *paymentController) HandleRefund() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			log.Error(err)
+			return constant.ErrInvalidArgument
+		}
+
+		payment, err := pC.paymentService.Refund(c.Request().Context(), id)
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+
+		return c.JSON(http.StatusOK, model.ResponseSuccess{
+			Success: true,
+			Data:    payment,
+		})
+	}
+}
+
+// HandleWebhook receive the notifications of the gateway, the signature is computed over the raw body
+func (pC *paymentController) HandleWebhook() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		payload, err := io.ReadAll(c.Request().Body)
+		if err != nil {
+			log.Error(err)
+			return constant.ErrInvalidArgument
+		}
+
+		payment, err := pC.paymentService.HandleWebhook(c.Request().Context(), payload, c.Request().Header.Get(model.PaymentSignatureHeader))
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+
+		return c.JSON(http.StatusOK, model.ResponseSuccess{
+			Success: true,
+			Data:    payment,
+		})
+	}
+}
+
+func (pC *paymentController) HandleFindById() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			log.Error(err)
+			return constant.ErrInvalidArgument
+		}
+
+		payment, err := pC.paymentService.FindById(c.Request().Context(), id)
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+
+		return c.JSON(http.StatusOK, model.ResponseSuccess{
+			Success: true,
+			Data:    payment,
+		})
+	}
+}
+
+// HandleFindByOrderId return the payment attempts of the order :id, the latest first
+func (pC *paymentController) HandleFindByOrderId() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		orderId, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			log.Error(err)
+			return constant.ErrInvalidArgument
+		}
+
+		payments, err := pC.paymentService.FindByOrderId(c.Request().Context(), orderId)
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+
+		return c.JSON(http.StatusOK, model.ResponseSuccess{
+			Success: true,
+			Data:    payments,
+		})
+	}
+}
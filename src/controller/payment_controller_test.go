@@ -0,0 +1,145 @@
+package controller
+
+import (
+	"cake-store/src/constant"
+	"cake-store/src/model"
+	"cake-store/src/model/mock"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTP_handleAuthorizePayment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPaymentService := mock.NewMockPaymentService(ctrl)
+	paymentController := &paymentController{
+		paymentService: mockPaymentService,
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodPost, "/orders/4/payments", strings.NewReader(`{"token":"tok_decline"}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ectx.SetParamNames("id")
+		ectx.SetParamValues("4")
+		ctx := context.Background()
+
+		mockPaymentService.EXPECT().Authorize(ctx, model.AuthorizePaymentRequest{Token: "tok_decline"}, 4).Times(1).
+			Return(&model.Payment{Id: 7, OrderId: 4, Status: model.PaymentStatusDeclined, Reason: "card declined"}, nil)
+
+		err := paymentController.HandleAuthorize()(ectx)
+		require.NoError(t, err)
+
+		resBody := map[string]interface{}{}
+		err = json.NewDecoder(rec.Result().Body).Decode(&resBody)
+		require.NoError(t, err)
+		data := resBody["data"].(map[string]interface{})
+		require.Equal(t, "declined", data["status"])
+		require.Equal(t, "card declined", data["reason"])
+	})
+
+	t.Run("already paid", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodPost, "/orders/4/payments", strings.NewReader(`{"token":"tok_success"}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ectx.SetParamNames("id")
+		ectx.SetParamValues("4")
+		ctx := context.Background()
+
+		mockPaymentService.EXPECT().Authorize(ctx, gomock.Any(), 4).Times(1).Return(nil, constant.ErrAlreadyPaid)
+
+		err := paymentController.HandleAuthorize()(ectx)
+		require.Equal(t, constant.ErrAlreadyPaid, err)
+	})
+
+	t.Run("invalid order id", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodPost, "/orders/abc/payments", strings.NewReader(`{}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ectx.SetParamNames("id")
+		ectx.SetParamValues("abc")
+
+		err := paymentController.HandleAuthorize()(ectx)
+		require.Equal(t, constant.ErrInvalidArgument, err)
+	})
+}
+
+func TestHTTP_handlePaymentWebhook(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPaymentService := mock.NewMockPaymentService(ctrl)
+	paymentController := &paymentController{
+		paymentService: mockPaymentService,
+	}
+
+	body := `{"reference":"fake_delayed_abc","status":"captured"}`
+
+	t.Run("ok", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodPost, "/payments/webhook", strings.NewReader(body))
+		req.Header.Set(model.PaymentSignatureHeader, "sig")
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ctx := context.Background()
+
+		mockPaymentService.EXPECT().HandleWebhook(ctx, []byte(body), "sig").Times(1).
+			Return(&model.Payment{Id: 8, Status: model.PaymentStatusCaptured}, nil)
+
+		err := paymentController.HandleWebhook()(ectx)
+		require.NoError(t, err)
+	})
+
+	t.Run("invalid signature", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodPost, "/payments/webhook", strings.NewReader(body))
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ctx := context.Background()
+
+		mockPaymentService.EXPECT().HandleWebhook(ctx, []byte(body), "").Times(1).Return(nil, constant.ErrInvalidSignature)
+
+		err := paymentController.HandleWebhook()(ectx)
+		require.Equal(t, constant.ErrInvalidSignature, err)
+	})
+}
+
+func TestHTTP_handleCapturePayment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPaymentService := mock.NewMockPaymentService(ctrl)
+	paymentController := &paymentController{
+		paymentService: mockPaymentService,
+	}
+
+	t.Run("invalid transition", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodPost, "/payments/7/capture", nil)
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ectx.SetParamNames("id")
+		ectx.SetParamValues("7")
+		ctx := context.Background()
+
+		mockPaymentService.EXPECT().Capture(ctx, 7).Times(1).Return(nil, constant.ErrInvalidTransition)
+
+		err := paymentController.HandleCapture()(ectx)
+		require.Equal(t, constant.ErrInvalidTransition, err)
+	})
+}
@@ -0,0 +1,111 @@
+package controller
+
+import (
+	"bytes"
+	"cake-store/src/constant"
+	"cake-store/src/model"
+	"cake-store/src/report"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	log "github.com/sirupsen/logrus"
+)
+
+type productionController struct {
+	productionService model.ProductionService
+}
+
+func NewProductionController(productionService model.ProductionService) model.ProductionController {
+	return &productionController{
+		productionService: productionService,
+	}
+}
+
+func (pC *productionController) HandleSetCakeProduction() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req := model.SetCakeProductionRequest{}
+		if err := c.Bind(&req); err != nil {
+			log.Error(err)
+			return constant.ErrInvalidArgument
+		}
+
+		cakeId, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			log.Error(err)
+			return constant.ErrInvalidArgument
+		}
+
+		production, err := pC.productionService.SetCakeProduction(c.Request().Context(), req, cakeId)
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+
+		return c.JSON(http.StatusOK, model.ResponseSuccess{
+			Success: true,
+			Data:    production,
+		})
+	}
+}
+
+func (pC *productionController) HandleFindCakeProduction() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		cakeId, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			log.Error(err)
+			return constant.ErrInvalidArgument
+		}
+
+		production, err := pC.productionService.FindCakeProduction(c.Request().Context(), cakeId)
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+
+		return c.JSON(http.StatusOK, model.ResponseSuccess{
+			Success: true,
+			Data:    production,
+		})
+	}
+}
+
+// HandlePlan respond the production plan as json, or as a csv or printable html document when the format say so
+func (pC *productionController) HandlePlan() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		query := model.ProductionQuery{}
+		if err := c.Bind(&query); err != nil {
+			log.Error(err)
+			return constant.ErrInvalidArgument
+		}
+
+		plan, err := pC.productionService.Plan(c.Request().Context(), query)
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+
+		switch query.Format {
+		case model.ProductionFormatCSV:
+			var buf bytes.Buffer
+			if err = report.ProductionCSV(&buf, plan); err != nil {
+				log.Error(err)
+				return err
+			}
+			c.Response().Header().Set(echo.HeaderContentDisposition, "attachment; filename=\"production-"+plan.Date+".csv\"")
+			return c.Blob(http.StatusOK, "text/csv; charset=utf-8", buf.Bytes())
+		case model.ProductionFormatHTML:
+			var buf bytes.Buffer
+			if err = report.ProductionHTML(&buf, plan); err != nil {
+				log.Error(err)
+				return err
+			}
+			return c.HTMLBlob(http.StatusOK, buf.Bytes())
+		}
+
+		return c.JSON(http.StatusOK, model.ResponseSuccess{
+			Success: true,
+			Data:    plan,
+		})
+	}
+}
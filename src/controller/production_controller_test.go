@@ -0,0 +1,192 @@
+package controller
+
+import (
+	"cake-store/src/constant"
+	"cake-store/src/model"
+	"cake-store/src/model/mock"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTP_handleSetCakeProduction(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockProductionService := mock.NewMockProductionService(ctrl)
+	productionController := &productionController{
+		productionService: mockProductionService,
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodPut, "/cakes/1/production", strings.NewReader(`{"prep_minutes":30,"bake_minutes":45,"batch_size":4}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ectx.SetParamNames("id")
+		ectx.SetParamValues("1")
+		ctx := context.Background()
+
+		mockProductionService.EXPECT().SetCakeProduction(ctx, model.SetCakeProductionRequest{PrepMinutes: 30, BakeMinutes: 45, BatchSize: 4}, 1).Times(1).
+			Return(&model.CakeProduction{CakeId: 1, PrepMinutes: 30, BakeMinutes: 45, BatchSize: 4}, nil)
+
+		err := productionController.HandleSetCakeProduction()(ectx)
+		require.NoError(t, err)
+
+		resBody := map[string]interface{}{}
+		err = json.NewDecoder(rec.Result().Body).Decode(&resBody)
+		require.NoError(t, err)
+		require.Equal(t, float64(4), resBody["data"].(map[string]interface{})["batch_size"])
+	})
+
+	t.Run("invalid id", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodPut, "/cakes/x/production", strings.NewReader(`{}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ectx.SetParamNames("id")
+		ectx.SetParamValues("x")
+
+		err := productionController.HandleSetCakeProduction()(ectx)
+		require.Equal(t, constant.ErrInvalidArgument, err)
+	})
+}
+
+func TestHTTP_handleFindCakeProduction(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockProductionService := mock.NewMockProductionService(ctrl)
+	productionController := &productionController{
+		productionService: mockProductionService,
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodGet, "/cakes/1/production", nil)
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ectx.SetParamNames("id")
+		ectx.SetParamValues("1")
+		ctx := context.Background()
+
+		mockProductionService.EXPECT().FindCakeProduction(ctx, 1).Times(1).
+			Return(&model.CakeProduction{CakeId: 1, PrepMinutes: 30, BakeMinutes: 45, BatchSize: 1, Default: true}, nil)
+
+		err := productionController.HandleFindCakeProduction()(ectx)
+		require.NoError(t, err)
+
+		resBody := map[string]interface{}{}
+		err = json.NewDecoder(rec.Result().Body).Decode(&resBody)
+		require.NoError(t, err)
+		require.Equal(t, true, resBody["data"].(map[string]interface{})["default"])
+	})
+
+	t.Run("handle error - service", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodGet, "/cakes/9/production", nil)
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ectx.SetParamNames("id")
+		ectx.SetParamValues("9")
+		ctx := context.Background()
+
+		mockProductionService.EXPECT().FindCakeProduction(ctx, 9).Times(1).Return(nil, constant.ErrNotFound)
+
+		err := productionController.HandleFindCakeProduction()(ectx)
+		require.Equal(t, constant.ErrNotFound, err)
+	})
+}
+
+func TestHTTP_handleProductionPlan(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockProductionService := mock.NewMockProductionService(ctrl)
+	productionController := &productionController{
+		productionService: mockProductionService,
+	}
+
+	start := time.Date(2026, 10, 20, 6, 0, 0, 0, time.UTC)
+	plan := &model.ProductionPlan{
+		Date:  "2026-10-20",
+		Lines: []*model.ProductionLine{{CakeId: 1, VariantId: 5, Title: "Black Forest", Size: "20cm", Ordered: 2, ToBake: 2, OrderIds: []int{3}}},
+		Batches: []*model.ProductionBatch{{Position: 1, CakeId: 1, VariantId: 5, Title: "Black Forest", Size: "20cm", Quantity: 2,
+			PrepStartAt: start, BakeStartAt: start.Add(30 * time.Minute), ReadyAt: start.Add(75 * time.Minute)}},
+		FinishAt: start.Add(75 * time.Minute),
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodGet, "/production/plan?date=2026-10-20", nil)
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ctx := context.Background()
+
+		mockProductionService.EXPECT().Plan(ctx, model.ProductionQuery{Date: "2026-10-20"}).Times(1).Return(plan, nil)
+
+		err := productionController.HandlePlan()(ectx)
+		require.NoError(t, err)
+
+		resBody := map[string]interface{}{}
+		err = json.NewDecoder(rec.Result().Body).Decode(&resBody)
+		require.NoError(t, err)
+		require.Len(t, resBody["data"].(map[string]interface{})["batches"], 1)
+	})
+
+	t.Run("ok - csv", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodGet, "/production/plan?date=2026-10-20&format=csv", nil)
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ctx := context.Background()
+
+		mockProductionService.EXPECT().Plan(ctx, model.ProductionQuery{Date: "2026-10-20", Format: model.ProductionFormatCSV}).Times(1).Return(plan, nil)
+
+		err := productionController.HandlePlan()(ectx)
+		require.NoError(t, err)
+		assert.Equal(t, "text/csv; charset=utf-8", rec.Header().Get(echo.HeaderContentType))
+		assert.Contains(t, rec.Header().Get(echo.HeaderContentDisposition), "production-2026-10-20.csv")
+		assert.Contains(t, rec.Body.String(), "2026-10-20,1,1,5,Black Forest,20cm,2,06:00,06:30,07:15")
+	})
+
+	t.Run("ok - html", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodGet, "/production/plan?format=html", nil)
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ctx := context.Background()
+
+		mockProductionService.EXPECT().Plan(ctx, model.ProductionQuery{Format: model.ProductionFormatHTML}).Times(1).Return(plan, nil)
+
+		err := productionController.HandlePlan()(ectx)
+		require.NoError(t, err)
+		assert.Contains(t, rec.Header().Get(echo.HeaderContentType), echo.MIMETextHTML)
+		assert.Contains(t, rec.Body.String(), "Bake schedule 2026-10-20")
+	})
+
+	t.Run("handle error - service", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodGet, "/production/plan", nil)
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ctx := context.Background()
+
+		mockProductionService.EXPECT().Plan(ctx, model.ProductionQuery{}).Times(1).Return(nil, errors.New("err db"))
+
+		err := productionController.HandlePlan()(ectx)
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,97 @@
+package controller
+
+import (
+	"cake-store/src/constant"
+	"cake-store/src/model"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	log "github.com/sirupsen/logrus"
+)
+
+type recipeController struct {
+	recipeService model.RecipeService
+}
+
+func NewRecipeController(recipeService model.RecipeService) model.RecipeController {
+	return &recipeController{
+		recipeService: recipeService,
+	}
+}
+
+func (rC *recipeController) HandleSave() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req := model.SaveRecipeRequest{}
+		if err := c.Bind(&req); err != nil {
+			log.Error(err)
+			return constant.ErrInvalidArgument
+		}
+
+		cakeId, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			log.Error(err)
+			return constant.ErrInvalidArgument
+		}
+
+		recipe, err := rC.recipeService.Save(c.Request().Context(), req, cakeId)
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+
+		return c.JSON(http.StatusOK, model.ResponseSuccess{
+			Success: true,
+			Data:    recipe,
+		})
+	}
+}
+
+func (rC *recipeController) HandleDelete() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		cakeId, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			log.Error(err)
+			return constant.ErrInvalidArgument
+		}
+
+		recipe, err := rC.recipeService.Delete(c.Request().Context(), cakeId)
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+
+		return c.JSON(http.StatusOK, model.ResponseSuccess{
+			Success: true,
+			Data:    recipe,
+		})
+	}
+}
+
+// HandleFindByCakeId return the recipe of the cake, scaled by ?servings=N and priced in ?currency=
+func (rC *recipeController) HandleFindByCakeId() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		query := model.RecipeQuery{}
+		if err := c.Bind(&query); err != nil {
+			log.Error(err)
+			return constant.ErrInvalidArgument
+		}
+
+		cakeId, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			log.Error(err)
+			return constant.ErrInvalidArgument
+		}
+
+		recipe, err := rC.recipeService.FindByCakeId(c.Request().Context(), query, cakeId)
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+
+		return c.JSON(http.StatusOK, model.ResponseSuccess{
+			Success: true,
+			Data:    recipe,
+		})
+	}
+}
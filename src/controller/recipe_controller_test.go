@@ -0,0 +1,149 @@
+package controller
+
+import (
+	"cake-store/src/constant"
+	"cake-store/src/model"
+	"cake-store/src/model/mock"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTP_handleFindRecipe(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRecipeService := mock.NewMockRecipeService(ctrl)
+	recipeController := &recipeController{
+		recipeService: mockRecipeService,
+	}
+
+	recipe := &model.Recipe{Id: 4, CakeId: 1, Yield: 8, Servings: 16}
+
+	t.Run("ok", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodGet, "/cakes/1/recipe?servings=16&currency=USD", nil)
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ectx.SetParamNames("id")
+		ectx.SetParamValues("1")
+		ctx := context.Background()
+
+		mockRecipeService.EXPECT().FindByCakeId(ctx, model.RecipeQuery{Servings: 16, Currency: "USD"}, 1).Times(1).Return(recipe, nil)
+
+		err := recipeController.HandleFindByCakeId()(ectx)
+		require.NoError(t, err)
+
+		resBody := map[string]interface{}{}
+		err = json.NewDecoder(rec.Result().Body).Decode(&resBody)
+		require.NoError(t, err)
+		require.EqualValues(t, 16, resBody["data"].(map[string]interface{})["servings"])
+	})
+
+	t.Run("handle error - invalid servings", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodGet, "/cakes/1/recipe?servings=many", nil)
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ectx.SetParamNames("id")
+		ectx.SetParamValues("1")
+
+		err := recipeController.HandleFindByCakeId()(ectx)
+		require.Equal(t, constant.ErrInvalidArgument, err)
+	})
+
+	t.Run("handle error - not found", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodGet, "/cakes/2/recipe", nil)
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ectx.SetParamNames("id")
+		ectx.SetParamValues("2")
+		ctx := context.Background()
+
+		mockRecipeService.EXPECT().FindByCakeId(ctx, model.RecipeQuery{}, 2).Times(1).Return(nil, constant.ErrNotFound)
+
+		err := recipeController.HandleFindByCakeId()(ectx)
+		require.Equal(t, constant.ErrNotFound, err)
+	})
+}
+
+func TestHTTP_handleSaveRecipe(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRecipeService := mock.NewMockRecipeService(ctrl)
+	recipeController := &recipeController{
+		recipeService: mockRecipeService,
+	}
+
+	body := `{"yield":8,"steps":[{"instruction":"Bake","minutes":35}],"ingredients":[{"ingredient_id":2,"quantity":250,"unit":"g"}]}`
+	saveReq := model.SaveRecipeRequest{
+		Yield:       8,
+		Steps:       []model.RecipeStepRequest{{Instruction: "Bake", Minutes: 35}},
+		Ingredients: []model.RecipeIngredientRequest{{IngredientId: 2, Quantity: 250, Unit: "g"}},
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodPut, "/cakes/1/recipe", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ectx.SetParamNames("id")
+		ectx.SetParamValues("1")
+		ctx := context.Background()
+
+		mockRecipeService.EXPECT().Save(ctx, saveReq, 1).Times(1).Return(&model.Recipe{Id: 4, CakeId: 1}, nil)
+
+		err := recipeController.HandleSave()(ectx)
+		require.NoError(t, err)
+		require.EqualValues(t, http.StatusOK, rec.Result().StatusCode)
+	})
+
+	t.Run("handle error - invalid id", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodPut, "/cakes/x/recipe", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ectx.SetParamNames("id")
+		ectx.SetParamValues("x")
+
+		err := recipeController.HandleSave()(ectx)
+		require.Equal(t, constant.ErrInvalidArgument, err)
+	})
+}
+
+func TestHTTP_handleDeleteRecipe(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRecipeService := mock.NewMockRecipeService(ctrl)
+	recipeController := &recipeController{
+		recipeService: mockRecipeService,
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodDelete, "/cakes/1/recipe", nil)
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ectx.SetParamNames("id")
+		ectx.SetParamValues("1")
+		ctx := context.Background()
+
+		mockRecipeService.EXPECT().Delete(ctx, 1).Times(1).Return(&model.Recipe{Id: 4}, nil)
+
+		err := recipeController.HandleDelete()(ectx)
+		require.NoError(t, err)
+		require.EqualValues(t, http.StatusOK, rec.Result().StatusCode)
+	})
+}
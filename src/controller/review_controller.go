@@ -0,0 +1,223 @@
+package controller
+
+import (
+	"cake-store/src/auth"
+	"cake-store/src/constant"
+	"cake-store/src/model"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	log "github.com/sirupsen/logrus"
+)
+
+type reviewController struct {
+	reviewService model.ReviewService
+}
+
+func NewReviewController(reviewService model.ReviewService) model.ReviewController {
+	return &reviewController{
+		reviewService: reviewService,
+	}
+}
+
+func (rC *reviewController) HandleCreate() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req := model.CreateUpdateReviewRequest{}
+		if err := c.Bind(&req); err != nil {
+			log.Error(err)
+			return constant.ErrInvalidArgument
+		}
+
+		cakeId, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			log.Error(err)
+			return constant.ErrInvalidArgument
+		}
+
+		create, err := rC.reviewService.Create(c.Request().Context(), req, cakeId)
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+
+		return c.JSON(http.StatusOK, model.ResponseSuccess{
+			Success: true,
+			Data:    create,
+		})
+	}
+}
+
+// HandleFindAll list the approved reviews of the cake, an admin can list the reviews of any status
+func (rC *reviewController) HandleFindAll() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		query := model.ReviewQuery{}
+		if err := c.Bind(&query); err != nil {
+			log.Error(err)
+			return constant.ErrInvalidArgument
+		}
+
+		if !auth.IsAdmin(c) {
+			query.Status = model.ReviewStatusApproved
+		}
+
+		cakeId, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			log.Error(err)
+			return constant.ErrInvalidArgument
+		}
+
+		reviews, pagination, err := rC.reviewService.FindAll(c.Request().Context(), query, cakeId)
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+
+		setPaginationLinks(c, pagination)
+		return c.JSON(http.StatusOK, model.ResponseSuccess{
+			Success: true,
+			Data:    reviews,
+			Meta:    pagination,
+		})
+	}
+}
+
+func (rC *reviewController) HandleFindById() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		cakeId, reviewId, err := reviewParams(c)
+		if err != nil {
+			log.Error(err)
+			return constant.ErrInvalidArgument
+		}
+
+		review, err := rC.reviewService.FindById(c.Request().Context(), cakeId, reviewId)
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+
+		// an unmoderated review is not public yet
+		if review.Status != model.ReviewStatusApproved && !auth.IsAdmin(c) {
+			log.Error(constant.ErrNotFound)
+			return constant.ErrNotFound
+		}
+
+		return c.JSON(http.StatusOK, model.ResponseSuccess{
+			Success: true,
+			Data:    review,
+		})
+	}
+}
+
+func (rC *reviewController) HandleUpdate() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req := model.CreateUpdateReviewRequest{}
+		if err := c.Bind(&req); err != nil {
+			log.Error(err)
+			return constant.ErrInvalidArgument
+		}
+
+		cakeId, reviewId, err := reviewParams(c)
+		if err != nil {
+			log.Error(err)
+			return constant.ErrInvalidArgument
+		}
+
+		update, err := rC.reviewService.Update(c.Request().Context(), req, cakeId, reviewId)
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+
+		return c.JSON(http.StatusOK, model.ResponseSuccess{
+			Success: true,
+			Data:    update,
+		})
+	}
+}
+
+func (rC *reviewController) HandleDelete() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		cakeId, reviewId, err := reviewParams(c)
+		if err != nil {
+			log.Error(err)
+			return constant.ErrInvalidArgument
+		}
+
+		review, err := rC.reviewService.Delete(c.Request().Context(), cakeId, reviewId)
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+
+		return c.JSON(http.StatusOK, model.ResponseSuccess{
+			Success: true,
+			Data:    review,
+		})
+	}
+}
+
+func (rC *reviewController) HandleModerate() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req := model.ModerateReviewRequest{}
+		if err := c.Bind(&req); err != nil {
+			log.Error(err)
+			return constant.ErrInvalidArgument
+		}
+
+		reviewId, err := strconv.Atoi(c.Param("reviewId"))
+		if err != nil {
+			log.Error(err)
+			return constant.ErrInvalidArgument
+		}
+
+		review, err := rC.reviewService.Moderate(c.Request().Context(), req, reviewId)
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+
+		return c.JSON(http.StatusOK, model.ResponseSuccess{
+			Success: true,
+			Data:    review,
+		})
+	}
+}
+
+// HandleFindModeration list the moderation queue of every cake, the pending reviews unless the query ask another status
+func (rC *reviewController) HandleFindModeration() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		query := model.ReviewQuery{}
+		if err := c.Bind(&query); err != nil {
+			log.Error(err)
+			return constant.ErrInvalidArgument
+		}
+
+		reviews, pagination, err := rC.reviewService.FindModeration(c.Request().Context(), query)
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+
+		setPaginationLinks(c, pagination)
+		return c.JSON(http.StatusOK, model.ResponseSuccess{
+			Success: true,
+			Data:    reviews,
+			Meta:    pagination,
+		})
+	}
+}
+
+func reviewParams(c echo.Context) (int, int, error) {
+	cakeId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return 0, 0, err
+	}
+
+	reviewId, err := strconv.Atoi(c.Param("reviewId"))
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return cakeId, reviewId, nil
+}
@@ -0,0 +1,242 @@
+package controller
+
+import (
+	"cake-store/src/auth"
+	"cake-store/src/constant"
+	"cake-store/src/model"
+	"cake-store/src/model/mock"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTP_handleCreateReview(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockReviewService := mock.NewMockReviewService(ctrl)
+	reviewController := &reviewController{
+		reviewService: mockReviewService,
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodPost, "/cakes/1/reviews", strings.NewReader(`
+		{
+            "score": 9,
+            "text":"Lembut dan tidak terlalu manis",
+            "author":"Sari"
+		}`,
+		))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ectx.SetParamNames("id")
+		ectx.SetParamValues("1")
+		ctx := context.Background()
+
+		mockReviewService.EXPECT().Create(ctx, model.CreateUpdateReviewRequest{
+			Score:  9,
+			Text:   "Lembut dan tidak terlalu manis",
+			Author: "Sari",
+		}, 1).Times(1).Return(&model.Review{Id: 3, CakeId: 1, Score: 9}, nil)
+
+		err := reviewController.HandleCreate()(ectx)
+		require.NoError(t, err)
+		require.EqualValues(t, http.StatusOK, rec.Result().StatusCode)
+	})
+
+	t.Run("handle error - invalid cake id", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodPost, "/cakes/abc/reviews", strings.NewReader(`{"score": 9}`))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ectx.SetParamNames("id")
+		ectx.SetParamValues("abc")
+
+		err := reviewController.HandleCreate()(ectx)
+		require.Equal(t, constant.ErrInvalidArgument, err)
+	})
+}
+
+func TestHTTP_handleUpdateReview(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockReviewService := mock.NewMockReviewService(ctrl)
+	reviewController := &reviewController{
+		reviewService: mockReviewService,
+	}
+
+	t.Run("handle error - review of another cake", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodPut, "/cakes/2/reviews/3", strings.NewReader(`{"score": 7, "author": "Sari"}`))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ectx.SetParamNames("id", "reviewId")
+		ectx.SetParamValues("2", "3")
+		ctx := context.Background()
+
+		mockReviewService.EXPECT().Update(ctx, model.CreateUpdateReviewRequest{Score: 7, Author: "Sari"}, 2, 3).Times(1).Return(nil, constant.ErrNotFound)
+
+		err := reviewController.HandleUpdate()(ectx)
+		require.Equal(t, constant.ErrNotFound, err)
+	})
+}
+
+func TestHTTP_handleDeleteReview(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockReviewService := mock.NewMockReviewService(ctrl)
+	reviewController := &reviewController{
+		reviewService: mockReviewService,
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodDelete, "/cakes/1/reviews/3", nil)
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ectx.SetParamNames("id", "reviewId")
+		ectx.SetParamValues("1", "3")
+		ctx := context.Background()
+
+		mockReviewService.EXPECT().Delete(ctx, 1, 3).Times(1).Return(&model.Review{Id: 3, CakeId: 1}, nil)
+
+		err := reviewController.HandleDelete()(ectx)
+		require.NoError(t, err)
+		require.EqualValues(t, http.StatusOK, rec.Result().StatusCode)
+	})
+}
+
+func TestHTTP_handleFindReview(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockReviewService := mock.NewMockReviewService(ctrl)
+	reviewController := &reviewController{
+		reviewService: mockReviewService,
+	}
+
+	t.Run("ok - find all as admin", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodGet, "/cakes/1/reviews?status=flagged", nil)
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ectx.SetParamNames("id")
+		ectx.SetParamValues("1")
+		ectx.Set(auth.ContextKeyAdmin, true)
+		ctx := context.Background()
+
+		mockReviewService.EXPECT().FindAll(ctx, model.ReviewQuery{Status: model.ReviewStatusFlagged}, 1).
+			Times(1).Return([]*model.Review{}, &model.Pagination{Total: 0, Page: 1, Limit: 10}, nil)
+
+		err := reviewController.HandleFindAll()(ectx)
+		require.NoError(t, err)
+	})
+
+	t.Run("ok - find all", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodGet, "/cakes/1/reviews?page=1", nil)
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ectx.SetParamNames("id")
+		ectx.SetParamValues("1")
+		ctx := context.Background()
+
+		mockReviewService.EXPECT().FindAll(ctx, model.ReviewQuery{Page: 1, Status: model.ReviewStatusApproved}, 1).
+			Times(1).Return([]*model.Review{{Id: 3, CakeId: 1}}, &model.Pagination{Total: 11, Page: 1, Limit: 10}, nil)
+
+		err := reviewController.HandleFindAll()(ectx)
+		require.NoError(t, err)
+
+		resBody := model.ResponseSuccess{}
+		err = json.NewDecoder(rec.Result().Body).Decode(&resBody)
+		require.NoError(t, err)
+		require.Equal(t, "/cakes/1/reviews?page=2", resBody.Meta.Next)
+	})
+
+	t.Run("ok - find by id", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodGet, "/cakes/1/reviews/3", nil)
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ectx.SetParamNames("id", "reviewId")
+		ectx.SetParamValues("1", "3")
+		ctx := context.Background()
+
+		mockReviewService.EXPECT().FindById(ctx, 1, 3).Times(1).Return(&model.Review{Id: 3, CakeId: 1, Status: model.ReviewStatusApproved}, nil)
+
+		err := reviewController.HandleFindById()(ectx)
+		require.NoError(t, err)
+		require.EqualValues(t, http.StatusOK, rec.Result().StatusCode)
+	})
+
+	t.Run("handle error - pending review is not public", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodGet, "/cakes/1/reviews/4", nil)
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ectx.SetParamNames("id", "reviewId")
+		ectx.SetParamValues("1", "4")
+		ctx := context.Background()
+
+		mockReviewService.EXPECT().FindById(ctx, 1, 4).Times(1).Return(&model.Review{Id: 4, CakeId: 1, Status: model.ReviewStatusPending}, nil)
+
+		err := reviewController.HandleFindById()(ectx)
+		require.Equal(t, constant.ErrNotFound, err)
+	})
+}
+
+func TestHTTP_handleModerateReview(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockReviewService := mock.NewMockReviewService(ctrl)
+	reviewController := &reviewController{
+		reviewService: mockReviewService,
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodPost, "/reviews/3/status", strings.NewReader(`{"status":"approved","note":"checked"}`))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ectx.SetParamNames("reviewId")
+		ectx.SetParamValues("3")
+		ctx := context.Background()
+
+		mockReviewService.EXPECT().Moderate(ctx, model.ModerateReviewRequest{Status: model.ReviewStatusApproved, Note: "checked"}, 3).
+			Times(1).Return(&model.Review{Id: 3, Status: model.ReviewStatusApproved}, nil)
+
+		err := reviewController.HandleModerate()(ectx)
+		require.NoError(t, err)
+		require.EqualValues(t, http.StatusOK, rec.Result().StatusCode)
+	})
+
+	t.Run("queue", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodGet, "/reviews?status=flagged", nil)
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ctx := context.Background()
+
+		mockReviewService.EXPECT().FindModeration(ctx, model.ReviewQuery{Status: model.ReviewStatusFlagged}).
+			Times(1).Return([]*model.Review{{Id: 3}}, &model.Pagination{Total: 1, Page: 1, Limit: 10}, nil)
+
+		err := reviewController.HandleFindModeration()(ectx)
+		require.NoError(t, err)
+		require.EqualValues(t, http.StatusOK, rec.Result().StatusCode)
+	})
+}
@@ -0,0 +1,161 @@
+package controller
+
+import (
+	"cake-store/src/constant"
+	"cake-store/src/model"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	log "github.com/sirupsen/logrus"
+)
+
+type slotController struct {
+	slotService model.SlotService
+}
+
+func NewSlotController(slotService model.SlotService) model.SlotController {
+	return &slotController{
+		slotService: slotService,
+	}
+}
+
+// HandleAvailability return the pickup slots of the ?store_id= on ?date=, the default store and today when empty,
+// with what is left of their capacity
+func (sC *slotController) HandleAvailability() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		query := model.SlotQuery{}
+		if err := c.Bind(&query); err != nil {
+			log.Error(err)
+			return constant.ErrInvalidArgument
+		}
+
+		day, err := sC.slotService.Availability(c.Request().Context(), query)
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+
+		return c.JSON(http.StatusOK, model.ResponseSuccess{
+			Success: true,
+			Data:    day,
+		})
+	}
+}
+
+func (sC *slotController) HandleFindOpeningHours() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		storeId, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			log.Error(err)
+			return constant.ErrInvalidArgument
+		}
+
+		hours, err := sC.slotService.FindOpeningHours(c.Request().Context(), storeId)
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+
+		return c.JSON(http.StatusOK, model.ResponseSuccess{
+			Success: true,
+			Data:    hours,
+		})
+	}
+}
+
+func (sC *slotController) HandleSetOpeningHours() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		storeId, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			log.Error(err)
+			return constant.ErrInvalidArgument
+		}
+
+		req := model.SetOpeningHoursRequest{}
+		if err := c.Bind(&req); err != nil {
+			log.Error(err)
+			return constant.ErrInvalidArgument
+		}
+
+		hours, err := sC.slotService.SetOpeningHours(c.Request().Context(), req, storeId)
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+
+		return c.JSON(http.StatusOK, model.ResponseSuccess{
+			Success: true,
+			Data:    hours,
+		})
+	}
+}
+
+func (sC *slotController) HandleFindClosures() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		storeId, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			log.Error(err)
+			return constant.ErrInvalidArgument
+		}
+
+		closures, err := sC.slotService.FindClosures(c.Request().Context(), storeId)
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+
+		return c.JSON(http.StatusOK, model.ResponseSuccess{
+			Success: true,
+			Data:    closures,
+		})
+	}
+}
+
+func (sC *slotController) HandleCreateClosure() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		storeId, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			log.Error(err)
+			return constant.ErrInvalidArgument
+		}
+
+		req := model.CreateClosureRequest{}
+		if err := c.Bind(&req); err != nil {
+			log.Error(err)
+			return constant.ErrInvalidArgument
+		}
+
+		closure, err := sC.slotService.CreateClosure(c.Request().Context(), req, storeId)
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+
+		return c.JSON(http.StatusOK, model.ResponseSuccess{
+			Success: true,
+			Data:    closure,
+		})
+	}
+}
+
+func (sC *slotController) HandleDeleteClosure() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		storeId, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			log.Error(err)
+			return constant.ErrInvalidArgument
+		}
+
+		closure, err := sC.slotService.DeleteClosure(c.Request().Context(), storeId, c.Param("date"))
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+
+		return c.JSON(http.StatusOK, model.ResponseSuccess{
+			Success: true,
+			Data:    closure,
+		})
+	}
+}
@@ -0,0 +1,195 @@
+package controller
+
+import (
+	"cake-store/src/constant"
+	"cake-store/src/model"
+	"cake-store/src/model/mock"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTP_handleSlotAvailability(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSlotService := mock.NewMockSlotService(ctrl)
+	slotController := &slotController{
+		slotService: mockSlotService,
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodGet, "/slots?store_id=2&date=2026-10-20", nil)
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ctx := context.Background()
+
+		mockSlotService.EXPECT().Availability(ctx, model.SlotQuery{StoreId: 2, Date: "2026-10-20"}).Times(1).
+			Return(&model.DaySlots{StoreId: 2, Date: "2026-10-20", Slots: []*model.Slot{{Capacity: 10, Booked: 4, Available: 6}}}, nil)
+
+		err := slotController.HandleAvailability()(ectx)
+		require.NoError(t, err)
+
+		resBody := map[string]interface{}{}
+		err = json.NewDecoder(rec.Result().Body).Decode(&resBody)
+		require.NoError(t, err)
+		data := resBody["data"].(map[string]interface{})
+		require.Equal(t, "2026-10-20", data["date"])
+		require.Len(t, data["slots"], 1)
+	})
+
+	t.Run("invalid date", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodGet, "/slots?date=tomorrow", nil)
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ctx := context.Background()
+
+		mockSlotService.EXPECT().Availability(ctx, model.SlotQuery{Date: "tomorrow"}).Times(1).Return(nil, constant.ErrInvalidArgument)
+
+		err := slotController.HandleAvailability()(ectx)
+		require.Equal(t, constant.ErrInvalidArgument, err)
+	})
+}
+
+func TestHTTP_handleSetOpeningHours(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSlotService := mock.NewMockSlotService(ctrl)
+	slotController := &slotController{
+		slotService: mockSlotService,
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		ec := echo.New()
+		body := `{"days":[{"weekday":1,"opens_at":"08:00","closes_at":"17:00","slot_capacity":10}]}`
+		req := httptest.NewRequest(http.MethodPut, "/stores/1/hours", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ectx.SetParamNames("id")
+		ectx.SetParamValues("1")
+		ctx := context.Background()
+
+		mockSlotService.EXPECT().SetOpeningHours(ctx, gomock.Any(), 1).Times(1).
+			DoAndReturn(func(_ context.Context, req model.SetOpeningHoursRequest, _ int) ([]*model.OpeningHours, error) {
+				require.Len(t, req.Days, 1)
+				require.Equal(t, "08:00", req.Days[0].OpensAt)
+				return []*model.OpeningHours{{Weekday: 1, OpensAt: "08:00", ClosesAt: "17:00", SlotCapacity: 10}}, nil
+			})
+
+		err := slotController.HandleSetOpeningHours()(ectx)
+		require.NoError(t, err)
+
+		resBody := map[string]interface{}{}
+		err = json.NewDecoder(rec.Result().Body).Decode(&resBody)
+		require.NoError(t, err)
+		require.Len(t, resBody["data"], 1)
+	})
+
+	t.Run("invalid body", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodPut, "/stores/1/hours", strings.NewReader(`{"days":`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ectx.SetParamNames("id")
+		ectx.SetParamValues("1")
+
+		err := slotController.HandleSetOpeningHours()(ectx)
+		require.Equal(t, constant.ErrInvalidArgument, err)
+	})
+}
+
+func TestHTTP_handleCreateClosure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSlotService := mock.NewMockSlotService(ctrl)
+	slotController := &slotController{
+		slotService: mockSlotService,
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		ec := echo.New()
+		body := `{"date":"2026-12-25","reason":"Christmas"}`
+		req := httptest.NewRequest(http.MethodPost, "/stores/1/closures", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ectx.SetParamNames("id")
+		ectx.SetParamValues("1")
+		ctx := context.Background()
+
+		mockSlotService.EXPECT().CreateClosure(ctx, model.CreateClosureRequest{Date: "2026-12-25", Reason: "Christmas"}, 1).Times(1).
+			Return(&model.Closure{Date: "2026-12-25", Reason: "Christmas"}, nil)
+
+		err := slotController.HandleCreateClosure()(ectx)
+		require.NoError(t, err)
+	})
+
+	t.Run("already closed", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodPost, "/stores/1/closures", strings.NewReader(`{"date":"2026-12-25"}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ectx.SetParamNames("id")
+		ectx.SetParamValues("1")
+		ctx := context.Background()
+
+		mockSlotService.EXPECT().CreateClosure(ctx, gomock.Any(), 1).Times(1).Return(nil, constant.ErrAlreadyExists)
+
+		err := slotController.HandleCreateClosure()(ectx)
+		require.Equal(t, constant.ErrAlreadyExists, err)
+	})
+}
+
+func TestHTTP_handleDeleteClosure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSlotService := mock.NewMockSlotService(ctrl)
+	slotController := &slotController{
+		slotService: mockSlotService,
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodDelete, "/stores/1/closures/2026-12-25", nil)
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ectx.SetParamNames("id", "date")
+		ectx.SetParamValues("1", "2026-12-25")
+		ctx := context.Background()
+
+		mockSlotService.EXPECT().DeleteClosure(ctx, 1, "2026-12-25").Times(1).Return(&model.Closure{Date: "2026-12-25"}, nil)
+
+		err := slotController.HandleDeleteClosure()(ectx)
+		require.NoError(t, err)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodDelete, "/stores/1/closures/2026-12-26", nil)
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ectx.SetParamNames("id", "date")
+		ectx.SetParamValues("1", "2026-12-26")
+		ctx := context.Background()
+
+		mockSlotService.EXPECT().DeleteClosure(ctx, 1, "2026-12-26").Times(1).Return(nil, constant.ErrNotFound)
+
+		err := slotController.HandleDeleteClosure()(ectx)
+		require.Equal(t, constant.ErrNotFound, err)
+	})
+}
@@ -0,0 +1,189 @@
+package controller
+
+import (
+	"cake-store/src/constant"
+	"cake-store/src/model"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	log "github.com/sirupsen/logrus"
+)
+
+type stockController struct {
+	stockService model.StockService
+}
+
+func NewStockController(stockService model.StockService) model.StockController {
+	return &stockController{
+		stockService: stockService,
+	}
+}
+
+func (sC *stockController) HandleFindByCakeId() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		cakeId, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			log.Error(err)
+			return constant.ErrInvalidArgument
+		}
+
+		stocks, err := sC.stockService.FindByCakeId(c.Request().Context(), cakeId)
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+
+		return c.JSON(http.StatusOK, model.ResponseSuccess{
+			Success: true,
+			Data:    stocks,
+		})
+	}
+}
+
+func (sC *stockController) HandleFindLow() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		stocks, err := sC.stockService.FindLow(c.Request().Context())
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+
+		return c.JSON(http.StatusOK, model.ResponseSuccess{
+			Success: true,
+			Data:    stocks,
+		})
+	}
+}
+
+func (sC *stockController) HandleAdjust() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req := model.AdjustStockRequest{}
+		if err := c.Bind(&req); err != nil {
+			log.Error(err)
+			return constant.ErrInvalidArgument
+		}
+
+		cakeId, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			log.Error(err)
+			return constant.ErrInvalidArgument
+		}
+
+		stock, err := sC.stockService.Adjust(c.Request().Context(), req, cakeId)
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+
+		return c.JSON(http.StatusOK, model.ResponseSuccess{
+			Success: true,
+			Data:    stock,
+		})
+	}
+}
+
+func (sC *stockController) HandleSetThreshold() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req := model.SetStockThresholdRequest{}
+		if err := c.Bind(&req); err != nil {
+			log.Error(err)
+			return constant.ErrInvalidArgument
+		}
+
+		cakeId, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			log.Error(err)
+			return constant.ErrInvalidArgument
+		}
+
+		stock, err := sC.stockService.SetThreshold(c.Request().Context(), req, cakeId)
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+
+		return c.JSON(http.StatusOK, model.ResponseSuccess{
+			Success: true,
+			Data:    stock,
+		})
+	}
+}
+
+func (sC *stockController) HandleFindAdjustments() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		cakeId, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			log.Error(err)
+			return constant.ErrInvalidArgument
+		}
+
+		adjustments, err := sC.stockService.FindAdjustments(c.Request().Context(), cakeId)
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+
+		return c.JSON(http.StatusOK, model.ResponseSuccess{
+			Success: true,
+			Data:    adjustments,
+		})
+	}
+}
+
+func (sC *stockController) HandleReserve() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req := model.ReserveStockRequest{}
+		if err := c.Bind(&req); err != nil {
+			log.Error(err)
+			return constant.ErrInvalidArgument
+		}
+
+		cakeId, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			log.Error(err)
+			return constant.ErrInvalidArgument
+		}
+
+		reservation, err := sC.stockService.Reserve(c.Request().Context(), req, cakeId)
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+
+		return c.JSON(http.StatusOK, model.ResponseSuccess{
+			Success: true,
+			Data:    reservation,
+		})
+	}
+}
+
+func (sC *stockController) HandleRelease() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		reservation, err := sC.stockService.Release(c.Request().Context(), c.Param("reservationId"))
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+
+		return c.JSON(http.StatusOK, model.ResponseSuccess{
+			Success: true,
+			Data:    reservation,
+		})
+	}
+}
+
+func (sC *stockController) HandleCommit() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		reservation, err := sC.stockService.Commit(c.Request().Context(), c.Param("reservationId"))
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+
+		return c.JSON(http.StatusOK, model.ResponseSuccess{
+			Success: true,
+			Data:    reservation,
+		})
+	}
+}
@@ -0,0 +1,187 @@
+package controller
+
+import (
+	"cake-store/src/constant"
+	"cake-store/src/model"
+	"cake-store/src/model/mock"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTP_handleFindStockByCakeId(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStockService := mock.NewMockStockService(ctrl)
+	stockController := &stockController{
+		stockService: mockStockService,
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodGet, "/cakes/1/stock", nil)
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ectx.SetParamNames("id")
+		ectx.SetParamValues("1")
+		ctx := context.Background()
+
+		stocks := []*model.Stock{{Id: 1, CakeId: 1, OnHand: 5, Available: 5}}
+		mockStockService.EXPECT().FindByCakeId(ctx, 1).Times(1).Return(stocks, nil)
+
+		err := stockController.HandleFindByCakeId()(ectx)
+		require.NoError(t, err)
+
+		resBody := map[string]interface{}{}
+		err = json.NewDecoder(rec.Result().Body).Decode(&resBody)
+		require.NoError(t, err)
+		require.EqualValues(t, http.StatusOK, rec.Result().StatusCode)
+	})
+
+	t.Run("handle error - invalid id", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodGet, "/cakes/abc/stock", nil)
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ectx.SetParamNames("id")
+		ectx.SetParamValues("abc")
+
+		err := stockController.HandleFindByCakeId()(ectx)
+		require.Equal(t, constant.ErrInvalidArgument, err)
+	})
+}
+
+func TestHTTP_handleAdjustStock(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStockService := mock.NewMockStockService(ctrl)
+	stockController := &stockController{
+		stockService: mockStockService,
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodPost, "/cakes/1/stock/adjustments", strings.NewReader(`
+		{
+            "variant_id": 5,
+            "delta": 10,
+            "reason": "restock",
+            "note": "morning batch"
+		}`,
+		))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ectx.SetParamNames("id")
+		ectx.SetParamValues("1")
+		ctx := context.Background()
+
+		mockStockService.EXPECT().Adjust(ctx, model.AdjustStockRequest{
+			VariantId: 5,
+			Delta:     10,
+			Reason:    model.StockReasonRestock,
+			Note:      "morning batch",
+		}, 1).Times(1).Return(&model.Stock{CakeId: 1, VariantId: 5, OnHand: 10}, nil)
+
+		err := stockController.HandleAdjust()(ectx)
+		require.NoError(t, err)
+		require.EqualValues(t, http.StatusOK, rec.Result().StatusCode)
+	})
+
+	t.Run("handle error - insufficient stock", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodPost, "/cakes/1/stock/adjustments", strings.NewReader(`{"delta":-10,"reason":"waste"}`))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ectx.SetParamNames("id")
+		ectx.SetParamValues("1")
+		ctx := context.Background()
+
+		mockStockService.EXPECT().Adjust(ctx, model.AdjustStockRequest{Delta: -10, Reason: model.StockReasonWaste}, 1).
+			Times(1).Return(nil, constant.ErrInsufficientStock)
+
+		err := stockController.HandleAdjust()(ectx)
+		require.Equal(t, constant.ErrInsufficientStock, err)
+	})
+}
+
+func TestHTTP_handleReserveStock(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStockService := mock.NewMockStockService(ctrl)
+	stockController := &stockController{
+		stockService: mockStockService,
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodPost, "/cakes/1/stock/reservations", strings.NewReader(`{"quantity":2,"ttl_seconds":120}`))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ectx.SetParamNames("id")
+		ectx.SetParamValues("1")
+		ctx := context.Background()
+
+		mockStockService.EXPECT().Reserve(ctx, model.ReserveStockRequest{Quantity: 2, TTLSeconds: 120}, 1).
+			Times(1).Return(&model.Reservation{Id: "abc", CakeId: 1, Quantity: 2}, nil)
+
+		err := stockController.HandleReserve()(ectx)
+		require.NoError(t, err)
+		require.EqualValues(t, http.StatusOK, rec.Result().StatusCode)
+	})
+}
+
+func TestHTTP_handleReleaseAndCommitStock(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStockService := mock.NewMockStockService(ctrl)
+	stockController := &stockController{
+		stockService: mockStockService,
+	}
+
+	reservation := &model.Reservation{Id: "abc", CakeId: 1, Quantity: 2}
+
+	t.Run("release", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodDelete, "/stock/reservations/abc", nil)
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ectx.SetParamNames("reservationId")
+		ectx.SetParamValues("abc")
+		ctx := context.Background()
+
+		mockStockService.EXPECT().Release(ctx, "abc").Times(1).Return(reservation, nil)
+
+		err := stockController.HandleRelease()(ectx)
+		require.NoError(t, err)
+		require.EqualValues(t, http.StatusOK, rec.Result().StatusCode)
+	})
+
+	t.Run("commit - expired", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodPost, "/stock/reservations/abc/commit", nil)
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ectx.SetParamNames("reservationId")
+		ectx.SetParamValues("abc")
+		ctx := context.Background()
+
+		mockStockService.EXPECT().Commit(ctx, "abc").Times(1).Return(nil, constant.ErrNotFound)
+
+		err := stockController.HandleCommit()(ectx)
+		require.Equal(t, constant.ErrNotFound, err)
+	})
+}
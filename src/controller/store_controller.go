@@ -0,0 +1,209 @@
+package controller
+
+import (
+	"cake-store/src/constant"
+	"cake-store/src/model"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	log "github.com/sirupsen/logrus"
+)
+
+type storeController struct {
+	storeService model.StoreService
+}
+
+func NewStoreController(storeService model.StoreService) model.StoreController {
+	return &storeController{
+		storeService: storeService,
+	}
+}
+
+func (sC *storeController) HandleCreate() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req := model.CreateUpdateStoreRequest{}
+		if err := c.Bind(&req); err != nil {
+			log.Error(err)
+			return constant.ErrInvalidArgument
+		}
+
+		create, err := sC.storeService.Create(c.Request().Context(), req)
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+
+		return c.JSON(http.StatusOK, model.ResponseSuccess{
+			Success: true,
+			Data:    create,
+		})
+	}
+}
+
+func (sC *storeController) HandleUpdate() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req := model.CreateUpdateStoreRequest{}
+		if err := c.Bind(&req); err != nil {
+			log.Error(err)
+			return constant.ErrInvalidArgument
+		}
+
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			log.Error(err)
+			return constant.ErrInvalidArgument
+		}
+
+		update, err := sC.storeService.Update(c.Request().Context(), req, id)
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+
+		return c.JSON(http.StatusOK, model.ResponseSuccess{
+			Success: true,
+			Data:    update,
+		})
+	}
+}
+
+func (sC *storeController) HandleFindById() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			log.Error(err)
+			return constant.ErrInvalidArgument
+		}
+
+		store, err := sC.storeService.FindById(c.Request().Context(), id)
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+
+		return c.JSON(http.StatusOK, model.ResponseSuccess{
+			Success: true,
+			Data:    store,
+		})
+	}
+}
+
+func (sC *storeController) HandleFindAll() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		stores, err := sC.storeService.FindAll(c.Request().Context())
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+
+		return c.JSON(http.StatusOK, model.ResponseSuccess{
+			Success: true,
+			Data:    stores,
+		})
+	}
+}
+
+// HandleSetCake replace the availability and the price overrides of the :cakeId in the store
+func (sC *storeController) HandleSetCake() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req := model.SetStoreCakeRequest{}
+		if err := c.Bind(&req); err != nil {
+			log.Error(err)
+			return constant.ErrInvalidArgument
+		}
+
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			log.Error(err)
+			return constant.ErrInvalidArgument
+		}
+
+		cakeId, err := strconv.Atoi(c.Param("cakeId"))
+		if err != nil {
+			log.Error(err)
+			return constant.ErrInvalidArgument
+		}
+
+		storeCake, err := sC.storeService.SetCake(c.Request().Context(), req, id, cakeId)
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+
+		return c.JSON(http.StatusOK, model.ResponseSuccess{
+			Success: true,
+			Data:    storeCake,
+		})
+	}
+}
+
+func (sC *storeController) HandleFindCake() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			log.Error(err)
+			return constant.ErrInvalidArgument
+		}
+
+		cakeId, err := strconv.Atoi(c.Param("cakeId"))
+		if err != nil {
+			log.Error(err)
+			return constant.ErrInvalidArgument
+		}
+
+		storeCake, err := sC.storeService.FindCake(c.Request().Context(), id, cakeId)
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+
+		return c.JSON(http.StatusOK, model.ResponseSuccess{
+			Success: true,
+			Data:    storeCake,
+		})
+	}
+}
+
+// HandleFindNearest return the active stores nearest to ?lat= and ?lng=, at most ?limit=
+func (sC *storeController) HandleFindNearest() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		query := model.NearestStoreQuery{}
+		if err := c.Bind(&query); err != nil {
+			log.Error(err)
+			return constant.ErrInvalidArgument
+		}
+
+		stores, err := sC.storeService.FindNearest(c.Request().Context(), query)
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+
+		return c.JSON(http.StatusOK, model.ResponseSuccess{
+			Success: true,
+			Data:    stores,
+		})
+	}
+}
+
+func (sC *storeController) HandleQuoteDelivery() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req := model.DeliveryQuoteRequest{}
+		if err := c.Bind(&req); err != nil {
+			log.Error(err)
+			return constant.ErrInvalidArgument
+		}
+
+		quote, err := sC.storeService.QuoteDelivery(c.Request().Context(), req)
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+
+		return c.JSON(http.StatusOK, model.ResponseSuccess{
+			Success: true,
+			Data:    quote,
+		})
+	}
+}
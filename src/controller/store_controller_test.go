@@ -0,0 +1,223 @@
+package controller
+
+import (
+	"cake-store/src/constant"
+	"cake-store/src/model"
+	"cake-store/src/model/mock"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTP_handleCreateStore(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStoreService := mock.NewMockStoreService(ctrl)
+	storeController := &storeController{
+		storeService: mockStoreService,
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodPost, "/stores", strings.NewReader(`{"code":"north","name":"North Branch"}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ctx := context.Background()
+
+		mockStoreService.EXPECT().Create(ctx, model.CreateUpdateStoreRequest{Code: "north", Name: "North Branch"}).Times(1).
+			Return(&model.Store{Id: 2, Code: "north", Name: "North Branch", Active: true}, nil)
+
+		err := storeController.HandleCreate()(ectx)
+		require.NoError(t, err)
+
+		resBody := map[string]interface{}{}
+		err = json.NewDecoder(rec.Result().Body).Decode(&resBody)
+		require.NoError(t, err)
+		data := resBody["data"].(map[string]interface{})
+		require.Equal(t, "north", data["code"])
+	})
+
+	t.Run("already exists", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodPost, "/stores", strings.NewReader(`{"code":"north","name":"North Branch"}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ctx := context.Background()
+
+		mockStoreService.EXPECT().Create(ctx, gomock.Any()).Times(1).Return(nil, constant.ErrAlreadyExists)
+
+		err := storeController.HandleCreate()(ectx)
+		require.Equal(t, constant.ErrAlreadyExists, err)
+	})
+}
+
+func TestHTTP_handleSetStoreCake(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStoreService := mock.NewMockStoreService(ctrl)
+	storeController := &storeController{
+		storeService: mockStoreService,
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		ec := echo.New()
+		body := `{"available":true,"prices":[{"variant_id":5,"price":{"amount":300000,"currency":"IDR"}}]}`
+		req := httptest.NewRequest(http.MethodPut, "/stores/2/cakes/1", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ectx.SetParamNames("id", "cakeId")
+		ectx.SetParamValues("2", "1")
+		ctx := context.Background()
+
+		mockStoreService.EXPECT().SetCake(ctx, gomock.Any(), 2, 1).Times(1).
+			DoAndReturn(func(_ context.Context, req model.SetStoreCakeRequest, _ int, _ int) (*model.StoreCake, error) {
+				require.Len(t, req.Prices, 1)
+				require.Equal(t, 5, req.Prices[0].VariantId)
+				return &model.StoreCake{StoreId: 2, CakeId: 1, Available: true}, nil
+			})
+
+		err := storeController.HandleSetCake()(ectx)
+		require.NoError(t, err)
+	})
+
+	t.Run("invalid cake id", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodPut, "/stores/2/cakes/abc", strings.NewReader(`{}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ectx.SetParamNames("id", "cakeId")
+		ectx.SetParamValues("2", "abc")
+
+		err := storeController.HandleSetCake()(ectx)
+		require.Equal(t, constant.ErrInvalidArgument, err)
+	})
+}
+
+func TestHTTP_handleFindStoreCake(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStoreService := mock.NewMockStoreService(ctrl)
+	storeController := &storeController{
+		storeService: mockStoreService,
+	}
+
+	t.Run("not found", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodGet, "/stores/9/cakes/1", nil)
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ectx.SetParamNames("id", "cakeId")
+		ectx.SetParamValues("9", "1")
+		ctx := context.Background()
+
+		mockStoreService.EXPECT().FindCake(ctx, 9, 1).Times(1).Return(nil, constant.ErrNotFound)
+
+		err := storeController.HandleFindCake()(ectx)
+		require.Equal(t, constant.ErrNotFound, err)
+	})
+}
+
+func TestHTTP_handleFindNearestStore(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStoreService := mock.NewMockStoreService(ctrl)
+	storeController := &storeController{
+		storeService: mockStoreService,
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodGet, "/stores/nearest?lat=-6.2&lng=106.8&limit=2", nil)
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ctx := context.Background()
+
+		mockStoreService.EXPECT().FindNearest(ctx, gomock.Any()).Times(1).
+			DoAndReturn(func(_ context.Context, query model.NearestStoreQuery) ([]*model.NearbyStore, error) {
+				require.NotNil(t, query.Latitude)
+				require.Equal(t, -6.2, *query.Latitude)
+				require.Equal(t, 106.8, *query.Longitude)
+				require.Equal(t, 2, query.Limit)
+				return []*model.NearbyStore{{Store: &model.Store{Id: 1, Code: "main"}, DistanceKm: 3.1}}, nil
+			})
+
+		err := storeController.HandleFindNearest()(ectx)
+		require.NoError(t, err)
+
+		resBody := map[string]interface{}{}
+		err = json.NewDecoder(rec.Result().Body).Decode(&resBody)
+		require.NoError(t, err)
+		data := resBody["data"].([]interface{})
+		require.Len(t, data, 1)
+		require.Equal(t, "main", data[0].(map[string]interface{})["code"])
+		require.Equal(t, 3.1, data[0].(map[string]interface{})["distance_km"])
+	})
+
+	t.Run("invalid latitude", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodGet, "/stores/nearest?lat=north&lng=106.8", nil)
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+
+		err := storeController.HandleFindNearest()(ectx)
+		require.Equal(t, constant.ErrInvalidArgument, err)
+	})
+}
+
+func TestHTTP_handleQuoteDelivery(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStoreService := mock.NewMockStoreService(ctrl)
+	storeController := &storeController{
+		storeService: mockStoreService,
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		ec := echo.New()
+		body := `{"latitude":-6.2,"longitude":106.8,"subtotal":{"amount":2000000,"currency":"IDR"}}`
+		req := httptest.NewRequest(http.MethodPost, "/delivery/quote", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ctx := context.Background()
+
+		mockStoreService.EXPECT().QuoteDelivery(ctx, gomock.Any()).Times(1).
+			DoAndReturn(func(_ context.Context, req model.DeliveryQuoteRequest) (*model.DeliveryQuote, error) {
+				require.Equal(t, model.NewMoney(2000000, "IDR"), req.Subtotal)
+				return &model.DeliveryQuote{Store: &model.Store{Id: 1}, DistanceKm: 2.5, Fee: model.NewMoney(1000000, "IDR")}, nil
+			})
+
+		err := storeController.HandleQuoteDelivery()(ectx)
+		require.NoError(t, err)
+	})
+
+	t.Run("out of delivery range", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodPost, "/delivery/quote", strings.NewReader(`{"latitude":-7.8,"longitude":110.4}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ctx := context.Background()
+
+		mockStoreService.EXPECT().QuoteDelivery(ctx, gomock.Any()).Times(1).Return(nil, constant.ErrOutOfDeliveryRange)
+
+		err := storeController.HandleQuoteDelivery()(ectx)
+		require.Equal(t, constant.ErrOutOfDeliveryRange, err)
+	})
+}
@@ -0,0 +1,154 @@
+package controller
+
+import (
+	"cake-store/src/constant"
+	"cake-store/src/model"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	log "github.com/sirupsen/logrus"
+)
+
+type taxonomyController struct {
+	taxonomyService model.TaxonomyService
+}
+
+// NewTaxonomyController serve the terms of one taxonomy, the categories or the tags
+func NewTaxonomyController(taxonomyService model.TaxonomyService) model.TaxonomyController {
+	return &taxonomyController{
+		taxonomyService: taxonomyService,
+	}
+}
+
+func (tC *taxonomyController) HandleCreate() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req := model.CreateUpdateTermRequest{}
+		if err := c.Bind(&req); err != nil {
+			log.Error(err)
+			return constant.ErrInvalidArgument
+		}
+
+		create, err := tC.taxonomyService.Create(c.Request().Context(), req)
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+
+		return c.JSON(http.StatusOK, model.ResponseSuccess{
+			Success: true,
+			Data:    create,
+		})
+	}
+}
+
+func (tC *taxonomyController) HandleFindAll() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		terms, err := tC.taxonomyService.FindAll(c.Request().Context())
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+
+		return c.JSON(http.StatusOK, model.ResponseSuccess{
+			Success: true,
+			Data:    terms,
+		})
+	}
+}
+
+func (tC *taxonomyController) HandleFindById() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			log.Error(err)
+			return constant.ErrInvalidArgument
+		}
+
+		term, err := tC.taxonomyService.FindById(c.Request().Context(), id)
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+
+		return c.JSON(http.StatusOK, model.ResponseSuccess{
+			Success: true,
+			Data:    term,
+		})
+	}
+}
+
+func (tC *taxonomyController) HandleUpdate() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req := model.CreateUpdateTermRequest{}
+		if err := c.Bind(&req); err != nil {
+			log.Error(err)
+			return constant.ErrInvalidArgument
+		}
+
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			log.Error(err)
+			return constant.ErrInvalidArgument
+		}
+
+		update, err := tC.taxonomyService.Update(c.Request().Context(), req, id)
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+
+		return c.JSON(http.StatusOK, model.ResponseSuccess{
+			Success: true,
+			Data:    update,
+		})
+	}
+}
+
+func (tC *taxonomyController) HandleDelete() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			log.Error(err)
+			return constant.ErrInvalidArgument
+		}
+
+		term, err := tC.taxonomyService.Delete(c.Request().Context(), id)
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+
+		return c.JSON(http.StatusOK, model.ResponseSuccess{
+			Success: true,
+			Data:    term,
+		})
+	}
+}
+
+func (tC *taxonomyController) HandleSetCakeTerms() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req := model.SetCakeRelationRequest{}
+		if err := c.Bind(&req); err != nil {
+			log.Error(err)
+			return constant.ErrInvalidArgument
+		}
+
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			log.Error(err)
+			return constant.ErrInvalidArgument
+		}
+
+		terms, err := tC.taxonomyService.SetCakeTerms(c.Request().Context(), req, id)
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+
+		return c.JSON(http.StatusOK, model.ResponseSuccess{
+			Success: true,
+			Data:    terms,
+		})
+	}
+}
@@ -0,0 +1,265 @@
+package controller
+
+import (
+	"cake-store/src/constant"
+	"cake-store/src/model"
+	"cake-store/src/model/mock"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTP_handleCreateTerm(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTaxonomyService := mock.NewMockTaxonomyService(ctrl)
+	taxonomyController := &taxonomyController{
+		taxonomyService: mockTaxonomyService,
+	}
+
+	term := &model.Term{
+		Id:        1,
+		Name:      "Birthday",
+		Slug:      "birthday",
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodPost, "/categories", strings.NewReader(`{"name":"Birthday"}`))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ctx := context.Background()
+
+		mockTaxonomyService.EXPECT().Create(ctx, model.CreateUpdateTermRequest{Name: "Birthday"}).Times(1).Return(term, nil)
+
+		err := taxonomyController.HandleCreate()(ectx)
+		require.NoError(t, err)
+
+		resBody := map[string]interface{}{}
+		err = json.NewDecoder(rec.Result().Body).Decode(&resBody)
+		require.NoError(t, err)
+		require.EqualValues(t, http.StatusOK, rec.Result().StatusCode)
+	})
+
+	t.Run("handle error - already exists", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodPost, "/categories", strings.NewReader(`{"name":"Birthday"}`))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ctx := context.Background()
+
+		mockTaxonomyService.EXPECT().Create(ctx, model.CreateUpdateTermRequest{Name: "Birthday"}).Times(1).Return(nil, constant.ErrAlreadyExists)
+
+		err := taxonomyController.HandleCreate()(ectx)
+		require.Equal(t, constant.ErrAlreadyExists, err)
+	})
+}
+
+func TestHTTP_handleUpdateTerm(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTaxonomyService := mock.NewMockTaxonomyService(ctrl)
+	taxonomyController := &taxonomyController{
+		taxonomyService: mockTaxonomyService,
+	}
+
+	term := &model.Term{Id: 1, Name: "Birthday", Slug: "birthday"}
+
+	t.Run("ok", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodPut, "/categories/1", strings.NewReader(`{"name":"Birthday","slug":"birthday"}`))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ectx.SetParamNames("id")
+		ectx.SetParamValues("1")
+		ctx := context.Background()
+
+		mockTaxonomyService.EXPECT().Update(ctx, model.CreateUpdateTermRequest{Name: "Birthday", Slug: "birthday"}, 1).Times(1).Return(term, nil)
+
+		err := taxonomyController.HandleUpdate()(ectx)
+		require.NoError(t, err)
+		require.EqualValues(t, http.StatusOK, rec.Result().StatusCode)
+	})
+
+	t.Run("handle error - invalid id", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodPut, "/categories/x", strings.NewReader(`{"name":"Birthday"}`))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ectx.SetParamNames("id")
+		ectx.SetParamValues("x")
+
+		err := taxonomyController.HandleUpdate()(ectx)
+		require.Equal(t, constant.ErrInvalidArgument, err)
+	})
+}
+
+func TestHTTP_handleDeleteTerm(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTaxonomyService := mock.NewMockTaxonomyService(ctrl)
+	taxonomyController := &taxonomyController{
+		taxonomyService: mockTaxonomyService,
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodDelete, "/categories/1", nil)
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ectx.SetParamNames("id")
+		ectx.SetParamValues("1")
+		ctx := context.Background()
+
+		mockTaxonomyService.EXPECT().Delete(ctx, 1).Times(1).Return(&model.Term{Id: 1}, nil)
+
+		err := taxonomyController.HandleDelete()(ectx)
+		require.NoError(t, err)
+		require.EqualValues(t, http.StatusOK, rec.Result().StatusCode)
+	})
+
+	t.Run("handle error - not found", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodDelete, "/categories/2", nil)
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ectx.SetParamNames("id")
+		ectx.SetParamValues("2")
+		ctx := context.Background()
+
+		mockTaxonomyService.EXPECT().Delete(ctx, 2).Times(1).Return(nil, constant.ErrNotFound)
+
+		err := taxonomyController.HandleDelete()(ectx)
+		require.Equal(t, constant.ErrNotFound, err)
+	})
+}
+
+func TestHTTP_handleFindTerm(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTaxonomyService := mock.NewMockTaxonomyService(ctrl)
+	taxonomyController := &taxonomyController{
+		taxonomyService: mockTaxonomyService,
+	}
+
+	terms := []*model.Term{{Id: 1, Name: "Birthday", Slug: "birthday"}}
+
+	t.Run("ok - find all", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodGet, "/categories", nil)
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ctx := context.Background()
+
+		mockTaxonomyService.EXPECT().FindAll(ctx).Times(1).Return(terms, nil)
+
+		err := taxonomyController.HandleFindAll()(ectx)
+		require.NoError(t, err)
+
+		resBody := map[string]interface{}{}
+		err = json.NewDecoder(rec.Result().Body).Decode(&resBody)
+		require.NoError(t, err)
+		require.Len(t, resBody["data"], 1)
+	})
+
+	t.Run("ok - tag without description", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodGet, "/tags/2", nil)
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ectx.SetParamNames("id")
+		ectx.SetParamValues("2")
+		ctx := context.Background()
+
+		mockTaxonomyService.EXPECT().FindById(ctx, 2).Times(1).Return(&model.Term{Id: 2, Name: "Chocolate", Slug: "chocolate"}, nil)
+
+		err := taxonomyController.HandleFindById()(ectx)
+		require.NoError(t, err)
+
+		resBody := struct {
+			Data map[string]interface{} `json:"data"`
+		}{}
+		err = json.NewDecoder(rec.Result().Body).Decode(&resBody)
+		require.NoError(t, err)
+		require.NotContains(t, resBody.Data, "description")
+	})
+
+	t.Run("ok - find by id", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodGet, "/categories/1", nil)
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ectx.SetParamNames("id")
+		ectx.SetParamValues("1")
+		ctx := context.Background()
+
+		mockTaxonomyService.EXPECT().FindById(ctx, 1).Times(1).Return(terms[0], nil)
+
+		err := taxonomyController.HandleFindById()(ectx)
+		require.NoError(t, err)
+		require.EqualValues(t, http.StatusOK, rec.Result().StatusCode)
+	})
+}
+
+func TestHTTP_handleSetCakeTerms(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTaxonomyService := mock.NewMockTaxonomyService(ctrl)
+	taxonomyController := &taxonomyController{
+		taxonomyService: mockTaxonomyService,
+	}
+
+	terms := []*model.Term{{Id: 1, Name: "Birthday", Slug: "birthday"}}
+
+	t.Run("ok", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodPut, "/cakes/3/categories", strings.NewReader(`{"ids":[1]}`))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ectx.SetParamNames("id")
+		ectx.SetParamValues("3")
+		ctx := context.Background()
+
+		mockTaxonomyService.EXPECT().SetCakeTerms(ctx, model.SetCakeRelationRequest{Ids: []int{1}}, 3).Times(1).Return(terms, nil)
+
+		err := taxonomyController.HandleSetCakeTerms()(ectx)
+		require.NoError(t, err)
+		require.EqualValues(t, http.StatusOK, rec.Result().StatusCode)
+	})
+
+	t.Run("handle error - unknown term", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodPut, "/cakes/3/categories", strings.NewReader(`{"ids":[9]}`))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ectx.SetParamNames("id")
+		ectx.SetParamValues("3")
+		ctx := context.Background()
+
+		mockTaxonomyService.EXPECT().SetCakeTerms(ctx, model.SetCakeRelationRequest{Ids: []int{9}}, 3).Times(1).Return(nil, constant.ErrInvalidArgument)
+
+		err := taxonomyController.HandleSetCakeTerms()(ectx)
+		require.Equal(t, constant.ErrInvalidArgument, err)
+	})
+}
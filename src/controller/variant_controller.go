@@ -0,0 +1,153 @@
+package controller
+
+import (
+	"cake-store/src/constant"
+	"cake-store/src/model"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	log "github.com/sirupsen/logrus"
+)
+
+type variantController struct {
+	variantService model.VariantService
+}
+
+func NewVariantController(variantService model.VariantService) model.VariantController {
+	return &variantController{
+		variantService: variantService,
+	}
+}
+
+func (vC *variantController) HandleCreate() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req := model.CreateUpdateVariantRequest{}
+		if err := c.Bind(&req); err != nil {
+			log.Error(err)
+			return constant.ErrInvalidArgument
+		}
+
+		cakeId, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			log.Error(err)
+			return constant.ErrInvalidArgument
+		}
+
+		create, err := vC.variantService.Create(c.Request().Context(), req, cakeId)
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+
+		return c.JSON(http.StatusOK, model.ResponseSuccess{
+			Success: true,
+			Data:    create,
+		})
+	}
+}
+
+func (vC *variantController) HandleFindAll() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		cakeId, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			log.Error(err)
+			return constant.ErrInvalidArgument
+		}
+
+		variants, err := vC.variantService.FindAll(c.Request().Context(), cakeId)
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+
+		return c.JSON(http.StatusOK, model.ResponseSuccess{
+			Success: true,
+			Data:    variants,
+		})
+	}
+}
+
+func (vC *variantController) HandleFindById() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		cakeId, variantId, err := variantParams(c)
+		if err != nil {
+			log.Error(err)
+			return constant.ErrInvalidArgument
+		}
+
+		variant, err := vC.variantService.FindById(c.Request().Context(), cakeId, variantId)
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+
+		return c.JSON(http.StatusOK, model.ResponseSuccess{
+			Success: true,
+			Data:    variant,
+		})
+	}
+}
+
+func (vC *variantController) HandleUpdate() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req := model.CreateUpdateVariantRequest{}
+		if err := c.Bind(&req); err != nil {
+			log.Error(err)
+			return constant.ErrInvalidArgument
+		}
+
+		cakeId, variantId, err := variantParams(c)
+		if err != nil {
+			log.Error(err)
+			return constant.ErrInvalidArgument
+		}
+
+		update, err := vC.variantService.Update(c.Request().Context(), req, cakeId, variantId)
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+
+		return c.JSON(http.StatusOK, model.ResponseSuccess{
+			Success: true,
+			Data:    update,
+		})
+	}
+}
+
+func (vC *variantController) HandleDelete() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		cakeId, variantId, err := variantParams(c)
+		if err != nil {
+			log.Error(err)
+			return constant.ErrInvalidArgument
+		}
+
+		variant, err := vC.variantService.Delete(c.Request().Context(), cakeId, variantId)
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+
+		return c.JSON(http.StatusOK, model.ResponseSuccess{
+			Success: true,
+			Data:    variant,
+		})
+	}
+}
+
+// variantParams parse the cake and variant id of the nested variant path
+func variantParams(c echo.Context) (int, int, error) {
+	cakeId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return 0, 0, err
+	}
+
+	variantId, err := strconv.Atoi(c.Param("variantId"))
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return cakeId, variantId, nil
+}
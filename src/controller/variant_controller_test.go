@@ -0,0 +1,199 @@
+package controller
+
+import (
+	"cake-store/src/constant"
+	"cake-store/src/model"
+	"cake-store/src/model/mock"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTP_handleCreateVariant(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockVariantService := mock.NewMockVariantService(ctrl)
+	variantController := &variantController{
+		variantService: mockVariantService,
+	}
+
+	variant := &model.Variant{Id: 5, CakeId: 1, Size: "20cm", Servings: 8, Price: model.NewMoney(250000, "IDR"), Sku: "CHOCO-20", Active: true}
+
+	t.Run("ok", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodPost, "/cakes/1/variants", strings.NewReader(`
+		{
+            "size":"20cm",
+            "servings": 8,
+            "price": {"amount": 250000, "currency": "idr"},
+            "sku":"CHOCO-20"
+		}`,
+		))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ectx.SetParamNames("id")
+		ectx.SetParamValues("1")
+		ctx := context.Background()
+
+		mockVariantService.EXPECT().Create(ctx, model.CreateUpdateVariantRequest{
+			Size:     "20cm",
+			Servings: 8,
+			Price:    model.NewMoney(250000, "IDR"),
+			Sku:      "CHOCO-20",
+		}, 1).Times(1).Return(variant, nil)
+
+		err := variantController.HandleCreate()(ectx)
+		require.NoError(t, err)
+
+		resBody := map[string]interface{}{}
+		err = json.NewDecoder(rec.Result().Body).Decode(&resBody)
+		require.NoError(t, err)
+		require.EqualValues(t, http.StatusOK, rec.Result().StatusCode)
+	})
+
+	t.Run("handle error - cake not found", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodPost, "/cakes/2/variants", strings.NewReader(`{"size":"20cm"}`))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ectx.SetParamNames("id")
+		ectx.SetParamValues("2")
+		ctx := context.Background()
+
+		mockVariantService.EXPECT().Create(ctx, model.CreateUpdateVariantRequest{Size: "20cm"}, 2).Times(1).Return(nil, constant.ErrNotFound)
+
+		err := variantController.HandleCreate()(ectx)
+		require.Equal(t, constant.ErrNotFound, err)
+	})
+}
+
+func TestHTTP_handleUpdateVariant(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockVariantService := mock.NewMockVariantService(ctrl)
+	variantController := &variantController{
+		variantService: mockVariantService,
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodPut, "/cakes/1/variants/5", strings.NewReader(`{"size":"24cm","servings":12,"price":{"amount":320000,"currency":"IDR"},"sku":"CHOCO-24","active":false}`))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ectx.SetParamNames("id", "variantId")
+		ectx.SetParamValues("1", "5")
+		ctx := context.Background()
+
+		active := false
+		mockVariantService.EXPECT().Update(ctx, model.CreateUpdateVariantRequest{
+			Size:     "24cm",
+			Servings: 12,
+			Price:    model.NewMoney(320000, "IDR"),
+			Sku:      "CHOCO-24",
+			Active:   &active,
+		}, 1, 5).Times(1).Return(&model.Variant{Id: 5, CakeId: 1}, nil)
+
+		err := variantController.HandleUpdate()(ectx)
+		require.NoError(t, err)
+		require.EqualValues(t, http.StatusOK, rec.Result().StatusCode)
+	})
+
+	t.Run("handle error - invalid variant id", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodPut, "/cakes/1/variants/x", strings.NewReader(`{}`))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ectx.SetParamNames("id", "variantId")
+		ectx.SetParamValues("1", "x")
+
+		err := variantController.HandleUpdate()(ectx)
+		require.Equal(t, constant.ErrInvalidArgument, err)
+	})
+}
+
+func TestHTTP_handleDeleteVariant(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockVariantService := mock.NewMockVariantService(ctrl)
+	variantController := &variantController{
+		variantService: mockVariantService,
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodDelete, "/cakes/1/variants/5", nil)
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ectx.SetParamNames("id", "variantId")
+		ectx.SetParamValues("1", "5")
+		ctx := context.Background()
+
+		mockVariantService.EXPECT().Delete(ctx, 1, 5).Times(1).Return(&model.Variant{Id: 5, CakeId: 1}, nil)
+
+		err := variantController.HandleDelete()(ectx)
+		require.NoError(t, err)
+		require.EqualValues(t, http.StatusOK, rec.Result().StatusCode)
+	})
+}
+
+func TestHTTP_handleFindVariant(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockVariantService := mock.NewMockVariantService(ctrl)
+	variantController := &variantController{
+		variantService: mockVariantService,
+	}
+
+	variants := []*model.Variant{{Id: 5, CakeId: 1, Size: "20cm"}}
+
+	t.Run("ok - find all", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodGet, "/cakes/1/variants", nil)
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ectx.SetParamNames("id")
+		ectx.SetParamValues("1")
+		ctx := context.Background()
+
+		mockVariantService.EXPECT().FindAll(ctx, 1).Times(1).Return(variants, nil)
+
+		err := variantController.HandleFindAll()(ectx)
+		require.NoError(t, err)
+
+		resBody := map[string]interface{}{}
+		err = json.NewDecoder(rec.Result().Body).Decode(&resBody)
+		require.NoError(t, err)
+		require.Len(t, resBody["data"], 1)
+	})
+
+	t.Run("ok - find by id", func(t *testing.T) {
+		ec := echo.New()
+		req := httptest.NewRequest(http.MethodGet, "/cakes/1/variants/5", nil)
+		rec := httptest.NewRecorder()
+		ectx := ec.NewContext(req, rec)
+		ectx.SetParamNames("id", "variantId")
+		ectx.SetParamValues("1", "5")
+		ctx := context.Background()
+
+		mockVariantService.EXPECT().FindById(ctx, 1, 5).Times(1).Return(variants[0], nil)
+
+		err := variantController.HandleFindById()(ectx)
+		require.NoError(t, err)
+		require.EqualValues(t, http.StatusOK, rec.Result().StatusCode)
+	})
+}
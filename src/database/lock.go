@@ -0,0 +1,77 @@
+package database
+
+import (
+	"cake-store/src/helper"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+var (
+	ErrLockNotObtained = errors.New("lock not obtained")
+	ErrLockLost        = errors.New("lock lost")
+)
+
+// releaseScript delete the lock key only when it is still held by the same token
+var releaseScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+// refreshScript extend the lock key only when it is still held by the same token
+var refreshScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return 0
+`)
+
+// Lock is a redis mutex shared across replicas
+type Lock struct {
+	client *redis.Client
+	key    string
+	token  string
+}
+
+// ObtainLock try to hold the lock key until released or the ttl expired, return ErrLockNotObtained when held by another
+func ObtainLock(ctx context.Context, client *redis.Client, key string, ttl time.Duration) (*Lock, error) {
+	token, err := helper.RandomToken()
+	if err != nil {
+		return nil, err
+	}
+
+	ok, err := client.SetNX(ctx, key, token, ttl).Result()
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrLockNotObtained
+	}
+
+	return &Lock{
+		client: client,
+		key:    key,
+		token:  token,
+	}, nil
+}
+
+// Release free the lock when it is still held by this holder
+func (l *Lock) Release(ctx context.Context) error {
+	return releaseScript.Run(ctx, l.client, []string{l.key}, l.token).Err()
+}
+
+// Refresh hold the lock for the ttl from now, return ErrLockLost when it expired and may be held by another
+func (l *Lock) Refresh(ctx context.Context, ttl time.Duration) error {
+	res, err := refreshScript.Run(ctx, l.client, []string{l.key}, l.token, ttl.Milliseconds()).Int()
+	if err != nil {
+		return err
+	}
+	if res == 0 {
+		return ErrLockLost
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLock(t *testing.T) {
+	mr, _ := miniredis.Run()
+	defer mr.Close()
+	rdb := NewRedisConn(mr.Addr())
+	ctx := context.TODO()
+
+	t.Run("ok", func(t *testing.T) {
+		lock, err := ObtainLock(ctx, rdb, "lock:test", time.Minute)
+		require.NoError(t, err)
+
+		_, err = ObtainLock(ctx, rdb, "lock:test", time.Minute)
+		assert.Equal(t, ErrLockNotObtained, err)
+
+		require.NoError(t, lock.Release(ctx))
+		assert.False(t, mr.Exists("lock:test"))
+
+		lock, err = ObtainLock(ctx, rdb, "lock:test", time.Minute)
+		require.NoError(t, err)
+		require.NoError(t, lock.Release(ctx))
+	})
+
+	t.Run("expired lock is not released by the old holder", func(t *testing.T) {
+		lock, err := ObtainLock(ctx, rdb, "lock:test", time.Minute)
+		require.NoError(t, err)
+
+		mr.FastForward(2 * time.Minute)
+		other, err := ObtainLock(ctx, rdb, "lock:test", time.Minute)
+		require.NoError(t, err)
+
+		require.NoError(t, lock.Release(ctx))
+		assert.True(t, mr.Exists("lock:test"))
+		require.NoError(t, other.Release(ctx))
+	})
+	t.Run("refresh", func(t *testing.T) {
+		lock, err := ObtainLock(ctx, rdb, "lock:test", time.Minute)
+		require.NoError(t, err)
+
+		mr.FastForward(50 * time.Second)
+		require.NoError(t, lock.Refresh(ctx, time.Minute))
+		assert.Equal(t, time.Minute, mr.TTL("lock:test"))
+
+		require.NoError(t, lock.Release(ctx))
+	})
+
+	t.Run("refresh an expired lock", func(t *testing.T) {
+		lock, err := ObtainLock(ctx, rdb, "lock:test", time.Minute)
+		require.NoError(t, err)
+
+		mr.FastForward(2 * time.Minute)
+		other, err := ObtainLock(ctx, rdb, "lock:test", time.Minute)
+		require.NoError(t, err)
+
+		assert.Equal(t, ErrLockLost, lock.Refresh(ctx, time.Minute))
+		require.NoError(t, other.Release(ctx))
+	})
+}
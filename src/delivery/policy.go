@@ -0,0 +1,124 @@
+package delivery
+
+import (
+	"cake-store/src/config"
+	"cake-store/src/constant"
+	"cake-store/src/model"
+	"context"
+	"fmt"
+	"sort"
+)
+
+// NewPolicy build the fee policy of the settings in the currency, wrapped to deliver for free above the threshold
+func NewPolicy(settings config.DeliverySettings, currency string) (model.DeliveryFeePolicy, error) {
+	var (
+		policy model.DeliveryFeePolicy
+		err    error
+	)
+	switch settings.Policy {
+	case "flat":
+		policy = NewFlat(model.NewMoney(settings.FlatFee, currency))
+	case "tiered":
+		tiers := make([]Tier, 0, len(settings.Tiers))
+		for _, tier := range settings.Tiers {
+			tiers = append(tiers, Tier{UpToKm: tier.UpToKm, Fee: model.NewMoney(tier.Fee, currency)})
+		}
+		if policy, err = NewTiered(tiers); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("delivery: unknown policy %q", settings.Policy)
+	}
+
+	if settings.FreeAbove > 0 {
+		policy = NewFreeAbove(model.NewMoney(settings.FreeAbove, currency), policy)
+	}
+	return policy, nil
+}
+
+type flat struct {
+	fee model.Money
+}
+
+// NewFlat charge the same fee whatever the distance
+func NewFlat(fee model.Money) model.DeliveryFeePolicy {
+	return &flat{
+		fee: fee,
+	}
+}
+
+func (f *flat) Fee(ctx context.Context, distanceKm float64, subtotal model.Money) (model.Money, error) {
+	return f.fee, nil
+}
+
+// Tier is the fee of the deliveries up to the distance
+type Tier struct {
+	UpToKm float64
+	Fee    model.Money
+}
+
+type tiered struct {
+	tiers []Tier
+}
+
+// NewTiered charge the fee of the shortest tier reaching the distance, the addresses beyond the longest tier are out
+// of the delivery range
+func NewTiered(tiers []Tier) (model.DeliveryFeePolicy, error) {
+	if len(tiers) == 0 {
+		return nil, fmt.Errorf("delivery: no tier")
+	}
+
+	sorted := make([]Tier, len(tiers))
+	copy(sorted, tiers)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].UpToKm < sorted[j].UpToKm
+	})
+
+	for idx, tier := range sorted {
+		if tier.UpToKm <= 0 {
+			return nil, fmt.Errorf("delivery: tier %d has no distance", idx)
+		}
+		if idx > 0 && tier.UpToKm == sorted[idx-1].UpToKm {
+			return nil, fmt.Errorf("delivery: two tiers up to %g km", tier.UpToKm)
+		}
+	}
+
+	return &tiered{
+		tiers: sorted,
+	}, nil
+}
+
+func (t *tiered) Fee(ctx context.Context, distanceKm float64, subtotal model.Money) (model.Money, error) {
+	for _, tier := range t.tiers {
+		if distanceKm <= tier.UpToKm {
+			return tier.Fee, nil
+		}
+	}
+	return model.Money{}, constant.ErrOutOfDeliveryRange
+}
+
+type freeAbove struct {
+	threshold model.Money
+	policy    model.DeliveryFeePolicy
+}
+
+// NewFreeAbove deliver for free the orders whose subtotal reach the threshold and price the others with the policy,
+// an address out of the delivery range of the policy stay out of range
+func NewFreeAbove(threshold model.Money, policy model.DeliveryFeePolicy) model.DeliveryFeePolicy {
+	return &freeAbove{
+		threshold: threshold,
+		policy:    policy,
+	}
+}
+
+func (f *freeAbove) Fee(ctx context.Context, distanceKm float64, subtotal model.Money) (model.Money, error) {
+	fee, err := f.policy.Fee(ctx, distanceKm, subtotal)
+	if err != nil {
+		return model.Money{}, err
+	}
+
+	if subtotal.Currency == f.threshold.Currency && subtotal.Amount >= f.threshold.Amount {
+		return model.NewMoney(0, fee.Currency), nil
+	}
+	return fee, nil
+}
@@ -0,0 +1,118 @@
+package delivery
+
+import (
+	"cake-store/src/config"
+	"cake-store/src/constant"
+	"cake-store/src/model"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlat_Fee(t *testing.T) {
+	policy := NewFlat(model.NewMoney(1500000, "IDR"))
+
+	fee, err := policy.Fee(context.TODO(), 120, model.NewMoney(100, "IDR"))
+	require.NoError(t, err)
+	assert.Equal(t, model.NewMoney(1500000, "IDR"), fee)
+}
+
+func TestTiered_Fee(t *testing.T) {
+	ctx := context.TODO()
+	policy, err := NewTiered([]Tier{
+		{UpToKm: 10, Fee: model.NewMoney(2500000, "IDR")},
+		{UpToKm: 3, Fee: model.NewMoney(1000000, "IDR")},
+	})
+	require.NoError(t, err)
+
+	t.Run("first tier", func(t *testing.T) {
+		fee, err := policy.Fee(ctx, 2.5, model.Money{})
+		require.NoError(t, err)
+		assert.Equal(t, model.NewMoney(1000000, "IDR"), fee)
+	})
+
+	t.Run("tier bound is included", func(t *testing.T) {
+		fee, err := policy.Fee(ctx, 3, model.Money{})
+		require.NoError(t, err)
+		assert.Equal(t, model.NewMoney(1000000, "IDR"), fee)
+	})
+
+	t.Run("next tier", func(t *testing.T) {
+		fee, err := policy.Fee(ctx, 3.01, model.Money{})
+		require.NoError(t, err)
+		assert.Equal(t, model.NewMoney(2500000, "IDR"), fee)
+	})
+
+	t.Run("out of range", func(t *testing.T) {
+		_, err := policy.Fee(ctx, 10.5, model.Money{})
+		assert.Equal(t, constant.ErrOutOfDeliveryRange, err)
+	})
+}
+
+func TestNewTiered(t *testing.T) {
+	_, err := NewTiered(nil)
+	assert.Error(t, err)
+
+	_, err = NewTiered([]Tier{{UpToKm: 0, Fee: model.NewMoney(1000, "IDR")}})
+	assert.Error(t, err)
+
+	_, err = NewTiered([]Tier{{UpToKm: 5, Fee: model.NewMoney(1000, "IDR")}, {UpToKm: 5, Fee: model.NewMoney(2000, "IDR")}})
+	assert.Error(t, err)
+}
+
+func TestFreeAbove_Fee(t *testing.T) {
+	ctx := context.TODO()
+	tiered, err := NewTiered([]Tier{{UpToKm: 5, Fee: model.NewMoney(1000000, "IDR")}})
+	require.NoError(t, err)
+	policy := NewFreeAbove(model.NewMoney(50000000, "IDR"), tiered)
+
+	t.Run("below the threshold", func(t *testing.T) {
+		fee, err := policy.Fee(ctx, 2, model.NewMoney(49999999, "IDR"))
+		require.NoError(t, err)
+		assert.Equal(t, model.NewMoney(1000000, "IDR"), fee)
+	})
+
+	t.Run("reach the threshold", func(t *testing.T) {
+		fee, err := policy.Fee(ctx, 2, model.NewMoney(50000000, "IDR"))
+		require.NoError(t, err)
+		assert.Equal(t, model.NewMoney(0, "IDR"), fee)
+	})
+
+	t.Run("out of range", func(t *testing.T) {
+		_, err := policy.Fee(ctx, 6, model.NewMoney(90000000, "IDR"))
+		assert.Equal(t, constant.ErrOutOfDeliveryRange, err)
+	})
+}
+
+func TestNewPolicy(t *testing.T) {
+	ctx := context.TODO()
+
+	t.Run("flat", func(t *testing.T) {
+		policy, err := NewPolicy(config.DeliverySettings{Policy: "flat", FlatFee: 1500000}, "IDR")
+		require.NoError(t, err)
+
+		fee, err := policy.Fee(ctx, 40, model.NewMoney(90000000, "IDR"))
+		require.NoError(t, err)
+		assert.Equal(t, model.NewMoney(1500000, "IDR"), fee)
+	})
+
+	t.Run("tiered free above", func(t *testing.T) {
+		policy, err := NewPolicy(config.DeliverySettings{
+			Policy:    "tiered",
+			Tiers:     []config.DeliveryTier{{UpToKm: 3, Fee: 1000000}},
+			FreeAbove: 50000000,
+		}, "IDR")
+		require.NoError(t, err)
+
+		fee, err := policy.Fee(ctx, 1, model.NewMoney(90000000, "IDR"))
+		require.NoError(t, err)
+		assert.Equal(t, model.NewMoney(0, "IDR"), fee)
+	})
+
+	t.Run("unknown policy", func(t *testing.T) {
+		_, err := NewPolicy(config.DeliverySettings{Policy: "zone"}, "IDR")
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,77 @@
+package exchange
+
+import (
+	"cake-store/src/constant"
+	"cake-store/src/model"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+)
+
+// rateFile is the format of the static exchange rates file, each rate is the units of the currency per one unit of the base
+type rateFile struct {
+	Base  string                 `json:"base"`
+	Rates map[string]json.Number `json:"rates"`
+}
+
+type staticProvider struct {
+	rates map[string]*big.Rat
+}
+
+// NewStaticProvider load the exchange rates from a JSON file, without a file only same currency conversion is supported
+func NewStaticProvider(path string) (model.ExchangeRateProvider, error) {
+	provider := &staticProvider{
+		rates: map[string]*big.Rat{},
+	}
+	if path == "" {
+		return provider, nil
+	}
+
+	bt, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	file := rateFile{}
+	if err := json.Unmarshal(bt, &file); err != nil {
+		return nil, fmt.Errorf("exchange rates %s: %w", path, err)
+	}
+
+	if file.Base == "" {
+		return nil, fmt.Errorf("exchange rates %s: missing base currency", path)
+	}
+	provider.rates[strings.ToUpper(file.Base)] = big.NewRat(1, 1)
+
+	for currency, value := range file.Rates {
+		rate, ok := new(big.Rat).SetString(value.String())
+		if !ok || rate.Sign() <= 0 {
+			return nil, fmt.Errorf("exchange rates %s: invalid rate of %s", path, currency)
+		}
+		provider.rates[strings.ToUpper(currency)] = rate
+	}
+
+	return provider, nil
+}
+
+// Rate convert through the base currency of the file
+func (s *staticProvider) Rate(ctx context.Context, from, to string) (*big.Rat, error) {
+	from, to = strings.ToUpper(from), strings.ToUpper(to)
+	if from == to {
+		return big.NewRat(1, 1), nil
+	}
+
+	fromRate, ok := s.rates[from]
+	if !ok {
+		return nil, constant.ErrUnsupportedCurrency
+	}
+
+	toRate, ok := s.rates[to]
+	if !ok {
+		return nil, constant.ErrUnsupportedCurrency
+	}
+
+	return new(big.Rat).Quo(toRate, fromRate), nil
+}
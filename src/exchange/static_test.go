@@ -0,0 +1,77 @@
+package exchange
+
+import (
+	"cake-store/src/constant"
+	"context"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeRates(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "rates.json")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestStaticProvider_Rate(t *testing.T) {
+	ctx := context.TODO()
+	provider, err := NewStaticProvider(writeRates(t, `{"base":"IDR","rates":{"USD":"0.00005","eur":0.0001}}`))
+	require.NoError(t, err)
+
+	t.Run("from base", func(t *testing.T) {
+		rate, err := provider.Rate(ctx, "IDR", "USD")
+		require.NoError(t, err)
+		assert.Equal(t, big.NewRat(1, 20000), rate)
+	})
+
+	t.Run("to base", func(t *testing.T) {
+		rate, err := provider.Rate(ctx, "usd", "IDR")
+		require.NoError(t, err)
+		assert.Equal(t, big.NewRat(20000, 1), rate)
+	})
+
+	t.Run("cross rate", func(t *testing.T) {
+		rate, err := provider.Rate(ctx, "USD", "EUR")
+		require.NoError(t, err)
+		assert.Equal(t, big.NewRat(2, 1), rate)
+	})
+
+	t.Run("unknown currency", func(t *testing.T) {
+		_, err := provider.Rate(ctx, "IDR", "GBP")
+		assert.Equal(t, constant.ErrUnsupportedCurrency, err)
+	})
+}
+
+func TestNewStaticProvider(t *testing.T) {
+	t.Run("without file", func(t *testing.T) {
+		provider, err := NewStaticProvider("")
+		require.NoError(t, err)
+
+		rate, err := provider.Rate(context.TODO(), "IDR", "IDR")
+		require.NoError(t, err)
+		assert.Equal(t, big.NewRat(1, 1), rate)
+
+		_, err = provider.Rate(context.TODO(), "IDR", "USD")
+		assert.Equal(t, constant.ErrUnsupportedCurrency, err)
+	})
+
+	t.Run("invalid rate", func(t *testing.T) {
+		_, err := NewStaticProvider(writeRates(t, `{"base":"IDR","rates":{"USD":"-1"}}`))
+		assert.Error(t, err)
+	})
+
+	t.Run("missing base", func(t *testing.T) {
+		_, err := NewStaticProvider(writeRates(t, `{"rates":{"USD":"1"}}`))
+		assert.Error(t, err)
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := NewStaticProvider(filepath.Join(t.TempDir(), "missing.json"))
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,47 @@
+package helper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCursor(t *testing.T) {
+	type cursor struct {
+		Rating float32 `json:"r"`
+		Title  string  `json:"t"`
+		Id     int     `json:"i"`
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		token, err := EncodeCursor(cursor{Rating: 5.5, Title: "Kue Test", Id: 3}, "secret")
+		require.NoError(t, err)
+
+		res := cursor{}
+		err = DecodeCursor(token, "secret", &res)
+		require.NoError(t, err)
+		assert.Equal(t, cursor{Rating: 5.5, Title: "Kue Test", Id: 3}, res)
+	})
+
+	t.Run("wrong secret", func(t *testing.T) {
+		token, err := EncodeCursor(cursor{Id: 3}, "secret")
+		require.NoError(t, err)
+
+		err = DecodeCursor(token, "other", &cursor{})
+		assert.Equal(t, ErrInvalidCursor, err)
+	})
+
+	t.Run("tampered payload", func(t *testing.T) {
+		token, err := EncodeCursor(cursor{Id: 3}, "secret")
+		require.NoError(t, err)
+
+		err = DecodeCursor("x"+token, "secret", &cursor{})
+		assert.Equal(t, ErrInvalidCursor, err)
+	})
+
+	t.Run("malformed", func(t *testing.T) {
+		err := DecodeCursor("not-a-cursor", "secret", &cursor{})
+		assert.Equal(t, ErrInvalidCursor, err)
+	})
+}
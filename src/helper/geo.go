@@ -0,0 +1,19 @@
+package helper
+
+import "math"
+
+// EarthRadiusKm is the earth radius used by the redis geo commands, so both give the same distances
+const EarthRadiusKm = 6372.797560856
+
+// MaxGeoLatitude is the furthest latitude from the equator the redis geo commands accept, in decimal degrees
+const MaxGeoLatitude = 85.05112878
+
+// HaversineKm return the great circle distance in kilometers between two points in decimal degrees
+func HaversineKm(lat1, lng1, lat2, lng2 float64) float64 {
+	toRad := func(deg float64) float64 { return deg * math.Pi / 180 }
+
+	dLat := toRad(lat2 - lat1)
+	dLng := toRad(lng2 - lng1)
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(toRad(lat1))*math.Cos(toRad(lat2))*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return 2 * EarthRadiusKm * math.Asin(math.Min(1, math.Sqrt(a)))
+}
@@ -0,0 +1,16 @@
+package helper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHaversineKm(t *testing.T) {
+	// Monas, Jakarta to Gedung Sate, Bandung
+	assert.InDelta(t, 119.1, HaversineKm(-6.1754, 106.8272, -6.9025, 107.6188), 0.5)
+	assert.InDelta(t, HaversineKm(-6.1754, 106.8272, -6.9025, 107.6188), HaversineKm(-6.9025, 107.6188, -6.1754, 106.8272), 1e-9)
+	assert.Zero(t, HaversineKm(-6.1754, 106.8272, -6.1754, 106.8272))
+	// half of the earth
+	assert.InDelta(t, 20020.7, HaversineKm(0, 0, 0, 180), 0.1)
+}
@@ -1,6 +1,8 @@
 package helper
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"time"
 )
 
@@ -11,3 +13,12 @@ func ParseTimeDuration(t string, defaultt time.Duration) time.Duration {
 	}
 	return timeDurr
 }
+
+// RandomToken return an unguessable 32 hex characters token
+func RandomToken() (string, error) {
+	bt := make([]byte, 16)
+	if _, err := rand.Read(bt); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(bt), nil
+}
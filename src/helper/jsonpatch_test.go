@@ -0,0 +1,71 @@
+package helper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergePatch(t *testing.T) {
+	doc := []byte(`{"title":"Kue Test","description":"Desc test","rating":5.5,"tags":{"a":1,"b":2}}`)
+
+	t.Run("ok", func(t *testing.T) {
+		res, err := MergePatch(doc, []byte(`{"rating":7,"description":null,"tags":{"a":null,"c":3}}`))
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"title":"Kue Test","rating":7,"tags":{"b":2,"c":3}}`, string(res))
+	})
+
+	t.Run("replace whole document", func(t *testing.T) {
+		res, err := MergePatch(doc, []byte(`["a"]`))
+		require.NoError(t, err)
+		assert.JSONEq(t, `["a"]`, string(res))
+	})
+
+	t.Run("invalid patch", func(t *testing.T) {
+		_, err := MergePatch(doc, []byte(`{`))
+		assert.Equal(t, ErrInvalidPatch, err)
+	})
+}
+
+func TestJSONPatch(t *testing.T) {
+	doc := []byte(`{"title":"Kue Test","rating":5.5,"list":["a","b"],"a~b/c":1}`)
+
+	t.Run("ok", func(t *testing.T) {
+		res, err := JSONPatch(doc, []byte(`[
+			{"op":"test","path":"/title","value":"Kue Test"},
+			{"op":"replace","path":"/rating","value":8},
+			{"op":"add","path":"/list/1","value":"x"},
+			{"op":"add","path":"/list/-","value":"z"},
+			{"op":"remove","path":"/list/0"},
+			{"op":"copy","from":"/title","path":"/image"},
+			{"op":"move","from":"/a~0b~1c","path":"/moved"}
+		]`))
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"title":"Kue Test","rating":8,"list":["x","b","z"],"image":"Kue Test","moved":1}`, string(res))
+	})
+
+	t.Run("test failed", func(t *testing.T) {
+		_, err := JSONPatch(doc, []byte(`[{"op":"test","path":"/title","value":"other"}]`))
+		assert.Equal(t, ErrPatchTestFailed, err)
+	})
+
+	t.Run("path not found", func(t *testing.T) {
+		_, err := JSONPatch(doc, []byte(`[{"op":"replace","path":"/image","value":"x"}]`))
+		assert.Equal(t, ErrPatchPath, err)
+
+		_, err = JSONPatch(doc, []byte(`[{"op":"add","path":"/list/5","value":"x"}]`))
+		assert.Equal(t, ErrPatchPath, err)
+	})
+
+	t.Run("invalid operation", func(t *testing.T) {
+		_, err := JSONPatch(doc, []byte(`[{"op":"merge","path":"/title","value":"x"}]`))
+		assert.Equal(t, ErrInvalidPatch, err)
+
+		_, err = JSONPatch(doc, []byte(`[{"op":"add","path":"/title"}]`))
+		assert.Equal(t, ErrInvalidPatch, err)
+
+		_, err = JSONPatch(doc, []byte(`{"op":"add"}`))
+		assert.Equal(t, ErrInvalidPatch, err)
+	})
+}
@@ -0,0 +1,13 @@
+package helper
+
+import (
+	"regexp"
+	"strings"
+)
+
+var nonSlugChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+// Slugify turn the text to a lowercase hyphen separated slug
+func Slugify(text string) string {
+	return strings.Trim(nonSlugChars.ReplaceAllString(strings.ToLower(text), "-"), "-")
+}
@@ -0,0 +1,14 @@
+package helper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlugify(t *testing.T) {
+	assert.Equal(t, "birthday", Slugify("Birthday"))
+	assert.Equal(t, "dark-chocolate", Slugify("  Dark Chocolate! "))
+	assert.Equal(t, "gluten-free-vegan", Slugify("Gluten-free & Vegan"))
+	assert.Equal(t, "", Slugify("!!!"))
+}
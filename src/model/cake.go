@@ -2,53 +2,222 @@ package model
 
 import (
 	"context"
+	"fmt"
+	"hash/fnv"
+	"strings"
 	"time"
 
 	"github.com/labstack/echo/v4"
 )
 
 type CreateUpdateRequest struct {
-	Title       string  `json:"title" validate:"required,min=3,max=60"`
-	Description string  `json:"description" validate:"min=3"`
-	Rating      float32 `json:"rating" validate:"gt=0,lte=10"`
-	Image       string  `json:"image"`
+	Title       string `json:"title" validate:"required,min=3,max=60"`
+	Description string `json:"description" validate:"min=3"`
+	Image       string `json:"image"`
+	// Rating is computed from the reviews, a request giving it is rejected rather than silently dropped
+	Rating *float32 `json:"rating,omitempty" validate:"isdefault"`
 }
 
 func (c *CreateUpdateRequest) Validate() error {
 	return validate.Struct(c)
 }
 
+// ValidateFields validate only the given struct fields
+func (c *CreateUpdateRequest) ValidateFields(fields ...string) error {
+	return validate.StructPartial(c, fields...)
+}
+
+// patch media type
+const (
+	MergePatchType = "application/merge-patch+json"
+	JSONPatchType  = "application/json-patch+json"
+)
+
+type PatchRequest struct {
+	Type  string
+	Patch []byte
+}
+
+type CakeQuery struct {
+	Page      int     `query:"page" validate:"omitempty,min=1"`
+	Limit     int     `query:"limit" validate:"omitempty,min=1,max=100"`
+	MinRating float32 `query:"min_rating" validate:"omitempty,gt=0,lte=10"`
+	MaxRating float32 `query:"max_rating" validate:"omitempty,gt=0,lte=10"`
+	Title     string  `query:"title" validate:"omitempty,max=60"`
+	SortBy    string  `query:"sort_by" validate:"omitempty,oneof=id title rating created_at updated_at"`
+	SortDir   string  `query:"sort_dir" validate:"omitempty,oneof=asc desc"`
+	Paginate  string  `query:"paginate" validate:"omitempty,oneof=offset cursor"`
+	Cursor    string  `query:"cursor" validate:"omitempty,max=512"`
+
+	IncludeDeleted bool   `query:"include_deleted"`
+	Category       string `query:"category" validate:"omitempty,max=60"`
+	Tag            string `query:"tag" validate:"omitempty,max=60"`
+	Currency       string `query:"currency" validate:"omitempty,iso4217"`
+	// InStock hide the cakes without any stock on hand, untracked cakes are hidden too. The live reservations are kept
+	// in redis and ignored, a cake whose whole stock is reserved is still listed and reserving more of it fail
+	InStock bool `query:"in_stock"`
+	// ExcludeAllergens is a comma separated list of allergens the listed cakes must be free of
+	ExcludeAllergens string `query:"exclude_allergens" validate:"omitempty,max=200"`
+	// StoreId list only the cakes available in the store, at the store prices
+	StoreId int `query:"store_id" validate:"omitempty,min=1"`
+
+	// Trashed list only the soft deleted cakes
+	Trashed bool
+	// After is the decoded Cursor, the listing continue after this cake
+	After *CakeCursor
+}
+
+// CakeCursor is the keyset of the last cake on a page, following the order of rating DESC, title ASC, id ASC
+type CakeCursor struct {
+	Rating float32 `json:"r"`
+	Title  string  `json:"t"`
+	Id     int     `json:"i"`
+}
+
+func (c *CakeQuery) Validate() error {
+	return validate.Struct(c)
+}
+
+// ExcludedAllergens split the excluded allergens, ok is false when one of them is not a known allergen
+func (c *CakeQuery) ExcludedAllergens() (allergens []string, ok bool) {
+	for _, allergen := range strings.Split(c.ExcludeAllergens, ",") {
+		allergen = strings.ToLower(strings.TrimSpace(allergen))
+		if allergen == "" {
+			continue
+		}
+		if !IsAllergen(allergen) {
+			return nil, false
+		}
+		allergens = append(allergens, allergen)
+	}
+	return allergens, true
+}
+
+// IsCursor report whether the keyset pagination mode is requested
+func (c *CakeQuery) IsCursor() bool {
+	return c.Paginate == PaginateCursor || c.Cursor != ""
+}
+
+// SetDefault fill the empty page, limit and sort direction
+func (c *CakeQuery) SetDefault() {
+	if c.IsCursor() {
+		c.Paginate = PaginateCursor
+		c.Page = 0
+	} else if c.Page == 0 {
+		c.Page = DefaultPage
+	}
+	if c.Limit == 0 {
+		c.Limit = DefaultLimit
+	}
+	if c.SortBy != "" && c.SortDir == "" {
+		c.SortDir = "asc"
+	}
+}
+
 type Cake struct {
-	Id          int        `json:"id"`
-	Title       string     `json:"title"`
-	Description string     `json:"description"`
+	Id          int    `json:"id"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	// Rating is computed from the reviews, see NewCakeRating, a cake rated before the reviews keep its typed in rating
+	// until one of its reviews is approved
 	Rating      float32    `json:"rating"`
+	RatingMean  float32    `json:"rating_mean"`
+	RatingCount int        `json:"rating_count"`
 	Image       string     `json:"image"`
+	Version     int        `json:"version"`
 	CreatedAt   time.Time  `json:"created_at"`
 	UpdatedAt   time.Time  `json:"updated_at"`
 	DeletedAt   *time.Time `json:"deleted_at"`
+
+	Categories []*Term    `json:"categories,omitempty"`
+	Tags       []*Term    `json:"tags,omitempty"`
+	Variants   []*Variant `json:"variants,omitempty"`
+
+	Ingredients []*CakeIngredient `json:"ingredients,omitempty"`
+	// Allergens is null when the ingredients of the cake are unknown, see SetIngredients
+	Allergens []string   `json:"allergens"`
+	Nutrition *Nutrition `json:"nutrition,omitempty"`
+
+	// Available is whether the store sell the cake, it is only set on the cake found in a store
+	Available *bool `json:"available,omitempty"`
+}
+
+// ETag return the entity tag of the cake current version
+func (c *Cake) ETag() string {
+	return fmt.Sprintf("\"%d\"", c.Version)
+}
+
+// IfMatch is the cake versions listed by the If-Match header, empty when the client expect no version
+type IfMatch []int
+
+// Matches report whether the version is one of the expected versions, any version match when none is expected
+func (m IfMatch) Matches(version int) bool {
+	if len(m) == 0 {
+		return true
+	}
+	for _, v := range m {
+		if v == version {
+			return true
+		}
+	}
+	return false
+}
+
+// CakeListETag return the weak entity tag of a cake page, it change whenever a listed cake or the total change
+func CakeListETag(cakes []*Cake, pagination *Pagination) string {
+	hash := fnv.New64a()
+	for _, cake := range cakes {
+		fmt.Fprintf(hash, "%d:%d,", cake.Id, cake.Version)
+		// the prices may be converted to the requested currency
+		for _, variant := range cake.Variants {
+			fmt.Fprintf(hash, "%d%s,", variant.Price.Amount, variant.Price.Currency)
+		}
+	}
+	if pagination != nil {
+		fmt.Fprintf(hash, "%d:%s", pagination.Total, pagination.NextCursor)
+	}
+	return fmt.Sprintf("W/\"%x\"", hash.Sum64())
 }
 
 type CakeRepository interface {
 	Save(ctx context.Context, cake *Cake) error
 	Update(ctx context.Context, cake *Cake) error
 	Delete(ctx context.Context, cake *Cake) error
-	FindAll(ctx context.Context) ([]*Cake, error)
+	FindAll(ctx context.Context, query CakeQuery) ([]*Cake, error)
+	CountAll(ctx context.Context, query CakeQuery) (int64, error)
 	FindById(ctx context.Context, id int) (*Cake, error)
+	// FindByIdInStore find the cake with its availability in the store
+	FindByIdInStore(ctx context.Context, id int, storeId int) (*Cake, error)
+	FindByIdUnscoped(ctx context.Context, id int) (*Cake, error)
+	Restore(ctx context.Context, cake *Cake) error
+	Purge(ctx context.Context, cake *Cake) error
+	CountDeletedBefore(ctx context.Context, before time.Time) (int64, error)
+	PurgeDeletedBefore(ctx context.Context, before time.Time, limit int) (int64, error)
+	Touch(ctx context.Context, ids ...int) error
 }
 
 type CakeService interface {
 	Create(ctx context.Context, req CreateUpdateRequest) (*Cake, error)
-	Update(ctx context.Context, req CreateUpdateRequest, cakeId int) (*Cake, error)
-	Delete(ctx context.Context, cakeId int) (*Cake, error)
+	Update(ctx context.Context, req CreateUpdateRequest, cakeId int, ifMatch IfMatch) (*Cake, error)
+	Patch(ctx context.Context, req PatchRequest, cakeId int, ifMatch IfMatch) (*Cake, error)
+	Delete(ctx context.Context, cakeId int, ifMatch IfMatch) (*Cake, error)
+	Restore(ctx context.Context, cakeId int, ifMatch IfMatch) (*Cake, error)
+	Purge(ctx context.Context, cakeId int, ifMatch IfMatch) (*Cake, error)
+	PurgeExpired(ctx context.Context, opt PurgeOption) (*PurgeSummary, error)
 	FindById(ctx context.Context, cakeId int) (*Cake, error)
-	FindAll(ctx context.Context) ([]*Cake, error)
+	FindByIdInStore(ctx context.Context, cakeId int, storeId int) (*Cake, error)
+	// FindVersion find the cake without its relations, to answer a revalidation from the cache
+	FindVersion(ctx context.Context, cakeId int) (*Cake, error)
+	FindAll(ctx context.Context, query CakeQuery) ([]*Cake, *Pagination, error)
 }
 
 type CakeController interface {
 	HandleCreate() echo.HandlerFunc
 	HandleUpdate() echo.HandlerFunc
+	HandlePatch() echo.HandlerFunc
 	HandleDelete() echo.HandlerFunc
 	HandleFindById() echo.HandlerFunc
 	HandleFindAll() echo.HandlerFunc
+	HandleFindTrash() echo.HandlerFunc
+	HandleRestore() echo.HandlerFunc
 }
@@ -0,0 +1,169 @@
+package model
+
+import (
+	"context"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// cart limits, the items limits of an order
+const (
+	MaxCartLines        int = 50
+	MaxCartLineQuantity int = 100
+)
+
+type AddCartLineRequest struct {
+	CakeId    int    `json:"cake_id" validate:"gt=0"`
+	VariantId int    `json:"variant_id" validate:"gt=0"`
+	Quantity  int    `json:"quantity" validate:"gte=1,lte=100"`
+	Message   string `json:"message" validate:"max=100"`
+}
+
+func (a *AddCartLineRequest) Validate() error {
+	return validate.Struct(a)
+}
+
+type UpdateCartLineRequest struct {
+	Quantity int    `json:"quantity" validate:"gte=1,lte=100"`
+	Message  string `json:"message" validate:"max=100"`
+}
+
+func (u *UpdateCartLineRequest) Validate() error {
+	return validate.Struct(u)
+}
+
+// SetCartStoreRequest price the cart in the store it will be checked out in
+type SetCartStoreRequest struct {
+	StoreId int `json:"store_id" validate:"required,min=1"`
+}
+
+func (s *SetCartStoreRequest) Validate() error {
+	return validate.Struct(s)
+}
+
+// CheckoutCartRequest place the cart as an order in the store, the store of the cart when StoreId is empty
+type CheckoutCartRequest struct {
+	StoreId       int    `json:"store_id" validate:"omitempty,min=1"`
+	CustomerName  string `json:"customer_name" validate:"required,max=100"`
+	CustomerPhone string `json:"customer_phone" validate:"required,max=30"`
+	Fulfillment   string `json:"fulfillment" validate:"required,oneof=pickup delivery"`
+	Note          string `json:"note" validate:"max=255"`
+	PickupDate    string `json:"pickup_date" validate:"omitempty,datetime=2006-01-02"`
+	PickupTime    string `json:"pickup_time" validate:"omitempty,datetime=15:04"`
+}
+
+func (c *CheckoutCartRequest) Validate() error {
+	return validate.Struct(c)
+}
+
+type SetCartCouponsRequest struct {
+	Codes []string `json:"codes" validate:"max=5,dive,required,max=40"`
+}
+
+func (s *SetCartCouponsRequest) Validate() error {
+	return validate.Struct(s)
+}
+
+// Cart is a customer basket kept in redis until checked out or expired, the id is the secret of the customer
+type Cart struct {
+	Id string `json:"id"`
+	// StoreId is the store the cart is priced in, the default store when empty
+	StoreId   int         `json:"store_id,omitempty"`
+	Lines     []*CartLine `json:"lines"`
+	Coupons   []string    `json:"coupons"`
+	Subtotal  *Money      `json:"subtotal,omitempty"`
+	Discount  *Discount   `json:"discount,omitempty"`
+	Total     *Money      `json:"total,omitempty"`
+	UpdatedAt time.Time   `json:"updated_at"`
+	ExpiresAt time.Time   `json:"expires_at"`
+}
+
+// FindLine find the line of the cart by id
+func (c *Cart) FindLine(lineId int) *CartLine {
+	for _, line := range c.Lines {
+		if line.Id == lineId {
+			return line
+		}
+	}
+	return nil
+}
+
+// AddLine add the line to the cart, a line of the same variant and message only increase the quantity
+func (c *Cart) AddLine(line *CartLine) *CartLine {
+	for _, existing := range c.Lines {
+		if existing.CakeId == line.CakeId && existing.VariantId == line.VariantId && existing.Message == line.Message {
+			existing.Quantity += line.Quantity
+			return existing
+		}
+	}
+
+	line.Id = 1
+	for _, existing := range c.Lines {
+		if existing.Id >= line.Id {
+			line.Id = existing.Id + 1
+		}
+	}
+	c.Lines = append(c.Lines, line)
+	return line
+}
+
+// RemoveLine remove the line of the cart, return false when the cart has no such line
+func (c *Cart) RemoveLine(lineId int) bool {
+	for i, line := range c.Lines {
+		if line.Id == lineId {
+			c.Lines = append(c.Lines[:i], c.Lines[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// CartLine is a cart entry, the title, size and prices are filled from the live catalog when the cart is read
+type CartLine struct {
+	Id        int    `json:"id"`
+	CakeId    int    `json:"cake_id"`
+	VariantId int    `json:"variant_id"`
+	Quantity  int    `json:"quantity"`
+	Message   string `json:"message"`
+
+	Title     string `json:"title,omitempty"`
+	Size      string `json:"size,omitempty"`
+	UnitPrice *Money `json:"unit_price,omitempty"`
+	Subtotal  *Money `json:"subtotal,omitempty"`
+	// Available is false when the cake or the variant is no longer sold, or not sold in the store of the cart
+	Available bool `json:"available"`
+}
+
+type CartRepository interface {
+	Save(ctx context.Context, cart *Cart) error
+	// Update apply the edit to the stored cart atomically, the edit is run again when the cart changed meanwhile
+	Update(ctx context.Context, id string, edit func(cart *Cart) error) (*Cart, error)
+	FindById(ctx context.Context, id string) (*Cart, error)
+	Delete(ctx context.Context, id string) error
+	Claim(ctx context.Context, id string) (*Cart, error)
+}
+
+type CartService interface {
+	Create(ctx context.Context) (*Cart, error)
+	FindById(ctx context.Context, cartId string) (*Cart, error)
+	AddLine(ctx context.Context, req AddCartLineRequest, cartId string) (*Cart, error)
+	UpdateLine(ctx context.Context, req UpdateCartLineRequest, cartId string, lineId int) (*Cart, error)
+	RemoveLine(ctx context.Context, cartId string, lineId int) (*Cart, error)
+	Delete(ctx context.Context, cartId string) error
+	SetCoupons(ctx context.Context, req SetCartCouponsRequest, cartId string) (*Cart, error)
+	SetStore(ctx context.Context, req SetCartStoreRequest, cartId string) (*Cart, error)
+	Checkout(ctx context.Context, req CheckoutCartRequest, cartId string) (*Order, error)
+}
+
+type CartController interface {
+	HandleCreate() echo.HandlerFunc
+	HandleFindById() echo.HandlerFunc
+	HandleAddLine() echo.HandlerFunc
+	HandleUpdateLine() echo.HandlerFunc
+	HandleRemoveLine() echo.HandlerFunc
+	HandleDelete() echo.HandlerFunc
+	HandleSetCoupons() echo.HandlerFunc
+	HandleSetStore() echo.HandlerFunc
+	HandleCheckout() echo.HandlerFunc
+}
@@ -0,0 +1,217 @@
+package model
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// coupon type
+const (
+	CouponTypePercentage string = "percentage"
+	CouponTypeFixed      string = "fixed"
+	CouponTypeBuyXGetY   string = "buy_x_get_y"
+)
+
+// MaxCoupons is the number of coupon codes applied together to a cart or an order
+const MaxCoupons int = 5
+
+// coupon rejection reason
+const (
+	CouponReasonNotFound     string = "coupon does not exist"
+	CouponReasonInactive     string = "coupon is not active"
+	CouponReasonNotStarted   string = "coupon is not valid yet"
+	CouponReasonExpired      string = "coupon has expired"
+	CouponReasonUsedUp       string = "coupon usage limit reached"
+	CouponReasonMinSubtotal  string = "subtotal is below the coupon minimum"
+	CouponReasonNoItems      string = "no item qualifies for the coupon"
+	CouponReasonNotStackable string = "coupon cannot be combined with other coupons"
+	CouponReasonDuplicate    string = "coupon is applied more than once"
+)
+
+type CreateUpdateCouponRequest struct {
+	Code string `json:"code" validate:"required,max=40,sku"`
+	Type string `json:"type" validate:"required,oneof=percentage fixed buy_x_get_y"`
+	// Value is the percent off of a percentage coupon, or the amount off of a fixed coupon in minor units of the base currency
+	Value int64 `json:"value" validate:"gte=0"`
+	// BuyQuantity and GetQuantity make every group of buy + get items give the cheapest get items for free
+	BuyQuantity int `json:"buy_quantity" validate:"required_if=Type buy_x_get_y,gte=0,lte=100"`
+	GetQuantity int `json:"get_quantity" validate:"required_if=Type buy_x_get_y,gte=0,lte=100"`
+	// CategoryId scope the coupon to the cakes of the category, zero apply to every item
+	CategoryId int `json:"category_id" validate:"gte=0"`
+	// MinSubtotal is in minor units of the base currency
+	MinSubtotal int64      `json:"min_subtotal" validate:"gte=0"`
+	StartsAt    *time.Time `json:"starts_at"`
+	EndsAt      *time.Time `json:"ends_at"`
+	// UsageLimit is the number of orders the coupon may be redeemed on, zero is unlimited
+	UsageLimit int   `json:"usage_limit" validate:"gte=0"`
+	Stackable  bool  `json:"stackable"`
+	Active     *bool `json:"active"`
+}
+
+func (c *CreateUpdateCouponRequest) Validate() error {
+	return validate.Struct(c)
+}
+
+// ValidateCouponRequest check the codes against the items priced in the store, the default store when StoreId is empty
+type ValidateCouponRequest struct {
+	StoreId int                      `json:"store_id" validate:"omitempty,min=1"`
+	Codes   []string                 `json:"codes" validate:"required,min=1,max=5,dive,required,max=40"`
+	Items   []CreateOrderItemRequest `json:"items" validate:"required,min=1,max=50,dive"`
+}
+
+func (v *ValidateCouponRequest) Validate() error {
+	return validate.Struct(v)
+}
+
+type Coupon struct {
+	Id          int        `json:"id"`
+	Code        string     `json:"code"`
+	Type        string     `json:"type"`
+	Value       int64      `json:"value"`
+	BuyQuantity int        `json:"buy_quantity"`
+	GetQuantity int        `json:"get_quantity"`
+	CategoryId  int        `json:"category_id"`
+	MinSubtotal int64      `json:"min_subtotal"`
+	StartsAt    *time.Time `json:"starts_at"`
+	EndsAt      *time.Time `json:"ends_at"`
+	UsageLimit  int        `json:"usage_limit"`
+	Stackable   bool       `json:"stackable"`
+	Active      bool       `json:"active"`
+	// Used is the redemption count kept in redis
+	Used      int       `json:"used"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Reject return why the coupon cannot be used at the time on the subtotal, or an empty reason when it can
+func (c *Coupon) Reject(now time.Time, subtotal int64) string {
+	switch {
+	case !c.Active:
+		return CouponReasonInactive
+	case c.StartsAt != nil && now.Before(*c.StartsAt):
+		return CouponReasonNotStarted
+	case c.EndsAt != nil && !now.Before(*c.EndsAt):
+		return CouponReasonExpired
+	case c.UsageLimit > 0 && c.Used >= c.UsageLimit:
+		return CouponReasonUsedUp
+	case subtotal < c.MinSubtotal:
+		return CouponReasonMinSubtotal
+	}
+	return ""
+}
+
+// Discount compute the amount off the eligible lines in minor units, the amount never exceed the lines subtotal
+func (c *Coupon) Discount(lines []*DiscountLine) int64 {
+	var subtotal int64
+	for _, line := range lines {
+		subtotal += line.UnitPrice.Amount * int64(line.Quantity)
+	}
+
+	var amount int64
+	switch c.Type {
+	case CouponTypePercentage:
+		amount = subtotal * c.Value / 100
+	case CouponTypeFixed:
+		amount = c.Value
+	case CouponTypeBuyXGetY:
+		amount = c.freeItems(lines)
+	}
+
+	if amount > subtotal {
+		return subtotal
+	}
+	return amount
+}
+
+// freeItems total the cheapest units given away, every buy + get units give get units for free
+func (c *Coupon) freeItems(lines []*DiscountLine) int64 {
+	group := c.BuyQuantity + c.GetQuantity
+	if c.GetQuantity <= 0 || group <= 0 {
+		return 0
+	}
+
+	units := make([]int64, 0)
+	for _, line := range lines {
+		for i := 0; i < line.Quantity; i++ {
+			units = append(units, line.UnitPrice.Amount)
+		}
+	}
+	sort.Slice(units, func(i, j int) bool { return units[i] < units[j] })
+
+	var amount int64
+	free := len(units) / group * c.GetQuantity
+	for _, unit := range units[:free] {
+		amount += unit
+	}
+	return amount
+}
+
+// DiscountLine is a priced line the coupons are applied to, the unit price is in the base currency
+type DiscountLine struct {
+	CakeId    int
+	Quantity  int
+	UnitPrice Money
+}
+
+// Discount is the outcome of applying coupon codes to priced lines
+type Discount struct {
+	Subtotal Money            `json:"subtotal"`
+	Amount   Money            `json:"amount"`
+	Total    Money            `json:"total"`
+	Coupons  []*AppliedCoupon `json:"coupons"`
+}
+
+// Rejected return the first rejected coupon, or nil when every coupon applied
+func (d *Discount) Rejected() *AppliedCoupon {
+	for _, coupon := range d.Coupons {
+		if !coupon.Valid {
+			return coupon
+		}
+	}
+	return nil
+}
+
+// AppliedCoupon explain the outcome of a coupon code, the reason is set when the coupon is rejected
+type AppliedCoupon struct {
+	Code     string  `json:"code"`
+	Valid    bool    `json:"valid"`
+	Reason   string  `json:"reason,omitempty"`
+	Discount Money   `json:"discount"`
+	Coupon   *Coupon `json:"-"`
+}
+
+type CouponRepository interface {
+	Save(ctx context.Context, coupon *Coupon) error
+	Update(ctx context.Context, coupon *Coupon) error
+	Delete(ctx context.Context, coupon *Coupon) error
+	FindById(ctx context.Context, id int) (*Coupon, error)
+	FindByCodes(ctx context.Context, codes []string) ([]*Coupon, error)
+	FindAll(ctx context.Context) ([]*Coupon, error)
+	LoadUsage(ctx context.Context, coupons []*Coupon) error
+	Redeem(ctx context.Context, coupon *Coupon) (bool, error)
+	Release(ctx context.Context, couponId int) error
+}
+
+type CouponService interface {
+	Create(ctx context.Context, req CreateUpdateCouponRequest) (*Coupon, error)
+	Update(ctx context.Context, req CreateUpdateCouponRequest, couponId int) (*Coupon, error)
+	Delete(ctx context.Context, couponId int) (*Coupon, error)
+	FindById(ctx context.Context, couponId int) (*Coupon, error)
+	FindAll(ctx context.Context) ([]*Coupon, error)
+	Validate(ctx context.Context, req ValidateCouponRequest) (*Discount, error)
+	Apply(ctx context.Context, codes []string, lines []*DiscountLine) (*Discount, error)
+	Redeem(ctx context.Context, discount *Discount) error
+	Release(ctx context.Context, couponIds ...int) error
+}
+
+type CouponController interface {
+	HandleCreate() echo.HandlerFunc
+	HandleUpdate() echo.HandlerFunc
+	HandleDelete() echo.HandlerFunc
+	HandleFindById() echo.HandlerFunc
+	HandleFindAll() echo.HandlerFunc
+	HandleValidate() echo.HandlerFunc
+}
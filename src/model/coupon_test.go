@@ -0,0 +1,69 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCoupon_Reject(t *testing.T) {
+	now := time.Now()
+	past := now.Add(-time.Hour)
+	future := now.Add(time.Hour)
+
+	cases := []struct {
+		name     string
+		coupon   Coupon
+		subtotal int64
+		reason   string
+	}{
+		{"ok", Coupon{Active: true}, 100000, ""},
+		{"ok - in window", Coupon{Active: true, StartsAt: &past, EndsAt: &future}, 100000, ""},
+		{"inactive", Coupon{Active: false}, 100000, CouponReasonInactive},
+		{"not started", Coupon{Active: true, StartsAt: &future}, 100000, CouponReasonNotStarted},
+		{"expired", Coupon{Active: true, EndsAt: &past}, 100000, CouponReasonExpired},
+		{"used up", Coupon{Active: true, UsageLimit: 10, Used: 10}, 100000, CouponReasonUsedUp},
+		{"unlimited", Coupon{Active: true, UsageLimit: 0, Used: 10}, 100000, ""},
+		{"below minimum", Coupon{Active: true, MinSubtotal: 200000}, 100000, CouponReasonMinSubtotal},
+	}
+
+	for _, c := range cases {
+		assert.Equal(t, c.reason, c.coupon.Reject(now, c.subtotal), c.name)
+	}
+}
+
+func TestCoupon_Discount(t *testing.T) {
+	lines := []*DiscountLine{
+		{CakeId: 1, Quantity: 2, UnitPrice: NewMoney(250000, "IDR")},
+		{CakeId: 2, Quantity: 1, UnitPrice: NewMoney(100000, "IDR")},
+	}
+
+	cases := []struct {
+		name   string
+		coupon Coupon
+		amount int64
+	}{
+		{"percentage", Coupon{Type: CouponTypePercentage, Value: 10}, 60000},
+		{"fixed", Coupon{Type: CouponTypeFixed, Value: 75000}, 75000},
+		{"fixed above subtotal", Coupon{Type: CouponTypeFixed, Value: 1000000}, 600000},
+		{"buy 2 get 1", Coupon{Type: CouponTypeBuyXGetY, BuyQuantity: 2, GetQuantity: 1}, 100000},
+		{"buy 1 get 1", Coupon{Type: CouponTypeBuyXGetY, BuyQuantity: 1, GetQuantity: 1}, 100000},
+		{"buy 3 get 1", Coupon{Type: CouponTypeBuyXGetY, BuyQuantity: 3, GetQuantity: 1}, 0},
+	}
+
+	for _, c := range cases {
+		assert.Equal(t, c.amount, c.coupon.Discount(lines), c.name)
+	}
+}
+
+func TestDiscount_Rejected(t *testing.T) {
+	discount := &Discount{Coupons: []*AppliedCoupon{
+		{Code: "A", Valid: true},
+		{Code: "B", Reason: CouponReasonExpired},
+	}}
+	assert.Equal(t, "B", discount.Rejected().Code)
+
+	discount.Coupons = discount.Coupons[:1]
+	assert.Nil(t, discount.Rejected())
+}
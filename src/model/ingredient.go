@@ -0,0 +1,233 @@
+package model
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// allergens that must be declared, following the 14 allergens of the EU food information regulation
+const (
+	AllergenGluten      = "gluten"
+	AllergenCrustaceans = "crustaceans"
+	AllergenEgg         = "egg"
+	AllergenFish        = "fish"
+	AllergenPeanuts     = "peanuts"
+	AllergenSoy         = "soy"
+	AllergenDairy       = "dairy"
+	AllergenNuts        = "nuts"
+	AllergenCelery      = "celery"
+	AllergenMustard     = "mustard"
+	AllergenSesame      = "sesame"
+	AllergenSulphites   = "sulphites"
+	AllergenLupin       = "lupin"
+	AllergenMolluscs    = "molluscs"
+)
+
+// Allergens is every allergen in the order they are declared
+var Allergens = []string{
+	AllergenGluten, AllergenCrustaceans, AllergenEgg, AllergenFish, AllergenPeanuts, AllergenSoy, AllergenDairy,
+	AllergenNuts, AllergenCelery, AllergenMustard, AllergenSesame, AllergenSulphites, AllergenLupin, AllergenMolluscs,
+}
+
+func IsAllergen(allergen string) bool {
+	for _, a := range Allergens {
+		if a == allergen {
+			return true
+		}
+	}
+	return false
+}
+
+// SortAllergens return the known allergens of the list once each, in their declaration order
+func SortAllergens(allergens []string) []string {
+	contained := make(map[string]bool, len(allergens))
+	for _, allergen := range allergens {
+		contained[allergen] = true
+	}
+
+	sorted := make([]string, 0, len(contained))
+	for _, allergen := range Allergens {
+		if contained[allergen] {
+			sorted = append(sorted, allergen)
+		}
+	}
+	return sorted
+}
+
+// NutritionFacts is the nutrition of an ingredient per 100 g, or of a whole cake, the masses are in grams
+type NutritionFacts struct {
+	EnergyKcal   float64 `json:"energy_kcal" validate:"gte=0,lte=900"`
+	Fat          float64 `json:"fat" validate:"gte=0,lte=100"`
+	Carbohydrate float64 `json:"carbohydrate" validate:"gte=0,lte=100"`
+	Sugar        float64 `json:"sugar" validate:"gte=0,ltefield=Carbohydrate"`
+	Protein      float64 `json:"protein" validate:"gte=0,lte=100"`
+	Salt         float64 `json:"salt" validate:"gte=0,lte=100"`
+}
+
+func (n NutritionFacts) plus(other NutritionFacts) NutritionFacts {
+	return NutritionFacts{
+		EnergyKcal:   n.EnergyKcal + other.EnergyKcal,
+		Fat:          n.Fat + other.Fat,
+		Carbohydrate: n.Carbohydrate + other.Carbohydrate,
+		Sugar:        n.Sugar + other.Sugar,
+		Protein:      n.Protein + other.Protein,
+		Salt:         n.Salt + other.Salt,
+	}
+}
+
+func (n NutritionFacts) scale(factor float64) NutritionFacts {
+	return NutritionFacts{
+		EnergyKcal:   n.EnergyKcal * factor,
+		Fat:          n.Fat * factor,
+		Carbohydrate: n.Carbohydrate * factor,
+		Sugar:        n.Sugar * factor,
+		Protein:      n.Protein * factor,
+		Salt:         n.Salt * factor,
+	}
+}
+
+func (n NutritionFacts) round() NutritionFacts {
+	return NutritionFacts{
+		EnergyKcal:   round1(n.EnergyKcal),
+		Fat:          round1(n.Fat),
+		Carbohydrate: round1(n.Carbohydrate),
+		Sugar:        round1(n.Sugar),
+		Protein:      round1(n.Protein),
+		Salt:         round1(n.Salt),
+	}
+}
+
+func round1(value float64) float64 {
+	return math.Round(value*10) / 10
+}
+
+// Nutrition is the nutrition summary of a cake computed from its ingredients, Weight is in grams
+type Nutrition struct {
+	Weight  float64        `json:"weight"`
+	Total   NutritionFacts `json:"total"`
+	Per100g NutritionFacts `json:"per_100g"`
+}
+
+type CreateUpdateIngredientRequest struct {
+	Name      string         `json:"name" validate:"required,min=2,max=60"`
+	Allergens []string       `json:"allergens" validate:"max=14,dive,allergen"`
+	Density   float64        `json:"density" validate:"gte=0,lte=25"`
+	Nutrition NutritionFacts `json:"nutrition"`
+}
+
+func (c *CreateUpdateIngredientRequest) Validate() error {
+	return validate.Struct(c)
+}
+
+type CakeIngredientRequest struct {
+	IngredientId int     `json:"ingredient_id" validate:"gt=0"`
+	Quantity     float64 `json:"quantity" validate:"gt=0,lte=100000"`
+}
+
+// SetCakeIngredientsRequest replace the ingredient list of a cake, the quantities are in grams
+type SetCakeIngredientsRequest struct {
+	Ingredients []CakeIngredientRequest `json:"ingredients" validate:"max=50,dive"`
+}
+
+func (s *SetCakeIngredientsRequest) Validate() error {
+	return validate.Struct(s)
+}
+
+// IngredientIds return the id of each requested ingredient, ok is false when an ingredient is listed twice
+func (s *SetCakeIngredientsRequest) IngredientIds() (ids []int, ok bool) {
+	seen := make(map[int]bool, len(s.Ingredients))
+	ids = make([]int, 0, len(s.Ingredients))
+	for _, ingredient := range s.Ingredients {
+		if seen[ingredient.IngredientId] {
+			return nil, false
+		}
+		seen[ingredient.IngredientId] = true
+		ids = append(ids, ingredient.IngredientId)
+	}
+	return ids, true
+}
+
+// Ingredient is an ingredient of the catalog, Density is in g/ml and zero when unknown
+type Ingredient struct {
+	Id        int            `json:"id"`
+	Name      string         `json:"name"`
+	Allergens []string       `json:"allergens"`
+	Density   float64        `json:"density"`
+	Nutrition NutritionFacts `json:"nutrition"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+}
+
+// CakeIngredient is an ingredient of a cake and its quantity in grams
+type CakeIngredient struct {
+	IngredientId int            `json:"ingredient_id"`
+	Name         string         `json:"name"`
+	Quantity     float64        `json:"quantity"`
+	Allergens    []string       `json:"allergens"`
+	Nutrition    NutritionFacts `json:"-"`
+}
+
+// SetIngredients embed the ingredients and summarize their allergens and nutrition, a cake without ingredients keep
+// nil summaries as its allergens are unknown rather than none
+func (c *Cake) SetIngredients(ingredients []*CakeIngredient) {
+	c.Ingredients = ingredients
+	c.Allergens = nil
+	c.Nutrition = nil
+	if len(ingredients) == 0 {
+		return
+	}
+
+	var allergens []string
+	nutrition := &Nutrition{}
+	for _, ingredient := range ingredients {
+		allergens = append(allergens, ingredient.Allergens...)
+		nutrition.Weight += ingredient.Quantity
+		nutrition.Total = nutrition.Total.plus(ingredient.Nutrition.scale(ingredient.Quantity / 100))
+	}
+
+	c.Allergens = SortAllergens(allergens)
+
+	nutrition.Per100g = nutrition.Total.scale(100 / nutrition.Weight).round()
+	nutrition.Total = nutrition.Total.round()
+	nutrition.Weight = round1(nutrition.Weight)
+	c.Nutrition = nutrition
+}
+
+type IngredientRepository interface {
+	Save(ctx context.Context, ingredient *Ingredient) error
+	Update(ctx context.Context, ingredient *Ingredient) error
+	Delete(ctx context.Context, ingredient *Ingredient) error
+	FindAll(ctx context.Context) ([]*Ingredient, error)
+	FindById(ctx context.Context, id int) (*Ingredient, error)
+	FindByIds(ctx context.Context, ids []int) ([]*Ingredient, error)
+	FindCakeIds(ctx context.Context, ingredientId int) ([]int, error)
+	SetCakeIngredients(ctx context.Context, cakeId int, ingredients []*CakeIngredient) error
+	LoadCakes(ctx context.Context, cakes []*Cake) error
+	FindPrices(ctx context.Context, ingredientIds []int) ([]*SupplierPrice, error)
+	SetPrices(ctx context.Context, ingredientId int, prices []*SupplierPrice) error
+}
+
+type IngredientService interface {
+	Create(ctx context.Context, req CreateUpdateIngredientRequest) (*Ingredient, error)
+	Update(ctx context.Context, req CreateUpdateIngredientRequest, ingredientId int) (*Ingredient, error)
+	Delete(ctx context.Context, ingredientId int) (*Ingredient, error)
+	FindById(ctx context.Context, ingredientId int) (*Ingredient, error)
+	FindAll(ctx context.Context) ([]*Ingredient, error)
+	SetCakeIngredients(ctx context.Context, req SetCakeIngredientsRequest, cakeId int) (*Cake, error)
+	FindPrices(ctx context.Context, ingredientId int) ([]*SupplierPrice, error)
+	SetPrices(ctx context.Context, req SetSupplierPricesRequest, ingredientId int) ([]*SupplierPrice, error)
+}
+
+type IngredientController interface {
+	HandleCreate() echo.HandlerFunc
+	HandleUpdate() echo.HandlerFunc
+	HandleDelete() echo.HandlerFunc
+	HandleFindById() echo.HandlerFunc
+	HandleFindAll() echo.HandlerFunc
+	HandleSetCakeIngredients() echo.HandlerFunc
+	HandleFindPrices() echo.HandlerFunc
+	HandleSetPrices() echo.HandlerFunc
+}
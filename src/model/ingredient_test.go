@@ -0,0 +1,80 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSortAllergens(t *testing.T) {
+	assert.Equal(t, []string{AllergenGluten, AllergenEgg, AllergenNuts}, SortAllergens([]string{"nuts", "gluten", "egg", "nuts", "unknown"}))
+	assert.Equal(t, []string{}, SortAllergens(nil))
+}
+
+func TestCake_SetIngredients(t *testing.T) {
+	t.Run("summaries", func(t *testing.T) {
+		cake := &Cake{}
+		cake.SetIngredients([]*CakeIngredient{
+			{
+				Name:      "Flour",
+				Quantity:  300,
+				Allergens: []string{AllergenGluten},
+				Nutrition: NutritionFacts{EnergyKcal: 364, Fat: 1, Carbohydrate: 76, Sugar: 0.3, Protein: 10, Salt: 0},
+			},
+			{
+				Name:      "Butter",
+				Quantity:  100,
+				Allergens: []string{AllergenDairy},
+				Nutrition: NutritionFacts{EnergyKcal: 717, Fat: 81, Carbohydrate: 0.1, Sugar: 0.1, Protein: 0.9, Salt: 1.6},
+			},
+			{
+				Name:      "Milk Chocolate",
+				Quantity:  100,
+				Allergens: []string{AllergenDairy, AllergenSoy},
+				Nutrition: NutritionFacts{EnergyKcal: 535, Fat: 30, Carbohydrate: 59, Sugar: 52, Protein: 8, Salt: 0.2},
+			},
+		})
+
+		assert.Equal(t, []string{AllergenGluten, AllergenSoy, AllergenDairy}, cake.Allergens)
+		assert.Equal(t, &Nutrition{
+			Weight:  500,
+			Total:   NutritionFacts{EnergyKcal: 2344, Fat: 114, Carbohydrate: 287.1, Sugar: 53, Protein: 38.9, Salt: 1.8},
+			Per100g: NutritionFacts{EnergyKcal: 468.8, Fat: 22.8, Carbohydrate: 57.4, Sugar: 10.6, Protein: 7.8, Salt: 0.4},
+		}, cake.Nutrition)
+	})
+
+	t.Run("allergen free", func(t *testing.T) {
+		cake := &Cake{}
+		cake.SetIngredients([]*CakeIngredient{{Name: "Sugar", Quantity: 100, Allergens: []string{}}})
+		assert.Equal(t, []string{}, cake.Allergens)
+	})
+
+	t.Run("unknown without ingredients", func(t *testing.T) {
+		cake := &Cake{Allergens: []string{AllergenNuts}, Nutrition: &Nutrition{}}
+		cake.SetIngredients(nil)
+		assert.Nil(t, cake.Allergens)
+		assert.Nil(t, cake.Nutrition)
+	})
+}
+
+func TestCakeQuery_ExcludedAllergens(t *testing.T) {
+	query := CakeQuery{ExcludeAllergens: " Nuts,gluten,,"}
+	allergens, ok := query.ExcludedAllergens()
+	assert.True(t, ok)
+	assert.Equal(t, []string{AllergenNuts, AllergenGluten}, allergens)
+
+	query = CakeQuery{ExcludeAllergens: "nuts,chocolate"}
+	_, ok = query.ExcludedAllergens()
+	assert.False(t, ok)
+}
+
+func TestSetCakeIngredientsRequest_IngredientIds(t *testing.T) {
+	req := SetCakeIngredientsRequest{Ingredients: []CakeIngredientRequest{{IngredientId: 2}, {IngredientId: 5}}}
+	ids, ok := req.IngredientIds()
+	assert.True(t, ok)
+	assert.Equal(t, []int{2, 5}, ids)
+
+	req.Ingredients = append(req.Ingredients, CakeIngredientRequest{IngredientId: 2})
+	_, ok = req.IngredientIds()
+	assert.False(t, ok)
+}
@@ -0,0 +1,173 @@
+package model
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// ingredient stock adjustment reason
+const (
+	InventoryReasonPurchase    string = "purchase"
+	InventoryReasonConsumption string = "consumption"
+	InventoryReasonWaste       string = "waste"
+	InventoryReasonCorrection  string = "correction"
+	InventoryReasonReturn      string = "return"
+)
+
+// AdjustInventoryRequest change the on hand quantity of an ingredient, the delta is in the stock unit when Unit is empty
+type AdjustInventoryRequest struct {
+	Delta  float64 `json:"delta" validate:"required,min=-1000000,max=1000000"`
+	Unit   string  `json:"unit" validate:"omitempty,unit"`
+	Reason string  `json:"reason" validate:"required,oneof=purchase waste correction"`
+	Note   string  `json:"note" validate:"max=255"`
+}
+
+func (a *AdjustInventoryRequest) Validate() error {
+	return validate.Struct(a)
+}
+
+// SetInventoryLevelsRequest set the unit the ingredient is counted in and when and how much of it to reorder
+type SetInventoryLevelsRequest struct {
+	Unit            string  `json:"unit" validate:"required,unit"`
+	ReorderPoint    float64 `json:"reorder_point" validate:"gte=0,lte=1000000"`
+	ReorderQuantity float64 `json:"reorder_quantity" validate:"gte=0,lte=1000000"`
+}
+
+func (s *SetInventoryLevelsRequest) Validate() error {
+	return validate.Struct(s)
+}
+
+// IngredientStock is the on hand quantity of an ingredient in its Unit. The quantity go below zero when the confirmed
+// orders consume more than was counted
+type IngredientStock struct {
+	IngredientId    int       `json:"ingredient_id"`
+	Name            string    `json:"name"`
+	OnHand          float64   `json:"on_hand"`
+	Unit            string    `json:"unit"`
+	ReorderPoint    float64   `json:"reorder_point"`
+	ReorderQuantity float64   `json:"reorder_quantity"`
+	LowStock        bool      `json:"low_stock"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+func (s *IngredientStock) SetLowStock() {
+	s.LowStock = s.OnHand <= s.ReorderPoint
+}
+
+// Shortfall is the quantity to buy, the reorder quantity or enough to get back to the reorder point when more is missing
+func (s *IngredientStock) Shortfall() float64 {
+	if !s.LowStock {
+		return 0
+	}
+	return round3(math.Max(s.ReorderQuantity, s.ReorderPoint-s.OnHand))
+}
+
+type InventoryAdjustment struct {
+	Id           int       `json:"id"`
+	IngredientId int       `json:"ingredient_id"`
+	OrderId      int       `json:"order_id,omitempty"`
+	Delta        float64   `json:"delta"`
+	Unit         string    `json:"unit"`
+	Reason       string    `json:"reason"`
+	Note         string    `json:"note"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// BaseUnit return the unit ingredients measured in the unit are counted in by default
+func BaseUnit(unit string) string {
+	switch units[unit].dimension {
+	case dimensionVolume:
+		return UnitMilliliter
+	case dimensionCount:
+		return UnitPiece
+	default:
+		return UnitGram
+	}
+}
+
+// Packs return the number of whole supplier quantities covering the quantity, ok is false when the units cannot be
+// converted
+func (s *SupplierPrice) Packs(quantity float64, unit string, density float64) (packs int, ok bool) {
+	converted, ok := ConvertQuantity(quantity, unit, s.Unit, density)
+	if !ok {
+		return 0, false
+	}
+	// round before the ceiling so a conversion error does not buy a whole extra pack
+	return int(math.Ceil(round3(converted / s.Quantity))), true
+}
+
+// ReorderQuery price the reorder report in the currency, the base currency when empty
+type ReorderQuery struct {
+	Currency string `query:"currency" validate:"omitempty,iso4217"`
+}
+
+func (r *ReorderQuery) Validate() error {
+	return validate.Struct(r)
+}
+
+// ReorderLine is the purchase suggested for a low ingredient, the Quantity is in the stock Unit. A line without
+// Supplier has no supplier price the quantity convert to
+type ReorderLine struct {
+	IngredientId int     `json:"ingredient_id"`
+	Name         string  `json:"name"`
+	OnHand       float64 `json:"on_hand"`
+	ReorderPoint float64 `json:"reorder_point"`
+	Quantity     float64 `json:"quantity"`
+	Unit         string  `json:"unit"`
+	Supplier     string  `json:"supplier,omitempty"`
+	Packs        int     `json:"packs,omitempty"`
+	PackQuantity float64 `json:"pack_quantity,omitempty"`
+	PackUnit     string  `json:"pack_unit,omitempty"`
+	Cost         *Money  `json:"cost,omitempty"`
+}
+
+// SupplierOrder is the purchases suggested from a supplier
+type SupplierOrder struct {
+	Supplier string         `json:"supplier"`
+	Lines    []*ReorderLine `json:"lines"`
+	Total    Money          `json:"total"`
+}
+
+// ReorderReport is the purchases suggested for the low ingredients grouped by supplier, Unsourced list the ingredients
+// no supplier sell
+type ReorderReport struct {
+	Suppliers   []*SupplierOrder `json:"suppliers"`
+	Unsourced   []*ReorderLine   `json:"unsourced"`
+	Total       Money            `json:"total"`
+	GeneratedAt time.Time        `json:"generated_at"`
+}
+
+type InventoryRepository interface {
+	FindAll(ctx context.Context) ([]*IngredientStock, error)
+	FindLow(ctx context.Context) ([]*IngredientStock, error)
+	FindByIngredientIds(ctx context.Context, ingredientIds []int) ([]*IngredientStock, error)
+	SetLevels(ctx context.Context, stock *IngredientStock) error
+	Adjust(ctx context.Context, adjustment *InventoryAdjustment) (*IngredientStock, error)
+	Consume(ctx context.Context, adjustments []*InventoryAdjustment) error
+	FindAdjustments(ctx context.Context, ingredientId int, limit int) ([]*InventoryAdjustment, error)
+	FindOrderAdjustments(ctx context.Context, orderId int) ([]*InventoryAdjustment, error)
+}
+
+type InventoryService interface {
+	FindAll(ctx context.Context) ([]*IngredientStock, error)
+	FindLow(ctx context.Context) ([]*IngredientStock, error)
+	Adjust(ctx context.Context, req AdjustInventoryRequest, ingredientId int) (*IngredientStock, error)
+	SetLevels(ctx context.Context, req SetInventoryLevelsRequest, ingredientId int) (*IngredientStock, error)
+	FindAdjustments(ctx context.Context, ingredientId int) ([]*InventoryAdjustment, error)
+	Consume(ctx context.Context, order *Order) ([]*InventoryAdjustment, error)
+	Restore(ctx context.Context, order *Order) error
+	Undo(ctx context.Context, order *Order, consumed []*InventoryAdjustment) error
+	ReorderReport(ctx context.Context, query ReorderQuery) (*ReorderReport, error)
+}
+
+type InventoryController interface {
+	HandleFindAll() echo.HandlerFunc
+	HandleFindLow() echo.HandlerFunc
+	HandleAdjust() echo.HandlerFunc
+	HandleSetLevels() echo.HandlerFunc
+	HandleFindAdjustments() echo.HandlerFunc
+	HandleReorderReport() echo.HandlerFunc
+}
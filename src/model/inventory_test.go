@@ -0,0 +1,53 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIngredientStock_Shortfall(t *testing.T) {
+	cases := []struct {
+		name      string
+		stock     IngredientStock
+		shortfall float64
+	}{
+		{"above the reorder point", IngredientStock{OnHand: 600, ReorderPoint: 500, ReorderQuantity: 2000}, 0},
+		{"at the reorder point", IngredientStock{OnHand: 500, ReorderPoint: 500, ReorderQuantity: 2000}, 2000},
+		{"more missing than the reorder quantity", IngredientStock{OnHand: -300, ReorderPoint: 500, ReorderQuantity: 600}, 800},
+		{"no reorder quantity", IngredientStock{OnHand: 200, ReorderPoint: 500}, 300},
+		{"not tracked", IngredientStock{}, 0},
+	}
+
+	for _, c := range cases {
+		c.stock.SetLowStock()
+		assert.Equal(t, c.shortfall, c.stock.Shortfall(), c.name)
+	}
+}
+
+func TestBaseUnit(t *testing.T) {
+	assert.Equal(t, UnitGram, BaseUnit(UnitKilogram))
+	assert.Equal(t, UnitMilliliter, BaseUnit(UnitCup))
+	assert.Equal(t, UnitPiece, BaseUnit(UnitPiece))
+	assert.Equal(t, UnitGram, BaseUnit("oz"))
+}
+
+func TestSupplierPrice_Packs(t *testing.T) {
+	price := &SupplierPrice{Price: NewMoney(700000, "IDR"), Quantity: 500, Unit: UnitGram}
+
+	packs, ok := price.Packs(2, UnitKilogram, 0)
+	assert.True(t, ok)
+	assert.Equal(t, 4, packs)
+
+	packs, ok = price.Packs(2001, UnitGram, 0)
+	assert.True(t, ok)
+	assert.Equal(t, 5, packs)
+
+	// 3 cups of 0.5 g/ml is 360 g, one pack
+	packs, ok = price.Packs(3, UnitCup, 0.5)
+	assert.True(t, ok)
+	assert.Equal(t, 1, packs)
+
+	_, ok = price.Packs(3, UnitPiece, 0)
+	assert.False(t, ok)
+}
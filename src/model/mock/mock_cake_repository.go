@@ -8,6 +8,7 @@ import (
 	model "cake-store/src/model"
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 )
@@ -35,6 +36,36 @@ func (m *MockCakeRepository) EXPECT() *MockCakeRepositoryMockRecorder {
 	return m.recorder
 }
 
+// CountAll mocks base method.
+func (m *MockCakeRepository) CountAll(arg0 context.Context, arg1 model.CakeQuery) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountAll", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountAll indicates an expected call of CountAll.
+func (mr *MockCakeRepositoryMockRecorder) CountAll(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountAll", reflect.TypeOf((*MockCakeRepository)(nil).CountAll), arg0, arg1)
+}
+
+// CountDeletedBefore mocks base method.
+func (m *MockCakeRepository) CountDeletedBefore(arg0 context.Context, arg1 time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountDeletedBefore", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountDeletedBefore indicates an expected call of CountDeletedBefore.
+func (mr *MockCakeRepositoryMockRecorder) CountDeletedBefore(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountDeletedBefore", reflect.TypeOf((*MockCakeRepository)(nil).CountDeletedBefore), arg0, arg1)
+}
+
 // Delete mocks base method.
 func (m *MockCakeRepository) Delete(arg0 context.Context, arg1 *model.Cake) error {
 	m.ctrl.T.Helper()
@@ -50,18 +81,18 @@ func (mr *MockCakeRepositoryMockRecorder) Delete(arg0, arg1 interface{}) *gomock
 }
 
 // FindAll mocks base method.
-func (m *MockCakeRepository) FindAll(arg0 context.Context) ([]*model.Cake, error) {
+func (m *MockCakeRepository) FindAll(arg0 context.Context, arg1 model.CakeQuery) ([]*model.Cake, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindAll", arg0)
+	ret := m.ctrl.Call(m, "FindAll", arg0, arg1)
 	ret0, _ := ret[0].([]*model.Cake)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FindAll indicates an expected call of FindAll.
-func (mr *MockCakeRepositoryMockRecorder) FindAll(arg0 interface{}) *gomock.Call {
+func (mr *MockCakeRepositoryMockRecorder) FindAll(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockCakeRepository)(nil).FindAll), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockCakeRepository)(nil).FindAll), arg0, arg1)
 }
 
 // FindById mocks base method.
@@ -79,6 +110,79 @@ func (mr *MockCakeRepositoryMockRecorder) FindById(arg0, arg1 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockCakeRepository)(nil).FindById), arg0, arg1)
 }
 
+// FindByIdInStore mocks base method.
+func (m *MockCakeRepository) FindByIdInStore(arg0 context.Context, arg1, arg2 int) (*model.Cake, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByIdInStore", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Cake)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByIdInStore indicates an expected call of FindByIdInStore.
+func (mr *MockCakeRepositoryMockRecorder) FindByIdInStore(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByIdInStore", reflect.TypeOf((*MockCakeRepository)(nil).FindByIdInStore), arg0, arg1, arg2)
+}
+
+// FindByIdUnscoped mocks base method.
+func (m *MockCakeRepository) FindByIdUnscoped(arg0 context.Context, arg1 int) (*model.Cake, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByIdUnscoped", arg0, arg1)
+	ret0, _ := ret[0].(*model.Cake)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByIdUnscoped indicates an expected call of FindByIdUnscoped.
+func (mr *MockCakeRepositoryMockRecorder) FindByIdUnscoped(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByIdUnscoped", reflect.TypeOf((*MockCakeRepository)(nil).FindByIdUnscoped), arg0, arg1)
+}
+
+// Purge mocks base method.
+func (m *MockCakeRepository) Purge(arg0 context.Context, arg1 *model.Cake) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockCakeRepositoryMockRecorder) Purge(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockCakeRepository)(nil).Purge), arg0, arg1)
+}
+
+// PurgeDeletedBefore mocks base method.
+func (m *MockCakeRepository) PurgeDeletedBefore(arg0 context.Context, arg1 time.Time, arg2 int) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeDeletedBefore", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeDeletedBefore indicates an expected call of PurgeDeletedBefore.
+func (mr *MockCakeRepositoryMockRecorder) PurgeDeletedBefore(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeDeletedBefore", reflect.TypeOf((*MockCakeRepository)(nil).PurgeDeletedBefore), arg0, arg1, arg2)
+}
+
+// Restore mocks base method.
+func (m *MockCakeRepository) Restore(arg0 context.Context, arg1 *model.Cake) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockCakeRepositoryMockRecorder) Restore(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockCakeRepository)(nil).Restore), arg0, arg1)
+}
+
 // Save mocks base method.
 func (m *MockCakeRepository) Save(arg0 context.Context, arg1 *model.Cake) error {
 	m.ctrl.T.Helper()
@@ -93,6 +197,25 @@ func (mr *MockCakeRepositoryMockRecorder) Save(arg0, arg1 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockCakeRepository)(nil).Save), arg0, arg1)
 }
 
+// Touch mocks base method.
+func (m *MockCakeRepository) Touch(arg0 context.Context, arg1 ...int) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0}
+	for _, a := range arg1 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Touch", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Touch indicates an expected call of Touch.
+func (mr *MockCakeRepositoryMockRecorder) Touch(arg0 interface{}, arg1 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0}, arg1...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Touch", reflect.TypeOf((*MockCakeRepository)(nil).Touch), varargs...)
+}
+
 // Update mocks base method.
 func (m *MockCakeRepository) Update(arg0 context.Context, arg1 *model.Cake) error {
 	m.ctrl.T.Helper()
@@ -51,33 +51,34 @@ func (mr *MockCakeServiceMockRecorder) Create(arg0, arg1 interface{}) *gomock.Ca
 }
 
 // Delete mocks base method.
-func (m *MockCakeService) Delete(arg0 context.Context, arg1 int) (*model.Cake, error) {
+func (m *MockCakeService) Delete(arg0 context.Context, arg1 int, arg2 model.IfMatch) (*model.Cake, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1, arg2)
 	ret0, _ := ret[0].(*model.Cake)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockCakeServiceMockRecorder) Delete(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockCakeServiceMockRecorder) Delete(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCakeService)(nil).Delete), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCakeService)(nil).Delete), arg0, arg1, arg2)
 }
 
 // FindAll mocks base method.
-func (m *MockCakeService) FindAll(arg0 context.Context) ([]*model.Cake, error) {
+func (m *MockCakeService) FindAll(arg0 context.Context, arg1 model.CakeQuery) ([]*model.Cake, *model.Pagination, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindAll", arg0)
+	ret := m.ctrl.Call(m, "FindAll", arg0, arg1)
 	ret0, _ := ret[0].([]*model.Cake)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret1, _ := ret[1].(*model.Pagination)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
 // FindAll indicates an expected call of FindAll.
-func (mr *MockCakeServiceMockRecorder) FindAll(arg0 interface{}) *gomock.Call {
+func (mr *MockCakeServiceMockRecorder) FindAll(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockCakeService)(nil).FindAll), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockCakeService)(nil).FindAll), arg0, arg1)
 }
 
 // FindById mocks base method.
@@ -95,17 +96,107 @@ func (mr *MockCakeServiceMockRecorder) FindById(arg0, arg1 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockCakeService)(nil).FindById), arg0, arg1)
 }
 
+// FindByIdInStore mocks base method.
+func (m *MockCakeService) FindByIdInStore(arg0 context.Context, arg1, arg2 int) (*model.Cake, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByIdInStore", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Cake)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByIdInStore indicates an expected call of FindByIdInStore.
+func (mr *MockCakeServiceMockRecorder) FindByIdInStore(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByIdInStore", reflect.TypeOf((*MockCakeService)(nil).FindByIdInStore), arg0, arg1, arg2)
+}
+
+// FindVersion mocks base method.
+func (m *MockCakeService) FindVersion(arg0 context.Context, arg1 int) (*model.Cake, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindVersion", arg0, arg1)
+	ret0, _ := ret[0].(*model.Cake)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindVersion indicates an expected call of FindVersion.
+func (mr *MockCakeServiceMockRecorder) FindVersion(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindVersion", reflect.TypeOf((*MockCakeService)(nil).FindVersion), arg0, arg1)
+}
+
+// Patch mocks base method.
+func (m *MockCakeService) Patch(arg0 context.Context, arg1 model.PatchRequest, arg2 int, arg3 model.IfMatch) (*model.Cake, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Patch", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*model.Cake)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Patch indicates an expected call of Patch.
+func (mr *MockCakeServiceMockRecorder) Patch(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Patch", reflect.TypeOf((*MockCakeService)(nil).Patch), arg0, arg1, arg2, arg3)
+}
+
+// Purge mocks base method.
+func (m *MockCakeService) Purge(arg0 context.Context, arg1 int, arg2 model.IfMatch) (*model.Cake, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Cake)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockCakeServiceMockRecorder) Purge(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockCakeService)(nil).Purge), arg0, arg1, arg2)
+}
+
+// PurgeExpired mocks base method.
+func (m *MockCakeService) PurgeExpired(arg0 context.Context, arg1 model.PurgeOption) (*model.PurgeSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeExpired", arg0, arg1)
+	ret0, _ := ret[0].(*model.PurgeSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeExpired indicates an expected call of PurgeExpired.
+func (mr *MockCakeServiceMockRecorder) PurgeExpired(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeExpired", reflect.TypeOf((*MockCakeService)(nil).PurgeExpired), arg0, arg1)
+}
+
+// Restore mocks base method.
+func (m *MockCakeService) Restore(arg0 context.Context, arg1 int, arg2 model.IfMatch) (*model.Cake, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Cake)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockCakeServiceMockRecorder) Restore(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockCakeService)(nil).Restore), arg0, arg1, arg2)
+}
+
 // Update mocks base method.
-func (m *MockCakeService) Update(arg0 context.Context, arg1 model.CreateUpdateRequest, arg2 int) (*model.Cake, error) {
+func (m *MockCakeService) Update(arg0 context.Context, arg1 model.CreateUpdateRequest, arg2 int, arg3 model.IfMatch) (*model.Cake, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "Update", arg0, arg1, arg2, arg3)
 	ret0, _ := ret[0].(*model.Cake)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Update indicates an expected call of Update.
-func (mr *MockCakeServiceMockRecorder) Update(arg0, arg1, arg2 interface{}) *gomock.Call {
+func (mr *MockCakeServiceMockRecorder) Update(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockCakeService)(nil).Update), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockCakeService)(nil).Update), arg0, arg1, arg2, arg3)
 }
@@ -0,0 +1,109 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: cake-store/src/model (interfaces: CartRepository)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	model "cake-store/src/model"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockCartRepository is a mock of CartRepository interface.
+type MockCartRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCartRepositoryMockRecorder
+}
+
+// MockCartRepositoryMockRecorder is the mock recorder for MockCartRepository.
+type MockCartRepositoryMockRecorder struct {
+	mock *MockCartRepository
+}
+
+// NewMockCartRepository creates a new mock instance.
+func NewMockCartRepository(ctrl *gomock.Controller) *MockCartRepository {
+	mock := &MockCartRepository{ctrl: ctrl}
+	mock.recorder = &MockCartRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCartRepository) EXPECT() *MockCartRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Claim mocks base method.
+func (m *MockCartRepository) Claim(arg0 context.Context, arg1 string) (*model.Cart, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Claim", arg0, arg1)
+	ret0, _ := ret[0].(*model.Cart)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Claim indicates an expected call of Claim.
+func (mr *MockCartRepositoryMockRecorder) Claim(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Claim", reflect.TypeOf((*MockCartRepository)(nil).Claim), arg0, arg1)
+}
+
+// Delete mocks base method.
+func (m *MockCartRepository) Delete(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockCartRepositoryMockRecorder) Delete(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCartRepository)(nil).Delete), arg0, arg1)
+}
+
+// FindById mocks base method.
+func (m *MockCartRepository) FindById(arg0 context.Context, arg1 string) (*model.Cart, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", arg0, arg1)
+	ret0, _ := ret[0].(*model.Cart)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockCartRepositoryMockRecorder) FindById(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockCartRepository)(nil).FindById), arg0, arg1)
+}
+
+// Save mocks base method.
+func (m *MockCartRepository) Save(arg0 context.Context, arg1 *model.Cart) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockCartRepositoryMockRecorder) Save(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockCartRepository)(nil).Save), arg0, arg1)
+}
+
+// Update mocks base method.
+func (m *MockCartRepository) Update(arg0 context.Context, arg1 string, arg2 func(*model.Cart) error) (*model.Cart, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Cart)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockCartRepositoryMockRecorder) Update(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockCartRepository)(nil).Update), arg0, arg1, arg2)
+}
@@ -0,0 +1,170 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: cake-store/src/model (interfaces: CartService)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	model "cake-store/src/model"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockCartService is a mock of CartService interface.
+type MockCartService struct {
+	ctrl     *gomock.Controller
+	recorder *MockCartServiceMockRecorder
+}
+
+// MockCartServiceMockRecorder is the mock recorder for MockCartService.
+type MockCartServiceMockRecorder struct {
+	mock *MockCartService
+}
+
+// NewMockCartService creates a new mock instance.
+func NewMockCartService(ctrl *gomock.Controller) *MockCartService {
+	mock := &MockCartService{ctrl: ctrl}
+	mock.recorder = &MockCartServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCartService) EXPECT() *MockCartServiceMockRecorder {
+	return m.recorder
+}
+
+// AddLine mocks base method.
+func (m *MockCartService) AddLine(arg0 context.Context, arg1 model.AddCartLineRequest, arg2 string) (*model.Cart, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddLine", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Cart)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddLine indicates an expected call of AddLine.
+func (mr *MockCartServiceMockRecorder) AddLine(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddLine", reflect.TypeOf((*MockCartService)(nil).AddLine), arg0, arg1, arg2)
+}
+
+// Checkout mocks base method.
+func (m *MockCartService) Checkout(arg0 context.Context, arg1 model.CheckoutCartRequest, arg2 string) (*model.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Checkout", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Checkout indicates an expected call of Checkout.
+func (mr *MockCartServiceMockRecorder) Checkout(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Checkout", reflect.TypeOf((*MockCartService)(nil).Checkout), arg0, arg1, arg2)
+}
+
+// Create mocks base method.
+func (m *MockCartService) Create(arg0 context.Context) (*model.Cart, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0)
+	ret0, _ := ret[0].(*model.Cart)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockCartServiceMockRecorder) Create(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCartService)(nil).Create), arg0)
+}
+
+// Delete mocks base method.
+func (m *MockCartService) Delete(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockCartServiceMockRecorder) Delete(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCartService)(nil).Delete), arg0, arg1)
+}
+
+// FindById mocks base method.
+func (m *MockCartService) FindById(arg0 context.Context, arg1 string) (*model.Cart, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", arg0, arg1)
+	ret0, _ := ret[0].(*model.Cart)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockCartServiceMockRecorder) FindById(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockCartService)(nil).FindById), arg0, arg1)
+}
+
+// RemoveLine mocks base method.
+func (m *MockCartService) RemoveLine(arg0 context.Context, arg1 string, arg2 int) (*model.Cart, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveLine", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Cart)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveLine indicates an expected call of RemoveLine.
+func (mr *MockCartServiceMockRecorder) RemoveLine(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveLine", reflect.TypeOf((*MockCartService)(nil).RemoveLine), arg0, arg1, arg2)
+}
+
+// SetCoupons mocks base method.
+func (m *MockCartService) SetCoupons(arg0 context.Context, arg1 model.SetCartCouponsRequest, arg2 string) (*model.Cart, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetCoupons", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Cart)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetCoupons indicates an expected call of SetCoupons.
+func (mr *MockCartServiceMockRecorder) SetCoupons(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCoupons", reflect.TypeOf((*MockCartService)(nil).SetCoupons), arg0, arg1, arg2)
+}
+
+// SetStore mocks base method.
+func (m *MockCartService) SetStore(arg0 context.Context, arg1 model.SetCartStoreRequest, arg2 string) (*model.Cart, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetStore", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Cart)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetStore indicates an expected call of SetStore.
+func (mr *MockCartServiceMockRecorder) SetStore(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetStore", reflect.TypeOf((*MockCartService)(nil).SetStore), arg0, arg1, arg2)
+}
+
+// UpdateLine mocks base method.
+func (m *MockCartService) UpdateLine(arg0 context.Context, arg1 model.UpdateCartLineRequest, arg2 string, arg3 int) (*model.Cart, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLine", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*model.Cart)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateLine indicates an expected call of UpdateLine.
+func (mr *MockCartServiceMockRecorder) UpdateLine(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLine", reflect.TypeOf((*MockCartService)(nil).UpdateLine), arg0, arg1, arg2, arg3)
+}
@@ -0,0 +1,166 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: cake-store/src/model (interfaces: CouponRepository)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	model "cake-store/src/model"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockCouponRepository is a mock of CouponRepository interface.
+type MockCouponRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCouponRepositoryMockRecorder
+}
+
+// MockCouponRepositoryMockRecorder is the mock recorder for MockCouponRepository.
+type MockCouponRepositoryMockRecorder struct {
+	mock *MockCouponRepository
+}
+
+// NewMockCouponRepository creates a new mock instance.
+func NewMockCouponRepository(ctrl *gomock.Controller) *MockCouponRepository {
+	mock := &MockCouponRepository{ctrl: ctrl}
+	mock.recorder = &MockCouponRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCouponRepository) EXPECT() *MockCouponRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockCouponRepository) Delete(arg0 context.Context, arg1 *model.Coupon) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockCouponRepositoryMockRecorder) Delete(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCouponRepository)(nil).Delete), arg0, arg1)
+}
+
+// FindAll mocks base method.
+func (m *MockCouponRepository) FindAll(arg0 context.Context) ([]*model.Coupon, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", arg0)
+	ret0, _ := ret[0].([]*model.Coupon)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockCouponRepositoryMockRecorder) FindAll(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockCouponRepository)(nil).FindAll), arg0)
+}
+
+// FindByCodes mocks base method.
+func (m *MockCouponRepository) FindByCodes(arg0 context.Context, arg1 []string) ([]*model.Coupon, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByCodes", arg0, arg1)
+	ret0, _ := ret[0].([]*model.Coupon)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByCodes indicates an expected call of FindByCodes.
+func (mr *MockCouponRepositoryMockRecorder) FindByCodes(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByCodes", reflect.TypeOf((*MockCouponRepository)(nil).FindByCodes), arg0, arg1)
+}
+
+// FindById mocks base method.
+func (m *MockCouponRepository) FindById(arg0 context.Context, arg1 int) (*model.Coupon, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", arg0, arg1)
+	ret0, _ := ret[0].(*model.Coupon)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockCouponRepositoryMockRecorder) FindById(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockCouponRepository)(nil).FindById), arg0, arg1)
+}
+
+// LoadUsage mocks base method.
+func (m *MockCouponRepository) LoadUsage(arg0 context.Context, arg1 []*model.Coupon) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadUsage", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LoadUsage indicates an expected call of LoadUsage.
+func (mr *MockCouponRepositoryMockRecorder) LoadUsage(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadUsage", reflect.TypeOf((*MockCouponRepository)(nil).LoadUsage), arg0, arg1)
+}
+
+// Redeem mocks base method.
+func (m *MockCouponRepository) Redeem(arg0 context.Context, arg1 *model.Coupon) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Redeem", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Redeem indicates an expected call of Redeem.
+func (mr *MockCouponRepositoryMockRecorder) Redeem(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Redeem", reflect.TypeOf((*MockCouponRepository)(nil).Redeem), arg0, arg1)
+}
+
+// Release mocks base method.
+func (m *MockCouponRepository) Release(arg0 context.Context, arg1 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Release", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Release indicates an expected call of Release.
+func (mr *MockCouponRepositoryMockRecorder) Release(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Release", reflect.TypeOf((*MockCouponRepository)(nil).Release), arg0, arg1)
+}
+
+// Save mocks base method.
+func (m *MockCouponRepository) Save(arg0 context.Context, arg1 *model.Coupon) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockCouponRepositoryMockRecorder) Save(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockCouponRepository)(nil).Save), arg0, arg1)
+}
+
+// Update mocks base method.
+func (m *MockCouponRepository) Update(arg0 context.Context, arg1 *model.Coupon) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockCouponRepositoryMockRecorder) Update(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockCouponRepository)(nil).Update), arg0, arg1)
+}
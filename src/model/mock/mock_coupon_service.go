@@ -0,0 +1,174 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: cake-store/src/model (interfaces: CouponService)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	model "cake-store/src/model"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockCouponService is a mock of CouponService interface.
+type MockCouponService struct {
+	ctrl     *gomock.Controller
+	recorder *MockCouponServiceMockRecorder
+}
+
+// MockCouponServiceMockRecorder is the mock recorder for MockCouponService.
+type MockCouponServiceMockRecorder struct {
+	mock *MockCouponService
+}
+
+// NewMockCouponService creates a new mock instance.
+func NewMockCouponService(ctrl *gomock.Controller) *MockCouponService {
+	mock := &MockCouponService{ctrl: ctrl}
+	mock.recorder = &MockCouponServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCouponService) EXPECT() *MockCouponServiceMockRecorder {
+	return m.recorder
+}
+
+// Apply mocks base method.
+func (m *MockCouponService) Apply(arg0 context.Context, arg1 []string, arg2 []*model.DiscountLine) (*model.Discount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Apply", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Discount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Apply indicates an expected call of Apply.
+func (mr *MockCouponServiceMockRecorder) Apply(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Apply", reflect.TypeOf((*MockCouponService)(nil).Apply), arg0, arg1, arg2)
+}
+
+// Create mocks base method.
+func (m *MockCouponService) Create(arg0 context.Context, arg1 model.CreateUpdateCouponRequest) (*model.Coupon, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(*model.Coupon)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockCouponServiceMockRecorder) Create(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCouponService)(nil).Create), arg0, arg1)
+}
+
+// Delete mocks base method.
+func (m *MockCouponService) Delete(arg0 context.Context, arg1 int) (*model.Coupon, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret0, _ := ret[0].(*model.Coupon)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockCouponServiceMockRecorder) Delete(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCouponService)(nil).Delete), arg0, arg1)
+}
+
+// FindAll mocks base method.
+func (m *MockCouponService) FindAll(arg0 context.Context) ([]*model.Coupon, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", arg0)
+	ret0, _ := ret[0].([]*model.Coupon)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockCouponServiceMockRecorder) FindAll(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockCouponService)(nil).FindAll), arg0)
+}
+
+// FindById mocks base method.
+func (m *MockCouponService) FindById(arg0 context.Context, arg1 int) (*model.Coupon, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", arg0, arg1)
+	ret0, _ := ret[0].(*model.Coupon)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockCouponServiceMockRecorder) FindById(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockCouponService)(nil).FindById), arg0, arg1)
+}
+
+// Redeem mocks base method.
+func (m *MockCouponService) Redeem(arg0 context.Context, arg1 *model.Discount) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Redeem", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Redeem indicates an expected call of Redeem.
+func (mr *MockCouponServiceMockRecorder) Redeem(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Redeem", reflect.TypeOf((*MockCouponService)(nil).Redeem), arg0, arg1)
+}
+
+// Release mocks base method.
+func (m *MockCouponService) Release(arg0 context.Context, arg1 ...int) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0}
+	for _, a := range arg1 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Release", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Release indicates an expected call of Release.
+func (mr *MockCouponServiceMockRecorder) Release(arg0 interface{}, arg1 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0}, arg1...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Release", reflect.TypeOf((*MockCouponService)(nil).Release), varargs...)
+}
+
+// Update mocks base method.
+func (m *MockCouponService) Update(arg0 context.Context, arg1 model.CreateUpdateCouponRequest, arg2 int) (*model.Coupon, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Coupon)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockCouponServiceMockRecorder) Update(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockCouponService)(nil).Update), arg0, arg1, arg2)
+}
+
+// Validate mocks base method.
+func (m *MockCouponService) Validate(arg0 context.Context, arg1 model.ValidateCouponRequest) (*model.Discount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Validate", arg0, arg1)
+	ret0, _ := ret[0].(*model.Discount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Validate indicates an expected call of Validate.
+func (mr *MockCouponServiceMockRecorder) Validate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Validate", reflect.TypeOf((*MockCouponService)(nil).Validate), arg0, arg1)
+}
@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: cake-store/src/model (interfaces: DeliveryFeePolicy)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	model "cake-store/src/model"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockDeliveryFeePolicy is a mock of DeliveryFeePolicy interface.
+type MockDeliveryFeePolicy struct {
+	ctrl     *gomock.Controller
+	recorder *MockDeliveryFeePolicyMockRecorder
+}
+
+// MockDeliveryFeePolicyMockRecorder is the mock recorder for MockDeliveryFeePolicy.
+type MockDeliveryFeePolicyMockRecorder struct {
+	mock *MockDeliveryFeePolicy
+}
+
+// NewMockDeliveryFeePolicy creates a new mock instance.
+func NewMockDeliveryFeePolicy(ctrl *gomock.Controller) *MockDeliveryFeePolicy {
+	mock := &MockDeliveryFeePolicy{ctrl: ctrl}
+	mock.recorder = &MockDeliveryFeePolicyMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDeliveryFeePolicy) EXPECT() *MockDeliveryFeePolicyMockRecorder {
+	return m.recorder
+}
+
+// Fee mocks base method.
+func (m *MockDeliveryFeePolicy) Fee(arg0 context.Context, arg1 float64, arg2 model.Money) (model.Money, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Fee", arg0, arg1, arg2)
+	ret0, _ := ret[0].(model.Money)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Fee indicates an expected call of Fee.
+func (mr *MockDeliveryFeePolicyMockRecorder) Fee(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Fee", reflect.TypeOf((*MockDeliveryFeePolicy)(nil).Fee), arg0, arg1, arg2)
+}
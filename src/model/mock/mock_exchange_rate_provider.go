@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: cake-store/src/model (interfaces: ExchangeRateProvider)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	big "math/big"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockExchangeRateProvider is a mock of ExchangeRateProvider interface.
+type MockExchangeRateProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockExchangeRateProviderMockRecorder
+}
+
+// MockExchangeRateProviderMockRecorder is the mock recorder for MockExchangeRateProvider.
+type MockExchangeRateProviderMockRecorder struct {
+	mock *MockExchangeRateProvider
+}
+
+// NewMockExchangeRateProvider creates a new mock instance.
+func NewMockExchangeRateProvider(ctrl *gomock.Controller) *MockExchangeRateProvider {
+	mock := &MockExchangeRateProvider{ctrl: ctrl}
+	mock.recorder = &MockExchangeRateProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockExchangeRateProvider) EXPECT() *MockExchangeRateProviderMockRecorder {
+	return m.recorder
+}
+
+// Rate mocks base method.
+func (m *MockExchangeRateProvider) Rate(arg0 context.Context, arg1, arg2 string) (*big.Rat, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rate", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*big.Rat)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Rate indicates an expected call of Rate.
+func (mr *MockExchangeRateProviderMockRecorder) Rate(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rate", reflect.TypeOf((*MockExchangeRateProvider)(nil).Rate), arg0, arg1, arg2)
+}
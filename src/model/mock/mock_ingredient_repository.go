@@ -0,0 +1,195 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: cake-store/src/model (interfaces: IngredientRepository)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	model "cake-store/src/model"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIngredientRepository is a mock of IngredientRepository interface.
+type MockIngredientRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIngredientRepositoryMockRecorder
+}
+
+// MockIngredientRepositoryMockRecorder is the mock recorder for MockIngredientRepository.
+type MockIngredientRepositoryMockRecorder struct {
+	mock *MockIngredientRepository
+}
+
+// NewMockIngredientRepository creates a new mock instance.
+func NewMockIngredientRepository(ctrl *gomock.Controller) *MockIngredientRepository {
+	mock := &MockIngredientRepository{ctrl: ctrl}
+	mock.recorder = &MockIngredientRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIngredientRepository) EXPECT() *MockIngredientRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockIngredientRepository) Delete(arg0 context.Context, arg1 *model.Ingredient) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockIngredientRepositoryMockRecorder) Delete(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockIngredientRepository)(nil).Delete), arg0, arg1)
+}
+
+// FindAll mocks base method.
+func (m *MockIngredientRepository) FindAll(arg0 context.Context) ([]*model.Ingredient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", arg0)
+	ret0, _ := ret[0].([]*model.Ingredient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockIngredientRepositoryMockRecorder) FindAll(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockIngredientRepository)(nil).FindAll), arg0)
+}
+
+// FindById mocks base method.
+func (m *MockIngredientRepository) FindById(arg0 context.Context, arg1 int) (*model.Ingredient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", arg0, arg1)
+	ret0, _ := ret[0].(*model.Ingredient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockIngredientRepositoryMockRecorder) FindById(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockIngredientRepository)(nil).FindById), arg0, arg1)
+}
+
+// FindByIds mocks base method.
+func (m *MockIngredientRepository) FindByIds(arg0 context.Context, arg1 []int) ([]*model.Ingredient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByIds", arg0, arg1)
+	ret0, _ := ret[0].([]*model.Ingredient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByIds indicates an expected call of FindByIds.
+func (mr *MockIngredientRepositoryMockRecorder) FindByIds(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByIds", reflect.TypeOf((*MockIngredientRepository)(nil).FindByIds), arg0, arg1)
+}
+
+// FindCakeIds mocks base method.
+func (m *MockIngredientRepository) FindCakeIds(arg0 context.Context, arg1 int) ([]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindCakeIds", arg0, arg1)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindCakeIds indicates an expected call of FindCakeIds.
+func (mr *MockIngredientRepositoryMockRecorder) FindCakeIds(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindCakeIds", reflect.TypeOf((*MockIngredientRepository)(nil).FindCakeIds), arg0, arg1)
+}
+
+// FindPrices mocks base method.
+func (m *MockIngredientRepository) FindPrices(arg0 context.Context, arg1 []int) ([]*model.SupplierPrice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPrices", arg0, arg1)
+	ret0, _ := ret[0].([]*model.SupplierPrice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindPrices indicates an expected call of FindPrices.
+func (mr *MockIngredientRepositoryMockRecorder) FindPrices(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPrices", reflect.TypeOf((*MockIngredientRepository)(nil).FindPrices), arg0, arg1)
+}
+
+// LoadCakes mocks base method.
+func (m *MockIngredientRepository) LoadCakes(arg0 context.Context, arg1 []*model.Cake) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadCakes", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LoadCakes indicates an expected call of LoadCakes.
+func (mr *MockIngredientRepositoryMockRecorder) LoadCakes(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadCakes", reflect.TypeOf((*MockIngredientRepository)(nil).LoadCakes), arg0, arg1)
+}
+
+// Save mocks base method.
+func (m *MockIngredientRepository) Save(arg0 context.Context, arg1 *model.Ingredient) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockIngredientRepositoryMockRecorder) Save(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockIngredientRepository)(nil).Save), arg0, arg1)
+}
+
+// SetCakeIngredients mocks base method.
+func (m *MockIngredientRepository) SetCakeIngredients(arg0 context.Context, arg1 int, arg2 []*model.CakeIngredient) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetCakeIngredients", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetCakeIngredients indicates an expected call of SetCakeIngredients.
+func (mr *MockIngredientRepositoryMockRecorder) SetCakeIngredients(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCakeIngredients", reflect.TypeOf((*MockIngredientRepository)(nil).SetCakeIngredients), arg0, arg1, arg2)
+}
+
+// SetPrices mocks base method.
+func (m *MockIngredientRepository) SetPrices(arg0 context.Context, arg1 int, arg2 []*model.SupplierPrice) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPrices", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPrices indicates an expected call of SetPrices.
+func (mr *MockIngredientRepositoryMockRecorder) SetPrices(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPrices", reflect.TypeOf((*MockIngredientRepository)(nil).SetPrices), arg0, arg1, arg2)
+}
+
+// Update mocks base method.
+func (m *MockIngredientRepository) Update(arg0 context.Context, arg1 *model.Ingredient) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockIngredientRepositoryMockRecorder) Update(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockIngredientRepository)(nil).Update), arg0, arg1)
+}
@@ -0,0 +1,156 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: cake-store/src/model (interfaces: IngredientService)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	model "cake-store/src/model"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIngredientService is a mock of IngredientService interface.
+type MockIngredientService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIngredientServiceMockRecorder
+}
+
+// MockIngredientServiceMockRecorder is the mock recorder for MockIngredientService.
+type MockIngredientServiceMockRecorder struct {
+	mock *MockIngredientService
+}
+
+// NewMockIngredientService creates a new mock instance.
+func NewMockIngredientService(ctrl *gomock.Controller) *MockIngredientService {
+	mock := &MockIngredientService{ctrl: ctrl}
+	mock.recorder = &MockIngredientServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIngredientService) EXPECT() *MockIngredientServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockIngredientService) Create(arg0 context.Context, arg1 model.CreateUpdateIngredientRequest) (*model.Ingredient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(*model.Ingredient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockIngredientServiceMockRecorder) Create(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIngredientService)(nil).Create), arg0, arg1)
+}
+
+// Delete mocks base method.
+func (m *MockIngredientService) Delete(arg0 context.Context, arg1 int) (*model.Ingredient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret0, _ := ret[0].(*model.Ingredient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockIngredientServiceMockRecorder) Delete(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockIngredientService)(nil).Delete), arg0, arg1)
+}
+
+// FindAll mocks base method.
+func (m *MockIngredientService) FindAll(arg0 context.Context) ([]*model.Ingredient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", arg0)
+	ret0, _ := ret[0].([]*model.Ingredient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockIngredientServiceMockRecorder) FindAll(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockIngredientService)(nil).FindAll), arg0)
+}
+
+// FindById mocks base method.
+func (m *MockIngredientService) FindById(arg0 context.Context, arg1 int) (*model.Ingredient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", arg0, arg1)
+	ret0, _ := ret[0].(*model.Ingredient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockIngredientServiceMockRecorder) FindById(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockIngredientService)(nil).FindById), arg0, arg1)
+}
+
+// FindPrices mocks base method.
+func (m *MockIngredientService) FindPrices(arg0 context.Context, arg1 int) ([]*model.SupplierPrice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPrices", arg0, arg1)
+	ret0, _ := ret[0].([]*model.SupplierPrice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindPrices indicates an expected call of FindPrices.
+func (mr *MockIngredientServiceMockRecorder) FindPrices(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPrices", reflect.TypeOf((*MockIngredientService)(nil).FindPrices), arg0, arg1)
+}
+
+// SetCakeIngredients mocks base method.
+func (m *MockIngredientService) SetCakeIngredients(arg0 context.Context, arg1 model.SetCakeIngredientsRequest, arg2 int) (*model.Cake, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetCakeIngredients", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Cake)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetCakeIngredients indicates an expected call of SetCakeIngredients.
+func (mr *MockIngredientServiceMockRecorder) SetCakeIngredients(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCakeIngredients", reflect.TypeOf((*MockIngredientService)(nil).SetCakeIngredients), arg0, arg1, arg2)
+}
+
+// SetPrices mocks base method.
+func (m *MockIngredientService) SetPrices(arg0 context.Context, arg1 model.SetSupplierPricesRequest, arg2 int) ([]*model.SupplierPrice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPrices", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*model.SupplierPrice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetPrices indicates an expected call of SetPrices.
+func (mr *MockIngredientServiceMockRecorder) SetPrices(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPrices", reflect.TypeOf((*MockIngredientService)(nil).SetPrices), arg0, arg1, arg2)
+}
+
+// Update mocks base method.
+func (m *MockIngredientService) Update(arg0 context.Context, arg1 model.CreateUpdateIngredientRequest, arg2 int) (*model.Ingredient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Ingredient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockIngredientServiceMockRecorder) Update(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockIngredientService)(nil).Update), arg0, arg1, arg2)
+}
@@ -0,0 +1,154 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: cake-store/src/model (interfaces: InventoryRepository)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	model "cake-store/src/model"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockInventoryRepository is a mock of InventoryRepository interface.
+type MockInventoryRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockInventoryRepositoryMockRecorder
+}
+
+// MockInventoryRepositoryMockRecorder is the mock recorder for MockInventoryRepository.
+type MockInventoryRepositoryMockRecorder struct {
+	mock *MockInventoryRepository
+}
+
+// NewMockInventoryRepository creates a new mock instance.
+func NewMockInventoryRepository(ctrl *gomock.Controller) *MockInventoryRepository {
+	mock := &MockInventoryRepository{ctrl: ctrl}
+	mock.recorder = &MockInventoryRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInventoryRepository) EXPECT() *MockInventoryRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Adjust mocks base method.
+func (m *MockInventoryRepository) Adjust(arg0 context.Context, arg1 *model.InventoryAdjustment) (*model.IngredientStock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Adjust", arg0, arg1)
+	ret0, _ := ret[0].(*model.IngredientStock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Adjust indicates an expected call of Adjust.
+func (mr *MockInventoryRepositoryMockRecorder) Adjust(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Adjust", reflect.TypeOf((*MockInventoryRepository)(nil).Adjust), arg0, arg1)
+}
+
+// Consume mocks base method.
+func (m *MockInventoryRepository) Consume(arg0 context.Context, arg1 []*model.InventoryAdjustment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Consume", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Consume indicates an expected call of Consume.
+func (mr *MockInventoryRepositoryMockRecorder) Consume(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Consume", reflect.TypeOf((*MockInventoryRepository)(nil).Consume), arg0, arg1)
+}
+
+// FindAdjustments mocks base method.
+func (m *MockInventoryRepository) FindAdjustments(arg0 context.Context, arg1, arg2 int) ([]*model.InventoryAdjustment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAdjustments", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*model.InventoryAdjustment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAdjustments indicates an expected call of FindAdjustments.
+func (mr *MockInventoryRepositoryMockRecorder) FindAdjustments(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAdjustments", reflect.TypeOf((*MockInventoryRepository)(nil).FindAdjustments), arg0, arg1, arg2)
+}
+
+// FindAll mocks base method.
+func (m *MockInventoryRepository) FindAll(arg0 context.Context) ([]*model.IngredientStock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", arg0)
+	ret0, _ := ret[0].([]*model.IngredientStock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockInventoryRepositoryMockRecorder) FindAll(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockInventoryRepository)(nil).FindAll), arg0)
+}
+
+// FindByIngredientIds mocks base method.
+func (m *MockInventoryRepository) FindByIngredientIds(arg0 context.Context, arg1 []int) ([]*model.IngredientStock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByIngredientIds", arg0, arg1)
+	ret0, _ := ret[0].([]*model.IngredientStock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByIngredientIds indicates an expected call of FindByIngredientIds.
+func (mr *MockInventoryRepositoryMockRecorder) FindByIngredientIds(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByIngredientIds", reflect.TypeOf((*MockInventoryRepository)(nil).FindByIngredientIds), arg0, arg1)
+}
+
+// FindLow mocks base method.
+func (m *MockInventoryRepository) FindLow(arg0 context.Context) ([]*model.IngredientStock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindLow", arg0)
+	ret0, _ := ret[0].([]*model.IngredientStock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindLow indicates an expected call of FindLow.
+func (mr *MockInventoryRepositoryMockRecorder) FindLow(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindLow", reflect.TypeOf((*MockInventoryRepository)(nil).FindLow), arg0)
+}
+
+// FindOrderAdjustments mocks base method.
+func (m *MockInventoryRepository) FindOrderAdjustments(arg0 context.Context, arg1 int) ([]*model.InventoryAdjustment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindOrderAdjustments", arg0, arg1)
+	ret0, _ := ret[0].([]*model.InventoryAdjustment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindOrderAdjustments indicates an expected call of FindOrderAdjustments.
+func (mr *MockInventoryRepositoryMockRecorder) FindOrderAdjustments(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOrderAdjustments", reflect.TypeOf((*MockInventoryRepository)(nil).FindOrderAdjustments), arg0, arg1)
+}
+
+// SetLevels mocks base method.
+func (m *MockInventoryRepository) SetLevels(arg0 context.Context, arg1 *model.IngredientStock) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetLevels", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetLevels indicates an expected call of SetLevels.
+func (mr *MockInventoryRepositoryMockRecorder) SetLevels(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLevels", reflect.TypeOf((*MockInventoryRepository)(nil).SetLevels), arg0, arg1)
+}
@@ -0,0 +1,169 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: cake-store/src/model (interfaces: InventoryService)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	model "cake-store/src/model"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockInventoryService is a mock of InventoryService interface.
+type MockInventoryService struct {
+	ctrl     *gomock.Controller
+	recorder *MockInventoryServiceMockRecorder
+}
+
+// MockInventoryServiceMockRecorder is the mock recorder for MockInventoryService.
+type MockInventoryServiceMockRecorder struct {
+	mock *MockInventoryService
+}
+
+// NewMockInventoryService creates a new mock instance.
+func NewMockInventoryService(ctrl *gomock.Controller) *MockInventoryService {
+	mock := &MockInventoryService{ctrl: ctrl}
+	mock.recorder = &MockInventoryServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInventoryService) EXPECT() *MockInventoryServiceMockRecorder {
+	return m.recorder
+}
+
+// Adjust mocks base method.
+func (m *MockInventoryService) Adjust(arg0 context.Context, arg1 model.AdjustInventoryRequest, arg2 int) (*model.IngredientStock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Adjust", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.IngredientStock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Adjust indicates an expected call of Adjust.
+func (mr *MockInventoryServiceMockRecorder) Adjust(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Adjust", reflect.TypeOf((*MockInventoryService)(nil).Adjust), arg0, arg1, arg2)
+}
+
+// Consume mocks base method.
+func (m *MockInventoryService) Consume(arg0 context.Context, arg1 *model.Order) ([]*model.InventoryAdjustment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Consume", arg0, arg1)
+	ret0, _ := ret[0].([]*model.InventoryAdjustment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Consume indicates an expected call of Consume.
+func (mr *MockInventoryServiceMockRecorder) Consume(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Consume", reflect.TypeOf((*MockInventoryService)(nil).Consume), arg0, arg1)
+}
+
+// FindAdjustments mocks base method.
+func (m *MockInventoryService) FindAdjustments(arg0 context.Context, arg1 int) ([]*model.InventoryAdjustment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAdjustments", arg0, arg1)
+	ret0, _ := ret[0].([]*model.InventoryAdjustment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAdjustments indicates an expected call of FindAdjustments.
+func (mr *MockInventoryServiceMockRecorder) FindAdjustments(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAdjustments", reflect.TypeOf((*MockInventoryService)(nil).FindAdjustments), arg0, arg1)
+}
+
+// FindAll mocks base method.
+func (m *MockInventoryService) FindAll(arg0 context.Context) ([]*model.IngredientStock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", arg0)
+	ret0, _ := ret[0].([]*model.IngredientStock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockInventoryServiceMockRecorder) FindAll(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockInventoryService)(nil).FindAll), arg0)
+}
+
+// FindLow mocks base method.
+func (m *MockInventoryService) FindLow(arg0 context.Context) ([]*model.IngredientStock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindLow", arg0)
+	ret0, _ := ret[0].([]*model.IngredientStock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindLow indicates an expected call of FindLow.
+func (mr *MockInventoryServiceMockRecorder) FindLow(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindLow", reflect.TypeOf((*MockInventoryService)(nil).FindLow), arg0)
+}
+
+// ReorderReport mocks base method.
+func (m *MockInventoryService) ReorderReport(arg0 context.Context, arg1 model.ReorderQuery) (*model.ReorderReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReorderReport", arg0, arg1)
+	ret0, _ := ret[0].(*model.ReorderReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReorderReport indicates an expected call of ReorderReport.
+func (mr *MockInventoryServiceMockRecorder) ReorderReport(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReorderReport", reflect.TypeOf((*MockInventoryService)(nil).ReorderReport), arg0, arg1)
+}
+
+// Restore mocks base method.
+func (m *MockInventoryService) Restore(arg0 context.Context, arg1 *model.Order) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockInventoryServiceMockRecorder) Restore(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockInventoryService)(nil).Restore), arg0, arg1)
+}
+
+// SetLevels mocks base method.
+func (m *MockInventoryService) SetLevels(arg0 context.Context, arg1 model.SetInventoryLevelsRequest, arg2 int) (*model.IngredientStock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetLevels", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.IngredientStock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetLevels indicates an expected call of SetLevels.
+func (mr *MockInventoryServiceMockRecorder) SetLevels(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLevels", reflect.TypeOf((*MockInventoryService)(nil).SetLevels), arg0, arg1, arg2)
+}
+
+// Undo mocks base method.
+func (m *MockInventoryService) Undo(arg0 context.Context, arg1 *model.Order, arg2 []*model.InventoryAdjustment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Undo", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Undo indicates an expected call of Undo.
+func (mr *MockInventoryServiceMockRecorder) Undo(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Undo", reflect.TypeOf((*MockInventoryService)(nil).Undo), arg0, arg1, arg2)
+}
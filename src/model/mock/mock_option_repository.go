@@ -0,0 +1,65 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: cake-store/src/model (interfaces: OptionRepository)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	model "cake-store/src/model"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockOptionRepository is a mock of OptionRepository interface.
+type MockOptionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockOptionRepositoryMockRecorder
+}
+
+// MockOptionRepositoryMockRecorder is the mock recorder for MockOptionRepository.
+type MockOptionRepositoryMockRecorder struct {
+	mock *MockOptionRepository
+}
+
+// NewMockOptionRepository creates a new mock instance.
+func NewMockOptionRepository(ctrl *gomock.Controller) *MockOptionRepository {
+	mock := &MockOptionRepository{ctrl: ctrl}
+	mock.recorder = &MockOptionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOptionRepository) EXPECT() *MockOptionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindByCakeId mocks base method.
+func (m *MockOptionRepository) FindByCakeId(arg0 context.Context, arg1 int) ([]*model.OptionGroup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByCakeId", arg0, arg1)
+	ret0, _ := ret[0].([]*model.OptionGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByCakeId indicates an expected call of FindByCakeId.
+func (mr *MockOptionRepositoryMockRecorder) FindByCakeId(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByCakeId", reflect.TypeOf((*MockOptionRepository)(nil).FindByCakeId), arg0, arg1)
+}
+
+// Save mocks base method.
+func (m *MockOptionRepository) Save(arg0 context.Context, arg1 int, arg2 []*model.OptionGroup) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockOptionRepositoryMockRecorder) Save(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockOptionRepository)(nil).Save), arg0, arg1, arg2)
+}
@@ -0,0 +1,96 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: cake-store/src/model (interfaces: OptionService)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	model "cake-store/src/model"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockOptionService is a mock of OptionService interface.
+type MockOptionService struct {
+	ctrl     *gomock.Controller
+	recorder *MockOptionServiceMockRecorder
+}
+
+// MockOptionServiceMockRecorder is the mock recorder for MockOptionService.
+type MockOptionServiceMockRecorder struct {
+	mock *MockOptionService
+}
+
+// NewMockOptionService creates a new mock instance.
+func NewMockOptionService(ctrl *gomock.Controller) *MockOptionService {
+	mock := &MockOptionService{ctrl: ctrl}
+	mock.recorder = &MockOptionServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOptionService) EXPECT() *MockOptionServiceMockRecorder {
+	return m.recorder
+}
+
+// FindByCakeId mocks base method.
+func (m *MockOptionService) FindByCakeId(arg0 context.Context, arg1 int) ([]*model.OptionGroup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByCakeId", arg0, arg1)
+	ret0, _ := ret[0].([]*model.OptionGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByCakeId indicates an expected call of FindByCakeId.
+func (mr *MockOptionServiceMockRecorder) FindByCakeId(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByCakeId", reflect.TypeOf((*MockOptionService)(nil).FindByCakeId), arg0, arg1)
+}
+
+// Quote mocks base method.
+func (m *MockOptionService) Quote(arg0 context.Context, arg1 model.QuoteOptionsRequest, arg2 int) (*model.OptionQuote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Quote", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.OptionQuote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Quote indicates an expected call of Quote.
+func (mr *MockOptionServiceMockRecorder) Quote(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Quote", reflect.TypeOf((*MockOptionService)(nil).Quote), arg0, arg1, arg2)
+}
+
+// Save mocks base method.
+func (m *MockOptionService) Save(arg0 context.Context, arg1 model.SaveOptionsRequest, arg2 int) ([]*model.OptionGroup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*model.OptionGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockOptionServiceMockRecorder) Save(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockOptionService)(nil).Save), arg0, arg1, arg2)
+}
+
+// Select mocks base method.
+func (m *MockOptionService) Select(arg0 context.Context, arg1 int, arg2 []model.OptionSelection, arg3 string) (model.SelectedOptions, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Select", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(model.SelectedOptions)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Select indicates an expected call of Select.
+func (mr *MockOptionServiceMockRecorder) Select(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Select", reflect.TypeOf((*MockOptionService)(nil).Select), arg0, arg1, arg2, arg3)
+}
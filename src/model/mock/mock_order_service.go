@@ -0,0 +1,97 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: cake-store/src/model (interfaces: OrderService)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	model "cake-store/src/model"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockOrderService is a mock of OrderService interface.
+type MockOrderService struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrderServiceMockRecorder
+}
+
+// MockOrderServiceMockRecorder is the mock recorder for MockOrderService.
+type MockOrderServiceMockRecorder struct {
+	mock *MockOrderService
+}
+
+// NewMockOrderService creates a new mock instance.
+func NewMockOrderService(ctrl *gomock.Controller) *MockOrderService {
+	mock := &MockOrderService{ctrl: ctrl}
+	mock.recorder = &MockOrderServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrderService) EXPECT() *MockOrderServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockOrderService) Create(arg0 context.Context, arg1 model.CreateOrderRequest) (*model.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(*model.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockOrderServiceMockRecorder) Create(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockOrderService)(nil).Create), arg0, arg1)
+}
+
+// FindAll mocks base method.
+func (m *MockOrderService) FindAll(arg0 context.Context, arg1 model.OrderQuery) ([]*model.Order, *model.Pagination, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", arg0, arg1)
+	ret0, _ := ret[0].([]*model.Order)
+	ret1, _ := ret[1].(*model.Pagination)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockOrderServiceMockRecorder) FindAll(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockOrderService)(nil).FindAll), arg0, arg1)
+}
+
+// FindById mocks base method.
+func (m *MockOrderService) FindById(arg0 context.Context, arg1 int) (*model.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", arg0, arg1)
+	ret0, _ := ret[0].(*model.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockOrderServiceMockRecorder) FindById(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockOrderService)(nil).FindById), arg0, arg1)
+}
+
+// Transition mocks base method.
+func (m *MockOrderService) Transition(arg0 context.Context, arg1 model.TransitionOrderRequest, arg2 int) (*model.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Transition", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Transition indicates an expected call of Transition.
+func (mr *MockOrderServiceMockRecorder) Transition(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Transition", reflect.TypeOf((*MockOrderService)(nil).Transition), arg0, arg1, arg2)
+}
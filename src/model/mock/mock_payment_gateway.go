@@ -0,0 +1,110 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: cake-store/src/model (interfaces: PaymentGateway)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	model "cake-store/src/model"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockPaymentGateway is a mock of PaymentGateway interface.
+type MockPaymentGateway struct {
+	ctrl     *gomock.Controller
+	recorder *MockPaymentGatewayMockRecorder
+}
+
+// MockPaymentGatewayMockRecorder is the mock recorder for MockPaymentGateway.
+type MockPaymentGatewayMockRecorder struct {
+	mock *MockPaymentGateway
+}
+
+// NewMockPaymentGateway creates a new mock instance.
+func NewMockPaymentGateway(ctrl *gomock.Controller) *MockPaymentGateway {
+	mock := &MockPaymentGateway{ctrl: ctrl}
+	mock.recorder = &MockPaymentGatewayMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPaymentGateway) EXPECT() *MockPaymentGatewayMockRecorder {
+	return m.recorder
+}
+
+// Authorize mocks base method.
+func (m *MockPaymentGateway) Authorize(arg0 context.Context, arg1 model.GatewayAuthorization) (*model.GatewayResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Authorize", arg0, arg1)
+	ret0, _ := ret[0].(*model.GatewayResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Authorize indicates an expected call of Authorize.
+func (mr *MockPaymentGatewayMockRecorder) Authorize(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authorize", reflect.TypeOf((*MockPaymentGateway)(nil).Authorize), arg0, arg1)
+}
+
+// Capture mocks base method.
+func (m *MockPaymentGateway) Capture(arg0 context.Context, arg1 string, arg2 model.Money) (*model.GatewayResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Capture", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.GatewayResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Capture indicates an expected call of Capture.
+func (mr *MockPaymentGatewayMockRecorder) Capture(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Capture", reflect.TypeOf((*MockPaymentGateway)(nil).Capture), arg0, arg1, arg2)
+}
+
+// Name mocks base method.
+func (m *MockPaymentGateway) Name() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Name")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Name indicates an expected call of Name.
+func (mr *MockPaymentGatewayMockRecorder) Name() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Name", reflect.TypeOf((*MockPaymentGateway)(nil).Name))
+}
+
+// Refund mocks base method.
+func (m *MockPaymentGateway) Refund(arg0 context.Context, arg1 string, arg2 model.Money) (*model.GatewayResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Refund", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.GatewayResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Refund indicates an expected call of Refund.
+func (mr *MockPaymentGatewayMockRecorder) Refund(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refund", reflect.TypeOf((*MockPaymentGateway)(nil).Refund), arg0, arg1, arg2)
+}
+
+// VerifyWebhook mocks base method.
+func (m *MockPaymentGateway) VerifyWebhook(arg0 context.Context, arg1 []byte, arg2 string) (*model.GatewayEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyWebhook", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.GatewayEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyWebhook indicates an expected call of VerifyWebhook.
+func (mr *MockPaymentGatewayMockRecorder) VerifyWebhook(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyWebhook", reflect.TypeOf((*MockPaymentGateway)(nil).VerifyWebhook), arg0, arg1, arg2)
+}
@@ -0,0 +1,126 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: cake-store/src/model (interfaces: PaymentService)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	model "cake-store/src/model"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockPaymentService is a mock of PaymentService interface.
+type MockPaymentService struct {
+	ctrl     *gomock.Controller
+	recorder *MockPaymentServiceMockRecorder
+}
+
+// MockPaymentServiceMockRecorder is the mock recorder for MockPaymentService.
+type MockPaymentServiceMockRecorder struct {
+	mock *MockPaymentService
+}
+
+// NewMockPaymentService creates a new mock instance.
+func NewMockPaymentService(ctrl *gomock.Controller) *MockPaymentService {
+	mock := &MockPaymentService{ctrl: ctrl}
+	mock.recorder = &MockPaymentServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPaymentService) EXPECT() *MockPaymentServiceMockRecorder {
+	return m.recorder
+}
+
+// Authorize mocks base method.
+func (m *MockPaymentService) Authorize(arg0 context.Context, arg1 model.AuthorizePaymentRequest, arg2 int) (*model.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Authorize", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Authorize indicates an expected call of Authorize.
+func (mr *MockPaymentServiceMockRecorder) Authorize(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authorize", reflect.TypeOf((*MockPaymentService)(nil).Authorize), arg0, arg1, arg2)
+}
+
+// Capture mocks base method.
+func (m *MockPaymentService) Capture(arg0 context.Context, arg1 int) (*model.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Capture", arg0, arg1)
+	ret0, _ := ret[0].(*model.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Capture indicates an expected call of Capture.
+func (mr *MockPaymentServiceMockRecorder) Capture(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Capture", reflect.TypeOf((*MockPaymentService)(nil).Capture), arg0, arg1)
+}
+
+// FindById mocks base method.
+func (m *MockPaymentService) FindById(arg0 context.Context, arg1 int) (*model.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", arg0, arg1)
+	ret0, _ := ret[0].(*model.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockPaymentServiceMockRecorder) FindById(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockPaymentService)(nil).FindById), arg0, arg1)
+}
+
+// FindByOrderId mocks base method.
+func (m *MockPaymentService) FindByOrderId(arg0 context.Context, arg1 int) ([]*model.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByOrderId", arg0, arg1)
+	ret0, _ := ret[0].([]*model.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByOrderId indicates an expected call of FindByOrderId.
+func (mr *MockPaymentServiceMockRecorder) FindByOrderId(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByOrderId", reflect.TypeOf((*MockPaymentService)(nil).FindByOrderId), arg0, arg1)
+}
+
+// HandleWebhook mocks base method.
+func (m *MockPaymentService) HandleWebhook(arg0 context.Context, arg1 []byte, arg2 string) (*model.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleWebhook", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HandleWebhook indicates an expected call of HandleWebhook.
+func (mr *MockPaymentServiceMockRecorder) HandleWebhook(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleWebhook", reflect.TypeOf((*MockPaymentService)(nil).HandleWebhook), arg0, arg1, arg2)
+}
+
+// Refund mocks base method.
+func (m *MockPaymentService) Refund(arg0 context.Context, arg1 int) (*model.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Refund", arg0, arg1)
+	ret0, _ := ret[0].(*model.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Refund indicates an expected call of Refund.
+func (mr *MockPaymentServiceMockRecorder) Refund(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refund", reflect.TypeOf((*MockPaymentService)(nil).Refund), arg0, arg1)
+}
@@ -0,0 +1,65 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: cake-store/src/model (interfaces: ProductionRepository)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	model "cake-store/src/model"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockProductionRepository is a mock of ProductionRepository interface.
+type MockProductionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockProductionRepositoryMockRecorder
+}
+
+// MockProductionRepositoryMockRecorder is the mock recorder for MockProductionRepository.
+type MockProductionRepositoryMockRecorder struct {
+	mock *MockProductionRepository
+}
+
+// NewMockProductionRepository creates a new mock instance.
+func NewMockProductionRepository(ctrl *gomock.Controller) *MockProductionRepository {
+	mock := &MockProductionRepository{ctrl: ctrl}
+	mock.recorder = &MockProductionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProductionRepository) EXPECT() *MockProductionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindByCakeIds mocks base method.
+func (m *MockProductionRepository) FindByCakeIds(arg0 context.Context, arg1 []int) ([]*model.CakeProduction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByCakeIds", arg0, arg1)
+	ret0, _ := ret[0].([]*model.CakeProduction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByCakeIds indicates an expected call of FindByCakeIds.
+func (mr *MockProductionRepositoryMockRecorder) FindByCakeIds(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByCakeIds", reflect.TypeOf((*MockProductionRepository)(nil).FindByCakeIds), arg0, arg1)
+}
+
+// Save mocks base method.
+func (m *MockProductionRepository) Save(arg0 context.Context, arg1 *model.CakeProduction) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockProductionRepositoryMockRecorder) Save(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockProductionRepository)(nil).Save), arg0, arg1)
+}
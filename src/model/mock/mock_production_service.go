@@ -0,0 +1,81 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: cake-store/src/model (interfaces: ProductionService)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	model "cake-store/src/model"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockProductionService is a mock of ProductionService interface.
+type MockProductionService struct {
+	ctrl     *gomock.Controller
+	recorder *MockProductionServiceMockRecorder
+}
+
+// MockProductionServiceMockRecorder is the mock recorder for MockProductionService.
+type MockProductionServiceMockRecorder struct {
+	mock *MockProductionService
+}
+
+// NewMockProductionService creates a new mock instance.
+func NewMockProductionService(ctrl *gomock.Controller) *MockProductionService {
+	mock := &MockProductionService{ctrl: ctrl}
+	mock.recorder = &MockProductionServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProductionService) EXPECT() *MockProductionServiceMockRecorder {
+	return m.recorder
+}
+
+// FindCakeProduction mocks base method.
+func (m *MockProductionService) FindCakeProduction(arg0 context.Context, arg1 int) (*model.CakeProduction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindCakeProduction", arg0, arg1)
+	ret0, _ := ret[0].(*model.CakeProduction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindCakeProduction indicates an expected call of FindCakeProduction.
+func (mr *MockProductionServiceMockRecorder) FindCakeProduction(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindCakeProduction", reflect.TypeOf((*MockProductionService)(nil).FindCakeProduction), arg0, arg1)
+}
+
+// Plan mocks base method.
+func (m *MockProductionService) Plan(arg0 context.Context, arg1 model.ProductionQuery) (*model.ProductionPlan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Plan", arg0, arg1)
+	ret0, _ := ret[0].(*model.ProductionPlan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Plan indicates an expected call of Plan.
+func (mr *MockProductionServiceMockRecorder) Plan(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Plan", reflect.TypeOf((*MockProductionService)(nil).Plan), arg0, arg1)
+}
+
+// SetCakeProduction mocks base method.
+func (m *MockProductionService) SetCakeProduction(arg0 context.Context, arg1 model.SetCakeProductionRequest, arg2 int) (*model.CakeProduction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetCakeProduction", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.CakeProduction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetCakeProduction indicates an expected call of SetCakeProduction.
+func (mr *MockProductionServiceMockRecorder) SetCakeProduction(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCakeProduction", reflect.TypeOf((*MockProductionService)(nil).SetCakeProduction), arg0, arg1, arg2)
+}
@@ -0,0 +1,93 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: cake-store/src/model (interfaces: RecipeRepository)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	model "cake-store/src/model"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRecipeRepository is a mock of RecipeRepository interface.
+type MockRecipeRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRecipeRepositoryMockRecorder
+}
+
+// MockRecipeRepositoryMockRecorder is the mock recorder for MockRecipeRepository.
+type MockRecipeRepositoryMockRecorder struct {
+	mock *MockRecipeRepository
+}
+
+// NewMockRecipeRepository creates a new mock instance.
+func NewMockRecipeRepository(ctrl *gomock.Controller) *MockRecipeRepository {
+	mock := &MockRecipeRepository{ctrl: ctrl}
+	mock.recorder = &MockRecipeRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRecipeRepository) EXPECT() *MockRecipeRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockRecipeRepository) Delete(arg0 context.Context, arg1 *model.Recipe) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockRecipeRepositoryMockRecorder) Delete(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRecipeRepository)(nil).Delete), arg0, arg1)
+}
+
+// FindByCakeId mocks base method.
+func (m *MockRecipeRepository) FindByCakeId(arg0 context.Context, arg1 int) (*model.Recipe, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByCakeId", arg0, arg1)
+	ret0, _ := ret[0].(*model.Recipe)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByCakeId indicates an expected call of FindByCakeId.
+func (mr *MockRecipeRepositoryMockRecorder) FindByCakeId(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByCakeId", reflect.TypeOf((*MockRecipeRepository)(nil).FindByCakeId), arg0, arg1)
+}
+
+// Save mocks base method.
+func (m *MockRecipeRepository) Save(arg0 context.Context, arg1 *model.Recipe) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockRecipeRepositoryMockRecorder) Save(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockRecipeRepository)(nil).Save), arg0, arg1)
+}
+
+// Update mocks base method.
+func (m *MockRecipeRepository) Update(arg0 context.Context, arg1 *model.Recipe) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockRecipeRepositoryMockRecorder) Update(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockRecipeRepository)(nil).Update), arg0, arg1)
+}
@@ -0,0 +1,81 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: cake-store/src/model (interfaces: RecipeService)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	model "cake-store/src/model"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRecipeService is a mock of RecipeService interface.
+type MockRecipeService struct {
+	ctrl     *gomock.Controller
+	recorder *MockRecipeServiceMockRecorder
+}
+
+// MockRecipeServiceMockRecorder is the mock recorder for MockRecipeService.
+type MockRecipeServiceMockRecorder struct {
+	mock *MockRecipeService
+}
+
+// NewMockRecipeService creates a new mock instance.
+func NewMockRecipeService(ctrl *gomock.Controller) *MockRecipeService {
+	mock := &MockRecipeService{ctrl: ctrl}
+	mock.recorder = &MockRecipeServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRecipeService) EXPECT() *MockRecipeServiceMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockRecipeService) Delete(arg0 context.Context, arg1 int) (*model.Recipe, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret0, _ := ret[0].(*model.Recipe)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockRecipeServiceMockRecorder) Delete(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRecipeService)(nil).Delete), arg0, arg1)
+}
+
+// FindByCakeId mocks base method.
+func (m *MockRecipeService) FindByCakeId(arg0 context.Context, arg1 model.RecipeQuery, arg2 int) (*model.Recipe, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByCakeId", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Recipe)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByCakeId indicates an expected call of FindByCakeId.
+func (mr *MockRecipeServiceMockRecorder) FindByCakeId(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByCakeId", reflect.TypeOf((*MockRecipeService)(nil).FindByCakeId), arg0, arg1, arg2)
+}
+
+// Save mocks base method.
+func (m *MockRecipeService) Save(arg0 context.Context, arg1 model.SaveRecipeRequest, arg2 int) (*model.Recipe, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Recipe)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockRecipeServiceMockRecorder) Save(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockRecipeService)(nil).Save), arg0, arg1, arg2)
+}
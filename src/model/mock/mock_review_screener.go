@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: cake-store/src/model (interfaces: ReviewScreener)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	model "cake-store/src/model"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockReviewScreener is a mock of ReviewScreener interface.
+type MockReviewScreener struct {
+	ctrl     *gomock.Controller
+	recorder *MockReviewScreenerMockRecorder
+}
+
+// MockReviewScreenerMockRecorder is the mock recorder for MockReviewScreener.
+type MockReviewScreenerMockRecorder struct {
+	mock *MockReviewScreener
+}
+
+// NewMockReviewScreener creates a new mock instance.
+func NewMockReviewScreener(ctrl *gomock.Controller) *MockReviewScreener {
+	mock := &MockReviewScreener{ctrl: ctrl}
+	mock.recorder = &MockReviewScreenerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReviewScreener) EXPECT() *MockReviewScreenerMockRecorder {
+	return m.recorder
+}
+
+// Screen mocks base method.
+func (m *MockReviewScreener) Screen(arg0 context.Context, arg1 *model.Review) (*model.ScreeningVerdict, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Screen", arg0, arg1)
+	ret0, _ := ret[0].(*model.ScreeningVerdict)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Screen indicates an expected call of Screen.
+func (mr *MockReviewScreenerMockRecorder) Screen(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Screen", reflect.TypeOf((*MockReviewScreener)(nil).Screen), arg0, arg1)
+}
@@ -0,0 +1,143 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: cake-store/src/model (interfaces: ReviewService)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	model "cake-store/src/model"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockReviewService is a mock of ReviewService interface.
+type MockReviewService struct {
+	ctrl     *gomock.Controller
+	recorder *MockReviewServiceMockRecorder
+}
+
+// MockReviewServiceMockRecorder is the mock recorder for MockReviewService.
+type MockReviewServiceMockRecorder struct {
+	mock *MockReviewService
+}
+
+// NewMockReviewService creates a new mock instance.
+func NewMockReviewService(ctrl *gomock.Controller) *MockReviewService {
+	mock := &MockReviewService{ctrl: ctrl}
+	mock.recorder = &MockReviewServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReviewService) EXPECT() *MockReviewServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockReviewService) Create(arg0 context.Context, arg1 model.CreateUpdateReviewRequest, arg2 int) (*model.Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockReviewServiceMockRecorder) Create(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockReviewService)(nil).Create), arg0, arg1, arg2)
+}
+
+// Delete mocks base method.
+func (m *MockReviewService) Delete(arg0 context.Context, arg1, arg2 int) (*model.Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockReviewServiceMockRecorder) Delete(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockReviewService)(nil).Delete), arg0, arg1, arg2)
+}
+
+// FindAll mocks base method.
+func (m *MockReviewService) FindAll(arg0 context.Context, arg1 model.ReviewQuery, arg2 int) ([]*model.Review, *model.Pagination, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*model.Review)
+	ret1, _ := ret[1].(*model.Pagination)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockReviewServiceMockRecorder) FindAll(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockReviewService)(nil).FindAll), arg0, arg1, arg2)
+}
+
+// FindById mocks base method.
+func (m *MockReviewService) FindById(arg0 context.Context, arg1, arg2 int) (*model.Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockReviewServiceMockRecorder) FindById(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockReviewService)(nil).FindById), arg0, arg1, arg2)
+}
+
+// FindModeration mocks base method.
+func (m *MockReviewService) FindModeration(arg0 context.Context, arg1 model.ReviewQuery) ([]*model.Review, *model.Pagination, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindModeration", arg0, arg1)
+	ret0, _ := ret[0].([]*model.Review)
+	ret1, _ := ret[1].(*model.Pagination)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FindModeration indicates an expected call of FindModeration.
+func (mr *MockReviewServiceMockRecorder) FindModeration(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindModeration", reflect.TypeOf((*MockReviewService)(nil).FindModeration), arg0, arg1)
+}
+
+// Moderate mocks base method.
+func (m *MockReviewService) Moderate(arg0 context.Context, arg1 model.ModerateReviewRequest, arg2 int) (*model.Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Moderate", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Moderate indicates an expected call of Moderate.
+func (mr *MockReviewServiceMockRecorder) Moderate(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Moderate", reflect.TypeOf((*MockReviewService)(nil).Moderate), arg0, arg1, arg2)
+}
+
+// Update mocks base method.
+func (m *MockReviewService) Update(arg0 context.Context, arg1 model.CreateUpdateReviewRequest, arg2, arg3 int) (*model.Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*model.Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockReviewServiceMockRecorder) Update(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockReviewService)(nil).Update), arg0, arg1, arg2, arg3)
+}
@@ -0,0 +1,197 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: cake-store/src/model (interfaces: SlotRepository)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	model "cake-store/src/model"
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockSlotRepository is a mock of SlotRepository interface.
+type MockSlotRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockSlotRepositoryMockRecorder
+}
+
+// MockSlotRepositoryMockRecorder is the mock recorder for MockSlotRepository.
+type MockSlotRepositoryMockRecorder struct {
+	mock *MockSlotRepository
+}
+
+// NewMockSlotRepository creates a new mock instance.
+func NewMockSlotRepository(ctrl *gomock.Controller) *MockSlotRepository {
+	mock := &MockSlotRepository{ctrl: ctrl}
+	mock.recorder = &MockSlotRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSlotRepository) EXPECT() *MockSlotRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Book mocks base method.
+func (m *MockSlotRepository) Book(arg0 context.Context, arg1 int, arg2 time.Time, arg3, arg4, arg5 int, arg6 time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Book", arg0, arg1, arg2, arg3, arg4, arg5, arg6)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Book indicates an expected call of Book.
+func (mr *MockSlotRepositoryMockRecorder) Book(arg0, arg1, arg2, arg3, arg4, arg5, arg6 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Book", reflect.TypeOf((*MockSlotRepository)(nil).Book), arg0, arg1, arg2, arg3, arg4, arg5, arg6)
+}
+
+// Booked mocks base method.
+func (m *MockSlotRepository) Booked(arg0 context.Context, arg1 int, arg2 []time.Time) (map[string]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Booked", arg0, arg1, arg2)
+	ret0, _ := ret[0].(map[string]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Booked indicates an expected call of Booked.
+func (mr *MockSlotRepositoryMockRecorder) Booked(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Booked", reflect.TypeOf((*MockSlotRepository)(nil).Booked), arg0, arg1, arg2)
+}
+
+// CountBooked mocks base method.
+func (m *MockSlotRepository) CountBooked(arg0 context.Context, arg1 int, arg2, arg3 time.Time) (map[string]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountBooked", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(map[string]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountBooked indicates an expected call of CountBooked.
+func (mr *MockSlotRepositoryMockRecorder) CountBooked(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountBooked", reflect.TypeOf((*MockSlotRepository)(nil).CountBooked), arg0, arg1, arg2, arg3)
+}
+
+// DeleteClosure mocks base method.
+func (m *MockSlotRepository) DeleteClosure(arg0 context.Context, arg1 *model.Closure) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteClosure", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteClosure indicates an expected call of DeleteClosure.
+func (mr *MockSlotRepositoryMockRecorder) DeleteClosure(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteClosure", reflect.TypeOf((*MockSlotRepository)(nil).DeleteClosure), arg0, arg1)
+}
+
+// FindClosure mocks base method.
+func (m *MockSlotRepository) FindClosure(arg0 context.Context, arg1 int, arg2 time.Time) (*model.Closure, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindClosure", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Closure)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindClosure indicates an expected call of FindClosure.
+func (mr *MockSlotRepositoryMockRecorder) FindClosure(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindClosure", reflect.TypeOf((*MockSlotRepository)(nil).FindClosure), arg0, arg1, arg2)
+}
+
+// FindClosures mocks base method.
+func (m *MockSlotRepository) FindClosures(arg0 context.Context, arg1 int, arg2 time.Time) ([]*model.Closure, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindClosures", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*model.Closure)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindClosures indicates an expected call of FindClosures.
+func (mr *MockSlotRepositoryMockRecorder) FindClosures(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindClosures", reflect.TypeOf((*MockSlotRepository)(nil).FindClosures), arg0, arg1, arg2)
+}
+
+// FindOpeningHours mocks base method.
+func (m *MockSlotRepository) FindOpeningHours(arg0 context.Context, arg1 int) ([]*model.OpeningHours, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindOpeningHours", arg0, arg1)
+	ret0, _ := ret[0].([]*model.OpeningHours)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindOpeningHours indicates an expected call of FindOpeningHours.
+func (mr *MockSlotRepositoryMockRecorder) FindOpeningHours(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOpeningHours", reflect.TypeOf((*MockSlotRepository)(nil).FindOpeningHours), arg0, arg1)
+}
+
+// Reconcile mocks base method.
+func (m *MockSlotRepository) Reconcile(arg0 context.Context, arg1 int, arg2 time.Time, arg3 int, arg4 time.Duration) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reconcile", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reconcile indicates an expected call of Reconcile.
+func (mr *MockSlotRepositoryMockRecorder) Reconcile(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reconcile", reflect.TypeOf((*MockSlotRepository)(nil).Reconcile), arg0, arg1, arg2, arg3, arg4)
+}
+
+// Release mocks base method.
+func (m *MockSlotRepository) Release(arg0 context.Context, arg1 int, arg2 time.Time, arg3 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Release", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Release indicates an expected call of Release.
+func (mr *MockSlotRepositoryMockRecorder) Release(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Release", reflect.TypeOf((*MockSlotRepository)(nil).Release), arg0, arg1, arg2, arg3)
+}
+
+// SaveClosure mocks base method.
+func (m *MockSlotRepository) SaveClosure(arg0 context.Context, arg1 *model.Closure) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveClosure", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveClosure indicates an expected call of SaveClosure.
+func (mr *MockSlotRepositoryMockRecorder) SaveClosure(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveClosure", reflect.TypeOf((*MockSlotRepository)(nil).SaveClosure), arg0, arg1)
+}
+
+// SaveOpeningHours mocks base method.
+func (m *MockSlotRepository) SaveOpeningHours(arg0 context.Context, arg1 int, arg2 []*model.OpeningHours) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveOpeningHours", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveOpeningHours indicates an expected call of SaveOpeningHours.
+func (mr *MockSlotRepositoryMockRecorder) SaveOpeningHours(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveOpeningHours", reflect.TypeOf((*MockSlotRepository)(nil).SaveOpeningHours), arg0, arg1, arg2)
+}
@@ -0,0 +1,170 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: cake-store/src/model (interfaces: SlotService)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	model "cake-store/src/model"
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockSlotService is a mock of SlotService interface.
+type MockSlotService struct {
+	ctrl     *gomock.Controller
+	recorder *MockSlotServiceMockRecorder
+}
+
+// MockSlotServiceMockRecorder is the mock recorder for MockSlotService.
+type MockSlotServiceMockRecorder struct {
+	mock *MockSlotService
+}
+
+// NewMockSlotService creates a new mock instance.
+func NewMockSlotService(ctrl *gomock.Controller) *MockSlotService {
+	mock := &MockSlotService{ctrl: ctrl}
+	mock.recorder = &MockSlotServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSlotService) EXPECT() *MockSlotServiceMockRecorder {
+	return m.recorder
+}
+
+// Availability mocks base method.
+func (m *MockSlotService) Availability(arg0 context.Context, arg1 model.SlotQuery) (*model.DaySlots, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Availability", arg0, arg1)
+	ret0, _ := ret[0].(*model.DaySlots)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Availability indicates an expected call of Availability.
+func (mr *MockSlotServiceMockRecorder) Availability(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Availability", reflect.TypeOf((*MockSlotService)(nil).Availability), arg0, arg1)
+}
+
+// Book mocks base method.
+func (m *MockSlotService) Book(arg0 context.Context, arg1 int, arg2 time.Time, arg3 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Book", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Book indicates an expected call of Book.
+func (mr *MockSlotServiceMockRecorder) Book(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Book", reflect.TypeOf((*MockSlotService)(nil).Book), arg0, arg1, arg2, arg3)
+}
+
+// CreateClosure mocks base method.
+func (m *MockSlotService) CreateClosure(arg0 context.Context, arg1 model.CreateClosureRequest, arg2 int) (*model.Closure, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateClosure", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Closure)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateClosure indicates an expected call of CreateClosure.
+func (mr *MockSlotServiceMockRecorder) CreateClosure(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateClosure", reflect.TypeOf((*MockSlotService)(nil).CreateClosure), arg0, arg1, arg2)
+}
+
+// DeleteClosure mocks base method.
+func (m *MockSlotService) DeleteClosure(arg0 context.Context, arg1 int, arg2 string) (*model.Closure, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteClosure", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Closure)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteClosure indicates an expected call of DeleteClosure.
+func (mr *MockSlotServiceMockRecorder) DeleteClosure(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteClosure", reflect.TypeOf((*MockSlotService)(nil).DeleteClosure), arg0, arg1, arg2)
+}
+
+// FindClosures mocks base method.
+func (m *MockSlotService) FindClosures(arg0 context.Context, arg1 int) ([]*model.Closure, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindClosures", arg0, arg1)
+	ret0, _ := ret[0].([]*model.Closure)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindClosures indicates an expected call of FindClosures.
+func (mr *MockSlotServiceMockRecorder) FindClosures(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindClosures", reflect.TypeOf((*MockSlotService)(nil).FindClosures), arg0, arg1)
+}
+
+// FindOpeningHours mocks base method.
+func (m *MockSlotService) FindOpeningHours(arg0 context.Context, arg1 int) ([]*model.OpeningHours, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindOpeningHours", arg0, arg1)
+	ret0, _ := ret[0].([]*model.OpeningHours)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindOpeningHours indicates an expected call of FindOpeningHours.
+func (mr *MockSlotServiceMockRecorder) FindOpeningHours(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOpeningHours", reflect.TypeOf((*MockSlotService)(nil).FindOpeningHours), arg0, arg1)
+}
+
+// Reconcile mocks base method.
+func (m *MockSlotService) Reconcile(arg0 context.Context, arg1 int, arg2 time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reconcile", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reconcile indicates an expected call of Reconcile.
+func (mr *MockSlotServiceMockRecorder) Reconcile(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reconcile", reflect.TypeOf((*MockSlotService)(nil).Reconcile), arg0, arg1, arg2)
+}
+
+// Release mocks base method.
+func (m *MockSlotService) Release(arg0 context.Context, arg1 int, arg2 time.Time, arg3 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Release", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Release indicates an expected call of Release.
+func (mr *MockSlotServiceMockRecorder) Release(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Release", reflect.TypeOf((*MockSlotService)(nil).Release), arg0, arg1, arg2, arg3)
+}
+
+// SetOpeningHours mocks base method.
+func (m *MockSlotService) SetOpeningHours(arg0 context.Context, arg1 model.SetOpeningHoursRequest, arg2 int) ([]*model.OpeningHours, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetOpeningHours", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*model.OpeningHours)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetOpeningHours indicates an expected call of SetOpeningHours.
+func (mr *MockSlotServiceMockRecorder) SetOpeningHours(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetOpeningHours", reflect.TypeOf((*MockSlotService)(nil).SetOpeningHours), arg0, arg1, arg2)
+}
@@ -0,0 +1,183 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: cake-store/src/model (interfaces: StockRepository)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	model "cake-store/src/model"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockStockRepository is a mock of StockRepository interface.
+type MockStockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockStockRepositoryMockRecorder
+}
+
+// MockStockRepositoryMockRecorder is the mock recorder for MockStockRepository.
+type MockStockRepositoryMockRecorder struct {
+	mock *MockStockRepository
+}
+
+// NewMockStockRepository creates a new mock instance.
+func NewMockStockRepository(ctrl *gomock.Controller) *MockStockRepository {
+	mock := &MockStockRepository{ctrl: ctrl}
+	mock.recorder = &MockStockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStockRepository) EXPECT() *MockStockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Adjust mocks base method.
+func (m *MockStockRepository) Adjust(arg0 context.Context, arg1 *model.StockAdjustment) (*model.Stock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Adjust", arg0, arg1)
+	ret0, _ := ret[0].(*model.Stock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Adjust indicates an expected call of Adjust.
+func (mr *MockStockRepositoryMockRecorder) Adjust(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Adjust", reflect.TypeOf((*MockStockRepository)(nil).Adjust), arg0, arg1)
+}
+
+// FindAdjustments mocks base method.
+func (m *MockStockRepository) FindAdjustments(arg0 context.Context, arg1, arg2 int) ([]*model.StockAdjustment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAdjustments", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*model.StockAdjustment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAdjustments indicates an expected call of FindAdjustments.
+func (mr *MockStockRepositoryMockRecorder) FindAdjustments(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAdjustments", reflect.TypeOf((*MockStockRepository)(nil).FindAdjustments), arg0, arg1, arg2)
+}
+
+// FindByCakeId mocks base method.
+func (m *MockStockRepository) FindByCakeId(arg0 context.Context, arg1 int) ([]*model.Stock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByCakeId", arg0, arg1)
+	ret0, _ := ret[0].([]*model.Stock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByCakeId indicates an expected call of FindByCakeId.
+func (mr *MockStockRepositoryMockRecorder) FindByCakeId(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByCakeId", reflect.TypeOf((*MockStockRepository)(nil).FindByCakeId), arg0, arg1)
+}
+
+// FindByItem mocks base method.
+func (m *MockStockRepository) FindByItem(arg0 context.Context, arg1, arg2 int) (*model.Stock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByItem", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Stock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByItem indicates an expected call of FindByItem.
+func (mr *MockStockRepositoryMockRecorder) FindByItem(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByItem", reflect.TypeOf((*MockStockRepository)(nil).FindByItem), arg0, arg1, arg2)
+}
+
+// FindLow mocks base method.
+func (m *MockStockRepository) FindLow(arg0 context.Context) ([]*model.Stock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindLow", arg0)
+	ret0, _ := ret[0].([]*model.Stock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindLow indicates an expected call of FindLow.
+func (mr *MockStockRepositoryMockRecorder) FindLow(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindLow", reflect.TypeOf((*MockStockRepository)(nil).FindLow), arg0)
+}
+
+// FindReservation mocks base method.
+func (m *MockStockRepository) FindReservation(arg0 context.Context, arg1 string) (*model.Reservation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindReservation", arg0, arg1)
+	ret0, _ := ret[0].(*model.Reservation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindReservation indicates an expected call of FindReservation.
+func (mr *MockStockRepositoryMockRecorder) FindReservation(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindReservation", reflect.TypeOf((*MockStockRepository)(nil).FindReservation), arg0, arg1)
+}
+
+// Release mocks base method.
+func (m *MockStockRepository) Release(arg0 context.Context, arg1 *model.Reservation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Release", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Release indicates an expected call of Release.
+func (mr *MockStockRepositoryMockRecorder) Release(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Release", reflect.TypeOf((*MockStockRepository)(nil).Release), arg0, arg1)
+}
+
+// Reserve mocks base method.
+func (m *MockStockRepository) Reserve(arg0 context.Context, arg1 *model.Reservation, arg2 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reserve", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reserve indicates an expected call of Reserve.
+func (mr *MockStockRepositoryMockRecorder) Reserve(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reserve", reflect.TypeOf((*MockStockRepository)(nil).Reserve), arg0, arg1, arg2)
+}
+
+// Reserved mocks base method.
+func (m *MockStockRepository) Reserved(arg0 context.Context, arg1, arg2 int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reserved", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reserved indicates an expected call of Reserved.
+func (mr *MockStockRepositoryMockRecorder) Reserved(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reserved", reflect.TypeOf((*MockStockRepository)(nil).Reserved), arg0, arg1, arg2)
+}
+
+// SetThreshold mocks base method.
+func (m *MockStockRepository) SetThreshold(arg0 context.Context, arg1, arg2, arg3 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetThreshold", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetThreshold indicates an expected call of SetThreshold.
+func (mr *MockStockRepositoryMockRecorder) SetThreshold(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetThreshold", reflect.TypeOf((*MockStockRepository)(nil).SetThreshold), arg0, arg1, arg2, arg3)
+}
@@ -0,0 +1,156 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: cake-store/src/model (interfaces: StockService)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	model "cake-store/src/model"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockStockService is a mock of StockService interface.
+type MockStockService struct {
+	ctrl     *gomock.Controller
+	recorder *MockStockServiceMockRecorder
+}
+
+// MockStockServiceMockRecorder is the mock recorder for MockStockService.
+type MockStockServiceMockRecorder struct {
+	mock *MockStockService
+}
+
+// NewMockStockService creates a new mock instance.
+func NewMockStockService(ctrl *gomock.Controller) *MockStockService {
+	mock := &MockStockService{ctrl: ctrl}
+	mock.recorder = &MockStockServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStockService) EXPECT() *MockStockServiceMockRecorder {
+	return m.recorder
+}
+
+// Adjust mocks base method.
+func (m *MockStockService) Adjust(arg0 context.Context, arg1 model.AdjustStockRequest, arg2 int) (*model.Stock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Adjust", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Stock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Adjust indicates an expected call of Adjust.
+func (mr *MockStockServiceMockRecorder) Adjust(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Adjust", reflect.TypeOf((*MockStockService)(nil).Adjust), arg0, arg1, arg2)
+}
+
+// Commit mocks base method.
+func (m *MockStockService) Commit(arg0 context.Context, arg1 string) (*model.Reservation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Commit", arg0, arg1)
+	ret0, _ := ret[0].(*model.Reservation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Commit indicates an expected call of Commit.
+func (mr *MockStockServiceMockRecorder) Commit(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Commit", reflect.TypeOf((*MockStockService)(nil).Commit), arg0, arg1)
+}
+
+// FindAdjustments mocks base method.
+func (m *MockStockService) FindAdjustments(arg0 context.Context, arg1 int) ([]*model.StockAdjustment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAdjustments", arg0, arg1)
+	ret0, _ := ret[0].([]*model.StockAdjustment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAdjustments indicates an expected call of FindAdjustments.
+func (mr *MockStockServiceMockRecorder) FindAdjustments(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAdjustments", reflect.TypeOf((*MockStockService)(nil).FindAdjustments), arg0, arg1)
+}
+
+// FindByCakeId mocks base method.
+func (m *MockStockService) FindByCakeId(arg0 context.Context, arg1 int) ([]*model.Stock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByCakeId", arg0, arg1)
+	ret0, _ := ret[0].([]*model.Stock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByCakeId indicates an expected call of FindByCakeId.
+func (mr *MockStockServiceMockRecorder) FindByCakeId(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByCakeId", reflect.TypeOf((*MockStockService)(nil).FindByCakeId), arg0, arg1)
+}
+
+// FindLow mocks base method.
+func (m *MockStockService) FindLow(arg0 context.Context) ([]*model.Stock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindLow", arg0)
+	ret0, _ := ret[0].([]*model.Stock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindLow indicates an expected call of FindLow.
+func (mr *MockStockServiceMockRecorder) FindLow(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindLow", reflect.TypeOf((*MockStockService)(nil).FindLow), arg0)
+}
+
+// Release mocks base method.
+func (m *MockStockService) Release(arg0 context.Context, arg1 string) (*model.Reservation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Release", arg0, arg1)
+	ret0, _ := ret[0].(*model.Reservation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Release indicates an expected call of Release.
+func (mr *MockStockServiceMockRecorder) Release(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Release", reflect.TypeOf((*MockStockService)(nil).Release), arg0, arg1)
+}
+
+// Reserve mocks base method.
+func (m *MockStockService) Reserve(arg0 context.Context, arg1 model.ReserveStockRequest, arg2 int) (*model.Reservation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reserve", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Reservation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reserve indicates an expected call of Reserve.
+func (mr *MockStockServiceMockRecorder) Reserve(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reserve", reflect.TypeOf((*MockStockService)(nil).Reserve), arg0, arg1, arg2)
+}
+
+// SetThreshold mocks base method.
+func (m *MockStockService) SetThreshold(arg0 context.Context, arg1 model.SetStockThresholdRequest, arg2 int) (*model.Stock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetThreshold", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Stock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetThreshold indicates an expected call of SetThreshold.
+func (mr *MockStockServiceMockRecorder) SetThreshold(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetThreshold", reflect.TypeOf((*MockStockService)(nil).SetThreshold), arg0, arg1, arg2)
+}
@@ -0,0 +1,153 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: cake-store/src/model (interfaces: StoreRepository)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	model "cake-store/src/model"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockStoreRepository is a mock of StoreRepository interface.
+type MockStoreRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockStoreRepositoryMockRecorder
+}
+
+// MockStoreRepositoryMockRecorder is the mock recorder for MockStoreRepository.
+type MockStoreRepositoryMockRecorder struct {
+	mock *MockStoreRepository
+}
+
+// NewMockStoreRepository creates a new mock instance.
+func NewMockStoreRepository(ctrl *gomock.Controller) *MockStoreRepository {
+	mock := &MockStoreRepository{ctrl: ctrl}
+	mock.recorder = &MockStoreRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStoreRepository) EXPECT() *MockStoreRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindAll mocks base method.
+func (m *MockStoreRepository) FindAll(arg0 context.Context) ([]*model.Store, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", arg0)
+	ret0, _ := ret[0].([]*model.Store)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockStoreRepositoryMockRecorder) FindAll(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockStoreRepository)(nil).FindAll), arg0)
+}
+
+// FindById mocks base method.
+func (m *MockStoreRepository) FindById(arg0 context.Context, arg1 int) (*model.Store, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", arg0, arg1)
+	ret0, _ := ret[0].(*model.Store)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockStoreRepositoryMockRecorder) FindById(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockStoreRepository)(nil).FindById), arg0, arg1)
+}
+
+// FindCake mocks base method.
+func (m *MockStoreRepository) FindCake(arg0 context.Context, arg1, arg2 int) (*model.StoreCake, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindCake", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.StoreCake)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindCake indicates an expected call of FindCake.
+func (mr *MockStoreRepositoryMockRecorder) FindCake(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindCake", reflect.TypeOf((*MockStoreRepository)(nil).FindCake), arg0, arg1, arg2)
+}
+
+// FindNearest mocks base method.
+func (m *MockStoreRepository) FindNearest(arg0 context.Context, arg1, arg2 float64, arg3 int) ([]*model.NearbyStore, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindNearest", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*model.NearbyStore)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindNearest indicates an expected call of FindNearest.
+func (mr *MockStoreRepositoryMockRecorder) FindNearest(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindNearest", reflect.TypeOf((*MockStoreRepository)(nil).FindNearest), arg0, arg1, arg2, arg3)
+}
+
+// FindPrices mocks base method.
+func (m *MockStoreRepository) FindPrices(arg0 context.Context, arg1 int, arg2 []int) (map[int]model.Money, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPrices", arg0, arg1, arg2)
+	ret0, _ := ret[0].(map[int]model.Money)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindPrices indicates an expected call of FindPrices.
+func (mr *MockStoreRepositoryMockRecorder) FindPrices(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPrices", reflect.TypeOf((*MockStoreRepository)(nil).FindPrices), arg0, arg1, arg2)
+}
+
+// Save mocks base method.
+func (m *MockStoreRepository) Save(arg0 context.Context, arg1 *model.Store) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockStoreRepositoryMockRecorder) Save(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockStoreRepository)(nil).Save), arg0, arg1)
+}
+
+// SaveCake mocks base method.
+func (m *MockStoreRepository) SaveCake(arg0 context.Context, arg1 *model.StoreCake) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveCake", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveCake indicates an expected call of SaveCake.
+func (mr *MockStoreRepositoryMockRecorder) SaveCake(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveCake", reflect.TypeOf((*MockStoreRepository)(nil).SaveCake), arg0, arg1)
+}
+
+// Update mocks base method.
+func (m *MockStoreRepository) Update(arg0 context.Context, arg1 *model.Store) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockStoreRepositoryMockRecorder) Update(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockStoreRepository)(nil).Update), arg0, arg1)
+}
@@ -0,0 +1,156 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: cake-store/src/model (interfaces: StoreService)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	model "cake-store/src/model"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockStoreService is a mock of StoreService interface.
+type MockStoreService struct {
+	ctrl     *gomock.Controller
+	recorder *MockStoreServiceMockRecorder
+}
+
+// MockStoreServiceMockRecorder is the mock recorder for MockStoreService.
+type MockStoreServiceMockRecorder struct {
+	mock *MockStoreService
+}
+
+// NewMockStoreService creates a new mock instance.
+func NewMockStoreService(ctrl *gomock.Controller) *MockStoreService {
+	mock := &MockStoreService{ctrl: ctrl}
+	mock.recorder = &MockStoreServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStoreService) EXPECT() *MockStoreServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockStoreService) Create(arg0 context.Context, arg1 model.CreateUpdateStoreRequest) (*model.Store, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(*model.Store)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockStoreServiceMockRecorder) Create(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockStoreService)(nil).Create), arg0, arg1)
+}
+
+// FindAll mocks base method.
+func (m *MockStoreService) FindAll(arg0 context.Context) ([]*model.Store, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", arg0)
+	ret0, _ := ret[0].([]*model.Store)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockStoreServiceMockRecorder) FindAll(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockStoreService)(nil).FindAll), arg0)
+}
+
+// FindById mocks base method.
+func (m *MockStoreService) FindById(arg0 context.Context, arg1 int) (*model.Store, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", arg0, arg1)
+	ret0, _ := ret[0].(*model.Store)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockStoreServiceMockRecorder) FindById(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockStoreService)(nil).FindById), arg0, arg1)
+}
+
+// FindCake mocks base method.
+func (m *MockStoreService) FindCake(arg0 context.Context, arg1, arg2 int) (*model.StoreCake, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindCake", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.StoreCake)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindCake indicates an expected call of FindCake.
+func (mr *MockStoreServiceMockRecorder) FindCake(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindCake", reflect.TypeOf((*MockStoreService)(nil).FindCake), arg0, arg1, arg2)
+}
+
+// FindNearest mocks base method.
+func (m *MockStoreService) FindNearest(arg0 context.Context, arg1 model.NearestStoreQuery) ([]*model.NearbyStore, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindNearest", arg0, arg1)
+	ret0, _ := ret[0].([]*model.NearbyStore)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindNearest indicates an expected call of FindNearest.
+func (mr *MockStoreServiceMockRecorder) FindNearest(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindNearest", reflect.TypeOf((*MockStoreService)(nil).FindNearest), arg0, arg1)
+}
+
+// QuoteDelivery mocks base method.
+func (m *MockStoreService) QuoteDelivery(arg0 context.Context, arg1 model.DeliveryQuoteRequest) (*model.DeliveryQuote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QuoteDelivery", arg0, arg1)
+	ret0, _ := ret[0].(*model.DeliveryQuote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QuoteDelivery indicates an expected call of QuoteDelivery.
+func (mr *MockStoreServiceMockRecorder) QuoteDelivery(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QuoteDelivery", reflect.TypeOf((*MockStoreService)(nil).QuoteDelivery), arg0, arg1)
+}
+
+// SetCake mocks base method.
+func (m *MockStoreService) SetCake(arg0 context.Context, arg1 model.SetStoreCakeRequest, arg2, arg3 int) (*model.StoreCake, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetCake", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*model.StoreCake)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetCake indicates an expected call of SetCake.
+func (mr *MockStoreServiceMockRecorder) SetCake(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCake", reflect.TypeOf((*MockStoreService)(nil).SetCake), arg0, arg1, arg2, arg3)
+}
+
+// Update mocks base method.
+func (m *MockStoreService) Update(arg0 context.Context, arg1 model.CreateUpdateStoreRequest, arg2 int) (*model.Store, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Store)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockStoreServiceMockRecorder) Update(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockStoreService)(nil).Update), arg0, arg1, arg2)
+}
@@ -0,0 +1,166 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: cake-store/src/model (interfaces: TaxonomyRepository)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	model "cake-store/src/model"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockTaxonomyRepository is a mock of TaxonomyRepository interface.
+type MockTaxonomyRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTaxonomyRepositoryMockRecorder
+}
+
+// MockTaxonomyRepositoryMockRecorder is the mock recorder for MockTaxonomyRepository.
+type MockTaxonomyRepositoryMockRecorder struct {
+	mock *MockTaxonomyRepository
+}
+
+// NewMockTaxonomyRepository creates a new mock instance.
+func NewMockTaxonomyRepository(ctrl *gomock.Controller) *MockTaxonomyRepository {
+	mock := &MockTaxonomyRepository{ctrl: ctrl}
+	mock.recorder = &MockTaxonomyRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTaxonomyRepository) EXPECT() *MockTaxonomyRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockTaxonomyRepository) Delete(arg0 context.Context, arg1 *model.Term) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockTaxonomyRepositoryMockRecorder) Delete(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTaxonomyRepository)(nil).Delete), arg0, arg1)
+}
+
+// FindAll mocks base method.
+func (m *MockTaxonomyRepository) FindAll(arg0 context.Context) ([]*model.Term, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", arg0)
+	ret0, _ := ret[0].([]*model.Term)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockTaxonomyRepositoryMockRecorder) FindAll(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockTaxonomyRepository)(nil).FindAll), arg0)
+}
+
+// FindById mocks base method.
+func (m *MockTaxonomyRepository) FindById(arg0 context.Context, arg1 int) (*model.Term, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", arg0, arg1)
+	ret0, _ := ret[0].(*model.Term)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockTaxonomyRepositoryMockRecorder) FindById(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockTaxonomyRepository)(nil).FindById), arg0, arg1)
+}
+
+// FindByIds mocks base method.
+func (m *MockTaxonomyRepository) FindByIds(arg0 context.Context, arg1 []int) ([]*model.Term, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByIds", arg0, arg1)
+	ret0, _ := ret[0].([]*model.Term)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByIds indicates an expected call of FindByIds.
+func (mr *MockTaxonomyRepositoryMockRecorder) FindByIds(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByIds", reflect.TypeOf((*MockTaxonomyRepository)(nil).FindByIds), arg0, arg1)
+}
+
+// FindCakeIds mocks base method.
+func (m *MockTaxonomyRepository) FindCakeIds(arg0 context.Context, arg1 int) ([]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindCakeIds", arg0, arg1)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindCakeIds indicates an expected call of FindCakeIds.
+func (mr *MockTaxonomyRepositoryMockRecorder) FindCakeIds(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindCakeIds", reflect.TypeOf((*MockTaxonomyRepository)(nil).FindCakeIds), arg0, arg1)
+}
+
+// LoadCakes mocks base method.
+func (m *MockTaxonomyRepository) LoadCakes(arg0 context.Context, arg1 []*model.Cake) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadCakes", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LoadCakes indicates an expected call of LoadCakes.
+func (mr *MockTaxonomyRepositoryMockRecorder) LoadCakes(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadCakes", reflect.TypeOf((*MockTaxonomyRepository)(nil).LoadCakes), arg0, arg1)
+}
+
+// Save mocks base method.
+func (m *MockTaxonomyRepository) Save(arg0 context.Context, arg1 *model.Term) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockTaxonomyRepositoryMockRecorder) Save(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockTaxonomyRepository)(nil).Save), arg0, arg1)
+}
+
+// SetCakeTerms mocks base method.
+func (m *MockTaxonomyRepository) SetCakeTerms(arg0 context.Context, arg1 int, arg2 []int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetCakeTerms", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetCakeTerms indicates an expected call of SetCakeTerms.
+func (mr *MockTaxonomyRepositoryMockRecorder) SetCakeTerms(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCakeTerms", reflect.TypeOf((*MockTaxonomyRepository)(nil).SetCakeTerms), arg0, arg1, arg2)
+}
+
+// Update mocks base method.
+func (m *MockTaxonomyRepository) Update(arg0 context.Context, arg1 *model.Term) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockTaxonomyRepositoryMockRecorder) Update(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockTaxonomyRepository)(nil).Update), arg0, arg1)
+}
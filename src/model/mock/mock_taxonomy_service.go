@@ -0,0 +1,126 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: cake-store/src/model (interfaces: TaxonomyService)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	model "cake-store/src/model"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockTaxonomyService is a mock of TaxonomyService interface.
+type MockTaxonomyService struct {
+	ctrl     *gomock.Controller
+	recorder *MockTaxonomyServiceMockRecorder
+}
+
+// MockTaxonomyServiceMockRecorder is the mock recorder for MockTaxonomyService.
+type MockTaxonomyServiceMockRecorder struct {
+	mock *MockTaxonomyService
+}
+
+// NewMockTaxonomyService creates a new mock instance.
+func NewMockTaxonomyService(ctrl *gomock.Controller) *MockTaxonomyService {
+	mock := &MockTaxonomyService{ctrl: ctrl}
+	mock.recorder = &MockTaxonomyServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTaxonomyService) EXPECT() *MockTaxonomyServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockTaxonomyService) Create(arg0 context.Context, arg1 model.CreateUpdateTermRequest) (*model.Term, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(*model.Term)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockTaxonomyServiceMockRecorder) Create(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockTaxonomyService)(nil).Create), arg0, arg1)
+}
+
+// Delete mocks base method.
+func (m *MockTaxonomyService) Delete(arg0 context.Context, arg1 int) (*model.Term, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret0, _ := ret[0].(*model.Term)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockTaxonomyServiceMockRecorder) Delete(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTaxonomyService)(nil).Delete), arg0, arg1)
+}
+
+// FindAll mocks base method.
+func (m *MockTaxonomyService) FindAll(arg0 context.Context) ([]*model.Term, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", arg0)
+	ret0, _ := ret[0].([]*model.Term)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockTaxonomyServiceMockRecorder) FindAll(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockTaxonomyService)(nil).FindAll), arg0)
+}
+
+// FindById mocks base method.
+func (m *MockTaxonomyService) FindById(arg0 context.Context, arg1 int) (*model.Term, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", arg0, arg1)
+	ret0, _ := ret[0].(*model.Term)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockTaxonomyServiceMockRecorder) FindById(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockTaxonomyService)(nil).FindById), arg0, arg1)
+}
+
+// SetCakeTerms mocks base method.
+func (m *MockTaxonomyService) SetCakeTerms(arg0 context.Context, arg1 model.SetCakeRelationRequest, arg2 int) ([]*model.Term, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetCakeTerms", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*model.Term)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetCakeTerms indicates an expected call of SetCakeTerms.
+func (mr *MockTaxonomyServiceMockRecorder) SetCakeTerms(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCakeTerms", reflect.TypeOf((*MockTaxonomyService)(nil).SetCakeTerms), arg0, arg1, arg2)
+}
+
+// Update mocks base method.
+func (m *MockTaxonomyService) Update(arg0 context.Context, arg1 model.CreateUpdateTermRequest, arg2 int) (*model.Term, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Term)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockTaxonomyServiceMockRecorder) Update(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockTaxonomyService)(nil).Update), arg0, arg1, arg2)
+}
@@ -0,0 +1,122 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: cake-store/src/model (interfaces: VariantRepository)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	model "cake-store/src/model"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockVariantRepository is a mock of VariantRepository interface.
+type MockVariantRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockVariantRepositoryMockRecorder
+}
+
+// MockVariantRepositoryMockRecorder is the mock recorder for MockVariantRepository.
+type MockVariantRepositoryMockRecorder struct {
+	mock *MockVariantRepository
+}
+
+// NewMockVariantRepository creates a new mock instance.
+func NewMockVariantRepository(ctrl *gomock.Controller) *MockVariantRepository {
+	mock := &MockVariantRepository{ctrl: ctrl}
+	mock.recorder = &MockVariantRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVariantRepository) EXPECT() *MockVariantRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockVariantRepository) Delete(arg0 context.Context, arg1 *model.Variant) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockVariantRepositoryMockRecorder) Delete(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockVariantRepository)(nil).Delete), arg0, arg1)
+}
+
+// FindByCakeId mocks base method.
+func (m *MockVariantRepository) FindByCakeId(arg0 context.Context, arg1 int) ([]*model.Variant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByCakeId", arg0, arg1)
+	ret0, _ := ret[0].([]*model.Variant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByCakeId indicates an expected call of FindByCakeId.
+func (mr *MockVariantRepositoryMockRecorder) FindByCakeId(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByCakeId", reflect.TypeOf((*MockVariantRepository)(nil).FindByCakeId), arg0, arg1)
+}
+
+// FindById mocks base method.
+func (m *MockVariantRepository) FindById(arg0 context.Context, arg1 int) (*model.Variant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", arg0, arg1)
+	ret0, _ := ret[0].(*model.Variant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockVariantRepositoryMockRecorder) FindById(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockVariantRepository)(nil).FindById), arg0, arg1)
+}
+
+// LoadCakes mocks base method.
+func (m *MockVariantRepository) LoadCakes(arg0 context.Context, arg1 []*model.Cake) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadCakes", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LoadCakes indicates an expected call of LoadCakes.
+func (mr *MockVariantRepositoryMockRecorder) LoadCakes(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadCakes", reflect.TypeOf((*MockVariantRepository)(nil).LoadCakes), arg0, arg1)
+}
+
+// Save mocks base method.
+func (m *MockVariantRepository) Save(arg0 context.Context, arg1 *model.Variant) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockVariantRepositoryMockRecorder) Save(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockVariantRepository)(nil).Save), arg0, arg1)
+}
+
+// Update mocks base method.
+func (m *MockVariantRepository) Update(arg0 context.Context, arg1 *model.Variant) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockVariantRepositoryMockRecorder) Update(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockVariantRepository)(nil).Update), arg0, arg1)
+}
@@ -0,0 +1,111 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: cake-store/src/model (interfaces: VariantService)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	model "cake-store/src/model"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockVariantService is a mock of VariantService interface.
+type MockVariantService struct {
+	ctrl     *gomock.Controller
+	recorder *MockVariantServiceMockRecorder
+}
+
+// MockVariantServiceMockRecorder is the mock recorder for MockVariantService.
+type MockVariantServiceMockRecorder struct {
+	mock *MockVariantService
+}
+
+// NewMockVariantService creates a new mock instance.
+func NewMockVariantService(ctrl *gomock.Controller) *MockVariantService {
+	mock := &MockVariantService{ctrl: ctrl}
+	mock.recorder = &MockVariantServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVariantService) EXPECT() *MockVariantServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockVariantService) Create(arg0 context.Context, arg1 model.CreateUpdateVariantRequest, arg2 int) (*model.Variant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Variant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockVariantServiceMockRecorder) Create(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockVariantService)(nil).Create), arg0, arg1, arg2)
+}
+
+// Delete mocks base method.
+func (m *MockVariantService) Delete(arg0 context.Context, arg1, arg2 int) (*model.Variant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Variant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockVariantServiceMockRecorder) Delete(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockVariantService)(nil).Delete), arg0, arg1, arg2)
+}
+
+// FindAll mocks base method.
+func (m *MockVariantService) FindAll(arg0 context.Context, arg1 int) ([]*model.Variant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", arg0, arg1)
+	ret0, _ := ret[0].([]*model.Variant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockVariantServiceMockRecorder) FindAll(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockVariantService)(nil).FindAll), arg0, arg1)
+}
+
+// FindById mocks base method.
+func (m *MockVariantService) FindById(arg0 context.Context, arg1, arg2 int) (*model.Variant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Variant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockVariantServiceMockRecorder) FindById(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockVariantService)(nil).FindById), arg0, arg1, arg2)
+}
+
+// Update mocks base method.
+func (m *MockVariantService) Update(arg0 context.Context, arg1 model.CreateUpdateVariantRequest, arg2, arg3 int) (*model.Variant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*model.Variant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockVariantServiceMockRecorder) Update(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockVariantService)(nil).Update), arg0, arg1, arg2, arg3)
+}
@@ -0,0 +1,157 @@
+package model
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Money is an amount in the minor units of an ISO-4217 currency, e.g. 1050 USD is 10.50 USD
+type Money struct {
+	Amount   int64  `json:"amount" validate:"gte=0"`
+	Currency string `json:"currency" validate:"required,iso4217"`
+}
+
+func NewMoney(amount int64, currency string) Money {
+	return Money{
+		Amount:   amount,
+		Currency: strings.ToUpper(currency),
+	}
+}
+
+// currencyExponents is the number of minor unit digits of the currencies not using two digits
+var currencyExponents = map[string]int{
+	"BIF": 0, "CLP": 0, "DJF": 0, "GNF": 0, "ISK": 0, "JPY": 0, "KMF": 0, "KRW": 0, "PYG": 0,
+	"RWF": 0, "UGX": 0, "UYI": 0, "VND": 0, "VUV": 0, "XAF": 0, "XOF": 0, "XPF": 0,
+	"BHD": 3, "IQD": 3, "JOD": 3, "KWD": 3, "LYD": 3, "OMR": 3, "TND": 3,
+}
+
+// CurrencyExponent return the number of minor unit digits of the currency
+func CurrencyExponent(currency string) int {
+	if exp, ok := currencyExponents[strings.ToUpper(currency)]; ok {
+		return exp
+	}
+	return 2
+}
+
+// String format the money in major units, e.g. "USD 10.50"
+func (m Money) String() string {
+	exp := CurrencyExponent(m.Currency)
+	amount := m.Amount
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+
+	digits := strconv.FormatInt(amount, 10)
+	if exp == 0 {
+		return fmt.Sprintf("%s %s%s", m.Currency, sign, digits)
+	}
+	if len(digits) <= exp {
+		digits = strings.Repeat("0", exp-len(digits)+1) + digits
+	}
+	return fmt.Sprintf("%s %s%s.%s", m.Currency, sign, digits[:len(digits)-exp], digits[len(digits)-exp:])
+}
+
+type moneyJSON struct {
+	Amount   int64  `json:"amount"`
+	Currency string `json:"currency"`
+	Display  string `json:"display,omitempty"`
+}
+
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(moneyJSON{
+		Amount:   m.Amount,
+		Currency: m.Currency,
+		Display:  m.String(),
+	})
+}
+
+func (m *Money) UnmarshalJSON(data []byte) error {
+	res := moneyJSON{}
+	if err := json.Unmarshal(data, &res); err != nil {
+		return err
+	}
+
+	*m = NewMoney(res.Amount, res.Currency)
+	return nil
+}
+
+// Value store the amount in minor units, the currency is kept in its own column
+func (m Money) Value() (driver.Value, error) {
+	return m.Amount, nil
+}
+
+// Scan read the amount in minor units, the currency must be scanned from its own column
+func (m *Money) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case int64:
+		m.Amount = v
+	case []byte:
+		return m.scanString(string(v))
+	case string:
+		return m.scanString(v)
+	case nil:
+		m.Amount = 0
+	default:
+		return fmt.Errorf("money: cannot scan %T", src)
+	}
+	return nil
+}
+
+func (m *Money) scanString(src string) error {
+	amount, err := strconv.ParseInt(src, 10, 64)
+	if err != nil {
+		return fmt.Errorf("money: %w", err)
+	}
+	m.Amount = amount
+	return nil
+}
+
+// Convert return the money in the given currency, rate is the units of the target currency per one unit
+// of the money currency, the result is rounded half away from zero to the target minor unit
+func (m Money) Convert(currency string, rate *big.Rat) Money {
+	currency = strings.ToUpper(currency)
+	if currency == m.Currency {
+		return m
+	}
+
+	value := new(big.Rat).SetInt64(m.Amount)
+	value.Mul(value, rate)
+
+	shift := CurrencyExponent(currency) - CurrencyExponent(m.Currency)
+	scale := new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(abs(shift))), nil))
+	if shift >= 0 {
+		value.Mul(value, scale)
+	} else {
+		value.Quo(value, scale)
+	}
+
+	quo, rem := new(big.Int).QuoRem(value.Num(), value.Denom(), new(big.Int))
+	if rem.Abs(rem).Lsh(rem, 1).Cmp(value.Denom()) >= 0 {
+		quo.Add(quo, big.NewInt(int64(value.Sign())))
+	}
+
+	return Money{
+		Amount:   quo.Int64(),
+		Currency: currency,
+	}
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// ExchangeRateProvider give the rate to convert money between currencies
+type ExchangeRateProvider interface {
+	// Rate return the units of the to currency per one unit of the from currency
+	Rate(ctx context.Context, from, to string) (*big.Rat, error)
+}
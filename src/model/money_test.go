@@ -0,0 +1,80 @@
+package model
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMoney_String(t *testing.T) {
+	assert.Equal(t, "USD 10.50", NewMoney(1050, "usd").String())
+	assert.Equal(t, "USD 0.05", NewMoney(5, "USD").String())
+	assert.Equal(t, "USD -1.00", NewMoney(-100, "USD").String())
+	assert.Equal(t, "JPY 1200", NewMoney(1200, "JPY").String())
+	assert.Equal(t, "KWD 1.250", NewMoney(1250, "KWD").String())
+}
+
+func TestMoney_JSON(t *testing.T) {
+	bt, err := json.Marshal(NewMoney(1050, "USD"))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"amount":1050,"currency":"USD","display":"USD 10.50"}`, string(bt))
+
+	res := Money{}
+	require.NoError(t, json.Unmarshal([]byte(`{"amount":250000,"currency":"idr"}`), &res))
+	assert.Equal(t, NewMoney(250000, "IDR"), res)
+}
+
+func TestMoney_Scan(t *testing.T) {
+	res := Money{Currency: "IDR"}
+	require.NoError(t, res.Scan(int64(250000)))
+	assert.Equal(t, NewMoney(250000, "IDR"), res)
+
+	require.NoError(t, res.Scan([]byte("300")))
+	assert.Equal(t, int64(300), res.Amount)
+
+	assert.Error(t, res.Scan("1.5"))
+	assert.Error(t, res.Scan(1.5))
+
+	value, err := NewMoney(250000, "IDR").Value()
+	require.NoError(t, err)
+	assert.Equal(t, int64(250000), value)
+}
+
+func TestMoney_Convert(t *testing.T) {
+	t.Run("two digits", func(t *testing.T) {
+		res := NewMoney(1050, "USD").Convert("EUR", big.NewRat(92, 100))
+		assert.Equal(t, NewMoney(966, "EUR"), res)
+	})
+
+	t.Run("round half away from zero", func(t *testing.T) {
+		res := NewMoney(5, "USD").Convert("EUR", big.NewRat(1, 2))
+		assert.Equal(t, NewMoney(3, "EUR"), res)
+	})
+
+	t.Run("different exponent", func(t *testing.T) {
+		res := NewMoney(1000, "USD").Convert("JPY", big.NewRat(150, 1))
+		assert.Equal(t, NewMoney(1500, "JPY"), res)
+
+		res = NewMoney(1500, "JPY").Convert("USD", big.NewRat(1, 150))
+		assert.Equal(t, NewMoney(1000, "USD"), res)
+	})
+
+	t.Run("same currency", func(t *testing.T) {
+		res := NewMoney(1050, "USD").Convert("usd", big.NewRat(2, 1))
+		assert.Equal(t, NewMoney(1050, "USD"), res)
+	})
+}
+
+func TestMoney_Validate(t *testing.T) {
+	req := CreateUpdateVariantRequest{Size: "20cm", Servings: 8, Sku: "CHOCO-20", Price: NewMoney(1000, "USD")}
+	assert.NoError(t, req.Validate())
+
+	req.Price = NewMoney(1000, "XYZ")
+	assert.Error(t, req.Validate())
+
+	req.Price = NewMoney(-1, "USD")
+	assert.Error(t, req.Validate())
+}
@@ -0,0 +1,356 @@
+package model
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+	"unicode/utf8"
+
+	"github.com/labstack/echo/v4"
+)
+
+// option group kinds, a choice group pick among its choices and a text group take a free text, e.g. the inscription
+const (
+	OptionKindChoice string = "choice"
+	OptionKindText   string = "text"
+)
+
+// OptionConstraintRequest restrict a choice to the selections of another group, e.g. fondant only with a size of
+// at least 20, the choice is only allowed when a choice of the group with a value in the range is selected
+type OptionConstraintRequest struct {
+	Group    string   `json:"group" validate:"required,max=40"`
+	MinValue *float64 `json:"min_value" validate:"omitempty,gte=0"`
+	MaxValue *float64 `json:"max_value" validate:"omitempty,gte=0"`
+}
+
+// OptionChoiceRequest is a choice of a group, Value is the number the constraints compare, e.g. the size in cm.
+// A choice without price modifier is free
+type OptionChoiceRequest struct {
+	Code          string                    `json:"code" validate:"required,max=40,slug"`
+	Name          string                    `json:"name" validate:"required,max=100"`
+	PriceModifier *Money                    `json:"price_modifier" validate:"omitempty"`
+	Value         float64                   `json:"value" validate:"gte=0,lte=100000"`
+	Requires      []OptionConstraintRequest `json:"requires" validate:"max=10,dive"`
+}
+
+// OptionGroupRequest is a group of options of a cake. MaxChoices is the number of choices a choice group allows and
+// MaxLength the length of the text of a text group, PriceModifier is charged when the text is given
+type OptionGroupRequest struct {
+	Code          string                `json:"code" validate:"required,max=40,slug"`
+	Name          string                `json:"name" validate:"required,max=100"`
+	Kind          string                `json:"kind" validate:"required,oneof=choice text"`
+	Required      bool                  `json:"required"`
+	MaxChoices    int                   `json:"max_choices" validate:"gte=0,lte=50"`
+	MaxLength     int                   `json:"max_length" validate:"gte=0,lte=200"`
+	PriceModifier *Money                `json:"price_modifier" validate:"omitempty"`
+	Choices       []OptionChoiceRequest `json:"choices" validate:"max=50,dive"`
+}
+
+// SaveOptionsRequest replace the option groups of a cake, an empty list make the cake not customizable
+type SaveOptionsRequest struct {
+	Groups []OptionGroupRequest `json:"groups" validate:"max=20,dive"`
+}
+
+func (s *SaveOptionsRequest) Validate() error {
+	return validate.Struct(s)
+}
+
+// Inconsistency return why the groups do not fit together, empty when they do
+func (s *SaveOptionsRequest) Inconsistency() string {
+	kinds := make(map[string]string, len(s.Groups))
+	for _, group := range s.Groups {
+		if _, ok := kinds[group.Code]; ok {
+			return fmt.Sprintf("group %s is listed more than once", group.Code)
+		}
+		kinds[group.Code] = group.Kind
+	}
+
+	for _, group := range s.Groups {
+		switch group.Kind {
+		case OptionKindChoice:
+			if len(group.Choices) == 0 {
+				return fmt.Sprintf("group %s has no choice", group.Code)
+			}
+			if group.MaxChoices > len(group.Choices) {
+				return fmt.Sprintf("group %s allows more choices than it has", group.Code)
+			}
+		case OptionKindText:
+			if len(group.Choices) > 0 {
+				return fmt.Sprintf("text group %s cannot have choices", group.Code)
+			}
+			if group.MaxLength == 0 {
+				return fmt.Sprintf("text group %s needs a max length", group.Code)
+			}
+		}
+
+		codes := make(map[string]bool, len(group.Choices))
+		for _, choice := range group.Choices {
+			if codes[choice.Code] {
+				return fmt.Sprintf("choice %s of group %s is listed more than once", choice.Code, group.Code)
+			}
+			codes[choice.Code] = true
+
+			for _, constraint := range choice.Requires {
+				if constraint.Group == group.Code || kinds[constraint.Group] != OptionKindChoice {
+					return fmt.Sprintf("choice %s of group %s requires an unknown group %s", choice.Code, group.Code, constraint.Group)
+				}
+				if constraint.MinValue != nil && constraint.MaxValue != nil && *constraint.MinValue > *constraint.MaxValue {
+					return fmt.Sprintf("choice %s of group %s requires an empty range", choice.Code, group.Code)
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// OptionGroup is a group of options of a cake, MaxChoices is always at least 1 for a choice group
+type OptionGroup struct {
+	Id            int             `json:"id"`
+	CakeId        int             `json:"cake_id"`
+	Code          string          `json:"code"`
+	Name          string          `json:"name"`
+	Kind          string          `json:"kind"`
+	Required      bool            `json:"required"`
+	MaxChoices    int             `json:"max_choices"`
+	MaxLength     int             `json:"max_length"`
+	PriceModifier Money           `json:"price_modifier"`
+	Position      int             `json:"position"`
+	Choices       []*OptionChoice `json:"choices"`
+	UpdatedAt     time.Time       `json:"updated_at"`
+}
+
+type OptionChoice struct {
+	Id            int                 `json:"id"`
+	GroupId       int                 `json:"group_id"`
+	Code          string              `json:"code"`
+	Name          string              `json:"name"`
+	PriceModifier Money               `json:"price_modifier"`
+	Value         float64             `json:"value"`
+	Position      int                 `json:"position"`
+	Requires      []*OptionConstraint `json:"requires"`
+}
+
+// OptionConstraint allow the choice only when a choice of the group with a value in the range is selected, a nil
+// bound is open
+type OptionConstraint struct {
+	Id       int      `json:"id"`
+	ChoiceId int      `json:"choice_id"`
+	Group    string   `json:"group"`
+	MinValue *float64 `json:"min_value"`
+	MaxValue *float64 `json:"max_value"`
+}
+
+// Allow check the value is in the range
+func (o *OptionConstraint) Allow(value float64) bool {
+	if o.MinValue != nil && value < *o.MinValue {
+		return false
+	}
+	if o.MaxValue != nil && value > *o.MaxValue {
+		return false
+	}
+	return true
+}
+
+// describe the range, e.g. "at least 20"
+func (o *OptionConstraint) describe() string {
+	switch {
+	case o.MinValue != nil && o.MaxValue != nil:
+		return fmt.Sprintf("between %g and %g", *o.MinValue, *o.MaxValue)
+	case o.MinValue != nil:
+		return fmt.Sprintf("at least %g", *o.MinValue)
+	case o.MaxValue != nil:
+		return fmt.Sprintf("at most %g", *o.MaxValue)
+	}
+	return "any"
+}
+
+// OptionSelection select the choices of a choice group, or the text of a text group, by their codes
+type OptionSelection struct {
+	Group   string   `json:"group" validate:"required,max=40"`
+	Choices []string `json:"choices" validate:"max=50,dive,required,max=40"`
+	Text    string   `json:"text" validate:"max=200"`
+}
+
+// SelectedOption is a selected choice or text, the names and price are kept as they were when selected
+type SelectedOption struct {
+	Group         string `json:"group"`
+	GroupName     string `json:"group_name"`
+	Choice        string `json:"choice,omitempty"`
+	ChoiceName    string `json:"choice_name,omitempty"`
+	Text          string `json:"text,omitempty"`
+	PriceModifier Money  `json:"price_modifier"`
+}
+
+// SelectedOptions are stored as a json document on the order item
+type SelectedOptions []*SelectedOption
+
+// Amount return the sum of the price modifiers, they are all in the same currency
+func (s SelectedOptions) Amount() int64 {
+	var amount int64
+	for _, option := range s {
+		amount += option.PriceModifier.Amount
+	}
+	return amount
+}
+
+func (s SelectedOptions) Value() (driver.Value, error) {
+	if len(s) == 0 {
+		return nil, nil
+	}
+	return json.Marshal(s)
+}
+
+func (s *SelectedOptions) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*s = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(v, s)
+	case string:
+		return json.Unmarshal([]byte(v), s)
+	}
+	return fmt.Errorf("cannot scan %T into SelectedOptions", src)
+}
+
+// SelectOptions check the selections against the groups of the cake and return the selected options in the order of
+// the groups, reason explain why the selections are rejected and is empty when they are not
+func SelectOptions(groups []*OptionGroup, selections []OptionSelection) (selected SelectedOptions, reason string) {
+	byGroup := make(map[string]OptionSelection, len(selections))
+	for _, selection := range selections {
+		if _, ok := byGroup[selection.Group]; ok {
+			return nil, fmt.Sprintf("group %s is selected more than once", selection.Group)
+		}
+		byGroup[selection.Group] = selection
+	}
+
+	selected = make(SelectedOptions, 0, len(selections))
+	// values is the value of the choices selected in each group, checked by the constraints
+	values := make(map[string][]float64, len(groups))
+	chosen := make([]*OptionChoice, 0, len(selections))
+	names := make(map[string]string, len(groups))
+	for _, group := range groups {
+		names[group.Code] = group.Name
+		selection, ok := byGroup[group.Code]
+		if !ok || (len(selection.Choices) == 0 && selection.Text == "") {
+			if group.Required {
+				return nil, fmt.Sprintf("%s is required", group.Name)
+			}
+			continue
+		}
+
+		if group.Kind == OptionKindText {
+			if len(selection.Choices) > 0 {
+				return nil, fmt.Sprintf("%s takes a text, not choices", group.Name)
+			}
+			if utf8.RuneCountInString(selection.Text) > group.MaxLength {
+				return nil, fmt.Sprintf("%s is longer than %d characters", group.Name, group.MaxLength)
+			}
+			selected = append(selected, &SelectedOption{Group: group.Code, GroupName: group.Name, Text: selection.Text, PriceModifier: group.PriceModifier})
+			continue
+		}
+
+		if selection.Text != "" {
+			return nil, fmt.Sprintf("%s takes choices, not a text", group.Name)
+		}
+		if len(selection.Choices) > group.MaxChoices {
+			return nil, fmt.Sprintf("%s allows at most %d choices", group.Name, group.MaxChoices)
+		}
+
+		seen := make(map[string]bool, len(selection.Choices))
+		for _, code := range selection.Choices {
+			if seen[code] {
+				return nil, fmt.Sprintf("%s %s is selected more than once", group.Name, code)
+			}
+			seen[code] = true
+
+			choice := group.choice(code)
+			if choice == nil {
+				return nil, fmt.Sprintf("%s has no choice %s", group.Name, code)
+			}
+			chosen = append(chosen, choice)
+			values[group.Code] = append(values[group.Code], choice.Value)
+			selected = append(selected, &SelectedOption{Group: group.Code, GroupName: group.Name, Choice: choice.Code, ChoiceName: choice.Name,
+				PriceModifier: choice.PriceModifier})
+		}
+	}
+
+	for _, selection := range selections {
+		if _, ok := names[selection.Group]; !ok {
+			return nil, fmt.Sprintf("unknown option group %s", selection.Group)
+		}
+	}
+
+	for _, choice := range chosen {
+		for _, constraint := range choice.Requires {
+			if !anyAllowed(constraint, values[constraint.Group]) {
+				return nil, fmt.Sprintf("%s requires a %s of %s", choice.Name, names[constraint.Group], constraint.describe())
+			}
+		}
+	}
+	return selected, ""
+}
+
+func anyAllowed(constraint *OptionConstraint, values []float64) bool {
+	for _, value := range values {
+		if constraint.Allow(value) {
+			return true
+		}
+	}
+	return false
+}
+
+func (o *OptionGroup) choice(code string) *OptionChoice {
+	for _, choice := range o.Choices {
+		if choice.Code == code {
+			return choice
+		}
+	}
+	return nil
+}
+
+// QuoteOptionsRequest price the variant of the cake with the options in the store, the default store when StoreId is
+// empty, in the base currency when Currency is empty
+type QuoteOptionsRequest struct {
+	StoreId   int               `json:"store_id" validate:"omitempty,min=1"`
+	VariantId int               `json:"variant_id" validate:"gt=0"`
+	Quantity  int               `json:"quantity" validate:"gte=1,lte=100"`
+	Currency  string            `json:"currency" validate:"omitempty,iso4217"`
+	Options   []OptionSelection `json:"options" validate:"max=20,dive"`
+}
+
+func (q *QuoteOptionsRequest) Validate() error {
+	return validate.Struct(q)
+}
+
+// OptionQuote is the price of a custom cake, UnitPrice is the variant price plus the price modifiers of the options
+type OptionQuote struct {
+	CakeId    int             `json:"cake_id"`
+	VariantId int             `json:"variant_id"`
+	Quantity  int             `json:"quantity"`
+	BasePrice Money           `json:"base_price"`
+	Options   SelectedOptions `json:"options"`
+	UnitPrice Money           `json:"unit_price"`
+	Total     Money           `json:"total"`
+}
+
+type OptionRepository interface {
+	Save(ctx context.Context, cakeId int, groups []*OptionGroup) error
+	FindByCakeId(ctx context.Context, cakeId int) ([]*OptionGroup, error)
+}
+
+type OptionService interface {
+	Save(ctx context.Context, req SaveOptionsRequest, cakeId int) ([]*OptionGroup, error)
+	FindByCakeId(ctx context.Context, cakeId int) ([]*OptionGroup, error)
+	Quote(ctx context.Context, req QuoteOptionsRequest, cakeId int) (*OptionQuote, error)
+	// Select check the selections against the options of the cake and price them in the currency
+	Select(ctx context.Context, cakeId int, selections []OptionSelection, currency string) (SelectedOptions, error)
+}
+
+type OptionController interface {
+	HandleSave() echo.HandlerFunc
+	HandleFindByCakeId() echo.HandlerFunc
+	HandleQuote() echo.HandlerFunc
+}
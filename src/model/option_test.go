@@ -0,0 +1,132 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func customCakeOptions() []*OptionGroup {
+	twenty := 20.0
+	return []*OptionGroup{
+		{Code: "size", Name: "Size", Kind: OptionKindChoice, Required: true, MaxChoices: 1, Choices: []*OptionChoice{
+			{Code: "16cm", Name: "16 cm", Value: 16, PriceModifier: NewMoney(0, "IDR")},
+			{Code: "24cm", Name: "24 cm", Value: 24, PriceModifier: NewMoney(100000, "IDR")},
+		}},
+		{Code: "frosting", Name: "Frosting", Kind: OptionKindChoice, MaxChoices: 1, Choices: []*OptionChoice{
+			{Code: "buttercream", Name: "Buttercream", PriceModifier: NewMoney(0, "IDR")},
+			{Code: "fondant", Name: "Fondant", PriceModifier: NewMoney(75000, "IDR"), Requires: []*OptionConstraint{{Group: "size", MinValue: &twenty}}},
+		}},
+		{Code: "decorations", Name: "Decorations", Kind: OptionKindChoice, MaxChoices: 2, Choices: []*OptionChoice{
+			{Code: "berries", Name: "Berries", PriceModifier: NewMoney(30000, "IDR")},
+			{Code: "macarons", Name: "Macarons", PriceModifier: NewMoney(40000, "IDR")},
+			{Code: "sprinkles", Name: "Sprinkles", PriceModifier: NewMoney(5000, "IDR")},
+		}},
+		{Code: "inscription", Name: "Inscription", Kind: OptionKindText, MaxLength: 10, PriceModifier: NewMoney(15000, "IDR")},
+	}
+}
+
+func TestSelectOptions(t *testing.T) {
+	groups := customCakeOptions()
+
+	t.Run("ok", func(t *testing.T) {
+		selected, reason := SelectOptions(groups, []OptionSelection{
+			{Group: "inscription", Text: "Happy 30"},
+			{Group: "frosting", Choices: []string{"fondant"}},
+			{Group: "size", Choices: []string{"24cm"}},
+			{Group: "decorations", Choices: []string{"berries", "macarons"}},
+		})
+		require.Empty(t, reason)
+		require.Len(t, selected, 5)
+		assert.Equal(t, "size", selected[0].Group)
+		assert.Equal(t, "Fondant", selected[1].ChoiceName)
+		assert.Equal(t, "Happy 30", selected[4].Text)
+		assert.Equal(t, int64(260000), selected.Amount())
+	})
+
+	t.Run("ok - empty optional groups", func(t *testing.T) {
+		selected, reason := SelectOptions(groups, []OptionSelection{{Group: "size", Choices: []string{"16cm"}}, {Group: "inscription"}})
+		require.Empty(t, reason)
+		require.Len(t, selected, 1)
+	})
+
+	cases := []struct {
+		name       string
+		selections []OptionSelection
+		reason     string
+	}{
+		{"required", []OptionSelection{{Group: "frosting", Choices: []string{"buttercream"}}}, "Size is required"},
+		{"constraint", []OptionSelection{{Group: "size", Choices: []string{"16cm"}}, {Group: "frosting", Choices: []string{"fondant"}}},
+			"Fondant requires a Size of at least 20"},
+		{"too many choices", []OptionSelection{{Group: "size", Choices: []string{"16cm", "24cm"}}}, "Size allows at most 1 choices"},
+		{"unknown choice", []OptionSelection{{Group: "size", Choices: []string{"30cm"}}}, "Size has no choice 30cm"},
+		{"unknown group", []OptionSelection{{Group: "size", Choices: []string{"16cm"}}, {Group: "topper", Choices: []string{"candle"}}},
+			"unknown option group topper"},
+		{"group twice", []OptionSelection{{Group: "size", Choices: []string{"16cm"}}, {Group: "size", Choices: []string{"24cm"}}},
+			"group size is selected more than once"},
+		{"choice twice", []OptionSelection{{Group: "size", Choices: []string{"16cm"}}, {Group: "decorations", Choices: []string{"berries", "berries"}}},
+			"Decorations berries is selected more than once"},
+		{"text too long", []OptionSelection{{Group: "size", Choices: []string{"16cm"}}, {Group: "inscription", Text: "Happy Birthday"}},
+			"Inscription is longer than 10 characters"},
+		{"text on a choice group", []OptionSelection{{Group: "size", Text: "big"}}, "Size takes choices, not a text"},
+	}
+	for _, c := range cases {
+		selected, reason := SelectOptions(groups, c.selections)
+		assert.Equal(t, c.reason, reason, c.name)
+		assert.Nil(t, selected, c.name)
+	}
+
+	t.Run("no options", func(t *testing.T) {
+		selected, reason := SelectOptions(nil, nil)
+		assert.Empty(t, reason)
+		assert.Empty(t, selected)
+	})
+}
+
+func TestSaveOptionsRequest_Inconsistency(t *testing.T) {
+	twenty, ten := 20.0, 10.0
+	size := OptionGroupRequest{Code: "size", Name: "Size", Kind: OptionKindChoice, Choices: []OptionChoiceRequest{{Code: "20cm", Name: "20 cm", Value: 20}}}
+	frosting := OptionGroupRequest{Code: "frosting", Name: "Frosting", Kind: OptionKindChoice, Choices: []OptionChoiceRequest{
+		{Code: "fondant", Name: "Fondant", Requires: []OptionConstraintRequest{{Group: "size", MinValue: &twenty}}},
+	}}
+	inscription := OptionGroupRequest{Code: "inscription", Name: "Inscription", Kind: OptionKindText, MaxLength: 30}
+
+	req := SaveOptionsRequest{Groups: []OptionGroupRequest{frosting, size, inscription}}
+	assert.Empty(t, req.Inconsistency())
+
+	req = SaveOptionsRequest{Groups: []OptionGroupRequest{size, size}}
+	assert.Equal(t, "group size is listed more than once", req.Inconsistency())
+
+	req = SaveOptionsRequest{Groups: []OptionGroupRequest{frosting}}
+	assert.Equal(t, "choice fondant of group frosting requires an unknown group size", req.Inconsistency())
+
+	emptyRange := frosting
+	emptyRange.Choices = []OptionChoiceRequest{{Code: "fondant", Name: "Fondant", Requires: []OptionConstraintRequest{{Group: "size", MinValue: &twenty, MaxValue: &ten}}}}
+	req = SaveOptionsRequest{Groups: []OptionGroupRequest{emptyRange, size}}
+	assert.Equal(t, "choice fondant of group frosting requires an empty range", req.Inconsistency())
+
+	noLength := inscription
+	noLength.MaxLength = 0
+	req = SaveOptionsRequest{Groups: []OptionGroupRequest{noLength}}
+	assert.Equal(t, "text group inscription needs a max length", req.Inconsistency())
+
+	tooMany := size
+	tooMany.MaxChoices = 2
+	req = SaveOptionsRequest{Groups: []OptionGroupRequest{tooMany}}
+	assert.Equal(t, "group size allows more choices than it has", req.Inconsistency())
+}
+
+func TestSelectedOptions_Scan(t *testing.T) {
+	var options SelectedOptions
+	require.NoError(t, options.Scan([]byte(`[{"group":"size","group_name":"Size","choice":"24cm","price_modifier":{"amount":100000,"currency":"IDR"}}]`)))
+	require.Len(t, options, 1)
+	assert.Equal(t, NewMoney(100000, "IDR"), options[0].PriceModifier)
+
+	require.NoError(t, options.Scan(nil))
+	assert.Nil(t, options)
+
+	value, err := options.Value()
+	require.NoError(t, err)
+	assert.Nil(t, value)
+}
@@ -0,0 +1,203 @@
+package model
+
+import (
+	"context"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// order status
+const (
+	OrderStatusPending   string = "pending"
+	OrderStatusConfirmed string = "confirmed"
+	OrderStatusBaking    string = "baking"
+	OrderStatusReady     string = "ready"
+	OrderStatusPickedUp  string = "picked_up"
+	OrderStatusDelivered string = "delivered"
+	OrderStatusCancelled string = "cancelled"
+)
+
+// order fulfillment
+const (
+	FulfillmentPickup   string = "pickup"
+	FulfillmentDelivery string = "delivery"
+)
+
+// DateLayout is the layout of the dates without time, e.g. the pickup date
+const DateLayout = "2006-01-02"
+
+// orderTransitions is the order state machine, the statuses an order may move to from its current status
+var orderTransitions = map[string][]string{
+	OrderStatusPending:   {OrderStatusConfirmed, OrderStatusCancelled},
+	OrderStatusConfirmed: {OrderStatusBaking, OrderStatusCancelled},
+	OrderStatusBaking:    {OrderStatusReady},
+	OrderStatusReady:     {OrderStatusPickedUp, OrderStatusDelivered},
+}
+
+type CreateOrderItemRequest struct {
+	CakeId    int `json:"cake_id" validate:"gt=0"`
+	VariantId int `json:"variant_id" validate:"gt=0"`
+	Quantity  int `json:"quantity" validate:"gte=1,lte=100"`
+	// Message is the custom message written on the cake
+	Message string `json:"message" validate:"max=100"`
+	// Options is the selection of a custom cake, see the option groups of the cake
+	Options []OptionSelection `json:"options" validate:"max=20,dive"`
+}
+
+// CreateOrderRequest place an order in the store, the default store when StoreId is empty. PickupDate is the day
+// the order is picked up or delivered, today when empty. PickupTime is the start of the time slot booked for the
+// order on that day, the order has no slot when it is empty
+type CreateOrderRequest struct {
+	StoreId       int                      `json:"store_id" validate:"omitempty,min=1"`
+	CustomerName  string                   `json:"customer_name" validate:"required,max=100"`
+	CustomerPhone string                   `json:"customer_phone" validate:"required,max=30"`
+	Fulfillment   string                   `json:"fulfillment" validate:"required,oneof=pickup delivery"`
+	Note          string                   `json:"note" validate:"max=255"`
+	PickupDate    string                   `json:"pickup_date" validate:"omitempty,datetime=2006-01-02"`
+	PickupTime    string                   `json:"pickup_time" validate:"omitempty,datetime=15:04"`
+	Items         []CreateOrderItemRequest `json:"items" validate:"required,min=1,max=50,dive"`
+	Coupons       []string                 `json:"coupons" validate:"max=5,dive,required,max=40"`
+}
+
+func (c *CreateOrderRequest) Validate() error {
+	return validate.Struct(c)
+}
+
+type TransitionOrderRequest struct {
+	Status string `json:"status" validate:"required,oneof=pending confirmed baking ready picked_up delivered cancelled"`
+}
+
+func (t *TransitionOrderRequest) Validate() error {
+	return validate.Struct(t)
+}
+
+type OrderQuery struct {
+	Page   int    `query:"page" validate:"omitempty,min=1"`
+	Limit  int    `query:"limit" validate:"omitempty,min=1,max=100"`
+	Status string `query:"status" validate:"omitempty,oneof=pending confirmed baking ready picked_up delivered cancelled"`
+	// StoreId list only the orders of the store
+	StoreId int `query:"store_id" validate:"omitempty,min=1"`
+}
+
+func (o *OrderQuery) Validate() error {
+	return validate.Struct(o)
+}
+
+// SetDefault fill the empty page and limit
+func (o *OrderQuery) SetDefault() {
+	if o.Page == 0 {
+		o.Page = DefaultPage
+	}
+	if o.Limit == 0 {
+		o.Limit = DefaultLimit
+	}
+}
+
+type Order struct {
+	Id            int              `json:"id"`
+	StoreId       int              `json:"store_id"`
+	CustomerName  string           `json:"customer_name"`
+	CustomerPhone string           `json:"customer_phone"`
+	Fulfillment   string           `json:"fulfillment"`
+	Status        string           `json:"status"`
+	Note          string           `json:"note"`
+	PickupDate    time.Time        `json:"pickup_date"`
+	PickupSlot    *time.Time       `json:"pickup_slot"`
+	Subtotal      Money            `json:"subtotal"`
+	Discount      Money            `json:"discount"`
+	Total         Money            `json:"total"`
+	Items         []*OrderItem     `json:"items"`
+	Discounts     []*OrderDiscount `json:"discounts"`
+	CreatedAt     time.Time        `json:"created_at"`
+	UpdatedAt     time.Time        `json:"updated_at"`
+}
+
+// CanTransition check the order may move to the status, an order is only picked up or delivered as its fulfillment say
+func (o *Order) CanTransition(status string) bool {
+	if status == OrderStatusPickedUp && o.Fulfillment != FulfillmentPickup {
+		return false
+	}
+	if status == OrderStatusDelivered && o.Fulfillment != FulfillmentDelivery {
+		return false
+	}
+
+	for _, next := range orderTransitions[o.Status] {
+		if next == status {
+			return true
+		}
+	}
+	return false
+}
+
+// Units return the number of cakes of the order, they are what a time slot is booked with
+func (o *Order) Units() int {
+	units := 0
+	for _, item := range o.Items {
+		units += item.Quantity
+	}
+	return units
+}
+
+// CouponIds return the id of the coupons redeemed on the order
+func (o *Order) CouponIds() []int {
+	ids := make([]int, 0, len(o.Discounts))
+	for _, discount := range o.Discounts {
+		ids = append(ids, discount.CouponId)
+	}
+	return ids
+}
+
+// OrderItem is an ordered variant, the cake title, size, sku, options and price are kept as they were when ordered.
+// The unit price include the price modifiers of the options
+type OrderItem struct {
+	Id        int             `json:"id"`
+	OrderId   int             `json:"order_id"`
+	CakeId    int             `json:"cake_id"`
+	VariantId int             `json:"variant_id"`
+	Title     string          `json:"title"`
+	Size      string          `json:"size"`
+	Sku       string          `json:"sku"`
+	Quantity  int             `json:"quantity"`
+	Message   string          `json:"message"`
+	Options   SelectedOptions `json:"options,omitempty"`
+	UnitPrice Money           `json:"unit_price"`
+	Subtotal  Money           `json:"subtotal"`
+}
+
+// SetSubtotal fill the subtotal from the unit price and the quantity
+func (o *OrderItem) SetSubtotal() {
+	o.Subtotal = NewMoney(o.UnitPrice.Amount*int64(o.Quantity), o.UnitPrice.Currency)
+}
+
+// OrderDiscount is a coupon redeemed on the order, the code and amount are kept as they were when ordered
+type OrderDiscount struct {
+	Id       int    `json:"id"`
+	OrderId  int    `json:"order_id"`
+	CouponId int    `json:"coupon_id"`
+	Code     string `json:"code"`
+	Amount   Money  `json:"amount"`
+}
+
+type OrderRepository interface {
+	Save(ctx context.Context, order *Order) error
+	UpdateStatus(ctx context.Context, order *Order, from string) error
+	FindById(ctx context.Context, id int) (*Order, error)
+	FindAll(ctx context.Context, query OrderQuery) ([]*Order, error)
+	CountAll(ctx context.Context, query OrderQuery) (int64, error)
+	FindByPickupDate(ctx context.Context, date time.Time, status string) ([]*Order, error)
+}
+
+type OrderService interface {
+	Create(ctx context.Context, req CreateOrderRequest) (*Order, error)
+	Transition(ctx context.Context, req TransitionOrderRequest, orderId int) (*Order, error)
+	FindById(ctx context.Context, orderId int) (*Order, error)
+	FindAll(ctx context.Context, query OrderQuery) ([]*Order, *Pagination, error)
+}
+
+type OrderController interface {
+	HandleCreate() echo.HandlerFunc
+	HandleTransition() echo.HandlerFunc
+	HandleFindById() echo.HandlerFunc
+	HandleFindAll() echo.HandlerFunc
+}
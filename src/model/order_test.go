@@ -0,0 +1,48 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrder_CanTransition(t *testing.T) {
+	cases := []struct {
+		from        string
+		to          string
+		fulfillment string
+		ok          bool
+	}{
+		{OrderStatusPending, OrderStatusConfirmed, FulfillmentPickup, true},
+		{OrderStatusPending, OrderStatusCancelled, FulfillmentPickup, true},
+		{OrderStatusPending, OrderStatusBaking, FulfillmentPickup, false},
+		{OrderStatusConfirmed, OrderStatusBaking, FulfillmentPickup, true},
+		{OrderStatusConfirmed, OrderStatusCancelled, FulfillmentPickup, true},
+		{OrderStatusBaking, OrderStatusReady, FulfillmentPickup, true},
+		{OrderStatusBaking, OrderStatusCancelled, FulfillmentPickup, false},
+		{OrderStatusReady, OrderStatusPickedUp, FulfillmentPickup, true},
+		{OrderStatusReady, OrderStatusDelivered, FulfillmentPickup, false},
+		{OrderStatusReady, OrderStatusDelivered, FulfillmentDelivery, true},
+		{OrderStatusReady, OrderStatusPickedUp, FulfillmentDelivery, false},
+		{OrderStatusPickedUp, OrderStatusPending, FulfillmentPickup, false},
+		{OrderStatusCancelled, OrderStatusConfirmed, FulfillmentPickup, false},
+		{OrderStatusPending, OrderStatusPending, FulfillmentPickup, false},
+	}
+
+	for _, c := range cases {
+		order := &Order{Status: c.from, Fulfillment: c.fulfillment}
+		assert.Equal(t, c.ok, order.CanTransition(c.to), "%s -> %s (%s)", c.from, c.to, c.fulfillment)
+	}
+}
+
+func TestOrderItem_SetSubtotal(t *testing.T) {
+	item := &OrderItem{Quantity: 3, UnitPrice: NewMoney(250000, "IDR")}
+	item.SetSubtotal()
+	assert.Equal(t, NewMoney(750000, "IDR"), item.Subtotal)
+}
+
+func TestOrder_Units(t *testing.T) {
+	order := &Order{Items: []*OrderItem{{Quantity: 2}, {Quantity: 3}}}
+	assert.Equal(t, 5, order.Units())
+	assert.Equal(t, 0, (&Order{}).Units())
+}
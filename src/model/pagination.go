@@ -0,0 +1,32 @@
+package model
+
+// pagination default
+const (
+	DefaultPage  int = 1
+	DefaultLimit int = 10
+	MaxLimit     int = 100
+)
+
+// pagination mode
+const (
+	PaginateOffset string = "offset"
+	PaginateCursor string = "cursor"
+)
+
+type Pagination struct {
+	Total int64  `json:"total"`
+	Page  int    `json:"page,omitempty"`
+	Limit int    `json:"limit"`
+	Next  string `json:"next"`
+	Prev  string `json:"prev"`
+
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// Offset return the row offset of the requested page
+func Offset(page, limit int) int {
+	if page < 1 {
+		return 0
+	}
+	return (page - 1) * limit
+}
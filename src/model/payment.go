@@ -0,0 +1,154 @@
+package model
+
+import (
+	"context"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// payment status
+const (
+	PaymentStatusAuthorized string = "authorized"
+	PaymentStatusDeclined   string = "declined"
+	// PaymentStatusCapturing claim the payment while the gateway capture it, so it is captured at most once
+	PaymentStatusCapturing string = "capturing"
+	// PaymentStatusSettling is a capture accepted by the gateway whose settlement is confirmed later by a webhook
+	PaymentStatusSettling string = "settling"
+	PaymentStatusCaptured string = "captured"
+	PaymentStatusFailed   string = "failed"
+	PaymentStatusRefunded string = "refunded"
+	// PaymentStatusRefunding claim the payment while the gateway refund it, so it is refunded at most once
+	PaymentStatusRefunding string = "refunding"
+)
+
+// PaymentSignatureHeader is the header of the signature of the webhooks sent by the payment gateway
+const PaymentSignatureHeader = "X-Payment-Signature"
+
+// paymentTransitions is the payment state machine, the statuses a payment may move to from its current status.
+// A claimed payment move back to its previous status when the gateway call fail.
+var paymentTransitions = map[string][]string{
+	PaymentStatusAuthorized: {PaymentStatusCapturing},
+	PaymentStatusCapturing:  {PaymentStatusSettling, PaymentStatusCaptured, PaymentStatusAuthorized},
+	PaymentStatusSettling:   {PaymentStatusCaptured, PaymentStatusFailed},
+	PaymentStatusCaptured:   {PaymentStatusRefunding},
+	PaymentStatusRefunding:  {PaymentStatusRefunded, PaymentStatusCaptured},
+}
+
+// AuthorizePaymentRequest pay an order with the payment method of the token, the token is given by the gateway to
+// the customer
+type AuthorizePaymentRequest struct {
+	Token string `json:"token" validate:"required,max=100"`
+}
+
+func (a *AuthorizePaymentRequest) Validate() error {
+	return validate.Struct(a)
+}
+
+// Payment is an attempt to pay an order through a gateway, a declined or failed attempt is kept and the order may be
+// paid by a new attempt
+type Payment struct {
+	Id        int             `json:"id"`
+	OrderId   int             `json:"order_id"`
+	Provider  string          `json:"provider"`
+	Reference string          `json:"reference"`
+	Status    string          `json:"status"`
+	Amount    Money           `json:"amount"`
+	Reason    string          `json:"reason,omitempty"`
+	Events    []*PaymentEvent `json:"events"`
+	CreatedAt time.Time       `json:"created_at"`
+	UpdatedAt time.Time       `json:"updated_at"`
+}
+
+// CanTransition check the payment may move to the status
+func (p *Payment) CanTransition(status string) bool {
+	for _, next := range paymentTransitions[p.Status] {
+		if next == status {
+			return true
+		}
+	}
+	return false
+}
+
+// Live tell whether the payment hold or took the money of the customer, an order has at most one live payment
+func (p *Payment) Live() bool {
+	switch p.Status {
+	case PaymentStatusAuthorized, PaymentStatusCapturing, PaymentStatusSettling, PaymentStatusCaptured, PaymentStatusRefunding:
+		return true
+	}
+	return false
+}
+
+// PaymentEvent is a status change of a payment, the first event of a payment has no From
+type PaymentEvent struct {
+	Id        int       `json:"id"`
+	PaymentId int       `json:"payment_id"`
+	From      string    `json:"from"`
+	To        string    `json:"to"`
+	Reason    string    `json:"reason,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// GatewayAuthorization ask the gateway to hold the amount on the payment method of the token
+type GatewayAuthorization struct {
+	OrderId int
+	Amount  Money
+	Token   string
+}
+
+// GatewayResult is the answer of the gateway, Reason explain a decline or a failure
+type GatewayResult struct {
+	Reference string
+	Status    string
+	Reason    string
+}
+
+// GatewayEvent is a status change of a payment notified by the gateway through a webhook
+type GatewayEvent struct {
+	Reference string `json:"reference"`
+	Status    string `json:"status"`
+	Reason    string `json:"reason"`
+}
+
+// PaymentGateway is a payment provider, a declined payment is a result and not an error
+type PaymentGateway interface {
+	// Name is the provider of the payments made through the gateway
+	Name() string
+	Authorize(ctx context.Context, authorization GatewayAuthorization) (*GatewayResult, error)
+	// Capture take the authorized amount, the result is settling when the gateway settle it later
+	Capture(ctx context.Context, reference string, amount Money) (*GatewayResult, error)
+	Refund(ctx context.Context, reference string, amount Money) (*GatewayResult, error)
+	// VerifyWebhook check the signature of the webhook payload, constant.ErrInvalidSignature when it does not match
+	VerifyWebhook(ctx context.Context, payload []byte, signature string) (*GatewayEvent, error)
+}
+
+type PaymentRepository interface {
+	// Save insert the payment with its first event, constant.ErrAlreadyExists when the order already has a live payment
+	Save(ctx context.Context, payment *Payment) error
+	// UpdateStatus move the payment from the status and record the event, constant.ErrVersionConflict when the
+	// payment is no longer in the from status
+	UpdateStatus(ctx context.Context, payment *Payment, from string, event *PaymentEvent) error
+	FindById(ctx context.Context, id int) (*Payment, error)
+	FindByReference(ctx context.Context, provider string, reference string) (*Payment, error)
+	// FindByOrderId return the payment attempts of the order, the latest first
+	FindByOrderId(ctx context.Context, orderId int) ([]*Payment, error)
+}
+
+type PaymentService interface {
+	Authorize(ctx context.Context, req AuthorizePaymentRequest, orderId int) (*Payment, error)
+	Capture(ctx context.Context, paymentId int) (*Payment, error)
+	Refund(ctx context.Context, paymentId int) (*Payment, error)
+	// HandleWebhook apply the status change notified by the gateway, a notification already applied is ignored
+	HandleWebhook(ctx context.Context, payload []byte, signature string) (*Payment, error)
+	FindById(ctx context.Context, paymentId int) (*Payment, error)
+	FindByOrderId(ctx context.Context, orderId int) ([]*Payment, error)
+}
+
+type PaymentController interface {
+	HandleAuthorize() echo.HandlerFunc
+	HandleCapture() echo.HandlerFunc
+	HandleRefund() echo.HandlerFunc
+	HandleWebhook() echo.HandlerFunc
+	HandleFindById() echo.HandlerFunc
+	HandleFindByOrderId() echo.HandlerFunc
+}
@@ -0,0 +1,50 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPayment_CanTransition(t *testing.T) {
+	cases := []struct {
+		from string
+		to   string
+		ok   bool
+	}{
+		{PaymentStatusAuthorized, PaymentStatusCapturing, true},
+		{PaymentStatusAuthorized, PaymentStatusCaptured, false},
+		{PaymentStatusAuthorized, PaymentStatusRefunded, false},
+		{PaymentStatusCapturing, PaymentStatusCaptured, true},
+		{PaymentStatusCapturing, PaymentStatusSettling, true},
+		{PaymentStatusCapturing, PaymentStatusAuthorized, true},
+		{PaymentStatusCapturing, PaymentStatusRefunded, false},
+		{PaymentStatusSettling, PaymentStatusCaptured, true},
+		{PaymentStatusSettling, PaymentStatusFailed, true},
+		{PaymentStatusSettling, PaymentStatusRefunded, false},
+		{PaymentStatusCaptured, PaymentStatusRefunding, true},
+		{PaymentStatusCaptured, PaymentStatusRefunded, false},
+		{PaymentStatusCaptured, PaymentStatusFailed, false},
+		{PaymentStatusRefunding, PaymentStatusRefunded, true},
+		{PaymentStatusRefunding, PaymentStatusCaptured, true},
+		{PaymentStatusDeclined, PaymentStatusAuthorized, false},
+		{PaymentStatusFailed, PaymentStatusCaptured, false},
+		{PaymentStatusRefunded, PaymentStatusCaptured, false},
+	}
+
+	for _, c := range cases {
+		payment := &Payment{Status: c.from}
+		assert.Equal(t, c.ok, payment.CanTransition(c.to), "%s -> %s", c.from, c.to)
+	}
+}
+
+func TestPayment_Live(t *testing.T) {
+	assert.True(t, (&Payment{Status: PaymentStatusAuthorized}).Live())
+	assert.True(t, (&Payment{Status: PaymentStatusSettling}).Live())
+	assert.True(t, (&Payment{Status: PaymentStatusCaptured}).Live())
+	assert.True(t, (&Payment{Status: PaymentStatusCapturing}).Live())
+	assert.True(t, (&Payment{Status: PaymentStatusRefunding}).Live())
+	assert.False(t, (&Payment{Status: PaymentStatusDeclined}).Live())
+	assert.False(t, (&Payment{Status: PaymentStatusFailed}).Live())
+	assert.False(t, (&Payment{Status: PaymentStatusRefunded}).Live())
+}
@@ -0,0 +1,149 @@
+package model
+
+import (
+	"context"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// SetCakeProductionRequest set how a cake is baked, BatchSize is the number of cakes baked together in one oven load
+type SetCakeProductionRequest struct {
+	PrepMinutes int `json:"prep_minutes" validate:"gte=0,lte=1440"`
+	BakeMinutes int `json:"bake_minutes" validate:"gte=1,lte=1440"`
+	BatchSize   int `json:"batch_size" validate:"gte=1,lte=1000"`
+}
+
+func (s *SetCakeProductionRequest) Validate() error {
+	return validate.Struct(s)
+}
+
+// CakeProduction is how a cake is baked, Default is true when the cake has no settings and the configured defaults
+// are used
+type CakeProduction struct {
+	CakeId      int       `json:"cake_id"`
+	PrepMinutes int       `json:"prep_minutes"`
+	BakeMinutes int       `json:"bake_minutes"`
+	BatchSize   int       `json:"batch_size"`
+	Default     bool      `json:"default"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// production plan format
+const (
+	ProductionFormatJSON string = "json"
+	ProductionFormatCSV  string = "csv"
+	ProductionFormatHTML string = "html"
+)
+
+// ProductionQuery plan the production of the date, today when empty, and output it in the format, json when empty
+type ProductionQuery struct {
+	Date   string `query:"date" validate:"omitempty,datetime=2006-01-02"`
+	Format string `query:"format" validate:"omitempty,oneof=json csv html"`
+}
+
+func (p *ProductionQuery) Validate() error {
+	return validate.Struct(p)
+}
+
+// ProductionLine is the quantity of a cake variant ordered for the date, ToBake is what the on hand stock does not cover
+type ProductionLine struct {
+	CakeId    int    `json:"cake_id"`
+	VariantId int    `json:"variant_id"`
+	Title     string `json:"title"`
+	Size      string `json:"size"`
+	Ordered   int    `json:"ordered"`
+	OnHand    int    `json:"on_hand"`
+	ToBake    int    `json:"to_bake"`
+	OrderIds  []int  `json:"order_ids"`
+}
+
+// SetToBake net the ordered quantity against the on hand stock
+func (p *ProductionLine) SetToBake() {
+	p.ToBake = p.Ordered - p.OnHand
+	if p.ToBake < 0 {
+		p.ToBake = 0
+	}
+}
+
+// ProductionBatch is an oven load, the cakes are prepared from PrepStartAt and baked from BakeStartAt until ReadyAt
+type ProductionBatch struct {
+	Position    int       `json:"position"`
+	CakeId      int       `json:"cake_id"`
+	VariantId   int       `json:"variant_id"`
+	Title       string    `json:"title"`
+	Size        string    `json:"size"`
+	Quantity    int       `json:"quantity"`
+	PrepStartAt time.Time `json:"prep_start_at"`
+	BakeStartAt time.Time `json:"bake_start_at"`
+	ReadyAt     time.Time `json:"ready_at"`
+}
+
+// ProductionPlan is the bake schedule of a date
+type ProductionPlan struct {
+	Date    string             `json:"date"`
+	Lines   []*ProductionLine  `json:"lines"`
+	Batches []*ProductionBatch `json:"batches"`
+	// FinishAt is when the last batch is ready, the start of the day when nothing is baked
+	FinishAt    time.Time `json:"finish_at"`
+	GeneratedAt time.Time `json:"generated_at"`
+}
+
+// Schedule split the lines to bake in batches baked one after the other in a single oven from start, the next batch
+// is prepared while the previous one bakes
+func (p *ProductionPlan) Schedule(start time.Time, productions map[int]*CakeProduction) {
+	p.Batches = make([]*ProductionBatch, 0)
+	ovenFreeAt := start
+	for _, line := range p.Lines {
+		production := productions[line.CakeId]
+		batchSize := production.BatchSize
+		if batchSize < 1 {
+			batchSize = 1
+		}
+		for remaining := line.ToBake; remaining > 0; remaining -= batchSize {
+			quantity := batchSize
+			if remaining < quantity {
+				quantity = remaining
+			}
+
+			prep := time.Duration(production.PrepMinutes) * time.Minute
+			prepStartAt := ovenFreeAt.Add(-prep)
+			if prepStartAt.Before(start) {
+				prepStartAt = start
+			}
+			bakeStartAt := prepStartAt.Add(prep)
+			readyAt := bakeStartAt.Add(time.Duration(production.BakeMinutes) * time.Minute)
+
+			p.Batches = append(p.Batches, &ProductionBatch{
+				Position:    len(p.Batches) + 1,
+				CakeId:      line.CakeId,
+				VariantId:   line.VariantId,
+				Title:       line.Title,
+				Size:        line.Size,
+				Quantity:    quantity,
+				PrepStartAt: prepStartAt,
+				BakeStartAt: bakeStartAt,
+				ReadyAt:     readyAt,
+			})
+			ovenFreeAt = readyAt
+		}
+	}
+	p.FinishAt = ovenFreeAt
+}
+
+type ProductionRepository interface {
+	Save(ctx context.Context, production *CakeProduction) error
+	FindByCakeIds(ctx context.Context, cakeIds []int) ([]*CakeProduction, error)
+}
+
+type ProductionService interface {
+	SetCakeProduction(ctx context.Context, req SetCakeProductionRequest, cakeId int) (*CakeProduction, error)
+	FindCakeProduction(ctx context.Context, cakeId int) (*CakeProduction, error)
+	Plan(ctx context.Context, query ProductionQuery) (*ProductionPlan, error)
+}
+
+type ProductionController interface {
+	HandleSetCakeProduction() echo.HandlerFunc
+	HandleFindCakeProduction() echo.HandlerFunc
+	HandlePlan() echo.HandlerFunc
+}
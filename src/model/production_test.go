@@ -0,0 +1,65 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProductionLine_SetToBake(t *testing.T) {
+	line := &ProductionLine{Ordered: 5, OnHand: 2}
+	line.SetToBake()
+	assert.Equal(t, 3, line.ToBake)
+
+	line = &ProductionLine{Ordered: 2, OnHand: 4}
+	line.SetToBake()
+	assert.Equal(t, 0, line.ToBake)
+}
+
+func TestProductionPlan_Schedule(t *testing.T) {
+	start := time.Date(2026, 10, 20, 6, 0, 0, 0, time.UTC)
+	productions := map[int]*CakeProduction{
+		1: {CakeId: 1, PrepMinutes: 30, BakeMinutes: 45, BatchSize: 2},
+		2: {CakeId: 2, PrepMinutes: 60, BakeMinutes: 30, BatchSize: 0},
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		plan := &ProductionPlan{Lines: []*ProductionLine{
+			{CakeId: 1, VariantId: 5, Title: "Black Forest", Size: "20cm", ToBake: 3},
+			{CakeId: 2, VariantId: 7, Title: "Cheesecake", Size: "16cm", ToBake: 1},
+			{CakeId: 2, VariantId: 8, Title: "Cheesecake", Size: "20cm", ToBake: 0},
+		}}
+		plan.Schedule(start, productions)
+
+		require.Len(t, plan.Batches, 3)
+		first, second, third := plan.Batches[0], plan.Batches[1], plan.Batches[2]
+
+		assert.Equal(t, 2, first.Quantity)
+		assert.Equal(t, start, first.PrepStartAt)
+		assert.Equal(t, start.Add(30*time.Minute), first.BakeStartAt)
+		assert.Equal(t, start.Add(75*time.Minute), first.ReadyAt)
+
+		// prepared while the first batch bakes
+		assert.Equal(t, 1, second.Quantity)
+		assert.Equal(t, start.Add(45*time.Minute), second.PrepStartAt)
+		assert.Equal(t, first.ReadyAt, second.BakeStartAt)
+
+		// the prep is longer than the previous bake
+		assert.Equal(t, 3, third.Position)
+		assert.Equal(t, 1, third.Quantity)
+		assert.Equal(t, start.Add(60*time.Minute), third.PrepStartAt)
+		assert.Equal(t, start.Add(120*time.Minute), third.BakeStartAt)
+		assert.Equal(t, start.Add(150*time.Minute), third.ReadyAt)
+		assert.Equal(t, third.ReadyAt, plan.FinishAt)
+	})
+
+	t.Run("nothing to bake", func(t *testing.T) {
+		plan := &ProductionPlan{Lines: []*ProductionLine{{CakeId: 1, ToBake: 0}}}
+		plan.Schedule(start, productions)
+
+		assert.Empty(t, plan.Batches)
+		assert.Equal(t, start, plan.FinishAt)
+	})
+}
@@ -0,0 +1,250 @@
+package model
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// units of the recipe and supplier quantities
+const (
+	UnitGram       = "g"
+	UnitKilogram   = "kg"
+	UnitMilliliter = "ml"
+	UnitLiter      = "l"
+	UnitCup        = "cup"
+	UnitTablespoon = "tbsp"
+	UnitTeaspoon   = "tsp"
+	UnitPiece      = "pc"
+)
+
+// dimensions of the units, quantities only convert within a dimension unless the density is known
+const (
+	dimensionMass   = "mass"
+	dimensionVolume = "volume"
+	dimensionCount  = "count"
+)
+
+type unitDefinition struct {
+	dimension string
+	// factor is the number of base units, gram, milliliter or piece, in one unit
+	factor float64
+}
+
+// units is every supported unit, the cup is the 240 ml US legal cup
+var units = map[string]unitDefinition{
+	UnitGram:       {dimensionMass, 1},
+	UnitKilogram:   {dimensionMass, 1000},
+	UnitMilliliter: {dimensionVolume, 1},
+	UnitLiter:      {dimensionVolume, 1000},
+	UnitCup:        {dimensionVolume, 240},
+	UnitTablespoon: {dimensionVolume, 15},
+	UnitTeaspoon:   {dimensionVolume, 5},
+	UnitPiece:      {dimensionCount, 1},
+}
+
+func IsUnit(unit string) bool {
+	_, ok := units[unit]
+	return ok
+}
+
+// ConvertQuantity convert the quantity between units, density is in g/ml and is required to convert between a mass
+// and a volume, ok is false when the units cannot be converted
+func ConvertQuantity(quantity float64, from string, to string, density float64) (converted float64, ok bool) {
+	fromUnit, ok := units[from]
+	if !ok {
+		return 0, false
+	}
+	toUnit, ok := units[to]
+	if !ok {
+		return 0, false
+	}
+
+	base := quantity * fromUnit.factor
+	switch {
+	case fromUnit.dimension == toUnit.dimension:
+	case density <= 0:
+		return 0, false
+	case fromUnit.dimension == dimensionVolume && toUnit.dimension == dimensionMass:
+		base *= density
+	case fromUnit.dimension == dimensionMass && toUnit.dimension == dimensionVolume:
+		base /= density
+	default:
+		return 0, false
+	}
+	return base / toUnit.factor, true
+}
+
+func round3(value float64) float64 {
+	return math.Round(value*1000) / 1000
+}
+
+type RecipeStepRequest struct {
+	Instruction string `json:"instruction" validate:"required,max=1000"`
+	Minutes     int    `json:"minutes" validate:"gte=0,lte=1440"`
+}
+
+type RecipeIngredientRequest struct {
+	IngredientId int     `json:"ingredient_id" validate:"gt=0"`
+	Quantity     float64 `json:"quantity" validate:"gt=0,lte=100000"`
+	Unit         string  `json:"unit" validate:"required,unit"`
+}
+
+// SaveRecipeRequest create or replace the recipe of a cake, Yield is the number of servings it makes
+type SaveRecipeRequest struct {
+	Yield       int                       `json:"yield" validate:"gte=1,lte=500"`
+	Steps       []RecipeStepRequest       `json:"steps" validate:"required,min=1,max=100,dive"`
+	Ingredients []RecipeIngredientRequest `json:"ingredients" validate:"required,min=1,max=100,dive"`
+}
+
+func (s *SaveRecipeRequest) Validate() error {
+	return validate.Struct(s)
+}
+
+// IngredientIds return the id of each ingredient once, an ingredient may be listed for several steps
+func (s *SaveRecipeRequest) IngredientIds() []int {
+	seen := make(map[int]bool, len(s.Ingredients))
+	ids := make([]int, 0, len(s.Ingredients))
+	for _, ingredient := range s.Ingredients {
+		if !seen[ingredient.IngredientId] {
+			seen[ingredient.IngredientId] = true
+			ids = append(ids, ingredient.IngredientId)
+		}
+	}
+	return ids
+}
+
+// RecipeQuery scale the recipe to the servings, zero keep the recipe yield, and price it in the currency
+type RecipeQuery struct {
+	Servings int    `query:"servings" validate:"gte=0,lte=10000"`
+	Currency string `query:"currency" validate:"omitempty,iso4217"`
+}
+
+func (r *RecipeQuery) Validate() error {
+	return validate.Struct(r)
+}
+
+type RecipeStep struct {
+	Position    int    `json:"position"`
+	Instruction string `json:"instruction"`
+	Minutes     int    `json:"minutes"`
+}
+
+// RecipeIngredient is a quantity of an ingredient, Cost is the cheapest supplier price of the quantity and is nil when
+// no supplier price convert to the unit
+type RecipeIngredient struct {
+	IngredientId int     `json:"ingredient_id"`
+	Name         string  `json:"name"`
+	Quantity     float64 `json:"quantity"`
+	Unit         string  `json:"unit"`
+	Density      float64 `json:"-"`
+	Cost         *Money  `json:"cost"`
+	Supplier     string  `json:"supplier,omitempty"`
+}
+
+// RecipeCost is the ingredient cost of the recipe, it is not Complete when an ingredient has no cost
+type RecipeCost struct {
+	Total      Money `json:"total"`
+	PerServing Money `json:"per_serving"`
+	Complete   bool  `json:"complete"`
+}
+
+type Recipe struct {
+	Id          int                 `json:"id"`
+	CakeId      int                 `json:"cake_id"`
+	Yield       int                 `json:"yield"`
+	Servings    int                 `json:"servings"`
+	Steps       []*RecipeStep       `json:"steps"`
+	Ingredients []*RecipeIngredient `json:"ingredients"`
+	Cost        *RecipeCost         `json:"cost,omitempty"`
+	CreatedAt   time.Time           `json:"created_at"`
+	UpdatedAt   time.Time           `json:"updated_at"`
+}
+
+// Scale change the ingredient quantities from the current servings, the recipe yield when not scaled yet, to the servings
+func (r *Recipe) Scale(servings int) {
+	current := r.Servings
+	if current == 0 {
+		current = r.Yield
+	}
+	factor := float64(servings) / float64(current)
+	for _, ingredient := range r.Ingredients {
+		ingredient.Quantity = round3(ingredient.Quantity * factor)
+	}
+	r.Servings = servings
+}
+
+// SetCost sum the ingredient costs in the currency, the costs must already be in the currency
+func (r *Recipe) SetCost(currency string) {
+	cost := &RecipeCost{
+		Total:    NewMoney(0, currency),
+		Complete: true,
+	}
+	for _, ingredient := range r.Ingredients {
+		if ingredient.Cost == nil {
+			cost.Complete = false
+			continue
+		}
+		cost.Total.Amount += ingredient.Cost.Amount
+	}
+
+	cost.PerServing = NewMoney(int64(math.Round(float64(cost.Total.Amount)/float64(r.Servings))), currency)
+	r.Cost = cost
+}
+
+type SupplierPriceRequest struct {
+	Supplier string  `json:"supplier" validate:"required,max=100"`
+	Price    Money   `json:"price"`
+	Quantity float64 `json:"quantity" validate:"gt=0,lte=100000"`
+	Unit     string  `json:"unit" validate:"required,unit"`
+}
+
+// SetSupplierPricesRequest replace the price list of an ingredient
+type SetSupplierPricesRequest struct {
+	Prices []SupplierPriceRequest `json:"prices" validate:"max=20,dive"`
+}
+
+func (s *SetSupplierPricesRequest) Validate() error {
+	return validate.Struct(s)
+}
+
+// SupplierPrice is the Price a supplier ask for the Quantity of an ingredient
+type SupplierPrice struct {
+	Id           int       `json:"id"`
+	IngredientId int       `json:"ingredient_id"`
+	Supplier     string    `json:"supplier"`
+	Price        Money     `json:"price"`
+	Quantity     float64   `json:"quantity"`
+	Unit         string    `json:"unit"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// Cost return the price of the quantity in the supplier currency, ok is false when the units cannot be converted
+func (s *SupplierPrice) Cost(quantity float64, unit string, density float64) (cost Money, ok bool) {
+	converted, ok := ConvertQuantity(quantity, unit, s.Unit, density)
+	if !ok {
+		return Money{}, false
+	}
+	return NewMoney(int64(math.Round(float64(s.Price.Amount)*converted/s.Quantity)), s.Price.Currency), true
+}
+
+type RecipeRepository interface {
+	Save(ctx context.Context, recipe *Recipe) error
+	Update(ctx context.Context, recipe *Recipe) error
+	Delete(ctx context.Context, recipe *Recipe) error
+	FindByCakeId(ctx context.Context, cakeId int) (*Recipe, error)
+}
+
+type RecipeService interface {
+	Save(ctx context.Context, req SaveRecipeRequest, cakeId int) (*Recipe, error)
+	Delete(ctx context.Context, cakeId int) (*Recipe, error)
+	FindByCakeId(ctx context.Context, query RecipeQuery, cakeId int) (*Recipe, error)
+}
+
+type RecipeController interface {
+	HandleSave() echo.HandlerFunc
+	HandleDelete() echo.HandlerFunc
+	HandleFindByCakeId() echo.HandlerFunc
+}
@@ -0,0 +1,88 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConvertQuantity(t *testing.T) {
+	cases := []struct {
+		name      string
+		quantity  float64
+		from      string
+		to        string
+		density   float64
+		converted float64
+		ok        bool
+	}{
+		{"same unit", 250, UnitGram, UnitGram, 0, 250, true},
+		{"gram to kilogram", 250, UnitGram, UnitKilogram, 0, 0.25, true},
+		{"cup to milliliter", 2, UnitCup, UnitMilliliter, 0, 480, true},
+		{"tablespoon to liter", 4, UnitTablespoon, UnitLiter, 0, 0.06, true},
+		{"cup to gram with density", 1, UnitCup, UnitGram, 0.5, 120, true},
+		{"kilogram to milliliter with density", 1.03, UnitKilogram, UnitMilliliter, 1.03, 1000, true},
+		{"cup to gram without density", 1, UnitCup, UnitGram, 0, 0, false},
+		{"piece to gram", 2, UnitPiece, UnitGram, 1, 0, false},
+		{"unknown unit", 1, "oz", UnitGram, 0, 0, false},
+	}
+
+	for _, c := range cases {
+		converted, ok := ConvertQuantity(c.quantity, c.from, c.to, c.density)
+		assert.Equal(t, c.ok, ok, c.name)
+		assert.InDelta(t, c.converted, converted, 1e-9, c.name)
+	}
+}
+
+func TestRecipe_Scale(t *testing.T) {
+	recipe := &Recipe{
+		Yield:    8,
+		Servings: 8,
+		Ingredients: []*RecipeIngredient{
+			{Name: "Flour", Quantity: 250, Unit: UnitGram},
+			{Name: "Egg", Quantity: 3, Unit: UnitPiece},
+			{Name: "Milk", Quantity: 1, Unit: UnitCup},
+		},
+	}
+
+	recipe.Scale(20)
+	assert.Equal(t, 20, recipe.Servings)
+	assert.Equal(t, 625.0, recipe.Ingredients[0].Quantity)
+	assert.Equal(t, 7.5, recipe.Ingredients[1].Quantity)
+	assert.Equal(t, 2.5, recipe.Ingredients[2].Quantity)
+
+	recipe.Scale(3)
+	assert.Equal(t, 0.375, recipe.Ingredients[2].Quantity)
+}
+
+func TestRecipe_SetCost(t *testing.T) {
+	flour := NewMoney(300000, "IDR")
+	egg := NewMoney(750000, "IDR")
+
+	recipe := &Recipe{
+		Servings:    8,
+		Ingredients: []*RecipeIngredient{{Cost: &flour}, {Cost: &egg}},
+	}
+	recipe.SetCost("IDR")
+	assert.Equal(t, &RecipeCost{Total: NewMoney(1050000, "IDR"), PerServing: NewMoney(131250, "IDR"), Complete: true}, recipe.Cost)
+
+	recipe.Ingredients = append(recipe.Ingredients, &RecipeIngredient{})
+	recipe.SetCost("IDR")
+	assert.False(t, recipe.Cost.Complete)
+	assert.Equal(t, NewMoney(1050000, "IDR"), recipe.Cost.Total)
+}
+
+func TestSupplierPrice_Cost(t *testing.T) {
+	price := &SupplierPrice{Price: NewMoney(1500000, "IDR"), Quantity: 1, Unit: UnitKilogram}
+
+	cost, ok := price.Cost(250, UnitGram, 0)
+	assert.True(t, ok)
+	assert.Equal(t, NewMoney(375000, "IDR"), cost)
+
+	cost, ok = price.Cost(2, UnitCup, 0.5)
+	assert.True(t, ok)
+	assert.Equal(t, NewMoney(360000, "IDR"), cost)
+
+	_, ok = price.Cost(2, UnitCup, 0)
+	assert.False(t, ok)
+}
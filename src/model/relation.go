@@ -0,0 +1,38 @@
+package model
+
+import "context"
+
+// CakeRelationLoader embed a relation into the listed cakes
+type CakeRelationLoader interface {
+	LoadCakes(ctx context.Context, cakes []*Cake) error
+}
+
+type SetCakeRelationRequest struct {
+	Ids []int `json:"ids" validate:"max=50,dive,gt=0"`
+}
+
+func (s *SetCakeRelationRequest) Validate() error {
+	return validate.Struct(s)
+}
+
+// CakeIds return the id of each cake
+func CakeIds(cakes []*Cake) []int {
+	ids := make([]int, 0, len(cakes))
+	for _, cake := range cakes {
+		ids = append(ids, cake.Id)
+	}
+	return ids
+}
+
+// UniqueIds return the requested ids without duplicates, keeping their order
+func (s *SetCakeRelationRequest) UniqueIds() []int {
+	seen := make(map[int]bool, len(s.Ids))
+	ids := make([]int, 0, len(s.Ids))
+	for _, id := range s.Ids {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
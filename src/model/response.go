@@ -3,4 +3,5 @@ package model
 type ResponseSuccess struct {
 	Success bool        `json:"success"`
 	Data    interface{} `json:"data"`
+	Meta    *Pagination `json:"meta,omitempty"`
 }
@@ -0,0 +1,23 @@
+package model
+
+import (
+	"context"
+	"time"
+)
+
+type PurgeOption struct {
+	Retention  time.Duration
+	BatchSize  int
+	MaxBatches int
+	DryRun     bool
+	// AfterBatch is run after each batch when set, an error stop the purge
+	AfterBatch func(ctx context.Context) error
+}
+
+type PurgeSummary struct {
+	DeletedBefore time.Time `json:"deleted_before"`
+	Matched       int64     `json:"matched"`
+	Purged        int64     `json:"purged"`
+	Batches       int       `json:"batches"`
+	DryRun        bool      `json:"dry_run"`
+}
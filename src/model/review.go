@@ -0,0 +1,166 @@
+package model
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	ReviewStatusPending  = "pending"
+	ReviewStatusApproved = "approved"
+	ReviewStatusRejected = "rejected"
+	ReviewStatusFlagged  = "flagged"
+)
+
+// screening reasons, a review keep the reasons of every screener that stopped it
+const (
+	ScreeningReasonBannedWord = "banned_word"
+	ScreeningReasonLink       = "link"
+	ScreeningReasonDuplicate  = "duplicate"
+	ScreeningReasonAuthorRate = "author_rate_limit"
+)
+
+type CreateUpdateReviewRequest struct {
+	Score  int    `json:"score" validate:"gte=1,lte=10"`
+	Text   string `json:"text" validate:"max=2000"`
+	Author string `json:"author" validate:"required,min=2,max=60"`
+}
+
+func (c *CreateUpdateReviewRequest) Validate() error {
+	return validate.Struct(c)
+}
+
+// ModerateReviewRequest is the decision of a moderator, a review cannot be sent back to pending
+type ModerateReviewRequest struct {
+	Status string `json:"status" validate:"required,oneof=approved rejected flagged"`
+	Note   string `json:"note" validate:"max=500"`
+}
+
+func (m *ModerateReviewRequest) Validate() error {
+	return validate.Struct(m)
+}
+
+type ReviewQuery struct {
+	Page   int    `query:"page" validate:"omitempty,min=1"`
+	Limit  int    `query:"limit" validate:"omitempty,min=1,max=100"`
+	Status string `query:"status" validate:"omitempty,oneof=pending approved rejected flagged"`
+	CakeId int    `query:"cake_id" validate:"omitempty,min=1"`
+}
+
+func (r *ReviewQuery) Validate() error {
+	return validate.Struct(r)
+}
+
+// SetDefault fill the empty page and limit
+func (r *ReviewQuery) SetDefault() {
+	if r.Page == 0 {
+		r.Page = DefaultPage
+	}
+	if r.Limit == 0 {
+		r.Limit = DefaultLimit
+	}
+}
+
+// Review is a customer score of a cake, on the same 1 to 10 scale as the cake rating, only approved reviews
+// are public and count toward the rating
+type Review struct {
+	Id             int        `json:"id"`
+	CakeId         int        `json:"cake_id"`
+	Score          int        `json:"score"`
+	Text           string     `json:"text"`
+	Author         string     `json:"author"`
+	Status         string     `json:"status"`
+	Screening      []string   `json:"screening,omitempty"`
+	ModerationNote string     `json:"moderation_note,omitempty"`
+	ModeratedAt    *time.Time `json:"moderated_at"`
+	Fingerprint    string     `json:"-"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+// ApplyVerdict record the reason of the verdict, a rejection outrank a flag and both outrank pending
+func (r *Review) ApplyVerdict(verdict *ScreeningVerdict) {
+	r.Screening = append(r.Screening, verdict.Reason)
+	if verdict.Status == ReviewStatusRejected || r.Status == ReviewStatusPending {
+		r.Status = verdict.Status
+	}
+}
+
+// ReviewFingerprint hash the text ignoring case and spacing, an empty text has no fingerprint
+func ReviewFingerprint(text string) string {
+	normalized := strings.Join(strings.Fields(strings.ToLower(text)), " ")
+	if normalized == "" {
+		return ""
+	}
+
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
+
+// ScreeningVerdict stop a review, Status is either flagged for a moderator or rejected
+type ScreeningVerdict struct {
+	Status string
+	Reason string
+}
+
+// ReviewScreener check a new review before it is saved, a nil verdict let the review through
+type ReviewScreener interface {
+	Screen(ctx context.Context, review *Review) (*ScreeningVerdict, error)
+}
+
+// CakeRating is the review aggregate of a cake
+type CakeRating struct {
+	// Rating is the mean blended with priorWeight virtual reviews scored priorMean, it is the plain mean when priorWeight is zero
+	Rating float32
+	Mean   float32
+	Count  int
+}
+
+// NewCakeRating aggregate count reviews scoring sum in total, a cake without reviews is rated at the prior mean
+// when weighted, and zero otherwise
+func NewCakeRating(count int, sum int, priorWeight int, priorMean float64) CakeRating {
+	rating := CakeRating{Count: count}
+	if count > 0 {
+		rating.Mean = float32(float64(sum) / float64(count))
+	}
+	if count+priorWeight > 0 {
+		rating.Rating = float32((float64(priorWeight)*priorMean + float64(sum)) / float64(priorWeight+count))
+	}
+	return rating
+}
+
+type ReviewRepository interface {
+	Save(ctx context.Context, review *Review) error
+	Update(ctx context.Context, review *Review) error
+	Delete(ctx context.Context, review *Review) error
+	FindById(ctx context.Context, id int) (*Review, error)
+	FindAll(ctx context.Context, query ReviewQuery) ([]*Review, error)
+	CountAll(ctx context.Context, query ReviewQuery) (int64, error)
+	ExistsFingerprint(ctx context.Context, cakeId int, fingerprint string) (bool, error)
+	CountByAuthorSince(ctx context.Context, author string, since time.Time) (int64, error)
+}
+
+type ReviewService interface {
+	Create(ctx context.Context, req CreateUpdateReviewRequest, cakeId int) (*Review, error)
+	Update(ctx context.Context, req CreateUpdateReviewRequest, cakeId int, reviewId int) (*Review, error)
+	Delete(ctx context.Context, cakeId int, reviewId int) (*Review, error)
+	FindById(ctx context.Context, cakeId int, reviewId int) (*Review, error)
+	FindAll(ctx context.Context, query ReviewQuery, cakeId int) ([]*Review, *Pagination, error)
+	Moderate(ctx context.Context, req ModerateReviewRequest, reviewId int) (*Review, error)
+	FindModeration(ctx context.Context, query ReviewQuery) ([]*Review, *Pagination, error)
+}
+
+type ReviewController interface {
+	HandleCreate() echo.HandlerFunc
+	HandleUpdate() echo.HandlerFunc
+	HandleDelete() echo.HandlerFunc
+	HandleFindById() echo.HandlerFunc
+	HandleFindAll() echo.HandlerFunc
+	HandleModerate() echo.HandlerFunc
+	HandleFindModeration() echo.HandlerFunc
+}
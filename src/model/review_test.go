@@ -0,0 +1,48 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewCakeRating(t *testing.T) {
+	cases := []struct {
+		name        string
+		count       int
+		sum         int
+		priorWeight int
+		priorMean   float64
+		rating      CakeRating
+	}{
+		{"no reviews", 0, 0, 0, 5.5, CakeRating{}},
+		{"no reviews - weighted", 0, 0, 5, 5.5, CakeRating{Rating: 5.5}},
+		{"plain mean", 4, 34, 0, 5.5, CakeRating{Rating: 8.5, Mean: 8.5, Count: 4}},
+		{"single review - weighted", 1, 10, 4, 5, CakeRating{Rating: 6, Mean: 10, Count: 1}},
+		{"many reviews - weighted", 96, 864, 4, 5, CakeRating{Rating: 8.84, Mean: 9, Count: 96}},
+	}
+
+	for _, c := range cases {
+		assert.Equal(t, c.rating, NewCakeRating(c.count, c.sum, c.priorWeight, c.priorMean), c.name)
+	}
+}
+
+func TestReview_ApplyVerdict(t *testing.T) {
+	review := &Review{Status: ReviewStatusPending}
+
+	review.ApplyVerdict(&ScreeningVerdict{Status: ReviewStatusFlagged, Reason: ScreeningReasonLink})
+	assert.Equal(t, ReviewStatusFlagged, review.Status)
+
+	review.ApplyVerdict(&ScreeningVerdict{Status: ReviewStatusRejected, Reason: ScreeningReasonDuplicate})
+	assert.Equal(t, ReviewStatusRejected, review.Status)
+
+	review.ApplyVerdict(&ScreeningVerdict{Status: ReviewStatusFlagged, Reason: ScreeningReasonLink})
+	assert.Equal(t, ReviewStatusRejected, review.Status)
+	assert.Equal(t, []string{ScreeningReasonLink, ScreeningReasonDuplicate, ScreeningReasonLink}, review.Screening)
+}
+
+func TestReviewFingerprint(t *testing.T) {
+	assert.Equal(t, ReviewFingerprint("Enak sekali"), ReviewFingerprint("  enak\tSEKALI "))
+	assert.NotEqual(t, ReviewFingerprint("Enak sekali"), ReviewFingerprint("Enak sekali!"))
+	assert.Empty(t, ReviewFingerprint("   "))
+}
@@ -0,0 +1,177 @@
+package model
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// SlotLayout is the layout of the start of a time slot, it is also the key of the slot bookings
+const SlotLayout = "2006-01-02T15:04"
+
+// ClockLayout is the layout of a time of day, e.g. the opening hours
+const ClockLayout = "15:04"
+
+// OpeningHoursRequest open the store on the weekday, 0 is sunday, SlotCapacity is the number of cakes the kitchen
+// finish in a slot
+type OpeningHoursRequest struct {
+	Weekday      int    `json:"weekday" validate:"gte=0,lte=6"`
+	OpensAt      string `json:"opens_at" validate:"required,datetime=15:04"`
+	ClosesAt     string `json:"closes_at" validate:"required,datetime=15:04"`
+	SlotCapacity int    `json:"slot_capacity" validate:"gte=1,lte=1000"`
+}
+
+// SetOpeningHoursRequest replace the weekly opening hours, the store is closed on the weekdays not listed
+type SetOpeningHoursRequest struct {
+	Days []OpeningHoursRequest `json:"days" validate:"max=7,dive"`
+}
+
+func (s *SetOpeningHoursRequest) Validate() error {
+	return validate.Struct(s)
+}
+
+// Inconsistency return why the opening hours do not fit together, empty when they do
+func (s *SetOpeningHoursRequest) Inconsistency() string {
+	seen := make(map[int]bool, len(s.Days))
+	for _, day := range s.Days {
+		if seen[day.Weekday] {
+			return fmt.Sprintf("%s is listed more than once", time.Weekday(day.Weekday))
+		}
+		seen[day.Weekday] = true
+
+		// the layout is fixed width, the times compare as strings
+		if day.ClosesAt <= day.OpensAt {
+			return fmt.Sprintf("%s closes before it opens", time.Weekday(day.Weekday))
+		}
+	}
+	return ""
+}
+
+type OpeningHours struct {
+	StoreId      int       `json:"store_id"`
+	Weekday      int       `json:"weekday"`
+	OpensAt      string    `json:"opens_at"`
+	ClosesAt     string    `json:"closes_at"`
+	SlotCapacity int       `json:"slot_capacity"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// Slots return the start of the slots of the length on the date, the last slot end at the closing time at the latest
+func (o *OpeningHours) Slots(date time.Time, length time.Duration) []time.Time {
+	slots := make([]time.Time, 0)
+	opens, err := time.Parse(ClockLayout, o.OpensAt)
+	if err != nil || length <= 0 {
+		return slots
+	}
+	closes, err := time.Parse(ClockLayout, o.ClosesAt)
+	if err != nil {
+		return slots
+	}
+
+	opensAt := time.Date(date.Year(), date.Month(), date.Day(), opens.Hour(), opens.Minute(), 0, 0, date.Location())
+	closesAt := time.Date(date.Year(), date.Month(), date.Day(), closes.Hour(), closes.Minute(), 0, 0, date.Location())
+	for start := opensAt; !start.Add(length).After(closesAt); start = start.Add(length) {
+		slots = append(slots, start)
+	}
+	return slots
+}
+
+// CreateClosureRequest close the store on the date, e.g. a holiday
+type CreateClosureRequest struct {
+	Date   string `json:"date" validate:"required,datetime=2006-01-02"`
+	Reason string `json:"reason" validate:"max=255"`
+}
+
+func (c *CreateClosureRequest) Validate() error {
+	return validate.Struct(c)
+}
+
+type Closure struct {
+	StoreId   int       `json:"store_id"`
+	Date      string    `json:"date"`
+	Reason    string    `json:"reason"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// SlotQuery find the slots of the store on the date, the default store and today when empty
+type SlotQuery struct {
+	StoreId int    `query:"store_id" validate:"omitempty,min=1"`
+	Date    string `query:"date" validate:"omitempty,datetime=2006-01-02"`
+}
+
+func (s *SlotQuery) Validate() error {
+	return validate.Struct(s)
+}
+
+// Slot is a pickup or delivery time slot, Booked is the number of cakes of the orders in the slot and Available
+// what is left of the capacity, nothing is available in a slot already started
+type Slot struct {
+	StartAt   time.Time `json:"start_at"`
+	EndAt     time.Time `json:"end_at"`
+	Capacity  int       `json:"capacity"`
+	Booked    int       `json:"booked"`
+	Available int       `json:"available"`
+}
+
+// SetAvailable fill what is left of the capacity at now
+func (s *Slot) SetAvailable(now time.Time) {
+	s.Available = s.Capacity - s.Booked
+	if s.Available < 0 || !now.Before(s.StartAt) {
+		s.Available = 0
+	}
+}
+
+// DaySlots is the availability of a date, a closed date has no slot
+type DaySlots struct {
+	StoreId int     `json:"store_id"`
+	Date    string  `json:"date"`
+	Closed  bool    `json:"closed"`
+	Reason  string  `json:"reason,omitempty"`
+	Slots   []*Slot `json:"slots"`
+}
+
+type SlotRepository interface {
+	FindOpeningHours(ctx context.Context, storeId int) ([]*OpeningHours, error)
+	SaveOpeningHours(ctx context.Context, storeId int, hours []*OpeningHours) error
+	FindClosure(ctx context.Context, storeId int, date time.Time) (*Closure, error)
+	FindClosures(ctx context.Context, storeId int, from time.Time) ([]*Closure, error)
+	SaveClosure(ctx context.Context, closure *Closure) error
+	DeleteClosure(ctx context.Context, closure *Closure) error
+	// CountBooked count the cakes of the orders of the store not cancelled in the slots starting in [from, to), by
+	// slot key
+	CountBooked(ctx context.Context, storeId int, from time.Time, to time.Time) (map[string]int, error)
+	// Booked return the booking counters of the slots of the store, by slot key, a slot without counter is missing
+	Booked(ctx context.Context, storeId int, slots []time.Time) (map[string]int, error)
+	// Book add the units to the counter of the slot, seeding it with seed when it has none, and return
+	// ErrSlotFull without booking anything when the capacity is exceeded
+	Book(ctx context.Context, storeId int, slot time.Time, units int, capacity int, seed int, ttl time.Duration) error
+	Release(ctx context.Context, storeId int, slot time.Time, units int) error
+	// Reconcile set the counter of the slot to the booked units, a counter changed within the reconcile grace is only
+	// raised, reconciled is false when the counter was left as is
+	Reconcile(ctx context.Context, storeId int, slot time.Time, booked int, ttl time.Duration) (reconciled bool, err error)
+}
+
+type SlotService interface {
+	FindOpeningHours(ctx context.Context, storeId int) ([]*OpeningHours, error)
+	SetOpeningHours(ctx context.Context, req SetOpeningHoursRequest, storeId int) ([]*OpeningHours, error)
+	FindClosures(ctx context.Context, storeId int) ([]*Closure, error)
+	CreateClosure(ctx context.Context, req CreateClosureRequest, storeId int) (*Closure, error)
+	DeleteClosure(ctx context.Context, storeId int, date string) (*Closure, error)
+	Availability(ctx context.Context, query SlotQuery) (*DaySlots, error)
+	Book(ctx context.Context, storeId int, slot time.Time, units int) error
+	Release(ctx context.Context, storeId int, slot time.Time, units int) error
+	// Reconcile reset the booking counters of the slots of the store on the date to the orders, it return the number
+	// of slots reconciled
+	Reconcile(ctx context.Context, storeId int, date time.Time) (int, error)
+}
+
+type SlotController interface {
+	HandleAvailability() echo.HandlerFunc
+	HandleFindOpeningHours() echo.HandlerFunc
+	HandleSetOpeningHours() echo.HandlerFunc
+	HandleFindClosures() echo.HandlerFunc
+	HandleCreateClosure() echo.HandlerFunc
+	HandleDeleteClosure() echo.HandlerFunc
+}
@@ -0,0 +1,60 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetOpeningHoursRequest_Inconsistency(t *testing.T) {
+	cases := []struct {
+		name   string
+		days   []OpeningHoursRequest
+		reason string
+	}{
+		{"ok", []OpeningHoursRequest{{Weekday: 1, OpensAt: "08:00", ClosesAt: "17:00"}, {Weekday: 6, OpensAt: "09:00", ClosesAt: "13:00"}}, ""},
+		{"duplicate weekday", []OpeningHoursRequest{{Weekday: 1, OpensAt: "08:00", ClosesAt: "17:00"}, {Weekday: 1, OpensAt: "09:00", ClosesAt: "13:00"}},
+			"Monday is listed more than once"},
+		{"closes before it opens", []OpeningHoursRequest{{Weekday: 0, OpensAt: "17:00", ClosesAt: "08:00"}}, "Sunday closes before it opens"},
+		{"closes when it opens", []OpeningHoursRequest{{Weekday: 0, OpensAt: "08:00", ClosesAt: "08:00"}}, "Sunday closes before it opens"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			req := &SetOpeningHoursRequest{Days: c.days}
+			assert.Equal(t, c.reason, req.Inconsistency())
+		})
+	}
+}
+
+func TestOpeningHours_Slots(t *testing.T) {
+	date := time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC)
+	hours := &OpeningHours{OpensAt: "08:00", ClosesAt: "11:30"}
+
+	slots := hours.Slots(date, time.Hour)
+	require.Len(t, slots, 3)
+	assert.Equal(t, time.Date(2026, 10, 20, 8, 0, 0, 0, time.UTC), slots[0])
+	assert.Equal(t, time.Date(2026, 10, 20, 10, 0, 0, 0, time.UTC), slots[2])
+
+	assert.Len(t, hours.Slots(date, 30*time.Minute), 7)
+	assert.Empty(t, hours.Slots(date, 0))
+	assert.Empty(t, (&OpeningHours{OpensAt: "8am", ClosesAt: "11:30"}).Slots(date, time.Hour))
+}
+
+func TestSlot_SetAvailable(t *testing.T) {
+	start := time.Date(2026, 10, 20, 9, 0, 0, 0, time.UTC)
+
+	slot := &Slot{StartAt: start, Capacity: 10, Booked: 4}
+	slot.SetAvailable(start.Add(-time.Hour))
+	assert.Equal(t, 6, slot.Available)
+
+	slot = &Slot{StartAt: start, Capacity: 10, Booked: 12}
+	slot.SetAvailable(start.Add(-time.Hour))
+	assert.Equal(t, 0, slot.Available)
+
+	slot = &Slot{StartAt: start, Capacity: 10, Booked: 4}
+	slot.SetAvailable(start)
+	assert.Equal(t, 0, slot.Available)
+}
@@ -0,0 +1,125 @@
+package model
+
+import (
+	"context"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// stock adjustment reason
+const (
+	StockReasonRestock    string = "restock"
+	StockReasonSale       string = "sale"
+	StockReasonWaste      string = "waste"
+	StockReasonCorrection string = "correction"
+	StockReasonReturn     string = "return"
+)
+
+type AdjustStockRequest struct {
+	VariantId int    `json:"variant_id" validate:"gte=0"`
+	Delta     int    `json:"delta" validate:"required,min=-100000,max=100000"`
+	Reason    string `json:"reason" validate:"required,oneof=restock sale waste correction return"`
+	Note      string `json:"note" validate:"max=255"`
+}
+
+func (a *AdjustStockRequest) Validate() error {
+	return validate.Struct(a)
+}
+
+type SetStockThresholdRequest struct {
+	VariantId int `json:"variant_id" validate:"gte=0"`
+	Threshold int `json:"threshold" validate:"gte=0,lte=100000"`
+}
+
+func (s *SetStockThresholdRequest) Validate() error {
+	return validate.Struct(s)
+}
+
+type ReserveStockRequest struct {
+	VariantId int `json:"variant_id" validate:"gte=0"`
+	Quantity  int `json:"quantity" validate:"gte=1,lte=1000"`
+	// TTLSeconds is how long the reservation is held, the configured default is used when empty
+	TTLSeconds int `json:"ttl_seconds" validate:"omitempty,min=30,max=86400"`
+}
+
+func (r *ReserveStockRequest) Validate() error {
+	return validate.Struct(r)
+}
+
+// Stock is the on hand count of a cake, or of one of its variants when VariantId is not zero
+type Stock struct {
+	Id                int       `json:"id"`
+	CakeId            int       `json:"cake_id"`
+	VariantId         int       `json:"variant_id"`
+	OnHand            int       `json:"on_hand"`
+	Reserved          int       `json:"reserved"`
+	Available         int       `json:"available"`
+	LowStockThreshold int       `json:"low_stock_threshold"`
+	LowStock          bool      `json:"low_stock"`
+	UpdatedAt         time.Time `json:"updated_at"`
+}
+
+// SetReserved fill the reserved count and the derived availability of the stock
+func (s *Stock) SetReserved(reserved int) {
+	s.Reserved = reserved
+	s.Available = s.OnHand - reserved
+	if s.Available < 0 {
+		s.Available = 0
+	}
+	s.LowStock = s.Available <= s.LowStockThreshold
+}
+
+type StockAdjustment struct {
+	Id        int       `json:"id"`
+	CakeId    int       `json:"cake_id"`
+	VariantId int       `json:"variant_id"`
+	Delta     int       `json:"delta"`
+	Reason    string    `json:"reason"`
+	Note      string    `json:"note"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Reservation hold a quantity of stock until it is committed, released or expired
+type Reservation struct {
+	Id        string    `json:"id"`
+	CakeId    int       `json:"cake_id"`
+	VariantId int       `json:"variant_id"`
+	Quantity  int       `json:"quantity"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+type StockRepository interface {
+	FindByItem(ctx context.Context, cakeId int, variantId int) (*Stock, error)
+	FindByCakeId(ctx context.Context, cakeId int) ([]*Stock, error)
+	FindLow(ctx context.Context) ([]*Stock, error)
+	Adjust(ctx context.Context, adjustment *StockAdjustment) (*Stock, error)
+	SetThreshold(ctx context.Context, cakeId int, variantId int, threshold int) error
+	FindAdjustments(ctx context.Context, cakeId int, limit int) ([]*StockAdjustment, error)
+	Reserve(ctx context.Context, reservation *Reservation, onHand int) error
+	Reserved(ctx context.Context, cakeId int, variantId int) (int, error)
+	FindReservation(ctx context.Context, id string) (*Reservation, error)
+	Release(ctx context.Context, reservation *Reservation) error
+}
+
+type StockService interface {
+	FindByCakeId(ctx context.Context, cakeId int) ([]*Stock, error)
+	FindLow(ctx context.Context) ([]*Stock, error)
+	Adjust(ctx context.Context, req AdjustStockRequest, cakeId int) (*Stock, error)
+	SetThreshold(ctx context.Context, req SetStockThresholdRequest, cakeId int) (*Stock, error)
+	FindAdjustments(ctx context.Context, cakeId int) ([]*StockAdjustment, error)
+	Reserve(ctx context.Context, req ReserveStockRequest, cakeId int) (*Reservation, error)
+	Release(ctx context.Context, reservationId string) (*Reservation, error)
+	Commit(ctx context.Context, reservationId string) (*Reservation, error)
+}
+
+type StockController interface {
+	HandleFindByCakeId() echo.HandlerFunc
+	HandleFindLow() echo.HandlerFunc
+	HandleAdjust() echo.HandlerFunc
+	HandleSetThreshold() echo.HandlerFunc
+	HandleFindAdjustments() echo.HandlerFunc
+	HandleReserve() echo.HandlerFunc
+	HandleRelease() echo.HandlerFunc
+	HandleCommit() echo.HandlerFunc
+}
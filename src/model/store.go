@@ -0,0 +1,200 @@
+package model
+
+import (
+	"cake-store/src/helper"
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+type CreateUpdateStoreRequest struct {
+	Code    string `json:"code" validate:"required,max=40,slug"`
+	Name    string `json:"name" validate:"required,min=2,max=100"`
+	Address string `json:"address" validate:"max=255"`
+	Phone   string `json:"phone" validate:"max=30"`
+	// Latitude and Longitude locate the store in decimal degrees, both or none are given. The latitude stop at the
+	// limit of the redis geo index, helper.MaxGeoLatitude
+	Latitude  *float64 `json:"latitude" validate:"required_with=Longitude,omitempty,gte=-85.05112878,lte=85.05112878"`
+	Longitude *float64 `json:"longitude" validate:"required_with=Latitude,omitempty,gte=-180,lte=180"`
+	Active    *bool    `json:"active"`
+}
+
+func (c *CreateUpdateStoreRequest) Validate() error {
+	return validate.Struct(c)
+}
+
+// Store is a bakery branch, an inactive store take no order and is hidden from the customers
+type Store struct {
+	Id        int       `json:"id"`
+	Code      string    `json:"code"`
+	Name      string    `json:"name"`
+	Address   string    `json:"address"`
+	Phone     string    `json:"phone"`
+	Latitude  *float64  `json:"latitude"`
+	Longitude *float64  `json:"longitude"`
+	Active    bool      `json:"active"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Located tell whether the store has a location, a store without one is never the nearest store
+func (s *Store) Located() bool {
+	return s.Latitude != nil && s.Longitude != nil
+}
+
+// DistanceKm return the haversine distance in kilometers from the store to the point, the store must be located
+func (s *Store) DistanceKm(lat, lng float64) float64 {
+	return helper.HaversineKm(*s.Latitude, *s.Longitude, lat, lng)
+}
+
+// default and max number of nearest stores
+const (
+	DefaultNearestLimit int = 1
+	MaxNearestLimit     int = 20
+)
+
+// NearestStoreQuery find the active stores nearest to the point, nearest first
+type NearestStoreQuery struct {
+	Latitude  *float64 `query:"lat" validate:"required,gte=-85.05112878,lte=85.05112878"`
+	Longitude *float64 `query:"lng" validate:"required,gte=-180,lte=180"`
+	Limit     int      `query:"limit" validate:"omitempty,min=1,max=20"`
+}
+
+func (n *NearestStoreQuery) Validate() error {
+	return validate.Struct(n)
+}
+
+// SetDefault fill the empty limit
+func (n *NearestStoreQuery) SetDefault() {
+	if n.Limit == 0 {
+		n.Limit = DefaultNearestLimit
+	}
+}
+
+// NearbyStore is a store with its distance to the customer
+type NearbyStore struct {
+	*Store
+	DistanceKm float64 `json:"distance_km"`
+}
+
+// RoundKm round the distance to the meter for display
+func RoundKm(km float64) float64 {
+	return math.Round(km*1000) / 1000
+}
+
+// DeliveryQuoteRequest price the delivery of an order to the point, from the store or the nearest store when empty
+type DeliveryQuoteRequest struct {
+	StoreId   int      `json:"store_id" validate:"omitempty,min=1"`
+	Latitude  *float64 `json:"latitude" validate:"required,gte=-85.05112878,lte=85.05112878"`
+	Longitude *float64 `json:"longitude" validate:"required,gte=-180,lte=180"`
+	Subtotal  Money    `json:"subtotal"`
+}
+
+func (d *DeliveryQuoteRequest) Validate() error {
+	return validate.Struct(d)
+}
+
+// DeliveryQuote is the fee of the delivery from the store, in the base currency
+type DeliveryQuote struct {
+	Store      *Store  `json:"store"`
+	DistanceKm float64 `json:"distance_km"`
+	Fee        Money   `json:"fee"`
+}
+
+// DeliveryFeePolicy price the delivery of an order over the distance, the subtotal of the order is in the base currency
+type DeliveryFeePolicy interface {
+	// Fee return the fee in the base currency, constant.ErrOutOfDeliveryRange when the policy does not deliver that far
+	Fee(ctx context.Context, distanceKm float64, subtotal Money) (Money, error)
+}
+
+type StorePriceRequest struct {
+	VariantId int   `json:"variant_id" validate:"gt=0"`
+	Price     Money `json:"price"`
+}
+
+// SetStoreCakeRequest replace the availability and the price overrides of a cake in a store, the variants not
+// listed are sold at their own price
+type SetStoreCakeRequest struct {
+	Available *bool               `json:"available"`
+	Prices    []StorePriceRequest `json:"prices" validate:"max=50,dive"`
+}
+
+func (s *SetStoreCakeRequest) Validate() error {
+	return validate.Struct(s)
+}
+
+// Inconsistency return why the price overrides do not fit together, empty when they do
+func (s *SetStoreCakeRequest) Inconsistency() string {
+	seen := make(map[int]bool, len(s.Prices))
+	for _, price := range s.Prices {
+		if seen[price.VariantId] {
+			return fmt.Sprintf("variant %d is priced more than once", price.VariantId)
+		}
+		seen[price.VariantId] = true
+	}
+	return ""
+}
+
+// StoreCake is the availability of a cake in a store and the prices of its variants overridden by the store
+type StoreCake struct {
+	StoreId   int           `json:"store_id"`
+	CakeId    int           `json:"cake_id"`
+	Available bool          `json:"available"`
+	Prices    []*StorePrice `json:"prices"`
+	UpdatedAt time.Time     `json:"updated_at"`
+}
+
+// Price return the price of the variant in the store, ok is false when the store sell it at the variant price
+func (s *StoreCake) Price(variantId int) (price Money, ok bool) {
+	for _, storePrice := range s.Prices {
+		if storePrice.VariantId == variantId {
+			return storePrice.Price, true
+		}
+	}
+	return Money{}, false
+}
+
+type StorePrice struct {
+	VariantId int   `json:"variant_id"`
+	Price     Money `json:"price"`
+}
+
+type StoreRepository interface {
+	Save(ctx context.Context, store *Store) error
+	Update(ctx context.Context, store *Store) error
+	FindById(ctx context.Context, id int) (*Store, error)
+	FindAll(ctx context.Context) ([]*Store, error)
+	// SaveCake replace the availability and the price overrides of the cake in the store
+	SaveCake(ctx context.Context, storeCake *StoreCake) error
+	// FindCake return nil when the store has no setting for the cake
+	FindCake(ctx context.Context, storeId int, cakeId int) (*StoreCake, error)
+	// FindPrices return the price overrides of the store for the variants, by variant id
+	FindPrices(ctx context.Context, storeId int, variantIds []int) (map[int]Money, error)
+	// FindNearest return at most limit active located stores, nearest to the point first
+	FindNearest(ctx context.Context, lat, lng float64, limit int) ([]*NearbyStore, error)
+}
+
+type StoreService interface {
+	Create(ctx context.Context, req CreateUpdateStoreRequest) (*Store, error)
+	Update(ctx context.Context, req CreateUpdateStoreRequest, storeId int) (*Store, error)
+	FindById(ctx context.Context, storeId int) (*Store, error)
+	FindAll(ctx context.Context) ([]*Store, error)
+	SetCake(ctx context.Context, req SetStoreCakeRequest, storeId int, cakeId int) (*StoreCake, error)
+	FindCake(ctx context.Context, storeId int, cakeId int) (*StoreCake, error)
+	FindNearest(ctx context.Context, query NearestStoreQuery) ([]*NearbyStore, error)
+	QuoteDelivery(ctx context.Context, req DeliveryQuoteRequest) (*DeliveryQuote, error)
+}
+
+type StoreController interface {
+	HandleCreate() echo.HandlerFunc
+	HandleUpdate() echo.HandlerFunc
+	HandleFindById() echo.HandlerFunc
+	HandleFindAll() echo.HandlerFunc
+	HandleSetCake() echo.HandlerFunc
+	HandleFindCake() echo.HandlerFunc
+	HandleFindNearest() echo.HandlerFunc
+	HandleQuoteDelivery() echo.HandlerFunc
+}
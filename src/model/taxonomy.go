@@ -0,0 +1,96 @@
+package model
+
+import (
+	"context"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Taxonomy is a classification of the cakes into terms, a cake may have many terms of each taxonomy.
+// The categories and the tags are the taxonomies, only the categories are described
+type Taxonomy struct {
+	// Name is the taxonomy in the logs
+	Name       string
+	Table      string
+	JoinTable  string
+	JoinColumn string
+	Described  bool
+	// embed set the terms of the taxonomy on the cake
+	embed func(cake *Cake, terms []*Term)
+}
+
+var (
+	Categories = Taxonomy{
+		Name:       "category",
+		Table:      "categories",
+		JoinTable:  "cake_categories",
+		JoinColumn: "category_id",
+		Described:  true,
+		embed:      func(cake *Cake, terms []*Term) { cake.Categories = terms },
+	}
+	Tags = Taxonomy{
+		Name:       "tag",
+		Table:      "tags",
+		JoinTable:  "cake_tags",
+		JoinColumn: "tag_id",
+		embed:      func(cake *Cake, terms []*Term) { cake.Tags = terms },
+	}
+)
+
+// Embed set the terms of the taxonomy on the cake
+func (t Taxonomy) Embed(cake *Cake, terms []*Term) {
+	t.embed(cake, terms)
+}
+
+type CreateUpdateTermRequest struct {
+	Name string `json:"name" validate:"required,min=2,max=60"`
+	Slug string `json:"slug" validate:"omitempty,max=60,slug"`
+	// Description is ignored by the taxonomies that are not described
+	Description string `json:"description"`
+}
+
+func (c *CreateUpdateTermRequest) Validate() error {
+	return validate.Struct(c)
+}
+
+// Term is a category or a tag
+type Term struct {
+	Id   int    `json:"id"`
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+	// Description is nil for the terms of a taxonomy that is not described
+	Description *string   `json:"description,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type TaxonomyRepository interface {
+	Save(ctx context.Context, term *Term) error
+	Update(ctx context.Context, term *Term) error
+	Delete(ctx context.Context, term *Term) error
+	FindAll(ctx context.Context) ([]*Term, error)
+	FindById(ctx context.Context, id int) (*Term, error)
+	FindByIds(ctx context.Context, ids []int) ([]*Term, error)
+	FindCakeIds(ctx context.Context, termId int) ([]int, error)
+	SetCakeTerms(ctx context.Context, cakeId int, termIds []int) error
+	LoadCakes(ctx context.Context, cakes []*Cake) error
+}
+
+type TaxonomyService interface {
+	Create(ctx context.Context, req CreateUpdateTermRequest) (*Term, error)
+	Update(ctx context.Context, req CreateUpdateTermRequest, termId int) (*Term, error)
+	Delete(ctx context.Context, termId int) (*Term, error)
+	FindById(ctx context.Context, termId int) (*Term, error)
+	FindAll(ctx context.Context) ([]*Term, error)
+	SetCakeTerms(ctx context.Context, req SetCakeRelationRequest, cakeId int) ([]*Term, error)
+}
+
+type TaxonomyController interface {
+	HandleCreate() echo.HandlerFunc
+	HandleUpdate() echo.HandlerFunc
+	HandleDelete() echo.HandlerFunc
+	HandleFindById() echo.HandlerFunc
+	HandleFindAll() echo.HandlerFunc
+	HandleSetCakeTerms() echo.HandlerFunc
+}
@@ -1,6 +1,7 @@
 package model
 
 import (
+	"regexp"
 	"sync"
 
 	"github.com/go-playground/validator/v10"
@@ -11,8 +12,25 @@ var validate *validator.Validate
 
 var initOnce sync.Once
 
+var (
+	slugRegex = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+	skuRegex  = regexp.MustCompile(`^[A-Z0-9]+([-_][A-Z0-9]+)*$`)
+)
+
 func init() {
 	initOnce.Do(func() {
 		validate = validator.New()
+		_ = validate.RegisterValidation("slug", func(fl validator.FieldLevel) bool {
+			return slugRegex.MatchString(fl.Field().String())
+		})
+		_ = validate.RegisterValidation("sku", func(fl validator.FieldLevel) bool {
+			return skuRegex.MatchString(fl.Field().String())
+		})
+		_ = validate.RegisterValidation("allergen", func(fl validator.FieldLevel) bool {
+			return IsAllergen(fl.Field().String())
+		})
+		_ = validate.RegisterValidation("unit", func(fl validator.FieldLevel) bool {
+			return IsUnit(fl.Field().String())
+		})
 	})
 }
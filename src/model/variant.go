@@ -0,0 +1,58 @@
+package model
+
+import (
+	"context"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+type CreateUpdateVariantRequest struct {
+	Size     string `json:"size" validate:"required,max=30"`
+	Servings int    `json:"servings" validate:"gte=1,lte=500"`
+	Price    Money  `json:"price"`
+	Sku      string `json:"sku" validate:"required,max=64,sku"`
+	Active   *bool  `json:"active"`
+}
+
+func (c *CreateUpdateVariantRequest) Validate() error {
+	return validate.Struct(c)
+}
+
+// Variant is a sellable size of a cake
+type Variant struct {
+	Id        int       `json:"id"`
+	CakeId    int       `json:"cake_id"`
+	Size      string    `json:"size"`
+	Servings  int       `json:"servings"`
+	Price     Money     `json:"price"`
+	Sku       string    `json:"sku"`
+	Active    bool      `json:"active"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type VariantRepository interface {
+	Save(ctx context.Context, variant *Variant) error
+	Update(ctx context.Context, variant *Variant) error
+	Delete(ctx context.Context, variant *Variant) error
+	FindById(ctx context.Context, id int) (*Variant, error)
+	FindByCakeId(ctx context.Context, cakeId int) ([]*Variant, error)
+	LoadCakes(ctx context.Context, cakes []*Cake) error
+}
+
+type VariantService interface {
+	Create(ctx context.Context, req CreateUpdateVariantRequest, cakeId int) (*Variant, error)
+	Update(ctx context.Context, req CreateUpdateVariantRequest, cakeId int, variantId int) (*Variant, error)
+	Delete(ctx context.Context, cakeId int, variantId int) (*Variant, error)
+	FindById(ctx context.Context, cakeId int, variantId int) (*Variant, error)
+	FindAll(ctx context.Context, cakeId int) ([]*Variant, error)
+}
+
+type VariantController interface {
+	HandleCreate() echo.HandlerFunc
+	HandleUpdate() echo.HandlerFunc
+	HandleDelete() echo.HandlerFunc
+	HandleFindById() echo.HandlerFunc
+	HandleFindAll() echo.HandlerFunc
+}
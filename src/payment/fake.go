@@ -0,0 +1,143 @@
+package payment
+
+import (
+	"cake-store/src/constant"
+	"cake-store/src/helper"
+	"cake-store/src/model"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// NewGateway build the gateway of the provider, the webhooks are signed with the secret, which must be set
+func NewGateway(provider string, secret string) (model.PaymentGateway, error) {
+	if secret == "" {
+		return nil, errors.New("payment: the webhook secret is not set")
+	}
+
+	switch provider {
+	case FakeProvider:
+		return NewFake(secret), nil
+	default:
+		return nil, fmt.Errorf("payment: unknown provider %q", provider)
+	}
+}
+
+const FakeProvider = "fake"
+
+// the tokens of the fake gateway scenarios, any other token is declined
+const (
+	TokenSuccess = "tok_success"
+	TokenDecline = "tok_decline"
+	// TokenDelayed is authorized but its capture settle later, when the settlement webhook is received
+	TokenDelayed = "tok_delayed"
+)
+
+// the scenario is kept in the reference, so the fake hold no state and survive a restart
+const (
+	scenarioSuccess  = "success"
+	scenarioDeclined = "declined"
+	scenarioDelayed  = "delayed"
+)
+
+type fake struct {
+	secret []byte
+}
+
+// NewFake simulate a gateway offline, the scenario of a payment is chosen by the token it is authorized with
+func NewFake(secret string) model.PaymentGateway {
+	return &fake{
+		secret: []byte(secret),
+	}
+}
+
+func (f *fake) Name() string {
+	return FakeProvider
+}
+
+func (f *fake) Authorize(ctx context.Context, authorization model.GatewayAuthorization) (*model.GatewayResult, error) {
+	scenario, reason := scenarioDeclined, "card declined"
+	switch authorization.Token {
+	case TokenSuccess:
+		scenario, reason = scenarioSuccess, ""
+	case TokenDelayed:
+		scenario, reason = scenarioDelayed, ""
+	case TokenDecline:
+	default:
+		reason = "unknown token"
+	}
+
+	random, err := helper.RandomToken()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &model.GatewayResult{
+		Reference: fmt.Sprintf("%s_%s_%s", FakeProvider, scenario, random),
+		Status:    model.PaymentStatusAuthorized,
+		Reason:    reason,
+	}
+	if scenario == scenarioDeclined {
+		result.Status = model.PaymentStatusDeclined
+	}
+	return result, nil
+}
+
+func (f *fake) Capture(ctx context.Context, reference string, amount model.Money) (*model.GatewayResult, error) {
+	switch scenarioOf(reference) {
+	case scenarioSuccess:
+		return &model.GatewayResult{Reference: reference, Status: model.PaymentStatusCaptured}, nil
+	case scenarioDelayed:
+		return &model.GatewayResult{Reference: reference, Status: model.PaymentStatusSettling}, nil
+	default:
+		return nil, fmt.Errorf("payment: %s is not authorized", reference)
+	}
+}
+
+func (f *fake) Refund(ctx context.Context, reference string, amount model.Money) (*model.GatewayResult, error) {
+	switch scenarioOf(reference) {
+	case scenarioSuccess, scenarioDelayed:
+		return &model.GatewayResult{Reference: reference, Status: model.PaymentStatusRefunded}, nil
+	default:
+		return nil, fmt.Errorf("payment: %s is not captured", reference)
+	}
+}
+
+// VerifyWebhook check the payload is signed with the secret, the signature is the hex HMAC-SHA256 of the payload
+func (f *fake) VerifyWebhook(ctx context.Context, payload []byte, signature string) (*model.GatewayEvent, error) {
+	expected, err := hex.DecodeString(signature)
+	if err != nil || !hmac.Equal(expected, sign(f.secret, payload)) {
+		return nil, constant.ErrInvalidSignature
+	}
+
+	event := &model.GatewayEvent{}
+	if err := json.Unmarshal(payload, event); err != nil {
+		return nil, constant.ErrInvalidArgument
+	}
+	return event, nil
+}
+
+// Sign return the signature of the webhook payload expected by the fake gateway, used to simulate the webhooks
+func Sign(secret string, payload []byte) string {
+	return hex.EncodeToString(sign([]byte(secret), payload))
+}
+
+func sign(secret []byte, payload []byte) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
+
+// scenarioOf return the scenario kept in the reference, empty when it is not a reference of the fake
+func scenarioOf(reference string) string {
+	parts := strings.SplitN(reference, "_", 3)
+	if len(parts) != 3 || parts[0] != FakeProvider {
+		return ""
+	}
+	return parts[1]
+}
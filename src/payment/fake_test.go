@@ -0,0 +1,121 @@
+package payment
+
+import (
+	"cake-store/src/constant"
+	"cake-store/src/model"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFake_Authorize(t *testing.T) {
+	ctx := context.TODO()
+	gateway := NewFake("secret")
+	amount := model.NewMoney(2000000, "IDR")
+
+	t.Run("success", func(t *testing.T) {
+		res, err := gateway.Authorize(ctx, model.GatewayAuthorization{OrderId: 1, Amount: amount, Token: TokenSuccess})
+		require.NoError(t, err)
+		assert.Equal(t, model.PaymentStatusAuthorized, res.Status)
+		assert.Empty(t, res.Reason)
+
+		capture, err := gateway.Capture(ctx, res.Reference, amount)
+		require.NoError(t, err)
+		assert.Equal(t, model.PaymentStatusCaptured, capture.Status)
+
+		refund, err := gateway.Refund(ctx, res.Reference, amount)
+		require.NoError(t, err)
+		assert.Equal(t, model.PaymentStatusRefunded, refund.Status)
+	})
+
+	t.Run("delayed settlement", func(t *testing.T) {
+		res, err := gateway.Authorize(ctx, model.GatewayAuthorization{OrderId: 1, Amount: amount, Token: TokenDelayed})
+		require.NoError(t, err)
+		assert.Equal(t, model.PaymentStatusAuthorized, res.Status)
+
+		capture, err := gateway.Capture(ctx, res.Reference, amount)
+		require.NoError(t, err)
+		assert.Equal(t, model.PaymentStatusSettling, capture.Status)
+	})
+
+	t.Run("decline", func(t *testing.T) {
+		res, err := gateway.Authorize(ctx, model.GatewayAuthorization{OrderId: 1, Amount: amount, Token: TokenDecline})
+		require.NoError(t, err)
+		assert.Equal(t, model.PaymentStatusDeclined, res.Status)
+		assert.Equal(t, "card declined", res.Reason)
+
+		_, err = gateway.Capture(ctx, res.Reference, amount)
+		assert.Error(t, err)
+	})
+
+	t.Run("unknown token", func(t *testing.T) {
+		res, err := gateway.Authorize(ctx, model.GatewayAuthorization{OrderId: 1, Amount: amount, Token: "tok_other"})
+		require.NoError(t, err)
+		assert.Equal(t, model.PaymentStatusDeclined, res.Status)
+	})
+
+	t.Run("unique references", func(t *testing.T) {
+		first, err := gateway.Authorize(ctx, model.GatewayAuthorization{Token: TokenSuccess})
+		require.NoError(t, err)
+		second, err := gateway.Authorize(ctx, model.GatewayAuthorization{Token: TokenSuccess})
+		require.NoError(t, err)
+		assert.NotEqual(t, first.Reference, second.Reference)
+	})
+
+	t.Run("capture unknown reference", func(t *testing.T) {
+		_, err := gateway.Capture(ctx, "other_success_abc", amount)
+		assert.Error(t, err)
+	})
+}
+
+func TestFake_VerifyWebhook(t *testing.T) {
+	ctx := context.TODO()
+	gateway := NewFake("secret")
+	payload := []byte(`{"reference":"fake_delayed_abc","status":"captured"}`)
+
+	t.Run("ok", func(t *testing.T) {
+		event, err := gateway.VerifyWebhook(ctx, payload, Sign("secret", payload))
+		require.NoError(t, err)
+		assert.Equal(t, &model.GatewayEvent{Reference: "fake_delayed_abc", Status: model.PaymentStatusCaptured}, event)
+	})
+
+	t.Run("signed with another secret", func(t *testing.T) {
+		event, err := gateway.VerifyWebhook(ctx, payload, Sign("other", payload))
+		assert.Equal(t, constant.ErrInvalidSignature, err)
+		assert.Nil(t, event)
+	})
+
+	t.Run("tampered payload", func(t *testing.T) {
+		signature := Sign("secret", payload)
+		event, err := gateway.VerifyWebhook(ctx, []byte(`{"reference":"fake_delayed_abc","status":"failed"}`), signature)
+		assert.Equal(t, constant.ErrInvalidSignature, err)
+		assert.Nil(t, event)
+	})
+
+	t.Run("invalid signature", func(t *testing.T) {
+		event, err := gateway.VerifyWebhook(ctx, payload, "not-hex")
+		assert.Equal(t, constant.ErrInvalidSignature, err)
+		assert.Nil(t, event)
+	})
+
+	t.Run("invalid payload", func(t *testing.T) {
+		invalid := []byte(`{"reference":`)
+		event, err := gateway.VerifyWebhook(ctx, invalid, Sign("secret", invalid))
+		assert.Equal(t, constant.ErrInvalidArgument, err)
+		assert.Nil(t, event)
+	})
+}
+
+func TestNewGateway(t *testing.T) {
+	gateway, err := NewGateway("fake", "secret")
+	require.NoError(t, err)
+	assert.Equal(t, "fake", gateway.Name())
+
+	_, err = NewGateway("stripe", "secret")
+	assert.Error(t, err)
+
+	_, err = NewGateway("fake", "")
+	assert.Error(t, err)
+}
@@ -0,0 +1,73 @@
+package report
+
+import (
+	"cake-store/src/model"
+	"encoding/csv"
+	"html/template"
+	"io"
+	"strconv"
+)
+
+// timeLayout is the layout of the batch times, the plan is for a single day
+const timeLayout = "15:04"
+
+// ProductionCSV write a row per batch of the plan
+func ProductionCSV(out io.Writer, plan *model.ProductionPlan) error {
+	w := csv.NewWriter(out)
+	w.Write([]string{"date", "batch", "cake_id", "variant_id", "cake", "size", "quantity", "prep_start", "bake_start", "ready"})
+	for _, batch := range plan.Batches {
+		w.Write([]string{
+			plan.Date,
+			strconv.Itoa(batch.Position),
+			strconv.Itoa(batch.CakeId),
+			strconv.Itoa(batch.VariantId),
+			batch.Title,
+			batch.Size,
+			strconv.Itoa(batch.Quantity),
+			batch.PrepStartAt.Format(timeLayout),
+			batch.BakeStartAt.Format(timeLayout),
+			batch.ReadyAt.Format(timeLayout),
+		})
+	}
+
+	w.Flush()
+	return w.Error()
+}
+
+// ProductionHTML write the plan as a page to print and pin up in the kitchen
+func ProductionHTML(out io.Writer, plan *model.ProductionPlan) error {
+	return productionTemplate.Execute(out, plan)
+}
+
+var productionTemplate = template.Must(template.New("production").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Bake schedule {{.Date}}</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+table { border-collapse: collapse; width: 100%; margin-bottom: 2em; }
+th, td { border: 1px solid #444; padding: 4px 8px; text-align: left; }
+td.number { text-align: right; }
+@media print { body { margin: 0; } h2 { page-break-after: avoid; } tr { page-break-inside: avoid; } }
+</style>
+</head>
+<body>
+<h1>Bake schedule {{.Date}}</h1>
+<h2>Batches</h2>
+{{if .Batches}}<table>
+<tr><th>#</th><th>Cake</th><th>Size</th><th>Quantity</th><th>Prep</th><th>Bake</th><th>Ready</th><th>Done</th></tr>
+{{range .Batches}}<tr><td>{{.Position}}</td><td>{{.Title}}</td><td>{{.Size}}</td><td class="number">{{.Quantity}}</td><td>{{.PrepStartAt.Format "15:04"}}</td><td>{{.BakeStartAt.Format "15:04"}}</td><td>{{.ReadyAt.Format "15:04"}}</td><td></td></tr>
+{{end}}</table>
+<p>Last batch ready at {{.FinishAt.Format "15:04"}}</p>
+{{else}}<p>Nothing to bake.</p>
+{{end}}<h2>Orders</h2>
+{{if .Lines}}<table>
+<tr><th>Cake</th><th>Size</th><th>Ordered</th><th>On hand</th><th>To bake</th></tr>
+{{range .Lines}}<tr><td>{{.Title}}</td><td>{{.Size}}</td><td class="number">{{.Ordered}}</td><td class="number">{{.OnHand}}</td><td class="number">{{.ToBake}}</td></tr>
+{{end}}</table>
+{{else}}<p>No confirmed orders.</p>
+{{end}}<p><small>Generated {{.GeneratedAt.Format "2006-01-02 15:04"}}</small></p>
+</body>
+</html>
+`))
@@ -0,0 +1,54 @@
+package report
+
+import (
+	"bytes"
+	"cake-store/src/model"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProductionCSV(t *testing.T) {
+	start := time.Date(2026, 10, 20, 6, 0, 0, 0, time.UTC)
+	plan := &model.ProductionPlan{
+		Date: "2026-10-20",
+		Batches: []*model.ProductionBatch{{Position: 1, CakeId: 1, VariantId: 5, Title: "Black Forest, Classic", Size: "20cm", Quantity: 2,
+			PrepStartAt: start, BakeStartAt: start.Add(30 * time.Minute), ReadyAt: start.Add(75 * time.Minute)}},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, ProductionCSV(&buf, plan))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 2)
+	assert.Equal(t, "date,batch,cake_id,variant_id,cake,size,quantity,prep_start,bake_start,ready", lines[0])
+	assert.Equal(t, `2026-10-20,1,1,5,"Black Forest, Classic",20cm,2,06:00,06:30,07:15`, lines[1])
+}
+
+func TestProductionHTML(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		start := time.Date(2026, 10, 20, 6, 0, 0, 0, time.UTC)
+		plan := &model.ProductionPlan{
+			Date:     "2026-10-20",
+			Lines:    []*model.ProductionLine{{Title: "Tart <Lemon>", Size: "16cm", Ordered: 1, ToBake: 1}},
+			Batches:  []*model.ProductionBatch{{Position: 1, Title: "Tart <Lemon>", Size: "16cm", Quantity: 1, PrepStartAt: start, BakeStartAt: start, ReadyAt: start.Add(time.Hour)}},
+			FinishAt: start.Add(time.Hour),
+		}
+
+		var buf bytes.Buffer
+		require.NoError(t, ProductionHTML(&buf, plan))
+		assert.Contains(t, buf.String(), "Bake schedule 2026-10-20")
+		assert.Contains(t, buf.String(), "Tart &lt;Lemon&gt;")
+		assert.Contains(t, buf.String(), "Last batch ready at 07:00")
+	})
+
+	t.Run("ok - empty", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, ProductionHTML(&buf, &model.ProductionPlan{Date: "2026-10-20"}))
+		assert.Contains(t, buf.String(), "Nothing to bake.")
+		assert.Contains(t, buf.String(), "No confirmed orders.")
+	})
+}
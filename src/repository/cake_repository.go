@@ -2,11 +2,15 @@ package repository
 
 import (
 	"cake-store/src/config"
+	"cake-store/src/constant"
 	"cake-store/src/model"
 	"context"
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/redis/go-redis/v9"
 	"github.com/sirupsen/logrus"
@@ -30,8 +34,9 @@ func (c *cakeRepository) Save(ctx context.Context, cake *model.Cake) error {
 		"cake":    cake,
 	})
 
-	sql := "INSERT INTO cakes(title,description,rating,image,created_at,updated_at,deleted_at) VALUES (?,?,?,?,?,?,?)"
-	res, err := c.db.ExecContext(ctx, sql, cake.Title, cake.Description, cake.Rating, cake.Image, cake.CreatedAt, cake.UpdatedAt, cake.DeletedAt)
+	sql := "INSERT INTO cakes(title,description,rating,rating_mean,rating_count,image,version,created_at,updated_at,deleted_at) VALUES (?,?,?,?,?,?,?,?,?,?)"
+	res, err := c.db.ExecContext(ctx, sql, cake.Title, cake.Description, cake.Rating, cake.RatingMean, cake.RatingCount, cake.Image, cake.Version,
+		cake.CreatedAt, cake.UpdatedAt, cake.DeletedAt)
 	if err != nil {
 		log.Error(err)
 		return err
@@ -53,16 +58,15 @@ func (c *cakeRepository) Update(ctx context.Context, cake *model.Cake) error {
 		"cake":    cake,
 	})
 
-	query := "UPDATE cakes SET title = ?, description = ?, rating = ?, Image = ?, updated_at = ? WHERE id = ?"
+	query := "UPDATE cakes SET title = ?, description = ?, Image = ?, updated_at = ?, version = version + 1 WHERE id = ? AND version = ?"
 
-	_, err := c.db.ExecContext(ctx, query, cake.Title, cake.Description, cake.Rating, cake.Image, cake.UpdatedAt, cake.Id)
+	res, err := c.db.ExecContext(ctx, query, cake.Title, cake.Description, cake.Image, cake.UpdatedAt, cake.Id, cake.Version)
 	if err != nil {
 		log.Error(err)
 		return err
 	}
 
-	err = c.redis.Del(ctx, fmt.Sprintf("cake:%d", cake.Id)).Err()
-	if err != nil {
+	if err = c.checkVersion(ctx, res, cake); err != nil {
 		log.Error(err)
 		return err
 	}
@@ -76,16 +80,15 @@ func (c *cakeRepository) Delete(ctx context.Context, cake *model.Cake) error {
 		"cake":    cake,
 	})
 
-	query := "UPDATE cakes SET deleted_at = ? where id = ?"
+	query := "UPDATE cakes SET deleted_at = ?, version = version + 1 where id = ? AND version = ?"
 
-	_, err := c.db.ExecContext(ctx, query, cake.DeletedAt, cake.Id)
+	res, err := c.db.ExecContext(ctx, query, cake.DeletedAt, cake.Id, cake.Version)
 	if err != nil {
 		log.Error(err)
 		return err
 	}
 
-	err = c.redis.Del(ctx, fmt.Sprintf("cake:%d", cake.Id)).Err()
-	if err != nil {
+	if err = c.checkVersion(ctx, res, cake); err != nil {
 		log.Error(err)
 		return err
 	}
@@ -93,13 +96,56 @@ func (c *cakeRepository) Delete(ctx context.Context, cake *model.Cake) error {
 	return nil
 }
 
-func (c *cakeRepository) FindAll(ctx context.Context) ([]*model.Cake, error) {
+// checkVersion invalidate the cake cache and bump the cake version when the versioned statement hit the row,
+// otherwise the row has been changed by someone else since it was read
+func (c *cakeRepository) checkVersion(ctx context.Context, res sql.Result, cake *model.Cake) error {
+	if err := c.checkAffected(ctx, res, cake); err != nil {
+		return err
+	}
+
+	cake.Version++
+	return nil
+}
+
+// checkAffected invalidate the cake cache and report a version conflict when the versioned statement missed the row
+func (c *cakeRepository) checkAffected(ctx context.Context, res sql.Result, cake *model.Cake) error {
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if err = c.redis.Del(ctx, cakeKey(cake.Id)).Err(); err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return constant.ErrVersionConflict
+	}
+	return nil
+}
+
+func (c *cakeRepository) FindAll(ctx context.Context, query model.CakeQuery) ([]*model.Cake, error) {
 	log := logrus.WithFields(logrus.Fields{
 		"message": "Find All Cake Repository",
+		"query":   query,
 	})
 
-	sql := "SELECT * FROM cakes WHERE deleted_at IS null ORDER BY rating DESC, title ASC"
-	rows, err := c.db.QueryContext(ctx, sql)
+	conditions, args := cakeFilter(query)
+	if after := query.After; query.IsCursor() && after != nil {
+		conditions = append(conditions, "(rating < ? OR (rating = ? AND (title > ? OR (title = ? AND id > ?))))")
+		args = append(args, after.Rating, after.Rating, after.Title, after.Title, after.Id)
+	}
+
+	sql := "SELECT " + cakeColumns + " FROM cakes" + where(conditions)
+	if query.IsCursor() {
+		sql += " ORDER BY rating DESC, title ASC, id ASC LIMIT ?"
+		args = append(args, query.Limit)
+	} else {
+		sql += " ORDER BY " + cakeOrder(query) + " LIMIT ? OFFSET ?"
+		args = append(args, query.Limit, model.Offset(query.Page, query.Limit))
+	}
+
+	rows, err := c.db.QueryContext(ctx, sql, args...)
 	if err != nil {
 		log.Error(err)
 		return nil, err
@@ -109,8 +155,7 @@ func (c *cakeRepository) FindAll(ctx context.Context) ([]*model.Cake, error) {
 	cakes := make([]*model.Cake, 0)
 
 	for rows.Next() {
-		cake := &model.Cake{}
-		err := rows.Scan(&cake.Id, &cake.Title, &cake.Description, &cake.Rating, &cake.Image, &cake.CreatedAt, &cake.UpdatedAt, &cake.DeletedAt)
+		cake, err := scanCake(rows)
 		if err != nil {
 			log.Error(err)
 			return nil, err
@@ -120,13 +165,48 @@ func (c *cakeRepository) FindAll(ctx context.Context) ([]*model.Cake, error) {
 	return cakes, nil
 }
 
+func (c *cakeRepository) CountAll(ctx context.Context, query model.CakeQuery) (int64, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Count All Cake Repository",
+		"query":   query,
+	})
+
+	conditions, args := cakeFilter(query)
+	sql := "SELECT COUNT(id) FROM cakes" + where(conditions)
+
+	var total int64
+	if err := c.db.QueryRowContext(ctx, sql, args...).Scan(&total); err != nil {
+		log.Error(err)
+		return 0, err
+	}
+
+	return total, nil
+}
+
 func (c *cakeRepository) FindById(ctx context.Context, id int) (*model.Cake, error) {
 	log := logrus.WithFields(logrus.Fields{
 		"message": "Find By ID Cake Repository",
 		"id":      id,
 	})
 
-	cache, err := c.redis.Get(ctx, fmt.Sprintf("cake:%d", id)).Result()
+	return c.findCached(ctx, log, id, 0)
+}
+
+func (c *cakeRepository) FindByIdInStore(ctx context.Context, id int, storeId int) (*model.Cake, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Find By ID In Store Cake Repository",
+		"id":      id,
+		"storeId": storeId,
+	})
+
+	return c.findCached(ctx, log, id, storeId)
+}
+
+// findCached find the live cake as seen from the store through the redis cache, the store 0 being the catalog.
+// The views of a cake share one hash, so deleting it invalidate the cake in every store
+func (c *cakeRepository) findCached(ctx context.Context, log *logrus.Entry, id int, storeId int) (*model.Cake, error) {
+	field := strconv.Itoa(storeId)
+	cache, err := c.redis.HGet(ctx, cakeKey(id), field).Result()
 	if err != nil && err != redis.Nil {
 		log.Error(err)
 		return nil, err
@@ -143,36 +223,303 @@ func (c *cakeRepository) FindById(ctx context.Context, id int) (*model.Cake, err
 		return cake, nil
 	}
 
-	sql := "SELECT * FROM cakes where id = ? AND deleted_at is null"
-	rows, err := c.db.QueryContext(ctx, sql, id)
+	cake, err := c.findLive(ctx, id)
 	if err != nil {
 		log.Error(err)
 		return nil, err
 	}
-	defer rows.Close()
 
-	cake := &model.Cake{}
-	if rows.Next() {
-		err := rows.Scan(&cake.Id, &cake.Title, &cake.Description, &cake.Rating, &cake.Image, &cake.CreatedAt, &cake.UpdatedAt, &cake.DeletedAt)
-		if err != nil {
-			log.Error(err)
-			return nil, err
-		}
+	if cake == nil {
+		return nil, nil
+	}
 
-		jsonData, err := json.Marshal(cake)
-		if err != nil {
+	// a cake the store has no setting for is sold there
+	if storeId != 0 {
+		available := true
+		query := "SELECT available FROM store_cakes WHERE store_id = ? AND cake_id = ?"
+		if err := c.db.QueryRowContext(ctx, query, storeId, id).Scan(&available); err != nil && err != sql.ErrNoRows {
 			log.Error(err)
 			return nil, err
 		}
+		cake.Available = &available
+	}
+
+	jsonData, err := json.Marshal(cake)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	log.Info("Set data to redis")
+	_, err = c.redis.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, cakeKey(id), field, string(jsonData))
+		pipe.Expire(ctx, cakeKey(id), config.RedisExp())
+		return nil
+	})
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return cake, nil
+}
+
+func (c *cakeRepository) findLive(ctx context.Context, id int) (*model.Cake, error) {
+	query := "SELECT " + cakeColumns + " FROM cakes where id = ? AND deleted_at is null"
+	rows, err := c.db.QueryContext(ctx, query, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	if rows.Next() {
+		return scanCake(rows)
+	}
+	return nil, nil
+}
 
-		log.Info("Set data to redis")
-		err = c.redis.Set(context.Background(), fmt.Sprintf("cake:%d", id), string(jsonData), config.RedisExp()).Err()
+// FindByIdUnscoped find the cake including the soft deleted one, it always read from the database
+func (c *cakeRepository) FindByIdUnscoped(ctx context.Context, id int) (*model.Cake, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Find By ID Unscoped Cake Repository",
+		"id":      id,
+	})
+
+	sql := "SELECT " + cakeColumns + " FROM cakes where id = ?"
+	rows, err := c.db.QueryContext(ctx, sql, id)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	if rows.Next() {
+		cake, err := scanCake(rows)
 		if err != nil {
 			log.Error(err)
 			return nil, err
 		}
-
 		return cake, nil
 	}
 	return nil, nil
 }
+
+func (c *cakeRepository) Restore(ctx context.Context, cake *model.Cake) error {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Restore Cake Repository",
+		"cake":    cake,
+	})
+
+	query := "UPDATE cakes SET deleted_at = NULL, updated_at = ?, version = version + 1 WHERE id = ? AND version = ?"
+
+	res, err := c.db.ExecContext(ctx, query, cake.UpdatedAt, cake.Id, cake.Version)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	if err = c.checkVersion(ctx, res, cake); err != nil {
+		log.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// Purge permanently delete the cake row
+func (c *cakeRepository) Purge(ctx context.Context, cake *model.Cake) error {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Purge Cake Repository",
+		"cake":    cake,
+	})
+
+	query := "DELETE FROM cakes WHERE id = ? AND version = ?"
+
+	res, err := c.db.ExecContext(ctx, query, cake.Id, cake.Version)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	// the row is gone, there is no version left to bump
+	if err = c.checkAffected(ctx, res, cake); err != nil {
+		log.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+func (c *cakeRepository) CountDeletedBefore(ctx context.Context, before time.Time) (int64, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Count Deleted Before Cake Repository",
+		"before":  before,
+	})
+
+	sql := "SELECT COUNT(id) FROM cakes WHERE deleted_at IS NOT null AND deleted_at < ?"
+
+	var total int64
+	if err := c.db.QueryRowContext(ctx, sql, before).Scan(&total); err != nil {
+		log.Error(err)
+		return 0, err
+	}
+
+	return total, nil
+}
+
+// PurgeDeletedBefore permanently delete at most limit cakes soft deleted before the time, return the deleted count
+func (c *cakeRepository) PurgeDeletedBefore(ctx context.Context, before time.Time, limit int) (int64, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Purge Deleted Before Cake Repository",
+		"before":  before,
+		"limit":   limit,
+	})
+
+	query := "DELETE FROM cakes WHERE deleted_at IS NOT null AND deleted_at < ? ORDER BY id LIMIT ?"
+
+	res, err := c.db.ExecContext(ctx, query, before, limit)
+	if err != nil {
+		log.Error(err)
+		return 0, err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		log.Error(err)
+		return 0, err
+	}
+
+	return affected, nil
+}
+
+// Touch bump the version and update time of the cakes and invalidate their cache, used when a cake relation changed
+func (c *cakeRepository) Touch(ctx context.Context, ids ...int) error {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Touch Cake Repository",
+		"ids":     ids,
+	})
+
+	if len(ids) == 0 {
+		return nil
+	}
+
+	query := "UPDATE cakes SET version = version + 1, updated_at = ? WHERE id IN (" + placeholders(len(ids)) + ")"
+	args := append([]interface{}{time.Now()}, intArgs(ids)...)
+
+	if _, err := c.db.ExecContext(ctx, query, args...); err != nil {
+		log.Error(err)
+		return err
+	}
+
+	keys := make([]string, 0, len(ids))
+	for _, id := range ids {
+		keys = append(keys, cakeKey(id))
+	}
+	if err := c.redis.Del(ctx, keys...).Err(); err != nil {
+		log.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// cakeKey is the redis hash of the cached views of the cake, by store
+func cakeKey(id int) string {
+	return fmt.Sprintf("cake:%d:stores", id)
+}
+
+// cakeColumns is the selected columns of cakes, in the order read by scanCake
+const cakeColumns = "id, title, description, rating, rating_mean, rating_count, image, version, created_at, updated_at, deleted_at"
+
+func scanCake(rows *sql.Rows) (*model.Cake, error) {
+	cake := &model.Cake{}
+	err := rows.Scan(&cake.Id, &cake.Title, &cake.Description, &cake.Rating, &cake.RatingMean, &cake.RatingCount, &cake.Image, &cake.Version,
+		&cake.CreatedAt, &cake.UpdatedAt, &cake.DeletedAt)
+	if err != nil {
+		return nil, err
+	}
+	return cake, nil
+}
+
+// cakeSortColumns whitelist the columns allowed to be used on ORDER BY
+var cakeSortColumns = map[string]string{
+	"id":         "id",
+	"title":      "title",
+	"rating":     "rating",
+	"created_at": "created_at",
+	"updated_at": "updated_at",
+}
+
+// likeReplacer escape the wildcard characters of a LIKE pattern
+var likeReplacer = strings.NewReplacer("\\", "\\\\", "%", "\\%", "_", "\\_")
+
+// cakeFilter build the where conditions of the cake query, soft deleted cakes are excluded unless requested
+func cakeFilter(query model.CakeQuery) ([]string, []interface{}) {
+	var (
+		conditions []string
+		args       []interface{}
+	)
+
+	switch {
+	case query.Trashed:
+		conditions = append(conditions, "deleted_at IS NOT null")
+	case !query.IncludeDeleted:
+		conditions = append(conditions, "deleted_at IS null")
+	}
+
+	if query.MinRating > 0 {
+		conditions = append(conditions, "rating >= ?")
+		args = append(args, query.MinRating)
+	}
+	if query.MaxRating > 0 {
+		conditions = append(conditions, "rating <= ?")
+		args = append(args, query.MaxRating)
+	}
+	if query.Title != "" {
+		conditions = append(conditions, "title LIKE ?")
+		args = append(args, "%"+likeReplacer.Replace(query.Title)+"%")
+	}
+
+	if query.Category != "" {
+		conditions = append(conditions, "id IN (SELECT cc.cake_id FROM cake_categories cc JOIN categories ca ON ca.id = cc.category_id WHERE ca.slug = ?)")
+		args = append(args, query.Category)
+	}
+	if query.Tag != "" {
+		conditions = append(conditions, "id IN (SELECT ct.cake_id FROM cake_tags ct JOIN tags t ON t.id = ct.tag_id WHERE t.slug = ?)")
+		args = append(args, query.Tag)
+	}
+	if query.StoreId != 0 {
+		conditions = append(conditions, "id NOT IN (SELECT cake_id FROM store_cakes WHERE store_id = ? AND available = false)")
+		args = append(args, query.StoreId)
+	}
+	// the reservations are in redis, only the stock on hand is filtered
+	if query.InStock {
+		conditions = append(conditions, "id IN (SELECT cake_id FROM stocks WHERE on_hand > 0)")
+	}
+	// a cake without an ingredient list may contain anything, so it is never listed as free of an allergen
+	if allergens, _ := query.ExcludedAllergens(); len(allergens) > 0 {
+		contains := make([]string, 0, len(allergens))
+		for _, allergen := range allergens {
+			contains = append(contains, "FIND_IN_SET(?, i.allergens) > 0")
+			args = append(args, allergen)
+		}
+		conditions = append(conditions, "id IN (SELECT cake_id FROM cake_ingredients)",
+			"id NOT IN (SELECT ci.cake_id FROM cake_ingredients ci JOIN ingredients i ON i.id = ci.ingredient_id WHERE "+strings.Join(contains, " OR ")+")")
+	}
+
+	return conditions, args
+}
+
+// cakeOrder build the order clause, default ordering by rating then title
+func cakeOrder(query model.CakeQuery) string {
+	column, ok := cakeSortColumns[query.SortBy]
+	if !ok {
+		return "rating DESC, title ASC"
+	}
+
+	direction := "ASC"
+	if strings.EqualFold(query.SortDir, "desc") {
+		direction = "DESC"
+	}
+	return fmt.Sprintf("%s %s, id ASC", column, direction)
+}
@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"cake-store/src/constant"
 	"cake-store/src/model"
 	"context"
 	"errors"
@@ -30,6 +31,7 @@ func TestCakeRepository_Create(t *testing.T) {
 		Description: "Desc test",
 		Rating:      5.5,
 		Image:       "test image",
+		Version:     1,
 		CreatedAt:   time.Now(),
 		UpdatedAt:   time.Now(),
 		DeletedAt:   nil,
@@ -37,7 +39,7 @@ func TestCakeRepository_Create(t *testing.T) {
 
 	t.Run("ok", func(t *testing.T) {
 		mock.ExpectExec("INSERT INTO cakes").
-			WithArgs(cake.Title, cake.Description, cake.Rating, cake.Image, cake.CreatedAt, cake.UpdatedAt, cake.DeletedAt).
+			WithArgs(cake.Title, cake.Description, cake.Rating, cake.RatingMean, cake.RatingCount, cake.Image, cake.Version, cake.CreatedAt, cake.UpdatedAt, cake.DeletedAt).
 			WillReturnResult(sqlmock.NewResult(1, 1))
 		err := repo.Save(ctx, cake)
 		require.NoError(t, err)
@@ -45,7 +47,7 @@ func TestCakeRepository_Create(t *testing.T) {
 
 	t.Run("failed to save cake", func(t *testing.T) {
 		mock.ExpectExec("INSERT INTO cakes").
-			WithArgs(cake.Title, cake.Description, cake.Rating, cake.Image, cake.CreatedAt, cake.UpdatedAt, cake.DeletedAt).
+			WithArgs(cake.Title, cake.Description, cake.Rating, cake.RatingMean, cake.RatingCount, cake.Image, cake.Version, cake.CreatedAt, cake.UpdatedAt, cake.DeletedAt).
 			WillReturnError(errors.New("db error"))
 		err := repo.Save(ctx, cake)
 		require.Error(t, err)
@@ -58,7 +60,8 @@ func TestCakeRepository_Update(t *testing.T) {
 	mock := kit.dbmock
 
 	repo := cakeRepository{
-		db: kit.db,
+		db:    kit.db,
+		redis: kit.redis,
 	}
 
 	ctx := context.TODO()
@@ -69,6 +72,7 @@ func TestCakeRepository_Update(t *testing.T) {
 		Description: "Desc test",
 		Rating:      5.5,
 		Image:       "test image",
+		Version:     1,
 		CreatedAt:   time.Now(),
 		UpdatedAt:   time.Now(),
 		DeletedAt:   nil,
@@ -76,15 +80,27 @@ func TestCakeRepository_Update(t *testing.T) {
 
 	t.Run("ok", func(t *testing.T) {
 		mock.ExpectExec("UPDATE cakes").
-			WithArgs(cake.Title, cake.Description, cake.Rating, cake.Image, cake.UpdatedAt, cake.Id).
+			WithArgs(cake.Title, cake.Description, cake.Image, cake.UpdatedAt, cake.Id, cake.Version).
 			WillReturnResult(sqlmock.NewResult(1, 1))
+		kit.miniredis.HSet(cakeKey(cake.Id), "0", "{}")
 		err := repo.Update(ctx, cake)
 		require.NoError(t, err)
+		assert.Equal(t, 2, cake.Version)
+		assert.False(t, kit.miniredis.Exists(cakeKey(cake.Id)))
+	})
+
+	t.Run("version conflict", func(t *testing.T) {
+		mock.ExpectExec("UPDATE cakes (.+) WHERE id = \\? AND version = \\?").
+			WithArgs(cake.Title, cake.Description, cake.Image, cake.UpdatedAt, cake.Id, cake.Version).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		err := repo.Update(ctx, cake)
+		require.Equal(t, constant.ErrVersionConflict, err)
+		assert.Equal(t, 2, cake.Version)
 	})
 
 	t.Run("failed to update cake", func(t *testing.T) {
 		mock.ExpectExec("UPDATE cakes").
-			WithArgs(cake.Title, cake.Description, cake.Rating, cake.Image, cake.CreatedAt, cake.UpdatedAt, cake.DeletedAt).
+			WithArgs(cake.Title, cake.Description, cake.Rating, cake.RatingMean, cake.RatingCount, cake.Image, cake.Version, cake.CreatedAt, cake.UpdatedAt, cake.DeletedAt).
 			WillReturnError(errors.New("db error"))
 		err := repo.Update(ctx, cake)
 		require.Error(t, err)
@@ -97,7 +113,8 @@ func TestCakeRepository_Delete(t *testing.T) {
 	mock := kit.dbmock
 
 	repo := cakeRepository{
-		db: kit.db,
+		db:    kit.db,
+		redis: kit.redis,
 	}
 
 	ctx := context.TODO()
@@ -108,6 +125,7 @@ func TestCakeRepository_Delete(t *testing.T) {
 		Description: "Desc test",
 		Rating:      5.5,
 		Image:       "test image",
+		Version:     1,
 		CreatedAt:   time.Now(),
 		UpdatedAt:   time.Now(),
 		DeletedAt:   nil,
@@ -117,15 +135,23 @@ func TestCakeRepository_Delete(t *testing.T) {
 
 	t.Run("ok", func(t *testing.T) {
 		mock.ExpectExec("UPDATE cakes").
-			WithArgs(cake.DeletedAt, cake.Id).
+			WithArgs(cake.DeletedAt, cake.Id, cake.Version).
 			WillReturnResult(sqlmock.NewResult(1, 1))
 		err := repo.Delete(ctx, cake)
 		require.NoError(t, err)
 	})
 
+	t.Run("version conflict", func(t *testing.T) {
+		mock.ExpectExec("UPDATE cakes").
+			WithArgs(cake.DeletedAt, cake.Id, cake.Version).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		err := repo.Delete(ctx, cake)
+		require.Equal(t, constant.ErrVersionConflict, err)
+	})
+
 	t.Run("failed to delete cake", func(t *testing.T) {
 		mock.ExpectExec("UPDATE cakes").
-			WithArgs(cake.DeletedAt, cake.Id).
+			WithArgs(cake.DeletedAt, cake.Id, cake.Version).
 			WillReturnError(errors.New("db error"))
 		err := repo.Delete(ctx, cake)
 		require.Error(t, err)
@@ -142,43 +168,185 @@ func TestCakeRepository_FindAll(t *testing.T) {
 	}
 
 	ctx := context.TODO()
+	query := model.CakeQuery{Page: 1, Limit: 10}
 
 	t.Run("ok - found", func(t *testing.T) {
-		resRows := sqlmock.NewRows([]string{"id", "title", "description", "rating", "image", "created_at", "updated_at", "deleted_at"}).
-			AddRow(1, "Kue Test", "Desc test", 5.5, "test image", time.Now(), time.Now(), nil).
-			AddRow(2, "Kue Test 2", "Desc test", 6, "test image", time.Now(), time.Now(), nil)
+		resRows := sqlmock.NewRows([]string{"id", "title", "description", "rating", "rating_mean", "rating_count", "image", "version", "created_at", "updated_at", "deleted_at"}).
+			AddRow(1, "Kue Test", "Desc test", 5.5, 5.5, 3, "test image", 1, time.Now(), time.Now(), nil).
+			AddRow(2, "Kue Test 2", "Desc test", 6, 6, 3, "test image", 1, time.Now(), time.Now(), nil)
 
-		mock.ExpectQuery("SELECT \\* FROM cakes WHERE deleted_at IS null ORDER BY rating DESC, title ASC").
+		mock.ExpectQuery("SELECT (.+) FROM cakes WHERE deleted_at IS null ORDER BY rating DESC, title ASC LIMIT \\? OFFSET \\?").
+			WithArgs(10, 0).
 			WillReturnRows(resRows)
 
-		res, err := repo.FindAll(ctx)
+		res, err := repo.FindAll(ctx, query)
 		require.NoError(t, err)
 		require.NotNil(t, res)
 		assert.Equal(t, 2, len(res))
 	})
 
+	t.Run("ok - filter and sort", func(t *testing.T) {
+		query := model.CakeQuery{
+			Page:      3,
+			Limit:     5,
+			MinRating: 2,
+			MaxRating: 8,
+			Title:     "choco_",
+			SortBy:    "created_at",
+			SortDir:   "desc",
+		}
+		resRows := sqlmock.NewRows([]string{"id", "title", "description", "rating", "rating_mean", "rating_count", "image", "version", "created_at", "updated_at", "deleted_at"}).
+			AddRow(1, "Kue Test", "Desc test", 5.5, 5.5, 3, "test image", 1, time.Now(), time.Now(), nil)
+
+		mock.ExpectQuery("SELECT (.+) FROM cakes WHERE deleted_at IS null AND rating >= \\? AND rating <= \\? AND title LIKE \\? ORDER BY created_at DESC, id ASC LIMIT \\? OFFSET \\?").
+			WithArgs(float32(2), float32(8), "%choco\\_%", 5, 10).
+			WillReturnRows(resRows)
+
+		res, err := repo.FindAll(ctx, query)
+		require.NoError(t, err)
+		assert.Equal(t, 1, len(res))
+	})
+
+	t.Run("ok - category and tag", func(t *testing.T) {
+		query := model.CakeQuery{Page: 1, Limit: 10, Category: "birthday", Tag: "vegan"}
+		resRows := sqlmock.NewRows([]string{"id", "title", "description", "rating", "rating_mean", "rating_count", "image", "version", "created_at", "updated_at", "deleted_at"}).
+			AddRow(1, "Kue Test", "Desc test", 5.5, 5.5, 3, "test image", 1, time.Now(), time.Now(), nil)
+
+		mock.ExpectQuery("SELECT (.+) FROM cakes WHERE deleted_at IS null AND id IN \\(SELECT cc.cake_id FROM cake_categories (.+) WHERE ca.slug = \\?\\) AND id IN \\(SELECT ct.cake_id FROM cake_tags (.+) WHERE t.slug = \\?\\) ORDER BY").
+			WithArgs("birthday", "vegan", 10, 0).
+			WillReturnRows(resRows)
+
+		res, err := repo.FindAll(ctx, query)
+		require.NoError(t, err)
+		assert.Equal(t, 1, len(res))
+	})
+
+	t.Run("ok - in stock", func(t *testing.T) {
+		query := model.CakeQuery{Page: 1, Limit: 10, InStock: true}
+		resRows := sqlmock.NewRows([]string{"id", "title", "description", "rating", "rating_mean", "rating_count", "image", "version", "created_at", "updated_at", "deleted_at"}).
+			AddRow(1, "Kue Test", "Desc test", 5.5, 5.5, 3, "test image", 1, time.Now(), time.Now(), nil)
+
+		mock.ExpectQuery("SELECT (.+) FROM cakes WHERE deleted_at IS null AND id IN \\(SELECT cake_id FROM stocks WHERE on_hand > 0\\) ORDER BY").
+			WithArgs(10, 0).
+			WillReturnRows(resRows)
+
+		res, err := repo.FindAll(ctx, query)
+		require.NoError(t, err)
+		assert.Equal(t, 1, len(res))
+	})
+
+	t.Run("ok - exclude allergens", func(t *testing.T) {
+		query := model.CakeQuery{Page: 1, Limit: 10, ExcludeAllergens: "nuts,gluten"}
+		resRows := sqlmock.NewRows([]string{"id", "title", "description", "rating", "rating_mean", "rating_count", "image", "version", "created_at", "updated_at", "deleted_at"}).
+			AddRow(1, "Kue Test", "Desc test", 5.5, 5.5, 3, "test image", 1, time.Now(), time.Now(), nil)
+
+		mock.ExpectQuery("SELECT (.+) FROM cakes WHERE deleted_at IS null AND id IN \\(SELECT cake_id FROM cake_ingredients\\) AND id NOT IN \\(SELECT ci.cake_id (.+) WHERE FIND_IN_SET\\(\\?, i.allergens\\) > 0 OR FIND_IN_SET\\(\\?, i.allergens\\) > 0\\) ORDER BY").
+			WithArgs("nuts", "gluten", 10, 0).
+			WillReturnRows(resRows)
+
+		res, err := repo.FindAll(ctx, query)
+		require.NoError(t, err)
+		assert.Equal(t, 1, len(res))
+	})
+
+	t.Run("ok - cursor", func(t *testing.T) {
+		query := model.CakeQuery{
+			Limit:    3,
+			Paginate: model.PaginateCursor,
+			After:    &model.CakeCursor{Rating: 8, Title: "Kue B", Id: 2},
+		}
+		resRows := sqlmock.NewRows([]string{"id", "title", "description", "rating", "rating_mean", "rating_count", "image", "version", "created_at", "updated_at", "deleted_at"}).
+			AddRow(3, "Kue C", "Desc test", 8, 8, 3, "test image", 1, time.Now(), time.Now(), nil)
+
+		mock.ExpectQuery("SELECT (.+) FROM cakes WHERE deleted_at IS null AND \\(rating < \\? OR \\(rating = \\? AND \\(title > \\? OR \\(title = \\? AND id > \\?\\)\\)\\)\\) ORDER BY rating DESC, title ASC, id ASC LIMIT \\?$").
+			WithArgs(float32(8), float32(8), "Kue B", "Kue B", 2, 3).
+			WillReturnRows(resRows)
+
+		res, err := repo.FindAll(ctx, query)
+		require.NoError(t, err)
+		assert.Equal(t, 1, len(res))
+	})
+
+	t.Run("ok - trashed", func(t *testing.T) {
+		resRows := sqlmock.NewRows([]string{"id", "title", "description", "rating", "rating_mean", "rating_count", "image", "version", "created_at", "updated_at", "deleted_at"}).
+			AddRow(1, "Kue Test", "Desc test", 5.5, 5.5, 3, "test image", 2, time.Now(), time.Now(), time.Now())
+
+		mock.ExpectQuery("SELECT (.+) FROM cakes WHERE deleted_at IS NOT null ORDER BY").
+			WithArgs(10, 0).
+			WillReturnRows(resRows)
+
+		res, err := repo.FindAll(ctx, model.CakeQuery{Page: 1, Limit: 10, Trashed: true})
+		require.NoError(t, err)
+		assert.Equal(t, 1, len(res))
+		assert.NotNil(t, res[0].DeletedAt)
+	})
+
+	t.Run("ok - include deleted", func(t *testing.T) {
+		resRows := sqlmock.NewRows([]string{"id", "title", "description", "rating", "rating_mean", "rating_count", "image", "version", "created_at", "updated_at", "deleted_at"}).
+			AddRow(1, "Kue Test", "Desc test", 5.5, 5.5, 3, "test image", 1, time.Now(), time.Now(), nil).
+			AddRow(2, "Kue Test 2", "Desc test", 5, 5, 3, "test image", 2, time.Now(), time.Now(), time.Now())
+
+		mock.ExpectQuery("SELECT (.+) FROM cakes ORDER BY rating DESC, title ASC LIMIT").
+			WithArgs(10, 0).
+			WillReturnRows(resRows)
+
+		res, err := repo.FindAll(ctx, model.CakeQuery{Page: 1, Limit: 10, IncludeDeleted: true})
+		require.NoError(t, err)
+		assert.Equal(t, 2, len(res))
+	})
+
 	t.Run("ok - not found", func(t *testing.T) {
-		resRows := sqlmock.NewRows([]string{"id", "title", "description", "rating", "image", "created_at", "updated_at", "deleted_at"})
+		resRows := sqlmock.NewRows([]string{"id", "title", "description", "rating", "rating_mean", "rating_count", "image", "version", "created_at", "updated_at", "deleted_at"})
 
-		mock.ExpectQuery("SELECT \\* FROM cakes WHERE deleted_at IS null ORDER BY rating DESC, title ASC").
+		mock.ExpectQuery("SELECT (.+) FROM cakes WHERE deleted_at IS null ORDER BY rating DESC, title ASC").
 			WillReturnRows(resRows)
 
-		res, err := repo.FindAll(ctx)
+		res, err := repo.FindAll(ctx, query)
 		require.NoError(t, err)
 		require.NotNil(t, res)
 		assert.Equal(t, 0, len(res))
 	})
 
 	t.Run("error", func(t *testing.T) {
-		mock.ExpectQuery("SELECT \\* FROM cakes WHERE deleted_at IS null ORDER BY rating DESC, title ASC").
+		mock.ExpectQuery("SELECT (.+) FROM cakes WHERE deleted_at IS null ORDER BY rating DESC, title ASC").
 			WillReturnError(errors.New("invalid db"))
 
-		res, err := repo.FindAll(ctx)
+		res, err := repo.FindAll(ctx, query)
 		require.Error(t, err)
 		require.Nil(t, res)
 	})
 }
 
+func TestCakeRepository_CountAll(t *testing.T) {
+	kit, closer := initializeRepoTestKit(t)
+	defer closer()
+	mock := kit.dbmock
+
+	repo := cakeRepository{
+		db: kit.db,
+	}
+
+	ctx := context.TODO()
+
+	t.Run("ok", func(t *testing.T) {
+		mock.ExpectQuery("SELECT COUNT\\(id\\) FROM cakes WHERE deleted_at IS null AND rating >= \\?").
+			WithArgs(float32(4)).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(12))
+
+		res, err := repo.CountAll(ctx, model.CakeQuery{MinRating: 4})
+		require.NoError(t, err)
+		assert.Equal(t, int64(12), res)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		mock.ExpectQuery("SELECT COUNT\\(id\\) FROM cakes").
+			WillReturnError(errors.New("invalid db"))
+
+		_, err := repo.CountAll(ctx, model.CakeQuery{})
+		require.Error(t, err)
+	})
+}
+
 func TestCakeRepository_FindByID(t *testing.T) {
 	cake := &model.Cake{
 		Id:          1,
@@ -186,6 +354,7 @@ func TestCakeRepository_FindByID(t *testing.T) {
 		Description: "Desc test",
 		Rating:      5.5,
 		Image:       "test image",
+		Version:     1,
 		CreatedAt:   time.Now(),
 		UpdatedAt:   time.Now(),
 		DeletedAt:   nil,
@@ -202,9 +371,9 @@ func TestCakeRepository_FindByID(t *testing.T) {
 		}
 
 		ctx := context.TODO()
-		resRows := sqlmock.NewRows([]string{"id", "title", "description", "rating", "image", "created_at", "updated_at", "deleted_at"}).
-			AddRow(cake.Id, cake.Title, cake.Description, cake.Rating, cake.Image, cake.CreatedAt, cake.UpdatedAt, cake.DeletedAt)
-		mock.ExpectQuery("SELECT \\* FROM cakes").
+		resRows := sqlmock.NewRows([]string{"id", "title", "description", "rating", "rating_mean", "rating_count", "image", "version", "created_at", "updated_at", "deleted_at"}).
+			AddRow(cake.Id, cake.Title, cake.Description, cake.Rating, cake.RatingMean, cake.RatingCount, cake.Image, cake.Version, cake.CreatedAt, cake.UpdatedAt, cake.DeletedAt)
+		mock.ExpectQuery("SELECT (.+) FROM cakes").
 			WithArgs(cake.Id).
 			WillReturnRows(resRows)
 
@@ -212,7 +381,7 @@ func TestCakeRepository_FindByID(t *testing.T) {
 		require.NoError(t, err)
 		require.NotNil(t, res)
 
-		cache := kit.miniredis.Exists(fmt.Sprintf("cake:%d", cake.Id))
+		cache := kit.miniredis.Exists(cakeKey(cake.Id))
 		require.True(t, cache)
 	})
 
@@ -227,8 +396,8 @@ func TestCakeRepository_FindByID(t *testing.T) {
 		}
 
 		ctx := context.TODO()
-		resRows := sqlmock.NewRows([]string{"id", "title", "description", "rating", "image", "created_at", "updated_at", "deleted_at"})
-		mock.ExpectQuery("SELECT \\* FROM cakes").
+		resRows := sqlmock.NewRows([]string{"id", "title", "description", "rating", "rating_mean", "rating_count", "image", "version", "created_at", "updated_at", "deleted_at"})
+		mock.ExpectQuery("SELECT (.+) FROM cakes").
 			WithArgs(cake.Id).
 			WillReturnRows(resRows)
 
@@ -249,8 +418,8 @@ func TestCakeRepository_FindByID(t *testing.T) {
 		}
 
 		ctx := context.TODO()
-		resRows := sqlmock.NewRows([]string{"id", "title", "description", "rating", "image", "created_at", "updated_at", "deleted_at"})
-		mock.ExpectQuery("SELECT \\* FROM cakes").
+		resRows := sqlmock.NewRows([]string{"id", "title", "description", "rating", "rating_mean", "rating_count", "image", "version", "created_at", "updated_at", "deleted_at"})
+		mock.ExpectQuery("SELECT (.+) FROM cakes").
 			WithArgs(cake.Id).
 			WillReturnRows(resRows)
 
@@ -258,9 +427,7 @@ func TestCakeRepository_FindByID(t *testing.T) {
 		require.NoError(t, err)
 		require.Nil(t, res)
 
-		cache, err := kit.miniredis.Get(fmt.Sprintf("cake:%d", cake.Id))
-		require.Error(t, err)
-		require.Equal(t, "", cache)
+		require.False(t, kit.miniredis.Exists(cakeKey(cake.Id)))
 	})
 
 	t.Run("err db", func(t *testing.T) {
@@ -274,7 +441,7 @@ func TestCakeRepository_FindByID(t *testing.T) {
 		}
 
 		ctx := context.TODO()
-		mock.ExpectQuery("SELECT \\* FROM cakes").
+		mock.ExpectQuery("SELECT (.+) FROM cakes").
 			WithArgs(cake.Id).
 			WillReturnError(errors.New("err db"))
 
@@ -283,3 +450,284 @@ func TestCakeRepository_FindByID(t *testing.T) {
 		require.Nil(t, res)
 	})
 }
+
+func TestCakeRepository_FindByIdInStore(t *testing.T) {
+	kit, closer := initializeRepoTestKit(t)
+	defer closer()
+	mock := kit.dbmock
+
+	repo := cakeRepository{
+		db:    kit.db,
+		redis: kit.redis,
+	}
+
+	ctx := context.TODO()
+	cakeColumns := []string{"id", "title", "description", "rating", "rating_mean", "rating_count", "image", "version", "created_at", "updated_at", "deleted_at"}
+
+	t.Run("ok - retrieve from db", func(t *testing.T) {
+		mock.ExpectQuery("SELECT (.+) FROM cakes").
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows(cakeColumns).AddRow(1, "Kue Test", "Desc test", 5.5, 5.5, 3, "test image", 1, time.Now(), time.Now(), nil))
+		mock.ExpectQuery("SELECT available FROM store_cakes WHERE store_id = \\? AND cake_id = \\?").
+			WithArgs(2, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"available"}).AddRow(false))
+
+		res, err := repo.FindByIdInStore(ctx, 1, 2)
+		require.NoError(t, err)
+		require.NotNil(t, res.Available)
+		assert.False(t, *res.Available)
+
+		assert.NotEmpty(t, kit.miniredis.HGet(cakeKey(1), "2"))
+	})
+
+	t.Run("ok - retrieve from cache", func(t *testing.T) {
+		res, err := repo.FindByIdInStore(ctx, 1, 2)
+		require.NoError(t, err)
+		require.NotNil(t, res.Available)
+		assert.False(t, *res.Available)
+	})
+
+	t.Run("ok - no store setting", func(t *testing.T) {
+		mock.ExpectQuery("SELECT (.+) FROM cakes").
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows(cakeColumns).AddRow(1, "Kue Test", "Desc test", 5.5, 5.5, 3, "test image", 1, time.Now(), time.Now(), nil))
+		mock.ExpectQuery("SELECT available FROM store_cakes").
+			WithArgs(3, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"available"}))
+
+		res, err := repo.FindByIdInStore(ctx, 1, 3)
+		require.NoError(t, err)
+		require.NotNil(t, res.Available)
+		assert.True(t, *res.Available)
+	})
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCakeRepository_FindByIdUnscoped(t *testing.T) {
+	kit, closer := initializeRepoTestKit(t)
+	defer closer()
+	mock := kit.dbmock
+
+	repo := cakeRepository{
+		db:    kit.db,
+		redis: kit.redis,
+	}
+
+	ctx := context.TODO()
+
+	t.Run("ok - deleted cake", func(t *testing.T) {
+		resRows := sqlmock.NewRows([]string{"id", "title", "description", "rating", "rating_mean", "rating_count", "image", "version", "created_at", "updated_at", "deleted_at"}).
+			AddRow(1, "Kue Test", "Desc test", 5.5, 5.5, 3, "test image", 2, time.Now(), time.Now(), time.Now())
+		mock.ExpectQuery("SELECT (.+) FROM cakes where id = \\?$").
+			WithArgs(1).
+			WillReturnRows(resRows)
+
+		res, err := repo.FindByIdUnscoped(ctx, 1)
+		require.NoError(t, err)
+		require.NotNil(t, res)
+		assert.NotNil(t, res.DeletedAt)
+		assert.False(t, kit.miniredis.Exists(cakeKey(1)))
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mock.ExpectQuery("SELECT (.+) FROM cakes where id = \\?$").
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		res, err := repo.FindByIdUnscoped(ctx, 1)
+		require.NoError(t, err)
+		require.Nil(t, res)
+	})
+
+	t.Run("err db", func(t *testing.T) {
+		mock.ExpectQuery("SELECT (.+) FROM cakes").
+			WithArgs(1).
+			WillReturnError(errors.New("err db"))
+
+		res, err := repo.FindByIdUnscoped(ctx, 1)
+		require.Error(t, err)
+		require.Nil(t, res)
+	})
+}
+
+func TestCakeRepository_Restore(t *testing.T) {
+	kit, closer := initializeRepoTestKit(t)
+	defer closer()
+	mock := kit.dbmock
+
+	repo := cakeRepository{
+		db:    kit.db,
+		redis: kit.redis,
+	}
+
+	ctx := context.TODO()
+
+	cake := &model.Cake{
+		Id:        1,
+		Title:     "Kue Test",
+		Version:   2,
+		UpdatedAt: time.Now(),
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		mock.ExpectExec("UPDATE cakes SET deleted_at = NULL").
+			WithArgs(cake.UpdatedAt, cake.Id, 2).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		err := repo.Restore(ctx, cake)
+		require.NoError(t, err)
+		assert.Equal(t, 3, cake.Version)
+	})
+
+	t.Run("version conflict", func(t *testing.T) {
+		mock.ExpectExec("UPDATE cakes SET deleted_at = NULL").
+			WithArgs(cake.UpdatedAt, cake.Id, 3).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		err := repo.Restore(ctx, cake)
+		require.Equal(t, constant.ErrVersionConflict, err)
+	})
+
+	t.Run("failed to restore cake", func(t *testing.T) {
+		mock.ExpectExec("UPDATE cakes SET deleted_at = NULL").
+			WillReturnError(errors.New("db error"))
+		err := repo.Restore(ctx, cake)
+		require.Error(t, err)
+	})
+}
+
+func TestCakeRepository_Purge(t *testing.T) {
+	kit, closer := initializeRepoTestKit(t)
+	defer closer()
+	mock := kit.dbmock
+
+	repo := cakeRepository{
+		db:    kit.db,
+		redis: kit.redis,
+	}
+
+	ctx := context.TODO()
+
+	cake := &model.Cake{
+		Id:      1,
+		Title:   "Kue Test",
+		Version: 2,
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		kit.miniredis.HSet(cakeKey(cake.Id), "0", "{}")
+		mock.ExpectExec("DELETE FROM cakes WHERE id = \\? AND version = \\?").
+			WithArgs(cake.Id, 2).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		err := repo.Purge(ctx, cake)
+		require.NoError(t, err)
+		assert.False(t, kit.miniredis.Exists(cakeKey(cake.Id)))
+		assert.Equal(t, 2, cake.Version)
+	})
+
+	t.Run("failed to purge cake", func(t *testing.T) {
+		mock.ExpectExec("DELETE FROM cakes").
+			WillReturnError(errors.New("db error"))
+		err := repo.Purge(ctx, cake)
+		require.Error(t, err)
+	})
+}
+
+func TestCakeRepository_CountDeletedBefore(t *testing.T) {
+	kit, closer := initializeRepoTestKit(t)
+	defer closer()
+	mock := kit.dbmock
+
+	repo := cakeRepository{
+		db: kit.db,
+	}
+
+	ctx := context.TODO()
+	before := time.Now()
+
+	t.Run("ok", func(t *testing.T) {
+		mock.ExpectQuery("SELECT COUNT\\(id\\) FROM cakes WHERE deleted_at IS NOT null AND deleted_at < \\?").
+			WithArgs(before).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(7))
+
+		res, err := repo.CountDeletedBefore(ctx, before)
+		require.NoError(t, err)
+		assert.Equal(t, int64(7), res)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		mock.ExpectQuery("SELECT COUNT\\(id\\) FROM cakes").
+			WillReturnError(errors.New("invalid db"))
+
+		_, err := repo.CountDeletedBefore(ctx, before)
+		require.Error(t, err)
+	})
+}
+
+func TestCakeRepository_PurgeDeletedBefore(t *testing.T) {
+	kit, closer := initializeRepoTestKit(t)
+	defer closer()
+	mock := kit.dbmock
+
+	repo := cakeRepository{
+		db: kit.db,
+	}
+
+	ctx := context.TODO()
+	before := time.Now()
+
+	t.Run("ok", func(t *testing.T) {
+		mock.ExpectExec("DELETE FROM cakes WHERE deleted_at IS NOT null AND deleted_at < \\? ORDER BY id LIMIT \\?").
+			WithArgs(before, 100).
+			WillReturnResult(sqlmock.NewResult(0, 42))
+
+		res, err := repo.PurgeDeletedBefore(ctx, before, 100)
+		require.NoError(t, err)
+		assert.Equal(t, int64(42), res)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		mock.ExpectExec("DELETE FROM cakes").
+			WillReturnError(errors.New("invalid db"))
+
+		_, err := repo.PurgeDeletedBefore(ctx, before, 100)
+		require.Error(t, err)
+	})
+}
+
+func TestCakeRepository_Touch(t *testing.T) {
+	kit, closer := initializeRepoTestKit(t)
+	defer closer()
+	mock := kit.dbmock
+
+	repo := cakeRepository{
+		db:    kit.db,
+		redis: kit.redis,
+	}
+
+	ctx := context.TODO()
+
+	t.Run("ok", func(t *testing.T) {
+		mock.ExpectExec("UPDATE cakes SET version = version \\+ 1, updated_at = \\? WHERE id IN \\(\\?,\\?\\)").
+			WithArgs(sqlmock.AnyArg(), 1, 2).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		kit.miniredis.HSet(cakeKey(1), "0", "{}")
+		kit.miniredis.HSet(cakeKey(2), "2", "{}")
+		err := repo.Touch(ctx, 1, 2)
+		require.NoError(t, err)
+		assert.False(t, kit.miniredis.Exists(cakeKey(1)))
+		assert.False(t, kit.miniredis.Exists(cakeKey(2)))
+	})
+
+	t.Run("ok - no cake", func(t *testing.T) {
+		err := repo.Touch(ctx)
+		require.NoError(t, err)
+	})
+
+	t.Run("failed to touch cakes", func(t *testing.T) {
+		mock.ExpectExec("UPDATE cakes").
+			WithArgs(sqlmock.AnyArg(), 1).
+			WillReturnError(errors.New("db error"))
+		err := repo.Touch(ctx, 1)
+		require.Error(t, err)
+	})
+}
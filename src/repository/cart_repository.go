@@ -0,0 +1,210 @@
+package repository
+
+import (
+	"cake-store/src/config"
+	"cake-store/src/constant"
+	"cake-store/src/model"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/sirupsen/logrus"
+)
+
+// cartUpdateAttempts is the number of times a cart edit is tried while the cart keep changing concurrently
+const cartUpdateAttempts = 5
+
+type cartRepository struct {
+	redis *redis.Client
+}
+
+func NewCartRepository(redis *redis.Client) model.CartRepository {
+	return &cartRepository{
+		redis: redis,
+	}
+}
+
+// Save store the cart and restart its ttl
+func (c *cartRepository) Save(ctx context.Context, cart *model.Cart) error {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Save Cart Repository",
+		"cart":    cart,
+	})
+
+	ttl := config.CartTTL()
+	cart.UpdatedAt = time.Now()
+	cart.ExpiresAt = cart.UpdatedAt.Add(ttl)
+
+	value, err := json.Marshal(cart)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	if err = c.redis.Set(ctx, cartKey(cart.Id), value, ttl).Err(); err != nil {
+		log.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// Update watch the cart while the edit run and store the result only when the cart did not change meanwhile,
+// return nil when the cart does not exist or expired
+func (c *cartRepository) Update(ctx context.Context, id string, edit func(cart *model.Cart) error) (*model.Cart, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Update Cart Repository",
+		"id":      id,
+	})
+
+	ttl := config.CartTTL()
+	var cart *model.Cart
+	update := func(tx *redis.Tx) error {
+		cart = nil
+		stored, err := decodeCart(tx.Get(ctx, cartKey(id)))
+		if err == redis.Nil {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if err = edit(stored); err != nil {
+			return err
+		}
+
+		stored.UpdatedAt = time.Now()
+		stored.ExpiresAt = stored.UpdatedAt.Add(ttl)
+		value, err := json.Marshal(stored)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.Set(ctx, cartKey(id), value, ttl)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		cart = stored
+		return nil
+	}
+
+	for attempt := 0; attempt < cartUpdateAttempts; attempt++ {
+		err := c.redis.Watch(ctx, update, cartKey(id))
+		if err == redis.TxFailedErr {
+			continue
+		}
+		if err != nil {
+			log.Error(err)
+			return nil, err
+		}
+		return cart, nil
+	}
+
+	log.Error(constant.ErrVersionConflict)
+	return nil, constant.ErrVersionConflict
+}
+
+// FindById find the cart and restart its ttl, return nil when the cart does not exist or expired
+func (c *cartRepository) FindById(ctx context.Context, id string) (*model.Cart, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Find By ID Cart Repository",
+		"id":      id,
+	})
+
+	ttl := config.CartTTL()
+	var get *redis.StringCmd
+	_, err := c.redis.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		get = pipe.Get(ctx, cartKey(id))
+		pipe.Expire(ctx, cartKey(id), ttl)
+		return nil
+	})
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	cart, err := decodeCart(get)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	cart.ExpiresAt = time.Now().Add(ttl)
+	return cart, nil
+}
+
+func (c *cartRepository) Delete(ctx context.Context, id string) error {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Delete Cart Repository",
+		"id":      id,
+	})
+
+	deleted, err := c.redis.Del(ctx, cartKey(id)).Result()
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	if deleted == 0 {
+		log.Error(constant.ErrNotFound)
+		return constant.ErrNotFound
+	}
+
+	return nil
+}
+
+// Claim take the cart out of redis in one step so only one checkout get it, return nil when the cart does not exist
+func (c *cartRepository) Claim(ctx context.Context, id string) (*model.Cart, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Claim Cart Repository",
+		"id":      id,
+	})
+
+	var get *redis.StringCmd
+	_, err := c.redis.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		get = pipe.Get(ctx, cartKey(id))
+		pipe.Del(ctx, cartKey(id))
+		return nil
+	})
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	cart, err := decodeCart(get)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return cart, nil
+}
+
+func decodeCart(get *redis.StringCmd) (*model.Cart, error) {
+	value, err := get.Bytes()
+	if err != nil {
+		return nil, err
+	}
+
+	cart := &model.Cart{}
+	if err := json.Unmarshal(value, cart); err != nil {
+		return nil, err
+	}
+	return cart, nil
+}
+
+func cartKey(id string) string {
+	return fmt.Sprintf("cart:%s", id)
+}
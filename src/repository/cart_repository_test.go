@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"cake-store/src/constant"
+	"cake-store/src/model"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCartRepository(t *testing.T) {
+	kit, closer := initializeRepoTestKit(t)
+	defer closer()
+
+	repo := cartRepository{
+		redis: kit.redis,
+	}
+
+	ctx := context.TODO()
+	cart := &model.Cart{
+		Id:    "abc",
+		Lines: []*model.CartLine{{Id: 1, CakeId: 1, VariantId: 5, Quantity: 2, Message: "Happy Birthday"}},
+	}
+
+	t.Run("save and find", func(t *testing.T) {
+		require.NoError(t, repo.Save(ctx, cart))
+		assert.True(t, kit.miniredis.TTL("cart:abc") > 0)
+
+		res, err := repo.FindById(ctx, "abc")
+		require.NoError(t, err)
+		assert.Equal(t, cart.Lines, res.Lines)
+	})
+
+	t.Run("sliding ttl", func(t *testing.T) {
+		kit.miniredis.FastForward(time.Hour)
+		ttl := kit.miniredis.TTL("cart:abc")
+
+		_, err := repo.FindById(ctx, "abc")
+		require.NoError(t, err)
+		assert.Greater(t, kit.miniredis.TTL("cart:abc"), ttl)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		res, err := repo.Update(ctx, "abc", func(cart *model.Cart) error {
+			cart.Lines[0].Quantity = 3
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 3, res.Lines[0].Quantity)
+
+		res, err = repo.FindById(ctx, "abc")
+		require.NoError(t, err)
+		assert.Equal(t, 3, res.Lines[0].Quantity)
+	})
+
+	t.Run("update - retried on a concurrent change", func(t *testing.T) {
+		attempts := 0
+		res, err := repo.Update(ctx, "abc", func(cart *model.Cart) error {
+			attempts++
+			if attempts == 1 {
+				concurrent := *cart
+				concurrent.Lines = append(concurrent.Lines, &model.CartLine{Id: 2, CakeId: 2, VariantId: 7, Quantity: 1})
+				require.NoError(t, repo.Save(ctx, &concurrent))
+			}
+			cart.Lines[0].Quantity = 4
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 2, attempts)
+		require.Len(t, res.Lines, 2)
+		assert.Equal(t, 4, res.Lines[0].Quantity)
+	})
+
+	t.Run("update - edit error keep the cart", func(t *testing.T) {
+		res, err := repo.Update(ctx, "abc", func(cart *model.Cart) error {
+			cart.Lines = nil
+			return constant.ErrInvalidArgument
+		})
+		assert.Equal(t, constant.ErrInvalidArgument, err)
+		assert.Nil(t, res)
+
+		res, err = repo.FindById(ctx, "abc")
+		require.NoError(t, err)
+		assert.Len(t, res.Lines, 2)
+	})
+
+	t.Run("update - not found", func(t *testing.T) {
+		res, err := repo.Update(ctx, "missing", func(cart *model.Cart) error {
+			t.Fatal("edit of a missing cart")
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		res, err := repo.FindById(ctx, "missing")
+		require.NoError(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("claim once", func(t *testing.T) {
+		res, err := repo.Claim(ctx, "abc")
+		require.NoError(t, err)
+		assert.Len(t, res.Lines, 2)
+
+		res, err = repo.Claim(ctx, "abc")
+		require.NoError(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		require.NoError(t, repo.Save(ctx, cart))
+		require.NoError(t, repo.Delete(ctx, "abc"))
+		assert.Equal(t, constant.ErrNotFound, repo.Delete(ctx, "abc"))
+	})
+}
@@ -0,0 +1,249 @@
+package repository
+
+import (
+	"cake-store/src/model"
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/sirupsen/logrus"
+)
+
+// redeemScript count one more use of the coupon only when it is under its limit, a zero limit is unlimited,
+// it return -1 when the limit is reached
+var redeemScript = redis.NewScript(`
+local used = tonumber(redis.call("GET", KEYS[1]) or "0")
+if tonumber(ARGV[1]) > 0 and used >= tonumber(ARGV[1]) then
+	return -1
+end
+return redis.call("INCR", KEYS[1])
+`)
+
+// releaseScript give back one use of the coupon, the count never goes below zero
+var releaseScript = redis.NewScript(`
+if tonumber(redis.call("GET", KEYS[1]) or "0") > 0 then
+	return redis.call("DECR", KEYS[1])
+end
+return 0
+`)
+
+type couponRepository struct {
+	db    *sql.DB
+	redis *redis.Client
+}
+
+func NewCouponRepository(db *sql.DB, redis *redis.Client) model.CouponRepository {
+	return &couponRepository{
+		db:    db,
+		redis: redis,
+	}
+}
+
+func (c *couponRepository) Save(ctx context.Context, coupon *model.Coupon) error {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Save Coupon Repository",
+		"coupon":  coupon,
+	})
+
+	query := "INSERT INTO coupons(code,type,value,buy_quantity,get_quantity,category_id,min_subtotal,starts_at,ends_at,usage_limit,stackable,active,created_at,updated_at) " +
+		"VALUES (?,?,?,?,?,?,?,?,?,?,?,?,?,?)"
+	res, err := c.db.ExecContext(ctx, query, coupon.Code, coupon.Type, coupon.Value, coupon.BuyQuantity, coupon.GetQuantity, coupon.CategoryId,
+		coupon.MinSubtotal, coupon.StartsAt, coupon.EndsAt, coupon.UsageLimit, coupon.Stackable, coupon.Active, coupon.CreatedAt, coupon.UpdatedAt)
+	if err != nil {
+		log.Error(err)
+		return duplicateErr(err)
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	coupon.Id = int(id)
+	return nil
+}
+
+func (c *couponRepository) Update(ctx context.Context, coupon *model.Coupon) error {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Update Coupon Repository",
+		"coupon":  coupon,
+	})
+
+	query := "UPDATE coupons SET code = ?, type = ?, value = ?, buy_quantity = ?, get_quantity = ?, category_id = ?, min_subtotal = ?, " +
+		"starts_at = ?, ends_at = ?, usage_limit = ?, stackable = ?, active = ?, updated_at = ? WHERE id = ?"
+	_, err := c.db.ExecContext(ctx, query, coupon.Code, coupon.Type, coupon.Value, coupon.BuyQuantity, coupon.GetQuantity, coupon.CategoryId,
+		coupon.MinSubtotal, coupon.StartsAt, coupon.EndsAt, coupon.UsageLimit, coupon.Stackable, coupon.Active, coupon.UpdatedAt, coupon.Id)
+	if err != nil {
+		log.Error(err)
+		return duplicateErr(err)
+	}
+
+	return nil
+}
+
+// Delete remove the coupon and its usage count
+func (c *couponRepository) Delete(ctx context.Context, coupon *model.Coupon) error {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Delete Coupon Repository",
+		"coupon":  coupon,
+	})
+
+	if _, err := c.db.ExecContext(ctx, "DELETE FROM coupons WHERE id = ?", coupon.Id); err != nil {
+		log.Error(err)
+		return err
+	}
+
+	if err := c.redis.Del(ctx, couponUsedKey(coupon.Id)).Err(); err != nil {
+		log.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+func (c *couponRepository) FindById(ctx context.Context, id int) (*model.Coupon, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Find By ID Coupon Repository",
+		"id":      id,
+	})
+
+	sql := "SELECT " + couponColumns + " FROM coupons WHERE id = ?"
+	coupons, err := c.findCoupons(ctx, log, sql, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(coupons) == 0 {
+		return nil, nil
+	}
+	return coupons[0], nil
+}
+
+func (c *couponRepository) FindByCodes(ctx context.Context, codes []string) ([]*model.Coupon, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Find By Codes Coupon Repository",
+		"codes":   codes,
+	})
+
+	if len(codes) == 0 {
+		return make([]*model.Coupon, 0), nil
+	}
+
+	args := make([]interface{}, 0, len(codes))
+	for _, code := range codes {
+		args = append(args, code)
+	}
+
+	sql := "SELECT " + couponColumns + " FROM coupons WHERE code IN (" + placeholders(len(codes)) + ")"
+	return c.findCoupons(ctx, log, sql, args...)
+}
+
+func (c *couponRepository) FindAll(ctx context.Context) ([]*model.Coupon, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Find All Coupon Repository",
+	})
+
+	sql := "SELECT " + couponColumns + " FROM coupons ORDER BY id DESC"
+	return c.findCoupons(ctx, log, sql)
+}
+
+// LoadUsage fill the redemption count of each coupon from redis
+func (c *couponRepository) LoadUsage(ctx context.Context, coupons []*model.Coupon) error {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Load Usage Coupon Repository",
+	})
+
+	if len(coupons) == 0 {
+		return nil
+	}
+
+	keys := make([]string, 0, len(coupons))
+	for _, coupon := range coupons {
+		keys = append(keys, couponUsedKey(coupon.Id))
+	}
+
+	values, err := c.redis.MGet(ctx, keys...).Result()
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	for i, value := range values {
+		coupons[i].Used = 0
+		if s, ok := value.(string); ok {
+			if coupons[i].Used, err = strconv.Atoi(s); err != nil {
+				log.Error(err)
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Redeem count one use of the coupon atomically, return false when the usage limit is already reached
+func (c *couponRepository) Redeem(ctx context.Context, coupon *model.Coupon) (bool, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Redeem Coupon Repository",
+		"coupon":  coupon,
+	})
+
+	used, err := redeemScript.Run(ctx, c.redis, []string{couponUsedKey(coupon.Id)}, coupon.UsageLimit).Int()
+	if err != nil {
+		log.Error(err)
+		return false, err
+	}
+
+	if used < 0 {
+		return false, nil
+	}
+
+	coupon.Used = used
+	return true, nil
+}
+
+// Release give back one use of the coupon, e.g. when the order is not placed or cancelled
+func (c *couponRepository) Release(ctx context.Context, couponId int) error {
+	log := logrus.WithFields(logrus.Fields{
+		"message":  "Release Coupon Repository",
+		"couponId": couponId,
+	})
+
+	if err := releaseScript.Run(ctx, c.redis, []string{couponUsedKey(couponId)}).Err(); err != nil {
+		log.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+func (c *couponRepository) findCoupons(ctx context.Context, log *logrus.Entry, sql string, args ...interface{}) ([]*model.Coupon, error) {
+	rows, err := c.db.QueryContext(ctx, sql, args...)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	coupons := make([]*model.Coupon, 0)
+	for rows.Next() {
+		coupon := &model.Coupon{}
+		err := rows.Scan(&coupon.Id, &coupon.Code, &coupon.Type, &coupon.Value, &coupon.BuyQuantity, &coupon.GetQuantity, &coupon.CategoryId,
+			&coupon.MinSubtotal, &coupon.StartsAt, &coupon.EndsAt, &coupon.UsageLimit, &coupon.Stackable, &coupon.Active, &coupon.CreatedAt, &coupon.UpdatedAt)
+		if err != nil {
+			log.Error(err)
+			return nil, err
+		}
+		coupons = append(coupons, coupon)
+	}
+
+	return coupons, nil
+}
+
+func couponUsedKey(couponId int) string {
+	return fmt.Sprintf("coupon:used:%d", couponId)
+}
+
+const couponColumns = "id, code, type, value, buy_quantity, get_quantity, category_id, min_subtotal, starts_at, ends_at, usage_limit, stackable, active, created_at, updated_at"
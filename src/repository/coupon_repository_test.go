@@ -0,0 +1,148 @@
+package repository
+
+import (
+	"cake-store/src/constant"
+	"cake-store/src/model"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var couponRowColumns = []string{"id", "code", "type", "value", "buy_quantity", "get_quantity", "category_id", "min_subtotal",
+	"starts_at", "ends_at", "usage_limit", "stackable", "active", "created_at", "updated_at"}
+
+func TestCouponRepository_Save(t *testing.T) {
+	kit, closer := initializeRepoTestKit(t)
+	defer closer()
+	mock := kit.dbmock
+
+	repo := couponRepository{
+		db:    kit.db,
+		redis: kit.redis,
+	}
+
+	ctx := context.TODO()
+	now := time.Now()
+	coupon := &model.Coupon{Code: "HEMAT10", Type: model.CouponTypePercentage, Value: 10, UsageLimit: 100, Active: true, CreatedAt: now, UpdatedAt: now}
+
+	t.Run("ok", func(t *testing.T) {
+		mock.ExpectExec("INSERT INTO coupons").
+			WithArgs("HEMAT10", model.CouponTypePercentage, int64(10), 0, 0, 0, int64(0), nil, nil, 100, false, true, now, now).
+			WillReturnResult(sqlmock.NewResult(7, 1))
+
+		err := repo.Save(ctx, coupon)
+		require.NoError(t, err)
+		assert.Equal(t, 7, coupon.Id)
+	})
+
+	t.Run("duplicate code", func(t *testing.T) {
+		mock.ExpectExec("INSERT INTO coupons").
+			WillReturnError(&mysql.MySQLError{Number: 1062, Message: "Duplicate entry"})
+
+		err := repo.Save(ctx, coupon)
+		assert.Equal(t, constant.ErrAlreadyExists, err)
+	})
+}
+
+func TestCouponRepository_FindByCodes(t *testing.T) {
+	kit, closer := initializeRepoTestKit(t)
+	defer closer()
+	mock := kit.dbmock
+
+	repo := couponRepository{
+		db:    kit.db,
+		redis: kit.redis,
+	}
+
+	ctx := context.TODO()
+	endsAt := time.Now().Add(time.Hour)
+
+	t.Run("ok", func(t *testing.T) {
+		mock.ExpectQuery("SELECT (.+) FROM coupons WHERE code IN \\(\\?,\\?\\)").
+			WithArgs("HEMAT10", "B2G1").
+			WillReturnRows(sqlmock.NewRows(couponRowColumns).
+				AddRow(7, "HEMAT10", "percentage", 10, 0, 0, 0, 0, nil, endsAt, 100, true, true, time.Now(), time.Now()).
+				AddRow(8, "B2G1", "buy_x_get_y", 0, 2, 1, 3, 0, nil, nil, 0, false, true, time.Now(), time.Now()))
+
+		res, err := repo.FindByCodes(ctx, []string{"HEMAT10", "B2G1"})
+		require.NoError(t, err)
+		require.Len(t, res, 2)
+		assert.Nil(t, res[0].StartsAt)
+		require.NotNil(t, res[0].EndsAt)
+		assert.True(t, endsAt.Equal(*res[0].EndsAt))
+		assert.Equal(t, 3, res[1].CategoryId)
+	})
+
+	t.Run("no codes", func(t *testing.T) {
+		res, err := repo.FindByCodes(ctx, nil)
+		require.NoError(t, err)
+		assert.Empty(t, res)
+	})
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCouponRepository_Usage(t *testing.T) {
+	kit, closer := initializeRepoTestKit(t)
+	defer closer()
+
+	repo := couponRepository{
+		db:    kit.db,
+		redis: kit.redis,
+	}
+
+	ctx := context.TODO()
+	limited := &model.Coupon{Id: 7, UsageLimit: 2}
+	unlimited := &model.Coupon{Id: 8}
+
+	t.Run("redeem until the limit", func(t *testing.T) {
+		for i := 1; i <= 2; i++ {
+			ok, err := repo.Redeem(ctx, limited)
+			require.NoError(t, err)
+			assert.True(t, ok)
+			assert.Equal(t, i, limited.Used)
+		}
+
+		ok, err := repo.Redeem(ctx, limited)
+		require.NoError(t, err)
+		assert.False(t, ok)
+	})
+
+	t.Run("unlimited", func(t *testing.T) {
+		for i := 0; i < 5; i++ {
+			ok, err := repo.Redeem(ctx, unlimited)
+			require.NoError(t, err)
+			assert.True(t, ok)
+		}
+	})
+
+	t.Run("load usage", func(t *testing.T) {
+		coupons := []*model.Coupon{{Id: 7}, {Id: 8}, {Id: 9}}
+		require.NoError(t, repo.LoadUsage(ctx, coupons))
+		assert.Equal(t, 2, coupons[0].Used)
+		assert.Equal(t, 5, coupons[1].Used)
+		assert.Equal(t, 0, coupons[2].Used)
+	})
+
+	t.Run("release", func(t *testing.T) {
+		require.NoError(t, repo.Release(ctx, 7))
+
+		ok, err := repo.Redeem(ctx, limited)
+		require.NoError(t, err)
+		assert.True(t, ok)
+	})
+
+	t.Run("release never below zero", func(t *testing.T) {
+		require.NoError(t, repo.Release(ctx, 9))
+		require.NoError(t, repo.Release(ctx, 9))
+
+		coupons := []*model.Coupon{{Id: 9}}
+		require.NoError(t, repo.LoadUsage(ctx, coupons))
+		assert.Equal(t, 0, coupons[0].Used)
+	})
+}
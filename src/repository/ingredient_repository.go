@@ -0,0 +1,349 @@
+package repository
+
+import (
+	"cake-store/src/model"
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+type ingredientRepository struct {
+	db *sql.DB
+}
+
+func NewIngredientRepository(db *sql.DB) model.IngredientRepository {
+	return &ingredientRepository{
+		db: db,
+	}
+}
+
+func (i *ingredientRepository) Save(ctx context.Context, ingredient *model.Ingredient) error {
+	log := logrus.WithFields(logrus.Fields{
+		"message":    "Save Ingredient Repository",
+		"ingredient": ingredient,
+	})
+
+	n := ingredient.Nutrition
+	query := "INSERT INTO ingredients(name,allergens,density,energy_kcal,fat,carbohydrate,sugar,protein,salt,created_at,updated_at) VALUES (?,?,?,?,?,?,?,?,?,?,?)"
+	res, err := i.db.ExecContext(ctx, query, ingredient.Name, strings.Join(ingredient.Allergens, ","), ingredient.Density, n.EnergyKcal, n.Fat, n.Carbohydrate,
+		n.Sugar, n.Protein, n.Salt, ingredient.CreatedAt, ingredient.UpdatedAt)
+	if err != nil {
+		log.Error(err)
+		return duplicateErr(err)
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	ingredient.Id = int(id)
+	return nil
+}
+
+func (i *ingredientRepository) Update(ctx context.Context, ingredient *model.Ingredient) error {
+	log := logrus.WithFields(logrus.Fields{
+		"message":    "Update Ingredient Repository",
+		"ingredient": ingredient,
+	})
+
+	n := ingredient.Nutrition
+	query := "UPDATE ingredients SET name = ?, allergens = ?, density = ?, energy_kcal = ?, fat = ?, carbohydrate = ?, sugar = ?, protein = ?, salt = ?, " +
+		"updated_at = ? WHERE id = ?"
+	_, err := i.db.ExecContext(ctx, query, ingredient.Name, strings.Join(ingredient.Allergens, ","), ingredient.Density, n.EnergyKcal, n.Fat, n.Carbohydrate,
+		n.Sugar, n.Protein, n.Salt, ingredient.UpdatedAt, ingredient.Id)
+	if err != nil {
+		log.Error(err)
+		return duplicateErr(err)
+	}
+
+	return nil
+}
+
+func (i *ingredientRepository) Delete(ctx context.Context, ingredient *model.Ingredient) error {
+	log := logrus.WithFields(logrus.Fields{
+		"message":    "Delete Ingredient Repository",
+		"ingredient": ingredient,
+	})
+
+	if _, err := i.db.ExecContext(ctx, "DELETE FROM ingredients WHERE id = ?", ingredient.Id); err != nil {
+		log.Error(err)
+		return inUseErr(err)
+	}
+
+	return nil
+}
+
+func (i *ingredientRepository) FindAll(ctx context.Context) ([]*model.Ingredient, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Find All Ingredient Repository",
+	})
+
+	sql := "SELECT " + ingredientColumns + " FROM ingredients ORDER BY name ASC"
+	return i.findIngredients(ctx, log, sql)
+}
+
+func (i *ingredientRepository) FindById(ctx context.Context, id int) (*model.Ingredient, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Find By ID Ingredient Repository",
+		"id":      id,
+	})
+
+	sql := "SELECT " + ingredientColumns + " FROM ingredients WHERE id = ?"
+	ingredients, err := i.findIngredients(ctx, log, sql, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ingredients) == 0 {
+		return nil, nil
+	}
+	return ingredients[0], nil
+}
+
+func (i *ingredientRepository) FindByIds(ctx context.Context, ids []int) ([]*model.Ingredient, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Find By IDs Ingredient Repository",
+		"ids":     ids,
+	})
+
+	if len(ids) == 0 {
+		return make([]*model.Ingredient, 0), nil
+	}
+
+	sql := "SELECT " + ingredientColumns + " FROM ingredients WHERE id IN (" + placeholders(len(ids)) + ") ORDER BY name ASC"
+	return i.findIngredients(ctx, log, sql, intArgs(ids)...)
+}
+
+// FindCakeIds find the id of the cakes listing the ingredient
+func (i *ingredientRepository) FindCakeIds(ctx context.Context, ingredientId int) ([]int, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message":      "Find Cake IDs Ingredient Repository",
+		"ingredientId": ingredientId,
+	})
+
+	sql := "SELECT cake_id FROM cake_ingredients WHERE ingredient_id = ?"
+	rows, err := i.db.QueryContext(ctx, sql, ingredientId)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := make([]int, 0)
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			log.Error(err)
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// SetCakeIngredients replace the ingredient list of the cake
+func (i *ingredientRepository) SetCakeIngredients(ctx context.Context, cakeId int, ingredients []*model.CakeIngredient) error {
+	log := logrus.WithFields(logrus.Fields{
+		"message":     "Set Cake Ingredients Ingredient Repository",
+		"cakeId":      cakeId,
+		"ingredients": ingredients,
+	})
+
+	tx, err := i.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err = tx.ExecContext(ctx, "DELETE FROM cake_ingredients WHERE cake_id = ?", cakeId); err != nil {
+		log.Error(err)
+		return err
+	}
+
+	if len(ingredients) > 0 {
+		values := make([]string, 0, len(ingredients))
+		args := make([]interface{}, 0, len(ingredients)*3)
+		for _, ingredient := range ingredients {
+			values = append(values, "(?,?,?)")
+			args = append(args, cakeId, ingredient.IngredientId, ingredient.Quantity)
+		}
+
+		query := "INSERT INTO cake_ingredients(cake_id,ingredient_id,quantity) VALUES " + strings.Join(values, ",")
+		if _, err = tx.ExecContext(ctx, query, args...); err != nil {
+			log.Error(err)
+			return err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		log.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// LoadCakes embed the ingredients of each cake, heaviest first as they are declared on a label
+func (i *ingredientRepository) LoadCakes(ctx context.Context, cakes []*model.Cake) error {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Load Cakes Ingredient Repository",
+	})
+
+	if len(cakes) == 0 {
+		return nil
+	}
+
+	ids := model.CakeIds(cakes)
+	sql := "SELECT ci.cake_id, ci.quantity, i.id, i.name, i.allergens, i.energy_kcal, i.fat, i.carbohydrate, i.sugar, i.protein, i.salt " +
+		"FROM cake_ingredients ci JOIN ingredients i ON i.id = ci.ingredient_id WHERE ci.cake_id IN (" + placeholders(len(ids)) + ") " +
+		"ORDER BY ci.quantity DESC, i.name ASC"
+	rows, err := i.db.QueryContext(ctx, sql, intArgs(ids)...)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+	defer rows.Close()
+
+	ingredients := make(map[int][]*model.CakeIngredient)
+	for rows.Next() {
+		var (
+			cakeId    int
+			allergens string
+		)
+		ingredient := &model.CakeIngredient{}
+		n := &ingredient.Nutrition
+		err := rows.Scan(&cakeId, &ingredient.Quantity, &ingredient.IngredientId, &ingredient.Name, &allergens,
+			&n.EnergyKcal, &n.Fat, &n.Carbohydrate, &n.Sugar, &n.Protein, &n.Salt)
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+		ingredient.Allergens = splitAllergens(allergens)
+		ingredients[cakeId] = append(ingredients[cakeId], ingredient)
+	}
+
+	for _, cake := range cakes {
+		cake.SetIngredients(ingredients[cake.Id])
+	}
+	return nil
+}
+
+// FindPrices find the supplier prices of the ingredients
+func (i *ingredientRepository) FindPrices(ctx context.Context, ingredientIds []int) ([]*model.SupplierPrice, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message":       "Find Prices Ingredient Repository",
+		"ingredientIds": ingredientIds,
+	})
+
+	prices := make([]*model.SupplierPrice, 0)
+	if len(ingredientIds) == 0 {
+		return prices, nil
+	}
+
+	sql := "SELECT id, ingredient_id, supplier, price, currency, quantity, unit, updated_at FROM supplier_prices " +
+		"WHERE ingredient_id IN (" + placeholders(len(ingredientIds)) + ") ORDER BY ingredient_id ASC, supplier ASC"
+	rows, err := i.db.QueryContext(ctx, sql, intArgs(ingredientIds)...)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		price := &model.SupplierPrice{}
+		err := rows.Scan(&price.Id, &price.IngredientId, &price.Supplier, &price.Price, &price.Price.Currency, &price.Quantity,
+			&price.Unit, &price.UpdatedAt)
+		if err != nil {
+			log.Error(err)
+			return nil, err
+		}
+		prices = append(prices, price)
+	}
+	return prices, nil
+}
+
+// SetPrices replace the supplier prices of the ingredient
+func (i *ingredientRepository) SetPrices(ctx context.Context, ingredientId int, prices []*model.SupplierPrice) error {
+	log := logrus.WithFields(logrus.Fields{
+		"message":      "Set Prices Ingredient Repository",
+		"ingredientId": ingredientId,
+		"prices":       prices,
+	})
+
+	tx, err := i.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err = tx.ExecContext(ctx, "DELETE FROM supplier_prices WHERE ingredient_id = ?", ingredientId); err != nil {
+		log.Error(err)
+		return err
+	}
+
+	for _, price := range prices {
+		query := "INSERT INTO supplier_prices(ingredient_id,supplier,price,currency,quantity,unit,updated_at) VALUES (?,?,?,?,?,?,?)"
+		res, err := tx.ExecContext(ctx, query, ingredientId, price.Supplier, price.Price, price.Price.Currency, price.Quantity,
+			price.Unit, price.UpdatedAt)
+		if err != nil {
+			log.Error(err)
+			return duplicateErr(err)
+		}
+
+		id, err := res.LastInsertId()
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+		price.Id = int(id)
+		price.IngredientId = ingredientId
+	}
+
+	if err = tx.Commit(); err != nil {
+		log.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+func (i *ingredientRepository) findIngredients(ctx context.Context, log *logrus.Entry, sql string, args ...interface{}) ([]*model.Ingredient, error) {
+	rows, err := i.db.QueryContext(ctx, sql, args...)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	ingredients := make([]*model.Ingredient, 0)
+	for rows.Next() {
+		var allergens string
+		ingredient := &model.Ingredient{}
+		n := &ingredient.Nutrition
+		err := rows.Scan(&ingredient.Id, &ingredient.Name, &allergens, &ingredient.Density, &n.EnergyKcal, &n.Fat, &n.Carbohydrate, &n.Sugar, &n.Protein, &n.Salt,
+			&ingredient.CreatedAt, &ingredient.UpdatedAt)
+		if err != nil {
+			log.Error(err)
+			return nil, err
+		}
+		ingredient.Allergens = splitAllergens(allergens)
+		ingredients = append(ingredients, ingredient)
+	}
+	return ingredients, nil
+}
+
+// splitAllergens split the stored allergens, an ingredient without allergens has an empty list
+func splitAllergens(allergens string) []string {
+	if allergens == "" {
+		return []string{}
+	}
+	return strings.Split(allergens, ",")
+}
+
+const ingredientColumns = "id, name, allergens, density, energy_kcal, fat, carbohydrate, sugar, protein, salt, created_at, updated_at"
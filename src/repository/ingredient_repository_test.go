@@ -0,0 +1,420 @@
+package repository
+
+import (
+	"cake-store/src/constant"
+	"cake-store/src/model"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var ingredientRowColumns = []string{"id", "name", "allergens", "density", "energy_kcal", "fat", "carbohydrate", "sugar", "protein", "salt", "created_at", "updated_at"}
+
+func TestIngredientRepository_Create(t *testing.T) {
+	kit, closer := initializeRepoTestKit(t)
+	defer closer()
+	mock := kit.dbmock
+
+	repo := ingredientRepository{
+		db: kit.db,
+	}
+
+	ctx := context.TODO()
+
+	ingredient := &model.Ingredient{
+		Name:      "Butter",
+		Allergens: []string{model.AllergenDairy},
+		Nutrition: model.NutritionFacts{EnergyKcal: 717, Fat: 81, Carbohydrate: 0.1, Sugar: 0.1, Protein: 0.9, Salt: 1.6},
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	n := ingredient.Nutrition
+
+	t.Run("ok", func(t *testing.T) {
+		mock.ExpectExec("INSERT INTO ingredients").
+			WithArgs(ingredient.Name, "dairy", ingredient.Density, n.EnergyKcal, n.Fat, n.Carbohydrate, n.Sugar, n.Protein, n.Salt, ingredient.CreatedAt, ingredient.UpdatedAt).
+			WillReturnResult(sqlmock.NewResult(3, 1))
+		err := repo.Save(ctx, ingredient)
+		require.NoError(t, err)
+		assert.Equal(t, 3, ingredient.Id)
+	})
+
+	t.Run("duplicate name", func(t *testing.T) {
+		mock.ExpectExec("INSERT INTO ingredients").
+			WithArgs(ingredient.Name, "dairy", ingredient.Density, n.EnergyKcal, n.Fat, n.Carbohydrate, n.Sugar, n.Protein, n.Salt, ingredient.CreatedAt, ingredient.UpdatedAt).
+			WillReturnError(&mysql.MySQLError{Number: 1062, Message: "Duplicate entry"})
+		err := repo.Save(ctx, ingredient)
+		require.Equal(t, constant.ErrAlreadyExists, err)
+	})
+}
+
+func TestIngredientRepository_Update(t *testing.T) {
+	kit, closer := initializeRepoTestKit(t)
+	defer closer()
+	mock := kit.dbmock
+
+	repo := ingredientRepository{
+		db: kit.db,
+	}
+
+	ctx := context.TODO()
+
+	ingredient := &model.Ingredient{
+		Id:        1,
+		Name:      "Milk Chocolate",
+		Allergens: []string{model.AllergenSoy, model.AllergenDairy},
+		Nutrition: model.NutritionFacts{EnergyKcal: 535, Fat: 30, Carbohydrate: 59, Sugar: 52, Protein: 8, Salt: 0.2},
+		UpdatedAt: time.Now(),
+	}
+	n := ingredient.Nutrition
+
+	t.Run("ok", func(t *testing.T) {
+		mock.ExpectExec("UPDATE ingredients").
+			WithArgs(ingredient.Name, "soy,dairy", ingredient.Density, n.EnergyKcal, n.Fat, n.Carbohydrate, n.Sugar, n.Protein, n.Salt, ingredient.UpdatedAt, ingredient.Id).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		err := repo.Update(ctx, ingredient)
+		require.NoError(t, err)
+	})
+
+	t.Run("failed to update ingredient", func(t *testing.T) {
+		mock.ExpectExec("UPDATE ingredients").
+			WithArgs(ingredient.Name, "soy,dairy", ingredient.Density, n.EnergyKcal, n.Fat, n.Carbohydrate, n.Sugar, n.Protein, n.Salt, ingredient.UpdatedAt, ingredient.Id).
+			WillReturnError(errors.New("db error"))
+		err := repo.Update(ctx, ingredient)
+		require.Error(t, err)
+	})
+}
+
+func TestIngredientRepository_Delete(t *testing.T) {
+	kit, closer := initializeRepoTestKit(t)
+	defer closer()
+	mock := kit.dbmock
+
+	repo := ingredientRepository{
+		db: kit.db,
+	}
+
+	ctx := context.TODO()
+	ingredient := &model.Ingredient{Id: 1}
+
+	t.Run("ok", func(t *testing.T) {
+		mock.ExpectExec("DELETE FROM ingredients WHERE id = \\?").
+			WithArgs(ingredient.Id).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		err := repo.Delete(ctx, ingredient)
+		require.NoError(t, err)
+	})
+
+	t.Run("still in a recipe", func(t *testing.T) {
+		mock.ExpectExec("DELETE FROM ingredients").
+			WithArgs(ingredient.Id).
+			WillReturnError(&mysql.MySQLError{Number: 1451, Message: "Cannot delete or update a parent row"})
+		err := repo.Delete(ctx, ingredient)
+		require.Equal(t, constant.ErrInUse, err)
+	})
+}
+
+func TestIngredientRepository_FindAll(t *testing.T) {
+	kit, closer := initializeRepoTestKit(t)
+	defer closer()
+	mock := kit.dbmock
+
+	repo := ingredientRepository{
+		db: kit.db,
+	}
+
+	ctx := context.TODO()
+
+	t.Run("ok", func(t *testing.T) {
+		resRows := sqlmock.NewRows(ingredientRowColumns).
+			AddRow(1, "Butter", "dairy", 0.91, 717, 81, 0.1, 0.1, 0.9, 1.6, time.Now(), time.Now()).
+			AddRow(2, "Sugar", "", 0.85, 400, 0, 100, 100, 0, 0, time.Now(), time.Now())
+
+		mock.ExpectQuery("SELECT (.+) FROM ingredients ORDER BY name ASC").
+			WillReturnRows(resRows)
+
+		res, err := repo.FindAll(ctx)
+		require.NoError(t, err)
+		require.Equal(t, 2, len(res))
+		assert.Equal(t, []string{model.AllergenDairy}, res[0].Allergens)
+		assert.Equal(t, []string{}, res[1].Allergens)
+		assert.Equal(t, 717.0, res[0].Nutrition.EnergyKcal)
+	})
+
+	t.Run("failed to find ingredients", func(t *testing.T) {
+		mock.ExpectQuery("SELECT (.+) FROM ingredients").
+			WillReturnError(errors.New("db error"))
+
+		_, err := repo.FindAll(ctx)
+		require.Error(t, err)
+	})
+}
+
+func TestIngredientRepository_FindById(t *testing.T) {
+	kit, closer := initializeRepoTestKit(t)
+	defer closer()
+	mock := kit.dbmock
+
+	repo := ingredientRepository{
+		db: kit.db,
+	}
+
+	ctx := context.TODO()
+
+	t.Run("ok", func(t *testing.T) {
+		resRows := sqlmock.NewRows(ingredientRowColumns).
+			AddRow(1, "Milk Chocolate", "soy,dairy", 0, 535, 30, 59, 52, 8, 0.2, time.Now(), time.Now())
+
+		mock.ExpectQuery("SELECT (.+) FROM ingredients WHERE id = \\?").
+			WithArgs(1).
+			WillReturnRows(resRows)
+
+		res, err := repo.FindById(ctx, 1)
+		require.NoError(t, err)
+		assert.Equal(t, []string{model.AllergenSoy, model.AllergenDairy}, res.Allergens)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mock.ExpectQuery("SELECT (.+) FROM ingredients WHERE id = \\?").
+			WithArgs(2).
+			WillReturnRows(sqlmock.NewRows(ingredientRowColumns))
+
+		res, err := repo.FindById(ctx, 2)
+		require.NoError(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestIngredientRepository_FindByIds(t *testing.T) {
+	kit, closer := initializeRepoTestKit(t)
+	defer closer()
+	mock := kit.dbmock
+
+	repo := ingredientRepository{
+		db: kit.db,
+	}
+
+	ctx := context.TODO()
+
+	t.Run("ok", func(t *testing.T) {
+		resRows := sqlmock.NewRows(ingredientRowColumns).
+			AddRow(1, "Butter", "dairy", 0.91, 717, 81, 0.1, 0.1, 0.9, 1.6, time.Now(), time.Now()).
+			AddRow(2, "Sugar", "", 0.85, 400, 0, 100, 100, 0, 0, time.Now(), time.Now())
+
+		mock.ExpectQuery("SELECT (.+) FROM ingredients WHERE id IN \\(\\?,\\?\\)").
+			WithArgs(1, 2).
+			WillReturnRows(resRows)
+
+		res, err := repo.FindByIds(ctx, []int{1, 2})
+		require.NoError(t, err)
+		assert.Equal(t, 2, len(res))
+	})
+
+	t.Run("ok - empty ids", func(t *testing.T) {
+		res, err := repo.FindByIds(ctx, nil)
+		require.NoError(t, err)
+		assert.Equal(t, 0, len(res))
+	})
+}
+
+func TestIngredientRepository_FindCakeIds(t *testing.T) {
+	kit, closer := initializeRepoTestKit(t)
+	defer closer()
+	mock := kit.dbmock
+
+	repo := ingredientRepository{
+		db: kit.db,
+	}
+
+	ctx := context.TODO()
+
+	t.Run("ok", func(t *testing.T) {
+		mock.ExpectQuery("SELECT cake_id FROM cake_ingredients WHERE ingredient_id = \\?").
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"cake_id"}).AddRow(4).AddRow(7))
+
+		res, err := repo.FindCakeIds(ctx, 1)
+		require.NoError(t, err)
+		assert.Equal(t, []int{4, 7}, res)
+	})
+}
+
+func TestIngredientRepository_SetCakeIngredients(t *testing.T) {
+	kit, closer := initializeRepoTestKit(t)
+	defer closer()
+	mock := kit.dbmock
+
+	repo := ingredientRepository{
+		db: kit.db,
+	}
+
+	ctx := context.TODO()
+	ingredients := []*model.CakeIngredient{{IngredientId: 2, Quantity: 300}, {IngredientId: 3, Quantity: 100}}
+
+	t.Run("ok", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec("DELETE FROM cake_ingredients WHERE cake_id = \\?").
+			WithArgs(1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("INSERT INTO cake_ingredients\\(cake_id,ingredient_id,quantity\\) VALUES \\(\\?,\\?,\\?\\),\\(\\?,\\?,\\?\\)").
+			WithArgs(1, 2, 300.0, 1, 3, 100.0).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectCommit()
+
+		err := repo.SetCakeIngredients(ctx, 1, ingredients)
+		require.NoError(t, err)
+	})
+
+	t.Run("ok - clear ingredients", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec("DELETE FROM cake_ingredients").
+			WithArgs(1).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectCommit()
+
+		err := repo.SetCakeIngredients(ctx, 1, nil)
+		require.NoError(t, err)
+	})
+
+	t.Run("failed to insert ingredients", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec("DELETE FROM cake_ingredients").
+			WithArgs(1).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("INSERT INTO cake_ingredients").
+			WithArgs(1, 2, 300.0, 1, 3, 100.0).
+			WillReturnError(errors.New("db error"))
+		mock.ExpectRollback()
+
+		err := repo.SetCakeIngredients(ctx, 1, ingredients)
+		require.Error(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestIngredientRepository_LoadCakes(t *testing.T) {
+	kit, closer := initializeRepoTestKit(t)
+	defer closer()
+	mock := kit.dbmock
+
+	repo := ingredientRepository{
+		db: kit.db,
+	}
+
+	ctx := context.TODO()
+
+	t.Run("ok", func(t *testing.T) {
+		cakes := []*model.Cake{{Id: 1}, {Id: 2}}
+		resRows := sqlmock.NewRows([]string{"cake_id", "quantity", "id", "name", "allergens", "energy_kcal", "fat", "carbohydrate", "sugar", "protein", "salt"}).
+			AddRow(1, 300, 1, "Flour", "gluten", 364, 1, 76, 0.3, 10, 0).
+			AddRow(1, 100, 2, "Butter", "dairy", 717, 81, 0.1, 0.1, 0.9, 1.6)
+
+		mock.ExpectQuery("SELECT (.+) FROM cake_ingredients ci JOIN ingredients i (.+) WHERE ci.cake_id IN \\(\\?,\\?\\) ORDER BY ci.quantity DESC").
+			WithArgs(1, 2).
+			WillReturnRows(resRows)
+
+		err := repo.LoadCakes(ctx, cakes)
+		require.NoError(t, err)
+		assert.Equal(t, 2, len(cakes[0].Ingredients))
+		assert.Equal(t, []string{model.AllergenGluten, model.AllergenDairy}, cakes[0].Allergens)
+		assert.Equal(t, 400.0, cakes[0].Nutrition.Weight)
+		assert.Nil(t, cakes[1].Ingredients)
+		assert.Nil(t, cakes[1].Allergens)
+	})
+
+	t.Run("ok - no cake", func(t *testing.T) {
+		err := repo.LoadCakes(ctx, nil)
+		require.NoError(t, err)
+	})
+
+	t.Run("failed to load ingredients", func(t *testing.T) {
+		mock.ExpectQuery("SELECT (.+) FROM cake_ingredients").
+			WithArgs(1).
+			WillReturnError(errors.New("db error"))
+
+		err := repo.LoadCakes(ctx, []*model.Cake{{Id: 1}})
+		require.Error(t, err)
+	})
+}
+
+func TestIngredientRepository_FindPrices(t *testing.T) {
+	kit, closer := initializeRepoTestKit(t)
+	defer closer()
+	mock := kit.dbmock
+
+	repo := ingredientRepository{
+		db: kit.db,
+	}
+
+	ctx := context.TODO()
+
+	t.Run("ok", func(t *testing.T) {
+		resRows := sqlmock.NewRows([]string{"id", "ingredient_id", "supplier", "price", "currency", "quantity", "unit", "updated_at"}).
+			AddRow(1, 2, "Toko Bahan Kue", 1500000, "IDR", 1, "kg", time.Now()).
+			AddRow(2, 3, "Dairy Farm", 2200000, "IDR", 1, "l", time.Now())
+
+		mock.ExpectQuery("SELECT (.+) FROM supplier_prices WHERE ingredient_id IN \\(\\?,\\?\\)").
+			WithArgs(2, 3).
+			WillReturnRows(resRows)
+
+		res, err := repo.FindPrices(ctx, []int{2, 3})
+		require.NoError(t, err)
+		require.Equal(t, 2, len(res))
+		assert.Equal(t, model.NewMoney(1500000, "IDR"), res[0].Price)
+		assert.Equal(t, "l", res[1].Unit)
+	})
+
+	t.Run("ok - no ingredient", func(t *testing.T) {
+		res, err := repo.FindPrices(ctx, nil)
+		require.NoError(t, err)
+		assert.Equal(t, 0, len(res))
+	})
+}
+
+func TestIngredientRepository_SetPrices(t *testing.T) {
+	kit, closer := initializeRepoTestKit(t)
+	defer closer()
+	mock := kit.dbmock
+
+	repo := ingredientRepository{
+		db: kit.db,
+	}
+
+	ctx := context.TODO()
+	price := &model.SupplierPrice{Supplier: "Toko Bahan Kue", Price: model.NewMoney(1500000, "IDR"), Quantity: 1, Unit: "kg", UpdatedAt: time.Now()}
+
+	t.Run("ok", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec("DELETE FROM supplier_prices WHERE ingredient_id = \\?").
+			WithArgs(2).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("INSERT INTO supplier_prices").
+			WithArgs(2, price.Supplier, price.Price, "IDR", 1.0, "kg", price.UpdatedAt).
+			WillReturnResult(sqlmock.NewResult(7, 1))
+		mock.ExpectCommit()
+
+		err := repo.SetPrices(ctx, 2, []*model.SupplierPrice{price})
+		require.NoError(t, err)
+		assert.Equal(t, 7, price.Id)
+	})
+
+	t.Run("failed to insert prices", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec("DELETE FROM supplier_prices").
+			WithArgs(2).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("INSERT INTO supplier_prices").
+			WillReturnError(errors.New("db error"))
+		mock.ExpectRollback()
+
+		err := repo.SetPrices(ctx, 2, []*model.SupplierPrice{price})
+		require.Error(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
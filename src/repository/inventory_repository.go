@@ -0,0 +1,262 @@
+package repository
+
+import (
+	"cake-store/src/constant"
+	"cake-store/src/model"
+	"context"
+	"database/sql"
+
+	"github.com/sirupsen/logrus"
+)
+
+type inventoryRepository struct {
+	db *sql.DB
+}
+
+func NewInventoryRepository(db *sql.DB) model.InventoryRepository {
+	return &inventoryRepository{
+		db: db,
+	}
+}
+
+func (i *inventoryRepository) FindAll(ctx context.Context) ([]*model.IngredientStock, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Find All Inventory Repository",
+	})
+
+	sql := "SELECT " + inventoryColumns + " FROM ingredient_stocks s JOIN ingredients i ON i.id = s.ingredient_id ORDER BY i.name ASC"
+	return i.findStocks(ctx, log, sql)
+}
+
+// FindLow find the stocks at or below their reorder point
+func (i *inventoryRepository) FindLow(ctx context.Context) ([]*model.IngredientStock, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Find Low Inventory Repository",
+	})
+
+	sql := "SELECT " + inventoryColumns + " FROM ingredient_stocks s JOIN ingredients i ON i.id = s.ingredient_id " +
+		"WHERE s.on_hand <= s.reorder_point ORDER BY i.name ASC"
+	return i.findStocks(ctx, log, sql)
+}
+
+func (i *inventoryRepository) FindByIngredientIds(ctx context.Context, ingredientIds []int) ([]*model.IngredientStock, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message":       "Find By Ingredient IDs Inventory Repository",
+		"ingredientIds": ingredientIds,
+	})
+
+	if len(ingredientIds) == 0 {
+		return make([]*model.IngredientStock, 0), nil
+	}
+
+	sql := "SELECT " + inventoryColumns + " FROM ingredient_stocks s JOIN ingredients i ON i.id = s.ingredient_id " +
+		"WHERE s.ingredient_id IN (" + placeholders(len(ingredientIds)) + ") ORDER BY i.name ASC"
+	return i.findStocks(ctx, log, sql, intArgs(ingredientIds)...)
+}
+
+// SetLevels store the unit, on hand quantity and reorder levels of the stock
+func (i *inventoryRepository) SetLevels(ctx context.Context, stock *model.IngredientStock) error {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Set Levels Inventory Repository",
+		"stock":   stock,
+	})
+
+	query := "INSERT INTO ingredient_stocks(ingredient_id,on_hand,unit,reorder_point,reorder_quantity,updated_at) VALUES (?,?,?,?,?,?) " +
+		"ON DUPLICATE KEY UPDATE on_hand = VALUES(on_hand), unit = VALUES(unit), reorder_point = VALUES(reorder_point), " +
+		"reorder_quantity = VALUES(reorder_quantity), updated_at = VALUES(updated_at)"
+	_, err := i.db.ExecContext(ctx, query, stock.IngredientId, stock.OnHand, stock.Unit, stock.ReorderPoint, stock.ReorderQuantity, stock.UpdatedAt)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// Adjust apply the adjustment to the on hand quantity and record it, the quantity never go below zero
+func (i *inventoryRepository) Adjust(ctx context.Context, adjustment *model.InventoryAdjustment) (*model.IngredientStock, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message":    "Adjust Inventory Repository",
+		"adjustment": adjustment,
+	})
+
+	tx, err := i.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	query := "INSERT INTO ingredient_stocks(ingredient_id,on_hand,unit,updated_at) VALUES (?,0,?,?) ON DUPLICATE KEY UPDATE ingredient_id = ingredient_id"
+	if _, err = tx.ExecContext(ctx, query, adjustment.IngredientId, adjustment.Unit, adjustment.CreatedAt); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	query = "UPDATE ingredient_stocks SET on_hand = on_hand + ?, updated_at = ? WHERE ingredient_id = ? AND on_hand + ? >= 0"
+	res, err := tx.ExecContext(ctx, query, adjustment.Delta, adjustment.CreatedAt, adjustment.IngredientId, adjustment.Delta)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	if affected == 0 {
+		log.Error(constant.ErrInsufficientStock)
+		return nil, constant.ErrInsufficientStock
+	}
+
+	if err = insertInventoryAdjustment(ctx, tx, adjustment); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	stock := &model.IngredientStock{}
+	query = "SELECT " + inventoryColumns + " FROM ingredient_stocks s JOIN ingredients i ON i.id = s.ingredient_id WHERE s.ingredient_id = ?"
+	err = tx.QueryRowContext(ctx, query, adjustment.IngredientId).
+		Scan(&stock.IngredientId, &stock.Name, &stock.OnHand, &stock.Unit, &stock.ReorderPoint, &stock.ReorderQuantity, &stock.UpdatedAt)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	stock.SetLowStock()
+	return stock, nil
+}
+
+// Consume apply the adjustments of an order all together, the on hand quantity may go below zero as the ingredients
+// were used whatever was counted
+func (i *inventoryRepository) Consume(ctx context.Context, adjustments []*model.InventoryAdjustment) error {
+	log := logrus.WithFields(logrus.Fields{
+		"message":     "Consume Inventory Repository",
+		"adjustments": adjustments,
+	})
+
+	if len(adjustments) == 0 {
+		return nil
+	}
+
+	tx, err := i.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, adjustment := range adjustments {
+		query := "INSERT INTO ingredient_stocks(ingredient_id,on_hand,unit,updated_at) VALUES (?,?,?,?) " +
+			"ON DUPLICATE KEY UPDATE on_hand = on_hand + VALUES(on_hand), updated_at = VALUES(updated_at)"
+		if _, err = tx.ExecContext(ctx, query, adjustment.IngredientId, adjustment.Delta, adjustment.Unit, adjustment.CreatedAt); err != nil {
+			log.Error(err)
+			return err
+		}
+
+		if err = insertInventoryAdjustment(ctx, tx, adjustment); err != nil {
+			log.Error(err)
+			return err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		log.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// FindAdjustments find the latest adjustments of the ingredient
+func (i *inventoryRepository) FindAdjustments(ctx context.Context, ingredientId int, limit int) ([]*model.InventoryAdjustment, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message":      "Find Adjustments Inventory Repository",
+		"ingredientId": ingredientId,
+	})
+
+	sql := "SELECT " + inventoryAdjustmentColumns + " FROM ingredient_stock_adjustments WHERE ingredient_id = ? ORDER BY id DESC LIMIT ?"
+	return i.findAdjustments(ctx, log, sql, ingredientId, limit)
+}
+
+// FindOrderAdjustments find the adjustments recorded for the order
+func (i *inventoryRepository) FindOrderAdjustments(ctx context.Context, orderId int) ([]*model.InventoryAdjustment, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Find Order Adjustments Inventory Repository",
+		"orderId": orderId,
+	})
+
+	sql := "SELECT " + inventoryAdjustmentColumns + " FROM ingredient_stock_adjustments WHERE order_id = ? ORDER BY id ASC"
+	return i.findAdjustments(ctx, log, sql, orderId)
+}
+
+func (i *inventoryRepository) findStocks(ctx context.Context, log *logrus.Entry, sql string, args ...interface{}) ([]*model.IngredientStock, error) {
+	rows, err := i.db.QueryContext(ctx, sql, args...)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	stocks := make([]*model.IngredientStock, 0)
+	for rows.Next() {
+		stock := &model.IngredientStock{}
+		err := rows.Scan(&stock.IngredientId, &stock.Name, &stock.OnHand, &stock.Unit, &stock.ReorderPoint, &stock.ReorderQuantity, &stock.UpdatedAt)
+		if err != nil {
+			log.Error(err)
+			return nil, err
+		}
+		stock.SetLowStock()
+		stocks = append(stocks, stock)
+	}
+	return stocks, nil
+}
+
+func (i *inventoryRepository) findAdjustments(ctx context.Context, log *logrus.Entry, sql string, args ...interface{}) ([]*model.InventoryAdjustment, error) {
+	rows, err := i.db.QueryContext(ctx, sql, args...)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	adjustments := make([]*model.InventoryAdjustment, 0)
+	for rows.Next() {
+		adjustment := &model.InventoryAdjustment{}
+		err := rows.Scan(&adjustment.Id, &adjustment.IngredientId, &adjustment.OrderId, &adjustment.Delta, &adjustment.Unit,
+			&adjustment.Reason, &adjustment.Note, &adjustment.CreatedAt)
+		if err != nil {
+			log.Error(err)
+			return nil, err
+		}
+		adjustments = append(adjustments, adjustment)
+	}
+	return adjustments, nil
+}
+
+func insertInventoryAdjustment(ctx context.Context, tx *sql.Tx, adjustment *model.InventoryAdjustment) error {
+	query := "INSERT INTO ingredient_stock_adjustments(ingredient_id,order_id,delta,unit,reason,note,created_at) VALUES (?,?,?,?,?,?,?)"
+	res, err := tx.ExecContext(ctx, query, adjustment.IngredientId, adjustment.OrderId, adjustment.Delta, adjustment.Unit,
+		adjustment.Reason, adjustment.Note, adjustment.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return err
+	}
+	adjustment.Id = int(id)
+	return nil
+}
+
+const (
+	inventoryColumns           = "s.ingredient_id, i.name, s.on_hand, s.unit, s.reorder_point, s.reorder_quantity, s.updated_at"
+	inventoryAdjustmentColumns = "id, ingredient_id, order_id, delta, unit, reason, note, created_at"
+)
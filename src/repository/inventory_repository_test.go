@@ -0,0 +1,274 @@
+package repository
+
+import (
+	"cake-store/src/constant"
+	"cake-store/src/model"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var inventoryStockColumns = []string{"ingredient_id", "name", "on_hand", "unit", "reorder_point", "reorder_quantity", "updated_at"}
+
+func TestInventoryRepository_Adjust(t *testing.T) {
+	kit, closer := initializeRepoTestKit(t)
+	defer closer()
+	mock := kit.dbmock
+
+	repo := inventoryRepository{
+		db: kit.db,
+	}
+
+	ctx := context.TODO()
+	adjustment := &model.InventoryAdjustment{IngredientId: 2, Delta: 1000, Unit: model.UnitGram, Reason: model.InventoryReasonPurchase, CreatedAt: time.Now()}
+
+	t.Run("ok", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec("INSERT INTO ingredient_stocks(.+) ON DUPLICATE KEY UPDATE ingredient_id = ingredient_id").
+			WithArgs(2, model.UnitGram, adjustment.CreatedAt).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("UPDATE ingredient_stocks SET on_hand = on_hand \\+ \\?, updated_at = \\? WHERE ingredient_id = \\? AND on_hand \\+ \\? >= 0").
+			WithArgs(1000.0, adjustment.CreatedAt, 2, 1000.0).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("INSERT INTO ingredient_stock_adjustments").
+			WithArgs(2, 0, 1000.0, model.UnitGram, model.InventoryReasonPurchase, "", adjustment.CreatedAt).
+			WillReturnResult(sqlmock.NewResult(7, 1))
+		mock.ExpectQuery("SELECT (.+) FROM ingredient_stocks s JOIN ingredients i ON i.id = s.ingredient_id WHERE s.ingredient_id = \\?").
+			WithArgs(2).
+			WillReturnRows(sqlmock.NewRows(inventoryStockColumns).AddRow(2, "Flour", 1500, "g", 500, 2000, adjustment.CreatedAt))
+		mock.ExpectCommit()
+
+		res, err := repo.Adjust(ctx, adjustment)
+		require.NoError(t, err)
+		assert.Equal(t, 1500.0, res.OnHand)
+		assert.False(t, res.LowStock)
+		assert.Equal(t, 7, adjustment.Id)
+	})
+
+	t.Run("insufficient stock", func(t *testing.T) {
+		adjustment := &model.InventoryAdjustment{IngredientId: 2, Delta: -5000, Unit: model.UnitGram, Reason: model.InventoryReasonWaste, CreatedAt: time.Now()}
+
+		mock.ExpectBegin()
+		mock.ExpectExec("INSERT INTO ingredient_stocks").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("UPDATE ingredient_stocks").
+			WithArgs(-5000.0, adjustment.CreatedAt, 2, -5000.0).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		res, err := repo.Adjust(ctx, adjustment)
+		assert.Equal(t, constant.ErrInsufficientStock, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("failed to adjust", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec("INSERT INTO ingredient_stocks").WillReturnError(errors.New("err db"))
+		mock.ExpectRollback()
+
+		res, err := repo.Adjust(ctx, adjustment)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestInventoryRepository_Consume(t *testing.T) {
+	kit, closer := initializeRepoTestKit(t)
+	defer closer()
+	mock := kit.dbmock
+
+	repo := inventoryRepository{
+		db: kit.db,
+	}
+
+	ctx := context.TODO()
+	now := time.Now()
+	adjustments := []*model.InventoryAdjustment{
+		{IngredientId: 2, OrderId: 3, Delta: -250, Unit: model.UnitGram, Reason: model.InventoryReasonConsumption, Note: "order #3", CreatedAt: now},
+		{IngredientId: 4, OrderId: 3, Delta: -3, Unit: model.UnitPiece, Reason: model.InventoryReasonConsumption, Note: "order #3", CreatedAt: now},
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec("INSERT INTO ingredient_stocks(.+) ON DUPLICATE KEY UPDATE on_hand = on_hand \\+ VALUES\\(on_hand\\)").
+			WithArgs(2, -250.0, model.UnitGram, now).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("INSERT INTO ingredient_stock_adjustments").
+			WithArgs(2, 3, -250.0, model.UnitGram, model.InventoryReasonConsumption, "order #3", now).
+			WillReturnResult(sqlmock.NewResult(8, 1))
+		mock.ExpectExec("INSERT INTO ingredient_stocks").
+			WithArgs(4, -3.0, model.UnitPiece, now).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("INSERT INTO ingredient_stock_adjustments").
+			WithArgs(4, 3, -3.0, model.UnitPiece, model.InventoryReasonConsumption, "order #3", now).
+			WillReturnResult(sqlmock.NewResult(9, 1))
+		mock.ExpectCommit()
+
+		err := repo.Consume(ctx, adjustments)
+		require.NoError(t, err)
+		assert.Equal(t, 8, adjustments[0].Id)
+		assert.Equal(t, 9, adjustments[1].Id)
+	})
+
+	t.Run("nothing to consume", func(t *testing.T) {
+		err := repo.Consume(ctx, nil)
+		assert.NoError(t, err)
+	})
+
+	t.Run("failed to consume", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec("INSERT INTO ingredient_stocks").WillReturnError(errors.New("err db"))
+		mock.ExpectRollback()
+
+		err := repo.Consume(ctx, adjustments)
+		assert.Error(t, err)
+	})
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestInventoryRepository_FindLow(t *testing.T) {
+	kit, closer := initializeRepoTestKit(t)
+	defer closer()
+	mock := kit.dbmock
+
+	repo := inventoryRepository{
+		db: kit.db,
+	}
+
+	ctx := context.TODO()
+
+	t.Run("ok", func(t *testing.T) {
+		mock.ExpectQuery("SELECT (.+) FROM ingredient_stocks s JOIN ingredients i ON i.id = s.ingredient_id WHERE s.on_hand <= s.reorder_point").
+			WillReturnRows(sqlmock.NewRows(inventoryStockColumns).AddRow(2, "Flour", -100, "g", 500, 2000, time.Now()))
+
+		res, err := repo.FindLow(ctx)
+		require.NoError(t, err)
+		require.Len(t, res, 1)
+		assert.True(t, res[0].LowStock)
+		assert.Equal(t, -100.0, res[0].OnHand)
+	})
+
+	t.Run("error from db", func(t *testing.T) {
+		mock.ExpectQuery("SELECT (.+) FROM ingredient_stocks").WillReturnError(errors.New("err db"))
+
+		res, err := repo.FindLow(ctx)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestInventoryRepository_FindByIngredientIds(t *testing.T) {
+	kit, closer := initializeRepoTestKit(t)
+	defer closer()
+	mock := kit.dbmock
+
+	repo := inventoryRepository{
+		db: kit.db,
+	}
+
+	ctx := context.TODO()
+
+	t.Run("ok", func(t *testing.T) {
+		mock.ExpectQuery("SELECT (.+) FROM ingredient_stocks s (.+) WHERE s.ingredient_id IN \\(\\?,\\?\\)").
+			WithArgs(2, 4).
+			WillReturnRows(sqlmock.NewRows(inventoryStockColumns).AddRow(2, "Flour", 1500, "g", 500, 2000, time.Now()))
+
+		res, err := repo.FindByIngredientIds(ctx, []int{2, 4})
+		require.NoError(t, err)
+		require.Len(t, res, 1)
+		assert.Equal(t, "Flour", res[0].Name)
+	})
+
+	t.Run("no ids", func(t *testing.T) {
+		res, err := repo.FindByIngredientIds(ctx, nil)
+		require.NoError(t, err)
+		assert.Empty(t, res)
+	})
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestInventoryRepository_SetLevels(t *testing.T) {
+	kit, closer := initializeRepoTestKit(t)
+	defer closer()
+	mock := kit.dbmock
+
+	repo := inventoryRepository{
+		db: kit.db,
+	}
+
+	ctx := context.TODO()
+	stock := &model.IngredientStock{IngredientId: 2, OnHand: 1.5, Unit: model.UnitKilogram, ReorderPoint: 0.5, ReorderQuantity: 2, UpdatedAt: time.Now()}
+
+	t.Run("ok", func(t *testing.T) {
+		mock.ExpectExec("INSERT INTO ingredient_stocks(.+) ON DUPLICATE KEY UPDATE on_hand = VALUES\\(on_hand\\)").
+			WithArgs(2, 1.5, model.UnitKilogram, 0.5, 2.0, stock.UpdatedAt).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := repo.SetLevels(ctx, stock)
+		assert.NoError(t, err)
+	})
+
+	t.Run("error from db", func(t *testing.T) {
+		mock.ExpectExec("INSERT INTO ingredient_stocks").WillReturnError(errors.New("err db"))
+
+		err := repo.SetLevels(ctx, stock)
+		assert.Error(t, err)
+	})
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestInventoryRepository_FindAdjustments(t *testing.T) {
+	kit, closer := initializeRepoTestKit(t)
+	defer closer()
+	mock := kit.dbmock
+
+	repo := inventoryRepository{
+		db: kit.db,
+	}
+
+	ctx := context.TODO()
+	columns := []string{"id", "ingredient_id", "order_id", "delta", "unit", "reason", "note", "created_at"}
+
+	t.Run("ok", func(t *testing.T) {
+		mock.ExpectQuery("SELECT (.+) FROM ingredient_stock_adjustments WHERE ingredient_id = \\? ORDER BY id DESC LIMIT \\?").
+			WithArgs(2, 100).
+			WillReturnRows(sqlmock.NewRows(columns).AddRow(7, 2, 0, 1000, "g", "purchase", "", time.Now()))
+
+		res, err := repo.FindAdjustments(ctx, 2, 100)
+		require.NoError(t, err)
+		require.Len(t, res, 1)
+		assert.Equal(t, 1000.0, res[0].Delta)
+	})
+
+	t.Run("order adjustments", func(t *testing.T) {
+		mock.ExpectQuery("SELECT (.+) FROM ingredient_stock_adjustments WHERE order_id = \\?").
+			WithArgs(3).
+			WillReturnRows(sqlmock.NewRows(columns).AddRow(8, 2, 3, -250, "g", "consumption", "order #3", time.Now()))
+
+		res, err := repo.FindOrderAdjustments(ctx, 3)
+		require.NoError(t, err)
+		require.Len(t, res, 1)
+		assert.Equal(t, 3, res[0].OrderId)
+	})
+
+	t.Run("error from db", func(t *testing.T) {
+		mock.ExpectQuery("SELECT (.+) FROM ingredient_stock_adjustments").WillReturnError(errors.New("err db"))
+
+		res, err := repo.FindAdjustments(ctx, 2, 100)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
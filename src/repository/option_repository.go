@@ -0,0 +1,225 @@
+package repository
+
+import (
+	"cake-store/src/model"
+	"context"
+	"database/sql"
+
+	"github.com/sirupsen/logrus"
+)
+
+type optionRepository struct {
+	db *sql.DB
+}
+
+func NewOptionRepository(db *sql.DB) model.OptionRepository {
+	return &optionRepository{
+		db: db,
+	}
+}
+
+// Save replace the option groups of the cake with their choices and constraints
+func (o *optionRepository) Save(ctx context.Context, cakeId int, groups []*model.OptionGroup) error {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Save Option Repository",
+		"cakeId":  cakeId,
+		"groups":  groups,
+	})
+
+	tx, err := o.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+	defer tx.Rollback()
+
+	// the choices and constraints are deleted with their group
+	if _, err = tx.ExecContext(ctx, "DELETE FROM option_groups WHERE cake_id = ?", cakeId); err != nil {
+		log.Error(err)
+		return err
+	}
+
+	for _, group := range groups {
+		group.CakeId = cakeId
+		if err = insertOptionGroup(ctx, tx, group); err != nil {
+			log.Error(err)
+			return err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		log.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+func insertOptionGroup(ctx context.Context, tx *sql.Tx, group *model.OptionGroup) error {
+	query := "INSERT INTO option_groups(cake_id,code,name,kind,required,max_choices,max_length,price_modifier,currency,position,updated_at) " +
+		"VALUES (?,?,?,?,?,?,?,?,?,?,?)"
+	res, err := tx.ExecContext(ctx, query, group.CakeId, group.Code, group.Name, group.Kind, group.Required, group.MaxChoices, group.MaxLength,
+		group.PriceModifier, group.PriceModifier.Currency, group.Position, group.UpdatedAt)
+	if err != nil {
+		return duplicateErr(err)
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return err
+	}
+	group.Id = int(id)
+
+	for _, choice := range group.Choices {
+		choice.GroupId = group.Id
+		query = "INSERT INTO option_choices(group_id,code,name,price_modifier,currency,value,position) VALUES (?,?,?,?,?,?,?)"
+		res, err = tx.ExecContext(ctx, query, choice.GroupId, choice.Code, choice.Name, choice.PriceModifier, choice.PriceModifier.Currency,
+			choice.Value, choice.Position)
+		if err != nil {
+			return duplicateErr(err)
+		}
+
+		id, err = res.LastInsertId()
+		if err != nil {
+			return err
+		}
+		choice.Id = int(id)
+
+		for _, constraint := range choice.Requires {
+			constraint.ChoiceId = choice.Id
+			query = "INSERT INTO option_constraints(choice_id,group_code,min_value,max_value) VALUES (?,?,?,?)"
+			res, err = tx.ExecContext(ctx, query, constraint.ChoiceId, constraint.Group, constraint.MinValue, constraint.MaxValue)
+			if err != nil {
+				return err
+			}
+
+			id, err = res.LastInsertId()
+			if err != nil {
+				return err
+			}
+			constraint.Id = int(id)
+		}
+	}
+	return nil
+}
+
+// FindByCakeId find the option groups of the cake in order, with their choices and constraints
+func (o *optionRepository) FindByCakeId(ctx context.Context, cakeId int) ([]*model.OptionGroup, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Find By Cake ID Option Repository",
+		"cakeId":  cakeId,
+	})
+
+	sql := "SELECT id, cake_id, code, name, kind, required, max_choices, max_length, price_modifier, currency, position, updated_at " +
+		"FROM option_groups WHERE cake_id = ? ORDER BY position ASC"
+	rows, err := o.db.QueryContext(ctx, sql, cakeId)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	groups := make([]*model.OptionGroup, 0)
+	for rows.Next() {
+		group := &model.OptionGroup{}
+		err := rows.Scan(&group.Id, &group.CakeId, &group.Code, &group.Name, &group.Kind, &group.Required, &group.MaxChoices, &group.MaxLength,
+			&group.PriceModifier, &group.PriceModifier.Currency, &group.Position, &group.UpdatedAt)
+		if err != nil {
+			log.Error(err)
+			return nil, err
+		}
+		group.Choices = make([]*model.OptionChoice, 0)
+		groups = append(groups, group)
+	}
+
+	choices, err := o.loadChoices(ctx, groups)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	if err = o.loadConstraints(ctx, choices); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return groups, nil
+}
+
+func (o *optionRepository) loadChoices(ctx context.Context, groups []*model.OptionGroup) ([]*model.OptionChoice, error) {
+	choices := make([]*model.OptionChoice, 0)
+	if len(groups) == 0 {
+		return choices, nil
+	}
+
+	byId := make(map[int]*model.OptionGroup, len(groups))
+	ids := make([]int, 0, len(groups))
+	for _, group := range groups {
+		byId[group.Id] = group
+		ids = append(ids, group.Id)
+	}
+
+	sql := "SELECT id, group_id, code, name, price_modifier, currency, value, position FROM option_choices " +
+		"WHERE group_id IN (" + placeholders(len(ids)) + ") ORDER BY position ASC"
+	rows, err := o.db.QueryContext(ctx, sql, intArgs(ids)...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		choice := &model.OptionChoice{}
+		err := rows.Scan(&choice.Id, &choice.GroupId, &choice.Code, &choice.Name, &choice.PriceModifier, &choice.PriceModifier.Currency,
+			&choice.Value, &choice.Position)
+		if err != nil {
+			return nil, err
+		}
+		choice.Requires = make([]*model.OptionConstraint, 0)
+
+		if group, ok := byId[choice.GroupId]; ok {
+			group.Choices = append(group.Choices, choice)
+			choices = append(choices, choice)
+		}
+	}
+	return choices, nil
+}
+
+func (o *optionRepository) loadConstraints(ctx context.Context, choices []*model.OptionChoice) error {
+	if len(choices) == 0 {
+		return nil
+	}
+
+	byId := make(map[int]*model.OptionChoice, len(choices))
+	ids := make([]int, 0, len(choices))
+	for _, choice := range choices {
+		byId[choice.Id] = choice
+		ids = append(ids, choice.Id)
+	}
+
+	query := "SELECT id, choice_id, group_code, min_value, max_value FROM option_constraints " +
+		"WHERE choice_id IN (" + placeholders(len(ids)) + ") ORDER BY id ASC"
+	rows, err := o.db.QueryContext(ctx, query, intArgs(ids)...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var minValue, maxValue sql.NullFloat64
+		constraint := &model.OptionConstraint{}
+		if err := rows.Scan(&constraint.Id, &constraint.ChoiceId, &constraint.Group, &minValue, &maxValue); err != nil {
+			return err
+		}
+		if minValue.Valid {
+			constraint.MinValue = &minValue.Float64
+		}
+		if maxValue.Valid {
+			constraint.MaxValue = &maxValue.Float64
+		}
+
+		if choice, ok := byId[constraint.ChoiceId]; ok {
+			choice.Requires = append(choice.Requires, constraint)
+		}
+	}
+	return nil
+}
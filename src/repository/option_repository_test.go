@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"cake-store/src/model"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOptionRepository_Save(t *testing.T) {
+	kit, closer := initializeRepoTestKit(t)
+	defer closer()
+	mock := kit.dbmock
+
+	repo := optionRepository{
+		db: kit.db,
+	}
+
+	ctx := context.TODO()
+	now := time.Now()
+	twenty := 20.0
+	groups := []*model.OptionGroup{
+		{Code: "frosting", Name: "Frosting", Kind: model.OptionKindChoice, MaxChoices: 1, PriceModifier: model.NewMoney(0, "IDR"), Position: 1, UpdatedAt: now,
+			Choices: []*model.OptionChoice{{Code: "fondant", Name: "Fondant", PriceModifier: model.NewMoney(75000, "IDR"), Position: 1,
+				Requires: []*model.OptionConstraint{{Group: "size", MinValue: &twenty}}}}},
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec("DELETE FROM option_groups WHERE cake_id = \\?").WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectExec("INSERT INTO option_groups").
+			WithArgs(1, "frosting", "Frosting", model.OptionKindChoice, false, 1, 0, model.NewMoney(0, "IDR"), "IDR", 1, now).
+			WillReturnResult(sqlmock.NewResult(4, 1))
+		mock.ExpectExec("INSERT INTO option_choices").
+			WithArgs(4, "fondant", "Fondant", model.NewMoney(75000, "IDR"), "IDR", 0.0, 1).
+			WillReturnResult(sqlmock.NewResult(9, 1))
+		mock.ExpectExec("INSERT INTO option_constraints").
+			WithArgs(9, "size", &twenty, nil).
+			WillReturnResult(sqlmock.NewResult(2, 1))
+		mock.ExpectCommit()
+
+		err := repo.Save(ctx, 1, groups)
+		require.NoError(t, err)
+		assert.Equal(t, 4, groups[0].Id)
+		assert.Equal(t, 1, groups[0].CakeId)
+		assert.Equal(t, 4, groups[0].Choices[0].GroupId)
+		assert.Equal(t, 9, groups[0].Choices[0].Requires[0].ChoiceId)
+	})
+
+	t.Run("failed to save", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec("DELETE FROM option_groups").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("INSERT INTO option_groups").WillReturnError(errors.New("err db"))
+		mock.ExpectRollback()
+
+		err := repo.Save(ctx, 1, groups)
+		assert.Error(t, err)
+	})
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestOptionRepository_FindByCakeId(t *testing.T) {
+	kit, closer := initializeRepoTestKit(t)
+	defer closer()
+	mock := kit.dbmock
+
+	repo := optionRepository{
+		db: kit.db,
+	}
+
+	ctx := context.TODO()
+
+	t.Run("ok", func(t *testing.T) {
+		mock.ExpectQuery("SELECT (.+) FROM option_groups WHERE cake_id = \\? ORDER BY position ASC").
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "cake_id", "code", "name", "kind", "required", "max_choices", "max_length", "price_modifier", "currency", "position", "updated_at"}).
+				AddRow(3, 1, "size", "Size", "choice", true, 1, 0, 0, "IDR", 1, time.Now()).
+				AddRow(4, 1, "frosting", "Frosting", "choice", false, 1, 0, 0, "IDR", 2, time.Now()))
+		mock.ExpectQuery("SELECT (.+) FROM option_choices WHERE group_id IN \\(\\?,\\?\\) ORDER BY position ASC").
+			WithArgs(3, 4).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "group_id", "code", "name", "price_modifier", "currency", "value", "position"}).
+				AddRow(7, 3, "24cm", "24 cm", 100000, "IDR", 24, 1).
+				AddRow(9, 4, "fondant", "Fondant", 75000, "IDR", 0, 1))
+		mock.ExpectQuery("SELECT (.+) FROM option_constraints WHERE choice_id IN \\(\\?,\\?\\)").
+			WithArgs(7, 9).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "choice_id", "group_code", "min_value", "max_value"}).
+				AddRow(2, 9, "size", 20, nil))
+
+		res, err := repo.FindByCakeId(ctx, 1)
+		require.NoError(t, err)
+		require.Len(t, res, 2)
+		require.Len(t, res[0].Choices, 1)
+		assert.Equal(t, model.NewMoney(100000, "IDR"), res[0].Choices[0].PriceModifier)
+		require.Len(t, res[1].Choices[0].Requires, 1)
+		assert.Equal(t, 20.0, *res[1].Choices[0].Requires[0].MinValue)
+		assert.Nil(t, res[1].Choices[0].Requires[0].MaxValue)
+	})
+
+	t.Run("ok - no options", func(t *testing.T) {
+		mock.ExpectQuery("SELECT (.+) FROM option_groups").
+			WithArgs(2).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		res, err := repo.FindByCakeId(ctx, 2)
+		require.NoError(t, err)
+		assert.Empty(t, res)
+	})
+
+	t.Run("failed to find", func(t *testing.T) {
+		mock.ExpectQuery("SELECT (.+) FROM option_groups").WillReturnError(errors.New("err db"))
+
+		res, err := repo.FindByCakeId(ctx, 1)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
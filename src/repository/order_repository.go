@@ -0,0 +1,309 @@
+package repository
+
+import (
+	"cake-store/src/constant"
+	"cake-store/src/model"
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+type orderRepository struct {
+	db *sql.DB
+}
+
+func NewOrderRepository(db *sql.DB) model.OrderRepository {
+	return &orderRepository{
+		db: db,
+	}
+}
+
+// Save insert the order, its items and its discounts in one transaction
+func (o *orderRepository) Save(ctx context.Context, order *model.Order) error {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Save Order Repository",
+		"order":   order,
+	})
+
+	tx, err := o.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+	defer tx.Rollback()
+
+	query := "INSERT INTO orders(store_id,customer_name,customer_phone,fulfillment,status,note,pickup_date,pickup_slot,subtotal,discount,total,currency,created_at,updated_at) " +
+		"VALUES (?,?,?,?,?,?,?,?,?,?,?,?,?,?)"
+	res, err := tx.ExecContext(ctx, query, order.StoreId, order.CustomerName, order.CustomerPhone, order.Fulfillment, order.Status, order.Note,
+		order.PickupDate.Format(model.DateLayout), order.PickupSlot, order.Subtotal, order.Discount, order.Total, order.Total.Currency, order.CreatedAt, order.UpdatedAt)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+	order.Id = int(id)
+
+	values := make([]string, 0, len(order.Items))
+	args := make([]interface{}, 0, len(order.Items)*11)
+	for _, item := range order.Items {
+		item.OrderId = order.Id
+		values = append(values, "(?,?,?,?,?,?,?,?,?,?,?)")
+		args = append(args, item.OrderId, item.CakeId, item.VariantId, item.Title, item.Size, item.Sku, item.Quantity, item.Message, item.Options,
+			item.UnitPrice, item.UnitPrice.Currency)
+	}
+
+	query = "INSERT INTO order_items(order_id,cake_id,variant_id,title,size,sku,quantity,message,options,unit_price,currency) VALUES " + strings.Join(values, ",")
+	if _, err = tx.ExecContext(ctx, query, args...); err != nil {
+		log.Error(err)
+		return err
+	}
+
+	if len(order.Discounts) > 0 {
+		values = make([]string, 0, len(order.Discounts))
+		args = make([]interface{}, 0, len(order.Discounts)*5)
+		for _, discount := range order.Discounts {
+			discount.OrderId = order.Id
+			values = append(values, "(?,?,?,?,?)")
+			args = append(args, discount.OrderId, discount.CouponId, discount.Code, discount.Amount, discount.Amount.Currency)
+		}
+
+		query = "INSERT INTO order_discounts(order_id,coupon_id,code,amount,currency) VALUES " + strings.Join(values, ",")
+		if _, err = tx.ExecContext(ctx, query, args...); err != nil {
+			log.Error(err)
+			return err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		log.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// UpdateStatus move the order to its status only when it is still in the from status, return ErrVersionConflict otherwise
+func (o *orderRepository) UpdateStatus(ctx context.Context, order *model.Order, from string) error {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Update Status Order Repository",
+		"order":   order,
+		"from":    from,
+	})
+
+	query := "UPDATE orders SET status = ?, updated_at = ? WHERE id = ? AND status = ?"
+	res, err := o.db.ExecContext(ctx, query, order.Status, order.UpdatedAt, order.Id, from)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	if affected == 0 {
+		log.Error(constant.ErrVersionConflict)
+		return constant.ErrVersionConflict
+	}
+
+	return nil
+}
+
+func (o *orderRepository) FindById(ctx context.Context, id int) (*model.Order, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Find By ID Order Repository",
+		"id":      id,
+	})
+
+	sql := "SELECT " + orderColumns + " FROM orders WHERE id = ?"
+	orders, err := o.findOrders(ctx, log, sql, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(orders) == 0 {
+		return nil, nil
+	}
+	return orders[0], nil
+}
+
+// FindAll find the page of orders, newest first
+func (o *orderRepository) FindAll(ctx context.Context, query model.OrderQuery) ([]*model.Order, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Find All Order Repository",
+		"query":   query,
+	})
+
+	conditions, args := orderFilter(query)
+	sql := "SELECT " + orderColumns + " FROM orders" + where(conditions) + " ORDER BY id DESC LIMIT ? OFFSET ?"
+	args = append(args, query.Limit, model.Offset(query.Page, query.Limit))
+	return o.findOrders(ctx, log, sql, args...)
+}
+
+func (o *orderRepository) CountAll(ctx context.Context, query model.OrderQuery) (int64, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Count All Order Repository",
+		"query":   query,
+	})
+
+	conditions, args := orderFilter(query)
+	sql := "SELECT COUNT(id) FROM orders" + where(conditions)
+
+	var total int64
+	if err := o.db.QueryRowContext(ctx, sql, args...).Scan(&total); err != nil {
+		log.Error(err)
+		return 0, err
+	}
+
+	return total, nil
+}
+
+// FindByPickupDate find the orders of the status picked up or delivered on the date, oldest first
+func (o *orderRepository) FindByPickupDate(ctx context.Context, date time.Time, status string) ([]*model.Order, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Find By Pickup Date Order Repository",
+		"date":    date,
+		"status":  status,
+	})
+
+	sql := "SELECT " + orderColumns + " FROM orders WHERE pickup_date = ? AND status = ? ORDER BY id ASC"
+	return o.findOrders(ctx, log, sql, date.Format(model.DateLayout), status)
+}
+
+// findOrders find the orders of the query and load their items and discounts
+func (o *orderRepository) findOrders(ctx context.Context, log *logrus.Entry, sql string, args ...interface{}) ([]*model.Order, error) {
+	rows, err := o.db.QueryContext(ctx, sql, args...)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	orders := make([]*model.Order, 0)
+	for rows.Next() {
+		order := &model.Order{}
+		err := rows.Scan(&order.Id, &order.StoreId, &order.CustomerName, &order.CustomerPhone, &order.Fulfillment, &order.Status, &order.Note,
+			&order.PickupDate, &order.PickupSlot, &order.Subtotal, &order.Discount, &order.Total, &order.Total.Currency, &order.CreatedAt, &order.UpdatedAt)
+		if err != nil {
+			log.Error(err)
+			return nil, err
+		}
+		order.Subtotal.Currency = order.Total.Currency
+		order.Discount.Currency = order.Total.Currency
+		order.Items = make([]*model.OrderItem, 0)
+		order.Discounts = make([]*model.OrderDiscount, 0)
+		orders = append(orders, order)
+	}
+
+	if err := o.loadItems(ctx, orders); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	if err := o.loadDiscounts(ctx, orders); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return orders, nil
+}
+
+func (o *orderRepository) loadItems(ctx context.Context, orders []*model.Order) error {
+	if len(orders) == 0 {
+		return nil
+	}
+
+	byId := make(map[int]*model.Order, len(orders))
+	ids := make([]int, 0, len(orders))
+	for _, order := range orders {
+		byId[order.Id] = order
+		ids = append(ids, order.Id)
+	}
+
+	sql := "SELECT id, order_id, cake_id, variant_id, title, size, sku, quantity, message, options, unit_price, currency FROM order_items " +
+		"WHERE order_id IN (" + placeholders(len(ids)) + ") ORDER BY id ASC"
+	rows, err := o.db.QueryContext(ctx, sql, intArgs(ids)...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		item := &model.OrderItem{}
+		err := rows.Scan(&item.Id, &item.OrderId, &item.CakeId, &item.VariantId, &item.Title, &item.Size, &item.Sku, &item.Quantity, &item.Message,
+			&item.Options, &item.UnitPrice, &item.UnitPrice.Currency)
+		if err != nil {
+			return err
+		}
+		item.SetSubtotal()
+
+		if order, ok := byId[item.OrderId]; ok {
+			order.Items = append(order.Items, item)
+		}
+	}
+	return nil
+}
+
+func (o *orderRepository) loadDiscounts(ctx context.Context, orders []*model.Order) error {
+	if len(orders) == 0 {
+		return nil
+	}
+
+	byId := make(map[int]*model.Order, len(orders))
+	ids := make([]int, 0, len(orders))
+	for _, order := range orders {
+		byId[order.Id] = order
+		ids = append(ids, order.Id)
+	}
+
+	sql := "SELECT id, order_id, coupon_id, code, amount, currency FROM order_discounts " +
+		"WHERE order_id IN (" + placeholders(len(ids)) + ") ORDER BY id ASC"
+	rows, err := o.db.QueryContext(ctx, sql, intArgs(ids)...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		discount := &model.OrderDiscount{}
+		err := rows.Scan(&discount.Id, &discount.OrderId, &discount.CouponId, &discount.Code, &discount.Amount, &discount.Amount.Currency)
+		if err != nil {
+			return err
+		}
+
+		if order, ok := byId[discount.OrderId]; ok {
+			order.Discounts = append(order.Discounts, discount)
+		}
+	}
+	return nil
+}
+
+func orderFilter(query model.OrderQuery) ([]string, []interface{}) {
+	var (
+		conditions []string
+		args       []interface{}
+	)
+
+	if query.Status != "" {
+		conditions = append(conditions, "status = ?")
+		args = append(args, query.Status)
+	}
+	if query.StoreId != 0 {
+		conditions = append(conditions, "store_id = ?")
+		args = append(args, query.StoreId)
+	}
+
+	return conditions, args
+}
+
+const orderColumns = "id, store_id, customer_name, customer_phone, fulfillment, status, note, pickup_date, pickup_slot, subtotal, discount, total, currency, created_at, updated_at"
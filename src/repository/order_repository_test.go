@@ -0,0 +1,250 @@
+package repository
+
+import (
+	"cake-store/src/constant"
+	"cake-store/src/model"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrderRepository_Save(t *testing.T) {
+	kit, closer := initializeRepoTestKit(t)
+	defer closer()
+	mock := kit.dbmock
+
+	repo := orderRepository{
+		db: kit.db,
+	}
+
+	ctx := context.TODO()
+	now := time.Now()
+	order := &model.Order{
+		StoreId:       1,
+		CustomerName:  "Budi",
+		CustomerPhone: "0812",
+		Fulfillment:   model.FulfillmentPickup,
+		Status:        model.OrderStatusPending,
+		PickupDate:    time.Date(2026, 10, 20, 0, 0, 0, 0, time.Local),
+		Subtotal:      model.NewMoney(500000, "IDR"),
+		Discount:      model.NewMoney(50000, "IDR"),
+		Total:         model.NewMoney(450000, "IDR"),
+		Items: []*model.OrderItem{
+			{CakeId: 1, VariantId: 5, Title: "Kue Test", Size: "20cm", Sku: "CHOCO-20", Quantity: 2, Message: "Happy Birthday", UnitPrice: model.NewMoney(250000, "IDR"),
+				Options: model.SelectedOptions{{Group: "flavor", GroupName: "Flavor", Choice: "lemon", ChoiceName: "Lemon", PriceModifier: model.NewMoney(20000, "IDR")}}},
+		},
+		Discounts: []*model.OrderDiscount{{CouponId: 7, Code: "HEMAT10", Amount: model.NewMoney(50000, "IDR")}},
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec("INSERT INTO orders").
+			WithArgs(1, "Budi", "0812", model.FulfillmentPickup, model.OrderStatusPending, "", "2026-10-20", nil, model.NewMoney(500000, "IDR"),
+				model.NewMoney(50000, "IDR"),
+				model.NewMoney(450000, "IDR"), "IDR", now, now).
+			WillReturnResult(sqlmock.NewResult(3, 1))
+		mock.ExpectExec("INSERT INTO order_items(.+) VALUES \\(\\?,\\?,\\?,\\?,\\?,\\?,\\?,\\?,\\?,\\?,\\?\\)$").
+			WithArgs(3, 1, 5, "Kue Test", "20cm", "CHOCO-20", 2, "Happy Birthday", order.Items[0].Options, model.NewMoney(250000, "IDR"), "IDR").
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec("INSERT INTO order_discounts(.+) VALUES \\(\\?,\\?,\\?,\\?,\\?\\)$").
+			WithArgs(3, 7, "HEMAT10", model.NewMoney(50000, "IDR"), "IDR").
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		err := repo.Save(ctx, order)
+		require.NoError(t, err)
+		assert.Equal(t, 3, order.Id)
+		assert.Equal(t, 3, order.Items[0].OrderId)
+		assert.Equal(t, 3, order.Discounts[0].OrderId)
+	})
+
+	t.Run("failed to save items", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec("INSERT INTO orders").WillReturnResult(sqlmock.NewResult(4, 1))
+		mock.ExpectExec("INSERT INTO order_items").WillReturnError(errors.New("err db"))
+		mock.ExpectRollback()
+
+		err := repo.Save(ctx, order)
+		assert.Error(t, err)
+	})
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestOrderRepository_UpdateStatus(t *testing.T) {
+	kit, closer := initializeRepoTestKit(t)
+	defer closer()
+	mock := kit.dbmock
+
+	repo := orderRepository{
+		db: kit.db,
+	}
+
+	ctx := context.TODO()
+	order := &model.Order{Id: 3, Status: model.OrderStatusConfirmed, UpdatedAt: time.Now()}
+
+	t.Run("ok", func(t *testing.T) {
+		mock.ExpectExec("UPDATE orders SET status = \\?, updated_at = \\? WHERE id = \\? AND status = \\?").
+			WithArgs(model.OrderStatusConfirmed, order.UpdatedAt, 3, model.OrderStatusPending).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := repo.UpdateStatus(ctx, order, model.OrderStatusPending)
+		require.NoError(t, err)
+	})
+
+	t.Run("moved concurrently", func(t *testing.T) {
+		mock.ExpectExec("UPDATE orders").
+			WithArgs(model.OrderStatusConfirmed, order.UpdatedAt, 3, model.OrderStatusPending).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := repo.UpdateStatus(ctx, order, model.OrderStatusPending)
+		assert.Equal(t, constant.ErrVersionConflict, err)
+	})
+}
+
+func TestOrderRepository_FindById(t *testing.T) {
+	kit, closer := initializeRepoTestKit(t)
+	defer closer()
+	mock := kit.dbmock
+
+	repo := orderRepository{
+		db: kit.db,
+	}
+
+	ctx := context.TODO()
+	orderColumns := []string{"id", "store_id", "customer_name", "customer_phone", "fulfillment", "status", "note", "pickup_date", "pickup_slot", "subtotal", "discount", "total", "currency", "created_at", "updated_at"}
+	itemColumns := []string{"id", "order_id", "cake_id", "variant_id", "title", "size", "sku", "quantity", "message", "options", "unit_price", "currency"}
+	discountColumns := []string{"id", "order_id", "coupon_id", "code", "amount", "currency"}
+
+	t.Run("ok", func(t *testing.T) {
+		mock.ExpectQuery("SELECT (.+) FROM orders WHERE id = \\?").
+			WithArgs(3).
+			WillReturnRows(sqlmock.NewRows(orderColumns).
+				AddRow(3, 1, "Budi", "0812", "pickup", "pending", "", time.Now(), nil, 500000, 50000, 450000, "IDR", time.Now(), time.Now()))
+		mock.ExpectQuery("SELECT (.+) FROM order_items WHERE order_id IN \\(\\?\\) ORDER BY id ASC").
+			WithArgs(3).
+			WillReturnRows(sqlmock.NewRows(itemColumns).
+				AddRow(1, 3, 1, 5, "Kue Test", "20cm", "CHOCO-20", 2, "", []byte(`[{"group":"flavor","group_name":"Flavor","choice":"lemon","choice_name":"Lemon","price_modifier":{"amount":20000,"currency":"IDR"}}]`), 250000, "IDR"))
+		mock.ExpectQuery("SELECT (.+) FROM order_discounts WHERE order_id IN \\(\\?\\) ORDER BY id ASC").
+			WithArgs(3).
+			WillReturnRows(sqlmock.NewRows(discountColumns).
+				AddRow(1, 3, 7, "HEMAT10", 50000, "IDR"))
+
+		res, err := repo.FindById(ctx, 3)
+		require.NoError(t, err)
+		assert.Equal(t, model.NewMoney(500000, "IDR"), res.Subtotal)
+		assert.Equal(t, model.NewMoney(50000, "IDR"), res.Discount)
+		assert.Equal(t, model.NewMoney(450000, "IDR"), res.Total)
+		require.Len(t, res.Items, 1)
+		assert.Equal(t, model.NewMoney(500000, "IDR"), res.Items[0].Subtotal)
+		require.Len(t, res.Items[0].Options, 1)
+		assert.Equal(t, model.NewMoney(20000, "IDR"), res.Items[0].Options[0].PriceModifier)
+		require.Len(t, res.Discounts, 1)
+		assert.Equal(t, "HEMAT10", res.Discounts[0].Code)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mock.ExpectQuery("SELECT (.+) FROM orders WHERE id = \\?").
+			WithArgs(4).
+			WillReturnRows(sqlmock.NewRows(orderColumns))
+
+		res, err := repo.FindById(ctx, 4)
+		require.NoError(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestOrderRepository_FindAll(t *testing.T) {
+	kit, closer := initializeRepoTestKit(t)
+	defer closer()
+	mock := kit.dbmock
+
+	repo := orderRepository{
+		db: kit.db,
+	}
+
+	ctx := context.TODO()
+	query := model.OrderQuery{Page: 2, Limit: 10, Status: model.OrderStatusReady, StoreId: 1}
+
+	t.Run("ok", func(t *testing.T) {
+		mock.ExpectQuery("SELECT (.+) FROM orders WHERE status = \\? AND store_id = \\? ORDER BY id DESC LIMIT \\? OFFSET \\?").
+			WithArgs(model.OrderStatusReady, 1, 10, 10).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "store_id", "customer_name", "customer_phone", "fulfillment", "status", "note", "pickup_date", "pickup_slot", "subtotal", "discount", "total", "currency", "created_at", "updated_at"}).
+				AddRow(12, 1, "Budi", "0812", "pickup", "ready", "", time.Now(), nil, 500000, 0, 500000, "IDR", time.Now(), time.Now()).
+				AddRow(11, 1, "Sari", "0813", "delivery", "ready", "", time.Now(), nil, 250000, 0, 250000, "IDR", time.Now(), time.Now()))
+		mock.ExpectQuery("SELECT (.+) FROM order_items WHERE order_id IN \\(\\?,\\?\\)").
+			WithArgs(12, 11).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "order_id", "cake_id", "variant_id", "title", "size", "sku", "quantity", "message", "options", "unit_price", "currency"}).
+				AddRow(1, 11, 1, 5, "Kue Test", "20cm", "CHOCO-20", 1, "", nil, 250000, "IDR").
+				AddRow(2, 12, 1, 5, "Kue Test", "20cm", "CHOCO-20", 2, "", nil, 250000, "IDR"))
+		mock.ExpectQuery("SELECT (.+) FROM order_discounts WHERE order_id IN \\(\\?,\\?\\)").
+			WithArgs(12, 11).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "order_id", "coupon_id", "code", "amount", "currency"}))
+
+		res, err := repo.FindAll(ctx, query)
+		require.NoError(t, err)
+		require.Len(t, res, 2)
+		assert.Equal(t, 2, res[0].Items[0].Quantity)
+		assert.Equal(t, 1, res[1].Items[0].Quantity)
+	})
+
+	t.Run("count", func(t *testing.T) {
+		mock.ExpectQuery("SELECT COUNT\\(id\\) FROM orders WHERE status = \\? AND store_id = \\?").
+			WithArgs(model.OrderStatusReady, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(12))
+
+		total, err := repo.CountAll(ctx, query)
+		require.NoError(t, err)
+		assert.Equal(t, int64(12), total)
+	})
+}
+
+func TestOrderRepository_FindByPickupDate(t *testing.T) {
+	kit, closer := initializeRepoTestKit(t)
+	defer closer()
+	mock := kit.dbmock
+
+	repo := orderRepository{
+		db: kit.db,
+	}
+
+	ctx := context.TODO()
+	date := time.Date(2026, 10, 20, 0, 0, 0, 0, time.Local)
+
+	t.Run("ok", func(t *testing.T) {
+		mock.ExpectQuery("SELECT (.+) FROM orders WHERE pickup_date = \\? AND status = \\? ORDER BY id ASC").
+			WithArgs("2026-10-20", model.OrderStatusConfirmed).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "store_id", "customer_name", "customer_phone", "fulfillment", "status", "note", "pickup_date", "pickup_slot", "subtotal", "discount", "total", "currency", "created_at", "updated_at"}).
+				AddRow(3, 1, "Budi", "0812", "pickup", "confirmed", "", date, nil, 500000, 0, 500000, "IDR", time.Now(), time.Now()))
+		mock.ExpectQuery("SELECT (.+) FROM order_items WHERE order_id IN \\(\\?\\)").
+			WithArgs(3).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "order_id", "cake_id", "variant_id", "title", "size", "sku", "quantity", "message", "options", "unit_price", "currency"}).
+				AddRow(1, 3, 1, 5, "Kue Test", "20cm", "CHOCO-20", 2, "", nil, 250000, "IDR"))
+		mock.ExpectQuery("SELECT (.+) FROM order_discounts WHERE order_id IN \\(\\?\\)").
+			WithArgs(3).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "order_id", "coupon_id", "code", "amount", "currency"}))
+
+		res, err := repo.FindByPickupDate(ctx, date, model.OrderStatusConfirmed)
+		require.NoError(t, err)
+		require.Len(t, res, 1)
+		assert.Equal(t, date, res[0].PickupDate)
+		assert.Equal(t, 2, res[0].Items[0].Quantity)
+	})
+
+	t.Run("failed to find", func(t *testing.T) {
+		mock.ExpectQuery("SELECT (.+) FROM orders").WillReturnError(errors.New("err db"))
+
+		res, err := repo.FindByPickupDate(ctx, date, model.OrderStatusConfirmed)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,228 @@
+package repository
+
+import (
+	"cake-store/src/constant"
+	"cake-store/src/model"
+	"context"
+	"database/sql"
+
+	"github.com/sirupsen/logrus"
+)
+
+type paymentRepository struct {
+	db *sql.DB
+}
+
+func NewPaymentRepository(db *sql.DB) model.PaymentRepository {
+	return &paymentRepository{
+		db: db,
+	}
+}
+
+// Save insert the payment and its events in one transaction
+func (p *paymentRepository) Save(ctx context.Context, payment *model.Payment) error {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Save Payment Repository",
+		"payment": payment,
+	})
+
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+	defer tx.Rollback()
+
+	query := "INSERT INTO payments(order_id,provider,reference,status,amount,currency,reason,created_at,updated_at) VALUES (?,?,?,?,?,?,?,?,?)"
+	res, err := tx.ExecContext(ctx, query, payment.OrderId, payment.Provider, payment.Reference, payment.Status, payment.Amount, payment.Amount.Currency,
+		payment.Reason, payment.CreatedAt, payment.UpdatedAt)
+	if err != nil {
+		log.Error(err)
+		return duplicateErr(err)
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+	payment.Id = int(id)
+
+	for _, event := range payment.Events {
+		if err = saveEvent(ctx, tx, payment.Id, event); err != nil {
+			log.Error(err)
+			return err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		log.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// UpdateStatus move the payment to its status only when it is still in the from status and record the event,
+// return ErrVersionConflict otherwise
+func (p *paymentRepository) UpdateStatus(ctx context.Context, payment *model.Payment, from string, event *model.PaymentEvent) error {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Update Status Payment Repository",
+		"payment": payment,
+		"from":    from,
+	})
+
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+	defer tx.Rollback()
+
+	query := "UPDATE payments SET status = ?, reason = ?, updated_at = ? WHERE id = ? AND status = ?"
+	res, err := tx.ExecContext(ctx, query, payment.Status, payment.Reason, payment.UpdatedAt, payment.Id, from)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	if affected == 0 {
+		log.Error(constant.ErrVersionConflict)
+		return constant.ErrVersionConflict
+	}
+
+	if err = saveEvent(ctx, tx, payment.Id, event); err != nil {
+		log.Error(err)
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		log.Error(err)
+		return err
+	}
+
+	payment.Events = append(payment.Events, event)
+	return nil
+}
+
+func saveEvent(ctx context.Context, tx *sql.Tx, paymentId int, event *model.PaymentEvent) error {
+	event.PaymentId = paymentId
+	query := "INSERT INTO payment_events(payment_id,from_status,to_status,reason,created_at) VALUES (?,?,?,?,?)"
+	res, err := tx.ExecContext(ctx, query, event.PaymentId, event.From, event.To, event.Reason, event.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	event.Id = int(id)
+	return nil
+}
+
+func (p *paymentRepository) FindById(ctx context.Context, id int) (*model.Payment, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Find By ID Payment Repository",
+		"id":      id,
+	})
+
+	payments, err := p.findPayments(ctx, log, "SELECT "+paymentColumns+" FROM payments WHERE id = ?", id)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(payments) == 0 {
+		return nil, nil
+	}
+	return payments[0], nil
+}
+
+func (p *paymentRepository) FindByReference(ctx context.Context, provider string, reference string) (*model.Payment, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message":   "Find By Reference Payment Repository",
+		"provider":  provider,
+		"reference": reference,
+	})
+
+	payments, err := p.findPayments(ctx, log, "SELECT "+paymentColumns+" FROM payments WHERE provider = ? AND reference = ?", provider, reference)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(payments) == 0 {
+		return nil, nil
+	}
+	return payments[0], nil
+}
+
+func (p *paymentRepository) FindByOrderId(ctx context.Context, orderId int) ([]*model.Payment, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Find By Order ID Payment Repository",
+		"orderId": orderId,
+	})
+
+	return p.findPayments(ctx, log, "SELECT "+paymentColumns+" FROM payments WHERE order_id = ? ORDER BY id DESC", orderId)
+}
+
+// findPayments find the payments of the query with their events
+func (p *paymentRepository) findPayments(ctx context.Context, log *logrus.Entry, query string, args ...interface{}) ([]*model.Payment, error) {
+	rows, err := p.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	payments := make([]*model.Payment, 0)
+	byId := make(map[int]*model.Payment)
+	for rows.Next() {
+		payment := &model.Payment{Events: make([]*model.PaymentEvent, 0)}
+		err := rows.Scan(&payment.Id, &payment.OrderId, &payment.Provider, &payment.Reference, &payment.Status, &payment.Amount, &payment.Amount.Currency,
+			&payment.Reason, &payment.CreatedAt, &payment.UpdatedAt)
+		if err != nil {
+			log.Error(err)
+			return nil, err
+		}
+		payments = append(payments, payment)
+		byId[payment.Id] = payment
+	}
+
+	if len(payments) == 0 {
+		return payments, nil
+	}
+
+	ids := make([]int, 0, len(payments))
+	for _, payment := range payments {
+		ids = append(ids, payment.Id)
+	}
+
+	query = "SELECT id, payment_id, from_status, to_status, reason, created_at FROM payment_events WHERE payment_id IN (" + placeholders(len(ids)) + ") ORDER BY id ASC"
+	eventRows, err := p.db.QueryContext(ctx, query, intArgs(ids)...)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	defer eventRows.Close()
+
+	for eventRows.Next() {
+		event := &model.PaymentEvent{}
+		if err := eventRows.Scan(&event.Id, &event.PaymentId, &event.From, &event.To, &event.Reason, &event.CreatedAt); err != nil {
+			log.Error(err)
+			return nil, err
+		}
+		if payment, ok := byId[event.PaymentId]; ok {
+			payment.Events = append(payment.Events, event)
+		}
+	}
+	return payments, nil
+}
+
+const paymentColumns = "id, order_id, provider, reference, status, amount, currency, reason, created_at, updated_at"
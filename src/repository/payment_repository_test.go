@@ -0,0 +1,204 @@
+package repository
+
+import (
+	"cake-store/src/constant"
+	"cake-store/src/model"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPaymentRepository_Save(t *testing.T) {
+	kit, closer := initializeRepoTestKit(t)
+	defer closer()
+	mock := kit.dbmock
+
+	repo := paymentRepository{
+		db: kit.db,
+	}
+
+	ctx := context.TODO()
+	now := time.Now()
+	newPayment := func() *model.Payment {
+		return &model.Payment{
+			OrderId:   4,
+			Provider:  "fake",
+			Reference: "fake_success_abc",
+			Status:    model.PaymentStatusAuthorized,
+			Amount:    model.NewMoney(2000000, "IDR"),
+			Events:    []*model.PaymentEvent{{To: model.PaymentStatusAuthorized, CreatedAt: now}},
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		payment := newPayment()
+		mock.ExpectBegin()
+		mock.ExpectExec("INSERT INTO payments\\(order_id,provider,reference,status,amount,currency,reason,created_at,updated_at\\)").
+			WithArgs(4, "fake", "fake_success_abc", model.PaymentStatusAuthorized, model.NewMoney(2000000, "IDR"), "IDR", "", now, now).
+			WillReturnResult(sqlmock.NewResult(7, 1))
+		mock.ExpectExec("INSERT INTO payment_events\\(payment_id,from_status,to_status,reason,created_at\\)").
+			WithArgs(7, "", model.PaymentStatusAuthorized, "", now).
+			WillReturnResult(sqlmock.NewResult(11, 1))
+		mock.ExpectCommit()
+
+		require.NoError(t, repo.Save(ctx, payment))
+		assert.Equal(t, 7, payment.Id)
+		assert.Equal(t, 7, payment.Events[0].PaymentId)
+		assert.Equal(t, 11, payment.Events[0].Id)
+	})
+
+	t.Run("order already has a live payment", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec("INSERT INTO payments").
+			WillReturnError(&mysql.MySQLError{Number: 1062, Message: "Duplicate entry '4' for key 'uq_payments_live'"})
+		mock.ExpectRollback()
+
+		assert.Equal(t, constant.ErrAlreadyExists, repo.Save(ctx, newPayment()))
+	})
+
+	t.Run("failed to save the event", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec("INSERT INTO payments").WillReturnResult(sqlmock.NewResult(8, 1))
+		mock.ExpectExec("INSERT INTO payment_events").WillReturnError(errors.New("err db"))
+		mock.ExpectRollback()
+
+		assert.Error(t, repo.Save(ctx, newPayment()))
+	})
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPaymentRepository_UpdateStatus(t *testing.T) {
+	kit, closer := initializeRepoTestKit(t)
+	defer closer()
+	mock := kit.dbmock
+
+	repo := paymentRepository{
+		db: kit.db,
+	}
+
+	ctx := context.TODO()
+	now := time.Now()
+
+	t.Run("ok", func(t *testing.T) {
+		payment := &model.Payment{Id: 7, Status: model.PaymentStatusCaptured, UpdatedAt: now}
+		event := &model.PaymentEvent{From: model.PaymentStatusSettling, To: model.PaymentStatusCaptured, CreatedAt: now}
+
+		mock.ExpectBegin()
+		mock.ExpectExec("UPDATE payments SET status = \\?, reason = \\?, updated_at = \\? WHERE id = \\? AND status = \\?").
+			WithArgs(model.PaymentStatusCaptured, "", now, 7, model.PaymentStatusSettling).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("INSERT INTO payment_events").
+			WithArgs(7, model.PaymentStatusSettling, model.PaymentStatusCaptured, "", now).
+			WillReturnResult(sqlmock.NewResult(12, 1))
+		mock.ExpectCommit()
+
+		require.NoError(t, repo.UpdateStatus(ctx, payment, model.PaymentStatusSettling, event))
+		require.Len(t, payment.Events, 1)
+		assert.Equal(t, 12, payment.Events[0].Id)
+	})
+
+	t.Run("moved concurrently", func(t *testing.T) {
+		payment := &model.Payment{Id: 7, Status: model.PaymentStatusCaptured, UpdatedAt: now}
+
+		mock.ExpectBegin()
+		mock.ExpectExec("UPDATE payments").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		err := repo.UpdateStatus(ctx, payment, model.PaymentStatusSettling, &model.PaymentEvent{})
+		assert.Equal(t, constant.ErrVersionConflict, err)
+		assert.Empty(t, payment.Events)
+	})
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPaymentRepository_FindByOrderId(t *testing.T) {
+	kit, closer := initializeRepoTestKit(t)
+	defer closer()
+	mock := kit.dbmock
+
+	repo := paymentRepository{
+		db: kit.db,
+	}
+
+	ctx := context.TODO()
+	columns := []string{"id", "order_id", "provider", "reference", "status", "amount", "currency", "reason", "created_at", "updated_at"}
+	eventColumns := []string{"id", "payment_id", "from_status", "to_status", "reason", "created_at"}
+
+	t.Run("ok", func(t *testing.T) {
+		mock.ExpectQuery("SELECT (.+) FROM payments WHERE order_id = \\? ORDER BY id DESC").
+			WithArgs(4).
+			WillReturnRows(sqlmock.NewRows(columns).
+				AddRow(8, 4, "fake", "fake_success_def", model.PaymentStatusCaptured, 2000000, "IDR", "", time.Now(), time.Now()).
+				AddRow(7, 4, "fake", "fake_declined_abc", model.PaymentStatusDeclined, 2000000, "IDR", "card declined", time.Now(), time.Now()))
+		mock.ExpectQuery("SELECT (.+) FROM payment_events WHERE payment_id IN \\(\\?,\\?\\) ORDER BY id ASC").
+			WithArgs(8, 7).
+			WillReturnRows(sqlmock.NewRows(eventColumns).
+				AddRow(11, 7, "", model.PaymentStatusDeclined, "card declined", time.Now()).
+				AddRow(12, 8, "", model.PaymentStatusAuthorized, "", time.Now()).
+				AddRow(13, 8, model.PaymentStatusAuthorized, model.PaymentStatusCaptured, "", time.Now()))
+
+		res, err := repo.FindByOrderId(ctx, 4)
+		require.NoError(t, err)
+		require.Len(t, res, 2)
+		assert.Equal(t, model.NewMoney(2000000, "IDR"), res[0].Amount)
+		require.Len(t, res[0].Events, 2)
+		assert.Equal(t, model.PaymentStatusCaptured, res[0].Events[1].To)
+		require.Len(t, res[1].Events, 1)
+		assert.Equal(t, "card declined", res[1].Reason)
+	})
+
+	t.Run("no payment", func(t *testing.T) {
+		mock.ExpectQuery("SELECT (.+) FROM payments WHERE order_id = \\?").
+			WithArgs(5).
+			WillReturnRows(sqlmock.NewRows(columns))
+
+		res, err := repo.FindByOrderId(ctx, 5)
+		require.NoError(t, err)
+		assert.Empty(t, res)
+	})
+
+	t.Run("failed to find", func(t *testing.T) {
+		mock.ExpectQuery("SELECT (.+) FROM payments").WillReturnError(errors.New("err db"))
+
+		res, err := repo.FindByOrderId(ctx, 4)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPaymentRepository_FindByReference(t *testing.T) {
+	kit, closer := initializeRepoTestKit(t)
+	defer closer()
+	mock := kit.dbmock
+
+	repo := paymentRepository{
+		db: kit.db,
+	}
+
+	ctx := context.TODO()
+	columns := []string{"id", "order_id", "provider", "reference", "status", "amount", "currency", "reason", "created_at", "updated_at"}
+
+	t.Run("not found", func(t *testing.T) {
+		mock.ExpectQuery("SELECT (.+) FROM payments WHERE provider = \\? AND reference = \\?").
+			WithArgs("fake", "fake_delayed_abc").
+			WillReturnRows(sqlmock.NewRows(columns))
+
+		res, err := repo.FindByReference(ctx, "fake", "fake_delayed_abc")
+		require.NoError(t, err)
+		assert.Nil(t, res)
+	})
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
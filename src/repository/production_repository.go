@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"cake-store/src/model"
+	"context"
+	"database/sql"
+
+	"github.com/sirupsen/logrus"
+)
+
+type productionRepository struct {
+	db *sql.DB
+}
+
+func NewProductionRepository(db *sql.DB) model.ProductionRepository {
+	return &productionRepository{
+		db: db,
+	}
+}
+
+// Save create or replace the production settings of the cake
+func (p *productionRepository) Save(ctx context.Context, production *model.CakeProduction) error {
+	log := logrus.WithFields(logrus.Fields{
+		"message":    "Save Production Repository",
+		"production": production,
+	})
+
+	query := "INSERT INTO cake_production(cake_id,prep_minutes,bake_minutes,batch_size,updated_at) VALUES (?,?,?,?,?) " +
+		"ON DUPLICATE KEY UPDATE prep_minutes = VALUES(prep_minutes), bake_minutes = VALUES(bake_minutes), " +
+		"batch_size = VALUES(batch_size), updated_at = VALUES(updated_at)"
+	_, err := p.db.ExecContext(ctx, query, production.CakeId, production.PrepMinutes, production.BakeMinutes, production.BatchSize, production.UpdatedAt)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+func (p *productionRepository) FindByCakeIds(ctx context.Context, cakeIds []int) ([]*model.CakeProduction, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Find By Cake IDs Production Repository",
+		"cakeIds": cakeIds,
+	})
+
+	productions := make([]*model.CakeProduction, 0)
+	if len(cakeIds) == 0 {
+		return productions, nil
+	}
+
+	sql := "SELECT cake_id, prep_minutes, bake_minutes, batch_size, updated_at FROM cake_production WHERE cake_id IN (" + placeholders(len(cakeIds)) + ")"
+	rows, err := p.db.QueryContext(ctx, sql, intArgs(cakeIds)...)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		production := &model.CakeProduction{}
+		err := rows.Scan(&production.CakeId, &production.PrepMinutes, &production.BakeMinutes, &production.BatchSize, &production.UpdatedAt)
+		if err != nil {
+			log.Error(err)
+			return nil, err
+		}
+		productions = append(productions, production)
+	}
+	return productions, nil
+}
@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"cake-store/src/model"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProductionRepository_Save(t *testing.T) {
+	kit, closer := initializeRepoTestKit(t)
+	defer closer()
+	mock := kit.dbmock
+
+	repo := productionRepository{
+		db: kit.db,
+	}
+
+	ctx := context.TODO()
+	production := &model.CakeProduction{CakeId: 1, PrepMinutes: 30, BakeMinutes: 45, BatchSize: 4, UpdatedAt: time.Now()}
+
+	t.Run("ok", func(t *testing.T) {
+		mock.ExpectExec("INSERT INTO cake_production(.+) ON DUPLICATE KEY UPDATE").
+			WithArgs(1, 30, 45, 4, production.UpdatedAt).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := repo.Save(ctx, production)
+		require.NoError(t, err)
+	})
+
+	t.Run("failed to save", func(t *testing.T) {
+		mock.ExpectExec("INSERT INTO cake_production").WillReturnError(errors.New("err db"))
+
+		err := repo.Save(ctx, production)
+		assert.Error(t, err)
+	})
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestProductionRepository_FindByCakeIds(t *testing.T) {
+	kit, closer := initializeRepoTestKit(t)
+	defer closer()
+	mock := kit.dbmock
+
+	repo := productionRepository{
+		db: kit.db,
+	}
+
+	ctx := context.TODO()
+
+	t.Run("ok", func(t *testing.T) {
+		mock.ExpectQuery("SELECT (.+) FROM cake_production WHERE cake_id IN \\(\\?,\\?\\)").
+			WithArgs(1, 2).
+			WillReturnRows(sqlmock.NewRows([]string{"cake_id", "prep_minutes", "bake_minutes", "batch_size", "updated_at"}).
+				AddRow(1, 30, 45, 4, time.Now()))
+
+		res, err := repo.FindByCakeIds(ctx, []int{1, 2})
+		require.NoError(t, err)
+		require.Len(t, res, 1)
+		assert.Equal(t, 4, res[0].BatchSize)
+	})
+
+	t.Run("ok - no cakes", func(t *testing.T) {
+		res, err := repo.FindByCakeIds(ctx, nil)
+		require.NoError(t, err)
+		assert.Empty(t, res)
+	})
+
+	t.Run("failed to find", func(t *testing.T) {
+		mock.ExpectQuery("SELECT (.+) FROM cake_production").WillReturnError(errors.New("err db"))
+
+		res, err := repo.FindByCakeIds(ctx, []int{1})
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
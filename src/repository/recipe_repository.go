@@ -0,0 +1,220 @@
+package repository
+
+import (
+	"cake-store/src/model"
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+type recipeRepository struct {
+	db *sql.DB
+}
+
+func NewRecipeRepository(db *sql.DB) model.RecipeRepository {
+	return &recipeRepository{
+		db: db,
+	}
+}
+
+func (r *recipeRepository) Save(ctx context.Context, recipe *model.Recipe) error {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Save Recipe Repository",
+		"recipe":  recipe,
+	})
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+	defer tx.Rollback()
+
+	query := "INSERT INTO recipes(cake_id,yield,created_at,updated_at) VALUES (?,?,?,?)"
+	res, err := tx.ExecContext(ctx, query, recipe.CakeId, recipe.Yield, recipe.CreatedAt, recipe.UpdatedAt)
+	if err != nil {
+		log.Error(err)
+		return duplicateErr(err)
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	if err = insertRecipeLines(ctx, tx, int(id), recipe); err != nil {
+		log.Error(err)
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		log.Error(err)
+		return err
+	}
+
+	recipe.Id = int(id)
+	return nil
+}
+
+// Update replace the yield, steps and ingredients of the recipe
+func (r *recipeRepository) Update(ctx context.Context, recipe *model.Recipe) error {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Update Recipe Repository",
+		"recipe":  recipe,
+	})
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err = tx.ExecContext(ctx, "UPDATE recipes SET yield = ?, updated_at = ? WHERE id = ?", recipe.Yield, recipe.UpdatedAt, recipe.Id); err != nil {
+		log.Error(err)
+		return err
+	}
+
+	if _, err = tx.ExecContext(ctx, "DELETE FROM recipe_steps WHERE recipe_id = ?", recipe.Id); err != nil {
+		log.Error(err)
+		return err
+	}
+
+	if _, err = tx.ExecContext(ctx, "DELETE FROM recipe_ingredients WHERE recipe_id = ?", recipe.Id); err != nil {
+		log.Error(err)
+		return err
+	}
+
+	if err = insertRecipeLines(ctx, tx, recipe.Id, recipe); err != nil {
+		log.Error(err)
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		log.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *recipeRepository) Delete(ctx context.Context, recipe *model.Recipe) error {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Delete Recipe Repository",
+		"recipe":  recipe,
+	})
+
+	if _, err := r.db.ExecContext(ctx, "DELETE FROM recipes WHERE id = ?", recipe.Id); err != nil {
+		log.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// FindByCakeId find the recipe of the cake with its steps and ingredients in order
+func (r *recipeRepository) FindByCakeId(ctx context.Context, cakeId int) (*model.Recipe, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Find By Cake ID Recipe Repository",
+		"cakeId":  cakeId,
+	})
+
+	recipe := &model.Recipe{}
+	row := r.db.QueryRowContext(ctx, "SELECT id, cake_id, yield, created_at, updated_at FROM recipes WHERE cake_id = ?", cakeId)
+	if err := row.Scan(&recipe.Id, &recipe.CakeId, &recipe.Yield, &recipe.CreatedAt, &recipe.UpdatedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		log.Error(err)
+		return nil, err
+	}
+	recipe.Servings = recipe.Yield
+
+	if err := r.loadSteps(ctx, recipe); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	if err := r.loadIngredients(ctx, recipe); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return recipe, nil
+}
+
+func (r *recipeRepository) loadSteps(ctx context.Context, recipe *model.Recipe) error {
+	sql := "SELECT position, instruction, minutes FROM recipe_steps WHERE recipe_id = ? ORDER BY position ASC"
+	rows, err := r.db.QueryContext(ctx, sql, recipe.Id)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	recipe.Steps = make([]*model.RecipeStep, 0)
+	for rows.Next() {
+		step := &model.RecipeStep{}
+		if err := rows.Scan(&step.Position, &step.Instruction, &step.Minutes); err != nil {
+			return err
+		}
+		recipe.Steps = append(recipe.Steps, step)
+	}
+	return nil
+}
+
+func (r *recipeRepository) loadIngredients(ctx context.Context, recipe *model.Recipe) error {
+	sql := "SELECT ri.ingredient_id, i.name, i.density, ri.quantity, ri.unit FROM recipe_ingredients ri " +
+		"JOIN ingredients i ON i.id = ri.ingredient_id WHERE ri.recipe_id = ? ORDER BY ri.position ASC"
+	rows, err := r.db.QueryContext(ctx, sql, recipe.Id)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	recipe.Ingredients = make([]*model.RecipeIngredient, 0)
+	for rows.Next() {
+		ingredient := &model.RecipeIngredient{}
+		if err := rows.Scan(&ingredient.IngredientId, &ingredient.Name, &ingredient.Density, &ingredient.Quantity, &ingredient.Unit); err != nil {
+			return err
+		}
+		recipe.Ingredients = append(recipe.Ingredients, ingredient)
+	}
+	return nil
+}
+
+// insertRecipeLines insert the steps and ingredients of the recipe numbered in their order
+func insertRecipeLines(ctx context.Context, tx *sql.Tx, recipeId int, recipe *model.Recipe) error {
+	if len(recipe.Steps) > 0 {
+		values := make([]string, 0, len(recipe.Steps))
+		args := make([]interface{}, 0, len(recipe.Steps)*4)
+		for idx, step := range recipe.Steps {
+			step.Position = idx + 1
+			values = append(values, "(?,?,?,?)")
+			args = append(args, recipeId, step.Position, step.Instruction, step.Minutes)
+		}
+
+		query := "INSERT INTO recipe_steps(recipe_id,position,instruction,minutes) VALUES " + strings.Join(values, ",")
+		if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+			return err
+		}
+	}
+
+	if len(recipe.Ingredients) > 0 {
+		values := make([]string, 0, len(recipe.Ingredients))
+		args := make([]interface{}, 0, len(recipe.Ingredients)*5)
+		for idx, ingredient := range recipe.Ingredients {
+			values = append(values, "(?,?,?,?,?)")
+			args = append(args, recipeId, idx+1, ingredient.IngredientId, ingredient.Quantity, ingredient.Unit)
+		}
+
+		query := "INSERT INTO recipe_ingredients(recipe_id,position,ingredient_id,quantity,unit) VALUES " + strings.Join(values, ",")
+		if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,197 @@
+package repository
+
+import (
+	"cake-store/src/model"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecipeRepository_Create(t *testing.T) {
+	kit, closer := initializeRepoTestKit(t)
+	defer closer()
+	mock := kit.dbmock
+
+	repo := recipeRepository{
+		db: kit.db,
+	}
+
+	ctx := context.TODO()
+
+	recipe := &model.Recipe{
+		CakeId: 1,
+		Yield:  8,
+		Steps: []*model.RecipeStep{
+			{Instruction: "Cream the butter and sugar", Minutes: 5},
+			{Instruction: "Bake", Minutes: 35},
+		},
+		Ingredients: []*model.RecipeIngredient{
+			{IngredientId: 2, Quantity: 250, Unit: model.UnitGram},
+			{IngredientId: 3, Quantity: 1, Unit: model.UnitCup},
+		},
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec("INSERT INTO recipes").
+			WithArgs(recipe.CakeId, recipe.Yield, recipe.CreatedAt, recipe.UpdatedAt).
+			WillReturnResult(sqlmock.NewResult(4, 1))
+		mock.ExpectExec("INSERT INTO recipe_steps\\(recipe_id,position,instruction,minutes\\) VALUES \\(\\?,\\?,\\?,\\?\\),\\(\\?,\\?,\\?,\\?\\)").
+			WithArgs(4, 1, "Cream the butter and sugar", 5, 4, 2, "Bake", 35).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectExec("INSERT INTO recipe_ingredients\\(recipe_id,position,ingredient_id,quantity,unit\\) VALUES \\(\\?,\\?,\\?,\\?,\\?\\),\\(\\?,\\?,\\?,\\?,\\?\\)").
+			WithArgs(4, 1, 2, 250.0, "g", 4, 2, 3, 1.0, "cup").
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectCommit()
+
+		err := repo.Save(ctx, recipe)
+		require.NoError(t, err)
+		assert.Equal(t, 4, recipe.Id)
+		assert.Equal(t, 2, recipe.Steps[1].Position)
+	})
+
+	t.Run("failed to insert steps", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec("INSERT INTO recipes").
+			WithArgs(recipe.CakeId, recipe.Yield, recipe.CreatedAt, recipe.UpdatedAt).
+			WillReturnResult(sqlmock.NewResult(5, 1))
+		mock.ExpectExec("INSERT INTO recipe_steps").
+			WillReturnError(errors.New("db error"))
+		mock.ExpectRollback()
+
+		err := repo.Save(ctx, recipe)
+		require.Error(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRecipeRepository_Update(t *testing.T) {
+	kit, closer := initializeRepoTestKit(t)
+	defer closer()
+	mock := kit.dbmock
+
+	repo := recipeRepository{
+		db: kit.db,
+	}
+
+	ctx := context.TODO()
+
+	recipe := &model.Recipe{
+		Id:          4,
+		CakeId:      1,
+		Yield:       12,
+		Steps:       []*model.RecipeStep{{Instruction: "Bake", Minutes: 40}},
+		Ingredients: []*model.RecipeIngredient{{IngredientId: 2, Quantity: 0.5, Unit: model.UnitKilogram}},
+		UpdatedAt:   time.Now(),
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec("UPDATE recipes SET yield = \\?, updated_at = \\? WHERE id = \\?").
+			WithArgs(recipe.Yield, recipe.UpdatedAt, recipe.Id).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("DELETE FROM recipe_steps WHERE recipe_id = \\?").
+			WithArgs(recipe.Id).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectExec("DELETE FROM recipe_ingredients WHERE recipe_id = \\?").
+			WithArgs(recipe.Id).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectExec("INSERT INTO recipe_steps").
+			WithArgs(4, 1, "Bake", 40).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("INSERT INTO recipe_ingredients").
+			WithArgs(4, 1, 2, 0.5, "kg").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err := repo.Update(ctx, recipe)
+		require.NoError(t, err)
+	})
+}
+
+func TestRecipeRepository_Delete(t *testing.T) {
+	kit, closer := initializeRepoTestKit(t)
+	defer closer()
+	mock := kit.dbmock
+
+	repo := recipeRepository{
+		db: kit.db,
+	}
+
+	ctx := context.TODO()
+
+	t.Run("ok", func(t *testing.T) {
+		mock.ExpectExec("DELETE FROM recipes WHERE id = \\?").
+			WithArgs(4).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		err := repo.Delete(ctx, &model.Recipe{Id: 4})
+		require.NoError(t, err)
+	})
+}
+
+func TestRecipeRepository_FindByCakeId(t *testing.T) {
+	kit, closer := initializeRepoTestKit(t)
+	defer closer()
+	mock := kit.dbmock
+
+	repo := recipeRepository{
+		db: kit.db,
+	}
+
+	ctx := context.TODO()
+
+	t.Run("ok", func(t *testing.T) {
+		mock.ExpectQuery("SELECT (.+) FROM recipes WHERE cake_id = \\?").
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "cake_id", "yield", "created_at", "updated_at"}).
+				AddRow(4, 1, 8, time.Now(), time.Now()))
+		mock.ExpectQuery("SELECT (.+) FROM recipe_steps WHERE recipe_id = \\? ORDER BY position ASC").
+			WithArgs(4).
+			WillReturnRows(sqlmock.NewRows([]string{"position", "instruction", "minutes"}).
+				AddRow(1, "Cream the butter and sugar", 5).
+				AddRow(2, "Bake", 35))
+		mock.ExpectQuery("SELECT (.+) FROM recipe_ingredients ri JOIN ingredients i (.+) WHERE ri.recipe_id = \\? ORDER BY ri.position ASC").
+			WithArgs(4).
+			WillReturnRows(sqlmock.NewRows([]string{"ingredient_id", "name", "density", "quantity", "unit"}).
+				AddRow(2, "Flour", 0.53, 250, "g").
+				AddRow(3, "Milk", 1.03, 1, "cup"))
+
+		res, err := repo.FindByCakeId(ctx, 1)
+		require.NoError(t, err)
+		assert.Equal(t, 8, res.Servings)
+		assert.Equal(t, 2, len(res.Steps))
+		assert.Equal(t, "Milk", res.Ingredients[1].Name)
+		assert.Equal(t, 1.03, res.Ingredients[1].Density)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mock.ExpectQuery("SELECT (.+) FROM recipes WHERE cake_id = \\?").
+			WithArgs(2).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "cake_id", "yield", "created_at", "updated_at"}))
+
+		res, err := repo.FindByCakeId(ctx, 2)
+		require.NoError(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("failed to load steps", func(t *testing.T) {
+		mock.ExpectQuery("SELECT (.+) FROM recipes WHERE cake_id = \\?").
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "cake_id", "yield", "created_at", "updated_at"}).
+				AddRow(4, 1, 8, time.Now(), time.Now()))
+		mock.ExpectQuery("SELECT (.+) FROM recipe_steps").
+			WithArgs(4).
+			WillReturnError(errors.New("db error"))
+
+		res, err := repo.FindByCakeId(ctx, 1)
+		require.Error(t, err)
+		assert.Nil(t, res)
+	})
+}
@@ -0,0 +1,263 @@
+package repository
+
+import (
+	"cake-store/src/config"
+	"cake-store/src/constant"
+	"cake-store/src/model"
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+type reviewRepository struct {
+	db *sql.DB
+}
+
+func NewReviewRepository(db *sql.DB) model.ReviewRepository {
+	return &reviewRepository{
+		db: db,
+	}
+}
+
+// Save insert the review and recompute the rating of its cake
+func (r *reviewRepository) Save(ctx context.Context, review *model.Review) error {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Save Review Repository",
+		"review":  review,
+	})
+
+	return r.write(ctx, log, review.CakeId, func(tx *sql.Tx) error {
+		query := "INSERT INTO reviews(cake_id,score,text,author,status,screening,moderation_note,moderated_at,fingerprint,created_at,updated_at) " +
+			"VALUES (?,?,?,?,?,?,?,?,?,?,?)"
+		res, err := tx.ExecContext(ctx, query, review.CakeId, review.Score, review.Text, review.Author, review.Status, strings.Join(review.Screening, ","),
+			review.ModerationNote, review.ModeratedAt, review.Fingerprint, review.CreatedAt, review.UpdatedAt)
+		if err != nil {
+			return err
+		}
+
+		id, err := res.LastInsertId()
+		if err != nil {
+			return err
+		}
+
+		review.Id = int(id)
+		return nil
+	})
+}
+
+// Update save the review and recompute the rating of its cake
+func (r *reviewRepository) Update(ctx context.Context, review *model.Review) error {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Update Review Repository",
+		"review":  review,
+	})
+
+	return r.write(ctx, log, review.CakeId, func(tx *sql.Tx) error {
+		query := "UPDATE reviews SET score = ?, text = ?, author = ?, status = ?, screening = ?, moderation_note = ?, moderated_at = ?, " +
+			"fingerprint = ?, updated_at = ? WHERE id = ?"
+		_, err := tx.ExecContext(ctx, query, review.Score, review.Text, review.Author, review.Status, strings.Join(review.Screening, ","),
+			review.ModerationNote, review.ModeratedAt, review.Fingerprint, review.UpdatedAt, review.Id)
+		return err
+	})
+}
+
+// Delete remove the review and recompute the rating of its cake
+func (r *reviewRepository) Delete(ctx context.Context, review *model.Review) error {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Delete Review Repository",
+		"review":  review,
+	})
+
+	return r.write(ctx, log, review.CakeId, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, "DELETE FROM reviews WHERE id = ?", review.Id)
+		return err
+	})
+}
+
+func (r *reviewRepository) FindById(ctx context.Context, id int) (*model.Review, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Find By ID Review Repository",
+		"id":      id,
+	})
+
+	sql := "SELECT " + reviewColumns + " FROM reviews WHERE id = ?"
+	reviews, err := r.findReviews(ctx, log, sql, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(reviews) == 0 {
+		return nil, nil
+	}
+	return reviews[0], nil
+}
+
+// FindAll find the page of reviews, newest first
+func (r *reviewRepository) FindAll(ctx context.Context, query model.ReviewQuery) ([]*model.Review, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Find All Review Repository",
+		"query":   query,
+	})
+
+	conditions, args := reviewFilter(query)
+	sql := "SELECT " + reviewColumns + " FROM reviews" + where(conditions) + " ORDER BY id DESC LIMIT ? OFFSET ?"
+	args = append(args, query.Limit, model.Offset(query.Page, query.Limit))
+	return r.findReviews(ctx, log, sql, args...)
+}
+
+func (r *reviewRepository) CountAll(ctx context.Context, query model.ReviewQuery) (int64, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Count All Review Repository",
+		"query":   query,
+	})
+
+	conditions, args := reviewFilter(query)
+	sql := "SELECT COUNT(id) FROM reviews" + where(conditions)
+
+	var total int64
+	if err := r.db.QueryRowContext(ctx, sql, args...).Scan(&total); err != nil {
+		log.Error(err)
+		return 0, err
+	}
+
+	return total, nil
+}
+
+// ExistsFingerprint report whether the cake already has a review of the same text, in any status
+func (r *reviewRepository) ExistsFingerprint(ctx context.Context, cakeId int, fingerprint string) (bool, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message":     "Exists Fingerprint Review Repository",
+		"cakeId":      cakeId,
+		"fingerprint": fingerprint,
+	})
+
+	var exists bool
+	sql := "SELECT EXISTS(SELECT 1 FROM reviews WHERE cake_id = ? AND fingerprint = ?)"
+	if err := r.db.QueryRowContext(ctx, sql, cakeId, fingerprint).Scan(&exists); err != nil {
+		log.Error(err)
+		return false, err
+	}
+
+	return exists, nil
+}
+
+// CountByAuthorSince count the reviews written by the author since the time, on every cake
+func (r *reviewRepository) CountByAuthorSince(ctx context.Context, author string, since time.Time) (int64, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Count By Author Since Review Repository",
+		"author":  author,
+		"since":   since,
+	})
+
+	var total int64
+	sql := "SELECT COUNT(id) FROM reviews WHERE author = ? AND created_at >= ?"
+	if err := r.db.QueryRowContext(ctx, sql, author, since).Scan(&total); err != nil {
+		log.Error(err)
+		return 0, err
+	}
+
+	return total, nil
+}
+
+// write run the review change in a transaction that also store the new rating of the cake from its approved reviews,
+// the cake row is locked first so concurrent reviews of one cake are aggregated one after another
+func (r *reviewRepository) write(ctx context.Context, log *logrus.Entry, cakeId int, change func(tx *sql.Tx) error) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+	defer tx.Rollback()
+
+	var id int
+	err = tx.QueryRowContext(ctx, "SELECT id FROM cakes WHERE id = ? FOR UPDATE", cakeId).Scan(&id)
+	if err == sql.ErrNoRows {
+		log.Error(err)
+		return constant.ErrNotFound
+	}
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	if err = change(tx); err != nil {
+		log.Error(err)
+		return err
+	}
+
+	var count, sum int
+	err = tx.QueryRowContext(ctx, "SELECT COUNT(id), COALESCE(SUM(score), 0) FROM reviews WHERE cake_id = ? AND status = ?", cakeId,
+		model.ReviewStatusApproved).Scan(&count, &sum)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	rating := model.NewCakeRating(count, sum, config.RatingPriorWeight(), config.RatingPriorMean())
+	query := "UPDATE cakes SET rating = ?, rating_mean = ?, rating_count = ? WHERE id = ?"
+	if rating.Count == 0 {
+		// a cake rated before the reviews keep its typed in rating until one of its reviews is approved
+		query = "UPDATE cakes SET rating = COALESCE(legacy_rating, ?), rating_mean = ?, rating_count = ? WHERE id = ?"
+	}
+	if _, err = tx.ExecContext(ctx, query, rating.Rating, rating.Mean, rating.Count, cakeId); err != nil {
+		log.Error(err)
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		log.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+func reviewFilter(query model.ReviewQuery) ([]string, []interface{}) {
+	var (
+		conditions []string
+		args       []interface{}
+	)
+
+	if query.CakeId > 0 {
+		conditions = append(conditions, "cake_id = ?")
+		args = append(args, query.CakeId)
+	}
+	if query.Status != "" {
+		conditions = append(conditions, "status = ?")
+		args = append(args, query.Status)
+	}
+
+	return conditions, args
+}
+
+const reviewColumns = "id, cake_id, score, text, author, status, screening, moderation_note, moderated_at, fingerprint, created_at, updated_at"
+
+func (r *reviewRepository) findReviews(ctx context.Context, log *logrus.Entry, sql string, args ...interface{}) ([]*model.Review, error) {
+	rows, err := r.db.QueryContext(ctx, sql, args...)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	reviews := make([]*model.Review, 0)
+	for rows.Next() {
+		review := &model.Review{}
+		var screening string
+		err := rows.Scan(&review.Id, &review.CakeId, &review.Score, &review.Text, &review.Author, &review.Status, &screening,
+			&review.ModerationNote, &review.ModeratedAt, &review.Fingerprint, &review.CreatedAt, &review.UpdatedAt)
+		if err != nil {
+			log.Error(err)
+			return nil, err
+		}
+		if screening != "" {
+			review.Screening = strings.Split(screening, ",")
+		}
+		reviews = append(reviews, review)
+	}
+
+	return reviews, nil
+}
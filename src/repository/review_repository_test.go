@@ -0,0 +1,195 @@
+package repository
+
+import (
+	"cake-store/src/constant"
+	"cake-store/src/model"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var reviewRowColumns = []string{"id", "cake_id", "score", "text", "author", "status", "screening", "moderation_note", "moderated_at", "fingerprint",
+	"created_at", "updated_at"}
+
+func TestReviewRepository_Save(t *testing.T) {
+	kit, closer := initializeRepoTestKit(t)
+	defer closer()
+	mock := kit.dbmock
+
+	repo := reviewRepository{
+		db: kit.db,
+	}
+
+	ctx := context.TODO()
+	now := time.Now()
+	review := &model.Review{CakeId: 1, Score: 9, Text: "Enak", Author: "Sari", Status: model.ReviewStatusFlagged,
+		Screening: []string{model.ScreeningReasonLink, model.ScreeningReasonBannedWord}, Fingerprint: "abc", CreatedAt: now, UpdatedAt: now}
+
+	t.Run("ok", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT id FROM cakes WHERE id = \\? FOR UPDATE").
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+		mock.ExpectExec("INSERT INTO reviews").
+			WithArgs(1, 9, "Enak", "Sari", model.ReviewStatusFlagged, "link,banned_word", "", nil, "abc", now, now).
+			WillReturnResult(sqlmock.NewResult(3, 1))
+		mock.ExpectQuery("SELECT COUNT\\(id\\), COALESCE\\(SUM\\(score\\), 0\\) FROM reviews WHERE cake_id = \\? AND status = \\?").
+			WithArgs(1, model.ReviewStatusApproved).
+			WillReturnRows(sqlmock.NewRows([]string{"count", "sum"}).AddRow(2, 15))
+		mock.ExpectExec("UPDATE cakes SET rating = \\?, rating_mean = \\?, rating_count = \\? WHERE id = \\?").
+			WithArgs(float32(7.5), float32(7.5), 2, 1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err := repo.Save(ctx, review)
+		require.NoError(t, err)
+		assert.Equal(t, 3, review.Id)
+	})
+
+	t.Run("cake not found", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT id FROM cakes").
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		mock.ExpectRollback()
+
+		err := repo.Save(ctx, review)
+		assert.Equal(t, constant.ErrNotFound, err)
+	})
+
+	t.Run("failed to save review", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT id FROM cakes").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+		mock.ExpectExec("INSERT INTO reviews").WillReturnError(errors.New("err db"))
+		mock.ExpectRollback()
+
+		err := repo.Save(ctx, review)
+		assert.Error(t, err)
+	})
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReviewRepository_Delete(t *testing.T) {
+	kit, closer := initializeRepoTestKit(t)
+	defer closer()
+	mock := kit.dbmock
+
+	repo := reviewRepository{
+		db: kit.db,
+	}
+
+	ctx := context.TODO()
+
+	t.Run("ok - last review", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT id FROM cakes").
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+		mock.ExpectExec("DELETE FROM reviews WHERE id = \\?").
+			WithArgs(3).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery("SELECT COUNT\\(id\\)").
+			WithArgs(1, model.ReviewStatusApproved).
+			WillReturnRows(sqlmock.NewRows([]string{"count", "sum"}).AddRow(0, 0))
+		mock.ExpectExec("UPDATE cakes SET rating = COALESCE\\(legacy_rating, \\?\\), rating_mean = \\?, rating_count = \\? WHERE id = \\?").
+			WithArgs(float32(0), float32(0), 0, 1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err := repo.Delete(ctx, &model.Review{Id: 3, CakeId: 1})
+		require.NoError(t, err)
+	})
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReviewRepository_FindAll(t *testing.T) {
+	kit, closer := initializeRepoTestKit(t)
+	defer closer()
+	mock := kit.dbmock
+
+	repo := reviewRepository{
+		db: kit.db,
+	}
+
+	ctx := context.TODO()
+
+	t.Run("ok", func(t *testing.T) {
+		mock.ExpectQuery("SELECT (.+) FROM reviews WHERE cake_id = \\? AND status = \\? ORDER BY id DESC LIMIT \\? OFFSET \\?").
+			WithArgs(1, model.ReviewStatusApproved, 10, 10).
+			WillReturnRows(sqlmock.NewRows(reviewRowColumns).
+				AddRow(4, 1, 8, "Enak", "Sari", "approved", "", "", time.Now(), "abc", time.Now(), time.Now()).
+				AddRow(3, 1, 6, "", "Budi", "approved", "link", "ok", time.Now(), "", time.Now(), time.Now()))
+
+		res, err := repo.FindAll(ctx, model.ReviewQuery{Page: 2, Limit: 10, CakeId: 1, Status: model.ReviewStatusApproved})
+		require.NoError(t, err)
+		require.Len(t, res, 2)
+		assert.Nil(t, res[0].Screening)
+		assert.Equal(t, []string{model.ScreeningReasonLink}, res[1].Screening)
+		assert.Equal(t, "Budi", res[1].Author)
+	})
+
+	t.Run("count", func(t *testing.T) {
+		mock.ExpectQuery("SELECT COUNT\\(id\\) FROM reviews WHERE status = \\?").
+			WithArgs(model.ReviewStatusPending).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(12))
+
+		total, err := repo.CountAll(ctx, model.ReviewQuery{Status: model.ReviewStatusPending})
+		require.NoError(t, err)
+		assert.Equal(t, int64(12), total)
+	})
+
+	t.Run("find by id - not found", func(t *testing.T) {
+		mock.ExpectQuery("SELECT (.+) FROM reviews WHERE id = \\?").
+			WithArgs(9).
+			WillReturnRows(sqlmock.NewRows(reviewRowColumns))
+
+		res, err := repo.FindById(ctx, 9)
+		require.NoError(t, err)
+		assert.Nil(t, res)
+	})
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReviewRepository_Screening(t *testing.T) {
+	kit, closer := initializeRepoTestKit(t)
+	defer closer()
+	mock := kit.dbmock
+
+	repo := reviewRepository{
+		db: kit.db,
+	}
+
+	ctx := context.TODO()
+
+	t.Run("exists fingerprint", func(t *testing.T) {
+		mock.ExpectQuery("SELECT EXISTS\\(SELECT 1 FROM reviews WHERE cake_id = \\? AND fingerprint = \\?\\)").
+			WithArgs(1, "abc").
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+
+		exists, err := repo.ExistsFingerprint(ctx, 1, "abc")
+		require.NoError(t, err)
+		assert.True(t, exists)
+	})
+
+	t.Run("count by author", func(t *testing.T) {
+		since := time.Now().Add(-time.Hour)
+		mock.ExpectQuery("SELECT COUNT\\(id\\) FROM reviews WHERE author = \\? AND created_at >= \\?").
+			WithArgs("Sari", since).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+
+		total, err := repo.CountByAuthorSince(ctx, "Sari", since)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), total)
+	})
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
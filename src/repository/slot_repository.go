@@ -0,0 +1,390 @@
+package repository
+
+import (
+	"cake-store/src/config"
+	"cake-store/src/constant"
+	"cake-store/src/model"
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/sirupsen/logrus"
+)
+
+// bookSlotScript seed the booking counter of the slot when it has none and add the units only when they still fit in
+// the capacity, it return -1 when the slot is full. A booking mark the slot as changed for the reconcile grace
+var bookSlotScript = redis.NewScript(`
+redis.call("SET", KEYS[1], ARGV[1], "NX", "PX", ARGV[4])
+local booked = tonumber(redis.call("GET", KEYS[1]))
+if booked + tonumber(ARGV[2]) > tonumber(ARGV[3]) then
+	return -1
+end
+redis.call("SET", KEYS[2], 1, "PX", ARGV[5])
+return redis.call("INCRBY", KEYS[1], ARGV[2])
+`)
+
+// releaseSlotScript take the units back from the booking counter of the slot, a missing counter is seeded again from
+// the orders on the next booking. A release mark the slot as changed for the reconcile grace
+var releaseSlotScript = redis.NewScript(`
+if redis.call("EXISTS", KEYS[1]) == 0 then
+	return 0
+end
+redis.call("SET", KEYS[2], 1, "PX", ARGV[2])
+local booked = redis.call("DECRBY", KEYS[1], ARGV[1])
+if booked < 0 then
+	redis.call("SET", KEYS[1], 0, "KEEPTTL")
+	return 0
+end
+return booked
+`)
+
+type slotRepository struct {
+	db    *sql.DB
+	redis *redis.Client
+}
+
+func NewSlotRepository(db *sql.DB, redis *redis.Client) model.SlotRepository {
+	return &slotRepository{
+		db:    db,
+		redis: redis,
+	}
+}
+
+func (s *slotRepository) FindOpeningHours(ctx context.Context, storeId int) ([]*model.OpeningHours, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Find Opening Hours Slot Repository",
+		"storeId": storeId,
+	})
+
+	query := "SELECT store_id, weekday, opens_at, closes_at, slot_capacity, updated_at FROM opening_hours WHERE store_id = ? ORDER BY weekday ASC"
+	rows, err := s.db.QueryContext(ctx, query, storeId)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	hours := make([]*model.OpeningHours, 0)
+	for rows.Next() {
+		day := &model.OpeningHours{}
+		if err := rows.Scan(&day.StoreId, &day.Weekday, &day.OpensAt, &day.ClosesAt, &day.SlotCapacity, &day.UpdatedAt); err != nil {
+			log.Error(err)
+			return nil, err
+		}
+		// a TIME column is read as "15:04:05"
+		day.OpensAt = clock(day.OpensAt)
+		day.ClosesAt = clock(day.ClosesAt)
+		hours = append(hours, day)
+	}
+	return hours, nil
+}
+
+// SaveOpeningHours replace the weekly opening hours of the store
+func (s *slotRepository) SaveOpeningHours(ctx context.Context, storeId int, hours []*model.OpeningHours) error {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Save Opening Hours Slot Repository",
+		"storeId": storeId,
+		"hours":   hours,
+	})
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err = tx.ExecContext(ctx, "DELETE FROM opening_hours WHERE store_id = ?", storeId); err != nil {
+		log.Error(err)
+		return err
+	}
+
+	for _, day := range hours {
+		query := "INSERT INTO opening_hours(store_id,weekday,opens_at,closes_at,slot_capacity,updated_at) VALUES (?,?,?,?,?,?)"
+		if _, err = tx.ExecContext(ctx, query, storeId, day.Weekday, day.OpensAt, day.ClosesAt, day.SlotCapacity, day.UpdatedAt); err != nil {
+			log.Error(err)
+			return err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		log.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+func (s *slotRepository) FindClosure(ctx context.Context, storeId int, date time.Time) (*model.Closure, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Find Closure Slot Repository",
+		"storeId": storeId,
+		"date":    date,
+	})
+
+	query := "SELECT store_id, date, reason, created_at FROM closures WHERE store_id = ? AND date = ?"
+	closures, err := s.findClosures(ctx, query, storeId, date.Format(model.DateLayout))
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	if len(closures) == 0 {
+		return nil, nil
+	}
+	return closures[0], nil
+}
+
+// FindClosures find the closures of the store from the date on, soonest first
+func (s *slotRepository) FindClosures(ctx context.Context, storeId int, from time.Time) ([]*model.Closure, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Find Closures Slot Repository",
+		"storeId": storeId,
+		"from":    from,
+	})
+
+	query := "SELECT store_id, date, reason, created_at FROM closures WHERE store_id = ? AND date >= ? ORDER BY date ASC"
+	closures, err := s.findClosures(ctx, query, storeId, from.Format(model.DateLayout))
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return closures, nil
+}
+
+func (s *slotRepository) findClosures(ctx context.Context, query string, args ...interface{}) ([]*model.Closure, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	closures := make([]*model.Closure, 0)
+	for rows.Next() {
+		var date time.Time
+		closure := &model.Closure{}
+		if err := rows.Scan(&closure.StoreId, &date, &closure.Reason, &closure.CreatedAt); err != nil {
+			return nil, err
+		}
+		closure.Date = date.Format(model.DateLayout)
+		closures = append(closures, closure)
+	}
+	return closures, nil
+}
+
+func (s *slotRepository) SaveClosure(ctx context.Context, closure *model.Closure) error {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Save Closure Slot Repository",
+		"closure": closure,
+	})
+
+	query := "INSERT INTO closures(store_id,date,reason,created_at) VALUES (?,?,?,?)"
+	if _, err := s.db.ExecContext(ctx, query, closure.StoreId, closure.Date, closure.Reason, closure.CreatedAt); err != nil {
+		log.Error(err)
+		return duplicateErr(err)
+	}
+
+	return nil
+}
+
+func (s *slotRepository) DeleteClosure(ctx context.Context, closure *model.Closure) error {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Delete Closure Slot Repository",
+		"closure": closure,
+	})
+
+	if _, err := s.db.ExecContext(ctx, "DELETE FROM closures WHERE store_id = ? AND date = ?", closure.StoreId, closure.Date); err != nil {
+		log.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+func (s *slotRepository) CountBooked(ctx context.Context, storeId int, from time.Time, to time.Time) (map[string]int, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Count Booked Slot Repository",
+		"storeId": storeId,
+		"from":    from,
+		"to":      to,
+	})
+
+	query := "SELECT o.pickup_slot, SUM(i.quantity) FROM orders o JOIN order_items i ON i.order_id = o.id " +
+		"WHERE o.store_id = ? AND o.pickup_slot >= ? AND o.pickup_slot < ? AND o.status <> ? GROUP BY o.pickup_slot"
+	rows, err := s.db.QueryContext(ctx, query, storeId, from, to, model.OrderStatusCancelled)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	booked := make(map[string]int)
+	for rows.Next() {
+		var (
+			slot  time.Time
+			units int
+		)
+		if err := rows.Scan(&slot, &units); err != nil {
+			log.Error(err)
+			return nil, err
+		}
+		booked[slot.Format(model.SlotLayout)] = units
+	}
+	return booked, nil
+}
+
+func (s *slotRepository) Booked(ctx context.Context, storeId int, slots []time.Time) (map[string]int, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Booked Slot Repository",
+		"storeId": storeId,
+		"slots":   slots,
+	})
+
+	booked := make(map[string]int, len(slots))
+	if len(slots) == 0 {
+		return booked, nil
+	}
+
+	keys := make([]string, 0, len(slots))
+	for _, slot := range slots {
+		keys = append(keys, slotKey(storeId, slot))
+	}
+
+	values, err := s.redis.MGet(ctx, keys...).Result()
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	for idx, value := range values {
+		str, ok := value.(string)
+		if !ok {
+			continue
+		}
+		units, err := strconv.Atoi(str)
+		if err != nil {
+			log.Error(err)
+			return nil, err
+		}
+		booked[slots[idx].Format(model.SlotLayout)] = units
+	}
+	return booked, nil
+}
+
+func (s *slotRepository) Book(ctx context.Context, storeId int, slot time.Time, units int, capacity int, seed int, ttl time.Duration) error {
+	log := logrus.WithFields(logrus.Fields{
+		"message":  "Book Slot Repository",
+		"storeId":  storeId,
+		"slot":     slot,
+		"units":    units,
+		"capacity": capacity,
+		"seed":     seed,
+	})
+
+	keys := []string{slotKey(storeId, slot), slotChangedKey(storeId, slot)}
+	res, err := bookSlotScript.Run(ctx, s.redis, keys, seed, units, capacity, ttl.Milliseconds(), config.SlotReconcileGrace().Milliseconds()).Int()
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	if res < 0 {
+		log.Error(constant.ErrSlotFull)
+		return constant.ErrSlotFull
+	}
+
+	return nil
+}
+
+func (s *slotRepository) Release(ctx context.Context, storeId int, slot time.Time, units int) error {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Release Slot Repository",
+		"storeId": storeId,
+		"slot":    slot,
+		"units":   units,
+	})
+
+	keys := []string{slotKey(storeId, slot), slotChangedKey(storeId, slot)}
+	if err := releaseSlotScript.Run(ctx, s.redis, keys, units, config.SlotReconcileGrace().Milliseconds()).Err(); err != nil {
+		log.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// Reconcile set the booking counter of the slot to the booked units counted from the orders. A counter changed within
+// the reconcile grace may count a checkout that did not save its order yet, it is only raised. The counter is left as
+// is when a booking or a release run meanwhile, reconciled is false then
+func (s *slotRepository) Reconcile(ctx context.Context, storeId int, slot time.Time, booked int, ttl time.Duration) (bool, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Reconcile Slot Repository",
+		"storeId": storeId,
+		"slot":    slot,
+		"booked":  booked,
+	})
+
+	key, changedKey := slotKey(storeId, slot), slotChangedKey(storeId, slot)
+	reconciled := false
+	reconcile := func(tx *redis.Tx) error {
+		changed, err := tx.Exists(ctx, changedKey).Result()
+		if err != nil {
+			return err
+		}
+
+		if changed > 0 {
+			current, err := tx.Get(ctx, key).Int()
+			if err != nil && err != redis.Nil {
+				return err
+			}
+			if err == nil && booked < current {
+				return nil
+			}
+		}
+
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.Set(ctx, key, booked, ttl)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		reconciled = true
+		return nil
+	}
+
+	err := s.redis.Watch(ctx, reconcile, key, changedKey)
+	if err == redis.TxFailedErr {
+		log.Info("Slot changed meanwhile, counter left as is")
+		return false, nil
+	}
+	if err != nil {
+		log.Error(err)
+		return false, err
+	}
+
+	return reconciled, nil
+}
+
+// slotKey is the redis counter of the cakes booked in the slot of the store
+func slotKey(storeId int, slot time.Time) string {
+	return fmt.Sprintf("slot:booked:%d:%s", storeId, slot.Format(model.SlotLayout))
+}
+
+// slotChangedKey exist while the booking counter of the slot of the store was changed within the reconcile grace
+func slotChangedKey(storeId int, slot time.Time) string {
+	return fmt.Sprintf("slot:changed:%d:%s", storeId, slot.Format(model.SlotLayout))
+}
+
+// clock trim the seconds of a time of day
+func clock(value string) string {
+	if len(value) > len(model.ClockLayout) {
+		return value[:len(model.ClockLayout)]
+	}
+	return value
+}
@@ -0,0 +1,312 @@
+package repository
+
+import (
+	"cake-store/src/config"
+	"cake-store/src/constant"
+	"cake-store/src/model"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSlotRepository_FindOpeningHours(t *testing.T) {
+	kit, closer := initializeRepoTestKit(t)
+	defer closer()
+	mock := kit.dbmock
+
+	repo := slotRepository{
+		db: kit.db,
+	}
+
+	ctx := context.TODO()
+
+	t.Run("ok", func(t *testing.T) {
+		mock.ExpectQuery("SELECT store_id, weekday, opens_at, closes_at, slot_capacity, updated_at FROM opening_hours WHERE store_id = \\? ORDER BY weekday ASC").
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"store_id", "weekday", "opens_at", "closes_at", "slot_capacity", "updated_at"}).
+				AddRow(1, 1, "08:00:00", "17:00:00", 10, time.Now()))
+
+		res, err := repo.FindOpeningHours(ctx, 1)
+		require.NoError(t, err)
+		require.Len(t, res, 1)
+		assert.Equal(t, "08:00", res[0].OpensAt)
+		assert.Equal(t, "17:00", res[0].ClosesAt)
+		assert.Equal(t, 10, res[0].SlotCapacity)
+	})
+
+	t.Run("failed to find", func(t *testing.T) {
+		mock.ExpectQuery("SELECT (.+) FROM opening_hours").WillReturnError(errors.New("err db"))
+
+		res, err := repo.FindOpeningHours(ctx, 1)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSlotRepository_SaveOpeningHours(t *testing.T) {
+	kit, closer := initializeRepoTestKit(t)
+	defer closer()
+	mock := kit.dbmock
+
+	repo := slotRepository{
+		db: kit.db,
+	}
+
+	ctx := context.TODO()
+	now := time.Now()
+	hours := []*model.OpeningHours{{Weekday: 1, OpensAt: "08:00", ClosesAt: "17:00", SlotCapacity: 10, UpdatedAt: now}}
+
+	t.Run("ok", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec("DELETE FROM opening_hours WHERE store_id = \\?").WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 7))
+		mock.ExpectExec("INSERT INTO opening_hours\\(store_id,weekday,opens_at,closes_at,slot_capacity,updated_at\\)").
+			WithArgs(1, 1, "08:00", "17:00", 10, now).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		require.NoError(t, repo.SaveOpeningHours(ctx, 1, hours))
+	})
+
+	t.Run("failed to save", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec("DELETE FROM opening_hours").WillReturnResult(sqlmock.NewResult(0, 7))
+		mock.ExpectExec("INSERT INTO opening_hours").WillReturnError(errors.New("err db"))
+		mock.ExpectRollback()
+
+		assert.Error(t, repo.SaveOpeningHours(ctx, 1, hours))
+	})
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSlotRepository_Closures(t *testing.T) {
+	kit, closer := initializeRepoTestKit(t)
+	defer closer()
+	mock := kit.dbmock
+
+	repo := slotRepository{
+		db: kit.db,
+	}
+
+	ctx := context.TODO()
+	now := time.Now()
+	date := time.Date(2026, 12, 25, 0, 0, 0, 0, time.Local)
+	closure := &model.Closure{StoreId: 1, Date: "2026-12-25", Reason: "Christmas", CreatedAt: now}
+
+	t.Run("find", func(t *testing.T) {
+		mock.ExpectQuery("SELECT store_id, date, reason, created_at FROM closures WHERE store_id = \\? AND date = \\?").
+			WithArgs(1, "2026-12-25").
+			WillReturnRows(sqlmock.NewRows([]string{"store_id", "date", "reason", "created_at"}).AddRow(1, date, "Christmas", now))
+
+		res, err := repo.FindClosure(ctx, 1, date)
+		require.NoError(t, err)
+		assert.Equal(t, closure, res)
+	})
+
+	t.Run("find - open", func(t *testing.T) {
+		mock.ExpectQuery("SELECT (.+) FROM closures WHERE store_id = \\? AND date = \\?").
+			WithArgs(1, "2026-12-26").
+			WillReturnRows(sqlmock.NewRows([]string{"store_id", "date", "reason", "created_at"}))
+
+		res, err := repo.FindClosure(ctx, 1, date.AddDate(0, 0, 1))
+		require.NoError(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("find from", func(t *testing.T) {
+		mock.ExpectQuery("SELECT (.+) FROM closures WHERE store_id = \\? AND date >= \\? ORDER BY date ASC").
+			WithArgs(1, "2026-12-01").
+			WillReturnRows(sqlmock.NewRows([]string{"store_id", "date", "reason", "created_at"}).AddRow(1, date, "Christmas", now))
+
+		res, err := repo.FindClosures(ctx, 1, time.Date(2026, 12, 1, 0, 0, 0, 0, time.Local))
+		require.NoError(t, err)
+		assert.Equal(t, []*model.Closure{closure}, res)
+	})
+
+	t.Run("save", func(t *testing.T) {
+		mock.ExpectExec("INSERT INTO closures\\(store_id,date,reason,created_at\\)").
+			WithArgs(1, "2026-12-25", "Christmas", now).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		require.NoError(t, repo.SaveClosure(ctx, closure))
+	})
+
+	t.Run("save - duplicate", func(t *testing.T) {
+		mock.ExpectExec("INSERT INTO closures").WillReturnError(&mysql.MySQLError{Number: 1062, Message: "Duplicate entry"})
+
+		assert.Equal(t, constant.ErrAlreadyExists, repo.SaveClosure(ctx, closure))
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		mock.ExpectExec("DELETE FROM closures WHERE store_id = \\? AND date = \\?").
+			WithArgs(1, "2026-12-25").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		require.NoError(t, repo.DeleteClosure(ctx, closure))
+	})
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSlotRepository_CountBooked(t *testing.T) {
+	kit, closer := initializeRepoTestKit(t)
+	defer closer()
+	mock := kit.dbmock
+
+	repo := slotRepository{
+		db: kit.db,
+	}
+
+	ctx := context.TODO()
+	from := time.Date(2026, 10, 20, 0, 0, 0, 0, time.Local)
+	to := from.AddDate(0, 0, 1)
+
+	t.Run("ok", func(t *testing.T) {
+		mock.ExpectQuery("SELECT o.pickup_slot, SUM\\(i.quantity\\) FROM orders o JOIN order_items i ON i.order_id = o.id "+
+			"WHERE o.store_id = \\? AND o.pickup_slot >= \\? AND o.pickup_slot < \\? AND o.status <> \\? GROUP BY o.pickup_slot").
+			WithArgs(1, from, to, model.OrderStatusCancelled).
+			WillReturnRows(sqlmock.NewRows([]string{"pickup_slot", "units"}).
+				AddRow(time.Date(2026, 10, 20, 9, 0, 0, 0, time.Local), 4).
+				AddRow(time.Date(2026, 10, 20, 13, 0, 0, 0, time.Local), 1))
+
+		res, err := repo.CountBooked(ctx, 1, from, to)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]int{"2026-10-20T09:00": 4, "2026-10-20T13:00": 1}, res)
+	})
+
+	t.Run("failed to count", func(t *testing.T) {
+		mock.ExpectQuery("SELECT (.+) FROM orders").WillReturnError(errors.New("err db"))
+
+		res, err := repo.CountBooked(ctx, 1, from, to)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSlotRepository_Book(t *testing.T) {
+	kit, closer := initializeRepoTestKit(t)
+	defer closer()
+
+	repo := slotRepository{
+		db:    kit.db,
+		redis: kit.redis,
+	}
+
+	ctx := context.TODO()
+	slot := time.Date(2026, 10, 20, 9, 0, 0, 0, time.Local)
+	other := slot.Add(time.Hour)
+
+	t.Run("seeded", func(t *testing.T) {
+		require.NoError(t, repo.Book(ctx, 1, slot, 3, 10, 4, time.Hour))
+
+		res, err := repo.Booked(ctx, 1, []time.Time{slot, other})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]int{"2026-10-20T09:00": 7}, res)
+		assert.Equal(t, time.Hour, kit.miniredis.TTL("slot:booked:1:2026-10-20T09:00"))
+	})
+
+	t.Run("seed ignored once counted", func(t *testing.T) {
+		require.NoError(t, repo.Book(ctx, 1, slot, 2, 10, 100, time.Hour))
+
+		res, err := repo.Booked(ctx, 1, []time.Time{slot})
+		require.NoError(t, err)
+		assert.Equal(t, 9, res["2026-10-20T09:00"])
+	})
+
+	t.Run("full", func(t *testing.T) {
+		assert.Equal(t, constant.ErrSlotFull, repo.Book(ctx, 1, slot, 2, 10, 0, time.Hour))
+
+		res, err := repo.Booked(ctx, 1, []time.Time{slot})
+		require.NoError(t, err)
+		assert.Equal(t, 9, res["2026-10-20T09:00"])
+	})
+
+	t.Run("release", func(t *testing.T) {
+		require.NoError(t, repo.Release(ctx, 1, slot, 4))
+
+		res, err := repo.Booked(ctx, 1, []time.Time{slot})
+		require.NoError(t, err)
+		assert.Equal(t, 5, res["2026-10-20T09:00"])
+	})
+
+	t.Run("release floored", func(t *testing.T) {
+		require.NoError(t, repo.Release(ctx, 1, slot, 20))
+
+		res, err := repo.Booked(ctx, 1, []time.Time{slot})
+		require.NoError(t, err)
+		assert.Equal(t, 0, res["2026-10-20T09:00"])
+		assert.Equal(t, time.Hour, kit.miniredis.TTL("slot:booked:1:2026-10-20T09:00"))
+	})
+
+	t.Run("release without counter", func(t *testing.T) {
+		require.NoError(t, repo.Release(ctx, 1, other, 1))
+
+		res, err := repo.Booked(ctx, 1, []time.Time{other})
+		require.NoError(t, err)
+		assert.Empty(t, res)
+	})
+
+	t.Run("reconcile", func(t *testing.T) {
+		reconciled, err := repo.Reconcile(ctx, 1, other, 6, 2*time.Hour)
+		require.NoError(t, err)
+		assert.True(t, reconciled)
+
+		res, err := repo.Booked(ctx, 1, []time.Time{slot, other})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]int{"2026-10-20T09:00": 0, "2026-10-20T10:00": 6}, res)
+		assert.Equal(t, 2*time.Hour, kit.miniredis.TTL("slot:booked:1:2026-10-20T10:00"))
+	})
+
+	t.Run("reconcile - lowered when quiet", func(t *testing.T) {
+		reconciled, err := repo.Reconcile(ctx, 1, other, 2, 2*time.Hour)
+		require.NoError(t, err)
+		assert.True(t, reconciled)
+
+		res, err := repo.Booked(ctx, 1, []time.Time{other})
+		require.NoError(t, err)
+		assert.Equal(t, 2, res["2026-10-20T10:00"])
+	})
+
+	t.Run("reconcile - only raised within the grace", func(t *testing.T) {
+		require.NoError(t, repo.Book(ctx, 1, slot, 4, 10, 0, time.Hour))
+
+		// the order of the booking is not saved yet
+		reconciled, err := repo.Reconcile(ctx, 1, slot, 1, time.Hour)
+		require.NoError(t, err)
+		assert.False(t, reconciled)
+
+		res, err := repo.Booked(ctx, 1, []time.Time{slot})
+		require.NoError(t, err)
+		assert.Equal(t, 4, res["2026-10-20T09:00"])
+
+		reconciled, err = repo.Reconcile(ctx, 1, slot, 5, time.Hour)
+		require.NoError(t, err)
+		assert.True(t, reconciled)
+
+		res, err = repo.Booked(ctx, 1, []time.Time{slot})
+		require.NoError(t, err)
+		assert.Equal(t, 5, res["2026-10-20T09:00"])
+	})
+
+	t.Run("reconcile - lowered after the grace", func(t *testing.T) {
+		kit.miniredis.FastForward(config.DefaultSlotReconcileGrace)
+
+		reconciled, err := repo.Reconcile(ctx, 1, slot, 1, time.Hour)
+		require.NoError(t, err)
+		assert.True(t, reconciled)
+
+		res, err := repo.Booked(ctx, 1, []time.Time{slot})
+		require.NoError(t, err)
+		assert.Equal(t, 1, res["2026-10-20T09:00"])
+	})
+}
@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"cake-store/src/constant"
+	"errors"
+	"strings"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+const (
+	// mysqlErrDuplicateEntry is the mysql error number of an unique key violation
+	mysqlErrDuplicateEntry = 1062
+	// mysqlErrRowIsReferenced is the mysql error number of deleting a row a foreign key still reference
+	mysqlErrRowIsReferenced = 1451
+)
+
+func where(conditions []string) string {
+	if len(conditions) == 0 {
+		return ""
+	}
+	return " WHERE " + strings.Join(conditions, " AND ")
+}
+
+// placeholders return n comma separated bind parameters for an IN clause
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?,", n), ",")
+}
+
+func intArgs(ids []int) []interface{} {
+	args := make([]interface{}, 0, len(ids))
+	for _, id := range ids {
+		args = append(args, id)
+	}
+	return args
+}
+
+// duplicateErr report an unique key violation as already exist error
+func duplicateErr(err error) error {
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlErrDuplicateEntry {
+		return constant.ErrAlreadyExists
+	}
+	return err
+}
+
+// inUseErr report a restricted foreign key violation as in use error
+func inUseErr(err error) error {
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlErrRowIsReferenced {
+		return constant.ErrInUse
+	}
+	return err
+}
@@ -0,0 +1,348 @@
+package repository
+
+import (
+	"cake-store/src/constant"
+	"cake-store/src/model"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/sirupsen/logrus"
+)
+
+// reserveScript sum the live reservations of the item, dropping the expired ones, and hold the quantity
+// only when it still fit in the on hand count, it return -1 when the stock is insufficient
+var reserveScript = redis.NewScript(`
+local reserved = 0
+local entries = redis.call("HGETALL", KEYS[1])
+for i = 1, #entries, 2 do
+	local qty, exp = string.match(entries[i + 1], "(%d+):(%d+)")
+	if tonumber(exp) <= tonumber(ARGV[4]) then
+		redis.call("HDEL", KEYS[1], entries[i])
+	else
+		reserved = reserved + tonumber(qty)
+	end
+end
+if reserved + tonumber(ARGV[2]) > tonumber(ARGV[3]) then
+	return -1
+end
+redis.call("HSET", KEYS[1], ARGV[1], ARGV[2] .. ":" .. ARGV[5])
+if redis.call("PTTL", KEYS[1]) < tonumber(ARGV[6]) then
+	redis.call("PEXPIRE", KEYS[1], ARGV[6])
+end
+redis.call("SET", KEYS[2], ARGV[7], "PX", ARGV[6])
+return reserved + tonumber(ARGV[2])
+`)
+
+// reservedScript sum the live reservations of the item, dropping the expired ones
+var reservedScript = redis.NewScript(`
+local reserved = 0
+local entries = redis.call("HGETALL", KEYS[1])
+for i = 1, #entries, 2 do
+	local qty, exp = string.match(entries[i + 1], "(%d+):(%d+)")
+	if tonumber(exp) <= tonumber(ARGV[1]) then
+		redis.call("HDEL", KEYS[1], entries[i])
+	else
+		reserved = reserved + tonumber(qty)
+	end
+end
+return reserved
+`)
+
+type stockRepository struct {
+	db    *sql.DB
+	redis *redis.Client
+}
+
+func NewStockRepository(db *sql.DB, redis *redis.Client) model.StockRepository {
+	return &stockRepository{
+		db:    db,
+		redis: redis,
+	}
+}
+
+func (s *stockRepository) FindByItem(ctx context.Context, cakeId int, variantId int) (*model.Stock, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message":   "Find By Item Stock Repository",
+		"cakeId":    cakeId,
+		"variantId": variantId,
+	})
+
+	sql := "SELECT " + stockColumns + " FROM stocks WHERE cake_id = ? AND variant_id = ?"
+	stocks, err := s.findStocks(ctx, log, sql, cakeId, variantId)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(stocks) == 0 {
+		return nil, nil
+	}
+	return stocks[0], nil
+}
+
+func (s *stockRepository) FindByCakeId(ctx context.Context, cakeId int) ([]*model.Stock, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Find By Cake ID Stock Repository",
+		"cakeId":  cakeId,
+	})
+
+	sql := "SELECT " + stockColumns + " FROM stocks WHERE cake_id = ? ORDER BY variant_id ASC"
+	return s.findStocks(ctx, log, sql, cakeId)
+}
+
+// FindLow find the stocks at or below their threshold, not counting the reservations
+func (s *stockRepository) FindLow(ctx context.Context) ([]*model.Stock, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Find Low Stock Repository",
+	})
+
+	sql := "SELECT " + stockColumns + " FROM stocks WHERE on_hand <= low_stock_threshold ORDER BY on_hand ASC, cake_id ASC, variant_id ASC"
+	return s.findStocks(ctx, log, sql)
+}
+
+// Adjust apply the adjustment to the on hand count and record it, the count never go below zero
+func (s *stockRepository) Adjust(ctx context.Context, adjustment *model.StockAdjustment) (*model.Stock, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message":    "Adjust Stock Repository",
+		"adjustment": adjustment,
+	})
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	query := "INSERT INTO stocks(cake_id,variant_id,on_hand,updated_at) VALUES (?,?,0,?) ON DUPLICATE KEY UPDATE id = id"
+	if _, err = tx.ExecContext(ctx, query, adjustment.CakeId, adjustment.VariantId, adjustment.CreatedAt); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	query = "UPDATE stocks SET on_hand = on_hand + ?, updated_at = ? WHERE cake_id = ? AND variant_id = ? AND on_hand + ? >= 0"
+	res, err := tx.ExecContext(ctx, query, adjustment.Delta, adjustment.CreatedAt, adjustment.CakeId, adjustment.VariantId, adjustment.Delta)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	if affected == 0 {
+		log.Error(constant.ErrInsufficientStock)
+		return nil, constant.ErrInsufficientStock
+	}
+
+	query = "INSERT INTO stock_adjustments(cake_id,variant_id,delta,reason,note,created_at) VALUES (?,?,?,?,?,?)"
+	res, err = tx.ExecContext(ctx, query, adjustment.CakeId, adjustment.VariantId, adjustment.Delta, adjustment.Reason, adjustment.Note, adjustment.CreatedAt)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	adjustment.Id = int(id)
+
+	stock := &model.Stock{}
+	query = "SELECT " + stockColumns + " FROM stocks WHERE cake_id = ? AND variant_id = ?"
+	err = tx.QueryRowContext(ctx, query, adjustment.CakeId, adjustment.VariantId).
+		Scan(&stock.Id, &stock.CakeId, &stock.VariantId, &stock.OnHand, &stock.LowStockThreshold, &stock.UpdatedAt)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return stock, nil
+}
+
+func (s *stockRepository) SetThreshold(ctx context.Context, cakeId int, variantId int, threshold int) error {
+	log := logrus.WithFields(logrus.Fields{
+		"message":   "Set Threshold Stock Repository",
+		"cakeId":    cakeId,
+		"variantId": variantId,
+		"threshold": threshold,
+	})
+
+	query := "INSERT INTO stocks(cake_id,variant_id,on_hand,low_stock_threshold,updated_at) VALUES (?,?,0,?,?) " +
+		"ON DUPLICATE KEY UPDATE low_stock_threshold = VALUES(low_stock_threshold), updated_at = VALUES(updated_at)"
+	if _, err := s.db.ExecContext(ctx, query, cakeId, variantId, threshold, time.Now()); err != nil {
+		log.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// FindAdjustments find the latest adjustments of the cake and its variants
+func (s *stockRepository) FindAdjustments(ctx context.Context, cakeId int, limit int) ([]*model.StockAdjustment, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Find Adjustments Stock Repository",
+		"cakeId":  cakeId,
+	})
+
+	sql := "SELECT id, cake_id, variant_id, delta, reason, note, created_at FROM stock_adjustments WHERE cake_id = ? ORDER BY id DESC LIMIT ?"
+	rows, err := s.db.QueryContext(ctx, sql, cakeId, limit)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	adjustments := make([]*model.StockAdjustment, 0)
+	for rows.Next() {
+		adjustment := &model.StockAdjustment{}
+		err := rows.Scan(&adjustment.Id, &adjustment.CakeId, &adjustment.VariantId, &adjustment.Delta, &adjustment.Reason, &adjustment.Note, &adjustment.CreatedAt)
+		if err != nil {
+			log.Error(err)
+			return nil, err
+		}
+		adjustments = append(adjustments, adjustment)
+	}
+	return adjustments, nil
+}
+
+// Reserve hold the reservation quantity when the live reservations of the item leave enough of the on hand count
+func (s *stockRepository) Reserve(ctx context.Context, reservation *model.Reservation, onHand int) error {
+	log := logrus.WithFields(logrus.Fields{
+		"message":     "Reserve Stock Repository",
+		"reservation": reservation,
+		"onHand":      onHand,
+	})
+
+	value, err := json.Marshal(reservation)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	ttl := time.Until(reservation.ExpiresAt)
+	keys := []string{reservedKey(reservation.CakeId, reservation.VariantId), reservationKey(reservation.Id)}
+	res, err := reserveScript.Run(ctx, s.redis, keys,
+		reservation.Id, reservation.Quantity, onHand, time.Now().UnixMilli(), reservation.ExpiresAt.UnixMilli(), ttl.Milliseconds(), value).Int()
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	if res < 0 {
+		log.Error(constant.ErrInsufficientStock)
+		return constant.ErrInsufficientStock
+	}
+
+	return nil
+}
+
+// Reserved sum the quantity of the live reservations of the item
+func (s *stockRepository) Reserved(ctx context.Context, cakeId int, variantId int) (int, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message":   "Reserved Stock Repository",
+		"cakeId":    cakeId,
+		"variantId": variantId,
+	})
+
+	res, err := reservedScript.Run(ctx, s.redis, []string{reservedKey(cakeId, variantId)}, time.Now().UnixMilli()).Int()
+	if err != nil {
+		log.Error(err)
+		return 0, err
+	}
+
+	return res, nil
+}
+
+func (s *stockRepository) FindReservation(ctx context.Context, id string) (*model.Reservation, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Find Reservation Stock Repository",
+		"id":      id,
+	})
+
+	value, err := s.redis.Get(ctx, reservationKey(id)).Bytes()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	reservation := &model.Reservation{}
+	if err := json.Unmarshal(value, reservation); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return reservation, nil
+}
+
+// Release drop the reservation, return ErrNotFound when it was already released or expired
+func (s *stockRepository) Release(ctx context.Context, reservation *model.Reservation) error {
+	log := logrus.WithFields(logrus.Fields{
+		"message":     "Release Stock Repository",
+		"reservation": reservation,
+	})
+
+	var deleted *redis.IntCmd
+	_, err := s.redis.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		deleted = pipe.Del(ctx, reservationKey(reservation.Id))
+		pipe.HDel(ctx, reservedKey(reservation.CakeId, reservation.VariantId), reservation.Id)
+		return nil
+	})
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	if deleted.Val() == 0 {
+		log.Error(constant.ErrNotFound)
+		return constant.ErrNotFound
+	}
+
+	return nil
+}
+
+func (s *stockRepository) findStocks(ctx context.Context, log *logrus.Entry, sql string, args ...interface{}) ([]*model.Stock, error) {
+	rows, err := s.db.QueryContext(ctx, sql, args...)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	stocks := make([]*model.Stock, 0)
+	for rows.Next() {
+		stock := &model.Stock{}
+		err := rows.Scan(&stock.Id, &stock.CakeId, &stock.VariantId, &stock.OnHand, &stock.LowStockThreshold, &stock.UpdatedAt)
+		if err != nil {
+			log.Error(err)
+			return nil, err
+		}
+		stocks = append(stocks, stock)
+	}
+	return stocks, nil
+}
+
+const stockColumns = "id, cake_id, variant_id, on_hand, low_stock_threshold, updated_at"
+
+// reservedKey is the redis hash of the live reservations of an item, by reservation id
+func reservedKey(cakeId int, variantId int) string {
+	return fmt.Sprintf("stock:reserved:%d:%d", cakeId, variantId)
+}
+
+func reservationKey(id string) string {
+	return fmt.Sprintf("stock:reservation:%s", id)
+}
@@ -0,0 +1,225 @@
+package repository
+
+import (
+	"cake-store/src/constant"
+	"cake-store/src/model"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStockRepository_Adjust(t *testing.T) {
+	kit, closer := initializeRepoTestKit(t)
+	defer closer()
+	mock := kit.dbmock
+
+	repo := stockRepository{
+		db: kit.db,
+	}
+
+	ctx := context.TODO()
+	adjustment := &model.StockAdjustment{CakeId: 1, VariantId: 5, Delta: 10, Reason: model.StockReasonRestock, CreatedAt: time.Now()}
+
+	t.Run("ok", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec("INSERT INTO stocks(.+) ON DUPLICATE KEY UPDATE id = id").
+			WithArgs(1, 5, adjustment.CreatedAt).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec("UPDATE stocks SET on_hand = on_hand \\+ \\?, updated_at = \\? WHERE cake_id = \\? AND variant_id = \\? AND on_hand \\+ \\? >= 0").
+			WithArgs(10, adjustment.CreatedAt, 1, 5, 10).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("INSERT INTO stock_adjustments").
+			WithArgs(1, 5, 10, model.StockReasonRestock, "", adjustment.CreatedAt).
+			WillReturnResult(sqlmock.NewResult(7, 1))
+		mock.ExpectQuery("SELECT (.+) FROM stocks WHERE cake_id = \\? AND variant_id = \\?").
+			WithArgs(1, 5).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "cake_id", "variant_id", "on_hand", "low_stock_threshold", "updated_at"}).
+				AddRow(1, 1, 5, 10, 2, adjustment.CreatedAt))
+		mock.ExpectCommit()
+
+		res, err := repo.Adjust(ctx, adjustment)
+		require.NoError(t, err)
+		assert.Equal(t, 10, res.OnHand)
+		assert.Equal(t, 7, adjustment.Id)
+	})
+
+	t.Run("insufficient stock", func(t *testing.T) {
+		adjustment := &model.StockAdjustment{CakeId: 1, VariantId: 5, Delta: -20, Reason: model.StockReasonWaste, CreatedAt: time.Now()}
+
+		mock.ExpectBegin()
+		mock.ExpectExec("INSERT INTO stocks").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("UPDATE stocks").
+			WithArgs(-20, adjustment.CreatedAt, 1, 5, -20).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		res, err := repo.Adjust(ctx, adjustment)
+		assert.Equal(t, constant.ErrInsufficientStock, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("failed to adjust", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec("INSERT INTO stocks").WillReturnError(errors.New("err db"))
+		mock.ExpectRollback()
+
+		res, err := repo.Adjust(ctx, adjustment)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestStockRepository_FindByItem(t *testing.T) {
+	kit, closer := initializeRepoTestKit(t)
+	defer closer()
+	mock := kit.dbmock
+
+	repo := stockRepository{
+		db: kit.db,
+	}
+
+	ctx := context.TODO()
+
+	t.Run("ok", func(t *testing.T) {
+		mock.ExpectQuery("SELECT (.+) FROM stocks WHERE cake_id = \\? AND variant_id = \\?").
+			WithArgs(1, 0).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "cake_id", "variant_id", "on_hand", "low_stock_threshold", "updated_at"}).
+				AddRow(1, 1, 0, 4, 2, time.Now()))
+
+		res, err := repo.FindByItem(ctx, 1, 0)
+		require.NoError(t, err)
+		assert.Equal(t, 4, res.OnHand)
+	})
+
+	t.Run("not tracked", func(t *testing.T) {
+		mock.ExpectQuery("SELECT (.+) FROM stocks").
+			WithArgs(2, 0).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "cake_id", "variant_id", "on_hand", "low_stock_threshold", "updated_at"}))
+
+		res, err := repo.FindByItem(ctx, 2, 0)
+		require.NoError(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestStockRepository_FindLow(t *testing.T) {
+	kit, closer := initializeRepoTestKit(t)
+	defer closer()
+	mock := kit.dbmock
+
+	repo := stockRepository{
+		db: kit.db,
+	}
+
+	mock.ExpectQuery("SELECT (.+) FROM stocks WHERE on_hand <= low_stock_threshold ORDER BY").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "cake_id", "variant_id", "on_hand", "low_stock_threshold", "updated_at"}).
+			AddRow(1, 1, 0, 1, 2, time.Now()).
+			AddRow(2, 3, 5, 2, 2, time.Now()))
+
+	res, err := repo.FindLow(context.TODO())
+	require.NoError(t, err)
+	assert.Equal(t, 2, len(res))
+}
+
+func TestStockRepository_SetThreshold(t *testing.T) {
+	kit, closer := initializeRepoTestKit(t)
+	defer closer()
+	mock := kit.dbmock
+
+	repo := stockRepository{
+		db: kit.db,
+	}
+
+	mock.ExpectExec("INSERT INTO stocks(.+) ON DUPLICATE KEY UPDATE low_stock_threshold = VALUES\\(low_stock_threshold\\)").
+		WithArgs(1, 5, 3, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	err := repo.SetThreshold(context.TODO(), 1, 5, 3)
+	require.NoError(t, err)
+}
+
+func TestStockRepository_FindAdjustments(t *testing.T) {
+	kit, closer := initializeRepoTestKit(t)
+	defer closer()
+	mock := kit.dbmock
+
+	repo := stockRepository{
+		db: kit.db,
+	}
+
+	mock.ExpectQuery("SELECT (.+) FROM stock_adjustments WHERE cake_id = \\? ORDER BY id DESC LIMIT \\?").
+		WithArgs(1, 100).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "cake_id", "variant_id", "delta", "reason", "note", "created_at"}).
+			AddRow(2, 1, 0, -1, "sale", "", time.Now()).
+			AddRow(1, 1, 0, 5, "restock", "first batch", time.Now()))
+
+	res, err := repo.FindAdjustments(context.TODO(), 1, 100)
+	require.NoError(t, err)
+	assert.Equal(t, 2, len(res))
+	assert.Equal(t, "first batch", res[1].Note)
+}
+
+func TestStockRepository_Reservation(t *testing.T) {
+	kit, closer := initializeRepoTestKit(t)
+	defer closer()
+
+	repo := stockRepository{
+		db:    kit.db,
+		redis: kit.redis,
+	}
+
+	ctx := context.TODO()
+	first := &model.Reservation{Id: "first", CakeId: 1, VariantId: 5, Quantity: 3, ExpiresAt: time.Now().Add(time.Minute)}
+	second := &model.Reservation{Id: "second", CakeId: 1, VariantId: 5, Quantity: 2, ExpiresAt: time.Now().Add(time.Minute)}
+
+	t.Run("reserve", func(t *testing.T) {
+		require.NoError(t, repo.Reserve(ctx, first, 5))
+		require.NoError(t, repo.Reserve(ctx, second, 5))
+
+		reserved, err := repo.Reserved(ctx, 1, 5)
+		require.NoError(t, err)
+		assert.Equal(t, 5, reserved)
+
+		res, err := repo.FindReservation(ctx, first.Id)
+		require.NoError(t, err)
+		assert.Equal(t, first.Quantity, res.Quantity)
+	})
+
+	t.Run("insufficient stock", func(t *testing.T) {
+		third := &model.Reservation{Id: "third", CakeId: 1, VariantId: 5, Quantity: 1, ExpiresAt: time.Now().Add(time.Minute)}
+		assert.Equal(t, constant.ErrInsufficientStock, repo.Reserve(ctx, third, 5))
+
+		res, err := repo.FindReservation(ctx, third.Id)
+		require.NoError(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("release", func(t *testing.T) {
+		require.NoError(t, repo.Release(ctx, first))
+		assert.Equal(t, constant.ErrNotFound, repo.Release(ctx, first))
+
+		reserved, err := repo.Reserved(ctx, 1, 5)
+		require.NoError(t, err)
+		assert.Equal(t, 2, reserved)
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		expiring := &model.Reservation{Id: "expiring", CakeId: 2, Quantity: 4, ExpiresAt: time.Now().Add(50 * time.Millisecond)}
+		require.NoError(t, repo.Reserve(ctx, expiring, 4))
+		time.Sleep(60 * time.Millisecond)
+
+		reserved, err := repo.Reserved(ctx, 2, 0)
+		require.NoError(t, err)
+		assert.Equal(t, 0, reserved)
+
+		other := &model.Reservation{Id: "other", CakeId: 2, Quantity: 4, ExpiresAt: time.Now().Add(time.Minute)}
+		assert.NoError(t, repo.Reserve(ctx, other, 4))
+	})
+}
@@ -0,0 +1,364 @@
+package repository
+
+import (
+	"cake-store/src/config"
+	"cake-store/src/helper"
+	"cake-store/src/model"
+	"context"
+	"database/sql"
+	"math"
+	"strconv"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/sirupsen/logrus"
+)
+
+// storeLocationsKey is the redis geo index of the active located stores, rebuilt from the database when missing
+const storeLocationsKey = "stores:locations"
+
+type storeRepository struct {
+	db    *sql.DB
+	redis *redis.Client
+}
+
+func NewStoreRepository(db *sql.DB, redis *redis.Client) model.StoreRepository {
+	return &storeRepository{
+		db:    db,
+		redis: redis,
+	}
+}
+
+func (s *storeRepository) Save(ctx context.Context, store *model.Store) error {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Save Store Repository",
+		"store":   store,
+	})
+
+	query := "INSERT INTO stores(code,name,address,phone,latitude,longitude,active,created_at,updated_at) VALUES (?,?,?,?,?,?,?,?,?)"
+	res, err := s.db.ExecContext(ctx, query, store.Code, store.Name, store.Address, store.Phone, store.Latitude, store.Longitude, store.Active, store.CreatedAt, store.UpdatedAt)
+	if err != nil {
+		log.Error(err)
+		return duplicateErr(err)
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	store.Id = int(id)
+
+	if err = s.redis.Del(ctx, storeLocationsKey).Err(); err != nil {
+		log.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+func (s *storeRepository) Update(ctx context.Context, store *model.Store) error {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Update Store Repository",
+		"store":   store,
+	})
+
+	query := "UPDATE stores SET code = ?, name = ?, address = ?, phone = ?, latitude = ?, longitude = ?, active = ?, updated_at = ? WHERE id = ?"
+	if _, err := s.db.ExecContext(ctx, query, store.Code, store.Name, store.Address, store.Phone, store.Latitude, store.Longitude, store.Active, store.UpdatedAt, store.Id); err != nil {
+		log.Error(err)
+		return duplicateErr(err)
+	}
+
+	if err := s.redis.Del(ctx, storeLocationsKey).Err(); err != nil {
+		log.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+func (s *storeRepository) FindById(ctx context.Context, id int) (*model.Store, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Find By ID Store Repository",
+		"id":      id,
+	})
+
+	stores, err := s.findStores(ctx, log, "SELECT "+storeColumns+" FROM stores WHERE id = ?", id)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(stores) == 0 {
+		return nil, nil
+	}
+	return stores[0], nil
+}
+
+func (s *storeRepository) FindAll(ctx context.Context) ([]*model.Store, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Find All Store Repository",
+	})
+
+	return s.findStores(ctx, log, "SELECT "+storeColumns+" FROM stores ORDER BY name ASC")
+}
+
+func (s *storeRepository) findStores(ctx context.Context, log *logrus.Entry, query string, args ...interface{}) ([]*model.Store, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	stores := make([]*model.Store, 0)
+	for rows.Next() {
+		store := &model.Store{}
+		var latitude, longitude sql.NullFloat64
+		err := rows.Scan(&store.Id, &store.Code, &store.Name, &store.Address, &store.Phone, &latitude, &longitude, &store.Active, &store.CreatedAt, &store.UpdatedAt)
+		if err != nil {
+			log.Error(err)
+			return nil, err
+		}
+		if latitude.Valid && longitude.Valid {
+			store.Latitude, store.Longitude = &latitude.Float64, &longitude.Float64
+		}
+		stores = append(stores, store)
+	}
+	return stores, nil
+}
+
+// SaveCake replace the availability of the cake in the store and the price overrides of its variants
+func (s *storeRepository) SaveCake(ctx context.Context, storeCake *model.StoreCake) error {
+	log := logrus.WithFields(logrus.Fields{
+		"message":   "Save Cake Store Repository",
+		"storeCake": storeCake,
+	})
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+	defer tx.Rollback()
+
+	query := "INSERT INTO store_cakes(store_id,cake_id,available,updated_at) VALUES (?,?,?,?) " +
+		"ON DUPLICATE KEY UPDATE available = VALUES(available), updated_at = VALUES(updated_at)"
+	if _, err = tx.ExecContext(ctx, query, storeCake.StoreId, storeCake.CakeId, storeCake.Available, storeCake.UpdatedAt); err != nil {
+		log.Error(err)
+		return err
+	}
+
+	query = "DELETE FROM store_prices WHERE store_id = ? AND variant_id IN (SELECT id FROM cake_variants WHERE cake_id = ?)"
+	if _, err = tx.ExecContext(ctx, query, storeCake.StoreId, storeCake.CakeId); err != nil {
+		log.Error(err)
+		return err
+	}
+
+	for _, price := range storeCake.Prices {
+		query = "INSERT INTO store_prices(store_id,variant_id,price,currency) VALUES (?,?,?,?)"
+		if _, err = tx.ExecContext(ctx, query, storeCake.StoreId, price.VariantId, price.Price, price.Price.Currency); err != nil {
+			log.Error(err)
+			return err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		log.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+func (s *storeRepository) FindCake(ctx context.Context, storeId int, cakeId int) (*model.StoreCake, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Find Cake Store Repository",
+		"storeId": storeId,
+		"cakeId":  cakeId,
+	})
+
+	storeCake := &model.StoreCake{StoreId: storeId, CakeId: cakeId, Available: true, Prices: make([]*model.StorePrice, 0)}
+	query := "SELECT available, updated_at FROM store_cakes WHERE store_id = ? AND cake_id = ?"
+	err := s.db.QueryRowContext(ctx, query, storeId, cakeId).Scan(&storeCake.Available, &storeCake.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	query = "SELECT sp.variant_id, sp.price, sp.currency FROM store_prices sp JOIN cake_variants v ON v.id = sp.variant_id " +
+		"WHERE sp.store_id = ? AND v.cake_id = ? ORDER BY sp.variant_id ASC"
+	rows, err := s.db.QueryContext(ctx, query, storeId, cakeId)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		price := &model.StorePrice{}
+		if err := rows.Scan(&price.VariantId, &price.Price, &price.Price.Currency); err != nil {
+			log.Error(err)
+			return nil, err
+		}
+		storeCake.Prices = append(storeCake.Prices, price)
+	}
+	return storeCake, nil
+}
+
+func (s *storeRepository) FindPrices(ctx context.Context, storeId int, variantIds []int) (map[int]model.Money, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message":    "Find Prices Store Repository",
+		"storeId":    storeId,
+		"variantIds": variantIds,
+	})
+
+	prices := make(map[int]model.Money, len(variantIds))
+	if len(variantIds) == 0 {
+		return prices, nil
+	}
+
+	query := "SELECT variant_id, price, currency FROM store_prices WHERE store_id = ? AND variant_id IN (" + placeholders(len(variantIds)) + ")"
+	args := append([]interface{}{storeId}, intArgs(variantIds)...)
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			variantId int
+			price     model.Money
+		)
+		if err := rows.Scan(&variantId, &price, &price.Currency); err != nil {
+			log.Error(err)
+			return nil, err
+		}
+		prices[variantId] = price
+	}
+	return prices, nil
+}
+
+// FindNearest search the redis geo index of the stores, the index is rebuilt from the database when it is missing
+// or has been invalidated by a change of a store
+func (s *storeRepository) FindNearest(ctx context.Context, lat, lng float64, limit int) ([]*model.NearbyStore, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Find Nearest Store Repository",
+		"lat":     lat,
+		"lng":     lng,
+		"limit":   limit,
+	})
+
+	indexed, err := s.redis.Exists(ctx, storeLocationsKey).Result()
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	if indexed == 0 {
+		if err = s.indexLocations(ctx, log); err != nil {
+			return nil, err
+		}
+	}
+
+	// the whole earth is within half of its circumference
+	locations, err := s.redis.GeoRadius(ctx, storeLocationsKey, lng, lat, &redis.GeoRadiusQuery{
+		Radius:   math.Ceil(math.Pi * helper.EarthRadiusKm),
+		Unit:     "km",
+		WithDist: true,
+		Count:    limit,
+		Sort:     "ASC",
+	}).Result()
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	nearby := make([]*model.NearbyStore, 0, len(locations))
+	if len(locations) == 0 {
+		return nearby, nil
+	}
+
+	ids := make([]int, 0, len(locations))
+	for _, location := range locations {
+		id, err := strconv.Atoi(location.Name)
+		if err != nil {
+			log.Error(err)
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	query := "SELECT " + storeColumns + " FROM stores WHERE active = 1 AND id IN (" + placeholders(len(ids)) + ")"
+	stores, err := s.findStores(ctx, log, query, intArgs(ids)...)
+	if err != nil {
+		return nil, err
+	}
+
+	byId := make(map[int]*model.Store, len(stores))
+	for _, store := range stores {
+		byId[store.Id] = store
+	}
+
+	// a store changed since the index was read is left out
+	for idx, location := range locations {
+		store, ok := byId[ids[idx]]
+		if !ok || !store.Located() {
+			continue
+		}
+		nearby = append(nearby, &model.NearbyStore{Store: store, DistanceKm: location.Dist})
+	}
+	return nearby, nil
+}
+
+// indexLocations add the active located stores to the geo index, the index expire so a missed invalidation heal
+func (s *storeRepository) indexLocations(ctx context.Context, log *logrus.Entry) error {
+	query := "SELECT id, latitude, longitude FROM stores WHERE active = 1 AND latitude IS NOT NULL AND longitude IS NOT NULL"
+	rows, err := s.db.QueryContext(ctx, query)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+	defer rows.Close()
+
+	locations := make([]*redis.GeoLocation, 0)
+	for rows.Next() {
+		var id int
+		location := &redis.GeoLocation{}
+		if err := rows.Scan(&id, &location.Latitude, &location.Longitude); err != nil {
+			log.Error(err)
+			return err
+		}
+		// a store saved before the latitude was limited would make the whole index fail
+		if math.Abs(location.Latitude) > helper.MaxGeoLatitude {
+			log.Warnf("store %d is beyond the geo index latitude", id)
+			continue
+		}
+		location.Name = strconv.Itoa(id)
+		locations = append(locations, location)
+	}
+
+	if len(locations) == 0 {
+		return nil
+	}
+
+	log.Info("Set store locations to redis")
+	_, err = s.redis.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, storeLocationsKey)
+		pipe.GeoAdd(ctx, storeLocationsKey, locations...)
+		pipe.Expire(ctx, storeLocationsKey, config.RedisExp())
+		return nil
+	})
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+const storeColumns = "id, code, name, address, phone, latitude, longitude, active, created_at, updated_at"
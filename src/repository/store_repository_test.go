@@ -0,0 +1,312 @@
+package repository
+
+import (
+	"cake-store/src/constant"
+	"cake-store/src/model"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStoreRepository_Save(t *testing.T) {
+	kit, closer := initializeRepoTestKit(t)
+	defer closer()
+	mock := kit.dbmock
+
+	repo := storeRepository{
+		db:    kit.db,
+		redis: kit.redis,
+	}
+
+	ctx := context.TODO()
+	now := time.Now()
+	lat, lng := -6.2, 106.8
+	store := &model.Store{Code: "north", Name: "North Branch", Address: "Jl. Utara 1", Phone: "0812", Latitude: &lat, Longitude: &lng, Active: true, CreatedAt: now, UpdatedAt: now}
+
+	t.Run("ok", func(t *testing.T) {
+		require.NoError(t, kit.miniredis.Set(storeLocationsKey, "stale"))
+		mock.ExpectExec("INSERT INTO stores\\(code,name,address,phone,latitude,longitude,active,created_at,updated_at\\)").
+			WithArgs("north", "North Branch", "Jl. Utara 1", "0812", &lat, &lng, true, now, now).
+			WillReturnResult(sqlmock.NewResult(2, 1))
+
+		require.NoError(t, repo.Save(ctx, store))
+		assert.Equal(t, 2, store.Id)
+		assert.False(t, kit.miniredis.Exists(storeLocationsKey))
+	})
+
+	t.Run("duplicate code", func(t *testing.T) {
+		mock.ExpectExec("INSERT INTO stores").WillReturnError(&mysql.MySQLError{Number: 1062, Message: "Duplicate entry"})
+
+		assert.Equal(t, constant.ErrAlreadyExists, repo.Save(ctx, store))
+	})
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestStoreRepository_FindById(t *testing.T) {
+	kit, closer := initializeRepoTestKit(t)
+	defer closer()
+	mock := kit.dbmock
+
+	repo := storeRepository{
+		db: kit.db,
+	}
+
+	ctx := context.TODO()
+	columns := []string{"id", "code", "name", "address", "phone", "latitude", "longitude", "active", "created_at", "updated_at"}
+
+	t.Run("ok", func(t *testing.T) {
+		mock.ExpectQuery("SELECT (.+) FROM stores WHERE id = \\?").
+			WithArgs(2).
+			WillReturnRows(sqlmock.NewRows(columns).AddRow(2, "north", "North Branch", "", "", -6.2, 106.8, false, time.Now(), time.Now()))
+
+		res, err := repo.FindById(ctx, 2)
+		require.NoError(t, err)
+		assert.Equal(t, "north", res.Code)
+		assert.False(t, res.Active)
+		require.True(t, res.Located())
+		assert.Equal(t, -6.2, *res.Latitude)
+	})
+
+	t.Run("ok - no location", func(t *testing.T) {
+		mock.ExpectQuery("SELECT (.+) FROM stores WHERE id = \\?").
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows(columns).AddRow(1, "main", "Main Store", "", "", nil, nil, true, time.Now(), time.Now()))
+
+		res, err := repo.FindById(ctx, 1)
+		require.NoError(t, err)
+		assert.False(t, res.Located())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mock.ExpectQuery("SELECT (.+) FROM stores WHERE id = \\?").
+			WithArgs(3).
+			WillReturnRows(sqlmock.NewRows(columns))
+
+		res, err := repo.FindById(ctx, 3)
+		require.NoError(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("failed to find", func(t *testing.T) {
+		mock.ExpectQuery("SELECT (.+) FROM stores").WillReturnError(errors.New("err db"))
+
+		res, err := repo.FindById(ctx, 2)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestStoreRepository_SaveCake(t *testing.T) {
+	kit, closer := initializeRepoTestKit(t)
+	defer closer()
+	mock := kit.dbmock
+
+	repo := storeRepository{
+		db: kit.db,
+	}
+
+	ctx := context.TODO()
+	now := time.Now()
+	storeCake := &model.StoreCake{
+		StoreId:   2,
+		CakeId:    1,
+		Available: true,
+		Prices:    []*model.StorePrice{{VariantId: 5, Price: model.NewMoney(300000, "IDR")}},
+		UpdatedAt: now,
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec("INSERT INTO store_cakes\\(store_id,cake_id,available,updated_at\\)").
+			WithArgs(2, 1, true, now).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("DELETE FROM store_prices WHERE store_id = \\? AND variant_id IN").
+			WithArgs(2, 1).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectExec("INSERT INTO store_prices\\(store_id,variant_id,price,currency\\)").
+			WithArgs(2, 5, model.NewMoney(300000, "IDR"), "IDR").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		require.NoError(t, repo.SaveCake(ctx, storeCake))
+	})
+
+	t.Run("failed to save", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec("INSERT INTO store_cakes").WillReturnError(errors.New("err db"))
+		mock.ExpectRollback()
+
+		assert.Error(t, repo.SaveCake(ctx, storeCake))
+	})
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestStoreRepository_FindCake(t *testing.T) {
+	kit, closer := initializeRepoTestKit(t)
+	defer closer()
+	mock := kit.dbmock
+
+	repo := storeRepository{
+		db: kit.db,
+	}
+
+	ctx := context.TODO()
+
+	t.Run("ok", func(t *testing.T) {
+		mock.ExpectQuery("SELECT available, updated_at FROM store_cakes WHERE store_id = \\? AND cake_id = \\?").
+			WithArgs(2, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"available", "updated_at"}).AddRow(false, time.Now()))
+		mock.ExpectQuery("SELECT sp.variant_id, sp.price, sp.currency FROM store_prices sp").
+			WithArgs(2, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"variant_id", "price", "currency"}).AddRow(5, 300000, "IDR"))
+
+		res, err := repo.FindCake(ctx, 2, 1)
+		require.NoError(t, err)
+		assert.False(t, res.Available)
+		price, ok := res.Price(5)
+		require.True(t, ok)
+		assert.Equal(t, model.NewMoney(300000, "IDR"), price)
+	})
+
+	t.Run("no setting", func(t *testing.T) {
+		mock.ExpectQuery("SELECT available, updated_at FROM store_cakes").
+			WithArgs(2, 9).
+			WillReturnRows(sqlmock.NewRows([]string{"available", "updated_at"}))
+
+		res, err := repo.FindCake(ctx, 2, 9)
+		require.NoError(t, err)
+		assert.Nil(t, res)
+	})
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestStoreRepository_FindPrices(t *testing.T) {
+	kit, closer := initializeRepoTestKit(t)
+	defer closer()
+	mock := kit.dbmock
+
+	repo := storeRepository{
+		db: kit.db,
+	}
+
+	ctx := context.TODO()
+
+	t.Run("ok", func(t *testing.T) {
+		mock.ExpectQuery("SELECT variant_id, price, currency FROM store_prices WHERE store_id = \\? AND variant_id IN \\(\\?,\\?\\)").
+			WithArgs(2, 5, 6).
+			WillReturnRows(sqlmock.NewRows([]string{"variant_id", "price", "currency"}).AddRow(6, 450000, "IDR"))
+
+		res, err := repo.FindPrices(ctx, 2, []int{5, 6})
+		require.NoError(t, err)
+		assert.Equal(t, map[int]model.Money{6: model.NewMoney(450000, "IDR")}, res)
+	})
+
+	t.Run("no variant", func(t *testing.T) {
+		res, err := repo.FindPrices(ctx, 2, nil)
+		require.NoError(t, err)
+		assert.Empty(t, res)
+	})
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestStoreRepository_FindNearest(t *testing.T) {
+	kit, closer := initializeRepoTestKit(t)
+	defer closer()
+	mock := kit.dbmock
+
+	repo := storeRepository{
+		db:    kit.db,
+		redis: kit.redis,
+	}
+
+	ctx := context.TODO()
+	columns := []string{"id", "code", "name", "address", "phone", "latitude", "longitude", "active", "created_at", "updated_at"}
+	locations := []string{"id", "latitude", "longitude"}
+
+	t.Run("ok - index the locations", func(t *testing.T) {
+		// Monas, Jakarta then Gedung Sate, Bandung
+		mock.ExpectQuery("SELECT id, latitude, longitude FROM stores WHERE active = 1 AND latitude IS NOT NULL").
+			WillReturnRows(sqlmock.NewRows(locations).AddRow(1, -6.1754, 106.8272).AddRow(2, -6.9025, 107.6188))
+		mock.ExpectQuery("SELECT (.+) FROM stores WHERE active = 1 AND id IN \\(\\?,\\?\\)").
+			WithArgs(2, 1).
+			WillReturnRows(sqlmock.NewRows(columns).
+				AddRow(1, "main", "Main Store", "", "", -6.1754, 106.8272, true, time.Now(), time.Now()).
+				AddRow(2, "bandung", "Bandung", "", "", -6.9025, 107.6188, true, time.Now(), time.Now()))
+
+		// Lembang, north of Bandung
+		res, err := repo.FindNearest(ctx, -6.8117, 107.6176, 2)
+		require.NoError(t, err)
+		require.Len(t, res, 2)
+		assert.Equal(t, 2, res[0].Id)
+		assert.InDelta(t, 10.1, res[0].DistanceKm, 0.1)
+		assert.Equal(t, 1, res[1].Id)
+		assert.True(t, kit.miniredis.Exists(storeLocationsKey))
+	})
+
+	t.Run("ok - from the index", func(t *testing.T) {
+		mock.ExpectQuery("SELECT (.+) FROM stores WHERE active = 1 AND id IN \\(\\?\\)").
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows(columns).AddRow(1, "main", "Main Store", "", "", -6.1754, 106.8272, true, time.Now(), time.Now()))
+
+		res, err := repo.FindNearest(ctx, -6.2, 106.8, 1)
+		require.NoError(t, err)
+		require.Len(t, res, 1)
+		assert.Equal(t, 1, res[0].Id)
+	})
+
+	t.Run("ok - store deactivated since indexed", func(t *testing.T) {
+		mock.ExpectQuery("SELECT (.+) FROM stores WHERE active = 1 AND id IN").
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows(columns))
+
+		res, err := repo.FindNearest(ctx, -6.2, 106.8, 1)
+		require.NoError(t, err)
+		assert.Empty(t, res)
+	})
+
+	t.Run("ok - polar store left out of the index", func(t *testing.T) {
+		kit.miniredis.Del(storeLocationsKey)
+		mock.ExpectQuery("SELECT id, latitude, longitude FROM stores").
+			WillReturnRows(sqlmock.NewRows(locations).AddRow(1, -6.1754, 106.8272).AddRow(3, 88.0, 15.0))
+		mock.ExpectQuery("SELECT (.+) FROM stores WHERE active = 1 AND id IN \\(\\?\\)").
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows(columns).AddRow(1, "main", "Main Store", "", "", -6.1754, 106.8272, true, time.Now(), time.Now()))
+
+		res, err := repo.FindNearest(ctx, -6.2, 106.8, 2)
+		require.NoError(t, err)
+		require.Len(t, res, 1)
+		assert.Equal(t, 1, res[0].Id)
+	})
+
+	t.Run("ok - no located store", func(t *testing.T) {
+		kit.miniredis.Del(storeLocationsKey)
+		mock.ExpectQuery("SELECT id, latitude, longitude FROM stores").
+			WillReturnRows(sqlmock.NewRows(locations))
+
+		res, err := repo.FindNearest(ctx, -6.2, 106.8, 1)
+		require.NoError(t, err)
+		assert.Empty(t, res)
+	})
+
+	t.Run("failed to index", func(t *testing.T) {
+		mock.ExpectQuery("SELECT id, latitude, longitude FROM stores").WillReturnError(errors.New("err db"))
+
+		res, err := repo.FindNearest(ctx, -6.2, 106.8, 1)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,298 @@
+package repository
+
+import (
+	"cake-store/src/model"
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+type taxonomyRepository struct {
+	db       *sql.DB
+	taxonomy model.Taxonomy
+}
+
+// NewTaxonomyRepository store the terms of the taxonomy in its table, and the terms of the cakes in its join table
+func NewTaxonomyRepository(db *sql.DB, taxonomy model.Taxonomy) model.TaxonomyRepository {
+	return &taxonomyRepository{
+		db:       db,
+		taxonomy: taxonomy,
+	}
+}
+
+func (t *taxonomyRepository) Save(ctx context.Context, term *model.Term) error {
+	log := logrus.WithFields(logrus.Fields{
+		"message":  "Save Taxonomy Repository",
+		"taxonomy": t.taxonomy.Name,
+		"term":     term,
+	})
+
+	query := "INSERT INTO " + t.taxonomy.Table + "(name,slug,created_at,updated_at) VALUES (?,?,?,?)"
+	args := []interface{}{term.Name, term.Slug, term.CreatedAt, term.UpdatedAt}
+	if t.taxonomy.Described {
+		query = "INSERT INTO " + t.taxonomy.Table + "(name,slug,description,created_at,updated_at) VALUES (?,?,?,?,?)"
+		args = []interface{}{term.Name, term.Slug, description(term), term.CreatedAt, term.UpdatedAt}
+	}
+
+	res, err := t.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		log.Error(err)
+		return duplicateErr(err)
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	term.Id = int(id)
+	return nil
+}
+
+func (t *taxonomyRepository) Update(ctx context.Context, term *model.Term) error {
+	log := logrus.WithFields(logrus.Fields{
+		"message":  "Update Taxonomy Repository",
+		"taxonomy": t.taxonomy.Name,
+		"term":     term,
+	})
+
+	query := "UPDATE " + t.taxonomy.Table + " SET name = ?, slug = ?, updated_at = ? WHERE id = ?"
+	args := []interface{}{term.Name, term.Slug, term.UpdatedAt, term.Id}
+	if t.taxonomy.Described {
+		query = "UPDATE " + t.taxonomy.Table + " SET name = ?, slug = ?, description = ?, updated_at = ? WHERE id = ?"
+		args = []interface{}{term.Name, term.Slug, description(term), term.UpdatedAt, term.Id}
+	}
+
+	if _, err := t.db.ExecContext(ctx, query, args...); err != nil {
+		log.Error(err)
+		return duplicateErr(err)
+	}
+
+	return nil
+}
+
+func (t *taxonomyRepository) Delete(ctx context.Context, term *model.Term) error {
+	log := logrus.WithFields(logrus.Fields{
+		"message":  "Delete Taxonomy Repository",
+		"taxonomy": t.taxonomy.Name,
+		"term":     term,
+	})
+
+	query := "DELETE FROM " + t.taxonomy.Table + " WHERE id = ?"
+
+	_, err := t.db.ExecContext(ctx, query, term.Id)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+func (t *taxonomyRepository) FindAll(ctx context.Context) ([]*model.Term, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message":  "Find All Taxonomy Repository",
+		"taxonomy": t.taxonomy.Name,
+	})
+
+	sql := "SELECT " + t.columns("") + " FROM " + t.taxonomy.Table + " ORDER BY name ASC"
+	return t.findTerms(ctx, log, sql)
+}
+
+func (t *taxonomyRepository) FindById(ctx context.Context, id int) (*model.Term, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message":  "Find By ID Taxonomy Repository",
+		"taxonomy": t.taxonomy.Name,
+		"id":       id,
+	})
+
+	sql := "SELECT " + t.columns("") + " FROM " + t.taxonomy.Table + " WHERE id = ?"
+	terms, err := t.findTerms(ctx, log, sql, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(terms) == 0 {
+		return nil, nil
+	}
+	return terms[0], nil
+}
+
+func (t *taxonomyRepository) FindByIds(ctx context.Context, ids []int) ([]*model.Term, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message":  "Find By IDs Taxonomy Repository",
+		"taxonomy": t.taxonomy.Name,
+		"ids":      ids,
+	})
+
+	if len(ids) == 0 {
+		return make([]*model.Term, 0), nil
+	}
+
+	sql := "SELECT " + t.columns("") + " FROM " + t.taxonomy.Table + " WHERE id IN (" + placeholders(len(ids)) + ") ORDER BY name ASC"
+	return t.findTerms(ctx, log, sql, intArgs(ids)...)
+}
+
+// FindCakeIds find the id of the cakes having the term
+func (t *taxonomyRepository) FindCakeIds(ctx context.Context, termId int) ([]int, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message":  "Find Cake IDs Taxonomy Repository",
+		"taxonomy": t.taxonomy.Name,
+		"termId":   termId,
+	})
+
+	sql := "SELECT cake_id FROM " + t.taxonomy.JoinTable + " WHERE " + t.taxonomy.JoinColumn + " = ?"
+	rows, err := t.db.QueryContext(ctx, sql, termId)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := make([]int, 0)
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			log.Error(err)
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// SetCakeTerms replace the terms of the cake
+func (t *taxonomyRepository) SetCakeTerms(ctx context.Context, cakeId int, termIds []int) error {
+	log := logrus.WithFields(logrus.Fields{
+		"message":  "Set Cake Terms Taxonomy Repository",
+		"taxonomy": t.taxonomy.Name,
+		"cakeId":   cakeId,
+		"termIds":  termIds,
+	})
+
+	tx, err := t.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err = tx.ExecContext(ctx, "DELETE FROM "+t.taxonomy.JoinTable+" WHERE cake_id = ?", cakeId); err != nil {
+		log.Error(err)
+		return err
+	}
+
+	if len(termIds) > 0 {
+		values := make([]string, 0, len(termIds))
+		args := make([]interface{}, 0, len(termIds)*2)
+		for _, termId := range termIds {
+			values = append(values, "(?,?)")
+			args = append(args, cakeId, termId)
+		}
+
+		query := "INSERT INTO " + t.taxonomy.JoinTable + "(cake_id," + t.taxonomy.JoinColumn + ") VALUES " + strings.Join(values, ",")
+		if _, err = tx.ExecContext(ctx, query, args...); err != nil {
+			log.Error(err)
+			return err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		log.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// LoadCakes embed the terms of each cake
+func (t *taxonomyRepository) LoadCakes(ctx context.Context, cakes []*model.Cake) error {
+	log := logrus.WithFields(logrus.Fields{
+		"message":  "Load Cakes Taxonomy Repository",
+		"taxonomy": t.taxonomy.Name,
+	})
+
+	if len(cakes) == 0 {
+		return nil
+	}
+
+	ids := model.CakeIds(cakes)
+	sql := "SELECT j.cake_id, " + t.columns("t.") + " FROM " + t.taxonomy.JoinTable + " j JOIN " + t.taxonomy.Table + " t ON t.id = j." +
+		t.taxonomy.JoinColumn + " WHERE j.cake_id IN (" + placeholders(len(ids)) + ") ORDER BY t.name ASC"
+	rows, err := t.db.QueryContext(ctx, sql, intArgs(ids)...)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+	defer rows.Close()
+
+	terms := make(map[int][]*model.Term)
+	for rows.Next() {
+		var cakeId int
+		term, err := scanTerm(rows, &cakeId)
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+		terms[cakeId] = append(terms[cakeId], term)
+	}
+
+	for _, cake := range cakes {
+		t.taxonomy.Embed(cake, terms[cake.Id])
+	}
+	return nil
+}
+
+func (t *taxonomyRepository) findTerms(ctx context.Context, log *logrus.Entry, sql string, args ...interface{}) ([]*model.Term, error) {
+	rows, err := t.db.QueryContext(ctx, sql, args...)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	terms := make([]*model.Term, 0)
+	for rows.Next() {
+		term, err := scanTerm(rows)
+		if err != nil {
+			log.Error(err)
+			return nil, err
+		}
+		terms = append(terms, term)
+	}
+	return terms, nil
+}
+
+// columns is the selected columns of the terms in the order read by scanTerm, the description of a taxonomy that is
+// not described is selected as null
+func (t *taxonomyRepository) columns(prefix string) string {
+	description := "NULL"
+	if t.taxonomy.Described {
+		description = prefix + "description"
+	}
+	return prefix + "id, " + prefix + "name, " + prefix + "slug, " + description + ", " + prefix + "created_at, " + prefix + "updated_at"
+}
+
+// scanTerm scan the term columns, after the given leading destinations
+func scanTerm(rows *sql.Rows, dest ...interface{}) (*model.Term, error) {
+	term := &model.Term{}
+	var description sql.NullString
+	dest = append(dest, &term.Id, &term.Name, &term.Slug, &description, &term.CreatedAt, &term.UpdatedAt)
+	if err := rows.Scan(dest...); err != nil {
+		return nil, err
+	}
+	if description.Valid {
+		term.Description = &description.String
+	}
+	return term, nil
+}
+
+func description(term *model.Term) string {
+	if term.Description == nil {
+		return ""
+	}
+	return *term.Description
+}
@@ -0,0 +1,385 @@
+package repository
+
+import (
+	"cake-store/src/constant"
+	"cake-store/src/model"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var termColumns = []string{"id", "name", "slug", "description", "created_at", "updated_at"}
+
+func TestTaxonomyRepository_Create(t *testing.T) {
+	kit, closer := initializeRepoTestKit(t)
+	defer closer()
+	mock := kit.dbmock
+
+	ctx := context.TODO()
+	description := "Desc test"
+
+	t.Run("ok - described", func(t *testing.T) {
+		repo := taxonomyRepository{db: kit.db, taxonomy: model.Categories}
+		term := &model.Term{Name: "Birthday", Slug: "birthday", Description: &description, CreatedAt: time.Now(), UpdatedAt: time.Now()}
+
+		mock.ExpectExec("INSERT INTO categories\\(name,slug,description,created_at,updated_at\\)").
+			WithArgs(term.Name, term.Slug, description, term.CreatedAt, term.UpdatedAt).
+			WillReturnResult(sqlmock.NewResult(3, 1))
+		err := repo.Save(ctx, term)
+		require.NoError(t, err)
+		assert.Equal(t, 3, term.Id)
+	})
+
+	t.Run("ok - not described", func(t *testing.T) {
+		repo := taxonomyRepository{db: kit.db, taxonomy: model.Tags}
+		term := &model.Term{Name: "Chocolate", Slug: "chocolate", CreatedAt: time.Now(), UpdatedAt: time.Now()}
+
+		mock.ExpectExec("INSERT INTO tags\\(name,slug,created_at,updated_at\\)").
+			WithArgs(term.Name, term.Slug, term.CreatedAt, term.UpdatedAt).
+			WillReturnResult(sqlmock.NewResult(4, 1))
+		err := repo.Save(ctx, term)
+		require.NoError(t, err)
+		assert.Equal(t, 4, term.Id)
+	})
+
+	t.Run("duplicate slug", func(t *testing.T) {
+		repo := taxonomyRepository{db: kit.db, taxonomy: model.Tags}
+		term := &model.Term{Name: "Chocolate", Slug: "chocolate"}
+
+		mock.ExpectExec("INSERT INTO tags").
+			WillReturnError(&mysql.MySQLError{Number: 1062, Message: "Duplicate entry"})
+		err := repo.Save(ctx, term)
+		require.Equal(t, constant.ErrAlreadyExists, err)
+	})
+
+	t.Run("failed to save term", func(t *testing.T) {
+		repo := taxonomyRepository{db: kit.db, taxonomy: model.Categories}
+		term := &model.Term{Name: "Birthday", Slug: "birthday"}
+
+		mock.ExpectExec("INSERT INTO categories").
+			WillReturnError(errors.New("db error"))
+		err := repo.Save(ctx, term)
+		require.Error(t, err)
+	})
+}
+
+func TestTaxonomyRepository_Update(t *testing.T) {
+	kit, closer := initializeRepoTestKit(t)
+	defer closer()
+	mock := kit.dbmock
+
+	ctx := context.TODO()
+	description := "Desc test"
+
+	t.Run("ok - described", func(t *testing.T) {
+		repo := taxonomyRepository{db: kit.db, taxonomy: model.Categories}
+		term := &model.Term{Id: 1, Name: "Birthday", Slug: "birthday", Description: &description, UpdatedAt: time.Now()}
+
+		mock.ExpectExec("UPDATE categories SET name = \\?, slug = \\?, description = \\?, updated_at = \\? WHERE id = \\?").
+			WithArgs(term.Name, term.Slug, description, term.UpdatedAt, term.Id).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		err := repo.Update(ctx, term)
+		require.NoError(t, err)
+	})
+
+	t.Run("ok - not described", func(t *testing.T) {
+		repo := taxonomyRepository{db: kit.db, taxonomy: model.Tags}
+		term := &model.Term{Id: 1, Name: "Chocolate", Slug: "chocolate", UpdatedAt: time.Now()}
+
+		mock.ExpectExec("UPDATE tags SET name = \\?, slug = \\?, updated_at = \\? WHERE id = \\?").
+			WithArgs(term.Name, term.Slug, term.UpdatedAt, term.Id).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		err := repo.Update(ctx, term)
+		require.NoError(t, err)
+	})
+
+	t.Run("duplicate slug", func(t *testing.T) {
+		repo := taxonomyRepository{db: kit.db, taxonomy: model.Categories}
+		term := &model.Term{Id: 1, Name: "Birthday", Slug: "birthday"}
+
+		mock.ExpectExec("UPDATE categories").
+			WillReturnError(&mysql.MySQLError{Number: 1062, Message: "Duplicate entry"})
+		err := repo.Update(ctx, term)
+		require.Equal(t, constant.ErrAlreadyExists, err)
+	})
+}
+
+func TestTaxonomyRepository_Delete(t *testing.T) {
+	kit, closer := initializeRepoTestKit(t)
+	defer closer()
+	mock := kit.dbmock
+
+	repo := taxonomyRepository{db: kit.db, taxonomy: model.Tags}
+	ctx := context.TODO()
+	term := &model.Term{Id: 1}
+
+	t.Run("ok", func(t *testing.T) {
+		mock.ExpectExec("DELETE FROM tags WHERE id = \\?").
+			WithArgs(term.Id).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		err := repo.Delete(ctx, term)
+		require.NoError(t, err)
+	})
+
+	t.Run("failed to delete term", func(t *testing.T) {
+		mock.ExpectExec("DELETE FROM tags").
+			WithArgs(term.Id).
+			WillReturnError(errors.New("db error"))
+		err := repo.Delete(ctx, term)
+		require.Error(t, err)
+	})
+}
+
+func TestTaxonomyRepository_FindAll(t *testing.T) {
+	kit, closer := initializeRepoTestKit(t)
+	defer closer()
+	mock := kit.dbmock
+
+	ctx := context.TODO()
+
+	t.Run("ok - described", func(t *testing.T) {
+		repo := taxonomyRepository{db: kit.db, taxonomy: model.Categories}
+		resRows := sqlmock.NewRows(termColumns).
+			AddRow(1, "Birthday", "birthday", "", time.Now(), time.Now()).
+			AddRow(2, "Vegan", "vegan", "Plant based", time.Now(), time.Now())
+
+		mock.ExpectQuery("SELECT id, name, slug, description, created_at, updated_at FROM categories ORDER BY name ASC").
+			WillReturnRows(resRows)
+
+		res, err := repo.FindAll(ctx)
+		require.NoError(t, err)
+		require.Equal(t, 2, len(res))
+		require.NotNil(t, res[0].Description)
+		assert.Equal(t, "", *res[0].Description)
+		assert.Equal(t, "Plant based", *res[1].Description)
+	})
+
+	t.Run("ok - not described", func(t *testing.T) {
+		repo := taxonomyRepository{db: kit.db, taxonomy: model.Tags}
+		resRows := sqlmock.NewRows(termColumns).
+			AddRow(1, "Chocolate", "chocolate", nil, time.Now(), time.Now())
+
+		mock.ExpectQuery("SELECT id, name, slug, NULL, created_at, updated_at FROM tags ORDER BY name ASC").
+			WillReturnRows(resRows)
+
+		res, err := repo.FindAll(ctx)
+		require.NoError(t, err)
+		require.Equal(t, 1, len(res))
+		assert.Nil(t, res[0].Description)
+	})
+
+	t.Run("failed to find terms", func(t *testing.T) {
+		repo := taxonomyRepository{db: kit.db, taxonomy: model.Tags}
+		mock.ExpectQuery("SELECT (.+) FROM tags").
+			WillReturnError(errors.New("db error"))
+
+		_, err := repo.FindAll(ctx)
+		require.Error(t, err)
+	})
+}
+
+func TestTaxonomyRepository_FindById(t *testing.T) {
+	kit, closer := initializeRepoTestKit(t)
+	defer closer()
+	mock := kit.dbmock
+
+	repo := taxonomyRepository{db: kit.db, taxonomy: model.Categories}
+	ctx := context.TODO()
+
+	t.Run("ok", func(t *testing.T) {
+		resRows := sqlmock.NewRows(termColumns).
+			AddRow(1, "Birthday", "birthday", "", time.Now(), time.Now())
+
+		mock.ExpectQuery("SELECT (.+) FROM categories WHERE id = \\?").
+			WithArgs(1).
+			WillReturnRows(resRows)
+
+		res, err := repo.FindById(ctx, 1)
+		require.NoError(t, err)
+		assert.Equal(t, "birthday", res.Slug)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mock.ExpectQuery("SELECT (.+) FROM categories WHERE id = \\?").
+			WithArgs(2).
+			WillReturnRows(sqlmock.NewRows(termColumns))
+
+		res, err := repo.FindById(ctx, 2)
+		require.NoError(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestTaxonomyRepository_FindByIds(t *testing.T) {
+	kit, closer := initializeRepoTestKit(t)
+	defer closer()
+	mock := kit.dbmock
+
+	repo := taxonomyRepository{db: kit.db, taxonomy: model.Tags}
+	ctx := context.TODO()
+
+	t.Run("ok", func(t *testing.T) {
+		resRows := sqlmock.NewRows(termColumns).
+			AddRow(1, "Chocolate", "chocolate", nil, time.Now(), time.Now()).
+			AddRow(2, "Fruit", "fruit", nil, time.Now(), time.Now())
+
+		mock.ExpectQuery("SELECT (.+) FROM tags WHERE id IN \\(\\?,\\?\\)").
+			WithArgs(1, 2).
+			WillReturnRows(resRows)
+
+		res, err := repo.FindByIds(ctx, []int{1, 2})
+		require.NoError(t, err)
+		assert.Equal(t, 2, len(res))
+	})
+
+	t.Run("ok - empty ids", func(t *testing.T) {
+		res, err := repo.FindByIds(ctx, nil)
+		require.NoError(t, err)
+		assert.Equal(t, 0, len(res))
+	})
+}
+
+func TestTaxonomyRepository_FindCakeIds(t *testing.T) {
+	kit, closer := initializeRepoTestKit(t)
+	defer closer()
+	mock := kit.dbmock
+
+	ctx := context.TODO()
+
+	t.Run("ok - categories", func(t *testing.T) {
+		repo := taxonomyRepository{db: kit.db, taxonomy: model.Categories}
+		mock.ExpectQuery("SELECT cake_id FROM cake_categories WHERE category_id = \\?").
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"cake_id"}).AddRow(4).AddRow(7))
+
+		res, err := repo.FindCakeIds(ctx, 1)
+		require.NoError(t, err)
+		assert.Equal(t, []int{4, 7}, res)
+	})
+
+	t.Run("ok - tags", func(t *testing.T) {
+		repo := taxonomyRepository{db: kit.db, taxonomy: model.Tags}
+		mock.ExpectQuery("SELECT cake_id FROM cake_tags WHERE tag_id = \\?").
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"cake_id"}).AddRow(2))
+
+		res, err := repo.FindCakeIds(ctx, 1)
+		require.NoError(t, err)
+		assert.Equal(t, []int{2}, res)
+	})
+}
+
+func TestTaxonomyRepository_SetCakeTerms(t *testing.T) {
+	kit, closer := initializeRepoTestKit(t)
+	defer closer()
+	mock := kit.dbmock
+
+	repo := taxonomyRepository{db: kit.db, taxonomy: model.Categories}
+	ctx := context.TODO()
+
+	t.Run("ok", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec("DELETE FROM cake_categories WHERE cake_id = \\?").
+			WithArgs(1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("INSERT INTO cake_categories\\(cake_id,category_id\\) VALUES \\(\\?,\\?\\),\\(\\?,\\?\\)").
+			WithArgs(1, 2, 1, 3).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectCommit()
+
+		err := repo.SetCakeTerms(ctx, 1, []int{2, 3})
+		require.NoError(t, err)
+	})
+
+	t.Run("ok - clear terms", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec("DELETE FROM cake_categories").
+			WithArgs(1).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectCommit()
+
+		err := repo.SetCakeTerms(ctx, 1, nil)
+		require.NoError(t, err)
+	})
+
+	t.Run("failed to insert terms", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec("DELETE FROM cake_categories").
+			WithArgs(1).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("INSERT INTO cake_categories").
+			WithArgs(1, 2).
+			WillReturnError(errors.New("db error"))
+		mock.ExpectRollback()
+
+		err := repo.SetCakeTerms(ctx, 1, []int{2})
+		require.Error(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestTaxonomyRepository_LoadCakes(t *testing.T) {
+	kit, closer := initializeRepoTestKit(t)
+	defer closer()
+	mock := kit.dbmock
+
+	ctx := context.TODO()
+	loadColumns := append([]string{"cake_id"}, termColumns...)
+
+	t.Run("ok - categories", func(t *testing.T) {
+		repo := taxonomyRepository{db: kit.db, taxonomy: model.Categories}
+		cakes := []*model.Cake{{Id: 1}, {Id: 2}}
+		resRows := sqlmock.NewRows(loadColumns).
+			AddRow(1, 1, "Birthday", "birthday", "", time.Now(), time.Now()).
+			AddRow(1, 2, "Vegan", "vegan", "", time.Now(), time.Now())
+
+		mock.ExpectQuery("SELECT j.cake_id, t.id, t.name, t.slug, t.description, (.+) FROM cake_categories j JOIN categories t ON t.id = j.category_id WHERE j.cake_id IN \\(\\?,\\?\\)").
+			WithArgs(1, 2).
+			WillReturnRows(resRows)
+
+		err := repo.LoadCakes(ctx, cakes)
+		require.NoError(t, err)
+		assert.Equal(t, 2, len(cakes[0].Categories))
+		assert.Nil(t, cakes[1].Categories)
+		assert.Nil(t, cakes[0].Tags)
+	})
+
+	t.Run("ok - tags", func(t *testing.T) {
+		repo := taxonomyRepository{db: kit.db, taxonomy: model.Tags}
+		cakes := []*model.Cake{{Id: 1}}
+		resRows := sqlmock.NewRows(loadColumns).
+			AddRow(1, 3, "Chocolate", "chocolate", nil, time.Now(), time.Now())
+
+		mock.ExpectQuery("SELECT j.cake_id, t.id, t.name, t.slug, NULL, (.+) FROM cake_tags j JOIN tags t ON t.id = j.tag_id WHERE j.cake_id IN \\(\\?\\)").
+			WithArgs(1).
+			WillReturnRows(resRows)
+
+		err := repo.LoadCakes(ctx, cakes)
+		require.NoError(t, err)
+		require.Equal(t, 1, len(cakes[0].Tags))
+		assert.Nil(t, cakes[0].Tags[0].Description)
+		assert.Nil(t, cakes[0].Categories)
+	})
+
+	t.Run("ok - no cake", func(t *testing.T) {
+		repo := taxonomyRepository{db: kit.db, taxonomy: model.Tags}
+		err := repo.LoadCakes(ctx, nil)
+		require.NoError(t, err)
+	})
+
+	t.Run("failed to load terms", func(t *testing.T) {
+		repo := taxonomyRepository{db: kit.db, taxonomy: model.Tags}
+		mock.ExpectQuery("SELECT (.+) FROM cake_tags").
+			WithArgs(1).
+			WillReturnError(errors.New("db error"))
+
+		err := repo.LoadCakes(ctx, []*model.Cake{{Id: 1}})
+		require.Error(t, err)
+	})
+}
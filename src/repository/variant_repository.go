@@ -0,0 +1,155 @@
+package repository
+
+import (
+	"cake-store/src/model"
+	"context"
+	"database/sql"
+
+	"github.com/sirupsen/logrus"
+)
+
+type variantRepository struct {
+	db *sql.DB
+}
+
+func NewVariantRepository(db *sql.DB) model.VariantRepository {
+	return &variantRepository{
+		db: db,
+	}
+}
+
+func (v *variantRepository) Save(ctx context.Context, variant *model.Variant) error {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Save Variant Repository",
+		"variant": variant,
+	})
+
+	sql := "INSERT INTO cake_variants(cake_id,size,servings,price,currency,sku,active,created_at,updated_at) VALUES (?,?,?,?,?,?,?,?,?)"
+	res, err := v.db.ExecContext(ctx, sql, variant.CakeId, variant.Size, variant.Servings, variant.Price, variant.Price.Currency, variant.Sku, variant.Active, variant.CreatedAt, variant.UpdatedAt)
+	if err != nil {
+		log.Error(err)
+		return duplicateErr(err)
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	variant.Id = int(id)
+	return nil
+}
+
+func (v *variantRepository) Update(ctx context.Context, variant *model.Variant) error {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Update Variant Repository",
+		"variant": variant,
+	})
+
+	query := "UPDATE cake_variants SET size = ?, servings = ?, price = ?, currency = ?, sku = ?, active = ?, updated_at = ? WHERE id = ?"
+
+	_, err := v.db.ExecContext(ctx, query, variant.Size, variant.Servings, variant.Price, variant.Price.Currency, variant.Sku, variant.Active, variant.UpdatedAt, variant.Id)
+	if err != nil {
+		log.Error(err)
+		return duplicateErr(err)
+	}
+
+	return nil
+}
+
+func (v *variantRepository) Delete(ctx context.Context, variant *model.Variant) error {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Delete Variant Repository",
+		"variant": variant,
+	})
+
+	query := "DELETE FROM cake_variants WHERE id = ?"
+
+	_, err := v.db.ExecContext(ctx, query, variant.Id)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+func (v *variantRepository) FindById(ctx context.Context, id int) (*model.Variant, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Find By ID Variant Repository",
+		"id":      id,
+	})
+
+	sql := "SELECT " + variantColumns + " FROM cake_variants WHERE id = ?"
+	variants, err := v.findVariants(ctx, log, sql, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(variants) == 0 {
+		return nil, nil
+	}
+	return variants[0], nil
+}
+
+func (v *variantRepository) FindByCakeId(ctx context.Context, cakeId int) ([]*model.Variant, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Find By Cake ID Variant Repository",
+		"cakeId":  cakeId,
+	})
+
+	sql := "SELECT " + variantColumns + " FROM cake_variants WHERE cake_id = ? ORDER BY servings ASC, id ASC"
+	return v.findVariants(ctx, log, sql, cakeId)
+}
+
+// LoadCakes embed the variants of each cake
+func (v *variantRepository) LoadCakes(ctx context.Context, cakes []*model.Cake) error {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Load Cakes Variant Repository",
+	})
+
+	if len(cakes) == 0 {
+		return nil
+	}
+
+	ids := model.CakeIds(cakes)
+	sql := "SELECT " + variantColumns + " FROM cake_variants WHERE cake_id IN (" + placeholders(len(ids)) + ") ORDER BY servings ASC, id ASC"
+	variants, err := v.findVariants(ctx, log, sql, intArgs(ids)...)
+	if err != nil {
+		return err
+	}
+
+	byCake := make(map[int][]*model.Variant)
+	for _, variant := range variants {
+		byCake[variant.CakeId] = append(byCake[variant.CakeId], variant)
+	}
+
+	for _, cake := range cakes {
+		cake.Variants = byCake[cake.Id]
+	}
+	return nil
+}
+
+func (v *variantRepository) findVariants(ctx context.Context, log *logrus.Entry, sql string, args ...interface{}) ([]*model.Variant, error) {
+	rows, err := v.db.QueryContext(ctx, sql, args...)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	variants := make([]*model.Variant, 0)
+	for rows.Next() {
+		variant := &model.Variant{}
+		err := rows.Scan(&variant.Id, &variant.CakeId, &variant.Size, &variant.Servings, &variant.Price, &variant.Price.Currency, &variant.Sku, &variant.Active, &variant.CreatedAt, &variant.UpdatedAt)
+		if err != nil {
+			log.Error(err)
+			return nil, err
+		}
+		variants = append(variants, variant)
+	}
+	return variants, nil
+}
+
+const variantColumns = "id, cake_id, size, servings, price, currency, sku, active, created_at, updated_at"
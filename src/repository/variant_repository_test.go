@@ -0,0 +1,206 @@
+package repository
+
+import (
+	"cake-store/src/constant"
+	"cake-store/src/model"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var variantRowColumns = []string{"id", "cake_id", "size", "servings", "price", "currency", "sku", "active", "created_at", "updated_at"}
+
+func TestVariantRepository_Create(t *testing.T) {
+	kit, closer := initializeRepoTestKit(t)
+	defer closer()
+	mock := kit.dbmock
+
+	repo := variantRepository{
+		db: kit.db,
+	}
+
+	ctx := context.TODO()
+
+	variant := &model.Variant{
+		CakeId:    1,
+		Size:      "20cm",
+		Servings:  8,
+		Price:     model.NewMoney(250000, "IDR"),
+		Sku:       "CHOCO-20",
+		Active:    true,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		mock.ExpectExec("INSERT INTO cake_variants").
+			WithArgs(variant.CakeId, variant.Size, variant.Servings, variant.Price, variant.Price.Currency, variant.Sku, variant.Active, variant.CreatedAt, variant.UpdatedAt).
+			WillReturnResult(sqlmock.NewResult(5, 1))
+		err := repo.Save(ctx, variant)
+		require.NoError(t, err)
+		assert.Equal(t, 5, variant.Id)
+	})
+
+	t.Run("duplicate sku", func(t *testing.T) {
+		mock.ExpectExec("INSERT INTO cake_variants").
+			WithArgs(variant.CakeId, variant.Size, variant.Servings, variant.Price, variant.Price.Currency, variant.Sku, variant.Active, variant.CreatedAt, variant.UpdatedAt).
+			WillReturnError(&mysql.MySQLError{Number: 1062, Message: "Duplicate entry"})
+		err := repo.Save(ctx, variant)
+		require.Equal(t, constant.ErrAlreadyExists, err)
+	})
+}
+
+func TestVariantRepository_Update(t *testing.T) {
+	kit, closer := initializeRepoTestKit(t)
+	defer closer()
+	mock := kit.dbmock
+
+	repo := variantRepository{
+		db: kit.db,
+	}
+
+	ctx := context.TODO()
+
+	variant := &model.Variant{Id: 5, CakeId: 1, Size: "24cm", Servings: 12, Price: model.NewMoney(320000, "IDR"), Sku: "CHOCO-24", UpdatedAt: time.Now()}
+
+	t.Run("ok", func(t *testing.T) {
+		mock.ExpectExec("UPDATE cake_variants SET (.+) WHERE id = \\?").
+			WithArgs(variant.Size, variant.Servings, variant.Price, variant.Price.Currency, variant.Sku, variant.Active, variant.UpdatedAt, variant.Id).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		err := repo.Update(ctx, variant)
+		require.NoError(t, err)
+	})
+
+	t.Run("failed to update variant", func(t *testing.T) {
+		mock.ExpectExec("UPDATE cake_variants").
+			WithArgs(variant.Size, variant.Servings, variant.Price, variant.Price.Currency, variant.Sku, variant.Active, variant.UpdatedAt, variant.Id).
+			WillReturnError(errors.New("db error"))
+		err := repo.Update(ctx, variant)
+		require.Error(t, err)
+	})
+}
+
+func TestVariantRepository_Delete(t *testing.T) {
+	kit, closer := initializeRepoTestKit(t)
+	defer closer()
+	mock := kit.dbmock
+
+	repo := variantRepository{
+		db: kit.db,
+	}
+
+	ctx := context.TODO()
+
+	t.Run("ok", func(t *testing.T) {
+		mock.ExpectExec("DELETE FROM cake_variants WHERE id = \\?").
+			WithArgs(5).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		err := repo.Delete(ctx, &model.Variant{Id: 5})
+		require.NoError(t, err)
+	})
+}
+
+func TestVariantRepository_FindById(t *testing.T) {
+	kit, closer := initializeRepoTestKit(t)
+	defer closer()
+	mock := kit.dbmock
+
+	repo := variantRepository{
+		db: kit.db,
+	}
+
+	ctx := context.TODO()
+
+	t.Run("ok", func(t *testing.T) {
+		mock.ExpectQuery("SELECT (.+) FROM cake_variants WHERE id = \\?").
+			WithArgs(5).
+			WillReturnRows(sqlmock.NewRows(variantRowColumns).
+				AddRow(5, 1, "20cm", 8, 250000, "IDR", "CHOCO-20", true, time.Now(), time.Now()))
+
+		res, err := repo.FindById(ctx, 5)
+		require.NoError(t, err)
+		assert.Equal(t, model.NewMoney(250000, "IDR"), res.Price)
+		assert.True(t, res.Active)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mock.ExpectQuery("SELECT (.+) FROM cake_variants WHERE id = \\?").
+			WithArgs(6).
+			WillReturnRows(sqlmock.NewRows(variantRowColumns))
+
+		res, err := repo.FindById(ctx, 6)
+		require.NoError(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestVariantRepository_FindByCakeId(t *testing.T) {
+	kit, closer := initializeRepoTestKit(t)
+	defer closer()
+	mock := kit.dbmock
+
+	repo := variantRepository{
+		db: kit.db,
+	}
+
+	ctx := context.TODO()
+
+	t.Run("ok", func(t *testing.T) {
+		mock.ExpectQuery("SELECT (.+) FROM cake_variants WHERE cake_id = \\? ORDER BY servings ASC, id ASC").
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows(variantRowColumns).
+				AddRow(5, 1, "20cm", 8, 250000, "IDR", "CHOCO-20", true, time.Now(), time.Now()).
+				AddRow(6, 1, "24cm", 12, 320000, "IDR", "CHOCO-24", false, time.Now(), time.Now()))
+
+		res, err := repo.FindByCakeId(ctx, 1)
+		require.NoError(t, err)
+		assert.Equal(t, 2, len(res))
+	})
+
+	t.Run("failed to find variants", func(t *testing.T) {
+		mock.ExpectQuery("SELECT (.+) FROM cake_variants").
+			WithArgs(1).
+			WillReturnError(errors.New("db error"))
+
+		_, err := repo.FindByCakeId(ctx, 1)
+		require.Error(t, err)
+	})
+}
+
+func TestVariantRepository_LoadCakes(t *testing.T) {
+	kit, closer := initializeRepoTestKit(t)
+	defer closer()
+	mock := kit.dbmock
+
+	repo := variantRepository{
+		db: kit.db,
+	}
+
+	ctx := context.TODO()
+
+	t.Run("ok", func(t *testing.T) {
+		cakes := []*model.Cake{{Id: 1}, {Id: 2}}
+		mock.ExpectQuery("SELECT (.+) FROM cake_variants WHERE cake_id IN \\(\\?,\\?\\)").
+			WithArgs(1, 2).
+			WillReturnRows(sqlmock.NewRows(variantRowColumns).
+				AddRow(5, 1, "20cm", 8, 250000, "IDR", "CHOCO-20", true, time.Now(), time.Now()).
+				AddRow(7, 2, "16cm", 4, 150000, "IDR", "VANILLA-16", true, time.Now(), time.Now()).
+				AddRow(6, 1, "24cm", 12, 320000, "IDR", "CHOCO-24", true, time.Now(), time.Now()))
+
+		err := repo.LoadCakes(ctx, cakes)
+		require.NoError(t, err)
+		assert.Equal(t, 2, len(cakes[0].Variants))
+		assert.Equal(t, 1, len(cakes[1].Variants))
+	})
+
+	t.Run("ok - no cake", func(t *testing.T) {
+		err := repo.LoadCakes(ctx, nil)
+		require.NoError(t, err)
+	})
+}
@@ -1,28 +1,163 @@
 package router
 
 import (
+	"cake-store/src/auth"
 	"cake-store/src/model"
 
 	"github.com/labstack/echo/v4"
 )
 
+// Controllers is the controllers the routes are served by
+type Controllers struct {
+	CakeController       model.CakeController
+	CategoryController   model.TaxonomyController
+	TagController        model.TaxonomyController
+	VariantController    model.VariantController
+	StockController      model.StockController
+	OrderController      model.OrderController
+	CartController       model.CartController
+	CouponController     model.CouponController
+	ReviewController     model.ReviewController
+	IngredientController model.IngredientController
+	RecipeController     model.RecipeController
+	InventoryController  model.InventoryController
+	ProductionController model.ProductionController
+	OptionController     model.OptionController
+	SlotController       model.SlotController
+	StoreController      model.StoreController
+	PaymentController    model.PaymentController
+}
+
 type route struct {
-	group          *echo.Group
-	cakeController model.CakeController
+	group *echo.Group
+	Controllers
 }
 
-func RouteService(group *echo.Group, cakeController model.CakeController) {
+func RouteService(group *echo.Group, controllers Controllers) {
 	rt := &route{
-		group:          group,
-		cakeController: cakeController,
+		group:       group,
+		Controllers: controllers,
 	}
 	rt.routerInit()
 }
 
 func (r *route) routerInit() {
-	r.group.GET("/cakes", r.cakeController.HandleFindAll())
-	r.group.POST("/cakes", r.cakeController.HandleCreate())
-	r.group.GET("/cakes/:id", r.cakeController.HandleFindById())
-	r.group.PUT("/cakes/:id", r.cakeController.HandleUpdate())
-	r.group.DELETE("/cakes/:id", r.cakeController.HandleDelete())
+	r.group.GET("/cakes", r.CakeController.HandleFindAll())
+	r.group.POST("/cakes", r.CakeController.HandleCreate())
+	r.group.GET("/cakes/trash", r.CakeController.HandleFindTrash(), auth.RequireAdmin)
+	r.group.GET("/cakes/:id", r.CakeController.HandleFindById())
+	r.group.PUT("/cakes/:id", r.CakeController.HandleUpdate())
+	r.group.PATCH("/cakes/:id", r.CakeController.HandlePatch())
+	r.group.DELETE("/cakes/:id", r.CakeController.HandleDelete())
+	r.group.POST("/cakes/:id/restore", r.CakeController.HandleRestore(), auth.RequireAdmin)
+	r.group.PUT("/cakes/:id/categories", r.CategoryController.HandleSetCakeTerms(), auth.RequireAdmin)
+	r.group.PUT("/cakes/:id/tags", r.TagController.HandleSetCakeTerms(), auth.RequireAdmin)
+	r.group.PUT("/cakes/:id/ingredients", r.IngredientController.HandleSetCakeIngredients(), auth.RequireAdmin)
+
+	r.group.GET("/cakes/:id/recipe", r.RecipeController.HandleFindByCakeId(), auth.RequireAdmin)
+	r.group.PUT("/cakes/:id/recipe", r.RecipeController.HandleSave(), auth.RequireAdmin)
+	r.group.DELETE("/cakes/:id/recipe", r.RecipeController.HandleDelete(), auth.RequireAdmin)
+	r.group.GET("/cakes/:id/production", r.ProductionController.HandleFindCakeProduction(), auth.RequireAdmin)
+	r.group.PUT("/cakes/:id/production", r.ProductionController.HandleSetCakeProduction(), auth.RequireAdmin)
+
+	r.group.GET("/cakes/:id/options", r.OptionController.HandleFindByCakeId())
+	r.group.PUT("/cakes/:id/options", r.OptionController.HandleSave(), auth.RequireAdmin)
+	r.group.POST("/cakes/:id/options/quote", r.OptionController.HandleQuote())
+
+	r.group.GET("/cakes/:id/variants", r.VariantController.HandleFindAll())
+	r.group.POST("/cakes/:id/variants", r.VariantController.HandleCreate(), auth.RequireAdmin)
+	r.group.GET("/cakes/:id/variants/:variantId", r.VariantController.HandleFindById())
+	r.group.PUT("/cakes/:id/variants/:variantId", r.VariantController.HandleUpdate(), auth.RequireAdmin)
+	r.group.DELETE("/cakes/:id/variants/:variantId", r.VariantController.HandleDelete(), auth.RequireAdmin)
+
+	r.group.GET("/cakes/:id/reviews", r.ReviewController.HandleFindAll())
+	r.group.POST("/cakes/:id/reviews", r.ReviewController.HandleCreate())
+	r.group.GET("/cakes/:id/reviews/:reviewId", r.ReviewController.HandleFindById())
+	r.group.PUT("/cakes/:id/reviews/:reviewId", r.ReviewController.HandleUpdate(), auth.RequireAdmin)
+	r.group.DELETE("/cakes/:id/reviews/:reviewId", r.ReviewController.HandleDelete(), auth.RequireAdmin)
+
+	r.group.GET("/cakes/:id/stock", r.StockController.HandleFindByCakeId())
+	r.group.PUT("/cakes/:id/stock/threshold", r.StockController.HandleSetThreshold(), auth.RequireAdmin)
+	r.group.GET("/cakes/:id/stock/adjustments", r.StockController.HandleFindAdjustments(), auth.RequireAdmin)
+	r.group.POST("/cakes/:id/stock/adjustments", r.StockController.HandleAdjust(), auth.RequireAdmin)
+	r.group.POST("/cakes/:id/stock/reservations", r.StockController.HandleReserve())
+	r.group.GET("/production/plan", r.ProductionController.HandlePlan(), auth.RequireAdmin)
+
+	r.group.GET("/stock/low", r.StockController.HandleFindLow(), auth.RequireAdmin)
+	r.group.DELETE("/stock/reservations/:reservationId", r.StockController.HandleRelease())
+	r.group.POST("/stock/reservations/:reservationId/commit", r.StockController.HandleCommit(), auth.RequireAdmin)
+
+	r.group.GET("/reviews", r.ReviewController.HandleFindModeration(), auth.RequireAdmin)
+	r.group.POST("/reviews/:reviewId/status", r.ReviewController.HandleModerate(), auth.RequireAdmin)
+
+	r.group.GET("/orders", r.OrderController.HandleFindAll(), auth.RequireAdmin)
+	r.group.POST("/orders", r.OrderController.HandleCreate())
+	r.group.GET("/orders/:id", r.OrderController.HandleFindById(), auth.RequireAdmin)
+	r.group.POST("/orders/:id/status", r.OrderController.HandleTransition(), auth.RequireAdmin)
+	r.group.GET("/orders/:id/payments", r.PaymentController.HandleFindByOrderId(), auth.RequireAdmin)
+	r.group.POST("/orders/:id/payments", r.PaymentController.HandleAuthorize())
+	r.group.POST("/payments/webhook", r.PaymentController.HandleWebhook())
+	r.group.GET("/payments/:id", r.PaymentController.HandleFindById(), auth.RequireAdmin)
+	r.group.POST("/payments/:id/capture", r.PaymentController.HandleCapture(), auth.RequireAdmin)
+	r.group.POST("/payments/:id/refund", r.PaymentController.HandleRefund(), auth.RequireAdmin)
+
+	r.group.POST("/carts", r.CartController.HandleCreate())
+	r.group.GET("/slots", r.SlotController.HandleAvailability())
+
+	r.group.GET("/stores", r.StoreController.HandleFindAll())
+	r.group.POST("/stores", r.StoreController.HandleCreate(), auth.RequireAdmin)
+	r.group.GET("/stores/nearest", r.StoreController.HandleFindNearest())
+	r.group.GET("/stores/:id", r.StoreController.HandleFindById())
+	r.group.PUT("/stores/:id", r.StoreController.HandleUpdate(), auth.RequireAdmin)
+	r.group.GET("/stores/:id/cakes/:cakeId", r.StoreController.HandleFindCake())
+	r.group.PUT("/stores/:id/cakes/:cakeId", r.StoreController.HandleSetCake(), auth.RequireAdmin)
+	r.group.GET("/stores/:id/hours", r.SlotController.HandleFindOpeningHours())
+	r.group.PUT("/stores/:id/hours", r.SlotController.HandleSetOpeningHours(), auth.RequireAdmin)
+	r.group.GET("/stores/:id/closures", r.SlotController.HandleFindClosures())
+	r.group.POST("/stores/:id/closures", r.SlotController.HandleCreateClosure(), auth.RequireAdmin)
+	r.group.DELETE("/stores/:id/closures/:date", r.SlotController.HandleDeleteClosure(), auth.RequireAdmin)
+	r.group.POST("/delivery/quote", r.StoreController.HandleQuoteDelivery())
+
+	r.group.GET("/carts/:cartId", r.CartController.HandleFindById())
+	r.group.DELETE("/carts/:cartId", r.CartController.HandleDelete())
+	r.group.POST("/carts/:cartId/lines", r.CartController.HandleAddLine())
+	r.group.PUT("/carts/:cartId/lines/:lineId", r.CartController.HandleUpdateLine())
+	r.group.DELETE("/carts/:cartId/lines/:lineId", r.CartController.HandleRemoveLine())
+	r.group.PUT("/carts/:cartId/coupons", r.CartController.HandleSetCoupons())
+	r.group.PUT("/carts/:cartId/store", r.CartController.HandleSetStore())
+	r.group.POST("/carts/:cartId/checkout", r.CartController.HandleCheckout())
+
+	r.group.GET("/coupons", r.CouponController.HandleFindAll(), auth.RequireAdmin)
+	r.group.POST("/coupons", r.CouponController.HandleCreate(), auth.RequireAdmin)
+	r.group.POST("/coupons/validate", r.CouponController.HandleValidate())
+	r.group.GET("/coupons/:id", r.CouponController.HandleFindById(), auth.RequireAdmin)
+	r.group.PUT("/coupons/:id", r.CouponController.HandleUpdate(), auth.RequireAdmin)
+	r.group.DELETE("/coupons/:id", r.CouponController.HandleDelete(), auth.RequireAdmin)
+
+	r.group.GET("/categories", r.CategoryController.HandleFindAll())
+	r.group.POST("/categories", r.CategoryController.HandleCreate(), auth.RequireAdmin)
+	r.group.GET("/categories/:id", r.CategoryController.HandleFindById())
+	r.group.PUT("/categories/:id", r.CategoryController.HandleUpdate(), auth.RequireAdmin)
+	r.group.DELETE("/categories/:id", r.CategoryController.HandleDelete(), auth.RequireAdmin)
+
+	r.group.GET("/tags", r.TagController.HandleFindAll())
+	r.group.POST("/tags", r.TagController.HandleCreate(), auth.RequireAdmin)
+	r.group.GET("/tags/:id", r.TagController.HandleFindById())
+	r.group.PUT("/tags/:id", r.TagController.HandleUpdate(), auth.RequireAdmin)
+	r.group.DELETE("/tags/:id", r.TagController.HandleDelete(), auth.RequireAdmin)
+
+	r.group.GET("/ingredients", r.IngredientController.HandleFindAll())
+	r.group.POST("/ingredients", r.IngredientController.HandleCreate(), auth.RequireAdmin)
+	r.group.GET("/ingredients/:id", r.IngredientController.HandleFindById())
+	r.group.PUT("/ingredients/:id", r.IngredientController.HandleUpdate(), auth.RequireAdmin)
+	r.group.DELETE("/ingredients/:id", r.IngredientController.HandleDelete(), auth.RequireAdmin)
+	r.group.GET("/ingredients/:id/prices", r.IngredientController.HandleFindPrices(), auth.RequireAdmin)
+	r.group.PUT("/ingredients/:id/prices", r.IngredientController.HandleSetPrices(), auth.RequireAdmin)
+
+	r.group.GET("/ingredients/stock", r.InventoryController.HandleFindAll(), auth.RequireAdmin)
+	r.group.GET("/ingredients/stock/low", r.InventoryController.HandleFindLow(), auth.RequireAdmin)
+	r.group.GET("/ingredients/stock/reorder", r.InventoryController.HandleReorderReport(), auth.RequireAdmin)
+	r.group.PUT("/ingredients/:id/stock", r.InventoryController.HandleSetLevels(), auth.RequireAdmin)
+	r.group.GET("/ingredients/:id/stock/adjustments", r.InventoryController.HandleFindAdjustments(), auth.RequireAdmin)
+	r.group.POST("/ingredients/:id/stock/adjustments", r.InventoryController.HandleAdjust(), auth.RequireAdmin)
 }
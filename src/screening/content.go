@@ -0,0 +1,58 @@
+package screening
+
+import (
+	"cake-store/src/model"
+	"context"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+type bannedWords struct {
+	phrases []string
+}
+
+// NewBannedWords reject the reviews whose text or author contain one of the words, a word may also be a phrase
+func NewBannedWords(words []string) model.ReviewScreener {
+	screener := &bannedWords{}
+	for _, word := range words {
+		if phrase := normalize(word); phrase != " " {
+			screener.phrases = append(screener.phrases, phrase)
+		}
+	}
+	return screener
+}
+
+func (b *bannedWords) Screen(ctx context.Context, review *model.Review) (*model.ScreeningVerdict, error) {
+	text := normalize(review.Author + " " + review.Text)
+	for _, phrase := range b.phrases {
+		if strings.Contains(text, phrase) {
+			return &model.ScreeningVerdict{Status: model.ReviewStatusRejected, Reason: model.ScreeningReasonBannedWord}, nil
+		}
+	}
+	return nil, nil
+}
+
+// normalize lower the words of the text and pad them with spaces, so a phrase only match whole words
+func normalize(text string) string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	return " " + strings.Join(words, " ") + " "
+}
+
+var linkPattern = regexp.MustCompile(`(?i)\b(?:https?://|www\.)\S+|\b[a-z0-9-]+(?:\.[a-z0-9-]+)*\.(?:com|net|org|id|io|co|me|info|biz|xyz|ly)\b`)
+
+type links struct{}
+
+// NewLinks flag the reviews containing a link for a moderator, links are often spam but not always
+func NewLinks() model.ReviewScreener {
+	return &links{}
+}
+
+func (l *links) Screen(ctx context.Context, review *model.Review) (*model.ScreeningVerdict, error) {
+	if linkPattern.MatchString(review.Text) || linkPattern.MatchString(review.Author) {
+		return &model.ScreeningVerdict{Status: model.ReviewStatusFlagged, Reason: model.ScreeningReasonLink}, nil
+	}
+	return nil, nil
+}
@@ -0,0 +1,67 @@
+package screening
+
+import (
+	"cake-store/src/model"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBannedWords_Screen(t *testing.T) {
+	ctx := context.TODO()
+	screener := NewBannedWords([]string{"Jelek", "tidak layak", " "})
+
+	cases := []struct {
+		name   string
+		review model.Review
+		reason string
+	}{
+		{"clean", model.Review{Author: "Sari", Text: "Enak sekali"}, ""},
+		{"word", model.Review{Author: "Sari", Text: "Kuenya JELEK!"}, model.ScreeningReasonBannedWord},
+		{"phrase", model.Review{Author: "Sari", Text: "Rasanya tidak   layak dijual"}, model.ScreeningReasonBannedWord},
+		{"author", model.Review{Author: "si jelek", Text: "Enak"}, model.ScreeningReasonBannedWord},
+		{"part of a word", model.Review{Author: "Sari", Text: "Jelekan"}, ""},
+	}
+
+	for _, c := range cases {
+		verdict, err := screener.Screen(ctx, &c.review)
+		require.NoError(t, err, c.name)
+		if c.reason == "" {
+			assert.Nil(t, verdict, c.name)
+			continue
+		}
+		require.NotNil(t, verdict, c.name)
+		assert.Equal(t, model.ReviewStatusRejected, verdict.Status, c.name)
+		assert.Equal(t, c.reason, verdict.Reason, c.name)
+	}
+}
+
+func TestLinks_Screen(t *testing.T) {
+	ctx := context.TODO()
+	screener := NewLinks()
+
+	cases := []struct {
+		name    string
+		text    string
+		flagged bool
+	}{
+		{"clean", "Harganya Rp.250.000 dan enak", false},
+		{"url", "cek https://promo.example/kue", true},
+		{"www", "kunjungi www.kuemurah", true},
+		{"bare domain", "beli di kuemurah.co.id saja", true},
+		{"sentence end", "Enak.Lembut", false},
+	}
+
+	for _, c := range cases {
+		verdict, err := screener.Screen(ctx, &model.Review{Author: "Sari", Text: c.text})
+		require.NoError(t, err, c.name)
+		if !c.flagged {
+			assert.Nil(t, verdict, c.name)
+			continue
+		}
+		require.NotNil(t, verdict, c.name)
+		assert.Equal(t, model.ReviewStatusFlagged, verdict.Status, c.name)
+	}
+}
@@ -0,0 +1,66 @@
+package screening
+
+import (
+	"cake-store/src/model"
+	"context"
+	"time"
+)
+
+type duplicates struct {
+	reviewRepository model.ReviewRepository
+}
+
+// NewDuplicates reject a review repeating the text of another review of the same cake
+func NewDuplicates(reviewRepository model.ReviewRepository) model.ReviewScreener {
+	return &duplicates{
+		reviewRepository: reviewRepository,
+	}
+}
+
+func (d *duplicates) Screen(ctx context.Context, review *model.Review) (*model.ScreeningVerdict, error) {
+	if review.Fingerprint == "" {
+		return nil, nil
+	}
+
+	exists, err := d.reviewRepository.ExistsFingerprint(ctx, review.CakeId, review.Fingerprint)
+	if err != nil {
+		return nil, err
+	}
+
+	if exists {
+		return &model.ScreeningVerdict{Status: model.ReviewStatusRejected, Reason: model.ScreeningReasonDuplicate}, nil
+	}
+	return nil, nil
+}
+
+type authorRate struct {
+	reviewRepository model.ReviewRepository
+	limit            int
+	window           time.Duration
+}
+
+// NewAuthorRate reject the reviews of an author who already wrote limit reviews in the window, a zero limit
+// let every review through
+func NewAuthorRate(reviewRepository model.ReviewRepository, limit int, window time.Duration) model.ReviewScreener {
+	return &authorRate{
+		reviewRepository: reviewRepository,
+		limit:            limit,
+		window:           window,
+	}
+}
+
+func (a *authorRate) Screen(ctx context.Context, review *model.Review) (*model.ScreeningVerdict, error) {
+	if a.limit <= 0 {
+		return nil, nil
+	}
+
+	count, err := a.reviewRepository.CountByAuthorSince(ctx, review.Author, review.CreatedAt.Add(-a.window))
+	if err != nil {
+		return nil, err
+	}
+
+	if count >= int64(a.limit) {
+		return &model.ScreeningVerdict{Status: model.ReviewStatusRejected, Reason: model.ScreeningReasonAuthorRate}, nil
+	}
+	return nil, nil
+}
@@ -0,0 +1,81 @@
+package screening
+
+import (
+	"cake-store/src/model"
+	"cake-store/src/model/mock"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDuplicates_Screen(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.TODO()
+	mockReviewRepo := mock.NewMockReviewRepository(ctrl)
+	screener := NewDuplicates(mockReviewRepo)
+
+	t.Run("duplicate", func(t *testing.T) {
+		mockReviewRepo.EXPECT().ExistsFingerprint(gomock.Any(), 1, "abc").Times(1).Return(true, nil)
+
+		verdict, err := screener.Screen(ctx, &model.Review{CakeId: 1, Fingerprint: "abc"})
+		require.NoError(t, err)
+		assert.Equal(t, &model.ScreeningVerdict{Status: model.ReviewStatusRejected, Reason: model.ScreeningReasonDuplicate}, verdict)
+	})
+
+	t.Run("empty text", func(t *testing.T) {
+		mockReviewRepo.EXPECT().ExistsFingerprint(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		verdict, err := screener.Screen(ctx, &model.Review{CakeId: 1})
+		require.NoError(t, err)
+		assert.Nil(t, verdict)
+	})
+
+	t.Run("error from repo", func(t *testing.T) {
+		mockReviewRepo.EXPECT().ExistsFingerprint(gomock.Any(), 1, "abc").Times(1).Return(false, errors.New("err db"))
+
+		verdict, err := screener.Screen(ctx, &model.Review{CakeId: 1, Fingerprint: "abc"})
+		assert.Error(t, err)
+		assert.Nil(t, verdict)
+	})
+}
+
+func TestAuthorRate_Screen(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.TODO()
+	mockReviewRepo := mock.NewMockReviewRepository(ctrl)
+	now := time.Now()
+	review := &model.Review{Author: "Sari", CreatedAt: now}
+
+	t.Run("under the limit", func(t *testing.T) {
+		mockReviewRepo.EXPECT().CountByAuthorSince(gomock.Any(), "Sari", now.Add(-time.Hour)).Times(1).Return(int64(2), nil)
+
+		verdict, err := NewAuthorRate(mockReviewRepo, 3, time.Hour).Screen(ctx, review)
+		require.NoError(t, err)
+		assert.Nil(t, verdict)
+	})
+
+	t.Run("limit reached", func(t *testing.T) {
+		mockReviewRepo.EXPECT().CountByAuthorSince(gomock.Any(), "Sari", now.Add(-time.Hour)).Times(1).Return(int64(3), nil)
+
+		verdict, err := NewAuthorRate(mockReviewRepo, 3, time.Hour).Screen(ctx, review)
+		require.NoError(t, err)
+		assert.Equal(t, model.ScreeningReasonAuthorRate, verdict.Reason)
+	})
+
+	t.Run("no limit", func(t *testing.T) {
+		mockReviewRepo.EXPECT().CountByAuthorSince(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		verdict, err := NewAuthorRate(mockReviewRepo, 0, time.Hour).Screen(ctx, review)
+		require.NoError(t, err)
+		assert.Nil(t, verdict)
+	})
+}
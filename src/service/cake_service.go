@@ -1,21 +1,33 @@
 package service
 
 import (
+	"bytes"
+	"cake-store/src/config"
 	"cake-store/src/constant"
+	"cake-store/src/helper"
 	"cake-store/src/model"
 	"context"
+	"encoding/json"
+	"math/big"
 	"time"
 
 	"github.com/sirupsen/logrus"
 )
 
 type cakeService struct {
-	cakeRepository model.CakeRepository
+	cakeRepository  model.CakeRepository
+	storeRepository model.StoreRepository
+	exchangeRate    model.ExchangeRateProvider
+	loaders         []model.CakeRelationLoader
 }
 
-func NewCakeService(cakeRepository model.CakeRepository) model.CakeService {
+func NewCakeService(cakeRepository model.CakeRepository, storeRepository model.StoreRepository, exchangeRate model.ExchangeRateProvider,
+	loaders ...model.CakeRelationLoader) model.CakeService {
 	return &cakeService{
-		cakeRepository: cakeRepository,
+		cakeRepository:  cakeRepository,
+		storeRepository: storeRepository,
+		exchangeRate:    exchangeRate,
+		loaders:         loaders,
 	}
 }
 
@@ -30,11 +42,14 @@ func (c *cakeService) Create(ctx context.Context, req model.CreateUpdateRequest)
 		return nil, constant.HttpValidationOrInternalErr(err)
 	}
 
+	// the rating of a cake without reviews
+	rating := model.NewCakeRating(0, 0, config.RatingPriorWeight(), config.RatingPriorMean())
 	cake := &model.Cake{
 		Title:       req.Title,
 		Description: req.Description,
-		Rating:      req.Rating,
+		Rating:      rating.Rating,
 		Image:       req.Image,
+		Version:     1,
 		CreatedAt:   time.Now(),
 		UpdatedAt:   time.Now(),
 	}
@@ -70,16 +85,191 @@ func (c *cakeService) FindById(ctx context.Context, cakeId int) (*model.Cake, er
 		return nil, constant.ErrNotFound
 	}
 
+	if err := c.loadRelations(ctx, cake); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
 	return cake, nil
 }
 
-func (c *cakeService) Update(ctx context.Context, req model.CreateUpdateRequest, cakeId int) (*model.Cake, error) {
+// FindVersion find the cake without its relations, enough to validate a conditional request from the redis cache.
+// A relation or a store setting change bump the cake version, so the cake has the same validators in every view
+func (c *cakeService) FindVersion(ctx context.Context, cakeId int) (*model.Cake, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Find Version Cake Service",
+		"cakeId":  cakeId,
+	})
+
+	if cakeId == 0 {
+		log.Error(constant.ErrInvalidArgument)
+		return nil, constant.ErrInvalidArgument
+	}
+
+	cake, err := c.cakeRepository.FindById(ctx, cakeId)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	if cake == nil {
+		log.Error(constant.ErrNotFound)
+		return nil, constant.ErrNotFound
+	}
+
+	return cake, nil
+}
+
+// FindByIdInStore find the cake as sold in the store, with its availability there and the store prices of its variants
+func (c *cakeService) FindByIdInStore(ctx context.Context, cakeId int, storeId int) (*model.Cake, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Find By ID In Store Cake Service",
+		"cakeId":  cakeId,
+		"storeId": storeId,
+	})
+
+	if cakeId == 0 || storeId == 0 {
+		log.Error(constant.ErrInvalidArgument)
+		return nil, constant.ErrInvalidArgument
+	}
+
+	if err := c.findStore(ctx, storeId); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	cake, err := c.cakeRepository.FindByIdInStore(ctx, cakeId, storeId)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	if cake == nil {
+		log.Error(constant.ErrNotFound)
+		return nil, constant.ErrNotFound
+	}
+
+	if err := c.loadRelations(ctx, cake); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	if err := c.applyStorePrices(ctx, storeId, cake); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return cake, nil
+}
+
+func (c *cakeService) findStore(ctx context.Context, storeId int) error {
+	store, err := c.storeRepository.FindById(ctx, storeId)
+	if err != nil {
+		return err
+	}
+
+	if store == nil {
+		return constant.ErrNotFound
+	}
+
+	return nil
+}
+
+// applyStorePrices replace the variant prices of the cakes by the prices of the store, a store without a price for a
+// variant sell it at the variant price
+func (c *cakeService) applyStorePrices(ctx context.Context, storeId int, cakes ...*model.Cake) error {
+	variantIds := make([]int, 0)
+	for _, cake := range cakes {
+		for _, variant := range cake.Variants {
+			variantIds = append(variantIds, variant.Id)
+		}
+	}
+
+	if len(variantIds) == 0 {
+		return nil
+	}
+
+	prices, err := c.storeRepository.FindPrices(ctx, storeId, variantIds)
+	if err != nil {
+		return err
+	}
+
+	for _, cake := range cakes {
+		for _, variant := range cake.Variants {
+			if price, ok := prices[variant.Id]; ok {
+				variant.Price = price
+			}
+		}
+	}
+	return nil
+}
+
+// loadRelations embed the categories, tags and other relations of the cakes
+func (c *cakeService) loadRelations(ctx context.Context, cakes ...*model.Cake) error {
+	for _, loader := range c.loaders {
+		if err := loader.LoadCakes(ctx, cakes); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// convertPrices convert the variant prices of the cakes to the currency, an empty currency keep the stored prices
+func (c *cakeService) convertPrices(ctx context.Context, currency string, cakes ...*model.Cake) error {
+	if currency == "" {
+		return nil
+	}
+	if c.exchangeRate == nil {
+		return constant.ErrUnsupportedCurrency
+	}
+
+	rates := map[string]*big.Rat{}
+	for _, cake := range cakes {
+		for _, variant := range cake.Variants {
+			rate, ok := rates[variant.Price.Currency]
+			if !ok {
+				var err error
+				if rate, err = c.exchangeRate.Rate(ctx, variant.Price.Currency, currency); err != nil {
+					return err
+				}
+				rates[variant.Price.Currency] = rate
+			}
+			variant.Price = variant.Price.Convert(currency, rate)
+		}
+	}
+	return nil
+}
+
+// findByVersion find the cake and check it match one of the expected versions, no expected version match any
+func (c *cakeService) findByVersion(ctx context.Context, cakeId int, ifMatch model.IfMatch) (*model.Cake, error) {
+	cake, err := c.FindById(ctx, cakeId)
+	if err != nil {
+		return nil, err
+	}
+
+	if !ifMatch.Matches(cake.Version) {
+		return nil, constant.ErrPrecondition
+	}
+
+	return cake, nil
+}
+
+// versionErr report a version conflict as failed precondition when the client sent the expected versions
+func versionErr(err error, ifMatch model.IfMatch) error {
+	if err == constant.ErrVersionConflict && len(ifMatch) > 0 {
+		return constant.ErrPrecondition
+	}
+	return err
+}
+
+func (c *cakeService) Update(ctx context.Context, req model.CreateUpdateRequest, cakeId int, ifMatch model.IfMatch) (*model.Cake, error) {
 	log := logrus.WithFields(logrus.Fields{
 		"message": "Update Cake Service",
 		"req":     req,
+		"ifMatch": ifMatch,
 	})
 
-	cake, err := c.FindById(ctx, cakeId)
+	cake, err := c.findByVersion(ctx, cakeId, ifMatch)
 	if err != nil {
 		log.Error(err)
 		return nil, err
@@ -92,39 +282,260 @@ func (c *cakeService) Update(ctx context.Context, req model.CreateUpdateRequest,
 
 	cake.Title = req.Title
 	cake.Description = req.Description
-	cake.Rating = req.Rating
 	cake.Image = req.Image
 	cake.UpdatedAt = time.Now()
 
 	if err = c.cakeRepository.Update(ctx, cake); err != nil {
 		log.Error(err)
-		return nil, err
+		return nil, versionErr(err, ifMatch)
 	}
 
 	return cake, err
 }
 
-func (c *cakeService) FindAll(ctx context.Context) ([]*model.Cake, error) {
+func (c *cakeService) Patch(ctx context.Context, req model.PatchRequest, cakeId int, ifMatch model.IfMatch) (*model.Cake, error) {
 	log := logrus.WithFields(logrus.Fields{
-		"message": "Find All Cake Service",
+		"message": "Patch Cake Service",
+		"type":    req.Type,
+		"patch":   string(req.Patch),
+		"cakeId":  cakeId,
+		"ifMatch": ifMatch,
 	})
 
-	cakes, err := c.cakeRepository.FindAll(ctx)
+	cake, err := c.findByVersion(ctx, cakeId, ifMatch)
 	if err != nil {
 		log.Error(err)
 		return nil, err
 	}
 
-	return cakes, nil
+	current := model.CreateUpdateRequest{
+		Title:       cake.Title,
+		Description: cake.Description,
+		Image:       cake.Image,
+	}
+	doc, err := json.Marshal(current)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	var patched []byte
+	switch req.Type {
+	case model.MergePatchType:
+		patched, err = helper.MergePatch(doc, req.Patch)
+	case model.JSONPatchType:
+		patched, err = helper.JSONPatch(doc, req.Patch)
+	default:
+		log.Error(constant.ErrUnsupportedType)
+		return nil, constant.ErrUnsupportedType
+	}
+
+	switch err {
+	case nil:
+	case helper.ErrPatchTestFailed:
+		log.Error(err)
+		return nil, constant.ErrPatchConflict
+	default:
+		log.Error(err)
+		return nil, constant.ErrInvalidPatch
+	}
+
+	update := model.CreateUpdateRequest{}
+	decoder := json.NewDecoder(bytes.NewReader(patched))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&update); err != nil {
+		log.Error(err)
+		return nil, constant.ErrInvalidPatch
+	}
+
+	changed := make([]string, 0)
+	if update.Title != current.Title {
+		changed = append(changed, "Title")
+	}
+	if update.Description != current.Description {
+		changed = append(changed, "Description")
+	}
+	if update.Image != current.Image {
+		changed = append(changed, "Image")
+	}
+	if update.Rating != nil {
+		changed = append(changed, "Rating")
+	}
+
+	if len(changed) == 0 {
+		return cake, nil
+	}
+
+	if err := update.ValidateFields(changed...); err != nil {
+		log.Error(err)
+		return nil, constant.HttpValidationOrInternalErr(err)
+	}
+
+	cake.Title = update.Title
+	cake.Description = update.Description
+	cake.Image = update.Image
+	cake.UpdatedAt = time.Now()
+
+	if err = c.cakeRepository.Update(ctx, cake); err != nil {
+		log.Error(err)
+		return nil, versionErr(err, ifMatch)
+	}
+
+	return cake, nil
 }
 
-func (c *cakeService) Delete(ctx context.Context, cakeId int) (*model.Cake, error) {
+func (c *cakeService) FindAll(ctx context.Context, query model.CakeQuery) ([]*model.Cake, *model.Pagination, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Find All Cake Service",
+		"query":   query,
+	})
+
+	if err := query.Validate(); err != nil {
+		log.Error(err)
+		return nil, nil, constant.HttpValidationOrInternalErr(err)
+	}
+
+	if query.MinRating > 0 && query.MaxRating > 0 && query.MinRating > query.MaxRating {
+		log.Error(constant.ErrInvalidArgument)
+		return nil, nil, constant.ErrInvalidArgument
+	}
+
+	if _, ok := query.ExcludedAllergens(); !ok {
+		log.Error(constant.ErrInvalidArgument)
+		return nil, nil, constant.ErrInvalidArgument
+	}
+
+	query.SetDefault()
+
+	if query.StoreId != 0 {
+		if err := c.findStore(ctx, query.StoreId); err != nil {
+			log.Error(err)
+			return nil, nil, err
+		}
+	}
+
+	if query.IsCursor() {
+		return c.findAllByCursor(ctx, query)
+	}
+
+	cakes, err := c.cakeRepository.FindAll(ctx, query)
+	if err != nil {
+		log.Error(err)
+		return nil, nil, err
+	}
+
+	total, err := c.cakeRepository.CountAll(ctx, query)
+	if err != nil {
+		log.Error(err)
+		return nil, nil, err
+	}
+
+	if err := c.loadRelations(ctx, cakes...); err != nil {
+		log.Error(err)
+		return nil, nil, err
+	}
+
+	if query.StoreId != 0 {
+		if err := c.applyStorePrices(ctx, query.StoreId, cakes...); err != nil {
+			log.Error(err)
+			return nil, nil, err
+		}
+	}
+
+	if err := c.convertPrices(ctx, query.Currency, cakes...); err != nil {
+		log.Error(err)
+		return nil, nil, err
+	}
+
+	return cakes, &model.Pagination{
+		Total: total,
+		Page:  query.Page,
+		Limit: query.Limit,
+	}, nil
+}
+
+// findAllByCursor list the cakes after the keyset of the query cursor, fetching one extra row to know whether a next page exist
+func (c *cakeService) findAllByCursor(ctx context.Context, query model.CakeQuery) ([]*model.Cake, *model.Pagination, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Find All By Cursor Cake Service",
+		"query":   query,
+	})
+
+	if query.SortBy != "" {
+		log.Error(constant.ErrInvalidArgument)
+		return nil, nil, constant.ErrInvalidArgument
+	}
+
+	if query.Cursor != "" {
+		query.After = &model.CakeCursor{}
+		if err := helper.DecodeCursor(query.Cursor, config.CursorSecret(), query.After); err != nil {
+			log.Error(err)
+			return nil, nil, constant.ErrInvalidCursor
+		}
+	}
+
+	limit := query.Limit
+	query.Limit = limit + 1
+	cakes, err := c.cakeRepository.FindAll(ctx, query)
+	if err != nil {
+		log.Error(err)
+		return nil, nil, err
+	}
+
+	query.Limit = limit
+	total, err := c.cakeRepository.CountAll(ctx, query)
+	if err != nil {
+		log.Error(err)
+		return nil, nil, err
+	}
+
+	pagination := &model.Pagination{
+		Total: total,
+		Limit: limit,
+	}
+
+	if len(cakes) > limit {
+		cakes = cakes[:limit]
+		last := cakes[limit-1]
+		pagination.NextCursor, err = helper.EncodeCursor(model.CakeCursor{
+			Rating: last.Rating,
+			Title:  last.Title,
+			Id:     last.Id,
+		}, config.CursorSecret())
+		if err != nil {
+			log.Error(err)
+			return nil, nil, err
+		}
+	}
+
+	if err := c.loadRelations(ctx, cakes...); err != nil {
+		log.Error(err)
+		return nil, nil, err
+	}
+
+	if query.StoreId != 0 {
+		if err := c.applyStorePrices(ctx, query.StoreId, cakes...); err != nil {
+			log.Error(err)
+			return nil, nil, err
+		}
+	}
+
+	if err := c.convertPrices(ctx, query.Currency, cakes...); err != nil {
+		log.Error(err)
+		return nil, nil, err
+	}
+
+	return cakes, pagination, nil
+}
+
+func (c *cakeService) Delete(ctx context.Context, cakeId int, ifMatch model.IfMatch) (*model.Cake, error) {
 	log := logrus.WithFields(logrus.Fields{
 		"message": "Delete Cake Service",
 		"cakeId":  cakeId,
+		"ifMatch": ifMatch,
 	})
 
-	cake, err := c.FindById(ctx, cakeId)
+	cake, err := c.findByVersion(ctx, cakeId, ifMatch)
 	if err != nil {
 		log.Error(err)
 		return nil, err
@@ -140,8 +551,132 @@ func (c *cakeService) Delete(ctx context.Context, cakeId int) (*model.Cake, erro
 
 	if err = c.cakeRepository.Delete(ctx, cake); err != nil {
 		log.Error(err)
-		return nil, err
+		return nil, versionErr(err, ifMatch)
 	}
 
 	return cake, err
 }
+
+func (c *cakeService) Restore(ctx context.Context, cakeId int, ifMatch model.IfMatch) (*model.Cake, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Restore Cake Service",
+		"cakeId":  cakeId,
+		"ifMatch": ifMatch,
+	})
+
+	cake, err := c.findUnscopedByVersion(ctx, cakeId, ifMatch)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	if cake.DeletedAt == nil {
+		log.Error(constant.ErrNotDeleted)
+		return nil, constant.ErrNotDeleted
+	}
+
+	cake.DeletedAt = nil
+	cake.UpdatedAt = time.Now()
+
+	if err = c.cakeRepository.Restore(ctx, cake); err != nil {
+		log.Error(err)
+		return nil, versionErr(err, ifMatch)
+	}
+
+	return cake, nil
+}
+
+func (c *cakeService) Purge(ctx context.Context, cakeId int, ifMatch model.IfMatch) (*model.Cake, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Purge Cake Service",
+		"cakeId":  cakeId,
+		"ifMatch": ifMatch,
+	})
+
+	cake, err := c.findUnscopedByVersion(ctx, cakeId, ifMatch)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	if err = c.cakeRepository.Purge(ctx, cake); err != nil {
+		log.Error(err)
+		return nil, versionErr(err, ifMatch)
+	}
+
+	return cake, nil
+}
+
+// PurgeExpired permanently delete the cakes soft deleted longer than the retention, batch by batch
+func (c *cakeService) PurgeExpired(ctx context.Context, opt model.PurgeOption) (*model.PurgeSummary, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Purge Expired Cake Service",
+		"opt":     opt,
+	})
+
+	if opt.Retention <= 0 || opt.BatchSize <= 0 {
+		log.Error(constant.ErrInvalidArgument)
+		return nil, constant.ErrInvalidArgument
+	}
+
+	summary := &model.PurgeSummary{
+		DeletedBefore: time.Now().Add(-opt.Retention),
+		DryRun:        opt.DryRun,
+	}
+
+	matched, err := c.cakeRepository.CountDeletedBefore(ctx, summary.DeletedBefore)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	summary.Matched = matched
+
+	if opt.DryRun {
+		return summary, nil
+	}
+
+	for opt.MaxBatches <= 0 || summary.Batches < opt.MaxBatches {
+		purged, err := c.cakeRepository.PurgeDeletedBefore(ctx, summary.DeletedBefore, opt.BatchSize)
+		if err != nil {
+			log.Error(err)
+			return summary, err
+		}
+
+		summary.Batches++
+		summary.Purged += purged
+		if purged < int64(opt.BatchSize) {
+			break
+		}
+
+		if opt.AfterBatch != nil {
+			if err = opt.AfterBatch(ctx); err != nil {
+				log.Error(err)
+				return summary, err
+			}
+		}
+	}
+
+	return summary, nil
+}
+
+// findUnscopedByVersion find the cake including the soft deleted one and check it match one of the expected versions
+func (c *cakeService) findUnscopedByVersion(ctx context.Context, cakeId int, ifMatch model.IfMatch) (*model.Cake, error) {
+	if cakeId == 0 {
+		return nil, constant.ErrInvalidArgument
+	}
+
+	cake, err := c.cakeRepository.FindByIdUnscoped(ctx, cakeId)
+	if err != nil {
+		return nil, err
+	}
+
+	if cake == nil {
+		return nil, constant.ErrNotFound
+	}
+
+	if !ifMatch.Matches(cake.Version) {
+		return nil, constant.ErrPrecondition
+	}
+
+	return cake, nil
+}
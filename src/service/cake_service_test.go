@@ -1,16 +1,20 @@
 package service
 
 import (
+	"cake-store/src/config"
 	"cake-store/src/constant"
+	"cake-store/src/helper"
 	"cake-store/src/model"
 	"cake-store/src/model/mock"
 	"context"
 	"errors"
+	"math/big"
 	"testing"
 	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestCakeService_Create(t *testing.T) {
@@ -38,7 +42,6 @@ func TestCakeService_Create(t *testing.T) {
 		cakeReq := model.CreateUpdateRequest{
 			Title:       cake.Title,
 			Description: cake.Description,
-			Rating:      cake.Rating,
 			Image:       cake.Image,
 		}
 
@@ -52,7 +55,6 @@ func TestCakeService_Create(t *testing.T) {
 		cakeReq := model.CreateUpdateRequest{
 			Title:       "a",
 			Description: cake.Description,
-			Rating:      cake.Rating,
 			Image:       cake.Image,
 		}
 		mockCakeRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Times(0).Return(nil)
@@ -61,11 +63,24 @@ func TestCakeService_Create(t *testing.T) {
 		assert.Nil(t, res)
 	})
 
+	t.Run("rating given", func(t *testing.T) {
+		rating := float32(5)
+		cakeReq := model.CreateUpdateRequest{
+			Title:       cake.Title,
+			Description: cake.Description,
+			Image:       cake.Image,
+			Rating:      &rating,
+		}
+		mockCakeRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Times(0).Return(nil)
+		res, err := cakeService.Create(ctx, cakeReq)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+
 	t.Run("error from repo", func(t *testing.T) {
 		cakeReq := model.CreateUpdateRequest{
 			Title:       cake.Title,
 			Description: cake.Description,
-			Rating:      cake.Rating,
 			Image:       cake.Image,
 		}
 		mockCakeRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Times(1).Return(errors.New("err db"))
@@ -101,28 +116,96 @@ func TestCakeService_Update(t *testing.T) {
 		cakeReq := model.CreateUpdateRequest{
 			Title:       cake.Title,
 			Description: cake.Description,
-			Rating:      cake.Rating,
 			Image:       cake.Image,
 		}
 
 		mockCakeRepo.EXPECT().FindById(gomock.Any(), cake.Id).Times(1).Return(cake, nil)
 		mockCakeRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Times(1).Return(nil)
 
-		res, err := cakeService.Update(ctx, cakeReq, cake.Id)
+		res, err := cakeService.Update(ctx, cakeReq, cake.Id, nil)
+		assert.NoError(t, err)
+		assert.NotNil(t, res)
+	})
+
+	t.Run("ok - match version", func(t *testing.T) {
+		cake := *cake
+		cake.Version = 3
+		cakeReq := model.CreateUpdateRequest{
+			Title:       cake.Title,
+			Description: cake.Description,
+			Image:       cake.Image,
+		}
+
+		mockCakeRepo.EXPECT().FindById(gomock.Any(), cake.Id).Times(1).Return(&cake, nil)
+		mockCakeRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+
+		res, err := cakeService.Update(ctx, cakeReq, cake.Id, model.IfMatch{3})
 		assert.NoError(t, err)
 		assert.NotNil(t, res)
 	})
 
+	t.Run("precondition failed", func(t *testing.T) {
+		cake := *cake
+		cake.Version = 3
+		cakeReq := model.CreateUpdateRequest{
+			Title:       cake.Title,
+			Description: cake.Description,
+			Image:       cake.Image,
+		}
+
+		mockCakeRepo.EXPECT().FindById(gomock.Any(), cake.Id).Times(1).Return(&cake, nil)
+		mockCakeRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Times(0)
+
+		res, err := cakeService.Update(ctx, cakeReq, cake.Id, model.IfMatch{2})
+		assert.Equal(t, constant.ErrPrecondition, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("concurrent update", func(t *testing.T) {
+		cake := *cake
+		cake.Version = 3
+		cakeReq := model.CreateUpdateRequest{
+			Title:       cake.Title,
+			Description: cake.Description,
+			Image:       cake.Image,
+		}
+
+		mockCakeRepo.EXPECT().FindById(gomock.Any(), cake.Id).Times(2).Return(&cake, nil)
+		mockCakeRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Times(2).Return(constant.ErrVersionConflict)
+
+		res, err := cakeService.Update(ctx, cakeReq, cake.Id, model.IfMatch{3})
+		assert.Equal(t, constant.ErrPrecondition, err)
+		assert.Nil(t, res)
+
+		res, err = cakeService.Update(ctx, cakeReq, cake.Id, nil)
+		assert.Equal(t, constant.ErrVersionConflict, err)
+		assert.Nil(t, res)
+	})
+
 	t.Run("validate error", func(t *testing.T) {
 		cakeReq := model.CreateUpdateRequest{
 			Title:       "a",
 			Description: cake.Description,
-			Rating:      cake.Rating,
 			Image:       cake.Image,
 		}
 		mockCakeRepo.EXPECT().FindById(gomock.Any(), cake.Id).Times(1).Return(cake, nil)
 		mockCakeRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Times(0).Return(nil)
-		res, err := cakeService.Update(ctx, cakeReq, cake.Id)
+		res, err := cakeService.Update(ctx, cakeReq, cake.Id, nil)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("rating given", func(t *testing.T) {
+		rating := float32(5)
+		cakeReq := model.CreateUpdateRequest{
+			Title:       cake.Title,
+			Description: cake.Description,
+			Image:       cake.Image,
+			Rating:      &rating,
+		}
+		mockCakeRepo.EXPECT().FindById(gomock.Any(), cake.Id).Times(1).Return(cake, nil)
+		mockCakeRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Times(0).Return(nil)
+		res, err := cakeService.Update(ctx, cakeReq, cake.Id, nil)
 		assert.Error(t, err)
 		assert.Nil(t, res)
 	})
@@ -131,12 +214,11 @@ func TestCakeService_Update(t *testing.T) {
 		cakeReq := model.CreateUpdateRequest{
 			Title:       cake.Title,
 			Description: cake.Description,
-			Rating:      cake.Rating,
 			Image:       cake.Image,
 		}
 		mockCakeRepo.EXPECT().FindById(gomock.Any(), cake.Id).Times(1).Return(nil, nil)
 		mockCakeRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Times(0).Return(nil)
-		res, err := cakeService.Update(ctx, cakeReq, cake.Id)
+		res, err := cakeService.Update(ctx, cakeReq, cake.Id, nil)
 		assert.Error(t, err)
 		assert.Nil(t, res)
 	})
@@ -145,12 +227,186 @@ func TestCakeService_Update(t *testing.T) {
 		cakeReq := model.CreateUpdateRequest{
 			Title:       cake.Title,
 			Description: cake.Description,
-			Rating:      cake.Rating,
 			Image:       cake.Image,
 		}
 		mockCakeRepo.EXPECT().FindById(gomock.Any(), cake.Id).Times(1).Return(cake, nil)
 		mockCakeRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Times(1).Return(errors.New("err db"))
-		res, err := cakeService.Update(ctx, cakeReq, cake.Id)
+		res, err := cakeService.Update(ctx, cakeReq, cake.Id, nil)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestCakeService_Patch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.TODO()
+	mockCakeRepo := mock.NewMockCakeRepository(ctrl)
+
+	cakeService := &cakeService{
+		cakeRepository: mockCakeRepo,
+	}
+	id := 1
+	newCake := func() *model.Cake {
+		return &model.Cake{
+			Id:          id,
+			Title:       "Kue Test",
+			Description: "Desc test",
+			Rating:      5.5,
+			Image:       "test image",
+			Version:     1,
+			CreatedAt:   time.Now(),
+			UpdatedAt:   time.Now(),
+			DeletedAt:   nil,
+		}
+	}
+
+	t.Run("ok - merge patch", func(t *testing.T) {
+		mockCakeRepo.EXPECT().FindById(gomock.Any(), id).Times(1).Return(newCake(), nil)
+		mockCakeRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+
+		res, err := cakeService.Patch(ctx, model.PatchRequest{
+			Type:  model.MergePatchType,
+			Patch: []byte(`{"image":"new image"}`),
+		}, id, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, "new image", res.Image)
+		assert.Equal(t, "Kue Test", res.Title)
+		assert.Equal(t, "Desc test", res.Description)
+		assert.Equal(t, float32(5.5), res.Rating)
+	})
+
+	t.Run("ok - json patch", func(t *testing.T) {
+		mockCakeRepo.EXPECT().FindById(gomock.Any(), id).Times(1).Return(newCake(), nil)
+		mockCakeRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+
+		res, err := cakeService.Patch(ctx, model.PatchRequest{
+			Type:  model.JSONPatchType,
+			Patch: []byte(`[{"op":"test","path":"/title","value":"Kue Test"},{"op":"replace","path":"/image","value":"new image"}]`),
+		}, id, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, "new image", res.Image)
+		assert.Equal(t, float32(5.5), res.Rating)
+	})
+
+	t.Run("ok - validate only changed field", func(t *testing.T) {
+		cake := newCake()
+		// stored description fail the min length rule, but is not touched by the patch
+		cake.Description = ""
+		mockCakeRepo.EXPECT().FindById(gomock.Any(), id).Times(1).Return(cake, nil)
+		mockCakeRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+
+		res, err := cakeService.Patch(ctx, model.PatchRequest{
+			Type:  model.MergePatchType,
+			Patch: []byte(`{"title":"Kue Baru"}`),
+		}, id, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, "Kue Baru", res.Title)
+	})
+
+	t.Run("ok - nothing changed", func(t *testing.T) {
+		mockCakeRepo.EXPECT().FindById(gomock.Any(), id).Times(1).Return(newCake(), nil)
+		mockCakeRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Times(0)
+
+		res, err := cakeService.Patch(ctx, model.PatchRequest{
+			Type:  model.MergePatchType,
+			Patch: []byte(`{"title":"Kue Test"}`),
+		}, id, nil)
+		assert.NoError(t, err)
+		assert.NotNil(t, res)
+	})
+
+	t.Run("validate error", func(t *testing.T) {
+		mockCakeRepo.EXPECT().FindById(gomock.Any(), id).Times(1).Return(newCake(), nil)
+		mockCakeRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Times(0)
+
+		res, err := cakeService.Patch(ctx, model.PatchRequest{
+			Type:  model.MergePatchType,
+			Patch: []byte(`{"title":null}`),
+		}, id, nil)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("precondition failed", func(t *testing.T) {
+		mockCakeRepo.EXPECT().FindById(gomock.Any(), id).Times(1).Return(newCake(), nil)
+		mockCakeRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Times(0)
+
+		res, err := cakeService.Patch(ctx, model.PatchRequest{
+			Type:  model.MergePatchType,
+			Patch: []byte(`{"image":"new image"}`),
+		}, id, model.IfMatch{7})
+		assert.Equal(t, constant.ErrPrecondition, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("unknown field", func(t *testing.T) {
+		mockCakeRepo.EXPECT().FindById(gomock.Any(), id).Times(1).Return(newCake(), nil)
+		mockCakeRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Times(0)
+
+		res, err := cakeService.Patch(ctx, model.PatchRequest{
+			Type:  model.MergePatchType,
+			Patch: []byte(`{"id":2}`),
+		}, id, nil)
+		assert.Equal(t, constant.ErrInvalidPatch, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("rating is computed", func(t *testing.T) {
+		mockCakeRepo.EXPECT().FindById(gomock.Any(), id).Times(1).Return(newCake(), nil)
+		mockCakeRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Times(0)
+
+		res, err := cakeService.Patch(ctx, model.PatchRequest{
+			Type:  model.MergePatchType,
+			Patch: []byte(`{"rating":8}`),
+		}, id, nil)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("test operation failed", func(t *testing.T) {
+		mockCakeRepo.EXPECT().FindById(gomock.Any(), id).Times(1).Return(newCake(), nil)
+		mockCakeRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Times(0)
+
+		res, err := cakeService.Patch(ctx, model.PatchRequest{
+			Type:  model.JSONPatchType,
+			Patch: []byte(`[{"op":"test","path":"/title","value":"Kue Lain"}]`),
+		}, id, nil)
+		assert.Equal(t, constant.ErrPatchConflict, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("unsupported type", func(t *testing.T) {
+		mockCakeRepo.EXPECT().FindById(gomock.Any(), id).Times(1).Return(newCake(), nil)
+
+		res, err := cakeService.Patch(ctx, model.PatchRequest{
+			Type:  "text/plain",
+			Patch: []byte(`image=1`),
+		}, id, nil)
+		assert.Equal(t, constant.ErrUnsupportedType, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("id not found", func(t *testing.T) {
+		mockCakeRepo.EXPECT().FindById(gomock.Any(), id).Times(1).Return(nil, nil)
+
+		res, err := cakeService.Patch(ctx, model.PatchRequest{
+			Type:  model.MergePatchType,
+			Patch: []byte(`{"image":"new image"}`),
+		}, id, nil)
+		assert.Equal(t, constant.ErrNotFound, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("error from repo", func(t *testing.T) {
+		mockCakeRepo.EXPECT().FindById(gomock.Any(), id).Times(1).Return(newCake(), nil)
+		mockCakeRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Times(1).Return(errors.New("err db"))
+
+		res, err := cakeService.Patch(ctx, model.PatchRequest{
+			Type:  model.MergePatchType,
+			Patch: []byte(`{"image":"new image"}`),
+		}, id, nil)
 		assert.Error(t, err)
 		assert.Nil(t, res)
 	})
@@ -189,29 +445,220 @@ func TestCakeService_FindAll(t *testing.T) {
 	cakes = append(cakes, cake, cake2)
 
 	t.Run("ok", func(t *testing.T) {
-		mockCakeRepo.EXPECT().FindAll(gomock.Any()).Times(1).Return(cakes, nil)
-		res, err := cakeService.FindAll(ctx)
+		query := model.CakeQuery{Page: 1, Limit: 10}
+		mockCakeRepo.EXPECT().FindAll(gomock.Any(), query).Times(1).Return(cakes, nil)
+		mockCakeRepo.EXPECT().CountAll(gomock.Any(), query).Times(1).Return(int64(2), nil)
+		res, pagination, err := cakeService.FindAll(ctx, model.CakeQuery{})
 		assert.NoError(t, err)
 		assert.NotNil(t, res)
+		assert.Equal(t, &model.Pagination{Total: 2, Page: 1, Limit: 10}, pagination)
+	})
+
+	t.Run("ok - filter by category and load relations", func(t *testing.T) {
+		mockCategoryRepo := mock.NewMockTaxonomyRepository(ctrl)
+		cakeService := NewCakeService(mockCakeRepo, nil, nil, mockCategoryRepo)
+
+		query := model.CakeQuery{Page: 1, Limit: 10, Category: "birthday"}
+		mockCakeRepo.EXPECT().FindAll(gomock.Any(), query).Times(1).Return(cakes, nil)
+		mockCakeRepo.EXPECT().CountAll(gomock.Any(), query).Times(1).Return(int64(2), nil)
+		mockCategoryRepo.EXPECT().LoadCakes(gomock.Any(), cakes).Times(1).Return(nil)
+		res, _, err := cakeService.FindAll(ctx, model.CakeQuery{Category: "birthday"})
+		assert.NoError(t, err)
+		assert.Equal(t, 2, len(res))
+	})
+
+	t.Run("unknown excluded allergen", func(t *testing.T) {
+		mockCakeRepo.EXPECT().FindAll(gomock.Any(), gomock.Any()).Times(0)
+		res, _, err := cakeService.FindAll(ctx, model.CakeQuery{ExcludeAllergens: "nuts,chocolate"})
+		assert.Equal(t, constant.ErrInvalidArgument, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("ok - convert currency", func(t *testing.T) {
+		mockVariantRepo := mock.NewMockVariantRepository(ctrl)
+		mockExchangeRate := mock.NewMockExchangeRateProvider(ctrl)
+		cakeService := NewCakeService(mockCakeRepo, nil, mockExchangeRate, mockVariantRepo)
+
+		cakes := []*model.Cake{{Id: 1, Title: "Kue A"}, {Id: 2, Title: "Kue B"}}
+		query := model.CakeQuery{Page: 1, Limit: 10, Currency: "EUR"}
+		mockCakeRepo.EXPECT().FindAll(gomock.Any(), query).Times(1).Return(cakes, nil)
+		mockCakeRepo.EXPECT().CountAll(gomock.Any(), query).Times(1).Return(int64(2), nil)
+		mockVariantRepo.EXPECT().LoadCakes(gomock.Any(), cakes).Times(1).DoAndReturn(func(ctx context.Context, cakes []*model.Cake) error {
+			cakes[0].Variants = []*model.Variant{{Id: 1, Price: model.NewMoney(2000000, "IDR")}}
+			cakes[1].Variants = []*model.Variant{{Id: 2, Price: model.NewMoney(1000000, "IDR")}, {Id: 3, Price: model.NewMoney(1000, "EUR")}}
+			return nil
+		})
+		mockExchangeRate.EXPECT().Rate(gomock.Any(), "IDR", "EUR").Times(1).Return(big.NewRat(6, 100000), nil)
+		mockExchangeRate.EXPECT().Rate(gomock.Any(), "EUR", "EUR").Times(1).Return(big.NewRat(1, 1), nil)
+
+		res, _, err := cakeService.FindAll(ctx, model.CakeQuery{Currency: "EUR"})
+		assert.NoError(t, err)
+		assert.Equal(t, model.NewMoney(120, "EUR"), res[0].Variants[0].Price)
+		assert.Equal(t, model.NewMoney(60, "EUR"), res[1].Variants[0].Price)
+		assert.Equal(t, model.NewMoney(1000, "EUR"), res[1].Variants[1].Price)
+	})
+
+	t.Run("unsupported currency", func(t *testing.T) {
+		mockVariantRepo := mock.NewMockVariantRepository(ctrl)
+		mockExchangeRate := mock.NewMockExchangeRateProvider(ctrl)
+		cakeService := NewCakeService(mockCakeRepo, nil, mockExchangeRate, mockVariantRepo)
+
+		cakes := []*model.Cake{{Id: 1, Title: "Kue A"}}
+		mockCakeRepo.EXPECT().FindAll(gomock.Any(), gomock.Any()).Times(1).Return(cakes, nil)
+		mockCakeRepo.EXPECT().CountAll(gomock.Any(), gomock.Any()).Times(1).Return(int64(1), nil)
+		mockVariantRepo.EXPECT().LoadCakes(gomock.Any(), cakes).Times(1).DoAndReturn(func(ctx context.Context, cakes []*model.Cake) error {
+			cakes[0].Variants = []*model.Variant{{Id: 1, Price: model.NewMoney(2000000, "IDR")}}
+			return nil
+		})
+		mockExchangeRate.EXPECT().Rate(gomock.Any(), "IDR", "GBP").Times(1).Return(nil, constant.ErrUnsupportedCurrency)
+
+		res, _, err := cakeService.FindAll(ctx, model.CakeQuery{Currency: "GBP"})
+		assert.Equal(t, constant.ErrUnsupportedCurrency, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("invalid currency", func(t *testing.T) {
+		mockCakeRepo.EXPECT().FindAll(gomock.Any(), gomock.Any()).Times(0)
+		res, _, err := cakeService.FindAll(ctx, model.CakeQuery{Currency: "EURO"})
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("ok - cursor first page", func(t *testing.T) {
+		cakes := []*model.Cake{
+			{Id: 1, Title: "Kue A", Rating: 9},
+			{Id: 2, Title: "Kue B", Rating: 8},
+			{Id: 3, Title: "Kue C", Rating: 8},
+		}
+		query := model.CakeQuery{Limit: 3, Paginate: model.PaginateCursor}
+		mockCakeRepo.EXPECT().FindAll(gomock.Any(), query).Times(1).Return(cakes, nil)
+		query.Limit = 2
+		mockCakeRepo.EXPECT().CountAll(gomock.Any(), query).Times(1).Return(int64(3), nil)
+
+		res, pagination, err := cakeService.FindAll(ctx, model.CakeQuery{Limit: 2, Paginate: model.PaginateCursor})
+		assert.NoError(t, err)
+		assert.Equal(t, 2, len(res))
+		assert.NotEmpty(t, pagination.NextCursor)
+
+		after := model.CakeCursor{}
+		assert.NoError(t, helper.DecodeCursor(pagination.NextCursor, config.CursorSecret(), &after))
+		assert.Equal(t, model.CakeCursor{Rating: 8, Title: "Kue B", Id: 2}, after)
+	})
+
+	t.Run("ok - cursor last page", func(t *testing.T) {
+		cursor, _ := helper.EncodeCursor(model.CakeCursor{Rating: 8, Title: "Kue B", Id: 2}, config.CursorSecret())
+		cakes := []*model.Cake{
+			{Id: 3, Title: "Kue C", Rating: 8},
+		}
+		mockCakeRepo.EXPECT().FindAll(gomock.Any(), model.CakeQuery{
+			Limit:    3,
+			Paginate: model.PaginateCursor,
+			Cursor:   cursor,
+			After:    &model.CakeCursor{Rating: 8, Title: "Kue B", Id: 2},
+		}).Times(1).Return(cakes, nil)
+		mockCakeRepo.EXPECT().CountAll(gomock.Any(), gomock.Any()).Times(1).Return(int64(3), nil)
+
+		res, pagination, err := cakeService.FindAll(ctx, model.CakeQuery{Limit: 2, Cursor: cursor})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(res))
+		assert.Empty(t, pagination.NextCursor)
+	})
+
+	t.Run("invalid cursor", func(t *testing.T) {
+		cursor, _ := helper.EncodeCursor(model.CakeCursor{Id: 2}, "not the secret")
+		mockCakeRepo.EXPECT().FindAll(gomock.Any(), gomock.Any()).Times(0)
+		res, _, err := cakeService.FindAll(ctx, model.CakeQuery{Cursor: cursor})
+		assert.Equal(t, constant.ErrInvalidCursor, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("cursor with sort", func(t *testing.T) {
+		mockCakeRepo.EXPECT().FindAll(gomock.Any(), gomock.Any()).Times(0)
+		res, _, err := cakeService.FindAll(ctx, model.CakeQuery{Paginate: model.PaginateCursor, SortBy: "title"})
+		assert.Equal(t, constant.ErrInvalidArgument, err)
+		assert.Nil(t, res)
 	})
 
 	t.Run("data empty", func(t *testing.T) {
 		cakes := make([]*model.Cake, 0)
 
-		mockCakeRepo.EXPECT().FindAll(gomock.Any()).Times(1).Return(cakes, nil)
-		res, err := cakeService.FindAll(ctx)
+		mockCakeRepo.EXPECT().FindAll(gomock.Any(), gomock.Any()).Times(1).Return(cakes, nil)
+		mockCakeRepo.EXPECT().CountAll(gomock.Any(), gomock.Any()).Times(1).Return(int64(0), nil)
+		res, _, err := cakeService.FindAll(ctx, model.CakeQuery{})
 		assert.NoError(t, err)
 		assert.NotNil(t, res)
 	})
 
+	t.Run("validate error", func(t *testing.T) {
+		mockCakeRepo.EXPECT().FindAll(gomock.Any(), gomock.Any()).Times(0)
+		res, _, err := cakeService.FindAll(ctx, model.CakeQuery{SortBy: "description"})
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("invalid rating range", func(t *testing.T) {
+		mockCakeRepo.EXPECT().FindAll(gomock.Any(), gomock.Any()).Times(0)
+		res, _, err := cakeService.FindAll(ctx, model.CakeQuery{MinRating: 8, MaxRating: 2})
+		assert.Equal(t, constant.ErrInvalidArgument, err)
+		assert.Nil(t, res)
+	})
+
 	t.Run("error from repo", func(t *testing.T) {
-		mockCakeRepo.EXPECT().FindAll(gomock.Any()).Times(1).Return(nil, errors.New("err db"))
-		res, err := cakeService.FindAll(ctx)
+		mockCakeRepo.EXPECT().FindAll(gomock.Any(), gomock.Any()).Times(1).Return(nil, errors.New("err db"))
+		res, _, err := cakeService.FindAll(ctx, model.CakeQuery{})
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("error count from repo", func(t *testing.T) {
+		mockCakeRepo.EXPECT().FindAll(gomock.Any(), gomock.Any()).Times(1).Return(cakes, nil)
+		mockCakeRepo.EXPECT().CountAll(gomock.Any(), gomock.Any()).Times(1).Return(int64(0), errors.New("err db"))
+		res, _, err := cakeService.FindAll(ctx, model.CakeQuery{})
 		assert.Error(t, err)
 		assert.Nil(t, res)
 	})
 }
 
+func TestCakeService_FindVersion(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.TODO()
+	mockCakeRepo := mock.NewMockCakeRepository(ctrl)
+	mockCategoryRepo := mock.NewMockTaxonomyRepository(ctrl)
+	cakeService := NewCakeService(mockCakeRepo, nil, nil, mockCategoryRepo)
+
+	cake := &model.Cake{
+		Id:        1,
+		Title:     "Kue Test",
+		Version:   3,
+		UpdatedAt: time.Now(),
+	}
+
+	t.Run("ok - without relations", func(t *testing.T) {
+		mockCakeRepo.EXPECT().FindById(gomock.Any(), cake.Id).Times(1).Return(cake, nil)
+		mockCategoryRepo.EXPECT().LoadCakes(gomock.Any(), gomock.Any()).Times(0)
+
+		res, err := cakeService.FindVersion(ctx, cake.Id)
+		assert.NoError(t, err)
+		assert.Equal(t, 3, res.Version)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mockCakeRepo.EXPECT().FindById(gomock.Any(), cake.Id).Times(1).Return(nil, nil)
+
+		res, err := cakeService.FindVersion(ctx, cake.Id)
+		assert.Equal(t, constant.ErrNotFound, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("invalid id", func(t *testing.T) {
+		res, err := cakeService.FindVersion(ctx, 0)
+		assert.Equal(t, constant.ErrInvalidArgument, err)
+		assert.Nil(t, res)
+	})
+}
+
 func TestCakeService_FindById(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -256,6 +703,84 @@ func TestCakeService_FindById(t *testing.T) {
 		assert.Error(t, err)
 		assert.Nil(t, res)
 	})
+
+	t.Run("ok - load relations", func(t *testing.T) {
+		mockCategoryRepo := mock.NewMockTaxonomyRepository(ctrl)
+		mockTagRepo := mock.NewMockTaxonomyRepository(ctrl)
+		cakeService := NewCakeService(mockCakeRepo, nil, nil, mockCategoryRepo, mockTagRepo)
+
+		mockCakeRepo.EXPECT().FindById(gomock.Any(), id).Times(1).Return(cake, nil)
+		mockCategoryRepo.EXPECT().LoadCakes(gomock.Any(), []*model.Cake{cake}).Times(1).Return(nil)
+		mockTagRepo.EXPECT().LoadCakes(gomock.Any(), []*model.Cake{cake}).Times(1).Return(nil)
+		res, err := cakeService.FindById(ctx, id)
+		assert.NoError(t, err)
+		assert.NotNil(t, res)
+	})
+
+	t.Run("error from loader", func(t *testing.T) {
+		mockCategoryRepo := mock.NewMockTaxonomyRepository(ctrl)
+		cakeService := NewCakeService(mockCakeRepo, nil, nil, mockCategoryRepo)
+
+		mockCakeRepo.EXPECT().FindById(gomock.Any(), id).Times(1).Return(cake, nil)
+		mockCategoryRepo.EXPECT().LoadCakes(gomock.Any(), gomock.Any()).Times(1).Return(errors.New("err db"))
+		res, err := cakeService.FindById(ctx, id)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestCakeService_FindByIdInStore(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.TODO()
+	mockCakeRepo := mock.NewMockCakeRepository(ctrl)
+	mockStoreRepo := mock.NewMockStoreRepository(ctrl)
+	mockVariantRepo := mock.NewMockVariantRepository(ctrl)
+
+	cakeService := NewCakeService(mockCakeRepo, mockStoreRepo, nil, mockVariantRepo)
+
+	available := true
+	store := &model.Store{Id: 2, Active: true}
+
+	t.Run("ok - store prices", func(t *testing.T) {
+		cake := &model.Cake{Id: 1, Title: "Kue Test", Available: &available}
+		mockStoreRepo.EXPECT().FindById(gomock.Any(), 2).Times(1).Return(store, nil)
+		mockCakeRepo.EXPECT().FindByIdInStore(gomock.Any(), 1, 2).Times(1).Return(cake, nil)
+		mockVariantRepo.EXPECT().LoadCakes(gomock.Any(), []*model.Cake{cake}).Times(1).
+			DoAndReturn(func(_ context.Context, cakes []*model.Cake) error {
+				cakes[0].Variants = []*model.Variant{
+					{Id: 5, Price: model.NewMoney(250000, "IDR")},
+					{Id: 6, Price: model.NewMoney(400000, "IDR")},
+				}
+				return nil
+			})
+		mockStoreRepo.EXPECT().FindPrices(gomock.Any(), 2, []int{5, 6}).Times(1).
+			Return(map[int]model.Money{6: model.NewMoney(450000, "IDR")}, nil)
+
+		res, err := cakeService.FindByIdInStore(ctx, 1, 2)
+		require.NoError(t, err)
+		assert.True(t, *res.Available)
+		assert.Equal(t, model.NewMoney(250000, "IDR"), res.Variants[0].Price)
+		assert.Equal(t, model.NewMoney(450000, "IDR"), res.Variants[1].Price)
+	})
+
+	t.Run("store not found", func(t *testing.T) {
+		mockStoreRepo.EXPECT().FindById(gomock.Any(), 3).Times(1).Return(nil, nil)
+
+		res, err := cakeService.FindByIdInStore(ctx, 1, 3)
+		assert.Equal(t, constant.ErrNotFound, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("cake not found", func(t *testing.T) {
+		mockStoreRepo.EXPECT().FindById(gomock.Any(), 2).Times(1).Return(store, nil)
+		mockCakeRepo.EXPECT().FindByIdInStore(gomock.Any(), 9, 2).Times(1).Return(nil, nil)
+
+		res, err := cakeService.FindByIdInStore(ctx, 9, 2)
+		assert.Equal(t, constant.ErrNotFound, err)
+		assert.Nil(t, res)
+	})
 }
 
 func TestCakeService_Delete(t *testing.T) {
@@ -284,7 +809,7 @@ func TestCakeService_Delete(t *testing.T) {
 		mockCakeRepo.EXPECT().FindById(gomock.Any(), cake.Id).Times(1).Return(cake, nil)
 		mockCakeRepo.EXPECT().Delete(gomock.Any(), cake).Times(1).Return(nil)
 
-		res, err := cakeService.Delete(ctx, id)
+		res, err := cakeService.Delete(ctx, id, nil)
 		assert.NoError(t, err)
 		assert.NotNil(t, res)
 	})
@@ -292,11 +817,39 @@ func TestCakeService_Delete(t *testing.T) {
 	t.Run("not found", func(t *testing.T) {
 		mockCakeRepo.EXPECT().FindById(gomock.Any(), cake.Id).Times(1).Return(nil, constant.ErrNotFound)
 		mockCakeRepo.EXPECT().Delete(gomock.Any(), cake).Times(0).Return(nil)
-		res, err := cakeService.Delete(ctx, cake.Id)
+		res, err := cakeService.Delete(ctx, cake.Id, nil)
 		assert.Error(t, err)
 		assert.Nil(t, res)
 	})
 
+	t.Run("precondition failed", func(t *testing.T) {
+		cake := &model.Cake{
+			Id:      id,
+			Title:   "Kue Test",
+			Version: 2,
+		}
+
+		mockCakeRepo.EXPECT().FindById(gomock.Any(), cake.Id).Times(1).Return(cake, nil)
+		mockCakeRepo.EXPECT().Delete(gomock.Any(), gomock.Any()).Times(0)
+		res, err := cakeService.Delete(ctx, cake.Id, model.IfMatch{1})
+		assert.Equal(t, constant.ErrPrecondition, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("ok - any listed version match", func(t *testing.T) {
+		cake := &model.Cake{
+			Id:      id,
+			Title:   "Kue Test",
+			Version: 2,
+		}
+
+		mockCakeRepo.EXPECT().FindById(gomock.Any(), cake.Id).Times(1).Return(cake, nil)
+		mockCakeRepo.EXPECT().Delete(gomock.Any(), cake).Times(1).Return(nil)
+		res, err := cakeService.Delete(ctx, cake.Id, model.IfMatch{1, 2})
+		assert.NoError(t, err)
+		assert.NotNil(t, res)
+	})
+
 	t.Run("already deleted", func(t *testing.T) {
 		cake := &model.Cake{
 			Id:          id,
@@ -313,16 +866,227 @@ func TestCakeService_Delete(t *testing.T) {
 
 		mockCakeRepo.EXPECT().FindById(gomock.Any(), cake.Id).Times(1).Return(cake, nil)
 		mockCakeRepo.EXPECT().Delete(gomock.Any(), cake).Times(0).Return(nil)
-		res, err := cakeService.Delete(ctx, cake.Id)
+		res, err := cakeService.Delete(ctx, cake.Id, nil)
 		assert.Error(t, err)
 		assert.Nil(t, res)
 	})
 
 	t.Run("error from repo", func(t *testing.T) {
+		cake.DeletedAt = nil
 		mockCakeRepo.EXPECT().FindById(gomock.Any(), cake.Id).Times(1).Return(cake, nil)
 		mockCakeRepo.EXPECT().Delete(gomock.Any(), gomock.Any()).Times(1).Return(errors.New("err db"))
-		res, err := cakeService.Delete(ctx, cake.Id)
+		res, err := cakeService.Delete(ctx, cake.Id, nil)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestCakeService_Restore(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.TODO()
+	mockCakeRepo := mock.NewMockCakeRepository(ctrl)
+
+	cakeService := &cakeService{
+		cakeRepository: mockCakeRepo,
+	}
+	id := 1
+	newCake := func() *model.Cake {
+		deletedAt := time.Now()
+		return &model.Cake{
+			Id:        id,
+			Title:     "Kue Test",
+			Version:   2,
+			DeletedAt: &deletedAt,
+		}
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		mockCakeRepo.EXPECT().FindByIdUnscoped(gomock.Any(), id).Times(1).Return(newCake(), nil)
+		mockCakeRepo.EXPECT().Restore(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+
+		res, err := cakeService.Restore(ctx, id, model.IfMatch{2})
+		assert.NoError(t, err)
+		assert.Nil(t, res.DeletedAt)
+	})
+
+	t.Run("not deleted", func(t *testing.T) {
+		cake := newCake()
+		cake.DeletedAt = nil
+		mockCakeRepo.EXPECT().FindByIdUnscoped(gomock.Any(), id).Times(1).Return(cake, nil)
+		mockCakeRepo.EXPECT().Restore(gomock.Any(), gomock.Any()).Times(0)
+
+		res, err := cakeService.Restore(ctx, id, nil)
+		assert.Equal(t, constant.ErrNotDeleted, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mockCakeRepo.EXPECT().FindByIdUnscoped(gomock.Any(), id).Times(1).Return(nil, nil)
+
+		res, err := cakeService.Restore(ctx, id, nil)
+		assert.Equal(t, constant.ErrNotFound, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("precondition failed", func(t *testing.T) {
+		mockCakeRepo.EXPECT().FindByIdUnscoped(gomock.Any(), id).Times(1).Return(newCake(), nil)
+		mockCakeRepo.EXPECT().Restore(gomock.Any(), gomock.Any()).Times(0)
+
+		res, err := cakeService.Restore(ctx, id, model.IfMatch{1})
+		assert.Equal(t, constant.ErrPrecondition, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("error from repo", func(t *testing.T) {
+		mockCakeRepo.EXPECT().FindByIdUnscoped(gomock.Any(), id).Times(1).Return(newCake(), nil)
+		mockCakeRepo.EXPECT().Restore(gomock.Any(), gomock.Any()).Times(1).Return(errors.New("err db"))
+
+		res, err := cakeService.Restore(ctx, id, nil)
 		assert.Error(t, err)
 		assert.Nil(t, res)
 	})
 }
+
+func TestCakeService_Purge(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.TODO()
+	mockCakeRepo := mock.NewMockCakeRepository(ctrl)
+
+	cakeService := &cakeService{
+		cakeRepository: mockCakeRepo,
+	}
+	id := 1
+	cake := &model.Cake{
+		Id:      id,
+		Title:   "Kue Test",
+		Version: 2,
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		mockCakeRepo.EXPECT().FindByIdUnscoped(gomock.Any(), id).Times(1).Return(cake, nil)
+		mockCakeRepo.EXPECT().Purge(gomock.Any(), cake).Times(1).Return(nil)
+
+		res, err := cakeService.Purge(ctx, id, nil)
+		assert.NoError(t, err)
+		assert.NotNil(t, res)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mockCakeRepo.EXPECT().FindByIdUnscoped(gomock.Any(), id).Times(1).Return(nil, nil)
+		mockCakeRepo.EXPECT().Purge(gomock.Any(), gomock.Any()).Times(0)
+
+		res, err := cakeService.Purge(ctx, id, nil)
+		assert.Equal(t, constant.ErrNotFound, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("concurrent update", func(t *testing.T) {
+		mockCakeRepo.EXPECT().FindByIdUnscoped(gomock.Any(), id).Times(1).Return(cake, nil)
+		mockCakeRepo.EXPECT().Purge(gomock.Any(), cake).Times(1).Return(constant.ErrVersionConflict)
+
+		res, err := cakeService.Purge(ctx, id, model.IfMatch{2})
+		assert.Equal(t, constant.ErrPrecondition, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestCakeService_PurgeExpired(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.TODO()
+	mockCakeRepo := mock.NewMockCakeRepository(ctrl)
+
+	cakeService := &cakeService{
+		cakeRepository: mockCakeRepo,
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		mockCakeRepo.EXPECT().CountDeletedBefore(gomock.Any(), gomock.Any()).Times(1).Return(int64(250), nil)
+		gomock.InOrder(
+			mockCakeRepo.EXPECT().PurgeDeletedBefore(gomock.Any(), gomock.Any(), 100).Times(2).Return(int64(100), nil),
+			mockCakeRepo.EXPECT().PurgeDeletedBefore(gomock.Any(), gomock.Any(), 100).Times(1).Return(int64(50), nil),
+		)
+
+		res, err := cakeService.PurgeExpired(ctx, model.PurgeOption{Retention: time.Hour, BatchSize: 100})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(250), res.Matched)
+		assert.Equal(t, int64(250), res.Purged)
+		assert.Equal(t, 3, res.Batches)
+		assert.WithinDuration(t, time.Now().Add(-time.Hour), res.DeletedBefore, time.Second)
+	})
+
+	t.Run("ok - max batches", func(t *testing.T) {
+		mockCakeRepo.EXPECT().CountDeletedBefore(gomock.Any(), gomock.Any()).Times(1).Return(int64(250), nil)
+		mockCakeRepo.EXPECT().PurgeDeletedBefore(gomock.Any(), gomock.Any(), 100).Times(2).Return(int64(100), nil)
+
+		res, err := cakeService.PurgeExpired(ctx, model.PurgeOption{Retention: time.Hour, BatchSize: 100, MaxBatches: 2})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(200), res.Purged)
+		assert.Equal(t, 2, res.Batches)
+	})
+
+	t.Run("ok - after each full batch", func(t *testing.T) {
+		mockCakeRepo.EXPECT().CountDeletedBefore(gomock.Any(), gomock.Any()).Times(1).Return(int64(150), nil)
+		gomock.InOrder(
+			mockCakeRepo.EXPECT().PurgeDeletedBefore(gomock.Any(), gomock.Any(), 100).Times(1).Return(int64(100), nil),
+			mockCakeRepo.EXPECT().PurgeDeletedBefore(gomock.Any(), gomock.Any(), 100).Times(1).Return(int64(50), nil),
+		)
+
+		calls := 0
+		afterBatch := func(ctx context.Context) error {
+			calls++
+			return nil
+		}
+
+		res, err := cakeService.PurgeExpired(ctx, model.PurgeOption{Retention: time.Hour, BatchSize: 100, AfterBatch: afterBatch})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(150), res.Purged)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("error after a batch - stopped", func(t *testing.T) {
+		mockCakeRepo.EXPECT().CountDeletedBefore(gomock.Any(), gomock.Any()).Times(1).Return(int64(250), nil)
+		mockCakeRepo.EXPECT().PurgeDeletedBefore(gomock.Any(), gomock.Any(), 100).Times(1).Return(int64(100), nil)
+
+		lost := errors.New("lock lost")
+		afterBatch := func(ctx context.Context) error {
+			return lost
+		}
+
+		res, err := cakeService.PurgeExpired(ctx, model.PurgeOption{Retention: time.Hour, BatchSize: 100, AfterBatch: afterBatch})
+		assert.Equal(t, lost, err)
+		assert.Equal(t, int64(100), res.Purged)
+		assert.Equal(t, 1, res.Batches)
+	})
+
+	t.Run("ok - dry run", func(t *testing.T) {
+		mockCakeRepo.EXPECT().CountDeletedBefore(gomock.Any(), gomock.Any()).Times(1).Return(int64(250), nil)
+		mockCakeRepo.EXPECT().PurgeDeletedBefore(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		res, err := cakeService.PurgeExpired(ctx, model.PurgeOption{Retention: time.Hour, BatchSize: 100, DryRun: true})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(250), res.Matched)
+		assert.Equal(t, int64(0), res.Purged)
+		assert.True(t, res.DryRun)
+	})
+
+	t.Run("invalid option", func(t *testing.T) {
+		res, err := cakeService.PurgeExpired(ctx, model.PurgeOption{BatchSize: 100})
+		assert.Equal(t, constant.ErrInvalidArgument, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("error from repo", func(t *testing.T) {
+		mockCakeRepo.EXPECT().CountDeletedBefore(gomock.Any(), gomock.Any()).Times(1).Return(int64(250), nil)
+		mockCakeRepo.EXPECT().PurgeDeletedBefore(gomock.Any(), gomock.Any(), 100).Times(1).Return(int64(0), errors.New("err db"))
+
+		res, err := cakeService.PurgeExpired(ctx, model.PurgeOption{Retention: time.Hour, BatchSize: 100})
+		assert.Error(t, err)
+		assert.Equal(t, 0, res.Batches)
+	})
+}
@@ -0,0 +1,427 @@
+package service
+
+import (
+	"cake-store/src/config"
+	"cake-store/src/constant"
+	"cake-store/src/helper"
+	"cake-store/src/model"
+	"context"
+
+	"github.com/sirupsen/logrus"
+)
+
+type cartService struct {
+	cartRepository  model.CartRepository
+	cakeService     model.CakeService
+	storeRepository model.StoreRepository
+	orderService    model.OrderService
+	couponService   model.CouponService
+	exchangeRate    model.ExchangeRateProvider
+}
+
+func NewCartService(cartRepository model.CartRepository, cakeService model.CakeService, storeRepository model.StoreRepository, orderService model.OrderService,
+	couponService model.CouponService, exchangeRate model.ExchangeRateProvider) model.CartService {
+	return &cartService{
+		cartRepository:  cartRepository,
+		cakeService:     cakeService,
+		storeRepository: storeRepository,
+		orderService:    orderService,
+		couponService:   couponService,
+		exchangeRate:    exchangeRate,
+	}
+}
+
+func (c *cartService) Create(ctx context.Context) (*model.Cart, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Create Cart Service",
+	})
+
+	id, err := helper.RandomToken()
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	cart := &model.Cart{
+		Id:      id,
+		Lines:   make([]*model.CartLine, 0),
+		Coupons: make([]string, 0),
+	}
+
+	if err = c.cartRepository.Save(ctx, cart); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	if err = c.price(ctx, cart); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return cart, nil
+}
+
+func (c *cartService) FindById(ctx context.Context, cartId string) (*model.Cart, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Find By ID Cart Service",
+		"cartId":  cartId,
+	})
+
+	cart, err := c.findCart(ctx, cartId)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	if err = c.price(ctx, cart); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return cart, nil
+}
+
+// AddLine add the variant to the cart, the cake must be live and the variant active
+func (c *cartService) AddLine(ctx context.Context, req model.AddCartLineRequest, cartId string) (*model.Cart, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Add Line Cart Service",
+		"req":     req,
+		"cartId":  cartId,
+	})
+
+	if err := req.Validate(); err != nil {
+		log.Error(err)
+		return nil, constant.HttpValidationOrInternalErr(err)
+	}
+
+	if cartId == "" {
+		log.Error(constant.ErrInvalidArgument)
+		return nil, constant.ErrInvalidArgument
+	}
+
+	if _, _, err := findVariant(ctx, c.cakeService, req.CakeId, req.VariantId); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return c.update(ctx, log, cartId, func(cart *model.Cart) error {
+		line := cart.AddLine(&model.CartLine{
+			CakeId:    req.CakeId,
+			VariantId: req.VariantId,
+			Quantity:  req.Quantity,
+			Message:   req.Message,
+		})
+		if len(cart.Lines) > model.MaxCartLines || line.Quantity > model.MaxCartLineQuantity {
+			return constant.ErrInvalidArgument
+		}
+		return nil
+	})
+}
+
+func (c *cartService) UpdateLine(ctx context.Context, req model.UpdateCartLineRequest, cartId string, lineId int) (*model.Cart, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Update Line Cart Service",
+		"req":     req,
+		"cartId":  cartId,
+		"lineId":  lineId,
+	})
+
+	if err := req.Validate(); err != nil {
+		log.Error(err)
+		return nil, constant.HttpValidationOrInternalErr(err)
+	}
+
+	return c.update(ctx, log, cartId, func(cart *model.Cart) error {
+		line := cart.FindLine(lineId)
+		if line == nil {
+			return constant.ErrNotFound
+		}
+
+		line.Quantity = req.Quantity
+		line.Message = req.Message
+		return nil
+	})
+}
+
+func (c *cartService) RemoveLine(ctx context.Context, cartId string, lineId int) (*model.Cart, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Remove Line Cart Service",
+		"cartId":  cartId,
+		"lineId":  lineId,
+	})
+
+	return c.update(ctx, log, cartId, func(cart *model.Cart) error {
+		if !cart.RemoveLine(lineId) {
+			return constant.ErrNotFound
+		}
+		return nil
+	})
+}
+
+func (c *cartService) Delete(ctx context.Context, cartId string) error {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Delete Cart Service",
+		"cartId":  cartId,
+	})
+
+	if cartId == "" {
+		log.Error(constant.ErrInvalidArgument)
+		return constant.ErrInvalidArgument
+	}
+
+	if err := c.cartRepository.Delete(ctx, cartId); err != nil {
+		log.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// SetCoupons replace the coupon codes of the cart, the cart is kept unchanged when a code is rejected
+func (c *cartService) SetCoupons(ctx context.Context, req model.SetCartCouponsRequest, cartId string) (*model.Cart, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Set Coupons Cart Service",
+		"req":     req,
+		"cartId":  cartId,
+	})
+
+	if err := req.Validate(); err != nil {
+		log.Error(err)
+		return nil, constant.HttpValidationOrInternalErr(err)
+	}
+
+	return c.update(ctx, log, cartId, func(cart *model.Cart) error {
+		cart.Coupons = normalizeCodes(req.Codes)
+		if err := c.price(ctx, cart); err != nil {
+			return err
+		}
+
+		if cart.Discount != nil {
+			if rejected := cart.Discount.Rejected(); rejected != nil {
+				return constant.CouponRejectedErr(rejected.Code, rejected.Reason)
+			}
+		}
+		return nil
+	})
+}
+
+// SetStore price the cart in the store, the store must be active
+func (c *cartService) SetStore(ctx context.Context, req model.SetCartStoreRequest, cartId string) (*model.Cart, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Set Store Cart Service",
+		"req":     req,
+		"cartId":  cartId,
+	})
+
+	if err := req.Validate(); err != nil {
+		log.Error(err)
+		return nil, constant.HttpValidationOrInternalErr(err)
+	}
+
+	store, err := c.storeRepository.FindById(ctx, req.StoreId)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	if store == nil || !store.Active {
+		log.Error(constant.ErrNotFound)
+		return nil, constant.ErrNotFound
+	}
+
+	return c.update(ctx, log, cartId, func(cart *model.Cart) error {
+		cart.StoreId = store.Id
+		return nil
+	})
+}
+
+// Checkout turn the cart into a pending order, the cart is claimed first so it is ordered at most once,
+// and it is put back when the order could not be placed
+func (c *cartService) Checkout(ctx context.Context, req model.CheckoutCartRequest, cartId string) (*model.Order, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Checkout Cart Service",
+		"req":     req,
+		"cartId":  cartId,
+	})
+
+	if err := req.Validate(); err != nil {
+		log.Error(err)
+		return nil, constant.HttpValidationOrInternalErr(err)
+	}
+
+	if cartId == "" {
+		log.Error(constant.ErrInvalidArgument)
+		return nil, constant.ErrInvalidArgument
+	}
+
+	cart, err := c.cartRepository.Claim(ctx, cartId)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	if cart == nil {
+		log.Error(constant.ErrNotFound)
+		return nil, constant.ErrNotFound
+	}
+
+	order, err := c.checkout(ctx, req, cart)
+	if err != nil {
+		log.Error(err)
+		if restoreErr := c.cartRepository.Save(ctx, cart); restoreErr != nil {
+			log.Error(restoreErr)
+		}
+		return nil, err
+	}
+
+	return order, nil
+}
+
+func (c *cartService) checkout(ctx context.Context, req model.CheckoutCartRequest, cart *model.Cart) (*model.Order, error) {
+	if len(cart.Lines) == 0 {
+		return nil, constant.ErrInvalidArgument
+	}
+
+	items := make([]model.CreateOrderItemRequest, 0, len(cart.Lines))
+	for _, line := range cart.Lines {
+		items = append(items, model.CreateOrderItemRequest{
+			CakeId:    line.CakeId,
+			VariantId: line.VariantId,
+			Quantity:  line.Quantity,
+			Message:   line.Message,
+		})
+	}
+
+	storeId := req.StoreId
+	if storeId == 0 {
+		storeId = cart.StoreId
+	}
+
+	return c.orderService.Create(ctx, model.CreateOrderRequest{
+		StoreId:       storeId,
+		CustomerName:  req.CustomerName,
+		CustomerPhone: req.CustomerPhone,
+		Fulfillment:   req.Fulfillment,
+		Note:          req.Note,
+		PickupDate:    req.PickupDate,
+		PickupTime:    req.PickupTime,
+		Items:         items,
+		Coupons:       cart.Coupons,
+	})
+}
+
+// update apply the edit to the stored cart and price the result, the cart is kept unchanged when the edit fail
+func (c *cartService) update(ctx context.Context, log *logrus.Entry, cartId string, edit func(cart *model.Cart) error) (*model.Cart, error) {
+	if cartId == "" {
+		log.Error(constant.ErrInvalidArgument)
+		return nil, constant.ErrInvalidArgument
+	}
+
+	cart, err := c.cartRepository.Update(ctx, cartId, edit)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	if cart == nil {
+		log.Error(constant.ErrNotFound)
+		return nil, constant.ErrNotFound
+	}
+
+	if err = c.price(ctx, cart); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return cart, nil
+}
+
+// price fill the lines from the live catalog at the prices of the cart store, total the available lines in the base
+// currency and apply the coupons of the cart to them
+func (c *cartService) price(ctx context.Context, cart *model.Cart) error {
+	currency := config.BaseCurrency()
+	storeId := storeOrDefault(cart.StoreId)
+	subtotal := model.NewMoney(0, currency)
+	lines := make([]*model.DiscountLine, 0, len(cart.Lines))
+
+	for _, line := range cart.Lines {
+		cake, variant, err := findVariant(ctx, c.cakeService, line.CakeId, line.VariantId)
+		if err == constant.ErrNotFound {
+			line.Available = false
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		price, err := storePrice(ctx, c.storeRepository, storeId, variant)
+		if err == constant.ErrCakeUnavailable {
+			line.Available = false
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		price, err = convertPrice(ctx, c.exchangeRate, price, currency)
+		if err != nil {
+			return err
+		}
+		lineSubtotal := model.NewMoney(price.Amount*int64(line.Quantity), currency)
+
+		line.Title = cake.Title
+		line.Size = variant.Size
+		line.UnitPrice = &price
+		line.Subtotal = &lineSubtotal
+		line.Available = true
+		subtotal.Amount += lineSubtotal.Amount
+		lines = append(lines, &model.DiscountLine{CakeId: line.CakeId, Quantity: line.Quantity, UnitPrice: price})
+	}
+
+	total := subtotal
+	cart.Subtotal = &subtotal
+	cart.Discount = nil
+	if len(cart.Coupons) > 0 {
+		discount, err := c.couponService.Apply(ctx, cart.Coupons, lines)
+		if err != nil {
+			return err
+		}
+		cart.Discount = discount
+		total = discount.Total
+	}
+
+	cart.Total = &total
+	return nil
+}
+
+// findVariant find the live cake and its active variant, return ErrNotFound when either is not sold
+func findVariant(ctx context.Context, cakeService model.CakeService, cakeId int, variantId int) (*model.Cake, *model.Variant, error) {
+	cake, err := cakeService.FindById(ctx, cakeId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, variant := range cake.Variants {
+		if variant.Id == variantId && variant.Active {
+			return cake, variant, nil
+		}
+	}
+	return nil, nil, constant.ErrNotFound
+}
+
+func (c *cartService) findCart(ctx context.Context, cartId string) (*model.Cart, error) {
+	if cartId == "" {
+		return nil, constant.ErrInvalidArgument
+	}
+
+	cart, err := c.cartRepository.FindById(ctx, cartId)
+	if err != nil {
+		return nil, err
+	}
+
+	if cart == nil {
+		return nil, constant.ErrNotFound
+	}
+
+	return cart, nil
+}
@@ -0,0 +1,437 @@
+package service
+
+import (
+	"cake-store/src/constant"
+	"cake-store/src/model"
+	"cake-store/src/model/mock"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCartService_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCartRepo := mock.NewMockCartRepository(ctrl)
+	cartService := &cartService{
+		cartRepository: mockCartRepo,
+	}
+
+	mockCartRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+
+	res, err := cartService.Create(context.TODO())
+	require.NoError(t, err)
+	assert.Len(t, res.Id, 32)
+	assert.Empty(t, res.Lines)
+	assert.Equal(t, model.NewMoney(0, "IDR"), *res.Total)
+}
+
+func TestCartService_AddLine(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.TODO()
+	mockCartRepo := mock.NewMockCartRepository(ctrl)
+	mockCakeService := mock.NewMockCakeService(ctrl)
+	mockStoreRepo := mock.NewMockStoreRepository(ctrl)
+
+	cartService := &cartService{
+		cartRepository:  mockCartRepo,
+		cakeService:     mockCakeService,
+		storeRepository: mockStoreRepo,
+	}
+
+	mockStoreRepo.EXPECT().FindCake(gomock.Any(), 1, 1).AnyTimes().Return(nil, nil)
+
+	cake := &model.Cake{Id: 1, Title: "Kue Test", Variants: []*model.Variant{
+		{Id: 5, CakeId: 1, Size: "20cm", Price: model.NewMoney(250000, "IDR"), Active: true},
+		{Id: 6, CakeId: 1, Size: "24cm", Price: model.NewMoney(300000, "IDR"), Active: false},
+	}}
+	req := model.AddCartLineRequest{CakeId: 1, VariantId: 5, Quantity: 2, Message: "Happy Birthday"}
+
+	t.Run("ok", func(t *testing.T) {
+		cart := &model.Cart{Id: "abc"}
+		mockCakeService.EXPECT().FindById(gomock.Any(), 1).Times(2).Return(cake, nil)
+		mockCartRepo.EXPECT().Update(gomock.Any(), "abc", gomock.Any()).Times(1).DoAndReturn(editCart(cart))
+
+		res, err := cartService.AddLine(ctx, req, "abc")
+		require.NoError(t, err)
+		require.Len(t, res.Lines, 1)
+		assert.Equal(t, "Kue Test", res.Lines[0].Title)
+		assert.True(t, res.Lines[0].Available)
+		assert.Equal(t, model.NewMoney(500000, "IDR"), *res.Total)
+	})
+
+	t.Run("ok - merge same line", func(t *testing.T) {
+		cart := &model.Cart{Id: "abc", Lines: []*model.CartLine{{Id: 1, CakeId: 1, VariantId: 5, Quantity: 1, Message: "Happy Birthday"}}}
+		mockCakeService.EXPECT().FindById(gomock.Any(), 1).Times(2).Return(cake, nil)
+		mockCartRepo.EXPECT().Update(gomock.Any(), "abc", gomock.Any()).Times(1).DoAndReturn(editCart(cart))
+
+		res, err := cartService.AddLine(ctx, req, "abc")
+		require.NoError(t, err)
+		require.Len(t, res.Lines, 1)
+		assert.Equal(t, 3, res.Lines[0].Quantity)
+	})
+
+	t.Run("deleted cake", func(t *testing.T) {
+		mockCakeService.EXPECT().FindById(gomock.Any(), 1).Times(1).Return(nil, constant.ErrNotFound)
+		mockCartRepo.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		res, err := cartService.AddLine(ctx, req, "abc")
+		assert.Equal(t, constant.ErrNotFound, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("inactive variant", func(t *testing.T) {
+		req := req
+		req.VariantId = 6
+
+		mockCakeService.EXPECT().FindById(gomock.Any(), 1).Times(1).Return(cake, nil)
+		mockCartRepo.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		res, err := cartService.AddLine(ctx, req, "abc")
+		assert.Equal(t, constant.ErrNotFound, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("too many", func(t *testing.T) {
+		req := req
+		req.Quantity = 100
+
+		cart := &model.Cart{Id: "abc", Lines: []*model.CartLine{{Id: 1, CakeId: 1, VariantId: 5, Quantity: 1, Message: "Happy Birthday"}}}
+		mockCakeService.EXPECT().FindById(gomock.Any(), 1).Times(1).Return(cake, nil)
+		mockCartRepo.EXPECT().Update(gomock.Any(), "abc", gomock.Any()).Times(1).DoAndReturn(editCart(cart))
+
+		res, err := cartService.AddLine(ctx, req, "abc")
+		assert.Equal(t, constant.ErrInvalidArgument, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("cart not found", func(t *testing.T) {
+		mockCakeService.EXPECT().FindById(gomock.Any(), 1).Times(1).Return(cake, nil)
+		mockCartRepo.EXPECT().Update(gomock.Any(), "gone", gomock.Any()).Times(1).DoAndReturn(editCart(nil))
+
+		res, err := cartService.AddLine(ctx, req, "gone")
+		assert.Equal(t, constant.ErrNotFound, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("validate error", func(t *testing.T) {
+		req := req
+		req.Quantity = 0
+
+		res, err := cartService.AddLine(ctx, req, "abc")
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestCartService_FindById(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCartRepo := mock.NewMockCartRepository(ctrl)
+	mockCakeService := mock.NewMockCakeService(ctrl)
+	mockStoreRepo := mock.NewMockStoreRepository(ctrl)
+
+	cartService := &cartService{
+		cartRepository:  mockCartRepo,
+		cakeService:     mockCakeService,
+		storeRepository: mockStoreRepo,
+	}
+
+	mockStoreRepo.EXPECT().FindCake(gomock.Any(), 1, 1).AnyTimes().Return(nil, nil)
+
+	cart := &model.Cart{Id: "abc", Lines: []*model.CartLine{
+		{Id: 1, CakeId: 1, VariantId: 5, Quantity: 2},
+		{Id: 2, CakeId: 2, VariantId: 7, Quantity: 1},
+	}}
+	cake := &model.Cake{Id: 1, Title: "Kue Test", Variants: []*model.Variant{{Id: 5, CakeId: 1, Price: model.NewMoney(250000, "IDR"), Active: true}}}
+
+	mockCartRepo.EXPECT().FindById(gomock.Any(), "abc").Times(1).Return(cart, nil)
+	mockCakeService.EXPECT().FindById(gomock.Any(), 1).Times(1).Return(cake, nil)
+	mockCakeService.EXPECT().FindById(gomock.Any(), 2).Times(1).Return(nil, constant.ErrNotFound)
+
+	res, err := cartService.FindById(context.TODO(), "abc")
+	require.NoError(t, err)
+	assert.True(t, res.Lines[0].Available)
+	assert.False(t, res.Lines[1].Available)
+	assert.Equal(t, model.NewMoney(500000, "IDR"), *res.Total)
+}
+
+func TestCartService_UpdateAndRemoveLine(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.TODO()
+	mockCartRepo := mock.NewMockCartRepository(ctrl)
+	mockCakeService := mock.NewMockCakeService(ctrl)
+	mockStoreRepo := mock.NewMockStoreRepository(ctrl)
+
+	cartService := &cartService{
+		cartRepository:  mockCartRepo,
+		cakeService:     mockCakeService,
+		storeRepository: mockStoreRepo,
+	}
+
+	mockStoreRepo.EXPECT().FindCake(gomock.Any(), 1, 1).AnyTimes().Return(nil, nil)
+
+	t.Run("update", func(t *testing.T) {
+		cart := &model.Cart{Id: "abc", Lines: []*model.CartLine{{Id: 1, CakeId: 1, VariantId: 5, Quantity: 2}}}
+		mockCartRepo.EXPECT().Update(gomock.Any(), "abc", gomock.Any()).Times(1).DoAndReturn(editCart(cart))
+		mockCakeService.EXPECT().FindById(gomock.Any(), 1).Times(1).Return(nil, constant.ErrNotFound)
+
+		res, err := cartService.UpdateLine(ctx, model.UpdateCartLineRequest{Quantity: 4, Message: "Selamat"}, "abc", 1)
+		require.NoError(t, err)
+		assert.Equal(t, 4, res.Lines[0].Quantity)
+		assert.Equal(t, "Selamat", res.Lines[0].Message)
+	})
+
+	t.Run("update unknown line", func(t *testing.T) {
+		mockCartRepo.EXPECT().Update(gomock.Any(), "abc", gomock.Any()).Times(1).DoAndReturn(editCart(&model.Cart{Id: "abc"}))
+
+		res, err := cartService.UpdateLine(ctx, model.UpdateCartLineRequest{Quantity: 4}, "abc", 9)
+		assert.Equal(t, constant.ErrNotFound, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("remove", func(t *testing.T) {
+		cart := &model.Cart{Id: "abc", Lines: []*model.CartLine{{Id: 1, CakeId: 1, VariantId: 5, Quantity: 2}}}
+		mockCartRepo.EXPECT().Update(gomock.Any(), "abc", gomock.Any()).Times(1).DoAndReturn(editCart(cart))
+
+		res, err := cartService.RemoveLine(ctx, "abc", 1)
+		require.NoError(t, err)
+		assert.Empty(t, res.Lines)
+	})
+}
+
+func TestCartService_SetCoupons(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.TODO()
+	mockCartRepo := mock.NewMockCartRepository(ctrl)
+	mockCakeService := mock.NewMockCakeService(ctrl)
+	mockStoreRepo := mock.NewMockStoreRepository(ctrl)
+	mockCouponService := mock.NewMockCouponService(ctrl)
+
+	cartService := &cartService{
+		cartRepository:  mockCartRepo,
+		cakeService:     mockCakeService,
+		storeRepository: mockStoreRepo,
+		couponService:   mockCouponService,
+	}
+
+	mockStoreRepo.EXPECT().FindCake(gomock.Any(), 1, 1).AnyTimes().Return(nil, nil)
+
+	cake := &model.Cake{Id: 1, Title: "Kue Test", Variants: []*model.Variant{{Id: 5, CakeId: 1, Price: model.NewMoney(250000, "IDR"), Active: true}}}
+	lines := []*model.DiscountLine{{CakeId: 1, Quantity: 2, UnitPrice: model.NewMoney(250000, "IDR")}}
+
+	t.Run("ok", func(t *testing.T) {
+		cart := &model.Cart{Id: "abc", Lines: []*model.CartLine{{Id: 1, CakeId: 1, VariantId: 5, Quantity: 2}}}
+		discount := &model.Discount{
+			Subtotal: model.NewMoney(500000, "IDR"),
+			Amount:   model.NewMoney(50000, "IDR"),
+			Total:    model.NewMoney(450000, "IDR"),
+			Coupons:  []*model.AppliedCoupon{{Code: "HEMAT10", Valid: true}},
+		}
+		mockCartRepo.EXPECT().Update(gomock.Any(), "abc", gomock.Any()).Times(1).DoAndReturn(editCart(cart))
+		mockCakeService.EXPECT().FindById(gomock.Any(), 1).Times(2).Return(cake, nil)
+		mockCouponService.EXPECT().Apply(gomock.Any(), []string{"HEMAT10"}, lines).Times(2).Return(discount, nil)
+
+		res, err := cartService.SetCoupons(ctx, model.SetCartCouponsRequest{Codes: []string{"hemat10"}}, "abc")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"HEMAT10"}, res.Coupons)
+		assert.Equal(t, model.NewMoney(500000, "IDR"), *res.Subtotal)
+		assert.Equal(t, model.NewMoney(450000, "IDR"), *res.Total)
+	})
+
+	t.Run("rejected coupon", func(t *testing.T) {
+		cart := &model.Cart{Id: "abc", Lines: []*model.CartLine{{Id: 1, CakeId: 1, VariantId: 5, Quantity: 2}}}
+		discount := &model.Discount{Coupons: []*model.AppliedCoupon{{Code: "OLD", Reason: model.CouponReasonExpired}}}
+		mockCartRepo.EXPECT().Update(gomock.Any(), "abc", gomock.Any()).Times(1).DoAndReturn(editCart(cart))
+		mockCakeService.EXPECT().FindById(gomock.Any(), 1).Times(1).Return(cake, nil)
+		mockCouponService.EXPECT().Apply(gomock.Any(), []string{"OLD"}, lines).Times(1).Return(discount, nil)
+
+		res, err := cartService.SetCoupons(ctx, model.SetCartCouponsRequest{Codes: []string{"OLD"}}, "abc")
+		assert.Equal(t, constant.CouponRejectedErr("OLD", model.CouponReasonExpired), err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("clear coupons", func(t *testing.T) {
+		cart := &model.Cart{Id: "abc", Coupons: []string{"HEMAT10"}}
+		mockCartRepo.EXPECT().Update(gomock.Any(), "abc", gomock.Any()).Times(1).DoAndReturn(editCart(cart))
+
+		res, err := cartService.SetCoupons(ctx, model.SetCartCouponsRequest{}, "abc")
+		require.NoError(t, err)
+		assert.Empty(t, res.Coupons)
+		assert.Nil(t, res.Discount)
+	})
+}
+
+func TestCartService_SetStore(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.TODO()
+	mockCartRepo := mock.NewMockCartRepository(ctrl)
+	mockCakeService := mock.NewMockCakeService(ctrl)
+	mockStoreRepo := mock.NewMockStoreRepository(ctrl)
+
+	cartService := &cartService{
+		cartRepository:  mockCartRepo,
+		cakeService:     mockCakeService,
+		storeRepository: mockStoreRepo,
+	}
+
+	cake := &model.Cake{Id: 1, Title: "Kue Test", Variants: []*model.Variant{{Id: 5, CakeId: 1, Price: model.NewMoney(250000, "IDR"), Active: true}}}
+
+	t.Run("ok - priced at the store price", func(t *testing.T) {
+		cart := &model.Cart{Id: "abc", Lines: []*model.CartLine{{Id: 1, CakeId: 1, VariantId: 5, Quantity: 2}}}
+		storeCake := &model.StoreCake{StoreId: 2, CakeId: 1, Available: true,
+			Prices: []*model.StorePrice{{VariantId: 5, Price: model.NewMoney(275000, "IDR")}}}
+		mockStoreRepo.EXPECT().FindById(gomock.Any(), 2).Times(1).Return(&model.Store{Id: 2, Active: true}, nil)
+		mockCartRepo.EXPECT().Update(gomock.Any(), "abc", gomock.Any()).Times(1).DoAndReturn(editCart(cart))
+		mockCakeService.EXPECT().FindById(gomock.Any(), 1).Times(1).Return(cake, nil)
+		mockStoreRepo.EXPECT().FindCake(gomock.Any(), 2, 1).Times(1).Return(storeCake, nil)
+
+		res, err := cartService.SetStore(ctx, model.SetCartStoreRequest{StoreId: 2}, "abc")
+		require.NoError(t, err)
+		assert.Equal(t, 2, res.StoreId)
+		assert.Equal(t, model.NewMoney(275000, "IDR"), *res.Lines[0].UnitPrice)
+		assert.Equal(t, model.NewMoney(550000, "IDR"), *res.Total)
+	})
+
+	t.Run("ok - not sold in the store", func(t *testing.T) {
+		cart := &model.Cart{Id: "abc", Lines: []*model.CartLine{{Id: 1, CakeId: 1, VariantId: 5, Quantity: 2}}}
+		mockStoreRepo.EXPECT().FindById(gomock.Any(), 2).Times(1).Return(&model.Store{Id: 2, Active: true}, nil)
+		mockCartRepo.EXPECT().Update(gomock.Any(), "abc", gomock.Any()).Times(1).DoAndReturn(editCart(cart))
+		mockCakeService.EXPECT().FindById(gomock.Any(), 1).Times(1).Return(cake, nil)
+		mockStoreRepo.EXPECT().FindCake(gomock.Any(), 2, 1).Times(1).Return(&model.StoreCake{StoreId: 2, CakeId: 1}, nil)
+
+		res, err := cartService.SetStore(ctx, model.SetCartStoreRequest{StoreId: 2}, "abc")
+		require.NoError(t, err)
+		assert.False(t, res.Lines[0].Available)
+		assert.Equal(t, model.NewMoney(0, "IDR"), *res.Total)
+	})
+
+	t.Run("inactive store", func(t *testing.T) {
+		mockStoreRepo.EXPECT().FindById(gomock.Any(), 3).Times(1).Return(&model.Store{Id: 3}, nil)
+		mockCartRepo.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		res, err := cartService.SetStore(ctx, model.SetCartStoreRequest{StoreId: 3}, "abc")
+		assert.Equal(t, constant.ErrNotFound, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("validate error", func(t *testing.T) {
+		res, err := cartService.SetStore(ctx, model.SetCartStoreRequest{}, "abc")
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestCartService_Checkout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.TODO()
+	mockCartRepo := mock.NewMockCartRepository(ctrl)
+	mockOrderService := mock.NewMockOrderService(ctrl)
+
+	cartService := &cartService{
+		cartRepository: mockCartRepo,
+		orderService:   mockOrderService,
+	}
+
+	req := model.CheckoutCartRequest{CustomerName: "Budi", CustomerPhone: "0812", Fulfillment: model.FulfillmentPickup}
+	orderReq := model.CreateOrderRequest{
+		CustomerName:  "Budi",
+		CustomerPhone: "0812",
+		Fulfillment:   model.FulfillmentPickup,
+		Items:         []model.CreateOrderItemRequest{{CakeId: 1, VariantId: 5, Quantity: 2, Message: "Happy Birthday"}},
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		cart := &model.Cart{Id: "abc", Lines: []*model.CartLine{{Id: 1, CakeId: 1, VariantId: 5, Quantity: 2, Message: "Happy Birthday"}}}
+		order := &model.Order{Id: 3, Status: model.OrderStatusPending}
+		mockCartRepo.EXPECT().Claim(gomock.Any(), "abc").Times(1).Return(cart, nil)
+		mockOrderService.EXPECT().Create(gomock.Any(), orderReq).Times(1).Return(order, nil)
+		mockCartRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Times(0)
+
+		res, err := cartService.Checkout(ctx, req, "abc")
+		require.NoError(t, err)
+		assert.Equal(t, order, res)
+	})
+
+	t.Run("ok - coupons", func(t *testing.T) {
+		cart := &model.Cart{Id: "abc", Lines: []*model.CartLine{{Id: 1, CakeId: 1, VariantId: 5, Quantity: 2, Message: "Happy Birthday"}}, Coupons: []string{"HEMAT10"}}
+		orderReq := orderReq
+		orderReq.Coupons = []string{"HEMAT10"}
+		mockCartRepo.EXPECT().Claim(gomock.Any(), "abc").Times(1).Return(cart, nil)
+		mockOrderService.EXPECT().Create(gomock.Any(), orderReq).Times(1).Return(&model.Order{Id: 4}, nil)
+
+		res, err := cartService.Checkout(ctx, req, "abc")
+		require.NoError(t, err)
+		assert.Equal(t, 4, res.Id)
+	})
+
+	t.Run("ok - store of the cart", func(t *testing.T) {
+		cart := &model.Cart{Id: "abc", StoreId: 2, Lines: []*model.CartLine{{Id: 1, CakeId: 1, VariantId: 5, Quantity: 2, Message: "Happy Birthday"}}}
+		orderReq := orderReq
+		orderReq.StoreId = 2
+		mockCartRepo.EXPECT().Claim(gomock.Any(), "abc").Times(1).Return(cart, nil)
+		mockOrderService.EXPECT().Create(gomock.Any(), orderReq).Times(1).Return(&model.Order{Id: 5, StoreId: 2}, nil)
+
+		res, err := cartService.Checkout(ctx, req, "abc")
+		require.NoError(t, err)
+		assert.Equal(t, 2, res.StoreId)
+	})
+
+	t.Run("order failed - cart restored", func(t *testing.T) {
+		cart := &model.Cart{Id: "abc", Lines: []*model.CartLine{{Id: 1, CakeId: 1, VariantId: 5, Quantity: 2, Message: "Happy Birthday"}}}
+		mockCartRepo.EXPECT().Claim(gomock.Any(), "abc").Times(1).Return(cart, nil)
+		mockOrderService.EXPECT().Create(gomock.Any(), orderReq).Times(1).Return(nil, errors.New("err db"))
+		mockCartRepo.EXPECT().Save(gomock.Any(), cart).Times(1).Return(nil)
+
+		res, err := cartService.Checkout(ctx, req, "abc")
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("empty cart", func(t *testing.T) {
+		cart := &model.Cart{Id: "abc"}
+		mockCartRepo.EXPECT().Claim(gomock.Any(), "abc").Times(1).Return(cart, nil)
+		mockCartRepo.EXPECT().Save(gomock.Any(), cart).Times(1).Return(nil)
+
+		res, err := cartService.Checkout(ctx, req, "abc")
+		assert.Equal(t, constant.ErrInvalidArgument, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("already checked out", func(t *testing.T) {
+		mockCartRepo.EXPECT().Claim(gomock.Any(), "abc").Times(1).Return(nil, nil)
+
+		res, err := cartService.Checkout(ctx, req, "abc")
+		assert.Equal(t, constant.ErrNotFound, err)
+		assert.Nil(t, res)
+	})
+}
+
+// editCart run the cart edit on the stored cart like the repository, the cart does not exist when nil
+func editCart(cart *model.Cart) func(context.Context, string, func(*model.Cart) error) (*model.Cart, error) {
+	return func(_ context.Context, _ string, edit func(*model.Cart) error) (*model.Cart, error) {
+		if cart == nil {
+			return nil, nil
+		}
+		if err := edit(cart); err != nil {
+			return nil, err
+		}
+		return cart, nil
+	}
+}
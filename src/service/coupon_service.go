@@ -0,0 +1,425 @@
+package service
+
+import (
+	"cake-store/src/config"
+	"cake-store/src/constant"
+	"cake-store/src/model"
+	"context"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+type couponService struct {
+	couponRepository   model.CouponRepository
+	categoryRepository model.TaxonomyRepository
+	cakeService        model.CakeService
+	storeRepository    model.StoreRepository
+	exchangeRate       model.ExchangeRateProvider
+}
+
+func NewCouponService(couponRepository model.CouponRepository, categoryRepository model.TaxonomyRepository, cakeService model.CakeService,
+	storeRepository model.StoreRepository, exchangeRate model.ExchangeRateProvider) model.CouponService {
+	return &couponService{
+		couponRepository:   couponRepository,
+		categoryRepository: categoryRepository,
+		cakeService:        cakeService,
+		storeRepository:    storeRepository,
+		exchangeRate:       exchangeRate,
+	}
+}
+
+func (c *couponService) Create(ctx context.Context, req model.CreateUpdateCouponRequest) (*model.Coupon, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Create Coupon Service",
+		"req":     req,
+	})
+
+	req.Code = strings.ToUpper(strings.TrimSpace(req.Code))
+	if err := req.Validate(); err != nil {
+		log.Error(err)
+		return nil, constant.HttpValidationOrInternalErr(err)
+	}
+
+	coupon := &model.Coupon{
+		Active:    true,
+		CreatedAt: time.Now(),
+	}
+	if err := c.fill(ctx, coupon, req); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	if err := c.couponRepository.Save(ctx, coupon); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return coupon, nil
+}
+
+func (c *couponService) Update(ctx context.Context, req model.CreateUpdateCouponRequest, couponId int) (*model.Coupon, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message":  "Update Coupon Service",
+		"req":      req,
+		"couponId": couponId,
+	})
+
+	coupon, err := c.FindById(ctx, couponId)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	req.Code = strings.ToUpper(strings.TrimSpace(req.Code))
+	if err = req.Validate(); err != nil {
+		log.Error(err)
+		return nil, constant.HttpValidationOrInternalErr(err)
+	}
+
+	if err = c.fill(ctx, coupon, req); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	if err = c.couponRepository.Update(ctx, coupon); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return coupon, nil
+}
+
+func (c *couponService) Delete(ctx context.Context, couponId int) (*model.Coupon, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message":  "Delete Coupon Service",
+		"couponId": couponId,
+	})
+
+	coupon, err := c.FindById(ctx, couponId)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	if err = c.couponRepository.Delete(ctx, coupon); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return coupon, nil
+}
+
+func (c *couponService) FindById(ctx context.Context, couponId int) (*model.Coupon, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message":  "Find By ID Coupon Service",
+		"couponId": couponId,
+	})
+
+	if couponId == 0 {
+		log.Error(constant.ErrInvalidArgument)
+		return nil, constant.ErrInvalidArgument
+	}
+
+	coupon, err := c.couponRepository.FindById(ctx, couponId)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	if coupon == nil {
+		log.Error(constant.ErrNotFound)
+		return nil, constant.ErrNotFound
+	}
+
+	if err = c.couponRepository.LoadUsage(ctx, []*model.Coupon{coupon}); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return coupon, nil
+}
+
+func (c *couponService) FindAll(ctx context.Context) ([]*model.Coupon, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Find All Coupon Service",
+	})
+
+	coupons, err := c.couponRepository.FindAll(ctx)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	if err = c.couponRepository.LoadUsage(ctx, coupons); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return coupons, nil
+}
+
+// Validate price the items at the store prices and apply the codes to them, each code explain why it is rejected
+func (c *couponService) Validate(ctx context.Context, req model.ValidateCouponRequest) (*model.Discount, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Validate Coupon Service",
+		"req":     req,
+	})
+
+	if err := req.Validate(); err != nil {
+		log.Error(err)
+		return nil, constant.HttpValidationOrInternalErr(err)
+	}
+
+	currency := config.BaseCurrency()
+	storeId := storeOrDefault(req.StoreId)
+	lines := make([]*model.DiscountLine, 0, len(req.Items))
+	for _, item := range req.Items {
+		_, variant, err := findVariant(ctx, c.cakeService, item.CakeId, item.VariantId)
+		if err != nil {
+			log.Error(err)
+			return nil, err
+		}
+
+		price, err := storePrice(ctx, c.storeRepository, storeId, variant)
+		if err != nil {
+			log.Error(err)
+			return nil, err
+		}
+
+		price, err = convertPrice(ctx, c.exchangeRate, price, currency)
+		if err != nil {
+			log.Error(err)
+			return nil, err
+		}
+
+		lines = append(lines, &model.DiscountLine{
+			CakeId:    item.CakeId,
+			Quantity:  item.Quantity,
+			UnitPrice: price,
+		})
+	}
+
+	discount, err := c.Apply(ctx, req.Codes, lines)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return discount, nil
+}
+
+// Apply apply the codes in order to the lines priced in the base currency. A rejected code does not discount,
+// a coupon that is not stackable is only applied alone, and the discount never exceed the subtotal
+func (c *couponService) Apply(ctx context.Context, codes []string, lines []*model.DiscountLine) (*model.Discount, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Apply Coupon Service",
+		"codes":   codes,
+	})
+
+	currency := config.BaseCurrency()
+	discount := &model.Discount{
+		Subtotal: model.NewMoney(0, currency),
+		Amount:   model.NewMoney(0, currency),
+		Coupons:  make([]*model.AppliedCoupon, 0, len(codes)),
+	}
+	for _, line := range lines {
+		discount.Subtotal.Amount += line.UnitPrice.Amount * int64(line.Quantity)
+	}
+
+	codes = normalizeCodes(codes)
+	coupons, err := c.couponRepository.FindByCodes(ctx, codes)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	if err = c.couponRepository.LoadUsage(ctx, coupons); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	byCode := make(map[string]*model.Coupon, len(coupons))
+	for _, coupon := range coupons {
+		byCode[coupon.Code] = coupon
+	}
+
+	now := time.Now()
+	seen := make(map[string]bool, len(codes))
+	var applied []*model.Coupon
+	for _, code := range codes {
+		result := &model.AppliedCoupon{
+			Code:     code,
+			Discount: model.NewMoney(0, currency),
+			Coupon:   byCode[code],
+		}
+		discount.Coupons = append(discount.Coupons, result)
+
+		coupon := result.Coupon
+		switch {
+		case seen[code]:
+			result.Reason = model.CouponReasonDuplicate
+		case coupon == nil:
+			result.Reason = model.CouponReasonNotFound
+		default:
+			result.Reason = coupon.Reject(now, discount.Subtotal.Amount)
+		}
+		seen[code] = true
+
+		if result.Reason == "" && len(applied) > 0 && (!coupon.Stackable || !applied[0].Stackable) {
+			result.Reason = model.CouponReasonNotStackable
+		}
+		if result.Reason != "" {
+			continue
+		}
+
+		eligible, err := c.eligibleLines(ctx, coupon, lines)
+		if err != nil {
+			log.Error(err)
+			return nil, err
+		}
+
+		amount := coupon.Discount(eligible)
+		if remaining := discount.Subtotal.Amount - discount.Amount.Amount; amount > remaining {
+			amount = remaining
+		}
+		if amount <= 0 {
+			result.Reason = model.CouponReasonNoItems
+			continue
+		}
+
+		result.Valid = true
+		result.Discount.Amount = amount
+		discount.Amount.Amount += amount
+		applied = append(applied, coupon)
+	}
+
+	discount.Total = model.NewMoney(discount.Subtotal.Amount-discount.Amount.Amount, currency)
+	return discount, nil
+}
+
+// Redeem count one use of each applied coupon, when a coupon reached its limit meanwhile
+// the uses already counted are given back and the coupon is rejected
+func (c *couponService) Redeem(ctx context.Context, discount *model.Discount) error {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Redeem Coupon Service",
+	})
+
+	redeemed := make([]int, 0, len(discount.Coupons))
+	for _, applied := range discount.Coupons {
+		if !applied.Valid {
+			continue
+		}
+
+		ok, err := c.couponRepository.Redeem(ctx, applied.Coupon)
+		if err == nil && !ok {
+			err = constant.CouponRejectedErr(applied.Code, model.CouponReasonUsedUp)
+		}
+		if err != nil {
+			log.Error(err)
+			if releaseErr := c.Release(ctx, redeemed...); releaseErr != nil {
+				log.Error(releaseErr)
+			}
+			return err
+		}
+		redeemed = append(redeemed, applied.Coupon.Id)
+	}
+
+	return nil
+}
+
+// Release give back one use of each coupon
+func (c *couponService) Release(ctx context.Context, couponIds ...int) error {
+	log := logrus.WithFields(logrus.Fields{
+		"message":   "Release Coupon Service",
+		"couponIds": couponIds,
+	})
+
+	for _, couponId := range couponIds {
+		if err := c.couponRepository.Release(ctx, couponId); err != nil {
+			log.Error(err)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// eligibleLines return the lines the coupon apply to, a category coupon only apply to the cakes of the category
+func (c *couponService) eligibleLines(ctx context.Context, coupon *model.Coupon, lines []*model.DiscountLine) ([]*model.DiscountLine, error) {
+	if coupon.CategoryId == 0 {
+		return lines, nil
+	}
+
+	cakeIds, err := c.categoryRepository.FindCakeIds(ctx, coupon.CategoryId)
+	if err != nil {
+		return nil, err
+	}
+
+	inCategory := make(map[int]bool, len(cakeIds))
+	for _, cakeId := range cakeIds {
+		inCategory[cakeId] = true
+	}
+
+	eligible := make([]*model.DiscountLine, 0, len(lines))
+	for _, line := range lines {
+		if inCategory[line.CakeId] {
+			eligible = append(eligible, line)
+		}
+	}
+	return eligible, nil
+}
+
+// fill set the coupon from the request, the value must make sense for the coupon type and the window must not be empty
+func (c *couponService) fill(ctx context.Context, coupon *model.Coupon, req model.CreateUpdateCouponRequest) error {
+	switch {
+	case req.Type == model.CouponTypePercentage && (req.Value < 1 || req.Value > 100):
+		return constant.ErrInvalidArgument
+	case req.Type == model.CouponTypeFixed && req.Value < 1:
+		return constant.ErrInvalidArgument
+	case req.StartsAt != nil && req.EndsAt != nil && !req.EndsAt.After(*req.StartsAt):
+		return constant.ErrInvalidArgument
+	}
+
+	if req.CategoryId != 0 {
+		category, err := c.categoryRepository.FindById(ctx, req.CategoryId)
+		if err != nil {
+			return err
+		}
+		if category == nil {
+			return constant.ErrNotFound
+		}
+	}
+
+	coupon.Code = req.Code
+	coupon.Type = req.Type
+	coupon.Value = req.Value
+	coupon.BuyQuantity = 0
+	coupon.GetQuantity = 0
+	if req.Type == model.CouponTypeBuyXGetY {
+		coupon.Value = 0
+		coupon.BuyQuantity = req.BuyQuantity
+		coupon.GetQuantity = req.GetQuantity
+	}
+	coupon.CategoryId = req.CategoryId
+	coupon.MinSubtotal = req.MinSubtotal
+	coupon.StartsAt = req.StartsAt
+	coupon.EndsAt = req.EndsAt
+	coupon.UsageLimit = req.UsageLimit
+	coupon.Stackable = req.Stackable
+	if req.Active != nil {
+		coupon.Active = *req.Active
+	}
+	coupon.UpdatedAt = time.Now()
+	return nil
+}
+
+// normalizeCodes return the coupon codes trimmed and upper cased, as they are stored
+func normalizeCodes(codes []string) []string {
+	normalized := make([]string, 0, len(codes))
+	for _, code := range codes {
+		normalized = append(normalized, strings.ToUpper(strings.TrimSpace(code)))
+	}
+	return normalized
+}
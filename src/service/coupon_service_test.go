@@ -0,0 +1,275 @@
+package service
+
+import (
+	"cake-store/src/constant"
+	"cake-store/src/model"
+	"cake-store/src/model/mock"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCouponService_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.TODO()
+	mockCouponRepo := mock.NewMockCouponRepository(ctrl)
+	mockCategoryRepo := mock.NewMockTaxonomyRepository(ctrl)
+
+	couponService := &couponService{
+		couponRepository:   mockCouponRepo,
+		categoryRepository: mockCategoryRepo,
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		mockCategoryRepo.EXPECT().FindById(gomock.Any(), 3).Times(1).Return(&model.Term{Id: 3}, nil)
+		mockCouponRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+
+		res, err := couponService.Create(ctx, model.CreateUpdateCouponRequest{Code: "b2g1", Type: model.CouponTypeBuyXGetY, BuyQuantity: 2, GetQuantity: 1, CategoryId: 3})
+		require.NoError(t, err)
+		assert.Equal(t, "B2G1", res.Code)
+		assert.True(t, res.Active)
+	})
+
+	t.Run("percentage above 100", func(t *testing.T) {
+		mockCouponRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Times(0)
+
+		res, err := couponService.Create(ctx, model.CreateUpdateCouponRequest{Code: "HEMAT", Type: model.CouponTypePercentage, Value: 150})
+		assert.Equal(t, constant.ErrInvalidArgument, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("empty window", func(t *testing.T) {
+		now := time.Now()
+
+		res, err := couponService.Create(ctx, model.CreateUpdateCouponRequest{Code: "HEMAT", Type: model.CouponTypeFixed, Value: 5000, StartsAt: &now, EndsAt: &now})
+		assert.Equal(t, constant.ErrInvalidArgument, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("buy x get y without quantities", func(t *testing.T) {
+		res, err := couponService.Create(ctx, model.CreateUpdateCouponRequest{Code: "B2G1", Type: model.CouponTypeBuyXGetY})
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("unknown category", func(t *testing.T) {
+		mockCategoryRepo.EXPECT().FindById(gomock.Any(), 9).Times(1).Return(nil, nil)
+
+		res, err := couponService.Create(ctx, model.CreateUpdateCouponRequest{Code: "HEMAT", Type: model.CouponTypeFixed, Value: 5000, CategoryId: 9})
+		assert.Equal(t, constant.ErrNotFound, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestCouponService_Apply(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.TODO()
+	mockCouponRepo := mock.NewMockCouponRepository(ctrl)
+	mockCategoryRepo := mock.NewMockTaxonomyRepository(ctrl)
+
+	couponService := &couponService{
+		couponRepository:   mockCouponRepo,
+		categoryRepository: mockCategoryRepo,
+	}
+
+	lines := []*model.DiscountLine{
+		{CakeId: 1, Quantity: 2, UnitPrice: model.NewMoney(250000, "IDR")},
+		{CakeId: 2, Quantity: 1, UnitPrice: model.NewMoney(100000, "IDR")},
+	}
+	past := time.Now().Add(-time.Hour)
+	percentage := &model.Coupon{Id: 7, Code: "HEMAT10", Type: model.CouponTypePercentage, Value: 10, Stackable: true, Active: true}
+	fixed := &model.Coupon{Id: 8, Code: "POTONG50", Type: model.CouponTypeFixed, Value: 50000, Stackable: true, Active: true}
+	solo := &model.Coupon{Id: 9, Code: "SOLO", Type: model.CouponTypeFixed, Value: 10000, Active: true}
+	expired := &model.Coupon{Id: 10, Code: "OLD", Type: model.CouponTypeFixed, Value: 10000, EndsAt: &past, Active: true}
+	category := &model.Coupon{Id: 11, Code: "BOLU", Type: model.CouponTypePercentage, Value: 50, CategoryId: 3, Stackable: true, Active: true}
+
+	expectCoupons := func(codes []string, coupons ...*model.Coupon) {
+		mockCouponRepo.EXPECT().FindByCodes(gomock.Any(), codes).Times(1).Return(coupons, nil)
+		mockCouponRepo.EXPECT().LoadUsage(gomock.Any(), coupons).Times(1).Return(nil)
+	}
+
+	t.Run("ok - stacked", func(t *testing.T) {
+		expectCoupons([]string{"HEMAT10", "POTONG50"}, percentage, fixed)
+
+		res, err := couponService.Apply(ctx, []string{"hemat10", " POTONG50 "}, lines)
+		require.NoError(t, err)
+		assert.Nil(t, res.Rejected())
+		assert.Equal(t, model.NewMoney(600000, "IDR"), res.Subtotal)
+		assert.Equal(t, model.NewMoney(110000, "IDR"), res.Amount)
+		assert.Equal(t, model.NewMoney(490000, "IDR"), res.Total)
+		assert.Equal(t, model.NewMoney(60000, "IDR"), res.Coupons[0].Discount)
+	})
+
+	t.Run("category scope", func(t *testing.T) {
+		expectCoupons([]string{"BOLU"}, category)
+		mockCategoryRepo.EXPECT().FindCakeIds(gomock.Any(), 3).Times(1).Return([]int{2}, nil)
+
+		res, err := couponService.Apply(ctx, []string{"BOLU"}, lines)
+		require.NoError(t, err)
+		assert.Equal(t, model.NewMoney(50000, "IDR"), res.Amount)
+	})
+
+	t.Run("category without items", func(t *testing.T) {
+		expectCoupons([]string{"BOLU"}, category)
+		mockCategoryRepo.EXPECT().FindCakeIds(gomock.Any(), 3).Times(1).Return([]int{5}, nil)
+
+		res, err := couponService.Apply(ctx, []string{"BOLU"}, lines)
+		require.NoError(t, err)
+		assert.Equal(t, model.CouponReasonNoItems, res.Rejected().Reason)
+		assert.Equal(t, model.NewMoney(600000, "IDR"), res.Total)
+	})
+
+	t.Run("rejection reasons", func(t *testing.T) {
+		expectCoupons([]string{"HEMAT10", "SOLO", "OLD", "NOPE", "HEMAT10"}, percentage, solo, expired)
+
+		res, err := couponService.Apply(ctx, []string{"HEMAT10", "SOLO", "OLD", "NOPE", "HEMAT10"}, lines)
+		require.NoError(t, err)
+		require.Len(t, res.Coupons, 5)
+		assert.True(t, res.Coupons[0].Valid)
+		assert.Equal(t, model.CouponReasonNotStackable, res.Coupons[1].Reason)
+		assert.Equal(t, model.CouponReasonExpired, res.Coupons[2].Reason)
+		assert.Equal(t, model.CouponReasonNotFound, res.Coupons[3].Reason)
+		assert.Equal(t, model.CouponReasonDuplicate, res.Coupons[4].Reason)
+		assert.Equal(t, model.NewMoney(60000, "IDR"), res.Amount)
+	})
+
+	t.Run("first coupon not stackable", func(t *testing.T) {
+		expectCoupons([]string{"SOLO", "HEMAT10"}, solo, percentage)
+
+		res, err := couponService.Apply(ctx, []string{"SOLO", "HEMAT10"}, lines)
+		require.NoError(t, err)
+		assert.True(t, res.Coupons[0].Valid)
+		assert.Equal(t, model.CouponReasonNotStackable, res.Coupons[1].Reason)
+	})
+}
+
+func TestCouponService_Redeem(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.TODO()
+	mockCouponRepo := mock.NewMockCouponRepository(ctrl)
+
+	couponService := &couponService{
+		couponRepository: mockCouponRepo,
+	}
+
+	first := &model.Coupon{Id: 7, Code: "HEMAT10", UsageLimit: 10}
+	second := &model.Coupon{Id: 8, Code: "POTONG50", UsageLimit: 1}
+	discount := &model.Discount{Coupons: []*model.AppliedCoupon{
+		{Code: "HEMAT10", Valid: true, Coupon: first},
+		{Code: "NOPE", Reason: model.CouponReasonNotFound},
+		{Code: "POTONG50", Valid: true, Coupon: second},
+	}}
+
+	t.Run("ok", func(t *testing.T) {
+		mockCouponRepo.EXPECT().Redeem(gomock.Any(), first).Times(1).Return(true, nil)
+		mockCouponRepo.EXPECT().Redeem(gomock.Any(), second).Times(1).Return(true, nil)
+
+		require.NoError(t, couponService.Redeem(ctx, discount))
+	})
+
+	t.Run("used up meanwhile", func(t *testing.T) {
+		mockCouponRepo.EXPECT().Redeem(gomock.Any(), first).Times(1).Return(true, nil)
+		mockCouponRepo.EXPECT().Redeem(gomock.Any(), second).Times(1).Return(false, nil)
+		mockCouponRepo.EXPECT().Release(gomock.Any(), 7).Times(1).Return(nil)
+
+		err := couponService.Redeem(ctx, discount)
+		assert.Equal(t, constant.CouponRejectedErr("POTONG50", model.CouponReasonUsedUp), err)
+	})
+
+	t.Run("redis error", func(t *testing.T) {
+		mockCouponRepo.EXPECT().Redeem(gomock.Any(), first).Times(1).Return(false, errors.New("err redis"))
+
+		err := couponService.Redeem(ctx, discount)
+		assert.Error(t, err)
+	})
+}
+
+func TestCouponService_Validate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.TODO()
+	mockCouponRepo := mock.NewMockCouponRepository(ctrl)
+	mockCakeService := mock.NewMockCakeService(ctrl)
+	mockStoreRepo := mock.NewMockStoreRepository(ctrl)
+
+	couponService := &couponService{
+		couponRepository: mockCouponRepo,
+		cakeService:      mockCakeService,
+		storeRepository:  mockStoreRepo,
+	}
+
+	cake := &model.Cake{Id: 1, Variants: []*model.Variant{{Id: 5, CakeId: 1, Price: model.NewMoney(250000, "IDR"), Active: true}}}
+	req := model.ValidateCouponRequest{
+		Codes: []string{"HEMAT10"},
+		Items: []model.CreateOrderItemRequest{{CakeId: 1, VariantId: 5, Quantity: 2}},
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		coupons := []*model.Coupon{{Id: 7, Code: "HEMAT10", Type: model.CouponTypePercentage, Value: 10, MinSubtotal: 1000000, Active: true}}
+		mockCakeService.EXPECT().FindById(gomock.Any(), 1).Times(1).Return(cake, nil)
+		mockStoreRepo.EXPECT().FindCake(gomock.Any(), 1, 1).Times(1).Return(nil, nil)
+		mockCouponRepo.EXPECT().FindByCodes(gomock.Any(), []string{"HEMAT10"}).Times(1).Return(coupons, nil)
+		mockCouponRepo.EXPECT().LoadUsage(gomock.Any(), coupons).Times(1).Return(nil)
+
+		res, err := couponService.Validate(ctx, req)
+		require.NoError(t, err)
+		assert.False(t, res.Coupons[0].Valid)
+		assert.Equal(t, model.CouponReasonMinSubtotal, res.Coupons[0].Reason)
+	})
+
+	t.Run("ok - minimum met at the store price", func(t *testing.T) {
+		req := req
+		req.StoreId = 2
+		coupons := []*model.Coupon{{Id: 7, Code: "HEMAT10", Type: model.CouponTypePercentage, Value: 10, MinSubtotal: 1000000, Active: true}}
+		storeCake := &model.StoreCake{StoreId: 2, CakeId: 1, Available: true,
+			Prices: []*model.StorePrice{{VariantId: 5, Price: model.NewMoney(500000, "IDR")}}}
+		mockCakeService.EXPECT().FindById(gomock.Any(), 1).Times(1).Return(cake, nil)
+		mockStoreRepo.EXPECT().FindCake(gomock.Any(), 2, 1).Times(1).Return(storeCake, nil)
+		mockCouponRepo.EXPECT().FindByCodes(gomock.Any(), []string{"HEMAT10"}).Times(1).Return(coupons, nil)
+		mockCouponRepo.EXPECT().LoadUsage(gomock.Any(), coupons).Times(1).Return(nil)
+
+		res, err := couponService.Validate(ctx, req)
+		require.NoError(t, err)
+		assert.True(t, res.Coupons[0].Valid)
+		assert.Equal(t, model.NewMoney(1000000, "IDR"), res.Subtotal)
+	})
+
+	t.Run("not sold in the store", func(t *testing.T) {
+		req := req
+		req.StoreId = 2
+		mockCakeService.EXPECT().FindById(gomock.Any(), 1).Times(1).Return(cake, nil)
+		mockStoreRepo.EXPECT().FindCake(gomock.Any(), 2, 1).Times(1).Return(&model.StoreCake{StoreId: 2, CakeId: 1}, nil)
+
+		res, err := couponService.Validate(ctx, req)
+		assert.Equal(t, constant.ErrCakeUnavailable, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("unknown variant", func(t *testing.T) {
+		req := req
+		req.Items = []model.CreateOrderItemRequest{{CakeId: 1, VariantId: 6, Quantity: 1}}
+		mockCakeService.EXPECT().FindById(gomock.Any(), 1).Times(1).Return(cake, nil)
+
+		res, err := couponService.Validate(ctx, req)
+		assert.Equal(t, constant.ErrNotFound, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("validate error", func(t *testing.T) {
+		res, err := couponService.Validate(ctx, model.ValidateCouponRequest{Items: req.Items})
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+}
@@ -0,0 +1,340 @@
+package service
+
+import (
+	"cake-store/src/config"
+	"cake-store/src/constant"
+	"cake-store/src/model"
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+type ingredientService struct {
+	ingredientRepository model.IngredientRepository
+	cakeRepository       model.CakeRepository
+}
+
+func NewIngredientService(ingredientRepository model.IngredientRepository, cakeRepository model.CakeRepository) model.IngredientService {
+	return &ingredientService{
+		ingredientRepository: ingredientRepository,
+		cakeRepository:       cakeRepository,
+	}
+}
+
+func (i *ingredientService) Create(ctx context.Context, req model.CreateUpdateIngredientRequest) (*model.Ingredient, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Create Ingredient Service",
+		"req":     req,
+	})
+
+	normalizeIngredient(&req)
+	if err := req.Validate(); err != nil {
+		log.Error(err)
+		return nil, constant.HttpValidationOrInternalErr(err)
+	}
+
+	ingredient := &model.Ingredient{
+		Name:      req.Name,
+		Allergens: model.SortAllergens(req.Allergens),
+		Density:   req.Density,
+		Nutrition: req.Nutrition,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	if err := i.ingredientRepository.Save(ctx, ingredient); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return ingredient, nil
+}
+
+// Update change the ingredient, the allergens and nutrition of every cake listing it change with it
+func (i *ingredientService) Update(ctx context.Context, req model.CreateUpdateIngredientRequest, ingredientId int) (*model.Ingredient, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message":      "Update Ingredient Service",
+		"req":          req,
+		"ingredientId": ingredientId,
+	})
+
+	ingredient, err := i.FindById(ctx, ingredientId)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	normalizeIngredient(&req)
+	if err := req.Validate(); err != nil {
+		log.Error(err)
+		return nil, constant.HttpValidationOrInternalErr(err)
+	}
+
+	ingredient.Name = req.Name
+	ingredient.Allergens = model.SortAllergens(req.Allergens)
+	ingredient.Density = req.Density
+	ingredient.Nutrition = req.Nutrition
+	ingredient.UpdatedAt = time.Now()
+
+	if err = i.ingredientRepository.Update(ctx, ingredient); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	cakeIds, err := i.ingredientRepository.FindCakeIds(ctx, ingredientId)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	if err = i.cakeRepository.Touch(ctx, cakeIds...); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return ingredient, nil
+}
+
+// Delete remove an ingredient no cake list anymore
+func (i *ingredientService) Delete(ctx context.Context, ingredientId int) (*model.Ingredient, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message":      "Delete Ingredient Service",
+		"ingredientId": ingredientId,
+	})
+
+	ingredient, err := i.FindById(ctx, ingredientId)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	cakeIds, err := i.ingredientRepository.FindCakeIds(ctx, ingredientId)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	if len(cakeIds) > 0 {
+		log.Error(constant.ErrInUse)
+		return nil, constant.ErrInUse
+	}
+
+	if err = i.ingredientRepository.Delete(ctx, ingredient); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return ingredient, nil
+}
+
+func (i *ingredientService) FindById(ctx context.Context, ingredientId int) (*model.Ingredient, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message":      "Find By ID Ingredient Service",
+		"ingredientId": ingredientId,
+	})
+
+	if ingredientId == 0 {
+		log.Error(constant.ErrInvalidArgument)
+		return nil, constant.ErrInvalidArgument
+	}
+
+	ingredient, err := i.ingredientRepository.FindById(ctx, ingredientId)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	if ingredient == nil {
+		log.Error(constant.ErrNotFound)
+		return nil, constant.ErrNotFound
+	}
+
+	return ingredient, nil
+}
+
+func (i *ingredientService) FindAll(ctx context.Context) ([]*model.Ingredient, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Find All Ingredient Service",
+	})
+
+	ingredients, err := i.ingredientRepository.FindAll(ctx)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return ingredients, nil
+}
+
+// SetCakeIngredients replace the ingredient list of the cake and return the cake with its new summaries
+func (i *ingredientService) SetCakeIngredients(ctx context.Context, req model.SetCakeIngredientsRequest, cakeId int) (*model.Cake, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Set Cake Ingredients Ingredient Service",
+		"req":     req,
+		"cakeId":  cakeId,
+	})
+
+	if err := req.Validate(); err != nil {
+		log.Error(err)
+		return nil, constant.HttpValidationOrInternalErr(err)
+	}
+
+	ids, ok := req.IngredientIds()
+	if !ok {
+		log.Error(constant.ErrInvalidArgument)
+		return nil, constant.ErrInvalidArgument
+	}
+
+	cake, err := i.cakeRepository.FindById(ctx, cakeId)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	if cake == nil {
+		log.Error(constant.ErrNotFound)
+		return nil, constant.ErrNotFound
+	}
+
+	found, err := i.ingredientRepository.FindByIds(ctx, ids)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	if len(found) != len(ids) {
+		log.Error(constant.ErrInvalidArgument)
+		return nil, constant.ErrInvalidArgument
+	}
+
+	byId := make(map[int]*model.Ingredient, len(found))
+	for _, ingredient := range found {
+		byId[ingredient.Id] = ingredient
+	}
+
+	ingredients := make([]*model.CakeIngredient, 0, len(req.Ingredients))
+	for _, item := range req.Ingredients {
+		ingredient := byId[item.IngredientId]
+		ingredients = append(ingredients, &model.CakeIngredient{
+			IngredientId: ingredient.Id,
+			Name:         ingredient.Name,
+			Quantity:     item.Quantity,
+			Allergens:    ingredient.Allergens,
+			Nutrition:    ingredient.Nutrition,
+		})
+	}
+
+	if err = i.ingredientRepository.SetCakeIngredients(ctx, cakeId, ingredients); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	if err = i.cakeRepository.Touch(ctx, cakeId); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	// read the cake again for the version bumped by the touch
+	cake, err = i.cakeRepository.FindById(ctx, cakeId)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	if cake == nil {
+		log.Error(constant.ErrNotFound)
+		return nil, constant.ErrNotFound
+	}
+
+	sort.SliceStable(ingredients, func(a, b int) bool {
+		return ingredients[a].Quantity > ingredients[b].Quantity
+	})
+	cake.SetIngredients(ingredients)
+	return cake, nil
+}
+
+// FindPrices find the supplier price list of the ingredient
+func (i *ingredientService) FindPrices(ctx context.Context, ingredientId int) ([]*model.SupplierPrice, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message":      "Find Prices Ingredient Service",
+		"ingredientId": ingredientId,
+	})
+
+	if _, err := i.FindById(ctx, ingredientId); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	prices, err := i.ingredientRepository.FindPrices(ctx, []int{ingredientId})
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return prices, nil
+}
+
+// SetPrices replace the supplier price list of the ingredient, each supplier is listed once
+func (i *ingredientService) SetPrices(ctx context.Context, req model.SetSupplierPricesRequest, ingredientId int) ([]*model.SupplierPrice, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message":      "Set Prices Ingredient Service",
+		"req":          req,
+		"ingredientId": ingredientId,
+	})
+
+	seen := make(map[string]bool, len(req.Prices))
+	for idx := range req.Prices {
+		price := &req.Prices[idx]
+		price.Supplier = strings.TrimSpace(price.Supplier)
+		price.Unit = strings.ToLower(strings.TrimSpace(price.Unit))
+		if price.Price.Currency == "" {
+			price.Price.Currency = config.BaseCurrency()
+		}
+
+		supplier := strings.ToLower(price.Supplier)
+		if seen[supplier] {
+			log.Error(constant.ErrInvalidArgument)
+			return nil, constant.ErrInvalidArgument
+		}
+		seen[supplier] = true
+	}
+
+	if err := req.Validate(); err != nil {
+		log.Error(err)
+		return nil, constant.HttpValidationOrInternalErr(err)
+	}
+
+	if _, err := i.FindById(ctx, ingredientId); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	prices := make([]*model.SupplierPrice, 0, len(req.Prices))
+	for _, price := range req.Prices {
+		prices = append(prices, &model.SupplierPrice{
+			IngredientId: ingredientId,
+			Supplier:     price.Supplier,
+			Price:        price.Price,
+			Quantity:     price.Quantity,
+			Unit:         price.Unit,
+			UpdatedAt:    time.Now(),
+		})
+	}
+
+	if err := i.ingredientRepository.SetPrices(ctx, ingredientId, prices); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return prices, nil
+}
+
+func normalizeIngredient(req *model.CreateUpdateIngredientRequest) {
+	req.Name = strings.TrimSpace(req.Name)
+	for idx, allergen := range req.Allergens {
+		req.Allergens[idx] = strings.ToLower(strings.TrimSpace(allergen))
+	}
+}
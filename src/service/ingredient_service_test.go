@@ -0,0 +1,343 @@
+package service
+
+import (
+	"cake-store/src/constant"
+	"cake-store/src/model"
+	"cake-store/src/model/mock"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIngredientService_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.TODO()
+	mockIngredientRepo := mock.NewMockIngredientRepository(ctrl)
+
+	ingredientService := &ingredientService{
+		ingredientRepository: mockIngredientRepo,
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		req := model.CreateUpdateIngredientRequest{
+			Name:      " Milk Chocolate ",
+			Allergens: []string{"Dairy", "soy", "dairy"},
+			Nutrition: model.NutritionFacts{EnergyKcal: 535, Fat: 30, Carbohydrate: 59, Sugar: 52, Protein: 8, Salt: 0.2},
+		}
+
+		mockIngredientRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+		res, err := ingredientService.Create(ctx, req)
+		assert.NoError(t, err)
+		assert.Equal(t, "Milk Chocolate", res.Name)
+		assert.Equal(t, []string{model.AllergenSoy, model.AllergenDairy}, res.Allergens)
+	})
+
+	t.Run("unknown allergen", func(t *testing.T) {
+		req := model.CreateUpdateIngredientRequest{Name: "Cocoa", Allergens: []string{"chocolate"}}
+
+		mockIngredientRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Times(0)
+		res, err := ingredientService.Create(ctx, req)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("more sugar than carbohydrate", func(t *testing.T) {
+		req := model.CreateUpdateIngredientRequest{Name: "Sugar", Nutrition: model.NutritionFacts{Carbohydrate: 10, Sugar: 20}}
+
+		mockIngredientRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Times(0)
+		res, err := ingredientService.Create(ctx, req)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("error from repo", func(t *testing.T) {
+		req := model.CreateUpdateIngredientRequest{Name: "Butter", Allergens: []string{"dairy"}}
+
+		mockIngredientRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Times(1).Return(constant.ErrAlreadyExists)
+		res, err := ingredientService.Create(ctx, req)
+		assert.Equal(t, constant.ErrAlreadyExists, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestIngredientService_Update(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.TODO()
+	mockIngredientRepo := mock.NewMockIngredientRepository(ctrl)
+	mockCakeRepo := mock.NewMockCakeRepository(ctrl)
+
+	ingredientService := &ingredientService{
+		ingredientRepository: mockIngredientRepo,
+		cakeRepository:       mockCakeRepo,
+	}
+
+	ingredient := &model.Ingredient{
+		Id:        1,
+		Name:      "Butter",
+		Allergens: []string{model.AllergenDairy},
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		req := model.CreateUpdateIngredientRequest{Name: "Salted Butter", Allergens: []string{"dairy"}}
+
+		mockIngredientRepo.EXPECT().FindById(gomock.Any(), ingredient.Id).Times(1).Return(ingredient, nil)
+		mockIngredientRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+		mockIngredientRepo.EXPECT().FindCakeIds(gomock.Any(), ingredient.Id).Times(1).Return([]int{2, 5}, nil)
+		mockCakeRepo.EXPECT().Touch(gomock.Any(), 2, 5).Times(1).Return(nil)
+
+		res, err := ingredientService.Update(ctx, req, ingredient.Id)
+		assert.NoError(t, err)
+		assert.Equal(t, "Salted Butter", res.Name)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mockIngredientRepo.EXPECT().FindById(gomock.Any(), 2).Times(1).Return(nil, nil)
+
+		res, err := ingredientService.Update(ctx, model.CreateUpdateIngredientRequest{Name: "Butter"}, 2)
+		assert.Equal(t, constant.ErrNotFound, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("error from repo", func(t *testing.T) {
+		mockIngredientRepo.EXPECT().FindById(gomock.Any(), ingredient.Id).Times(1).Return(ingredient, nil)
+		mockIngredientRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Times(1).Return(errors.New("db error"))
+
+		res, err := ingredientService.Update(ctx, model.CreateUpdateIngredientRequest{Name: "Butter"}, ingredient.Id)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestIngredientService_Delete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.TODO()
+	mockIngredientRepo := mock.NewMockIngredientRepository(ctrl)
+
+	ingredientService := &ingredientService{
+		ingredientRepository: mockIngredientRepo,
+	}
+
+	ingredient := &model.Ingredient{Id: 1, Name: "Butter"}
+
+	t.Run("ok", func(t *testing.T) {
+		mockIngredientRepo.EXPECT().FindById(gomock.Any(), ingredient.Id).Times(1).Return(ingredient, nil)
+		mockIngredientRepo.EXPECT().FindCakeIds(gomock.Any(), ingredient.Id).Times(1).Return([]int{}, nil)
+		mockIngredientRepo.EXPECT().Delete(gomock.Any(), ingredient).Times(1).Return(nil)
+
+		res, err := ingredientService.Delete(ctx, ingredient.Id)
+		assert.NoError(t, err)
+		assert.Equal(t, ingredient, res)
+	})
+
+	t.Run("still in use", func(t *testing.T) {
+		mockIngredientRepo.EXPECT().FindById(gomock.Any(), ingredient.Id).Times(1).Return(ingredient, nil)
+		mockIngredientRepo.EXPECT().FindCakeIds(gomock.Any(), ingredient.Id).Times(1).Return([]int{3}, nil)
+		mockIngredientRepo.EXPECT().Delete(gomock.Any(), gomock.Any()).Times(0)
+
+		res, err := ingredientService.Delete(ctx, ingredient.Id)
+		assert.Equal(t, constant.ErrInUse, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("invalid id", func(t *testing.T) {
+		res, err := ingredientService.Delete(ctx, 0)
+		assert.Equal(t, constant.ErrInvalidArgument, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestIngredientService_FindAll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.TODO()
+	mockIngredientRepo := mock.NewMockIngredientRepository(ctrl)
+
+	ingredientService := &ingredientService{
+		ingredientRepository: mockIngredientRepo,
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		mockIngredientRepo.EXPECT().FindAll(gomock.Any()).Times(1).Return([]*model.Ingredient{{Id: 1}, {Id: 2}}, nil)
+
+		res, err := ingredientService.FindAll(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, len(res))
+	})
+
+	t.Run("error from repo", func(t *testing.T) {
+		mockIngredientRepo.EXPECT().FindAll(gomock.Any()).Times(1).Return(nil, errors.New("db error"))
+
+		res, err := ingredientService.FindAll(ctx)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestIngredientService_SetCakeIngredients(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.TODO()
+	mockIngredientRepo := mock.NewMockIngredientRepository(ctrl)
+	mockCakeRepo := mock.NewMockCakeRepository(ctrl)
+
+	ingredientService := &ingredientService{
+		ingredientRepository: mockIngredientRepo,
+		cakeRepository:       mockCakeRepo,
+	}
+
+	cake := &model.Cake{Id: 1, Title: "Brownies", Version: 1}
+	ingredients := []*model.Ingredient{
+		{Id: 2, Name: "Butter", Allergens: []string{model.AllergenDairy}, Nutrition: model.NutritionFacts{EnergyKcal: 700}},
+		{Id: 3, Name: "Flour", Allergens: []string{model.AllergenGluten}, Nutrition: model.NutritionFacts{EnergyKcal: 360}},
+	}
+	req := model.SetCakeIngredientsRequest{Ingredients: []model.CakeIngredientRequest{
+		{IngredientId: 2, Quantity: 100},
+		{IngredientId: 3, Quantity: 300},
+	}}
+
+	t.Run("ok", func(t *testing.T) {
+		mockCakeRepo.EXPECT().FindById(gomock.Any(), cake.Id).Times(1).Return(cake, nil)
+		mockIngredientRepo.EXPECT().FindByIds(gomock.Any(), []int{2, 3}).Times(1).Return(ingredients, nil)
+		mockIngredientRepo.EXPECT().SetCakeIngredients(gomock.Any(), cake.Id, gomock.Len(2)).Times(1).Return(nil)
+		mockCakeRepo.EXPECT().Touch(gomock.Any(), cake.Id).Times(1).Return(nil)
+		mockCakeRepo.EXPECT().FindById(gomock.Any(), cake.Id).Times(1).Return(&model.Cake{Id: 1, Title: "Brownies", Version: 2}, nil)
+
+		res, err := ingredientService.SetCakeIngredients(ctx, req, cake.Id)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, res.Version)
+		assert.Equal(t, "Flour", res.Ingredients[0].Name)
+		assert.Equal(t, []string{model.AllergenGluten, model.AllergenDairy}, res.Allergens)
+		assert.Equal(t, 1780.0, res.Nutrition.Total.EnergyKcal)
+	})
+
+	t.Run("cake not found", func(t *testing.T) {
+		mockCakeRepo.EXPECT().FindById(gomock.Any(), 9).Times(1).Return(nil, nil)
+
+		res, err := ingredientService.SetCakeIngredients(ctx, req, 9)
+		assert.Equal(t, constant.ErrNotFound, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("unknown ingredient", func(t *testing.T) {
+		mockCakeRepo.EXPECT().FindById(gomock.Any(), cake.Id).Times(1).Return(cake, nil)
+		mockIngredientRepo.EXPECT().FindByIds(gomock.Any(), []int{2, 3}).Times(1).Return(ingredients[:1], nil)
+		mockIngredientRepo.EXPECT().SetCakeIngredients(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		res, err := ingredientService.SetCakeIngredients(ctx, req, cake.Id)
+		assert.Equal(t, constant.ErrInvalidArgument, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("duplicate ingredient", func(t *testing.T) {
+		req := model.SetCakeIngredientsRequest{Ingredients: []model.CakeIngredientRequest{
+			{IngredientId: 2, Quantity: 100},
+			{IngredientId: 2, Quantity: 50},
+		}}
+
+		res, err := ingredientService.SetCakeIngredients(ctx, req, cake.Id)
+		assert.Equal(t, constant.ErrInvalidArgument, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("validate error", func(t *testing.T) {
+		req := model.SetCakeIngredientsRequest{Ingredients: []model.CakeIngredientRequest{{IngredientId: 2}}}
+
+		res, err := ingredientService.SetCakeIngredients(ctx, req, cake.Id)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestIngredientService_SetPrices(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.TODO()
+	mockIngredientRepo := mock.NewMockIngredientRepository(ctrl)
+
+	ingredientService := &ingredientService{
+		ingredientRepository: mockIngredientRepo,
+	}
+
+	ingredient := &model.Ingredient{Id: 2, Name: "Flour"}
+
+	t.Run("ok", func(t *testing.T) {
+		req := model.SetSupplierPricesRequest{Prices: []model.SupplierPriceRequest{
+			{Supplier: " Toko A ", Price: model.Money{Amount: 1500000}, Quantity: 1, Unit: "KG"},
+			{Supplier: "Toko B", Price: model.NewMoney(100, "usd"), Quantity: 500, Unit: "g"},
+		}}
+
+		mockIngredientRepo.EXPECT().FindById(gomock.Any(), ingredient.Id).Times(1).Return(ingredient, nil)
+		mockIngredientRepo.EXPECT().SetPrices(gomock.Any(), ingredient.Id, gomock.Len(2)).Times(1).Return(nil)
+
+		res, err := ingredientService.SetPrices(ctx, req, ingredient.Id)
+		assert.NoError(t, err)
+		assert.Equal(t, "Toko A", res[0].Supplier)
+		assert.Equal(t, model.UnitKilogram, res[0].Unit)
+		assert.Equal(t, model.NewMoney(1500000, "IDR"), res[0].Price)
+	})
+
+	t.Run("duplicate supplier", func(t *testing.T) {
+		req := model.SetSupplierPricesRequest{Prices: []model.SupplierPriceRequest{
+			{Supplier: "Toko A", Price: model.NewMoney(1500000, "IDR"), Quantity: 1, Unit: "kg"},
+			{Supplier: "toko a", Price: model.NewMoney(700000, "IDR"), Quantity: 500, Unit: "g"},
+		}}
+
+		res, err := ingredientService.SetPrices(ctx, req, ingredient.Id)
+		assert.Equal(t, constant.ErrInvalidArgument, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("unknown unit", func(t *testing.T) {
+		req := model.SetSupplierPricesRequest{Prices: []model.SupplierPriceRequest{
+			{Supplier: "Toko A", Price: model.NewMoney(1500000, "IDR"), Quantity: 1, Unit: "lb"},
+		}}
+
+		res, err := ingredientService.SetPrices(ctx, req, ingredient.Id)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("ingredient not found", func(t *testing.T) {
+		mockIngredientRepo.EXPECT().FindById(gomock.Any(), 9).Times(1).Return(nil, nil)
+
+		res, err := ingredientService.SetPrices(ctx, model.SetSupplierPricesRequest{}, 9)
+		assert.Equal(t, constant.ErrNotFound, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestIngredientService_FindPrices(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.TODO()
+	mockIngredientRepo := mock.NewMockIngredientRepository(ctrl)
+
+	ingredientService := &ingredientService{
+		ingredientRepository: mockIngredientRepo,
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		mockIngredientRepo.EXPECT().FindById(gomock.Any(), 2).Times(1).Return(&model.Ingredient{Id: 2}, nil)
+		mockIngredientRepo.EXPECT().FindPrices(gomock.Any(), []int{2}).Times(1).Return([]*model.SupplierPrice{{Id: 1}}, nil)
+
+		res, err := ingredientService.FindPrices(ctx, 2)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(res))
+	})
+}
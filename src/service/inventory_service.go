@@ -0,0 +1,557 @@
+package service
+
+import (
+	"cake-store/src/config"
+	"cake-store/src/constant"
+	"cake-store/src/model"
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+type inventoryService struct {
+	inventoryRepository  model.InventoryRepository
+	ingredientRepository model.IngredientRepository
+	recipeRepository     model.RecipeRepository
+	variantRepository    model.VariantRepository
+	exchangeRate         model.ExchangeRateProvider
+}
+
+func NewInventoryService(inventoryRepository model.InventoryRepository, ingredientRepository model.IngredientRepository, recipeRepository model.RecipeRepository,
+	variantRepository model.VariantRepository, exchangeRate model.ExchangeRateProvider) model.InventoryService {
+	return &inventoryService{
+		inventoryRepository:  inventoryRepository,
+		ingredientRepository: ingredientRepository,
+		recipeRepository:     recipeRepository,
+		variantRepository:    variantRepository,
+		exchangeRate:         exchangeRate,
+	}
+}
+
+func (i *inventoryService) FindAll(ctx context.Context) ([]*model.IngredientStock, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Find All Inventory Service",
+	})
+
+	stocks, err := i.inventoryRepository.FindAll(ctx)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return stocks, nil
+}
+
+func (i *inventoryService) FindLow(ctx context.Context) ([]*model.IngredientStock, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Find Low Inventory Service",
+	})
+
+	stocks, err := i.inventoryRepository.FindLow(ctx)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return stocks, nil
+}
+
+// Adjust record a manual change of the on hand quantity, the delta is converted to the stock unit. An ingredient
+// without stock start to be counted in the unit of the delta
+func (i *inventoryService) Adjust(ctx context.Context, req model.AdjustInventoryRequest, ingredientId int) (*model.IngredientStock, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message":      "Adjust Inventory Service",
+		"req":          req,
+		"ingredientId": ingredientId,
+	})
+
+	req.Unit = strings.ToLower(strings.TrimSpace(req.Unit))
+	if err := req.Validate(); err != nil {
+		log.Error(err)
+		return nil, constant.HttpValidationOrInternalErr(err)
+	}
+
+	ingredient, stock, err := i.findStock(ctx, ingredientId)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	unit, delta := req.Unit, req.Delta
+	switch {
+	case stock != nil:
+		unit = stock.Unit
+		if req.Unit != "" {
+			converted, ok := model.ConvertQuantity(req.Delta, req.Unit, unit, ingredient.Density)
+			if !ok {
+				log.Error(constant.ErrInvalidArgument)
+				return nil, constant.ErrInvalidArgument
+			}
+			delta = converted
+		}
+	case unit == "":
+		unit = model.UnitGram
+	}
+
+	stock, err = i.inventoryRepository.Adjust(ctx, &model.InventoryAdjustment{
+		IngredientId: ingredientId,
+		Delta:        roundQuantity(delta),
+		Unit:         unit,
+		Reason:       req.Reason,
+		Note:         req.Note,
+		CreatedAt:    time.Now(),
+	})
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return stock, nil
+}
+
+// SetLevels set the unit and reorder levels of the ingredient, the on hand quantity is converted to the new unit
+func (i *inventoryService) SetLevels(ctx context.Context, req model.SetInventoryLevelsRequest, ingredientId int) (*model.IngredientStock, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message":      "Set Levels Inventory Service",
+		"req":          req,
+		"ingredientId": ingredientId,
+	})
+
+	req.Unit = strings.ToLower(strings.TrimSpace(req.Unit))
+	if err := req.Validate(); err != nil {
+		log.Error(err)
+		return nil, constant.HttpValidationOrInternalErr(err)
+	}
+
+	ingredient, stock, err := i.findStock(ctx, ingredientId)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	onHand := float64(0)
+	if stock != nil {
+		converted, ok := model.ConvertQuantity(stock.OnHand, stock.Unit, req.Unit, ingredient.Density)
+		if !ok {
+			log.Error(constant.ErrInvalidArgument)
+			return nil, constant.ErrInvalidArgument
+		}
+		onHand = roundQuantity(converted)
+	}
+
+	stock = &model.IngredientStock{
+		IngredientId:    ingredientId,
+		Name:            ingredient.Name,
+		OnHand:          onHand,
+		Unit:            req.Unit,
+		ReorderPoint:    req.ReorderPoint,
+		ReorderQuantity: req.ReorderQuantity,
+		UpdatedAt:       time.Now(),
+	}
+	stock.SetLowStock()
+
+	if err = i.inventoryRepository.SetLevels(ctx, stock); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return stock, nil
+}
+
+func (i *inventoryService) FindAdjustments(ctx context.Context, ingredientId int) ([]*model.InventoryAdjustment, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message":      "Find Adjustments Inventory Service",
+		"ingredientId": ingredientId,
+	})
+
+	if _, _, err := i.findStock(ctx, ingredientId); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	adjustments, err := i.inventoryRepository.FindAdjustments(ctx, ingredientId, stockAdjustmentsLimit)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return adjustments, nil
+}
+
+// Consume deduct the ingredients the order use, each item use the recipe of its cake scaled to the variant servings.
+// Items without recipe, and recipe quantities that do not convert to the stock unit, are skipped. The adjustments
+// written are returned so the caller can give back exactly them with Undo.
+func (i *inventoryService) Consume(ctx context.Context, order *model.Order) ([]*model.InventoryAdjustment, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Consume Inventory Service",
+		"orderId": order.Id,
+	})
+
+	recipes := make(map[int]*model.Recipe)
+	usages := make([]*model.RecipeIngredient, 0)
+	for _, item := range order.Items {
+		variant, err := i.variantRepository.FindById(ctx, item.VariantId)
+		if err != nil {
+			log.Error(err)
+			return nil, err
+		}
+		if variant == nil {
+			continue
+		}
+
+		recipe, found := recipes[item.CakeId]
+		if !found {
+			if recipe, err = i.recipeRepository.FindByCakeId(ctx, item.CakeId); err != nil {
+				log.Error(err)
+				return nil, err
+			}
+			recipes[item.CakeId] = recipe
+		}
+		if recipe == nil {
+			continue
+		}
+
+		factor := float64(variant.Servings*item.Quantity) / float64(recipe.Yield)
+		for _, ingredient := range recipe.Ingredients {
+			usages = append(usages, &model.RecipeIngredient{
+				IngredientId: ingredient.IngredientId,
+				Quantity:     ingredient.Quantity * factor,
+				Unit:         ingredient.Unit,
+				Density:      ingredient.Density,
+			})
+		}
+	}
+
+	if len(usages) == 0 {
+		return nil, nil
+	}
+
+	units, err := i.stockUnits(ctx, usages)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	adjustments := make([]*model.InventoryAdjustment, 0)
+	byIngredient := make(map[int]*model.InventoryAdjustment)
+	for _, usage := range usages {
+		unit := units[usage.IngredientId]
+		quantity, ok := model.ConvertQuantity(usage.Quantity, usage.Unit, unit, usage.Density)
+		if !ok {
+			log.Warnf("cannot convert %s of ingredient %d to %s", usage.Unit, usage.IngredientId, unit)
+			continue
+		}
+
+		adjustment, found := byIngredient[usage.IngredientId]
+		if !found {
+			adjustment = &model.InventoryAdjustment{
+				IngredientId: usage.IngredientId,
+				OrderId:      order.Id,
+				Unit:         unit,
+				Reason:       model.InventoryReasonConsumption,
+				Note:         fmt.Sprintf("order #%d", order.Id),
+				CreatedAt:    time.Now(),
+			}
+			byIngredient[usage.IngredientId] = adjustment
+			adjustments = append(adjustments, adjustment)
+		}
+		adjustment.Delta -= quantity
+	}
+
+	for _, adjustment := range adjustments {
+		adjustment.Delta = roundQuantity(adjustment.Delta)
+	}
+
+	if err = i.inventoryRepository.Consume(ctx, adjustments); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return adjustments, nil
+}
+
+// Restore give back the ingredients the order consumed and that were not given back yet, in the current stock units
+func (i *inventoryService) Restore(ctx context.Context, order *model.Order) error {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Restore Inventory Service",
+		"orderId": order.Id,
+	})
+
+	recorded, err := i.inventoryRepository.FindOrderAdjustments(ctx, order.Id)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	return i.giveBack(ctx, log, order, recorded, fmt.Sprintf("order #%d cancelled", order.Id))
+}
+
+// Undo give back the adjustments a Consume of the order wrote, and only them, when the order could not be confirmed
+func (i *inventoryService) Undo(ctx context.Context, order *model.Order, consumed []*model.InventoryAdjustment) error {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Undo Inventory Service",
+		"orderId": order.Id,
+	})
+
+	return i.giveBack(ctx, log, order, consumed, fmt.Sprintf("order #%d not confirmed", order.Id))
+}
+
+// giveBack return the net of the recorded adjustments to the stock, in the current stock units
+func (i *inventoryService) giveBack(ctx context.Context, log *logrus.Entry, order *model.Order, recorded []*model.InventoryAdjustment, note string) error {
+	if len(recorded) == 0 {
+		return nil
+	}
+
+	ids := make([]int, 0)
+	for _, adjustment := range recorded {
+		ids = append(ids, adjustment.IngredientId)
+	}
+
+	ingredients, err := i.ingredientRepository.FindByIds(ctx, ids)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+	densities := make(map[int]float64, len(ingredients))
+	for _, ingredient := range ingredients {
+		densities[ingredient.Id] = ingredient.Density
+	}
+
+	stocks, err := i.inventoryRepository.FindByIngredientIds(ctx, ids)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+	units := make(map[int]string, len(stocks))
+	for _, stock := range stocks {
+		units[stock.IngredientId] = stock.Unit
+	}
+
+	adjustments := make([]*model.InventoryAdjustment, 0)
+	byIngredient := make(map[int]*model.InventoryAdjustment)
+	for _, consumed := range recorded {
+		unit, found := units[consumed.IngredientId]
+		if !found {
+			// the stock was removed with its ingredient
+			continue
+		}
+
+		delta, ok := model.ConvertQuantity(consumed.Delta, consumed.Unit, unit, densities[consumed.IngredientId])
+		if !ok {
+			log.Warnf("cannot convert %s of ingredient %d to %s", consumed.Unit, consumed.IngredientId, unit)
+			continue
+		}
+
+		adjustment, found := byIngredient[consumed.IngredientId]
+		if !found {
+			adjustment = &model.InventoryAdjustment{
+				IngredientId: consumed.IngredientId,
+				OrderId:      order.Id,
+				Unit:         unit,
+				Reason:       model.InventoryReasonReturn,
+				Note:         note,
+				CreatedAt:    time.Now(),
+			}
+			byIngredient[consumed.IngredientId] = adjustment
+			adjustments = append(adjustments, adjustment)
+		}
+		adjustment.Delta -= delta
+	}
+
+	returned := make([]*model.InventoryAdjustment, 0, len(adjustments))
+	for _, adjustment := range adjustments {
+		adjustment.Delta = roundQuantity(adjustment.Delta)
+		if adjustment.Delta > 0 {
+			returned = append(returned, adjustment)
+		}
+	}
+
+	if err = i.inventoryRepository.Consume(ctx, returned); err != nil {
+		log.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// ReorderReport suggest the purchases bringing the low ingredients back up, each ingredient is bought in whole packs
+// from the supplier where the packs cost the least in the currency
+func (i *inventoryService) ReorderReport(ctx context.Context, query model.ReorderQuery) (*model.ReorderReport, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Reorder Report Inventory Service",
+		"query":   query,
+	})
+
+	query.Currency = strings.ToUpper(query.Currency)
+	if err := query.Validate(); err != nil {
+		log.Error(err)
+		return nil, constant.HttpValidationOrInternalErr(err)
+	}
+
+	currency := query.Currency
+	if currency == "" {
+		currency = config.BaseCurrency()
+	}
+
+	report := &model.ReorderReport{
+		Suppliers:   make([]*model.SupplierOrder, 0),
+		Unsourced:   make([]*model.ReorderLine, 0),
+		Total:       model.NewMoney(0, currency),
+		GeneratedAt: time.Now(),
+	}
+
+	stocks, err := i.inventoryRepository.FindLow(ctx)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	lines := make([]*model.ReorderLine, 0, len(stocks))
+	ids := make([]int, 0, len(stocks))
+	for _, stock := range stocks {
+		if shortfall := stock.Shortfall(); shortfall > 0 {
+			lines = append(lines, &model.ReorderLine{
+				IngredientId: stock.IngredientId,
+				Name:         stock.Name,
+				OnHand:       stock.OnHand,
+				ReorderPoint: stock.ReorderPoint,
+				Quantity:     shortfall,
+				Unit:         stock.Unit,
+			})
+			ids = append(ids, stock.IngredientId)
+		}
+	}
+
+	if len(lines) == 0 {
+		return report, nil
+	}
+
+	ingredients, err := i.ingredientRepository.FindByIds(ctx, ids)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	densities := make(map[int]float64, len(ingredients))
+	for _, ingredient := range ingredients {
+		densities[ingredient.Id] = ingredient.Density
+	}
+
+	prices, err := i.ingredientRepository.FindPrices(ctx, ids)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	byIngredient := make(map[int][]*model.SupplierPrice)
+	for _, price := range prices {
+		byIngredient[price.IngredientId] = append(byIngredient[price.IngredientId], price)
+	}
+
+	bySupplier := make(map[string]*model.SupplierOrder)
+	for _, line := range lines {
+		for _, price := range byIngredient[line.IngredientId] {
+			packs, ok := price.Packs(line.Quantity, line.Unit, densities[line.IngredientId])
+			if !ok {
+				continue
+			}
+
+			cost, err := convertPrice(ctx, i.exchangeRate, model.NewMoney(price.Price.Amount*int64(packs), price.Price.Currency), currency)
+			if err != nil {
+				log.Error(err)
+				return nil, err
+			}
+
+			if line.Cost == nil || cost.Amount < line.Cost.Amount {
+				line.Cost = &cost
+				line.Supplier = price.Supplier
+				line.Packs = packs
+				line.PackQuantity = price.Quantity
+				line.PackUnit = price.Unit
+			}
+		}
+
+		if line.Cost == nil {
+			report.Unsourced = append(report.Unsourced, line)
+			continue
+		}
+
+		supplierOrder, found := bySupplier[line.Supplier]
+		if !found {
+			supplierOrder = &model.SupplierOrder{
+				Supplier: line.Supplier,
+				Lines:    make([]*model.ReorderLine, 0),
+				Total:    model.NewMoney(0, currency),
+			}
+			bySupplier[line.Supplier] = supplierOrder
+			report.Suppliers = append(report.Suppliers, supplierOrder)
+		}
+		supplierOrder.Lines = append(supplierOrder.Lines, line)
+		supplierOrder.Total.Amount += line.Cost.Amount
+		report.Total.Amount += line.Cost.Amount
+	}
+
+	sort.Slice(report.Suppliers, func(a, b int) bool {
+		return report.Suppliers[a].Supplier < report.Suppliers[b].Supplier
+	})
+
+	return report, nil
+}
+
+// findStock find the ingredient and its stock, the stock is nil when the ingredient is not counted yet
+func (i *inventoryService) findStock(ctx context.Context, ingredientId int) (*model.Ingredient, *model.IngredientStock, error) {
+	if ingredientId == 0 {
+		return nil, nil, constant.ErrInvalidArgument
+	}
+
+	ingredient, err := i.ingredientRepository.FindById(ctx, ingredientId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if ingredient == nil {
+		return nil, nil, constant.ErrNotFound
+	}
+
+	stocks, err := i.inventoryRepository.FindByIngredientIds(ctx, []int{ingredientId})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(stocks) == 0 {
+		return ingredient, nil, nil
+	}
+	return ingredient, stocks[0], nil
+}
+
+// stockUnits return the unit each ingredient is counted in, the base unit of its recipe unit when not counted yet
+func (i *inventoryService) stockUnits(ctx context.Context, usages []*model.RecipeIngredient) (map[int]string, error) {
+	ids := make([]int, 0, len(usages))
+	for _, usage := range usages {
+		ids = append(ids, usage.IngredientId)
+	}
+
+	stocks, err := i.inventoryRepository.FindByIngredientIds(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	units := make(map[int]string, len(usages))
+	for _, stock := range stocks {
+		units[stock.IngredientId] = stock.Unit
+	}
+	for _, usage := range usages {
+		if _, found := units[usage.IngredientId]; !found {
+			units[usage.IngredientId] = model.BaseUnit(usage.Unit)
+		}
+	}
+	return units, nil
+}
+
+// roundQuantity round the quantity to the precision it is stored in
+func roundQuantity(quantity float64) float64 {
+	return math.Round(quantity*1000) / 1000
+}
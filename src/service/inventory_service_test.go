@@ -0,0 +1,389 @@
+package service
+
+import (
+	"cake-store/src/constant"
+	"cake-store/src/model"
+	"cake-store/src/model/mock"
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInventoryService_Adjust(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.TODO()
+	mockInventoryRepo := mock.NewMockInventoryRepository(ctrl)
+	mockIngredientRepo := mock.NewMockIngredientRepository(ctrl)
+
+	inventoryService := &inventoryService{
+		inventoryRepository:  mockInventoryRepo,
+		ingredientRepository: mockIngredientRepo,
+	}
+
+	flour := &model.Ingredient{Id: 2, Name: "Flour", Density: 0.5}
+	stock := &model.IngredientStock{IngredientId: 2, Name: "Flour", OnHand: 1.5, Unit: model.UnitKilogram}
+
+	t.Run("ok - converted to the stock unit", func(t *testing.T) {
+		mockIngredientRepo.EXPECT().FindById(gomock.Any(), 2).Times(1).Return(flour, nil)
+		mockInventoryRepo.EXPECT().FindByIngredientIds(gomock.Any(), []int{2}).Times(1).Return([]*model.IngredientStock{stock}, nil)
+		mockInventoryRepo.EXPECT().Adjust(gomock.Any(), gomock.Any()).Times(1).
+			DoAndReturn(func(_ context.Context, adjustment *model.InventoryAdjustment) (*model.IngredientStock, error) {
+				assert.Equal(t, 0.5, adjustment.Delta)
+				assert.Equal(t, model.UnitKilogram, adjustment.Unit)
+				return &model.IngredientStock{IngredientId: 2, OnHand: 2, Unit: model.UnitKilogram}, nil
+			})
+
+		res, err := inventoryService.Adjust(ctx, model.AdjustInventoryRequest{Delta: 500, Unit: "G", Reason: model.InventoryReasonPurchase}, 2)
+		require.NoError(t, err)
+		assert.Equal(t, 2.0, res.OnHand)
+	})
+
+	t.Run("ok - first count in the delta unit", func(t *testing.T) {
+		mockIngredientRepo.EXPECT().FindById(gomock.Any(), 2).Times(1).Return(flour, nil)
+		mockInventoryRepo.EXPECT().FindByIngredientIds(gomock.Any(), []int{2}).Times(1).Return(nil, nil)
+		mockInventoryRepo.EXPECT().Adjust(gomock.Any(), gomock.Any()).Times(1).
+			DoAndReturn(func(_ context.Context, adjustment *model.InventoryAdjustment) (*model.IngredientStock, error) {
+				assert.Equal(t, 5.0, adjustment.Delta)
+				assert.Equal(t, model.UnitKilogram, adjustment.Unit)
+				return &model.IngredientStock{IngredientId: 2, OnHand: 5, Unit: model.UnitKilogram}, nil
+			})
+
+		_, err := inventoryService.Adjust(ctx, model.AdjustInventoryRequest{Delta: 5, Unit: "kg", Reason: model.InventoryReasonPurchase}, 2)
+		require.NoError(t, err)
+	})
+
+	t.Run("unit not convertible", func(t *testing.T) {
+		mockIngredientRepo.EXPECT().FindById(gomock.Any(), 2).Times(1).Return(flour, nil)
+		mockInventoryRepo.EXPECT().FindByIngredientIds(gomock.Any(), []int{2}).Times(1).Return([]*model.IngredientStock{stock}, nil)
+		mockInventoryRepo.EXPECT().Adjust(gomock.Any(), gomock.Any()).Times(0)
+
+		res, err := inventoryService.Adjust(ctx, model.AdjustInventoryRequest{Delta: 3, Unit: "pc", Reason: model.InventoryReasonPurchase}, 2)
+		assert.Equal(t, constant.ErrInvalidArgument, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("consumption is not a manual reason", func(t *testing.T) {
+		res, err := inventoryService.Adjust(ctx, model.AdjustInventoryRequest{Delta: -3, Reason: model.InventoryReasonConsumption}, 2)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("ingredient not found", func(t *testing.T) {
+		mockIngredientRepo.EXPECT().FindById(gomock.Any(), 9).Times(1).Return(nil, nil)
+
+		res, err := inventoryService.Adjust(ctx, model.AdjustInventoryRequest{Delta: 3, Reason: model.InventoryReasonPurchase}, 9)
+		assert.Equal(t, constant.ErrNotFound, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestInventoryService_SetLevels(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.TODO()
+	mockInventoryRepo := mock.NewMockInventoryRepository(ctrl)
+	mockIngredientRepo := mock.NewMockIngredientRepository(ctrl)
+
+	inventoryService := &inventoryService{
+		inventoryRepository:  mockInventoryRepo,
+		ingredientRepository: mockIngredientRepo,
+	}
+
+	milk := &model.Ingredient{Id: 3, Name: "Milk", Density: 1.03}
+
+	t.Run("ok - on hand converted", func(t *testing.T) {
+		mockIngredientRepo.EXPECT().FindById(gomock.Any(), 3).Times(1).Return(milk, nil)
+		mockInventoryRepo.EXPECT().FindByIngredientIds(gomock.Any(), []int{3}).Times(1).
+			Return([]*model.IngredientStock{{IngredientId: 3, OnHand: 1500, Unit: model.UnitMilliliter}}, nil)
+		mockInventoryRepo.EXPECT().SetLevels(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+
+		res, err := inventoryService.SetLevels(ctx, model.SetInventoryLevelsRequest{Unit: "l", ReorderPoint: 2, ReorderQuantity: 6}, 3)
+		require.NoError(t, err)
+		assert.Equal(t, 1.5, res.OnHand)
+		assert.Equal(t, model.UnitLiter, res.Unit)
+		assert.True(t, res.LowStock)
+	})
+
+	t.Run("ok - not counted yet", func(t *testing.T) {
+		mockIngredientRepo.EXPECT().FindById(gomock.Any(), 3).Times(1).Return(milk, nil)
+		mockInventoryRepo.EXPECT().FindByIngredientIds(gomock.Any(), []int{3}).Times(1).Return(nil, nil)
+		mockInventoryRepo.EXPECT().SetLevels(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+
+		res, err := inventoryService.SetLevels(ctx, model.SetInventoryLevelsRequest{Unit: "ml", ReorderPoint: 2000}, 3)
+		require.NoError(t, err)
+		assert.Equal(t, 0.0, res.OnHand)
+		assert.Equal(t, "Milk", res.Name)
+	})
+
+	t.Run("unknown unit", func(t *testing.T) {
+		res, err := inventoryService.SetLevels(ctx, model.SetInventoryLevelsRequest{Unit: "bucket"}, 3)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("error from repo", func(t *testing.T) {
+		mockIngredientRepo.EXPECT().FindById(gomock.Any(), 3).Times(1).Return(milk, nil)
+		mockInventoryRepo.EXPECT().FindByIngredientIds(gomock.Any(), []int{3}).Times(1).Return(nil, nil)
+		mockInventoryRepo.EXPECT().SetLevels(gomock.Any(), gomock.Any()).Times(1).Return(errors.New("err db"))
+
+		res, err := inventoryService.SetLevels(ctx, model.SetInventoryLevelsRequest{Unit: "ml"}, 3)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestInventoryService_Consume(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.TODO()
+	mockInventoryRepo := mock.NewMockInventoryRepository(ctrl)
+	mockRecipeRepo := mock.NewMockRecipeRepository(ctrl)
+	mockVariantRepo := mock.NewMockVariantRepository(ctrl)
+
+	inventoryService := &inventoryService{
+		inventoryRepository: mockInventoryRepo,
+		recipeRepository:    mockRecipeRepo,
+		variantRepository:   mockVariantRepo,
+	}
+
+	order := &model.Order{Id: 3, Items: []*model.OrderItem{
+		{CakeId: 1, VariantId: 5, Quantity: 2},
+		{CakeId: 1, VariantId: 6, Quantity: 1},
+		{CakeId: 2, VariantId: 7, Quantity: 1},
+	}}
+
+	t.Run("ok", func(t *testing.T) {
+		mockVariantRepo.EXPECT().FindById(gomock.Any(), 5).Times(1).Return(&model.Variant{Id: 5, CakeId: 1, Servings: 8}, nil)
+		mockVariantRepo.EXPECT().FindById(gomock.Any(), 6).Times(1).Return(&model.Variant{Id: 6, CakeId: 1, Servings: 4}, nil)
+		mockVariantRepo.EXPECT().FindById(gomock.Any(), 7).Times(1).Return(&model.Variant{Id: 7, CakeId: 2, Servings: 8}, nil)
+		mockRecipeRepo.EXPECT().FindByCakeId(gomock.Any(), 1).Times(1).Return(newTestRecipe(), nil)
+		mockRecipeRepo.EXPECT().FindByCakeId(gomock.Any(), 2).Times(1).Return(nil, nil)
+		mockInventoryRepo.EXPECT().FindByIngredientIds(gomock.Any(), []int{2, 3, 2, 3}).Times(1).
+			Return([]*model.IngredientStock{{IngredientId: 2, Unit: model.UnitKilogram}}, nil)
+		mockInventoryRepo.EXPECT().Consume(gomock.Any(), gomock.Any()).Times(1).
+			DoAndReturn(func(_ context.Context, adjustments []*model.InventoryAdjustment) error {
+				require.Len(t, adjustments, 2)
+				assert.Equal(t, -0.625, adjustments[0].Delta)
+				assert.Equal(t, model.UnitKilogram, adjustments[0].Unit)
+				assert.Equal(t, -600.0, adjustments[1].Delta)
+				assert.Equal(t, model.UnitMilliliter, adjustments[1].Unit)
+				assert.Equal(t, 3, adjustments[1].OrderId)
+				assert.Equal(t, model.InventoryReasonConsumption, adjustments[1].Reason)
+				return nil
+			})
+
+		consumed, err := inventoryService.Consume(ctx, order)
+		require.NoError(t, err)
+		assert.Len(t, consumed, 2)
+	})
+
+	t.Run("no recipe", func(t *testing.T) {
+		mockVariantRepo.EXPECT().FindById(gomock.Any(), 7).Times(1).Return(&model.Variant{Id: 7, CakeId: 2, Servings: 8}, nil)
+		mockRecipeRepo.EXPECT().FindByCakeId(gomock.Any(), 2).Times(1).Return(nil, nil)
+		mockInventoryRepo.EXPECT().Consume(gomock.Any(), gomock.Any()).Times(0)
+
+		consumed, err := inventoryService.Consume(ctx, &model.Order{Id: 4, Items: []*model.OrderItem{{CakeId: 2, VariantId: 7, Quantity: 1}}})
+		assert.NoError(t, err)
+		assert.Empty(t, consumed)
+	})
+
+	t.Run("error from repo", func(t *testing.T) {
+		mockVariantRepo.EXPECT().FindById(gomock.Any(), 5).Times(1).Return(nil, errors.New("err db"))
+
+		_, err := inventoryService.Consume(ctx, order)
+		assert.Error(t, err)
+	})
+}
+
+func TestInventoryService_Restore(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.TODO()
+	mockInventoryRepo := mock.NewMockInventoryRepository(ctrl)
+	mockIngredientRepo := mock.NewMockIngredientRepository(ctrl)
+
+	inventoryService := &inventoryService{
+		inventoryRepository:  mockInventoryRepo,
+		ingredientRepository: mockIngredientRepo,
+	}
+
+	order := &model.Order{Id: 3}
+
+	t.Run("ok - in the current stock unit", func(t *testing.T) {
+		mockInventoryRepo.EXPECT().FindOrderAdjustments(gomock.Any(), 3).Times(1).Return([]*model.InventoryAdjustment{
+			{IngredientId: 2, OrderId: 3, Delta: -0.625, Unit: model.UnitKilogram, Reason: model.InventoryReasonConsumption},
+			{IngredientId: 3, OrderId: 3, Delta: -600, Unit: model.UnitMilliliter, Reason: model.InventoryReasonConsumption},
+		}, nil)
+		mockIngredientRepo.EXPECT().FindByIds(gomock.Any(), []int{2, 3}).Times(1).
+			Return([]*model.Ingredient{{Id: 2, Density: 0.5}, {Id: 3, Density: 1}}, nil)
+		mockInventoryRepo.EXPECT().FindByIngredientIds(gomock.Any(), []int{2, 3}).Times(1).Return([]*model.IngredientStock{
+			{IngredientId: 2, Unit: model.UnitGram},
+			{IngredientId: 3, Unit: model.UnitMilliliter},
+		}, nil)
+		mockInventoryRepo.EXPECT().Consume(gomock.Any(), gomock.Any()).Times(1).
+			DoAndReturn(func(_ context.Context, adjustments []*model.InventoryAdjustment) error {
+				require.Len(t, adjustments, 2)
+				assert.Equal(t, 625.0, adjustments[0].Delta)
+				assert.Equal(t, model.UnitGram, adjustments[0].Unit)
+				assert.Equal(t, model.InventoryReasonReturn, adjustments[0].Reason)
+				assert.Equal(t, 600.0, adjustments[1].Delta)
+				return nil
+			})
+
+		err := inventoryService.Restore(ctx, order)
+		assert.NoError(t, err)
+	})
+
+	t.Run("already given back", func(t *testing.T) {
+		mockInventoryRepo.EXPECT().FindOrderAdjustments(gomock.Any(), 3).Times(1).Return([]*model.InventoryAdjustment{
+			{IngredientId: 2, OrderId: 3, Delta: -625, Unit: model.UnitGram, Reason: model.InventoryReasonConsumption},
+			{IngredientId: 2, OrderId: 3, Delta: 625, Unit: model.UnitGram, Reason: model.InventoryReasonReturn},
+		}, nil)
+		mockIngredientRepo.EXPECT().FindByIds(gomock.Any(), []int{2, 2}).Times(1).Return([]*model.Ingredient{{Id: 2}}, nil)
+		mockInventoryRepo.EXPECT().FindByIngredientIds(gomock.Any(), []int{2, 2}).Times(1).
+			Return([]*model.IngredientStock{{IngredientId: 2, Unit: model.UnitGram}}, nil)
+		mockInventoryRepo.EXPECT().Consume(gomock.Any(), []*model.InventoryAdjustment{}).Times(1).Return(nil)
+
+		err := inventoryService.Restore(ctx, order)
+		assert.NoError(t, err)
+	})
+
+	t.Run("nothing consumed", func(t *testing.T) {
+		mockInventoryRepo.EXPECT().FindOrderAdjustments(gomock.Any(), 3).Times(1).Return(nil, nil)
+
+		err := inventoryService.Restore(ctx, order)
+		assert.NoError(t, err)
+	})
+}
+
+func TestInventoryService_Undo(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.TODO()
+	mockInventoryRepo := mock.NewMockInventoryRepository(ctrl)
+	mockIngredientRepo := mock.NewMockIngredientRepository(ctrl)
+
+	inventoryService := &inventoryService{
+		inventoryRepository:  mockInventoryRepo,
+		ingredientRepository: mockIngredientRepo,
+	}
+
+	order := &model.Order{Id: 3}
+
+	t.Run("ok - only the given adjustments", func(t *testing.T) {
+		consumed := []*model.InventoryAdjustment{
+			{Id: 12, IngredientId: 2, OrderId: 3, Delta: -625, Unit: model.UnitGram, Reason: model.InventoryReasonConsumption},
+		}
+		mockInventoryRepo.EXPECT().FindOrderAdjustments(gomock.Any(), gomock.Any()).Times(0)
+		mockIngredientRepo.EXPECT().FindByIds(gomock.Any(), []int{2}).Times(1).Return([]*model.Ingredient{{Id: 2}}, nil)
+		mockInventoryRepo.EXPECT().FindByIngredientIds(gomock.Any(), []int{2}).Times(1).
+			Return([]*model.IngredientStock{{IngredientId: 2, Unit: model.UnitGram}}, nil)
+		mockInventoryRepo.EXPECT().Consume(gomock.Any(), gomock.Any()).Times(1).
+			DoAndReturn(func(_ context.Context, adjustments []*model.InventoryAdjustment) error {
+				require.Len(t, adjustments, 1)
+				assert.Equal(t, 625.0, adjustments[0].Delta)
+				assert.Equal(t, model.InventoryReasonReturn, adjustments[0].Reason)
+				assert.Equal(t, "order #3 not confirmed", adjustments[0].Note)
+				return nil
+			})
+
+		err := inventoryService.Undo(ctx, order, consumed)
+		assert.NoError(t, err)
+	})
+
+	t.Run("nothing consumed", func(t *testing.T) {
+		err := inventoryService.Undo(ctx, order, nil)
+		assert.NoError(t, err)
+	})
+}
+
+func TestInventoryService_ReorderReport(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.TODO()
+	mockInventoryRepo := mock.NewMockInventoryRepository(ctrl)
+	mockIngredientRepo := mock.NewMockIngredientRepository(ctrl)
+	mockExchangeRate := mock.NewMockExchangeRateProvider(ctrl)
+
+	inventoryService := &inventoryService{
+		inventoryRepository:  mockInventoryRepo,
+		ingredientRepository: mockIngredientRepo,
+		exchangeRate:         mockExchangeRate,
+	}
+
+	stocks := []*model.IngredientStock{
+		{IngredientId: 5, Name: "Butter", OnHand: 0, Unit: model.UnitGram, ReorderPoint: 250, ReorderQuantity: 1000, LowStock: true},
+		{IngredientId: 6, Name: "Eggs", OnHand: 6, Unit: model.UnitPiece, ReorderPoint: 12, ReorderQuantity: 30, LowStock: true},
+		{IngredientId: 2, Name: "Flour", OnHand: 200, Unit: model.UnitGram, ReorderPoint: 500, ReorderQuantity: 2000, LowStock: true},
+		{IngredientId: 7, Name: "Salt", OnHand: 0, Unit: model.UnitGram, LowStock: true},
+	}
+	prices := []*model.SupplierPrice{
+		{IngredientId: 2, Supplier: "Toko A", Price: model.NewMoney(1500000, "IDR"), Quantity: 1, Unit: model.UnitKilogram},
+		{IngredientId: 2, Supplier: "Toko B", Price: model.NewMoney(700000, "IDR"), Quantity: 500, Unit: model.UnitGram},
+		{IngredientId: 6, Supplier: "Toko A", Price: model.NewMoney(300, "USD"), Quantity: 12, Unit: model.UnitPiece},
+		{IngredientId: 5, Supplier: "Dairy Farm", Price: model.NewMoney(1000000, "IDR"), Quantity: 1, Unit: model.UnitLiter},
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		mockInventoryRepo.EXPECT().FindLow(gomock.Any()).Times(1).Return(stocks, nil)
+		mockIngredientRepo.EXPECT().FindByIds(gomock.Any(), []int{5, 6, 2}).Times(1).
+			Return([]*model.Ingredient{{Id: 2, Density: 0.5}, {Id: 5}, {Id: 6}}, nil)
+		mockIngredientRepo.EXPECT().FindPrices(gomock.Any(), []int{5, 6, 2}).Times(1).Return(prices, nil)
+		mockExchangeRate.EXPECT().Rate(gomock.Any(), "USD", "IDR").Times(1).Return(big.NewRat(16000, 1), nil)
+
+		res, err := inventoryService.ReorderReport(ctx, model.ReorderQuery{})
+		require.NoError(t, err)
+
+		require.Len(t, res.Suppliers, 2)
+		assert.Equal(t, "Toko A", res.Suppliers[0].Supplier)
+		require.Len(t, res.Suppliers[0].Lines, 1)
+		assert.Equal(t, "Eggs", res.Suppliers[0].Lines[0].Name)
+		assert.Equal(t, 30.0, res.Suppliers[0].Lines[0].Quantity)
+		assert.Equal(t, 3, res.Suppliers[0].Lines[0].Packs)
+		assert.Equal(t, model.NewMoney(14400000, "IDR"), res.Suppliers[0].Total)
+
+		assert.Equal(t, "Toko B", res.Suppliers[1].Supplier)
+		assert.Equal(t, 4, res.Suppliers[1].Lines[0].Packs)
+		assert.Equal(t, model.NewMoney(2800000, "IDR"), *res.Suppliers[1].Lines[0].Cost)
+
+		// the butter is sold by volume and has no density
+		require.Len(t, res.Unsourced, 1)
+		assert.Equal(t, "Butter", res.Unsourced[0].Name)
+		assert.Equal(t, model.NewMoney(17200000, "IDR"), res.Total)
+	})
+
+	t.Run("nothing to reorder", func(t *testing.T) {
+		mockInventoryRepo.EXPECT().FindLow(gomock.Any()).Times(1).Return(stocks[3:], nil)
+
+		res, err := inventoryService.ReorderReport(ctx, model.ReorderQuery{Currency: "usd"})
+		require.NoError(t, err)
+		assert.Empty(t, res.Suppliers)
+		assert.Equal(t, model.NewMoney(0, "USD"), res.Total)
+	})
+
+	t.Run("invalid currency", func(t *testing.T) {
+		res, err := inventoryService.ReorderReport(ctx, model.ReorderQuery{Currency: "XYZ"})
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("error from repo", func(t *testing.T) {
+		mockInventoryRepo.EXPECT().FindLow(gomock.Any()).Times(1).Return(nil, errors.New("err db"))
+
+		res, err := inventoryService.ReorderReport(ctx, model.ReorderQuery{})
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+}
@@ -0,0 +1,252 @@
+package service
+
+import (
+	"cake-store/src/config"
+	"cake-store/src/constant"
+	"cake-store/src/model"
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+type optionService struct {
+	optionRepository  model.OptionRepository
+	cakeRepository    model.CakeRepository
+	variantRepository model.VariantRepository
+	storeRepository   model.StoreRepository
+	exchangeRate      model.ExchangeRateProvider
+}
+
+func NewOptionService(optionRepository model.OptionRepository, cakeRepository model.CakeRepository, variantRepository model.VariantRepository,
+	storeRepository model.StoreRepository, exchangeRate model.ExchangeRateProvider) model.OptionService {
+	return &optionService{
+		optionRepository:  optionRepository,
+		cakeRepository:    cakeRepository,
+		variantRepository: variantRepository,
+		storeRepository:   storeRepository,
+		exchangeRate:      exchangeRate,
+	}
+}
+
+// Save replace the option groups of the cake, the orders keep the options as they were selected
+func (o *optionService) Save(ctx context.Context, req model.SaveOptionsRequest, cakeId int) ([]*model.OptionGroup, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Save Option Service",
+		"req":     req,
+		"cakeId":  cakeId,
+	})
+
+	if err := req.Validate(); err != nil {
+		log.Error(err)
+		return nil, constant.HttpValidationOrInternalErr(err)
+	}
+
+	if reason := req.Inconsistency(); reason != "" {
+		log.Error(reason)
+		return nil, constant.OptionsRejectedErr(reason)
+	}
+
+	if err := o.findCake(ctx, cakeId); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	now := time.Now()
+	groups := make([]*model.OptionGroup, 0, len(req.Groups))
+	for idx, groupReq := range req.Groups {
+		group := &model.OptionGroup{
+			CakeId:        cakeId,
+			Code:          groupReq.Code,
+			Name:          groupReq.Name,
+			Kind:          groupReq.Kind,
+			Required:      groupReq.Required,
+			PriceModifier: priceModifier(groupReq.PriceModifier),
+			Position:      idx + 1,
+			Choices:       make([]*model.OptionChoice, 0, len(groupReq.Choices)),
+			UpdatedAt:     now,
+		}
+
+		// a choice group pick one choice unless it say otherwise, and only a text is charged at the group level
+		switch group.Kind {
+		case model.OptionKindChoice:
+			group.MaxChoices = groupReq.MaxChoices
+			if group.MaxChoices == 0 {
+				group.MaxChoices = 1
+			}
+			group.PriceModifier = model.NewMoney(0, group.PriceModifier.Currency)
+		case model.OptionKindText:
+			group.MaxLength = groupReq.MaxLength
+		}
+
+		for choiceIdx, choiceReq := range groupReq.Choices {
+			choice := &model.OptionChoice{
+				Code:          choiceReq.Code,
+				Name:          choiceReq.Name,
+				PriceModifier: priceModifier(choiceReq.PriceModifier),
+				Value:         choiceReq.Value,
+				Position:      choiceIdx + 1,
+				Requires:      make([]*model.OptionConstraint, 0, len(choiceReq.Requires)),
+			}
+			for _, constraintReq := range choiceReq.Requires {
+				choice.Requires = append(choice.Requires, &model.OptionConstraint{
+					Group:    constraintReq.Group,
+					MinValue: constraintReq.MinValue,
+					MaxValue: constraintReq.MaxValue,
+				})
+			}
+			group.Choices = append(group.Choices, choice)
+		}
+		groups = append(groups, group)
+	}
+
+	if err := o.optionRepository.Save(ctx, cakeId, groups); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return groups, nil
+}
+
+func (o *optionService) FindByCakeId(ctx context.Context, cakeId int) ([]*model.OptionGroup, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Find By Cake ID Option Service",
+		"cakeId":  cakeId,
+	})
+
+	if err := o.findCake(ctx, cakeId); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	groups, err := o.optionRepository.FindByCakeId(ctx, cakeId)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return groups, nil
+}
+
+// Quote check the options selected for the variant of the cake and price them at the store price, in the base
+// currency unless the request ask for another one
+func (o *optionService) Quote(ctx context.Context, req model.QuoteOptionsRequest, cakeId int) (*model.OptionQuote, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Quote Option Service",
+		"req":     req,
+		"cakeId":  cakeId,
+	})
+
+	if err := req.Validate(); err != nil {
+		log.Error(err)
+		return nil, constant.HttpValidationOrInternalErr(err)
+	}
+
+	if err := o.findCake(ctx, cakeId); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	variant, err := o.variantRepository.FindById(ctx, req.VariantId)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	if variant == nil || variant.CakeId != cakeId || !variant.Active {
+		log.Error(constant.ErrNotFound)
+		return nil, constant.ErrNotFound
+	}
+
+	currency := config.BaseCurrency()
+	if req.Currency != "" {
+		currency = req.Currency
+	}
+
+	basePrice, err := storePrice(ctx, o.storeRepository, storeOrDefault(req.StoreId), variant)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	basePrice, err = convertPrice(ctx, o.exchangeRate, basePrice, currency)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	options, err := o.Select(ctx, cakeId, req.Options, currency)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	unitPrice := model.NewMoney(basePrice.Amount+options.Amount(), currency)
+	return &model.OptionQuote{
+		CakeId:    cakeId,
+		VariantId: variant.Id,
+		Quantity:  req.Quantity,
+		BasePrice: basePrice,
+		Options:   options,
+		UnitPrice: unitPrice,
+		Total:     model.NewMoney(unitPrice.Amount*int64(req.Quantity), currency),
+	}, nil
+}
+
+// Select check the selections against the option groups of the cake, a cake without options accept no selection.
+// The price modifiers of the selected options are converted to the currency
+func (o *optionService) Select(ctx context.Context, cakeId int, selections []model.OptionSelection, currency string) (model.SelectedOptions, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message":    "Select Option Service",
+		"cakeId":     cakeId,
+		"selections": selections,
+		"currency":   currency,
+	})
+
+	groups, err := o.optionRepository.FindByCakeId(ctx, cakeId)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	selected, reason := model.SelectOptions(groups, selections)
+	if reason != "" {
+		log.Error(reason)
+		return nil, constant.OptionsRejectedErr(reason)
+	}
+
+	for _, option := range selected {
+		option.PriceModifier, err = convertPrice(ctx, o.exchangeRate, option.PriceModifier, currency)
+		if err != nil {
+			log.Error(err)
+			return nil, err
+		}
+	}
+
+	return selected, nil
+}
+
+func (o *optionService) findCake(ctx context.Context, cakeId int) error {
+	if cakeId == 0 {
+		return constant.ErrInvalidArgument
+	}
+
+	cake, err := o.cakeRepository.FindById(ctx, cakeId)
+	if err != nil {
+		return err
+	}
+
+	if cake == nil {
+		return constant.ErrNotFound
+	}
+
+	return nil
+}
+
+// priceModifier return the price modifier of the request, free in the base currency when it has none
+func priceModifier(price *model.Money) model.Money {
+	if price == nil {
+		return model.NewMoney(0, config.BaseCurrency())
+	}
+	return model.NewMoney(price.Amount, price.Currency)
+}
@@ -0,0 +1,212 @@
+package service
+
+import (
+	"cake-store/src/constant"
+	"cake-store/src/model"
+	"cake-store/src/model/mock"
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOptionService_Save(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.TODO()
+	mockOptionRepo := mock.NewMockOptionRepository(ctrl)
+	mockCakeRepo := mock.NewMockCakeRepository(ctrl)
+
+	optionService := &optionService{
+		optionRepository: mockOptionRepo,
+		cakeRepository:   mockCakeRepo,
+	}
+
+	twenty := 20.0
+	fondant := model.NewMoney(75000, "IDR")
+	req := model.SaveOptionsRequest{Groups: []model.OptionGroupRequest{
+		{Code: "size", Name: "Size", Kind: model.OptionKindChoice, Required: true, Choices: []model.OptionChoiceRequest{
+			{Code: "16cm", Name: "16 cm", Value: 16},
+			{Code: "24cm", Name: "24 cm", Value: 24},
+		}},
+		{Code: "frosting", Name: "Frosting", Kind: model.OptionKindChoice, Choices: []model.OptionChoiceRequest{
+			{Code: "fondant", Name: "Fondant", PriceModifier: &fondant, Requires: []model.OptionConstraintRequest{{Group: "size", MinValue: &twenty}}},
+		}},
+		{Code: "inscription", Name: "Inscription", Kind: model.OptionKindText, MaxLength: 30},
+	}}
+
+	t.Run("ok", func(t *testing.T) {
+		mockCakeRepo.EXPECT().FindById(gomock.Any(), 1).Times(1).Return(&model.Cake{Id: 1}, nil)
+		mockOptionRepo.EXPECT().Save(gomock.Any(), 1, gomock.Any()).Times(1).Return(nil)
+
+		res, err := optionService.Save(ctx, req, 1)
+		require.NoError(t, err)
+		require.Len(t, res, 3)
+		assert.Equal(t, 1, res[0].MaxChoices)
+		assert.Equal(t, 2, res[1].Position)
+		assert.Equal(t, model.NewMoney(0, "IDR"), res[0].Choices[0].PriceModifier)
+		assert.Equal(t, fondant, res[1].Choices[0].PriceModifier)
+		assert.Equal(t, "size", res[1].Choices[0].Requires[0].Group)
+		assert.Equal(t, 0, res[2].MaxChoices)
+	})
+
+	t.Run("inconsistent groups", func(t *testing.T) {
+		req := model.SaveOptionsRequest{Groups: req.Groups[1:2]}
+
+		res, err := optionService.Save(ctx, req, 1)
+		assert.Equal(t, constant.OptionsRejectedErr("choice fondant of group frosting requires an unknown group size"), err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("validate error", func(t *testing.T) {
+		req := model.SaveOptionsRequest{Groups: []model.OptionGroupRequest{{Code: "Size!", Name: "Size", Kind: "list"}}}
+
+		res, err := optionService.Save(ctx, req, 1)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("cake not found", func(t *testing.T) {
+		mockCakeRepo.EXPECT().FindById(gomock.Any(), 9).Times(1).Return(nil, nil)
+
+		res, err := optionService.Save(ctx, req, 9)
+		assert.Equal(t, constant.ErrNotFound, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestOptionService_Quote(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.TODO()
+	mockOptionRepo := mock.NewMockOptionRepository(ctrl)
+	mockCakeRepo := mock.NewMockCakeRepository(ctrl)
+	mockVariantRepo := mock.NewMockVariantRepository(ctrl)
+	mockStoreRepo := mock.NewMockStoreRepository(ctrl)
+	mockExchangeRate := mock.NewMockExchangeRateProvider(ctrl)
+
+	optionService := &optionService{
+		optionRepository:  mockOptionRepo,
+		cakeRepository:    mockCakeRepo,
+		variantRepository: mockVariantRepo,
+		storeRepository:   mockStoreRepo,
+		exchangeRate:      mockExchangeRate,
+	}
+
+	cake := &model.Cake{Id: 1, Title: "Custom Cake"}
+	variant := &model.Variant{Id: 5, CakeId: cake.Id, Size: "Custom", Price: model.NewMoney(200000, "IDR"), Active: true}
+	twenty := 20.0
+	groups := []*model.OptionGroup{
+		{Code: "size", Name: "Size", Kind: model.OptionKindChoice, Required: true, MaxChoices: 1, Choices: []*model.OptionChoice{
+			{Code: "16cm", Name: "16 cm", Value: 16, PriceModifier: model.NewMoney(0, "IDR")},
+			{Code: "24cm", Name: "24 cm", Value: 24, PriceModifier: model.NewMoney(100000, "IDR")},
+		}},
+		{Code: "frosting", Name: "Frosting", Kind: model.OptionKindChoice, MaxChoices: 1, Choices: []*model.OptionChoice{
+			{Code: "fondant", Name: "Fondant", PriceModifier: model.NewMoney(5, "USD"), Requires: []*model.OptionConstraint{{Group: "size", MinValue: &twenty}}},
+		}},
+	}
+	req := model.QuoteOptionsRequest{VariantId: variant.Id, Quantity: 2, Options: []model.OptionSelection{
+		{Group: "size", Choices: []string{"24cm"}},
+		{Group: "frosting", Choices: []string{"fondant"}},
+	}}
+
+	mockStoreRepo.EXPECT().FindCake(gomock.Any(), 1, cake.Id).AnyTimes().Return(nil, nil)
+
+	t.Run("ok", func(t *testing.T) {
+		mockCakeRepo.EXPECT().FindById(gomock.Any(), cake.Id).Times(1).Return(cake, nil)
+		mockVariantRepo.EXPECT().FindById(gomock.Any(), variant.Id).Times(1).Return(variant, nil)
+		mockOptionRepo.EXPECT().FindByCakeId(gomock.Any(), cake.Id).Times(1).Return(groups, nil)
+		mockExchangeRate.EXPECT().Rate(gomock.Any(), "USD", "IDR").Times(1).Return(big.NewRat(16000, 1), nil)
+
+		res, err := optionService.Quote(ctx, req, cake.Id)
+		require.NoError(t, err)
+		assert.Equal(t, model.NewMoney(200000, "IDR"), res.BasePrice)
+		require.Len(t, res.Options, 2)
+		assert.Equal(t, model.NewMoney(80000, "IDR"), res.Options[1].PriceModifier)
+		assert.Equal(t, model.NewMoney(380000, "IDR"), res.UnitPrice)
+		assert.Equal(t, model.NewMoney(760000, "IDR"), res.Total)
+	})
+
+	t.Run("ok - store price", func(t *testing.T) {
+		req := req
+		req.StoreId = 2
+		req.Options = []model.OptionSelection{{Group: "size", Choices: []string{"16cm"}}}
+		storeCake := &model.StoreCake{StoreId: 2, CakeId: cake.Id, Available: true,
+			Prices: []*model.StorePrice{{VariantId: variant.Id, Price: model.NewMoney(220000, "IDR")}}}
+		mockCakeRepo.EXPECT().FindById(gomock.Any(), cake.Id).Times(1).Return(cake, nil)
+		mockVariantRepo.EXPECT().FindById(gomock.Any(), variant.Id).Times(1).Return(variant, nil)
+		mockStoreRepo.EXPECT().FindCake(gomock.Any(), 2, cake.Id).Times(1).Return(storeCake, nil)
+		mockOptionRepo.EXPECT().FindByCakeId(gomock.Any(), cake.Id).Times(1).Return(groups, nil)
+
+		res, err := optionService.Quote(ctx, req, cake.Id)
+		require.NoError(t, err)
+		assert.Equal(t, model.NewMoney(220000, "IDR"), res.BasePrice)
+		assert.Equal(t, model.NewMoney(440000, "IDR"), res.Total)
+	})
+
+	t.Run("constraint not met", func(t *testing.T) {
+		req := req
+		req.Options = []model.OptionSelection{{Group: "size", Choices: []string{"16cm"}}, {Group: "frosting", Choices: []string{"fondant"}}}
+
+		mockCakeRepo.EXPECT().FindById(gomock.Any(), cake.Id).Times(1).Return(cake, nil)
+		mockVariantRepo.EXPECT().FindById(gomock.Any(), variant.Id).Times(1).Return(variant, nil)
+		mockOptionRepo.EXPECT().FindByCakeId(gomock.Any(), cake.Id).Times(1).Return(groups, nil)
+
+		res, err := optionService.Quote(ctx, req, cake.Id)
+		assert.Equal(t, constant.OptionsRejectedErr("Fondant requires a Size of at least 20"), err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("variant of another cake", func(t *testing.T) {
+		mockCakeRepo.EXPECT().FindById(gomock.Any(), cake.Id).Times(1).Return(cake, nil)
+		mockVariantRepo.EXPECT().FindById(gomock.Any(), variant.Id).Times(1).Return(&model.Variant{Id: 5, CakeId: 2, Active: true}, nil)
+
+		res, err := optionService.Quote(ctx, req, cake.Id)
+		assert.Equal(t, constant.ErrNotFound, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("error from repository", func(t *testing.T) {
+		mockCakeRepo.EXPECT().FindById(gomock.Any(), cake.Id).Times(1).Return(cake, nil)
+		mockVariantRepo.EXPECT().FindById(gomock.Any(), variant.Id).Times(1).Return(variant, nil)
+		mockOptionRepo.EXPECT().FindByCakeId(gomock.Any(), cake.Id).Times(1).Return(nil, errors.New("err db"))
+
+		res, err := optionService.Quote(ctx, req, cake.Id)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestOptionService_Select(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.TODO()
+	mockOptionRepo := mock.NewMockOptionRepository(ctrl)
+
+	optionService := &optionService{
+		optionRepository: mockOptionRepo,
+	}
+
+	t.Run("ok - cake without options", func(t *testing.T) {
+		mockOptionRepo.EXPECT().FindByCakeId(gomock.Any(), 1).Times(1).Return([]*model.OptionGroup{}, nil)
+
+		res, err := optionService.Select(ctx, 1, nil, "IDR")
+		require.NoError(t, err)
+		assert.Empty(t, res)
+	})
+
+	t.Run("selection on a cake without options", func(t *testing.T) {
+		mockOptionRepo.EXPECT().FindByCakeId(gomock.Any(), 1).Times(1).Return([]*model.OptionGroup{}, nil)
+
+		res, err := optionService.Select(ctx, 1, []model.OptionSelection{{Group: "size", Choices: []string{"24cm"}}}, "IDR")
+		assert.Equal(t, constant.OptionsRejectedErr("unknown option group size"), err)
+		assert.Nil(t, res)
+	})
+}
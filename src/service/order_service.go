@@ -0,0 +1,433 @@
+package service
+
+import (
+	"cake-store/src/config"
+	"cake-store/src/constant"
+	"cake-store/src/model"
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+type orderService struct {
+	orderRepository   model.OrderRepository
+	storeRepository   model.StoreRepository
+	cakeRepository    model.CakeRepository
+	variantRepository model.VariantRepository
+	couponService     model.CouponService
+	inventoryService  model.InventoryService
+	optionService     model.OptionService
+	slotService       model.SlotService
+	exchangeRate      model.ExchangeRateProvider
+}
+
+func NewOrderService(orderRepository model.OrderRepository, storeRepository model.StoreRepository, cakeRepository model.CakeRepository, variantRepository model.VariantRepository, couponService model.CouponService,
+	inventoryService model.InventoryService, optionService model.OptionService, slotService model.SlotService, exchangeRate model.ExchangeRateProvider) model.OrderService {
+	return &orderService{
+		orderRepository:   orderRepository,
+		storeRepository:   storeRepository,
+		cakeRepository:    cakeRepository,
+		variantRepository: variantRepository,
+		couponService:     couponService,
+		inventoryService:  inventoryService,
+		optionService:     optionService,
+		slotService:       slotService,
+		exchangeRate:      exchangeRate,
+	}
+}
+
+// Create place a pending order in an active store, the items are priced in the base currency at the store prices of
+// the variants plus the price modifiers of their options. The pickup slot and the coupons are booked with the order,
+// a full slot or a rejected coupon reject the order
+func (o *orderService) Create(ctx context.Context, req model.CreateOrderRequest) (*model.Order, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Create Order Service",
+		"req":     req,
+	})
+
+	if err := req.Validate(); err != nil {
+		log.Error(err)
+		return nil, constant.HttpValidationOrInternalErr(err)
+	}
+
+	pickup, err := pickupDate(req.PickupDate, time.Now())
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	storeId := storeOrDefault(req.StoreId)
+	store, err := o.storeRepository.FindById(ctx, storeId)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	if store == nil || !store.Active {
+		log.Error(constant.ErrNotFound)
+		return nil, constant.ErrNotFound
+	}
+
+	currency := config.BaseCurrency()
+	order := &model.Order{
+		StoreId:       storeId,
+		CustomerName:  req.CustomerName,
+		CustomerPhone: req.CustomerPhone,
+		Fulfillment:   req.Fulfillment,
+		Status:        model.OrderStatusPending,
+		Note:          req.Note,
+		PickupDate:    pickup,
+		Subtotal:      model.NewMoney(0, currency),
+		Discount:      model.NewMoney(0, currency),
+		Items:         make([]*model.OrderItem, 0, len(req.Items)),
+		Discounts:     make([]*model.OrderDiscount, 0),
+		CreatedAt:     time.Now(),
+		UpdatedAt:     time.Now(),
+	}
+
+	if req.PickupTime != "" {
+		slot, err := pickupSlot(pickup, req.PickupTime)
+		if err != nil {
+			log.Error(err)
+			return nil, err
+		}
+		order.PickupSlot = &slot
+	}
+
+	for _, itemReq := range req.Items {
+		item, err := o.orderItem(ctx, storeId, itemReq, currency)
+		if err != nil {
+			log.Error(err)
+			return nil, err
+		}
+		order.Items = append(order.Items, item)
+		order.Subtotal.Amount += item.Subtotal.Amount
+	}
+
+	if order.PickupSlot != nil {
+		if err := o.slotService.Book(ctx, order.StoreId, *order.PickupSlot, order.Units()); err != nil {
+			log.Error(err)
+			return nil, err
+		}
+	}
+
+	if len(req.Coupons) > 0 {
+		if err := o.applyCoupons(ctx, order, req.Coupons); err != nil {
+			log.Error(err)
+			o.releaseSlot(ctx, log, order)
+			return nil, err
+		}
+	}
+	order.Total = model.NewMoney(order.Subtotal.Amount-order.Discount.Amount, currency)
+
+	if err := o.orderRepository.Save(ctx, order); err != nil {
+		log.Error(err)
+		if len(order.Discounts) > 0 {
+			if releaseErr := o.couponService.Release(ctx, order.CouponIds()...); releaseErr != nil {
+				log.Error(releaseErr)
+			}
+		}
+		o.releaseSlot(ctx, log, order)
+		return nil, err
+	}
+
+	return order, nil
+}
+
+// Transition move the order along the state machine, an illegal transition return ErrInvalidTransition
+func (o *orderService) Transition(ctx context.Context, req model.TransitionOrderRequest, orderId int) (*model.Order, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Transition Order Service",
+		"req":     req,
+		"orderId": orderId,
+	})
+
+	if err := req.Validate(); err != nil {
+		log.Error(err)
+		return nil, constant.HttpValidationOrInternalErr(err)
+	}
+
+	order, err := o.FindById(ctx, orderId)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	if !order.CanTransition(req.Status) {
+		log.Error(constant.ErrInvalidTransition)
+		return nil, constant.ErrInvalidTransition
+	}
+
+	from := order.Status
+	order.Status = req.Status
+	order.UpdatedAt = time.Now()
+
+	// a confirmed order use the ingredients of its recipes, they are taken before the status is written and
+	// exactly what was taken is given back when the write fail, a concurrent confirm keep its own
+	var consumed []*model.InventoryAdjustment
+	if order.Status == model.OrderStatusConfirmed {
+		if consumed, err = o.inventoryService.Consume(ctx, order); err != nil {
+			log.Error(err)
+			return nil, err
+		}
+	}
+
+	if err = o.orderRepository.UpdateStatus(ctx, order, from); err != nil {
+		log.Error(err)
+		if len(consumed) > 0 {
+			if undoErr := o.inventoryService.Undo(ctx, order, consumed); undoErr != nil {
+				log.Error(undoErr)
+			}
+		}
+		return nil, err
+	}
+
+	// the status write claim the cancellation so its coupons, slot and ingredients are given back at most once
+	if order.Status == model.OrderStatusCancelled {
+		o.giveBack(ctx, log, order, from)
+	}
+
+	return order, nil
+}
+
+// giveBack give the coupon uses, the slot and the ingredients of a cancelled order back, the ingredients only when
+// it was cancelled before baking. Every resource is given back even when another fail, a failure is only logged.
+func (o *orderService) giveBack(ctx context.Context, log *logrus.Entry, order *model.Order, from string) {
+	if len(order.Discounts) > 0 {
+		if err := o.couponService.Release(ctx, order.CouponIds()...); err != nil {
+			log.Error(err)
+		}
+	}
+
+	o.releaseSlot(ctx, log, order)
+
+	if from == model.OrderStatusConfirmed {
+		if err := o.inventoryService.Restore(ctx, order); err != nil {
+			log.Error(err)
+		}
+	}
+}
+
+func (o *orderService) FindById(ctx context.Context, orderId int) (*model.Order, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Find By ID Order Service",
+		"orderId": orderId,
+	})
+
+	if orderId == 0 {
+		log.Error(constant.ErrInvalidArgument)
+		return nil, constant.ErrInvalidArgument
+	}
+
+	order, err := o.orderRepository.FindById(ctx, orderId)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	if order == nil {
+		log.Error(constant.ErrNotFound)
+		return nil, constant.ErrNotFound
+	}
+
+	return order, nil
+}
+
+func (o *orderService) FindAll(ctx context.Context, query model.OrderQuery) ([]*model.Order, *model.Pagination, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Find All Order Service",
+		"query":   query,
+	})
+
+	if err := query.Validate(); err != nil {
+		log.Error(err)
+		return nil, nil, constant.HttpValidationOrInternalErr(err)
+	}
+
+	query.SetDefault()
+
+	orders, err := o.orderRepository.FindAll(ctx, query)
+	if err != nil {
+		log.Error(err)
+		return nil, nil, err
+	}
+
+	total, err := o.orderRepository.CountAll(ctx, query)
+	if err != nil {
+		log.Error(err)
+		return nil, nil, err
+	}
+
+	return orders, &model.Pagination{
+		Total: total,
+		Page:  query.Page,
+		Limit: query.Limit,
+	}, nil
+}
+
+// orderItem price the ordered variant at the store price, the variant must be active and belong to the ordered cake
+// and the cake must be available in the store
+func (o *orderService) orderItem(ctx context.Context, storeId int, req model.CreateOrderItemRequest, currency string) (*model.OrderItem, error) {
+	cake, err := o.cakeRepository.FindById(ctx, req.CakeId)
+	if err != nil {
+		return nil, err
+	}
+
+	if cake == nil {
+		return nil, constant.ErrNotFound
+	}
+
+	variant, err := o.variantRepository.FindById(ctx, req.VariantId)
+	if err != nil {
+		return nil, err
+	}
+
+	if variant == nil || variant.CakeId != cake.Id || !variant.Active {
+		return nil, constant.ErrNotFound
+	}
+
+	price, err := storePrice(ctx, o.storeRepository, storeId, variant)
+	if err != nil {
+		return nil, err
+	}
+
+	price, err = convertPrice(ctx, o.exchangeRate, price, currency)
+	if err != nil {
+		return nil, err
+	}
+
+	options, err := o.optionService.Select(ctx, cake.Id, req.Options, currency)
+	if err != nil {
+		return nil, err
+	}
+	price.Amount += options.Amount()
+
+	item := &model.OrderItem{
+		CakeId:    cake.Id,
+		VariantId: variant.Id,
+		Title:     cake.Title,
+		Size:      variant.Size,
+		Sku:       variant.Sku,
+		Quantity:  req.Quantity,
+		Message:   req.Message,
+		Options:   options,
+		UnitPrice: price,
+	}
+	item.SetSubtotal()
+
+	return item, nil
+}
+
+// releaseSlot give the slot booked for an order back when the order was not placed or is cancelled, a failure is
+// only logged as the reconcile fix the booking counter
+func (o *orderService) releaseSlot(ctx context.Context, log *logrus.Entry, order *model.Order) {
+	if order.PickupSlot == nil {
+		return
+	}
+	if err := o.slotService.Release(ctx, order.StoreId, *order.PickupSlot, order.Units()); err != nil {
+		log.Error(err)
+	}
+}
+
+// applyCoupons apply and redeem the coupons on the order items, the first rejected coupon reject the order
+func (o *orderService) applyCoupons(ctx context.Context, order *model.Order, codes []string) error {
+	lines := make([]*model.DiscountLine, 0, len(order.Items))
+	for _, item := range order.Items {
+		lines = append(lines, &model.DiscountLine{CakeId: item.CakeId, Quantity: item.Quantity, UnitPrice: item.UnitPrice})
+	}
+
+	discount, err := o.couponService.Apply(ctx, codes, lines)
+	if err != nil {
+		return err
+	}
+
+	if rejected := discount.Rejected(); rejected != nil {
+		return constant.CouponRejectedErr(rejected.Code, rejected.Reason)
+	}
+
+	if err = o.couponService.Redeem(ctx, discount); err != nil {
+		return err
+	}
+
+	order.Discount = discount.Amount
+	for _, applied := range discount.Coupons {
+		order.Discounts = append(order.Discounts, &model.OrderDiscount{
+			CouponId: applied.Coupon.Id,
+			Code:     applied.Code,
+			Amount:   applied.Discount,
+		})
+	}
+	return nil
+}
+
+// pickupDate parse the pickup date of an order placed at now, an empty date is the day of now and a past date is
+// rejected
+func pickupDate(date string, now time.Time) (time.Time, error) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	if date == "" {
+		return today, nil
+	}
+
+	pickup, err := time.ParseInLocation(model.DateLayout, date, time.Local)
+	if err != nil || pickup.Before(today) {
+		return time.Time{}, constant.ErrInvalidArgument
+	}
+	return pickup, nil
+}
+
+// pickupSlot return the start of the pickup slot at the clock time on the pickup date
+func pickupSlot(date time.Time, clock string) (time.Time, error) {
+	at, err := time.ParseInLocation(model.ClockLayout, clock, time.Local)
+	if err != nil {
+		return time.Time{}, constant.ErrInvalidArgument
+	}
+	return time.Date(date.Year(), date.Month(), date.Day(), at.Hour(), at.Minute(), 0, 0, time.Local), nil
+}
+
+// storeOrDefault return the store, the default store when empty
+func storeOrDefault(storeId int) int {
+	if storeId == 0 {
+		return config.DefaultStoreId()
+	}
+	return storeId
+}
+
+// storePrice return the price of the variant in the store, the variant price unless the store override it. A cake the
+// store does not sell is ErrCakeUnavailable. Orders, carts, coupon checks and option quotes all price through it so
+// they agree on the price
+func storePrice(ctx context.Context, storeRepository model.StoreRepository, storeId int, variant *model.Variant) (model.Money, error) {
+	storeCake, err := storeRepository.FindCake(ctx, storeId, variant.CakeId)
+	if err != nil {
+		return model.Money{}, err
+	}
+
+	if storeCake == nil {
+		return variant.Price, nil
+	}
+
+	if !storeCake.Available {
+		return model.Money{}, constant.ErrCakeUnavailable
+	}
+
+	if price, ok := storeCake.Price(variant.Id); ok {
+		return price, nil
+	}
+	return variant.Price, nil
+}
+
+// convertPrice convert the price to the currency, a price already in the currency is kept as is
+func convertPrice(ctx context.Context, exchangeRate model.ExchangeRateProvider, price model.Money, currency string) (model.Money, error) {
+	if price.Currency == currency {
+		return price, nil
+	}
+	if exchangeRate == nil {
+		return model.Money{}, constant.ErrUnsupportedCurrency
+	}
+
+	rate, err := exchangeRate.Rate(ctx, price.Currency, currency)
+	if err != nil {
+		return model.Money{}, err
+	}
+	return price.Convert(currency, rate), nil
+}
@@ -0,0 +1,522 @@
+package service
+
+import (
+	"cake-store/src/constant"
+	"cake-store/src/model"
+	"cake-store/src/model/mock"
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrderService_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.TODO()
+	mockOrderRepo := mock.NewMockOrderRepository(ctrl)
+	mockStoreRepo := mock.NewMockStoreRepository(ctrl)
+	mockCakeRepo := mock.NewMockCakeRepository(ctrl)
+	mockVariantRepo := mock.NewMockVariantRepository(ctrl)
+	mockCouponService := mock.NewMockCouponService(ctrl)
+	mockOptionService := mock.NewMockOptionService(ctrl)
+	mockSlotService := mock.NewMockSlotService(ctrl)
+	mockExchangeRate := mock.NewMockExchangeRateProvider(ctrl)
+
+	orderService := &orderService{
+		orderRepository:   mockOrderRepo,
+		storeRepository:   mockStoreRepo,
+		cakeRepository:    mockCakeRepo,
+		variantRepository: mockVariantRepo,
+		couponService:     mockCouponService,
+		optionService:     mockOptionService,
+		slotService:       mockSlotService,
+		exchangeRate:      mockExchangeRate,
+	}
+
+	cake := &model.Cake{Id: 1, Title: "Kue Test", Version: 1}
+	variant := &model.Variant{Id: 5, CakeId: cake.Id, Size: "20cm", Sku: "CHOCO-20", Price: model.NewMoney(250000, "IDR"), Active: true}
+	req := model.CreateOrderRequest{
+		CustomerName:  "Budi",
+		CustomerPhone: "0812",
+		Fulfillment:   model.FulfillmentPickup,
+		Items:         []model.CreateOrderItemRequest{{CakeId: cake.Id, VariantId: variant.Id, Quantity: 2}},
+	}
+
+	// the orders without a store are placed in the default store, which has no setting for the cake
+	mockStoreRepo.EXPECT().FindById(gomock.Any(), 1).AnyTimes().Return(&model.Store{Id: 1, Active: true}, nil)
+	mockStoreRepo.EXPECT().FindCake(gomock.Any(), 1, cake.Id).AnyTimes().Return(nil, nil)
+
+	t.Run("ok", func(t *testing.T) {
+		mockCakeRepo.EXPECT().FindById(gomock.Any(), cake.Id).Times(1).Return(cake, nil)
+		mockVariantRepo.EXPECT().FindById(gomock.Any(), variant.Id).Times(1).Return(variant, nil)
+		mockOptionService.EXPECT().Select(gomock.Any(), cake.Id, gomock.Any(), "IDR").Times(1).Return(nil, nil)
+		mockOrderRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+
+		res, err := orderService.Create(ctx, req)
+		require.NoError(t, err)
+		assert.Equal(t, 1, res.StoreId)
+		assert.Equal(t, model.OrderStatusPending, res.Status)
+		assert.Equal(t, model.NewMoney(500000, "IDR"), res.Total)
+		assert.Equal(t, "Kue Test", res.Items[0].Title)
+	})
+
+	t.Run("ok - converted price", func(t *testing.T) {
+		variant := &model.Variant{Id: 6, CakeId: cake.Id, Price: model.NewMoney(1000, "USD"), Active: true}
+		req := req
+		req.Items = []model.CreateOrderItemRequest{{CakeId: cake.Id, VariantId: variant.Id, Quantity: 1}}
+
+		mockCakeRepo.EXPECT().FindById(gomock.Any(), cake.Id).Times(1).Return(cake, nil)
+		mockVariantRepo.EXPECT().FindById(gomock.Any(), variant.Id).Times(1).Return(variant, nil)
+		mockOptionService.EXPECT().Select(gomock.Any(), cake.Id, gomock.Any(), "IDR").Times(1).Return(nil, nil)
+		mockExchangeRate.EXPECT().Rate(gomock.Any(), "USD", "IDR").Times(1).Return(big.NewRat(16000, 1), nil)
+		mockOrderRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+
+		res, err := orderService.Create(ctx, req)
+		require.NoError(t, err)
+		assert.Equal(t, model.NewMoney(16000000, "IDR"), res.Total)
+	})
+
+	t.Run("ok - options", func(t *testing.T) {
+		req := req
+		req.Items = []model.CreateOrderItemRequest{{CakeId: cake.Id, VariantId: variant.Id, Quantity: 2,
+			Options: []model.OptionSelection{{Group: "frosting", Choices: []string{"fondant"}}}}}
+		options := model.SelectedOptions{{Group: "frosting", GroupName: "Frosting", Choice: "fondant", ChoiceName: "Fondant", PriceModifier: model.NewMoney(50000, "IDR")}}
+
+		mockCakeRepo.EXPECT().FindById(gomock.Any(), cake.Id).Times(1).Return(cake, nil)
+		mockVariantRepo.EXPECT().FindById(gomock.Any(), variant.Id).Times(1).Return(variant, nil)
+		mockOptionService.EXPECT().Select(gomock.Any(), cake.Id, req.Items[0].Options, "IDR").Times(1).Return(options, nil)
+		mockOrderRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+
+		res, err := orderService.Create(ctx, req)
+		require.NoError(t, err)
+		assert.Equal(t, model.NewMoney(300000, "IDR"), res.Items[0].UnitPrice)
+		assert.Equal(t, model.NewMoney(600000, "IDR"), res.Total)
+		assert.Equal(t, options, res.Items[0].Options)
+	})
+
+	t.Run("options rejected", func(t *testing.T) {
+		mockCakeRepo.EXPECT().FindById(gomock.Any(), cake.Id).Times(1).Return(cake, nil)
+		mockVariantRepo.EXPECT().FindById(gomock.Any(), variant.Id).Times(1).Return(variant, nil)
+		mockOptionService.EXPECT().Select(gomock.Any(), cake.Id, gomock.Any(), "IDR").Times(1).
+			Return(nil, constant.OptionsRejectedErr("Size is required"))
+		mockOrderRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Times(0)
+
+		res, err := orderService.Create(ctx, req)
+		assert.Equal(t, constant.OptionsRejectedErr("Size is required"), err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("ok - coupon", func(t *testing.T) {
+		req := req
+		req.Coupons = []string{"HEMAT10"}
+		coupon := &model.Coupon{Id: 7, Code: "HEMAT10"}
+		discount := &model.Discount{
+			Subtotal: model.NewMoney(500000, "IDR"),
+			Amount:   model.NewMoney(50000, "IDR"),
+			Total:    model.NewMoney(450000, "IDR"),
+			Coupons:  []*model.AppliedCoupon{{Code: "HEMAT10", Valid: true, Discount: model.NewMoney(50000, "IDR"), Coupon: coupon}},
+		}
+
+		mockCakeRepo.EXPECT().FindById(gomock.Any(), cake.Id).Times(1).Return(cake, nil)
+		mockVariantRepo.EXPECT().FindById(gomock.Any(), variant.Id).Times(1).Return(variant, nil)
+		mockOptionService.EXPECT().Select(gomock.Any(), cake.Id, gomock.Any(), "IDR").Times(1).Return(nil, nil)
+		mockCouponService.EXPECT().Apply(gomock.Any(), []string{"HEMAT10"}, []*model.DiscountLine{{CakeId: cake.Id, Quantity: 2, UnitPrice: model.NewMoney(250000, "IDR")}}).
+			Times(1).Return(discount, nil)
+		mockCouponService.EXPECT().Redeem(gomock.Any(), discount).Times(1).Return(nil)
+		mockOrderRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+
+		res, err := orderService.Create(ctx, req)
+		require.NoError(t, err)
+		assert.Equal(t, model.NewMoney(500000, "IDR"), res.Subtotal)
+		assert.Equal(t, model.NewMoney(50000, "IDR"), res.Discount)
+		assert.Equal(t, model.NewMoney(450000, "IDR"), res.Total)
+		require.Len(t, res.Discounts, 1)
+		assert.Equal(t, 7, res.Discounts[0].CouponId)
+	})
+
+	t.Run("coupon rejected", func(t *testing.T) {
+		req := req
+		req.Coupons = []string{"OLD"}
+		discount := &model.Discount{Coupons: []*model.AppliedCoupon{{Code: "OLD", Reason: model.CouponReasonExpired}}}
+
+		mockCakeRepo.EXPECT().FindById(gomock.Any(), cake.Id).Times(1).Return(cake, nil)
+		mockVariantRepo.EXPECT().FindById(gomock.Any(), variant.Id).Times(1).Return(variant, nil)
+		mockOptionService.EXPECT().Select(gomock.Any(), cake.Id, gomock.Any(), "IDR").Times(1).Return(nil, nil)
+		mockCouponService.EXPECT().Apply(gomock.Any(), []string{"OLD"}, gomock.Any()).Times(1).Return(discount, nil)
+		mockCouponService.EXPECT().Redeem(gomock.Any(), gomock.Any()).Times(0)
+		mockOrderRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Times(0)
+
+		res, err := orderService.Create(ctx, req)
+		assert.Equal(t, constant.CouponRejectedErr("OLD", model.CouponReasonExpired), err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("failed to save - coupon released", func(t *testing.T) {
+		req := req
+		req.Coupons = []string{"HEMAT10"}
+		discount := &model.Discount{
+			Amount:  model.NewMoney(50000, "IDR"),
+			Coupons: []*model.AppliedCoupon{{Code: "HEMAT10", Valid: true, Discount: model.NewMoney(50000, "IDR"), Coupon: &model.Coupon{Id: 7}}},
+		}
+
+		mockCakeRepo.EXPECT().FindById(gomock.Any(), cake.Id).Times(1).Return(cake, nil)
+		mockVariantRepo.EXPECT().FindById(gomock.Any(), variant.Id).Times(1).Return(variant, nil)
+		mockOptionService.EXPECT().Select(gomock.Any(), cake.Id, gomock.Any(), "IDR").Times(1).Return(nil, nil)
+		mockCouponService.EXPECT().Apply(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(discount, nil)
+		mockCouponService.EXPECT().Redeem(gomock.Any(), discount).Times(1).Return(nil)
+		mockOrderRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Times(1).Return(errors.New("err db"))
+		mockCouponService.EXPECT().Release(gomock.Any(), 7).Times(1).Return(nil)
+
+		res, err := orderService.Create(ctx, req)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+
+	tomorrow := time.Now().AddDate(0, 0, 1)
+	slot := time.Date(tomorrow.Year(), tomorrow.Month(), tomorrow.Day(), 10, 0, 0, 0, time.Local)
+
+	t.Run("ok - pickup slot", func(t *testing.T) {
+		req := req
+		req.PickupDate = slot.Format(model.DateLayout)
+		req.PickupTime = "10:00"
+
+		mockCakeRepo.EXPECT().FindById(gomock.Any(), cake.Id).Times(1).Return(cake, nil)
+		mockVariantRepo.EXPECT().FindById(gomock.Any(), variant.Id).Times(1).Return(variant, nil)
+		mockOptionService.EXPECT().Select(gomock.Any(), cake.Id, gomock.Any(), "IDR").Times(1).Return(nil, nil)
+		mockSlotService.EXPECT().Book(gomock.Any(), 1, slot, 2).Times(1).Return(nil)
+		mockOrderRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+
+		res, err := orderService.Create(ctx, req)
+		require.NoError(t, err)
+		require.NotNil(t, res.PickupSlot)
+		assert.Equal(t, slot, *res.PickupSlot)
+	})
+
+	t.Run("pickup slot full", func(t *testing.T) {
+		req := req
+		req.PickupDate = slot.Format(model.DateLayout)
+		req.PickupTime = "10:00"
+
+		mockCakeRepo.EXPECT().FindById(gomock.Any(), cake.Id).Times(1).Return(cake, nil)
+		mockVariantRepo.EXPECT().FindById(gomock.Any(), variant.Id).Times(1).Return(variant, nil)
+		mockOptionService.EXPECT().Select(gomock.Any(), cake.Id, gomock.Any(), "IDR").Times(1).Return(nil, nil)
+		mockSlotService.EXPECT().Book(gomock.Any(), 1, slot, 2).Times(1).Return(constant.ErrSlotFull)
+		mockOrderRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Times(0)
+
+		res, err := orderService.Create(ctx, req)
+		assert.Equal(t, constant.ErrSlotFull, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("failed to save - slot released", func(t *testing.T) {
+		req := req
+		req.PickupDate = slot.Format(model.DateLayout)
+		req.PickupTime = "10:00"
+
+		mockCakeRepo.EXPECT().FindById(gomock.Any(), cake.Id).Times(1).Return(cake, nil)
+		mockVariantRepo.EXPECT().FindById(gomock.Any(), variant.Id).Times(1).Return(variant, nil)
+		mockOptionService.EXPECT().Select(gomock.Any(), cake.Id, gomock.Any(), "IDR").Times(1).Return(nil, nil)
+		mockSlotService.EXPECT().Book(gomock.Any(), 1, slot, 2).Times(1).Return(nil)
+		mockOrderRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Times(1).Return(errors.New("err db"))
+		mockSlotService.EXPECT().Release(gomock.Any(), 1, slot, 2).Times(1).Return(nil)
+
+		res, err := orderService.Create(ctx, req)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("ok - store price", func(t *testing.T) {
+		req := req
+		req.StoreId = 2
+		storeCake := &model.StoreCake{StoreId: 2, CakeId: cake.Id, Available: true,
+			Prices: []*model.StorePrice{{VariantId: variant.Id, Price: model.NewMoney(275000, "IDR")}}}
+
+		mockStoreRepo.EXPECT().FindById(gomock.Any(), 2).Times(1).Return(&model.Store{Id: 2, Active: true}, nil)
+		mockCakeRepo.EXPECT().FindById(gomock.Any(), cake.Id).Times(1).Return(cake, nil)
+		mockVariantRepo.EXPECT().FindById(gomock.Any(), variant.Id).Times(1).Return(variant, nil)
+		mockStoreRepo.EXPECT().FindCake(gomock.Any(), 2, cake.Id).Times(1).Return(storeCake, nil)
+		mockOptionService.EXPECT().Select(gomock.Any(), cake.Id, gomock.Any(), "IDR").Times(1).Return(nil, nil)
+		mockOrderRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+
+		res, err := orderService.Create(ctx, req)
+		require.NoError(t, err)
+		assert.Equal(t, 2, res.StoreId)
+		assert.Equal(t, model.NewMoney(275000, "IDR"), res.Items[0].UnitPrice)
+		assert.Equal(t, model.NewMoney(550000, "IDR"), res.Total)
+	})
+
+	t.Run("cake unavailable in the store", func(t *testing.T) {
+		req := req
+		req.StoreId = 2
+
+		mockStoreRepo.EXPECT().FindById(gomock.Any(), 2).Times(1).Return(&model.Store{Id: 2, Active: true}, nil)
+		mockCakeRepo.EXPECT().FindById(gomock.Any(), cake.Id).Times(1).Return(cake, nil)
+		mockVariantRepo.EXPECT().FindById(gomock.Any(), variant.Id).Times(1).Return(variant, nil)
+		mockStoreRepo.EXPECT().FindCake(gomock.Any(), 2, cake.Id).Times(1).Return(&model.StoreCake{StoreId: 2, CakeId: cake.Id}, nil)
+		mockOrderRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Times(0)
+
+		res, err := orderService.Create(ctx, req)
+		assert.Equal(t, constant.ErrCakeUnavailable, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("inactive store", func(t *testing.T) {
+		req := req
+		req.StoreId = 3
+
+		mockStoreRepo.EXPECT().FindById(gomock.Any(), 3).Times(1).Return(&model.Store{Id: 3, Active: false}, nil)
+		mockOrderRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Times(0)
+
+		res, err := orderService.Create(ctx, req)
+		assert.Equal(t, constant.ErrNotFound, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("inactive variant", func(t *testing.T) {
+		inactive := *variant
+		inactive.Active = false
+
+		mockCakeRepo.EXPECT().FindById(gomock.Any(), cake.Id).Times(1).Return(cake, nil)
+		mockVariantRepo.EXPECT().FindById(gomock.Any(), variant.Id).Times(1).Return(&inactive, nil)
+		mockOrderRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Times(0)
+
+		res, err := orderService.Create(ctx, req)
+		assert.Equal(t, constant.ErrNotFound, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("cake not found", func(t *testing.T) {
+		mockCakeRepo.EXPECT().FindById(gomock.Any(), cake.Id).Times(1).Return(nil, nil)
+
+		res, err := orderService.Create(ctx, req)
+		assert.Equal(t, constant.ErrNotFound, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("pickup date in the past", func(t *testing.T) {
+		req := req
+		req.PickupDate = time.Now().AddDate(0, 0, -1).Format(model.DateLayout)
+
+		res, err := orderService.Create(ctx, req)
+		assert.Equal(t, constant.ErrInvalidArgument, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("validate error", func(t *testing.T) {
+		req := req
+		req.Items = nil
+
+		res, err := orderService.Create(ctx, req)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestOrderService_Transition(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.TODO()
+	mockOrderRepo := mock.NewMockOrderRepository(ctrl)
+	mockCouponService := mock.NewMockCouponService(ctrl)
+	mockInventoryService := mock.NewMockInventoryService(ctrl)
+	mockSlotService := mock.NewMockSlotService(ctrl)
+
+	orderService := &orderService{
+		orderRepository:  mockOrderRepo,
+		couponService:    mockCouponService,
+		inventoryService: mockInventoryService,
+		slotService:      mockSlotService,
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		order := &model.Order{Id: 3, Status: model.OrderStatusPending, Fulfillment: model.FulfillmentPickup}
+		mockOrderRepo.EXPECT().FindById(gomock.Any(), 3).Times(1).Return(order, nil)
+		gomock.InOrder(
+			mockInventoryService.EXPECT().Consume(gomock.Any(), order).Times(1).Return(nil, nil),
+			mockOrderRepo.EXPECT().UpdateStatus(gomock.Any(), order, model.OrderStatusPending).Times(1).Return(nil),
+		)
+
+		res, err := orderService.Transition(ctx, model.TransitionOrderRequest{Status: model.OrderStatusConfirmed}, 3)
+		require.NoError(t, err)
+		assert.Equal(t, model.OrderStatusConfirmed, res.Status)
+	})
+
+	t.Run("confirmed cancelled - ingredients restored", func(t *testing.T) {
+		order := &model.Order{Id: 3, Status: model.OrderStatusConfirmed, Fulfillment: model.FulfillmentPickup}
+		mockOrderRepo.EXPECT().FindById(gomock.Any(), 3).Times(1).Return(order, nil)
+		mockOrderRepo.EXPECT().UpdateStatus(gomock.Any(), order, model.OrderStatusConfirmed).Times(1).Return(nil)
+		mockInventoryService.EXPECT().Restore(gomock.Any(), order).Times(1).Return(nil)
+
+		res, err := orderService.Transition(ctx, model.TransitionOrderRequest{Status: model.OrderStatusCancelled}, 3)
+		require.NoError(t, err)
+		assert.Equal(t, model.OrderStatusCancelled, res.Status)
+	})
+
+	t.Run("error from inventory - status not written", func(t *testing.T) {
+		order := &model.Order{Id: 3, Status: model.OrderStatusPending, Fulfillment: model.FulfillmentPickup}
+		mockOrderRepo.EXPECT().FindById(gomock.Any(), 3).Times(1).Return(order, nil)
+		mockInventoryService.EXPECT().Consume(gomock.Any(), order).Times(1).Return(nil, errors.New("err db"))
+		mockOrderRepo.EXPECT().UpdateStatus(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		res, err := orderService.Transition(ctx, model.TransitionOrderRequest{Status: model.OrderStatusConfirmed}, 3)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("error from status write - only its own ingredients given back", func(t *testing.T) {
+		order := &model.Order{Id: 3, Status: model.OrderStatusPending, Fulfillment: model.FulfillmentPickup}
+		consumed := []*model.InventoryAdjustment{{Id: 12, IngredientId: 2, OrderId: 3, Delta: -625, Unit: model.UnitGram}}
+		mockOrderRepo.EXPECT().FindById(gomock.Any(), 3).Times(1).Return(order, nil)
+		mockInventoryService.EXPECT().Restore(gomock.Any(), gomock.Any()).Times(0)
+		gomock.InOrder(
+			mockInventoryService.EXPECT().Consume(gomock.Any(), order).Times(1).Return(consumed, nil),
+			mockOrderRepo.EXPECT().UpdateStatus(gomock.Any(), order, model.OrderStatusPending).Times(1).Return(constant.ErrVersionConflict),
+			mockInventoryService.EXPECT().Undo(gomock.Any(), order, consumed).Times(1).Return(nil),
+		)
+
+		res, err := orderService.Transition(ctx, model.TransitionOrderRequest{Status: model.OrderStatusConfirmed}, 3)
+		assert.Equal(t, constant.ErrVersionConflict, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("cancelled - everything given back despite a failure", func(t *testing.T) {
+		slot := time.Date(2026, 10, 20, 10, 0, 0, 0, time.Local)
+		order := &model.Order{Id: 3, StoreId: 2, Status: model.OrderStatusConfirmed, Fulfillment: model.FulfillmentPickup, PickupSlot: &slot,
+			Items: []*model.OrderItem{{Quantity: 1}}, Discounts: []*model.OrderDiscount{{CouponId: 7, Code: "HEMAT10"}}}
+		mockOrderRepo.EXPECT().FindById(gomock.Any(), 3).Times(1).Return(order, nil)
+		mockOrderRepo.EXPECT().UpdateStatus(gomock.Any(), order, model.OrderStatusConfirmed).Times(1).Return(nil)
+		mockCouponService.EXPECT().Release(gomock.Any(), 7).Times(1).Return(errors.New("err db"))
+		mockSlotService.EXPECT().Release(gomock.Any(), 2, slot, 1).Times(1).Return(nil)
+		mockInventoryService.EXPECT().Restore(gomock.Any(), order).Times(1).Return(nil)
+
+		res, err := orderService.Transition(ctx, model.TransitionOrderRequest{Status: model.OrderStatusCancelled}, 3)
+		require.NoError(t, err)
+		assert.Equal(t, model.OrderStatusCancelled, res.Status)
+	})
+
+	t.Run("cancelled - coupons released", func(t *testing.T) {
+		order := &model.Order{Id: 3, Status: model.OrderStatusPending, Fulfillment: model.FulfillmentPickup,
+			Discounts: []*model.OrderDiscount{{CouponId: 7, Code: "HEMAT10"}}}
+		mockOrderRepo.EXPECT().FindById(gomock.Any(), 3).Times(1).Return(order, nil)
+		mockOrderRepo.EXPECT().UpdateStatus(gomock.Any(), order, model.OrderStatusPending).Times(1).Return(nil)
+		mockCouponService.EXPECT().Release(gomock.Any(), 7).Times(1).Return(nil)
+
+		res, err := orderService.Transition(ctx, model.TransitionOrderRequest{Status: model.OrderStatusCancelled}, 3)
+		require.NoError(t, err)
+		assert.Equal(t, model.OrderStatusCancelled, res.Status)
+	})
+
+	t.Run("cancelled - slot released", func(t *testing.T) {
+		slot := time.Date(2026, 10, 20, 10, 0, 0, 0, time.Local)
+		order := &model.Order{Id: 3, StoreId: 2, Status: model.OrderStatusPending, Fulfillment: model.FulfillmentPickup, PickupSlot: &slot,
+			Items: []*model.OrderItem{{Quantity: 2}, {Quantity: 1}}}
+		mockOrderRepo.EXPECT().FindById(gomock.Any(), 3).Times(1).Return(order, nil)
+		mockOrderRepo.EXPECT().UpdateStatus(gomock.Any(), order, model.OrderStatusPending).Times(1).Return(nil)
+		mockSlotService.EXPECT().Release(gomock.Any(), 2, slot, 3).Times(1).Return(nil)
+
+		res, err := orderService.Transition(ctx, model.TransitionOrderRequest{Status: model.OrderStatusCancelled}, 3)
+		require.NoError(t, err)
+		assert.Equal(t, model.OrderStatusCancelled, res.Status)
+	})
+
+	t.Run("illegal transition", func(t *testing.T) {
+		order := &model.Order{Id: 3, Status: model.OrderStatusPending, Fulfillment: model.FulfillmentPickup}
+		mockOrderRepo.EXPECT().FindById(gomock.Any(), 3).Times(1).Return(order, nil)
+		mockOrderRepo.EXPECT().UpdateStatus(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		res, err := orderService.Transition(ctx, model.TransitionOrderRequest{Status: model.OrderStatusReady}, 3)
+		assert.Equal(t, constant.ErrInvalidTransition, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("moved concurrently", func(t *testing.T) {
+		order := &model.Order{Id: 3, Status: model.OrderStatusConfirmed, Fulfillment: model.FulfillmentPickup}
+		mockOrderRepo.EXPECT().FindById(gomock.Any(), 3).Times(1).Return(order, nil)
+		mockOrderRepo.EXPECT().UpdateStatus(gomock.Any(), order, model.OrderStatusConfirmed).Times(1).Return(constant.ErrVersionConflict)
+
+		res, err := orderService.Transition(ctx, model.TransitionOrderRequest{Status: model.OrderStatusCancelled}, 3)
+		assert.Equal(t, constant.ErrVersionConflict, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("unknown status", func(t *testing.T) {
+		res, err := orderService.Transition(ctx, model.TransitionOrderRequest{Status: "eaten"}, 3)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mockOrderRepo.EXPECT().FindById(gomock.Any(), 4).Times(1).Return(nil, nil)
+
+		res, err := orderService.Transition(ctx, model.TransitionOrderRequest{Status: model.OrderStatusConfirmed}, 4)
+		assert.Equal(t, constant.ErrNotFound, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestOrderService_FindAll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.TODO()
+	mockOrderRepo := mock.NewMockOrderRepository(ctrl)
+
+	orderService := &orderService{
+		orderRepository: mockOrderRepo,
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		orders := []*model.Order{{Id: 3}}
+		query := model.OrderQuery{Page: 1, Limit: 10}
+		mockOrderRepo.EXPECT().FindAll(gomock.Any(), query).Times(1).Return(orders, nil)
+		mockOrderRepo.EXPECT().CountAll(gomock.Any(), query).Times(1).Return(int64(1), nil)
+
+		res, pagination, err := orderService.FindAll(ctx, model.OrderQuery{})
+		require.NoError(t, err)
+		assert.Equal(t, orders, res)
+		assert.Equal(t, int64(1), pagination.Total)
+	})
+
+	t.Run("error from repo", func(t *testing.T) {
+		mockOrderRepo.EXPECT().FindAll(gomock.Any(), gomock.Any()).Times(1).Return(nil, errors.New("err db"))
+
+		res, pagination, err := orderService.FindAll(ctx, model.OrderQuery{})
+		assert.Error(t, err)
+		assert.Nil(t, res)
+		assert.Nil(t, pagination)
+	})
+}
+
+func TestPickupDate(t *testing.T) {
+	now := time.Date(2026, 10, 18, 15, 30, 0, 0, time.Local)
+
+	res, err := pickupDate("", now)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 10, 18, 0, 0, 0, 0, time.Local), res)
+
+	res, err = pickupDate("2026-10-20", now)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 10, 20, 0, 0, 0, 0, time.Local), res)
+
+	_, err = pickupDate("2026-10-17", now)
+	assert.Equal(t, constant.ErrInvalidArgument, err)
+}
+
+func TestPickupSlot(t *testing.T) {
+	date := time.Date(2026, 10, 20, 0, 0, 0, 0, time.Local)
+
+	res, err := pickupSlot(date, "14:30")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 10, 20, 14, 30, 0, 0, time.Local), res)
+
+	_, err = pickupSlot(date, "25:00")
+	assert.Equal(t, constant.ErrInvalidArgument, err)
+}
@@ -0,0 +1,312 @@
+package service
+
+import (
+	"cake-store/src/constant"
+	"cake-store/src/model"
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+type paymentService struct {
+	paymentRepository model.PaymentRepository
+	orderRepository   model.OrderRepository
+	gateway           model.PaymentGateway
+}
+
+func NewPaymentService(paymentRepository model.PaymentRepository, orderRepository model.OrderRepository, gateway model.PaymentGateway) model.PaymentService {
+	return &paymentService{
+		paymentRepository: paymentRepository,
+		orderRepository:   orderRepository,
+		gateway:           gateway,
+	}
+}
+
+// Authorize hold the total of the order on the payment method of the token, a declined attempt is saved and returned
+// so the customer can try again. The database keep one live payment per order, the authorization losing a concurrent
+// attempt is not saved and lapse at the gateway.
+func (p *paymentService) Authorize(ctx context.Context, req model.AuthorizePaymentRequest, orderId int) (*model.Payment, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Authorize Payment Service",
+		"orderId": orderId,
+	})
+
+	if err := req.Validate(); err != nil {
+		log.Error(err)
+		return nil, constant.HttpValidationOrInternalErr(err)
+	}
+
+	order, err := p.findOrder(ctx, orderId)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	if order.Status == model.OrderStatusCancelled {
+		log.Error(constant.ErrInvalidArgument)
+		return nil, constant.ErrInvalidArgument
+	}
+
+	payments, err := p.paymentRepository.FindByOrderId(ctx, orderId)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	for _, payment := range payments {
+		if payment.Live() {
+			log.Error(constant.ErrAlreadyPaid)
+			return nil, constant.ErrAlreadyPaid
+		}
+	}
+
+	result, err := p.gateway.Authorize(ctx, model.GatewayAuthorization{OrderId: order.Id, Amount: order.Total, Token: req.Token})
+	if err != nil {
+		log.Error(err)
+		return nil, constant.ErrPaymentGateway
+	}
+
+	if result.Status != model.PaymentStatusAuthorized && result.Status != model.PaymentStatusDeclined {
+		log.Errorf("unexpected authorization status %s", result.Status)
+		return nil, constant.ErrPaymentGateway
+	}
+
+	now := time.Now()
+	payment := &model.Payment{
+		OrderId:   order.Id,
+		Provider:  p.gateway.Name(),
+		Reference: result.Reference,
+		Status:    result.Status,
+		Amount:    order.Total,
+		Reason:    result.Reason,
+		Events:    []*model.PaymentEvent{{To: result.Status, Reason: result.Reason, CreatedAt: now}},
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err = p.paymentRepository.Save(ctx, payment); err == constant.ErrAlreadyExists {
+		log.Error(constant.ErrAlreadyPaid)
+		return nil, constant.ErrAlreadyPaid
+	}
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return payment, nil
+}
+
+// Capture take the authorized amount, the payment is settling until the gateway confirm the settlement when the
+// gateway settle later. The payment is claimed as capturing before the gateway is called so it is captured at most once.
+func (p *paymentService) Capture(ctx context.Context, paymentId int) (*model.Payment, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message":   "Capture Payment Service",
+		"paymentId": paymentId,
+	})
+
+	payment, err := p.claim(ctx, paymentId, model.PaymentStatusAuthorized, model.PaymentStatusCapturing)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	result, err := p.gateway.Capture(ctx, payment.Reference, payment.Amount)
+	if err != nil {
+		log.Error(err)
+		p.unclaim(ctx, log, payment, model.PaymentStatusAuthorized)
+		return nil, constant.ErrPaymentGateway
+	}
+
+	if err = p.settle(ctx, log, payment, result, model.PaymentStatusAuthorized); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return payment, nil
+}
+
+// Refund give the captured amount back to the customer, the payment is claimed as refunding before the gateway is
+// called so it is refunded at most once
+func (p *paymentService) Refund(ctx context.Context, paymentId int) (*model.Payment, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message":   "Refund Payment Service",
+		"paymentId": paymentId,
+	})
+
+	payment, err := p.claim(ctx, paymentId, model.PaymentStatusCaptured, model.PaymentStatusRefunding)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	result, err := p.gateway.Refund(ctx, payment.Reference, payment.Amount)
+	if err != nil {
+		log.Error(err)
+		p.unclaim(ctx, log, payment, model.PaymentStatusCaptured)
+		return nil, constant.ErrPaymentGateway
+	}
+
+	if err = p.settle(ctx, log, payment, result, model.PaymentStatusCaptured); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return payment, nil
+}
+
+// HandleWebhook apply the status change notified by the gateway, the gateway may send a notification more than once
+func (p *paymentService) HandleWebhook(ctx context.Context, payload []byte, signature string) (*model.Payment, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Handle Webhook Payment Service",
+	})
+
+	event, err := p.gateway.VerifyWebhook(ctx, payload, signature)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	payment, err := p.paymentRepository.FindByReference(ctx, p.gateway.Name(), event.Reference)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	if payment == nil {
+		log.Error(constant.ErrNotFound)
+		return nil, constant.ErrNotFound
+	}
+
+	if payment.Status == event.Status {
+		return payment, nil
+	}
+
+	if !payment.CanTransition(event.Status) {
+		log.Error(constant.ErrInvalidTransition)
+		return nil, constant.ErrInvalidTransition
+	}
+
+	if err = p.transition(ctx, payment, event.Status, event.Reason); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return payment, nil
+}
+
+func (p *paymentService) FindById(ctx context.Context, paymentId int) (*model.Payment, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message":   "Find By ID Payment Service",
+		"paymentId": paymentId,
+	})
+
+	if paymentId == 0 {
+		log.Error(constant.ErrInvalidArgument)
+		return nil, constant.ErrInvalidArgument
+	}
+
+	payment, err := p.paymentRepository.FindById(ctx, paymentId)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	if payment == nil {
+		log.Error(constant.ErrNotFound)
+		return nil, constant.ErrNotFound
+	}
+
+	return payment, nil
+}
+
+func (p *paymentService) FindByOrderId(ctx context.Context, orderId int) ([]*model.Payment, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Find By Order ID Payment Service",
+		"orderId": orderId,
+	})
+
+	if _, err := p.findOrder(ctx, orderId); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	payments, err := p.paymentRepository.FindByOrderId(ctx, orderId)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return payments, nil
+}
+
+// transition move the payment to the status the gateway answered with, a status the payment may not move to is a
+// gateway error
+func (p *paymentService) transition(ctx context.Context, payment *model.Payment, status string, reason string) error {
+	if !payment.CanTransition(status) {
+		return constant.ErrPaymentGateway
+	}
+
+	from := payment.Status
+	now := time.Now()
+	payment.Status = status
+	payment.Reason = reason
+	payment.UpdatedAt = now
+
+	return p.paymentRepository.UpdateStatus(ctx, payment, from, &model.PaymentEvent{From: from, To: status, Reason: reason, CreatedAt: now})
+}
+
+// claim move the payment from the status to the claim status, the concurrent claims of the same payment fail with
+// ErrVersionConflict
+func (p *paymentService) claim(ctx context.Context, paymentId int, from string, claim string) (*model.Payment, error) {
+	payment, err := p.FindById(ctx, paymentId)
+	if err != nil {
+		return nil, err
+	}
+
+	if payment.Status != from {
+		return nil, constant.ErrInvalidTransition
+	}
+
+	if err = p.transition(ctx, payment, claim, ""); err != nil {
+		return nil, err
+	}
+
+	return payment, nil
+}
+
+// settle move the claimed payment to the status the gateway answered with, the claim is given up when the gateway
+// answered with a status the payment may not move to
+func (p *paymentService) settle(ctx context.Context, log *logrus.Entry, payment *model.Payment, result *model.GatewayResult, previous string) error {
+	if result.Status == previous || !payment.CanTransition(result.Status) {
+		p.unclaim(ctx, log, payment, previous)
+		return constant.ErrPaymentGateway
+	}
+
+	return p.transition(ctx, payment, result.Status, result.Reason)
+}
+
+// unclaim move the claimed payment back to its previous status after a failed gateway call, a failure is only logged
+// and leave the payment claimed for a manual check
+func (p *paymentService) unclaim(ctx context.Context, log *logrus.Entry, payment *model.Payment, previous string) {
+	if err := p.transition(ctx, payment, previous, "gateway error"); err != nil {
+		log.Error(err)
+	}
+}
+
+func (p *paymentService) findOrder(ctx context.Context, orderId int) (*model.Order, error) {
+	if orderId == 0 {
+		return nil, constant.ErrInvalidArgument
+	}
+
+	order, err := p.orderRepository.FindById(ctx, orderId)
+	if err != nil {
+		return nil, err
+	}
+
+	if order == nil {
+		return nil, constant.ErrNotFound
+	}
+
+	return order, nil
+}
@@ -0,0 +1,392 @@
+package service
+
+import (
+	"cake-store/src/constant"
+	"cake-store/src/model"
+	"cake-store/src/model/mock"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPaymentService_Authorize(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.TODO()
+	mockPaymentRepo := mock.NewMockPaymentRepository(ctrl)
+	mockOrderRepo := mock.NewMockOrderRepository(ctrl)
+	mockGateway := mock.NewMockPaymentGateway(ctrl)
+	mockGateway.EXPECT().Name().AnyTimes().Return("fake")
+
+	paymentService := &paymentService{
+		paymentRepository: mockPaymentRepo,
+		orderRepository:   mockOrderRepo,
+		gateway:           mockGateway,
+	}
+
+	total := model.NewMoney(2000000, "IDR")
+	order := &model.Order{Id: 4, Status: model.OrderStatusPending, Total: total}
+	req := model.AuthorizePaymentRequest{Token: "tok_success"}
+
+	t.Run("ok", func(t *testing.T) {
+		mockOrderRepo.EXPECT().FindById(gomock.Any(), 4).Times(1).Return(order, nil)
+		mockPaymentRepo.EXPECT().FindByOrderId(gomock.Any(), 4).Times(1).
+			Return([]*model.Payment{{Id: 6, Status: model.PaymentStatusDeclined}}, nil)
+		mockGateway.EXPECT().Authorize(gomock.Any(), model.GatewayAuthorization{OrderId: 4, Amount: total, Token: "tok_success"}).Times(1).
+			Return(&model.GatewayResult{Reference: "fake_success_abc", Status: model.PaymentStatusAuthorized}, nil)
+		mockPaymentRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+
+		res, err := paymentService.Authorize(ctx, req, 4)
+		require.NoError(t, err)
+		assert.Equal(t, model.PaymentStatusAuthorized, res.Status)
+		assert.Equal(t, "fake", res.Provider)
+		assert.Equal(t, total, res.Amount)
+		require.Len(t, res.Events, 1)
+		assert.Equal(t, model.PaymentStatusAuthorized, res.Events[0].To)
+	})
+
+	t.Run("ok - declined attempt is saved", func(t *testing.T) {
+		mockOrderRepo.EXPECT().FindById(gomock.Any(), 4).Times(1).Return(order, nil)
+		mockPaymentRepo.EXPECT().FindByOrderId(gomock.Any(), 4).Times(1).Return([]*model.Payment{}, nil)
+		mockGateway.EXPECT().Authorize(gomock.Any(), gomock.Any()).Times(1).
+			Return(&model.GatewayResult{Reference: "fake_declined_abc", Status: model.PaymentStatusDeclined, Reason: "card declined"}, nil)
+		mockPaymentRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+
+		res, err := paymentService.Authorize(ctx, model.AuthorizePaymentRequest{Token: "tok_decline"}, 4)
+		require.NoError(t, err)
+		assert.Equal(t, model.PaymentStatusDeclined, res.Status)
+		assert.Equal(t, "card declined", res.Reason)
+	})
+
+	t.Run("already paid", func(t *testing.T) {
+		mockOrderRepo.EXPECT().FindById(gomock.Any(), 4).Times(1).Return(order, nil)
+		mockPaymentRepo.EXPECT().FindByOrderId(gomock.Any(), 4).Times(1).
+			Return([]*model.Payment{{Id: 6, Status: model.PaymentStatusSettling}}, nil)
+		mockGateway.EXPECT().Authorize(gomock.Any(), gomock.Any()).Times(0)
+
+		res, err := paymentService.Authorize(ctx, req, 4)
+		assert.Equal(t, constant.ErrAlreadyPaid, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("cancelled order", func(t *testing.T) {
+		mockOrderRepo.EXPECT().FindById(gomock.Any(), 5).Times(1).Return(&model.Order{Id: 5, Status: model.OrderStatusCancelled}, nil)
+
+		res, err := paymentService.Authorize(ctx, req, 5)
+		assert.Equal(t, constant.ErrInvalidArgument, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("order not found", func(t *testing.T) {
+		mockOrderRepo.EXPECT().FindById(gomock.Any(), 9).Times(1).Return(nil, nil)
+
+		res, err := paymentService.Authorize(ctx, req, 9)
+		assert.Equal(t, constant.ErrNotFound, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("missing token", func(t *testing.T) {
+		res, err := paymentService.Authorize(ctx, model.AuthorizePaymentRequest{}, 4)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("paid concurrently", func(t *testing.T) {
+		mockOrderRepo.EXPECT().FindById(gomock.Any(), 4).Times(1).Return(order, nil)
+		mockPaymentRepo.EXPECT().FindByOrderId(gomock.Any(), 4).Times(1).Return([]*model.Payment{}, nil)
+		mockGateway.EXPECT().Authorize(gomock.Any(), gomock.Any()).Times(1).
+			Return(&model.GatewayResult{Reference: "fake_success_def", Status: model.PaymentStatusAuthorized}, nil)
+		mockPaymentRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Times(1).Return(constant.ErrAlreadyExists)
+
+		res, err := paymentService.Authorize(ctx, req, 4)
+		assert.Equal(t, constant.ErrAlreadyPaid, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("gateway error", func(t *testing.T) {
+		mockOrderRepo.EXPECT().FindById(gomock.Any(), 4).Times(1).Return(order, nil)
+		mockPaymentRepo.EXPECT().FindByOrderId(gomock.Any(), 4).Times(1).Return([]*model.Payment{}, nil)
+		mockGateway.EXPECT().Authorize(gomock.Any(), gomock.Any()).Times(1).Return(nil, errors.New("timeout"))
+		mockPaymentRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Times(0)
+
+		res, err := paymentService.Authorize(ctx, req, 4)
+		assert.Equal(t, constant.ErrPaymentGateway, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestPaymentService_Capture(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.TODO()
+	mockPaymentRepo := mock.NewMockPaymentRepository(ctrl)
+	mockGateway := mock.NewMockPaymentGateway(ctrl)
+
+	paymentService := &paymentService{
+		paymentRepository: mockPaymentRepo,
+		gateway:           mockGateway,
+	}
+
+	amount := model.NewMoney(2000000, "IDR")
+
+	t.Run("ok", func(t *testing.T) {
+		payment := &model.Payment{Id: 7, Reference: "fake_success_abc", Status: model.PaymentStatusAuthorized, Amount: amount}
+		mockPaymentRepo.EXPECT().FindById(gomock.Any(), 7).Times(1).Return(payment, nil)
+		gomock.InOrder(
+			mockPaymentRepo.EXPECT().UpdateStatus(gomock.Any(), payment, model.PaymentStatusAuthorized, gomock.Any()).Times(1).
+				DoAndReturn(func(_ context.Context, _ *model.Payment, _ string, event *model.PaymentEvent) error {
+					assert.Equal(t, model.PaymentStatusCapturing, event.To)
+					return nil
+				}),
+			mockGateway.EXPECT().Capture(gomock.Any(), "fake_success_abc", amount).Times(1).
+				Return(&model.GatewayResult{Reference: "fake_success_abc", Status: model.PaymentStatusCaptured}, nil),
+			mockPaymentRepo.EXPECT().UpdateStatus(gomock.Any(), payment, model.PaymentStatusCapturing, gomock.Any()).Times(1).
+				DoAndReturn(func(_ context.Context, _ *model.Payment, _ string, event *model.PaymentEvent) error {
+					assert.Equal(t, model.PaymentStatusCapturing, event.From)
+					assert.Equal(t, model.PaymentStatusCaptured, event.To)
+					return nil
+				}),
+		)
+
+		res, err := paymentService.Capture(ctx, 7)
+		require.NoError(t, err)
+		assert.Equal(t, model.PaymentStatusCaptured, res.Status)
+	})
+
+	t.Run("ok - delayed settlement", func(t *testing.T) {
+		payment := &model.Payment{Id: 8, Reference: "fake_delayed_abc", Status: model.PaymentStatusAuthorized, Amount: amount}
+		mockPaymentRepo.EXPECT().FindById(gomock.Any(), 8).Times(1).Return(payment, nil)
+		mockPaymentRepo.EXPECT().UpdateStatus(gomock.Any(), payment, model.PaymentStatusAuthorized, gomock.Any()).Times(1).Return(nil)
+		mockGateway.EXPECT().Capture(gomock.Any(), "fake_delayed_abc", amount).Times(1).
+			Return(&model.GatewayResult{Reference: "fake_delayed_abc", Status: model.PaymentStatusSettling}, nil)
+		mockPaymentRepo.EXPECT().UpdateStatus(gomock.Any(), payment, model.PaymentStatusCapturing, gomock.Any()).Times(1).Return(nil)
+
+		res, err := paymentService.Capture(ctx, 8)
+		require.NoError(t, err)
+		assert.Equal(t, model.PaymentStatusSettling, res.Status)
+	})
+
+	t.Run("claimed concurrently", func(t *testing.T) {
+		payment := &model.Payment{Id: 12, Reference: "fake_success_ghi", Status: model.PaymentStatusAuthorized, Amount: amount}
+		mockPaymentRepo.EXPECT().FindById(gomock.Any(), 12).Times(1).Return(payment, nil)
+		mockPaymentRepo.EXPECT().UpdateStatus(gomock.Any(), payment, model.PaymentStatusAuthorized, gomock.Any()).Times(1).
+			Return(constant.ErrVersionConflict)
+		mockGateway.EXPECT().Capture(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		res, err := paymentService.Capture(ctx, 12)
+		assert.Equal(t, constant.ErrVersionConflict, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("gateway error - claim given up", func(t *testing.T) {
+		payment := &model.Payment{Id: 13, Reference: "fake_success_jkl", Status: model.PaymentStatusAuthorized, Amount: amount}
+		mockPaymentRepo.EXPECT().FindById(gomock.Any(), 13).Times(1).Return(payment, nil)
+		gomock.InOrder(
+			mockPaymentRepo.EXPECT().UpdateStatus(gomock.Any(), payment, model.PaymentStatusAuthorized, gomock.Any()).Times(1).Return(nil),
+			mockGateway.EXPECT().Capture(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil, errors.New("timeout")),
+			mockPaymentRepo.EXPECT().UpdateStatus(gomock.Any(), payment, model.PaymentStatusCapturing, gomock.Any()).Times(1).
+				DoAndReturn(func(_ context.Context, _ *model.Payment, _ string, event *model.PaymentEvent) error {
+					assert.Equal(t, model.PaymentStatusAuthorized, event.To)
+					return nil
+				}),
+		)
+
+		res, err := paymentService.Capture(ctx, 13)
+		assert.Equal(t, constant.ErrPaymentGateway, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("not authorized", func(t *testing.T) {
+		mockPaymentRepo.EXPECT().FindById(gomock.Any(), 9).Times(1).Return(&model.Payment{Id: 9, Status: model.PaymentStatusDeclined}, nil)
+		mockGateway.EXPECT().Capture(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		res, err := paymentService.Capture(ctx, 9)
+		assert.Equal(t, constant.ErrInvalidTransition, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("unexpected gateway status", func(t *testing.T) {
+		payment := &model.Payment{Id: 10, Reference: "fake_success_def", Status: model.PaymentStatusAuthorized, Amount: amount}
+		mockPaymentRepo.EXPECT().FindById(gomock.Any(), 10).Times(1).Return(payment, nil)
+		mockPaymentRepo.EXPECT().UpdateStatus(gomock.Any(), payment, model.PaymentStatusAuthorized, gomock.Any()).Times(1).Return(nil)
+		mockGateway.EXPECT().Capture(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).
+			Return(&model.GatewayResult{Status: model.PaymentStatusRefunded}, nil)
+		mockPaymentRepo.EXPECT().UpdateStatus(gomock.Any(), payment, model.PaymentStatusCapturing, gomock.Any()).Times(1).
+			DoAndReturn(func(_ context.Context, _ *model.Payment, _ string, event *model.PaymentEvent) error {
+				assert.Equal(t, model.PaymentStatusAuthorized, event.To)
+				return nil
+			})
+
+		res, err := paymentService.Capture(ctx, 10)
+		assert.Equal(t, constant.ErrPaymentGateway, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mockPaymentRepo.EXPECT().FindById(gomock.Any(), 11).Times(1).Return(nil, nil)
+
+		res, err := paymentService.Capture(ctx, 11)
+		assert.Equal(t, constant.ErrNotFound, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestPaymentService_Refund(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.TODO()
+	mockPaymentRepo := mock.NewMockPaymentRepository(ctrl)
+	mockGateway := mock.NewMockPaymentGateway(ctrl)
+
+	paymentService := &paymentService{
+		paymentRepository: mockPaymentRepo,
+		gateway:           mockGateway,
+	}
+
+	amount := model.NewMoney(2000000, "IDR")
+
+	t.Run("ok", func(t *testing.T) {
+		payment := &model.Payment{Id: 7, Reference: "fake_success_abc", Status: model.PaymentStatusCaptured, Amount: amount}
+		mockPaymentRepo.EXPECT().FindById(gomock.Any(), 7).Times(1).Return(payment, nil)
+		gomock.InOrder(
+			mockPaymentRepo.EXPECT().UpdateStatus(gomock.Any(), payment, model.PaymentStatusCaptured, gomock.Any()).Times(1).Return(nil),
+			mockGateway.EXPECT().Refund(gomock.Any(), "fake_success_abc", amount).Times(1).
+				Return(&model.GatewayResult{Reference: "fake_success_abc", Status: model.PaymentStatusRefunded}, nil),
+			mockPaymentRepo.EXPECT().UpdateStatus(gomock.Any(), payment, model.PaymentStatusRefunding, gomock.Any()).Times(1).Return(nil),
+		)
+
+		res, err := paymentService.Refund(ctx, 7)
+		require.NoError(t, err)
+		assert.Equal(t, model.PaymentStatusRefunded, res.Status)
+	})
+
+	t.Run("gateway error - claim given up", func(t *testing.T) {
+		payment := &model.Payment{Id: 9, Reference: "fake_success_def", Status: model.PaymentStatusCaptured, Amount: amount}
+		mockPaymentRepo.EXPECT().FindById(gomock.Any(), 9).Times(1).Return(payment, nil)
+		gomock.InOrder(
+			mockPaymentRepo.EXPECT().UpdateStatus(gomock.Any(), payment, model.PaymentStatusCaptured, gomock.Any()).Times(1).Return(nil),
+			mockGateway.EXPECT().Refund(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil, errors.New("timeout")),
+			mockPaymentRepo.EXPECT().UpdateStatus(gomock.Any(), payment, model.PaymentStatusRefunding, gomock.Any()).Times(1).Return(nil),
+		)
+
+		res, err := paymentService.Refund(ctx, 9)
+		assert.Equal(t, constant.ErrPaymentGateway, err)
+		assert.Nil(t, res)
+		assert.Equal(t, model.PaymentStatusCaptured, payment.Status)
+	})
+
+	t.Run("still settling", func(t *testing.T) {
+		mockPaymentRepo.EXPECT().FindById(gomock.Any(), 8).Times(1).Return(&model.Payment{Id: 8, Status: model.PaymentStatusSettling}, nil)
+		mockGateway.EXPECT().Refund(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		res, err := paymentService.Refund(ctx, 8)
+		assert.Equal(t, constant.ErrInvalidTransition, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestPaymentService_HandleWebhook(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.TODO()
+	mockPaymentRepo := mock.NewMockPaymentRepository(ctrl)
+	mockGateway := mock.NewMockPaymentGateway(ctrl)
+	mockGateway.EXPECT().Name().AnyTimes().Return("fake")
+
+	paymentService := &paymentService{
+		paymentRepository: mockPaymentRepo,
+		gateway:           mockGateway,
+	}
+
+	payload := []byte(`{"reference":"fake_delayed_abc","status":"captured"}`)
+	event := &model.GatewayEvent{Reference: "fake_delayed_abc", Status: model.PaymentStatusCaptured}
+
+	t.Run("ok - settled", func(t *testing.T) {
+		payment := &model.Payment{Id: 8, Reference: "fake_delayed_abc", Status: model.PaymentStatusSettling}
+		mockGateway.EXPECT().VerifyWebhook(gomock.Any(), payload, "sig").Times(1).Return(event, nil)
+		mockPaymentRepo.EXPECT().FindByReference(gomock.Any(), "fake", "fake_delayed_abc").Times(1).Return(payment, nil)
+		mockPaymentRepo.EXPECT().UpdateStatus(gomock.Any(), payment, model.PaymentStatusSettling, gomock.Any()).Times(1).Return(nil)
+
+		res, err := paymentService.HandleWebhook(ctx, payload, "sig")
+		require.NoError(t, err)
+		assert.Equal(t, model.PaymentStatusCaptured, res.Status)
+	})
+
+	t.Run("ok - already applied", func(t *testing.T) {
+		payment := &model.Payment{Id: 8, Reference: "fake_delayed_abc", Status: model.PaymentStatusCaptured}
+		mockGateway.EXPECT().VerifyWebhook(gomock.Any(), payload, "sig").Times(1).Return(event, nil)
+		mockPaymentRepo.EXPECT().FindByReference(gomock.Any(), "fake", "fake_delayed_abc").Times(1).Return(payment, nil)
+		mockPaymentRepo.EXPECT().UpdateStatus(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		res, err := paymentService.HandleWebhook(ctx, payload, "sig")
+		require.NoError(t, err)
+		assert.Equal(t, model.PaymentStatusCaptured, res.Status)
+	})
+
+	t.Run("invalid signature", func(t *testing.T) {
+		mockGateway.EXPECT().VerifyWebhook(gomock.Any(), payload, "forged").Times(1).Return(nil, constant.ErrInvalidSignature)
+		mockPaymentRepo.EXPECT().FindByReference(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		res, err := paymentService.HandleWebhook(ctx, payload, "forged")
+		assert.Equal(t, constant.ErrInvalidSignature, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("unknown payment", func(t *testing.T) {
+		mockGateway.EXPECT().VerifyWebhook(gomock.Any(), payload, "sig").Times(1).Return(event, nil)
+		mockPaymentRepo.EXPECT().FindByReference(gomock.Any(), "fake", "fake_delayed_abc").Times(1).Return(nil, nil)
+
+		res, err := paymentService.HandleWebhook(ctx, payload, "sig")
+		assert.Equal(t, constant.ErrNotFound, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("invalid transition", func(t *testing.T) {
+		payment := &model.Payment{Id: 8, Reference: "fake_delayed_abc", Status: model.PaymentStatusRefunded}
+		mockGateway.EXPECT().VerifyWebhook(gomock.Any(), payload, "sig").Times(1).Return(event, nil)
+		mockPaymentRepo.EXPECT().FindByReference(gomock.Any(), "fake", "fake_delayed_abc").Times(1).Return(payment, nil)
+
+		res, err := paymentService.HandleWebhook(ctx, payload, "sig")
+		assert.Equal(t, constant.ErrInvalidTransition, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestPaymentService_FindByOrderId(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.TODO()
+	mockPaymentRepo := mock.NewMockPaymentRepository(ctrl)
+	mockOrderRepo := mock.NewMockOrderRepository(ctrl)
+
+	paymentService := &paymentService{
+		paymentRepository: mockPaymentRepo,
+		orderRepository:   mockOrderRepo,
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		mockOrderRepo.EXPECT().FindById(gomock.Any(), 4).Times(1).Return(&model.Order{Id: 4}, nil)
+		mockPaymentRepo.EXPECT().FindByOrderId(gomock.Any(), 4).Times(1).Return([]*model.Payment{{Id: 7}}, nil)
+
+		res, err := paymentService.FindByOrderId(ctx, 4)
+		require.NoError(t, err)
+		assert.Len(t, res, 1)
+	})
+
+	t.Run("order not found", func(t *testing.T) {
+		mockOrderRepo.EXPECT().FindById(gomock.Any(), 9).Times(1).Return(nil, nil)
+
+		res, err := paymentService.FindByOrderId(ctx, 9)
+		assert.Equal(t, constant.ErrNotFound, err)
+		assert.Nil(t, res)
+	})
+}
@@ -0,0 +1,220 @@
+package service
+
+import (
+	"cake-store/src/config"
+	"cake-store/src/constant"
+	"cake-store/src/model"
+	"context"
+	"sort"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+type productionService struct {
+	productionRepository model.ProductionRepository
+	orderRepository      model.OrderRepository
+	stockRepository      model.StockRepository
+	cakeRepository       model.CakeRepository
+}
+
+func NewProductionService(productionRepository model.ProductionRepository, orderRepository model.OrderRepository, stockRepository model.StockRepository,
+	cakeRepository model.CakeRepository) model.ProductionService {
+	return &productionService{
+		productionRepository: productionRepository,
+		orderRepository:      orderRepository,
+		stockRepository:      stockRepository,
+		cakeRepository:       cakeRepository,
+	}
+}
+
+func (p *productionService) SetCakeProduction(ctx context.Context, req model.SetCakeProductionRequest, cakeId int) (*model.CakeProduction, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Set Cake Production Service",
+		"req":     req,
+		"cakeId":  cakeId,
+	})
+
+	if err := req.Validate(); err != nil {
+		log.Error(err)
+		return nil, constant.HttpValidationOrInternalErr(err)
+	}
+
+	if err := p.findCake(ctx, cakeId); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	production := &model.CakeProduction{
+		CakeId:      cakeId,
+		PrepMinutes: req.PrepMinutes,
+		BakeMinutes: req.BakeMinutes,
+		BatchSize:   req.BatchSize,
+		UpdatedAt:   time.Now(),
+	}
+	if err := p.productionRepository.Save(ctx, production); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return production, nil
+}
+
+// FindCakeProduction find the production settings of the cake, the configured defaults when it has none
+func (p *productionService) FindCakeProduction(ctx context.Context, cakeId int) (*model.CakeProduction, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Find Cake Production Service",
+		"cakeId":  cakeId,
+	})
+
+	if err := p.findCake(ctx, cakeId); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	productions, err := p.findProductions(ctx, []int{cakeId})
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return productions[cakeId], nil
+}
+
+// Plan aggregate the confirmed orders picked up on the date by cake variant, net them against the on hand stock and
+// schedule the batches to bake from the start of the day
+func (p *productionService) Plan(ctx context.Context, query model.ProductionQuery) (*model.ProductionPlan, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Plan Production Service",
+		"query":   query,
+	})
+
+	if err := query.Validate(); err != nil {
+		log.Error(err)
+		return nil, constant.HttpValidationOrInternalErr(err)
+	}
+
+	now := time.Now()
+	date := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	if query.Date != "" {
+		parsed, err := time.ParseInLocation(model.DateLayout, query.Date, time.Local)
+		if err != nil {
+			log.Error(err)
+			return nil, constant.ErrInvalidArgument
+		}
+		date = parsed
+	}
+
+	orders, err := p.orderRepository.FindByPickupDate(ctx, date, model.OrderStatusConfirmed)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	type lineKey struct{ cakeId, variantId int }
+	byItem := make(map[lineKey]*model.ProductionLine)
+	lines := make([]*model.ProductionLine, 0)
+	cakeIds := make([]int, 0)
+	seenCakes := make(map[int]bool)
+	for _, order := range orders {
+		for _, item := range order.Items {
+			key := lineKey{item.CakeId, item.VariantId}
+			line, found := byItem[key]
+			if !found {
+				line = &model.ProductionLine{
+					CakeId:    item.CakeId,
+					VariantId: item.VariantId,
+					Title:     item.Title,
+					Size:      item.Size,
+					OrderIds:  make([]int, 0),
+				}
+				byItem[key] = line
+				lines = append(lines, line)
+			}
+			if !seenCakes[item.CakeId] {
+				seenCakes[item.CakeId] = true
+				cakeIds = append(cakeIds, item.CakeId)
+			}
+			line.Ordered += item.Quantity
+			if len(line.OrderIds) == 0 || line.OrderIds[len(line.OrderIds)-1] != order.Id {
+				line.OrderIds = append(line.OrderIds, order.Id)
+			}
+		}
+	}
+
+	for _, line := range lines {
+		stock, err := p.stockRepository.FindByItem(ctx, line.CakeId, line.VariantId)
+		if err != nil {
+			log.Error(err)
+			return nil, err
+		}
+		if stock != nil && stock.OnHand > 0 {
+			line.OnHand = stock.OnHand
+		}
+		line.SetToBake()
+	}
+
+	sort.SliceStable(lines, func(a, b int) bool {
+		if lines[a].Title != lines[b].Title {
+			return lines[a].Title < lines[b].Title
+		}
+		return lines[a].Size < lines[b].Size
+	})
+
+	productions, err := p.findProductions(ctx, cakeIds)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	plan := &model.ProductionPlan{
+		Date:        date.Format(model.DateLayout),
+		Lines:       lines,
+		GeneratedAt: now,
+	}
+	plan.Schedule(date.Add(config.ProductionDayStart()), productions)
+
+	return plan, nil
+}
+
+// findProductions find the production settings of each cake, the cakes without settings get the configured defaults
+func (p *productionService) findProductions(ctx context.Context, cakeIds []int) (map[int]*model.CakeProduction, error) {
+	found, err := p.productionRepository.FindByCakeIds(ctx, cakeIds)
+	if err != nil {
+		return nil, err
+	}
+
+	productions := make(map[int]*model.CakeProduction, len(cakeIds))
+	for _, production := range found {
+		productions[production.CakeId] = production
+	}
+	for _, cakeId := range cakeIds {
+		if _, ok := productions[cakeId]; !ok {
+			productions[cakeId] = &model.CakeProduction{
+				CakeId:      cakeId,
+				PrepMinutes: config.ProductionPrepMinutes(),
+				BakeMinutes: config.ProductionBakeMinutes(),
+				BatchSize:   config.ProductionBatchSize(),
+				Default:     true,
+			}
+		}
+	}
+	return productions, nil
+}
+
+func (p *productionService) findCake(ctx context.Context, cakeId int) error {
+	if cakeId == 0 {
+		return constant.ErrInvalidArgument
+	}
+
+	cake, err := p.cakeRepository.FindById(ctx, cakeId)
+	if err != nil {
+		return err
+	}
+
+	if cake == nil {
+		return constant.ErrNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,164 @@
+package service
+
+import (
+	"cake-store/src/constant"
+	"cake-store/src/model"
+	"cake-store/src/model/mock"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProductionService_SetCakeProduction(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.TODO()
+	mockProductionRepo := mock.NewMockProductionRepository(ctrl)
+	mockCakeRepo := mock.NewMockCakeRepository(ctrl)
+
+	productionService := &productionService{
+		productionRepository: mockProductionRepo,
+		cakeRepository:       mockCakeRepo,
+	}
+
+	req := model.SetCakeProductionRequest{PrepMinutes: 30, BakeMinutes: 45, BatchSize: 4}
+
+	t.Run("ok", func(t *testing.T) {
+		mockCakeRepo.EXPECT().FindById(gomock.Any(), 1).Times(1).Return(&model.Cake{Id: 1}, nil)
+		mockProductionRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+
+		res, err := productionService.SetCakeProduction(ctx, req, 1)
+		require.NoError(t, err)
+		assert.Equal(t, 4, res.BatchSize)
+		assert.False(t, res.Default)
+	})
+
+	t.Run("invalid batch size", func(t *testing.T) {
+		res, err := productionService.SetCakeProduction(ctx, model.SetCakeProductionRequest{BakeMinutes: 45}, 1)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("cake not found", func(t *testing.T) {
+		mockCakeRepo.EXPECT().FindById(gomock.Any(), 9).Times(1).Return(nil, nil)
+
+		res, err := productionService.SetCakeProduction(ctx, req, 9)
+		assert.Equal(t, constant.ErrNotFound, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestProductionService_FindCakeProduction(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.TODO()
+	mockProductionRepo := mock.NewMockProductionRepository(ctrl)
+	mockCakeRepo := mock.NewMockCakeRepository(ctrl)
+
+	productionService := &productionService{
+		productionRepository: mockProductionRepo,
+		cakeRepository:       mockCakeRepo,
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		mockCakeRepo.EXPECT().FindById(gomock.Any(), 1).Times(1).Return(&model.Cake{Id: 1}, nil)
+		mockProductionRepo.EXPECT().FindByCakeIds(gomock.Any(), []int{1}).Times(1).
+			Return([]*model.CakeProduction{{CakeId: 1, PrepMinutes: 20, BakeMinutes: 60, BatchSize: 6}}, nil)
+
+		res, err := productionService.FindCakeProduction(ctx, 1)
+		require.NoError(t, err)
+		assert.Equal(t, 6, res.BatchSize)
+		assert.False(t, res.Default)
+	})
+
+	t.Run("ok - defaults", func(t *testing.T) {
+		mockCakeRepo.EXPECT().FindById(gomock.Any(), 2).Times(1).Return(&model.Cake{Id: 2}, nil)
+		mockProductionRepo.EXPECT().FindByCakeIds(gomock.Any(), []int{2}).Times(1).Return(nil, nil)
+
+		res, err := productionService.FindCakeProduction(ctx, 2)
+		require.NoError(t, err)
+		assert.True(t, res.Default)
+		assert.Equal(t, 2, res.CakeId)
+	})
+}
+
+func TestProductionService_Plan(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.TODO()
+	mockProductionRepo := mock.NewMockProductionRepository(ctrl)
+	mockOrderRepo := mock.NewMockOrderRepository(ctrl)
+	mockStockRepo := mock.NewMockStockRepository(ctrl)
+
+	productionService := &productionService{
+		productionRepository: mockProductionRepo,
+		orderRepository:      mockOrderRepo,
+		stockRepository:      mockStockRepo,
+	}
+
+	date := time.Date(2026, 10, 20, 0, 0, 0, 0, time.Local)
+	orders := []*model.Order{
+		{Id: 3, Items: []*model.OrderItem{
+			{CakeId: 2, VariantId: 7, Title: "Cheesecake", Size: "16cm", Quantity: 1},
+			{CakeId: 1, VariantId: 5, Title: "Black Forest", Size: "20cm", Quantity: 2},
+		}},
+		{Id: 4, Items: []*model.OrderItem{
+			{CakeId: 1, VariantId: 5, Title: "Black Forest", Size: "20cm", Quantity: 3},
+		}},
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		mockOrderRepo.EXPECT().FindByPickupDate(gomock.Any(), date, model.OrderStatusConfirmed).Times(1).Return(orders, nil)
+		mockStockRepo.EXPECT().FindByItem(gomock.Any(), 2, 7).Times(1).Return(&model.Stock{OnHand: 4}, nil)
+		mockStockRepo.EXPECT().FindByItem(gomock.Any(), 1, 5).Times(1).Return(&model.Stock{OnHand: 1}, nil)
+		mockProductionRepo.EXPECT().FindByCakeIds(gomock.Any(), []int{2, 1}).Times(1).
+			Return([]*model.CakeProduction{{CakeId: 1, PrepMinutes: 30, BakeMinutes: 45, BatchSize: 2}}, nil)
+
+		res, err := productionService.Plan(ctx, model.ProductionQuery{Date: "2026-10-20"})
+		require.NoError(t, err)
+		assert.Equal(t, "2026-10-20", res.Date)
+
+		require.Len(t, res.Lines, 2)
+		assert.Equal(t, "Black Forest", res.Lines[0].Title)
+		assert.Equal(t, 5, res.Lines[0].Ordered)
+		assert.Equal(t, 4, res.Lines[0].ToBake)
+		assert.Equal(t, []int{3, 4}, res.Lines[0].OrderIds)
+		assert.Equal(t, 0, res.Lines[1].ToBake)
+
+		require.Len(t, res.Batches, 2)
+		assert.Equal(t, 2, res.Batches[0].Quantity)
+		assert.Equal(t, date.Add(6*time.Hour), res.Batches[0].PrepStartAt)
+	})
+
+	t.Run("ok - no orders", func(t *testing.T) {
+		mockOrderRepo.EXPECT().FindByPickupDate(gomock.Any(), date, model.OrderStatusConfirmed).Times(1).Return(nil, nil)
+		mockProductionRepo.EXPECT().FindByCakeIds(gomock.Any(), []int{}).Times(1).Return(nil, nil)
+
+		res, err := productionService.Plan(ctx, model.ProductionQuery{Date: "2026-10-20"})
+		require.NoError(t, err)
+		assert.Empty(t, res.Lines)
+		assert.Empty(t, res.Batches)
+	})
+
+	t.Run("invalid date", func(t *testing.T) {
+		res, err := productionService.Plan(ctx, model.ProductionQuery{Date: "20-10-2026"})
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("error from repository", func(t *testing.T) {
+		mockOrderRepo.EXPECT().FindByPickupDate(gomock.Any(), date, model.OrderStatusConfirmed).Times(1).Return(nil, errors.New("err db"))
+
+		res, err := productionService.Plan(ctx, model.ProductionQuery{Date: "2026-10-20"})
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+}
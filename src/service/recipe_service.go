@@ -0,0 +1,225 @@
+package service
+
+import (
+	"cake-store/src/config"
+	"cake-store/src/constant"
+	"cake-store/src/model"
+	"context"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+type recipeService struct {
+	recipeRepository     model.RecipeRepository
+	ingredientRepository model.IngredientRepository
+	cakeRepository       model.CakeRepository
+	exchangeRate         model.ExchangeRateProvider
+}
+
+func NewRecipeService(recipeRepository model.RecipeRepository, ingredientRepository model.IngredientRepository, cakeRepository model.CakeRepository,
+	exchangeRate model.ExchangeRateProvider) model.RecipeService {
+	return &recipeService{
+		recipeRepository:     recipeRepository,
+		ingredientRepository: ingredientRepository,
+		cakeRepository:       cakeRepository,
+		exchangeRate:         exchangeRate,
+	}
+}
+
+// Save create the recipe of the cake or replace the existing one
+func (r *recipeService) Save(ctx context.Context, req model.SaveRecipeRequest, cakeId int) (*model.Recipe, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Save Recipe Service",
+		"req":     req,
+		"cakeId":  cakeId,
+	})
+
+	for idx := range req.Ingredients {
+		req.Ingredients[idx].Unit = strings.ToLower(strings.TrimSpace(req.Ingredients[idx].Unit))
+	}
+	if err := req.Validate(); err != nil {
+		log.Error(err)
+		return nil, constant.HttpValidationOrInternalErr(err)
+	}
+
+	cake, err := r.cakeRepository.FindById(ctx, cakeId)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	if cake == nil {
+		log.Error(constant.ErrNotFound)
+		return nil, constant.ErrNotFound
+	}
+
+	ids := req.IngredientIds()
+	found, err := r.ingredientRepository.FindByIds(ctx, ids)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	if len(found) != len(ids) {
+		log.Error(constant.ErrInvalidArgument)
+		return nil, constant.ErrInvalidArgument
+	}
+
+	recipe, err := r.recipeRepository.FindByCakeId(ctx, cakeId)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	exists := recipe != nil
+	if !exists {
+		recipe = &model.Recipe{
+			CakeId:    cakeId,
+			CreatedAt: time.Now(),
+		}
+	}
+
+	recipe.Yield = req.Yield
+	recipe.UpdatedAt = time.Now()
+	recipe.Steps = make([]*model.RecipeStep, 0, len(req.Steps))
+	for _, step := range req.Steps {
+		recipe.Steps = append(recipe.Steps, &model.RecipeStep{
+			Instruction: strings.TrimSpace(step.Instruction),
+			Minutes:     step.Minutes,
+		})
+	}
+	recipe.Ingredients = make([]*model.RecipeIngredient, 0, len(req.Ingredients))
+	for _, ingredient := range req.Ingredients {
+		recipe.Ingredients = append(recipe.Ingredients, &model.RecipeIngredient{
+			IngredientId: ingredient.IngredientId,
+			Quantity:     ingredient.Quantity,
+			Unit:         ingredient.Unit,
+		})
+	}
+
+	if exists {
+		err = r.recipeRepository.Update(ctx, recipe)
+	} else {
+		err = r.recipeRepository.Save(ctx, recipe)
+	}
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return r.FindByCakeId(ctx, model.RecipeQuery{}, cakeId)
+}
+
+func (r *recipeService) Delete(ctx context.Context, cakeId int) (*model.Recipe, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Delete Recipe Service",
+		"cakeId":  cakeId,
+	})
+
+	recipe, err := r.findRecipe(ctx, cakeId)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	if err = r.recipeRepository.Delete(ctx, recipe); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return recipe, nil
+}
+
+// FindByCakeId find the recipe of the cake scaled to the servings and priced at the cheapest supplier prices
+func (r *recipeService) FindByCakeId(ctx context.Context, query model.RecipeQuery, cakeId int) (*model.Recipe, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Find By Cake ID Recipe Service",
+		"query":   query,
+		"cakeId":  cakeId,
+	})
+
+	query.Currency = strings.ToUpper(query.Currency)
+	if err := query.Validate(); err != nil {
+		log.Error(err)
+		return nil, constant.HttpValidationOrInternalErr(err)
+	}
+
+	recipe, err := r.findRecipe(ctx, cakeId)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	if query.Servings > 0 {
+		recipe.Scale(query.Servings)
+	}
+
+	currency := query.Currency
+	if currency == "" {
+		currency = config.BaseCurrency()
+	}
+	if err = r.price(ctx, recipe, currency); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return recipe, nil
+}
+
+// price cost each ingredient at its cheapest supplier price in the currency, then sum the recipe cost
+func (r *recipeService) price(ctx context.Context, recipe *model.Recipe, currency string) error {
+	ids := make([]int, 0, len(recipe.Ingredients))
+	for _, ingredient := range recipe.Ingredients {
+		ids = append(ids, ingredient.IngredientId)
+	}
+
+	prices, err := r.ingredientRepository.FindPrices(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	byIngredient := make(map[int][]*model.SupplierPrice)
+	for _, price := range prices {
+		byIngredient[price.IngredientId] = append(byIngredient[price.IngredientId], price)
+	}
+
+	for _, ingredient := range recipe.Ingredients {
+		for _, price := range byIngredient[ingredient.IngredientId] {
+			cost, ok := price.Cost(ingredient.Quantity, ingredient.Unit, ingredient.Density)
+			if !ok {
+				continue
+			}
+
+			if cost, err = convertPrice(ctx, r.exchangeRate, cost, currency); err != nil {
+				return err
+			}
+
+			if ingredient.Cost == nil || cost.Amount < ingredient.Cost.Amount {
+				ingredient.Cost = &cost
+				ingredient.Supplier = price.Supplier
+			}
+		}
+	}
+
+	recipe.SetCost(currency)
+	return nil
+}
+
+func (r *recipeService) findRecipe(ctx context.Context, cakeId int) (*model.Recipe, error) {
+	if cakeId == 0 {
+		return nil, constant.ErrInvalidArgument
+	}
+
+	recipe, err := r.recipeRepository.FindByCakeId(ctx, cakeId)
+	if err != nil {
+		return nil, err
+	}
+
+	if recipe == nil {
+		return nil, constant.ErrNotFound
+	}
+
+	return recipe, nil
+}
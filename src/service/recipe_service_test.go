@@ -0,0 +1,231 @@
+package service
+
+import (
+	"cake-store/src/constant"
+	"cake-store/src/model"
+	"cake-store/src/model/mock"
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestRecipe() *model.Recipe {
+	return &model.Recipe{
+		Id:       4,
+		CakeId:   1,
+		Yield:    8,
+		Servings: 8,
+		Steps:    []*model.RecipeStep{{Position: 1, Instruction: "Bake", Minutes: 35}},
+		Ingredients: []*model.RecipeIngredient{
+			{IngredientId: 2, Name: "Flour", Quantity: 250, Unit: model.UnitGram, Density: 0.5},
+			{IngredientId: 3, Name: "Milk", Quantity: 1, Unit: model.UnitCup, Density: 1},
+		},
+	}
+}
+
+func TestRecipeService_FindByCakeId(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.TODO()
+	mockRecipeRepo := mock.NewMockRecipeRepository(ctrl)
+	mockIngredientRepo := mock.NewMockIngredientRepository(ctrl)
+	mockExchangeRate := mock.NewMockExchangeRateProvider(ctrl)
+
+	recipeService := &recipeService{
+		recipeRepository:     mockRecipeRepo,
+		ingredientRepository: mockIngredientRepo,
+		exchangeRate:         mockExchangeRate,
+	}
+
+	prices := []*model.SupplierPrice{
+		{IngredientId: 2, Supplier: "Toko A", Price: model.NewMoney(1500000, "IDR"), Quantity: 1, Unit: model.UnitKilogram},
+		{IngredientId: 2, Supplier: "Toko B", Price: model.NewMoney(700000, "IDR"), Quantity: 500, Unit: model.UnitGram},
+		{IngredientId: 3, Supplier: "Dairy Farm", Price: model.NewMoney(2400000, "IDR"), Quantity: 1, Unit: model.UnitLiter},
+	}
+
+	t.Run("ok - scaled and priced", func(t *testing.T) {
+		mockRecipeRepo.EXPECT().FindByCakeId(gomock.Any(), 1).Times(1).Return(newTestRecipe(), nil)
+		mockIngredientRepo.EXPECT().FindPrices(gomock.Any(), []int{2, 3}).Times(1).Return(prices, nil)
+
+		res, err := recipeService.FindByCakeId(ctx, model.RecipeQuery{Servings: 16}, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, 16, res.Servings)
+		assert.Equal(t, 500.0, res.Ingredients[0].Quantity)
+		assert.Equal(t, "Toko B", res.Ingredients[0].Supplier)
+		assert.Equal(t, model.NewMoney(700000, "IDR"), *res.Ingredients[0].Cost)
+		assert.Equal(t, model.NewMoney(1152000, "IDR"), *res.Ingredients[1].Cost)
+		assert.Equal(t, &model.RecipeCost{
+			Total:      model.NewMoney(1852000, "IDR"),
+			PerServing: model.NewMoney(115750, "IDR"),
+			Complete:   true,
+		}, res.Cost)
+	})
+
+	t.Run("ok - missing price", func(t *testing.T) {
+		mockRecipeRepo.EXPECT().FindByCakeId(gomock.Any(), 1).Times(1).Return(newTestRecipe(), nil)
+		mockIngredientRepo.EXPECT().FindPrices(gomock.Any(), []int{2, 3}).Times(1).Return(prices[:2], nil)
+
+		res, err := recipeService.FindByCakeId(ctx, model.RecipeQuery{}, 1)
+		assert.NoError(t, err)
+		assert.Nil(t, res.Ingredients[1].Cost)
+		assert.False(t, res.Cost.Complete)
+		assert.Equal(t, model.NewMoney(350000, "IDR"), res.Cost.Total)
+	})
+
+	t.Run("ok - convert currency", func(t *testing.T) {
+		mockRecipeRepo.EXPECT().FindByCakeId(gomock.Any(), 1).Times(1).Return(newTestRecipe(), nil)
+		mockIngredientRepo.EXPECT().FindPrices(gomock.Any(), []int{2, 3}).Times(1).Return(prices[2:], nil)
+		mockExchangeRate.EXPECT().Rate(gomock.Any(), "IDR", "USD").Times(1).Return(big.NewRat(1, 16000), nil)
+
+		res, err := recipeService.FindByCakeId(ctx, model.RecipeQuery{Currency: "usd"}, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, model.NewMoney(36, "USD"), *res.Ingredients[1].Cost)
+		assert.Equal(t, "USD", res.Cost.Total.Currency)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mockRecipeRepo.EXPECT().FindByCakeId(gomock.Any(), 2).Times(1).Return(nil, nil)
+
+		res, err := recipeService.FindByCakeId(ctx, model.RecipeQuery{}, 2)
+		assert.Equal(t, constant.ErrNotFound, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("validate error", func(t *testing.T) {
+		res, err := recipeService.FindByCakeId(ctx, model.RecipeQuery{Servings: -1}, 1)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestRecipeService_Save(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.TODO()
+	mockRecipeRepo := mock.NewMockRecipeRepository(ctrl)
+	mockIngredientRepo := mock.NewMockIngredientRepository(ctrl)
+	mockCakeRepo := mock.NewMockCakeRepository(ctrl)
+
+	recipeService := &recipeService{
+		recipeRepository:     mockRecipeRepo,
+		ingredientRepository: mockIngredientRepo,
+		cakeRepository:       mockCakeRepo,
+	}
+
+	cake := &model.Cake{Id: 1, Title: "Sponge Cake"}
+	req := model.SaveRecipeRequest{
+		Yield: 8,
+		Steps: []model.RecipeStepRequest{{Instruction: "Bake", Minutes: 35}},
+		Ingredients: []model.RecipeIngredientRequest{
+			{IngredientId: 2, Quantity: 250, Unit: "G"},
+			{IngredientId: 3, Quantity: 1, Unit: "cup"},
+			{IngredientId: 2, Quantity: 20, Unit: "g"},
+		},
+	}
+	ingredients := []*model.Ingredient{{Id: 2, Name: "Flour"}, {Id: 3, Name: "Milk"}}
+
+	t.Run("ok - create", func(t *testing.T) {
+		mockCakeRepo.EXPECT().FindById(gomock.Any(), cake.Id).Times(1).Return(cake, nil)
+		mockIngredientRepo.EXPECT().FindByIds(gomock.Any(), []int{2, 3}).Times(1).Return(ingredients, nil)
+		mockRecipeRepo.EXPECT().FindByCakeId(gomock.Any(), cake.Id).Times(1).Return(nil, nil)
+		mockRecipeRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(func(ctx context.Context, recipe *model.Recipe) error {
+			assert.Equal(t, 3, len(recipe.Ingredients))
+			assert.Equal(t, model.UnitGram, recipe.Ingredients[0].Unit)
+			return nil
+		})
+		mockRecipeRepo.EXPECT().FindByCakeId(gomock.Any(), cake.Id).Times(1).Return(newTestRecipe(), nil)
+		mockIngredientRepo.EXPECT().FindPrices(gomock.Any(), gomock.Any()).Times(1).Return(nil, nil)
+
+		res, err := recipeService.Save(ctx, req, cake.Id)
+		assert.NoError(t, err)
+		assert.Equal(t, 4, res.Id)
+	})
+
+	t.Run("ok - replace", func(t *testing.T) {
+		mockCakeRepo.EXPECT().FindById(gomock.Any(), cake.Id).Times(1).Return(cake, nil)
+		mockIngredientRepo.EXPECT().FindByIds(gomock.Any(), []int{2, 3}).Times(1).Return(ingredients, nil)
+		mockRecipeRepo.EXPECT().FindByCakeId(gomock.Any(), cake.Id).Times(2).Return(newTestRecipe(), nil)
+		mockRecipeRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+		mockIngredientRepo.EXPECT().FindPrices(gomock.Any(), gomock.Any()).Times(1).Return(nil, nil)
+
+		res, err := recipeService.Save(ctx, req, cake.Id)
+		assert.NoError(t, err)
+		assert.Equal(t, 4, res.Id)
+	})
+
+	t.Run("cake not found", func(t *testing.T) {
+		mockCakeRepo.EXPECT().FindById(gomock.Any(), 9).Times(1).Return(nil, nil)
+
+		res, err := recipeService.Save(ctx, req, 9)
+		assert.Equal(t, constant.ErrNotFound, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("unknown ingredient", func(t *testing.T) {
+		mockCakeRepo.EXPECT().FindById(gomock.Any(), cake.Id).Times(1).Return(cake, nil)
+		mockIngredientRepo.EXPECT().FindByIds(gomock.Any(), []int{2, 3}).Times(1).Return(ingredients[:1], nil)
+
+		res, err := recipeService.Save(ctx, req, cake.Id)
+		assert.Equal(t, constant.ErrInvalidArgument, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("unknown unit", func(t *testing.T) {
+		req := model.SaveRecipeRequest{
+			Yield:       8,
+			Steps:       []model.RecipeStepRequest{{Instruction: "Bake"}},
+			Ingredients: []model.RecipeIngredientRequest{{IngredientId: 2, Quantity: 8, Unit: "oz"}},
+		}
+
+		res, err := recipeService.Save(ctx, req, cake.Id)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("error from repo", func(t *testing.T) {
+		mockCakeRepo.EXPECT().FindById(gomock.Any(), cake.Id).Times(1).Return(cake, nil)
+		mockIngredientRepo.EXPECT().FindByIds(gomock.Any(), []int{2, 3}).Times(1).Return(ingredients, nil)
+		mockRecipeRepo.EXPECT().FindByCakeId(gomock.Any(), cake.Id).Times(1).Return(nil, nil)
+		mockRecipeRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Times(1).Return(errors.New("db error"))
+
+		res, err := recipeService.Save(ctx, req, cake.Id)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestRecipeService_Delete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.TODO()
+	mockRecipeRepo := mock.NewMockRecipeRepository(ctrl)
+
+	recipeService := &recipeService{
+		recipeRepository: mockRecipeRepo,
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		recipe := newTestRecipe()
+		mockRecipeRepo.EXPECT().FindByCakeId(gomock.Any(), 1).Times(1).Return(recipe, nil)
+		mockRecipeRepo.EXPECT().Delete(gomock.Any(), recipe).Times(1).Return(nil)
+
+		res, err := recipeService.Delete(ctx, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, recipe, res)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mockRecipeRepo.EXPECT().FindByCakeId(gomock.Any(), 2).Times(1).Return(nil, nil)
+
+		res, err := recipeService.Delete(ctx, 2)
+		assert.Equal(t, constant.ErrNotFound, err)
+		assert.Nil(t, res)
+	})
+}
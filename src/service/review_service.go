@@ -0,0 +1,305 @@
+package service
+
+import (
+	"cake-store/src/constant"
+	"cake-store/src/model"
+	"context"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+type reviewService struct {
+	reviewRepository model.ReviewRepository
+	cakeRepository   model.CakeRepository
+	screeners        []model.ReviewScreener
+}
+
+// NewReviewService screen every new review with the screeners in order, see the screening package
+func NewReviewService(reviewRepository model.ReviewRepository, cakeRepository model.CakeRepository, screeners ...model.ReviewScreener) model.ReviewService {
+	return &reviewService{
+		reviewRepository: reviewRepository,
+		cakeRepository:   cakeRepository,
+		screeners:        screeners,
+	}
+}
+
+// Create save the review as pending for a moderator, or as flagged or rejected when a screener stop it
+func (r *reviewService) Create(ctx context.Context, req model.CreateUpdateReviewRequest, cakeId int) (*model.Review, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Create Review Service",
+		"req":     req,
+		"cakeId":  cakeId,
+	})
+
+	if err := r.findCake(ctx, cakeId); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	trimReview(&req)
+	if err := req.Validate(); err != nil {
+		log.Error(err)
+		return nil, constant.HttpValidationOrInternalErr(err)
+	}
+
+	review := &model.Review{
+		CakeId:      cakeId,
+		Score:       req.Score,
+		Text:        req.Text,
+		Author:      req.Author,
+		Status:      model.ReviewStatusPending,
+		Fingerprint: model.ReviewFingerprint(req.Text),
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+
+	for _, screener := range r.screeners {
+		verdict, err := screener.Screen(ctx, review)
+		if err != nil {
+			log.Error(err)
+			return nil, err
+		}
+		if verdict != nil {
+			review.ApplyVerdict(verdict)
+		}
+	}
+
+	// a new review is never approved, so the rating of the cake is unchanged
+	if err := r.reviewRepository.Save(ctx, review); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return review, nil
+}
+
+func (r *reviewService) Update(ctx context.Context, req model.CreateUpdateReviewRequest, cakeId int, reviewId int) (*model.Review, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message":  "Update Review Service",
+		"req":      req,
+		"cakeId":   cakeId,
+		"reviewId": reviewId,
+	})
+
+	review, err := r.FindById(ctx, cakeId, reviewId)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	trimReview(&req)
+	if err := req.Validate(); err != nil {
+		log.Error(err)
+		return nil, constant.HttpValidationOrInternalErr(err)
+	}
+
+	review.Score = req.Score
+	review.Text = req.Text
+	review.Author = req.Author
+	review.Fingerprint = model.ReviewFingerprint(req.Text)
+	review.UpdatedAt = time.Now()
+
+	if err = r.reviewRepository.Update(ctx, review); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	if err = r.cakeRepository.Touch(ctx, cakeId); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return review, nil
+}
+
+func (r *reviewService) Delete(ctx context.Context, cakeId int, reviewId int) (*model.Review, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message":  "Delete Review Service",
+		"cakeId":   cakeId,
+		"reviewId": reviewId,
+	})
+
+	review, err := r.FindById(ctx, cakeId, reviewId)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	if err = r.reviewRepository.Delete(ctx, review); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	if err = r.cakeRepository.Touch(ctx, cakeId); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return review, nil
+}
+
+// Moderate set the status decided by a moderator, the rating of the cake follow when the review enter or leave approved
+func (r *reviewService) Moderate(ctx context.Context, req model.ModerateReviewRequest, reviewId int) (*model.Review, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message":  "Moderate Review Service",
+		"req":      req,
+		"reviewId": reviewId,
+	})
+
+	if err := req.Validate(); err != nil {
+		log.Error(err)
+		return nil, constant.HttpValidationOrInternalErr(err)
+	}
+
+	review, err := r.reviewRepository.FindById(ctx, reviewId)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	if review == nil {
+		log.Error(constant.ErrNotFound)
+		return nil, constant.ErrNotFound
+	}
+
+	now := time.Now()
+	review.Status = req.Status
+	review.ModerationNote = strings.TrimSpace(req.Note)
+	review.ModeratedAt = &now
+	review.UpdatedAt = now
+
+	if err = r.reviewRepository.Update(ctx, review); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	if err = r.cakeRepository.Touch(ctx, review.CakeId); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return review, nil
+}
+
+// FindModeration find the reviews of every cake waiting for a moderator, or of the status of the query
+func (r *reviewService) FindModeration(ctx context.Context, query model.ReviewQuery) ([]*model.Review, *model.Pagination, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Find Moderation Review Service",
+		"query":   query,
+	})
+
+	if query.Status == "" {
+		query.Status = model.ReviewStatusPending
+	}
+
+	reviews, pagination, err := r.findAll(ctx, query)
+	if err != nil {
+		log.Error(err)
+		return nil, nil, err
+	}
+
+	return reviews, pagination, nil
+}
+
+// FindById find the review of the cake, a review of another cake is reported as not found
+func (r *reviewService) FindById(ctx context.Context, cakeId int, reviewId int) (*model.Review, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message":  "Find By ID Review Service",
+		"cakeId":   cakeId,
+		"reviewId": reviewId,
+	})
+
+	if err := r.findCake(ctx, cakeId); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	if reviewId == 0 {
+		log.Error(constant.ErrInvalidArgument)
+		return nil, constant.ErrInvalidArgument
+	}
+
+	review, err := r.reviewRepository.FindById(ctx, reviewId)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	if review == nil || review.CakeId != cakeId {
+		log.Error(constant.ErrNotFound)
+		return nil, constant.ErrNotFound
+	}
+
+	return review, nil
+}
+
+func (r *reviewService) FindAll(ctx context.Context, query model.ReviewQuery, cakeId int) ([]*model.Review, *model.Pagination, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Find All Review Service",
+		"query":   query,
+		"cakeId":  cakeId,
+	})
+
+	if err := r.findCake(ctx, cakeId); err != nil {
+		log.Error(err)
+		return nil, nil, err
+	}
+
+	query.CakeId = cakeId
+	reviews, pagination, err := r.findAll(ctx, query)
+	if err != nil {
+		log.Error(err)
+		return nil, nil, err
+	}
+
+	return reviews, pagination, nil
+}
+
+func (r *reviewService) findAll(ctx context.Context, query model.ReviewQuery) ([]*model.Review, *model.Pagination, error) {
+	if err := query.Validate(); err != nil {
+		return nil, nil, constant.HttpValidationOrInternalErr(err)
+	}
+
+	query.SetDefault()
+
+	reviews, err := r.reviewRepository.FindAll(ctx, query)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	total, err := r.reviewRepository.CountAll(ctx, query)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return reviews, &model.Pagination{
+		Total: total,
+		Page:  query.Page,
+		Limit: query.Limit,
+	}, nil
+}
+
+// findCake check the cake exist and is not deleted
+func (r *reviewService) findCake(ctx context.Context, cakeId int) error {
+	if cakeId == 0 {
+		return constant.ErrInvalidArgument
+	}
+
+	cake, err := r.cakeRepository.FindById(ctx, cakeId)
+	if err != nil {
+		return err
+	}
+
+	if cake == nil {
+		return constant.ErrNotFound
+	}
+
+	return nil
+}
+
+func trimReview(req *model.CreateUpdateReviewRequest) {
+	req.Text = strings.TrimSpace(req.Text)
+	req.Author = strings.TrimSpace(req.Author)
+}
@@ -0,0 +1,272 @@
+package service
+
+import (
+	"cake-store/src/constant"
+	"cake-store/src/model"
+	"cake-store/src/model/mock"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReviewService_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.TODO()
+	mockReviewRepo := mock.NewMockReviewRepository(ctrl)
+	mockCakeRepo := mock.NewMockCakeRepository(ctrl)
+	mockLinks := mock.NewMockReviewScreener(ctrl)
+	mockDuplicates := mock.NewMockReviewScreener(ctrl)
+
+	reviewService := &reviewService{
+		reviewRepository: mockReviewRepo,
+		cakeRepository:   mockCakeRepo,
+		screeners:        []model.ReviewScreener{mockLinks, mockDuplicates},
+	}
+
+	cake := &model.Cake{Id: 1, Title: "Kue Test", Version: 1}
+	req := model.CreateUpdateReviewRequest{Score: 9, Text: " Enak sekali ", Author: " Sari "}
+
+	t.Run("ok - pending", func(t *testing.T) {
+		mockCakeRepo.EXPECT().FindById(gomock.Any(), cake.Id).Times(1).Return(cake, nil)
+		mockLinks.EXPECT().Screen(gomock.Any(), gomock.Any()).Times(1).Return(nil, nil)
+		mockDuplicates.EXPECT().Screen(gomock.Any(), gomock.Any()).Times(1).Return(nil, nil)
+		mockReviewRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+		mockCakeRepo.EXPECT().Touch(gomock.Any(), gomock.Any()).Times(0)
+
+		res, err := reviewService.Create(ctx, req, cake.Id)
+		require.NoError(t, err)
+		assert.Equal(t, cake.Id, res.CakeId)
+		assert.Equal(t, model.ReviewStatusPending, res.Status)
+		assert.Equal(t, "Enak sekali", res.Text)
+		assert.Equal(t, "Sari", res.Author)
+		assert.Equal(t, model.ReviewFingerprint("enak   SEKALI"), res.Fingerprint)
+	})
+
+	t.Run("ok - rejection outrank flag", func(t *testing.T) {
+		mockCakeRepo.EXPECT().FindById(gomock.Any(), cake.Id).Times(1).Return(cake, nil)
+		mockLinks.EXPECT().Screen(gomock.Any(), gomock.Any()).Times(1).
+			Return(&model.ScreeningVerdict{Status: model.ReviewStatusFlagged, Reason: model.ScreeningReasonLink}, nil)
+		mockDuplicates.EXPECT().Screen(gomock.Any(), gomock.Any()).Times(1).
+			Return(&model.ScreeningVerdict{Status: model.ReviewStatusRejected, Reason: model.ScreeningReasonDuplicate}, nil)
+		mockReviewRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+
+		res, err := reviewService.Create(ctx, req, cake.Id)
+		require.NoError(t, err)
+		assert.Equal(t, model.ReviewStatusRejected, res.Status)
+		assert.Equal(t, []string{model.ScreeningReasonLink, model.ScreeningReasonDuplicate}, res.Screening)
+	})
+
+	t.Run("error from screener", func(t *testing.T) {
+		mockCakeRepo.EXPECT().FindById(gomock.Any(), cake.Id).Times(1).Return(cake, nil)
+		mockLinks.EXPECT().Screen(gomock.Any(), gomock.Any()).Times(1).Return(nil, errors.New("err db"))
+		mockReviewRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Times(0)
+
+		res, err := reviewService.Create(ctx, req, cake.Id)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("score out of range", func(t *testing.T) {
+		req := req
+		req.Score = 11
+
+		mockCakeRepo.EXPECT().FindById(gomock.Any(), cake.Id).Times(1).Return(cake, nil)
+		mockReviewRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Times(0)
+
+		res, err := reviewService.Create(ctx, req, cake.Id)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("cake not found", func(t *testing.T) {
+		mockCakeRepo.EXPECT().FindById(gomock.Any(), 2).Times(1).Return(nil, nil)
+		mockReviewRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Times(0)
+
+		res, err := reviewService.Create(ctx, req, 2)
+		assert.Equal(t, constant.ErrNotFound, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("error from repo", func(t *testing.T) {
+		mockCakeRepo.EXPECT().FindById(gomock.Any(), cake.Id).Times(1).Return(cake, nil)
+		mockLinks.EXPECT().Screen(gomock.Any(), gomock.Any()).Times(1).Return(nil, nil)
+		mockDuplicates.EXPECT().Screen(gomock.Any(), gomock.Any()).Times(1).Return(nil, nil)
+		mockReviewRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Times(1).Return(errors.New("err db"))
+
+		res, err := reviewService.Create(ctx, req, cake.Id)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestReviewService_Update(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.TODO()
+	mockReviewRepo := mock.NewMockReviewRepository(ctrl)
+	mockCakeRepo := mock.NewMockCakeRepository(ctrl)
+
+	reviewService := &reviewService{
+		reviewRepository: mockReviewRepo,
+		cakeRepository:   mockCakeRepo,
+	}
+
+	cake := &model.Cake{Id: 1, Title: "Kue Test", Version: 1}
+	req := model.CreateUpdateReviewRequest{Score: 4, Text: "Terlalu manis", Author: "Sari"}
+
+	t.Run("ok", func(t *testing.T) {
+		mockCakeRepo.EXPECT().FindById(gomock.Any(), cake.Id).Times(1).Return(cake, nil)
+		mockReviewRepo.EXPECT().FindById(gomock.Any(), 3).Times(1).Return(&model.Review{Id: 3, CakeId: 1, Score: 9, Author: "Sari"}, nil)
+		mockReviewRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+		mockCakeRepo.EXPECT().Touch(gomock.Any(), cake.Id).Times(1).Return(nil)
+
+		res, err := reviewService.Update(ctx, req, cake.Id, 3)
+		require.NoError(t, err)
+		assert.Equal(t, 4, res.Score)
+		assert.Equal(t, "Terlalu manis", res.Text)
+	})
+
+	t.Run("review of another cake", func(t *testing.T) {
+		mockCakeRepo.EXPECT().FindById(gomock.Any(), cake.Id).Times(1).Return(cake, nil)
+		mockReviewRepo.EXPECT().FindById(gomock.Any(), 4).Times(1).Return(&model.Review{Id: 4, CakeId: 2}, nil)
+		mockReviewRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Times(0)
+
+		res, err := reviewService.Update(ctx, req, cake.Id, 4)
+		assert.Equal(t, constant.ErrNotFound, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestReviewService_Delete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.TODO()
+	mockReviewRepo := mock.NewMockReviewRepository(ctrl)
+	mockCakeRepo := mock.NewMockCakeRepository(ctrl)
+
+	reviewService := &reviewService{
+		reviewRepository: mockReviewRepo,
+		cakeRepository:   mockCakeRepo,
+	}
+
+	cake := &model.Cake{Id: 1, Title: "Kue Test", Version: 1}
+	review := &model.Review{Id: 3, CakeId: 1, Score: 9}
+
+	t.Run("ok", func(t *testing.T) {
+		mockCakeRepo.EXPECT().FindById(gomock.Any(), cake.Id).Times(1).Return(cake, nil)
+		mockReviewRepo.EXPECT().FindById(gomock.Any(), 3).Times(1).Return(review, nil)
+		mockReviewRepo.EXPECT().Delete(gomock.Any(), review).Times(1).Return(nil)
+		mockCakeRepo.EXPECT().Touch(gomock.Any(), cake.Id).Times(1).Return(nil)
+
+		res, err := reviewService.Delete(ctx, cake.Id, 3)
+		require.NoError(t, err)
+		assert.Equal(t, review, res)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mockCakeRepo.EXPECT().FindById(gomock.Any(), cake.Id).Times(1).Return(cake, nil)
+		mockReviewRepo.EXPECT().FindById(gomock.Any(), 5).Times(1).Return(nil, nil)
+		mockReviewRepo.EXPECT().Delete(gomock.Any(), gomock.Any()).Times(0)
+
+		res, err := reviewService.Delete(ctx, cake.Id, 5)
+		assert.Equal(t, constant.ErrNotFound, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestReviewService_Moderate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.TODO()
+	mockReviewRepo := mock.NewMockReviewRepository(ctrl)
+	mockCakeRepo := mock.NewMockCakeRepository(ctrl)
+
+	reviewService := &reviewService{
+		reviewRepository: mockReviewRepo,
+		cakeRepository:   mockCakeRepo,
+	}
+
+	t.Run("ok - approve", func(t *testing.T) {
+		mockReviewRepo.EXPECT().FindById(gomock.Any(), 3).Times(1).Return(&model.Review{Id: 3, CakeId: 1, Status: model.ReviewStatusPending}, nil)
+		mockReviewRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+		mockCakeRepo.EXPECT().Touch(gomock.Any(), 1).Times(1).Return(nil)
+
+		res, err := reviewService.Moderate(ctx, model.ModerateReviewRequest{Status: model.ReviewStatusApproved, Note: " ok "}, 3)
+		require.NoError(t, err)
+		assert.Equal(t, model.ReviewStatusApproved, res.Status)
+		assert.Equal(t, "ok", res.ModerationNote)
+		assert.NotNil(t, res.ModeratedAt)
+	})
+
+	t.Run("back to pending", func(t *testing.T) {
+		mockReviewRepo.EXPECT().FindById(gomock.Any(), gomock.Any()).Times(0)
+
+		res, err := reviewService.Moderate(ctx, model.ModerateReviewRequest{Status: model.ReviewStatusPending}, 3)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mockReviewRepo.EXPECT().FindById(gomock.Any(), 5).Times(1).Return(nil, nil)
+
+		res, err := reviewService.Moderate(ctx, model.ModerateReviewRequest{Status: model.ReviewStatusRejected}, 5)
+		assert.Equal(t, constant.ErrNotFound, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestReviewService_FindAll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.TODO()
+	mockReviewRepo := mock.NewMockReviewRepository(ctrl)
+	mockCakeRepo := mock.NewMockCakeRepository(ctrl)
+
+	reviewService := &reviewService{
+		reviewRepository: mockReviewRepo,
+		cakeRepository:   mockCakeRepo,
+	}
+
+	cake := &model.Cake{Id: 1, Title: "Kue Test", Version: 1}
+
+	t.Run("ok", func(t *testing.T) {
+		query := model.ReviewQuery{Page: model.DefaultPage, Limit: model.DefaultLimit, CakeId: cake.Id, Status: model.ReviewStatusApproved}
+		mockCakeRepo.EXPECT().FindById(gomock.Any(), cake.Id).Times(1).Return(cake, nil)
+		mockReviewRepo.EXPECT().FindAll(gomock.Any(), query).Times(1).Return([]*model.Review{{Id: 3, CakeId: 1}}, nil)
+		mockReviewRepo.EXPECT().CountAll(gomock.Any(), query).Times(1).Return(int64(1), nil)
+
+		res, pagination, err := reviewService.FindAll(ctx, model.ReviewQuery{Status: model.ReviewStatusApproved}, cake.Id)
+		require.NoError(t, err)
+		assert.Len(t, res, 1)
+		assert.Equal(t, int64(1), pagination.Total)
+	})
+
+	t.Run("validate error", func(t *testing.T) {
+		mockCakeRepo.EXPECT().FindById(gomock.Any(), cake.Id).Times(1).Return(cake, nil)
+
+		res, pagination, err := reviewService.FindAll(ctx, model.ReviewQuery{Limit: 500}, cake.Id)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+		assert.Nil(t, pagination)
+	})
+
+	t.Run("moderation queue default to pending", func(t *testing.T) {
+		query := model.ReviewQuery{Page: model.DefaultPage, Limit: model.DefaultLimit, Status: model.ReviewStatusPending}
+		mockReviewRepo.EXPECT().FindAll(gomock.Any(), query).Times(1).Return([]*model.Review{}, nil)
+		mockReviewRepo.EXPECT().CountAll(gomock.Any(), query).Times(1).Return(int64(0), nil)
+
+		_, pagination, err := reviewService.FindModeration(ctx, model.ReviewQuery{})
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), pagination.Total)
+	})
+}
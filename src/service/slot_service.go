@@ -0,0 +1,427 @@
+package service
+
+import (
+	"cake-store/src/config"
+	"cake-store/src/constant"
+	"cake-store/src/model"
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+type slotService struct {
+	slotRepository  model.SlotRepository
+	storeRepository model.StoreRepository
+}
+
+func NewSlotService(slotRepository model.SlotRepository, storeRepository model.StoreRepository) model.SlotService {
+	return &slotService{
+		slotRepository:  slotRepository,
+		storeRepository: storeRepository,
+	}
+}
+
+func (s *slotService) FindOpeningHours(ctx context.Context, storeId int) ([]*model.OpeningHours, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Find Opening Hours Slot Service",
+		"storeId": storeId,
+	})
+
+	if _, err := s.findStore(ctx, storeId); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	hours, err := s.slotRepository.FindOpeningHours(ctx, storeId)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return hours, nil
+}
+
+// SetOpeningHours replace the weekly opening hours of the store, the booked orders keep their slots
+func (s *slotService) SetOpeningHours(ctx context.Context, req model.SetOpeningHoursRequest, storeId int) ([]*model.OpeningHours, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Set Opening Hours Slot Service",
+		"req":     req,
+		"storeId": storeId,
+	})
+
+	if err := req.Validate(); err != nil {
+		log.Error(err)
+		return nil, constant.HttpValidationOrInternalErr(err)
+	}
+
+	if reason := req.Inconsistency(); reason != "" {
+		log.Error(reason)
+		return nil, constant.OpeningHoursRejectedErr(reason)
+	}
+
+	if _, err := s.findStore(ctx, storeId); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	now := time.Now()
+	hours := make([]*model.OpeningHours, 0, len(req.Days))
+	for _, day := range req.Days {
+		hours = append(hours, &model.OpeningHours{
+			StoreId:      storeId,
+			Weekday:      day.Weekday,
+			OpensAt:      day.OpensAt,
+			ClosesAt:     day.ClosesAt,
+			SlotCapacity: day.SlotCapacity,
+			UpdatedAt:    now,
+		})
+	}
+
+	if err := s.slotRepository.SaveOpeningHours(ctx, storeId, hours); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return hours, nil
+}
+
+// FindClosures find the closures of the store from today on
+func (s *slotService) FindClosures(ctx context.Context, storeId int) ([]*model.Closure, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Find Closures Slot Service",
+		"storeId": storeId,
+	})
+
+	if _, err := s.findStore(ctx, storeId); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	closures, err := s.slotRepository.FindClosures(ctx, storeId, startOfDay(time.Now()))
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return closures, nil
+}
+
+func (s *slotService) CreateClosure(ctx context.Context, req model.CreateClosureRequest, storeId int) (*model.Closure, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Create Closure Slot Service",
+		"req":     req,
+		"storeId": storeId,
+	})
+
+	if err := req.Validate(); err != nil {
+		log.Error(err)
+		return nil, constant.HttpValidationOrInternalErr(err)
+	}
+
+	if _, err := s.findStore(ctx, storeId); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	closure := &model.Closure{
+		StoreId:   storeId,
+		Date:      req.Date,
+		Reason:    req.Reason,
+		CreatedAt: time.Now(),
+	}
+	if err := s.slotRepository.SaveClosure(ctx, closure); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return closure, nil
+}
+
+func (s *slotService) DeleteClosure(ctx context.Context, storeId int, date string) (*model.Closure, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Delete Closure Slot Service",
+		"storeId": storeId,
+		"date":    date,
+	})
+
+	day, err := time.ParseInLocation(model.DateLayout, date, time.Local)
+	if err != nil {
+		log.Error(err)
+		return nil, constant.ErrInvalidArgument
+	}
+
+	closure, err := s.slotRepository.FindClosure(ctx, storeId, day)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	if closure == nil {
+		log.Error(constant.ErrNotFound)
+		return nil, constant.ErrNotFound
+	}
+
+	if err = s.slotRepository.DeleteClosure(ctx, closure); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return closure, nil
+}
+
+// Availability list the slots of the store on the date with what is left of their capacity, an inactive store is not
+// found. The booking counters are preferred over the orders as they also hold the checkouts in progress
+func (s *slotService) Availability(ctx context.Context, query model.SlotQuery) (*model.DaySlots, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Availability Slot Service",
+		"query":   query,
+	})
+
+	if err := query.Validate(); err != nil {
+		log.Error(err)
+		return nil, constant.HttpValidationOrInternalErr(err)
+	}
+
+	storeId := query.StoreId
+	if storeId == 0 {
+		storeId = config.DefaultStoreId()
+	}
+
+	store, err := s.findStore(ctx, storeId)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	if !store.Active {
+		log.Error(constant.ErrNotFound)
+		return nil, constant.ErrNotFound
+	}
+
+	now := time.Now()
+	date := startOfDay(now)
+	if query.Date != "" {
+		parsed, err := time.ParseInLocation(model.DateLayout, query.Date, time.Local)
+		if err != nil {
+			log.Error(err)
+			return nil, constant.ErrInvalidArgument
+		}
+		date = parsed
+	}
+
+	day := &model.DaySlots{
+		StoreId: storeId,
+		Date:    date.Format(model.DateLayout),
+		Slots:   make([]*model.Slot, 0),
+	}
+
+	hours, slots, closure, err := s.daySlots(ctx, storeId, date)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	if closure != nil || hours == nil {
+		day.Closed = true
+		if closure != nil {
+			day.Reason = closure.Reason
+		}
+		return day, nil
+	}
+
+	booked, err := s.slotRepository.CountBooked(ctx, storeId, date, date.AddDate(0, 0, 1))
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	counters, err := s.slotRepository.Booked(ctx, storeId, slots)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	for key, units := range counters {
+		booked[key] = units
+	}
+
+	length := config.SlotLength()
+	for _, start := range slots {
+		slot := &model.Slot{
+			StartAt:  start,
+			EndAt:    start.Add(length),
+			Capacity: hours.SlotCapacity,
+			Booked:   booked[start.Format(model.SlotLayout)],
+		}
+		slot.SetAvailable(now)
+		day.Slots = append(day.Slots, slot)
+	}
+
+	return day, nil
+}
+
+// Book hold the units in the slot of the store, the slot must be a coming slot of an open day. The booking counter
+// of the slot is seeded from the orders when it has none, so a lost counter does not oversell the slot
+func (s *slotService) Book(ctx context.Context, storeId int, slot time.Time, units int) error {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Book Slot Service",
+		"storeId": storeId,
+		"slot":    slot,
+		"units":   units,
+	})
+
+	hours, slots, closure, err := s.daySlots(ctx, storeId, slot)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	if closure != nil || hours == nil || !time.Now().Before(slot) || !containsTime(slots, slot) {
+		log.Error(constant.ErrSlotUnavailable)
+		return constant.ErrSlotUnavailable
+	}
+
+	if units > hours.SlotCapacity {
+		log.Error(constant.ErrSlotFull)
+		return constant.ErrSlotFull
+	}
+
+	key := slot.Format(model.SlotLayout)
+	counters, err := s.slotRepository.Booked(ctx, storeId, []time.Time{slot})
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	seed := 0
+	if _, ok := counters[key]; !ok {
+		booked, err := s.slotRepository.CountBooked(ctx, storeId, slot, slot.Add(config.SlotLength()))
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+		seed = booked[key]
+	}
+
+	if err = s.slotRepository.Book(ctx, storeId, slot, units, hours.SlotCapacity, seed, bookingTTL(slot)); err != nil {
+		log.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+func (s *slotService) Release(ctx context.Context, storeId int, slot time.Time, units int) error {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Release Slot Service",
+		"storeId": storeId,
+		"slot":    slot,
+		"units":   units,
+	})
+
+	if err := s.slotRepository.Release(ctx, storeId, slot, units); err != nil {
+		log.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// Reconcile reset the booking counters of the slots of the store on the date to the units of the orders, it fix the
+// counters left behind by the checkouts that failed between the booking and the release. A checkout in progress is
+// not counted yet, so a slot booked or released within the reconcile grace is only raised
+func (s *slotService) Reconcile(ctx context.Context, storeId int, date time.Time) (int, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Reconcile Slot Service",
+		"storeId": storeId,
+		"date":    date,
+	})
+
+	date = startOfDay(date)
+	hours, slots, _, err := s.daySlots(ctx, storeId, date)
+	if err != nil {
+		log.Error(err)
+		return 0, err
+	}
+
+	if hours == nil {
+		return 0, nil
+	}
+
+	booked, err := s.slotRepository.CountBooked(ctx, storeId, date, date.AddDate(0, 0, 1))
+	if err != nil {
+		log.Error(err)
+		return 0, err
+	}
+
+	reconciled := 0
+	for _, slot := range slots {
+		ok, err := s.slotRepository.Reconcile(ctx, storeId, slot, booked[slot.Format(model.SlotLayout)], bookingTTL(slot))
+		if err != nil {
+			log.Error(err)
+			return 0, err
+		}
+		if ok {
+			reconciled++
+		}
+	}
+
+	return reconciled, nil
+}
+
+// findStore find the store of the slots, a missing store is not found
+func (s *slotService) findStore(ctx context.Context, storeId int) (*model.Store, error) {
+	store, err := s.storeRepository.FindById(ctx, storeId)
+	if err != nil {
+		return nil, err
+	}
+
+	if store == nil {
+		return nil, constant.ErrNotFound
+	}
+	return store, nil
+}
+
+// daySlots find the opening hours of the store on the weekday of the date, the start of its slots and its closure.
+// The hours are nil when the store does not open on the weekday
+func (s *slotService) daySlots(ctx context.Context, storeId int, date time.Time) (*model.OpeningHours, []time.Time, *model.Closure, error) {
+	closure, err := s.slotRepository.FindClosure(ctx, storeId, date)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	week, err := s.slotRepository.FindOpeningHours(ctx, storeId)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	for _, hours := range week {
+		if time.Weekday(hours.Weekday) == date.Weekday() {
+			return hours, hours.Slots(date, config.SlotLength()), closure, nil
+		}
+	}
+	return nil, nil, closure, nil
+}
+
+// bookingTTL keep the booking counter of the slot a while after the slot ended
+func bookingTTL(slot time.Time) time.Duration {
+	ttl := time.Until(slot.Add(config.SlotLength()))
+	if ttl < 0 {
+		ttl = 0
+	}
+	return ttl + config.SlotBookingTTL()
+}
+
+func containsTime(times []time.Time, t time.Time) bool {
+	for _, candidate := range times {
+		if candidate.Equal(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// startOfDay return the local midnight of the day of now
+func startOfDay(now time.Time) time.Time {
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+}
@@ -0,0 +1,340 @@
+package service
+
+import (
+	"cake-store/src/constant"
+	"cake-store/src/model"
+	"cake-store/src/model/mock"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSlotService_SetOpeningHours(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.TODO()
+	mockSlotRepo := mock.NewMockSlotRepository(ctrl)
+	mockStoreRepo := mock.NewMockStoreRepository(ctrl)
+
+	slotService := &slotService{
+		slotRepository:  mockSlotRepo,
+		storeRepository: mockStoreRepo,
+	}
+
+	mockStoreRepo.EXPECT().FindById(gomock.Any(), 1).AnyTimes().Return(&model.Store{Id: 1, Active: true}, nil)
+
+	req := model.SetOpeningHoursRequest{Days: []model.OpeningHoursRequest{{Weekday: 1, OpensAt: "08:00", ClosesAt: "17:00", SlotCapacity: 10}}}
+
+	t.Run("ok", func(t *testing.T) {
+		mockSlotRepo.EXPECT().SaveOpeningHours(gomock.Any(), 1, gomock.Any()).Times(1).Return(nil)
+
+		res, err := slotService.SetOpeningHours(ctx, req, 1)
+		require.NoError(t, err)
+		require.Len(t, res, 1)
+		assert.Equal(t, 10, res[0].SlotCapacity)
+	})
+
+	t.Run("closes before it opens", func(t *testing.T) {
+		req := model.SetOpeningHoursRequest{Days: []model.OpeningHoursRequest{{Weekday: 1, OpensAt: "17:00", ClosesAt: "08:00", SlotCapacity: 10}}}
+
+		res, err := slotService.SetOpeningHours(ctx, req, 1)
+		assert.Equal(t, constant.OpeningHoursRejectedErr("Monday closes before it opens"), err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("invalid time", func(t *testing.T) {
+		req := model.SetOpeningHoursRequest{Days: []model.OpeningHoursRequest{{Weekday: 1, OpensAt: "8am", ClosesAt: "17:00", SlotCapacity: 10}}}
+
+		res, err := slotService.SetOpeningHours(ctx, req, 1)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("failed to save", func(t *testing.T) {
+		mockSlotRepo.EXPECT().SaveOpeningHours(gomock.Any(), 1, gomock.Any()).Times(1).Return(errors.New("err db"))
+
+		res, err := slotService.SetOpeningHours(ctx, req, 1)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestSlotService_Closures(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.TODO()
+	mockSlotRepo := mock.NewMockSlotRepository(ctrl)
+	mockStoreRepo := mock.NewMockStoreRepository(ctrl)
+
+	slotService := &slotService{
+		slotRepository:  mockSlotRepo,
+		storeRepository: mockStoreRepo,
+	}
+
+	mockStoreRepo.EXPECT().FindById(gomock.Any(), 1).AnyTimes().Return(&model.Store{Id: 1, Active: true}, nil)
+
+	date := time.Date(2026, 12, 25, 0, 0, 0, 0, time.Local)
+	closure := &model.Closure{Date: "2026-12-25", Reason: "Christmas"}
+
+	t.Run("create", func(t *testing.T) {
+		mockSlotRepo.EXPECT().SaveClosure(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+
+		res, err := slotService.CreateClosure(ctx, model.CreateClosureRequest{Date: "2026-12-25", Reason: "Christmas"}, 1)
+		require.NoError(t, err)
+		assert.Equal(t, "Christmas", res.Reason)
+	})
+
+	t.Run("create - already closed", func(t *testing.T) {
+		mockSlotRepo.EXPECT().SaveClosure(gomock.Any(), gomock.Any()).Times(1).Return(constant.ErrAlreadyExists)
+
+		res, err := slotService.CreateClosure(ctx, model.CreateClosureRequest{Date: "2026-12-25"}, 1)
+		assert.Equal(t, constant.ErrAlreadyExists, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("create - invalid date", func(t *testing.T) {
+		res, err := slotService.CreateClosure(ctx, model.CreateClosureRequest{Date: "25-12-2026"}, 1)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		mockSlotRepo.EXPECT().FindClosure(gomock.Any(), 1, date).Times(1).Return(closure, nil)
+		mockSlotRepo.EXPECT().DeleteClosure(gomock.Any(), closure).Times(1).Return(nil)
+
+		res, err := slotService.DeleteClosure(ctx, 1, "2026-12-25")
+		require.NoError(t, err)
+		assert.Equal(t, closure, res)
+	})
+
+	t.Run("delete - not found", func(t *testing.T) {
+		mockSlotRepo.EXPECT().FindClosure(gomock.Any(), 1, date).Times(1).Return(nil, nil)
+
+		res, err := slotService.DeleteClosure(ctx, 1, "2026-12-25")
+		assert.Equal(t, constant.ErrNotFound, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("delete - invalid date", func(t *testing.T) {
+		res, err := slotService.DeleteClosure(ctx, 1, "christmas")
+		assert.Equal(t, constant.ErrInvalidArgument, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestSlotService_Availability(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.TODO()
+	mockSlotRepo := mock.NewMockSlotRepository(ctrl)
+	mockStoreRepo := mock.NewMockStoreRepository(ctrl)
+
+	slotService := &slotService{
+		slotRepository:  mockSlotRepo,
+		storeRepository: mockStoreRepo,
+	}
+
+	mockStoreRepo.EXPECT().FindById(gomock.Any(), 1).AnyTimes().Return(&model.Store{Id: 1, Active: true}, nil)
+
+	date := startOfDay(time.Now().AddDate(0, 0, 1))
+	query := model.SlotQuery{Date: date.Format(model.DateLayout)}
+	hours := []*model.OpeningHours{{Weekday: int(date.Weekday()), OpensAt: "09:00", ClosesAt: "12:00", SlotCapacity: 10}}
+	nine, ten := date.Add(9*time.Hour), date.Add(10*time.Hour)
+
+	t.Run("ok", func(t *testing.T) {
+		mockSlotRepo.EXPECT().FindClosure(gomock.Any(), 1, date).Times(1).Return(nil, nil)
+		mockSlotRepo.EXPECT().FindOpeningHours(gomock.Any(), 1).Times(1).Return(hours, nil)
+		mockSlotRepo.EXPECT().CountBooked(gomock.Any(), 1, date, date.AddDate(0, 0, 1)).Times(1).
+			Return(map[string]int{nine.Format(model.SlotLayout): 3, ten.Format(model.SlotLayout): 2}, nil)
+		mockSlotRepo.EXPECT().Booked(gomock.Any(), 1, gomock.Len(3)).Times(1).
+			Return(map[string]int{nine.Format(model.SlotLayout): 10}, nil)
+
+		res, err := slotService.Availability(ctx, query)
+		require.NoError(t, err)
+		assert.Equal(t, 1, res.StoreId)
+		assert.False(t, res.Closed)
+		require.Len(t, res.Slots, 3)
+		assert.Equal(t, nine, res.Slots[0].StartAt)
+		assert.Equal(t, ten, res.Slots[0].EndAt)
+		assert.Equal(t, 0, res.Slots[0].Available)
+		assert.Equal(t, 8, res.Slots[1].Available)
+		assert.Equal(t, 10, res.Slots[2].Available)
+	})
+
+	t.Run("holiday", func(t *testing.T) {
+		mockSlotRepo.EXPECT().FindClosure(gomock.Any(), 1, date).Times(1).Return(&model.Closure{Reason: "Holiday"}, nil)
+		mockSlotRepo.EXPECT().FindOpeningHours(gomock.Any(), 1).Times(1).Return(hours, nil)
+
+		res, err := slotService.Availability(ctx, query)
+		require.NoError(t, err)
+		assert.True(t, res.Closed)
+		assert.Equal(t, "Holiday", res.Reason)
+		assert.Empty(t, res.Slots)
+	})
+
+	t.Run("closed weekday", func(t *testing.T) {
+		mockSlotRepo.EXPECT().FindClosure(gomock.Any(), 1, date).Times(1).Return(nil, nil)
+		mockSlotRepo.EXPECT().FindOpeningHours(gomock.Any(), 1).Times(1).Return(nil, nil)
+
+		res, err := slotService.Availability(ctx, query)
+		require.NoError(t, err)
+		assert.True(t, res.Closed)
+	})
+
+	t.Run("inactive store", func(t *testing.T) {
+		mockStoreRepo.EXPECT().FindById(gomock.Any(), 2).Times(1).Return(&model.Store{Id: 2, Active: false}, nil)
+
+		res, err := slotService.Availability(ctx, model.SlotQuery{StoreId: 2, Date: query.Date})
+		assert.Equal(t, constant.ErrNotFound, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("invalid date", func(t *testing.T) {
+		res, err := slotService.Availability(ctx, model.SlotQuery{Date: "tomorrow"})
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestSlotService_Book(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.TODO()
+	mockSlotRepo := mock.NewMockSlotRepository(ctrl)
+
+	slotService := &slotService{
+		slotRepository: mockSlotRepo,
+	}
+
+	date := startOfDay(time.Now().AddDate(0, 0, 1))
+	hours := []*model.OpeningHours{{Weekday: int(date.Weekday()), OpensAt: "09:00", ClosesAt: "12:00", SlotCapacity: 10}}
+	slot := date.Add(10 * time.Hour)
+	key := slot.Format(model.SlotLayout)
+
+	t.Run("ok - seeded from the orders", func(t *testing.T) {
+		mockSlotRepo.EXPECT().FindClosure(gomock.Any(), 1, slot).Times(1).Return(nil, nil)
+		mockSlotRepo.EXPECT().FindOpeningHours(gomock.Any(), 1).Times(1).Return(hours, nil)
+		mockSlotRepo.EXPECT().Booked(gomock.Any(), 1, []time.Time{slot}).Times(1).Return(map[string]int{}, nil)
+		mockSlotRepo.EXPECT().CountBooked(gomock.Any(), 1, slot, slot.Add(time.Hour)).Times(1).Return(map[string]int{key: 4}, nil)
+		mockSlotRepo.EXPECT().Book(gomock.Any(), 1, slot, 2, 10, 4, gomock.Any()).Times(1).Return(nil)
+
+		require.NoError(t, slotService.Book(ctx, 1, slot, 2))
+	})
+
+	t.Run("ok - counted", func(t *testing.T) {
+		mockSlotRepo.EXPECT().FindClosure(gomock.Any(), 1, slot).Times(1).Return(nil, nil)
+		mockSlotRepo.EXPECT().FindOpeningHours(gomock.Any(), 1).Times(1).Return(hours, nil)
+		mockSlotRepo.EXPECT().Booked(gomock.Any(), 1, []time.Time{slot}).Times(1).Return(map[string]int{key: 6}, nil)
+		mockSlotRepo.EXPECT().Book(gomock.Any(), 1, slot, 2, 10, 0, gomock.Any()).Times(1).Return(nil)
+
+		require.NoError(t, slotService.Book(ctx, 1, slot, 2))
+	})
+
+	t.Run("full", func(t *testing.T) {
+		mockSlotRepo.EXPECT().FindClosure(gomock.Any(), 1, slot).Times(1).Return(nil, nil)
+		mockSlotRepo.EXPECT().FindOpeningHours(gomock.Any(), 1).Times(1).Return(hours, nil)
+		mockSlotRepo.EXPECT().Booked(gomock.Any(), 1, []time.Time{slot}).Times(1).Return(map[string]int{key: 9}, nil)
+		mockSlotRepo.EXPECT().Book(gomock.Any(), 1, slot, 2, 10, 0, gomock.Any()).Times(1).Return(constant.ErrSlotFull)
+
+		assert.Equal(t, constant.ErrSlotFull, slotService.Book(ctx, 1, slot, 2))
+	})
+
+	t.Run("more than the capacity", func(t *testing.T) {
+		mockSlotRepo.EXPECT().FindClosure(gomock.Any(), 1, slot).Times(1).Return(nil, nil)
+		mockSlotRepo.EXPECT().FindOpeningHours(gomock.Any(), 1).Times(1).Return(hours, nil)
+
+		assert.Equal(t, constant.ErrSlotFull, slotService.Book(ctx, 1, slot, 11))
+	})
+
+	t.Run("not a slot", func(t *testing.T) {
+		slot := date.Add(10*time.Hour + 30*time.Minute)
+		mockSlotRepo.EXPECT().FindClosure(gomock.Any(), 1, slot).Times(1).Return(nil, nil)
+		mockSlotRepo.EXPECT().FindOpeningHours(gomock.Any(), 1).Times(1).Return(hours, nil)
+
+		assert.Equal(t, constant.ErrSlotUnavailable, slotService.Book(ctx, 1, slot, 1))
+	})
+
+	t.Run("closed", func(t *testing.T) {
+		mockSlotRepo.EXPECT().FindClosure(gomock.Any(), 1, slot).Times(1).Return(&model.Closure{Reason: "Holiday"}, nil)
+		mockSlotRepo.EXPECT().FindOpeningHours(gomock.Any(), 1).Times(1).Return(hours, nil)
+
+		assert.Equal(t, constant.ErrSlotUnavailable, slotService.Book(ctx, 1, slot, 1))
+	})
+
+	t.Run("started", func(t *testing.T) {
+		slot := slot.AddDate(0, 0, -7)
+		mockSlotRepo.EXPECT().FindClosure(gomock.Any(), 1, slot).Times(1).Return(nil, nil)
+		mockSlotRepo.EXPECT().FindOpeningHours(gomock.Any(), 1).Times(1).Return(hours, nil)
+
+		assert.Equal(t, constant.ErrSlotUnavailable, slotService.Book(ctx, 1, slot, 1))
+	})
+}
+
+func TestSlotService_Reconcile(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.TODO()
+	mockSlotRepo := mock.NewMockSlotRepository(ctrl)
+
+	slotService := &slotService{
+		slotRepository: mockSlotRepo,
+	}
+
+	date := startOfDay(time.Now().AddDate(0, 0, 1))
+	hours := []*model.OpeningHours{{Weekday: int(date.Weekday()), OpensAt: "09:00", ClosesAt: "11:00", SlotCapacity: 10}}
+	nine, ten := date.Add(9*time.Hour), date.Add(10*time.Hour)
+
+	t.Run("ok", func(t *testing.T) {
+		mockSlotRepo.EXPECT().FindClosure(gomock.Any(), 1, date).Times(1).Return(nil, nil)
+		mockSlotRepo.EXPECT().FindOpeningHours(gomock.Any(), 1).Times(1).Return(hours, nil)
+		mockSlotRepo.EXPECT().CountBooked(gomock.Any(), 1, date, date.AddDate(0, 0, 1)).Times(1).
+			Return(map[string]int{ten.Format(model.SlotLayout): 5}, nil)
+		mockSlotRepo.EXPECT().Reconcile(gomock.Any(), 1, nine, 0, gomock.Any()).Times(1).Return(true, nil)
+		mockSlotRepo.EXPECT().Reconcile(gomock.Any(), 1, ten, 5, gomock.Any()).Times(1).Return(true, nil)
+
+		res, err := slotService.Reconcile(ctx, 1, date.Add(15*time.Hour))
+		require.NoError(t, err)
+		assert.Equal(t, 2, res)
+	})
+
+	t.Run("ok - slot changed within the grace", func(t *testing.T) {
+		mockSlotRepo.EXPECT().FindClosure(gomock.Any(), 1, date).Times(1).Return(nil, nil)
+		mockSlotRepo.EXPECT().FindOpeningHours(gomock.Any(), 1).Times(1).Return(hours, nil)
+		mockSlotRepo.EXPECT().CountBooked(gomock.Any(), 1, date, date.AddDate(0, 0, 1)).Times(1).Return(map[string]int{}, nil)
+		mockSlotRepo.EXPECT().Reconcile(gomock.Any(), 1, nine, 0, gomock.Any()).Times(1).Return(false, nil)
+		mockSlotRepo.EXPECT().Reconcile(gomock.Any(), 1, ten, 0, gomock.Any()).Times(1).Return(true, nil)
+
+		res, err := slotService.Reconcile(ctx, 1, date)
+		require.NoError(t, err)
+		assert.Equal(t, 1, res)
+	})
+
+	t.Run("closed weekday", func(t *testing.T) {
+		mockSlotRepo.EXPECT().FindClosure(gomock.Any(), 1, date).Times(1).Return(nil, nil)
+		mockSlotRepo.EXPECT().FindOpeningHours(gomock.Any(), 1).Times(1).Return(nil, nil)
+
+		res, err := slotService.Reconcile(ctx, 1, date)
+		require.NoError(t, err)
+		assert.Equal(t, 0, res)
+	})
+
+	t.Run("failed to count", func(t *testing.T) {
+		mockSlotRepo.EXPECT().FindClosure(gomock.Any(), 1, date).Times(1).Return(nil, nil)
+		mockSlotRepo.EXPECT().FindOpeningHours(gomock.Any(), 1).Times(1).Return(hours, nil)
+		mockSlotRepo.EXPECT().CountBooked(gomock.Any(), 1, gomock.Any(), gomock.Any()).Times(1).Return(nil, errors.New("err db"))
+
+		res, err := slotService.Reconcile(ctx, 1, date)
+		assert.Error(t, err)
+		assert.Equal(t, 0, res)
+	})
+}
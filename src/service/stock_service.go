@@ -0,0 +1,359 @@
+package service
+
+import (
+	"cake-store/src/config"
+	"cake-store/src/constant"
+	"cake-store/src/helper"
+	"cake-store/src/model"
+	"context"
+	"math"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// stockAdjustmentsLimit is the number of latest adjustments listed
+const stockAdjustmentsLimit = 100
+
+type stockService struct {
+	stockRepository   model.StockRepository
+	cakeRepository    model.CakeRepository
+	variantRepository model.VariantRepository
+}
+
+func NewStockService(stockRepository model.StockRepository, cakeRepository model.CakeRepository, variantRepository model.VariantRepository) model.StockService {
+	return &stockService{
+		stockRepository:   stockRepository,
+		cakeRepository:    cakeRepository,
+		variantRepository: variantRepository,
+	}
+}
+
+func (s *stockService) FindByCakeId(ctx context.Context, cakeId int) ([]*model.Stock, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Find By Cake ID Stock Service",
+		"cakeId":  cakeId,
+	})
+
+	if err := s.findItem(ctx, cakeId, 0); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	stocks, err := s.stockRepository.FindByCakeId(ctx, cakeId)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	if err = s.setReserved(ctx, stocks...); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return stocks, nil
+}
+
+func (s *stockService) FindLow(ctx context.Context) ([]*model.Stock, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Find Low Stock Service",
+	})
+
+	stocks, err := s.stockRepository.FindLow(ctx)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	if err = s.setReserved(ctx, stocks...); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return stocks, nil
+}
+
+func (s *stockService) Adjust(ctx context.Context, req model.AdjustStockRequest, cakeId int) (*model.Stock, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Adjust Stock Service",
+		"req":     req,
+		"cakeId":  cakeId,
+	})
+
+	if err := req.Validate(); err != nil {
+		log.Error(err)
+		return nil, constant.HttpValidationOrInternalErr(err)
+	}
+
+	if err := s.findItem(ctx, cakeId, req.VariantId); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	stock, err := s.adjust(ctx, &model.StockAdjustment{
+		CakeId:    cakeId,
+		VariantId: req.VariantId,
+		Delta:     req.Delta,
+		Reason:    req.Reason,
+		Note:      req.Note,
+	})
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return stock, nil
+}
+
+func (s *stockService) SetThreshold(ctx context.Context, req model.SetStockThresholdRequest, cakeId int) (*model.Stock, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Set Threshold Stock Service",
+		"req":     req,
+		"cakeId":  cakeId,
+	})
+
+	if err := req.Validate(); err != nil {
+		log.Error(err)
+		return nil, constant.HttpValidationOrInternalErr(err)
+	}
+
+	if err := s.findItem(ctx, cakeId, req.VariantId); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	if err := s.stockRepository.SetThreshold(ctx, cakeId, req.VariantId, req.Threshold); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	stock, err := s.stockRepository.FindByItem(ctx, cakeId, req.VariantId)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	if stock == nil {
+		log.Error(constant.ErrNotFound)
+		return nil, constant.ErrNotFound
+	}
+
+	if err = s.setReserved(ctx, stock); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return stock, nil
+}
+
+func (s *stockService) FindAdjustments(ctx context.Context, cakeId int) ([]*model.StockAdjustment, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Find Adjustments Stock Service",
+		"cakeId":  cakeId,
+	})
+
+	if err := s.findItem(ctx, cakeId, 0); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	adjustments, err := s.stockRepository.FindAdjustments(ctx, cakeId, stockAdjustmentsLimit)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return adjustments, nil
+}
+
+// Reserve hold the quantity of the item until the reservation is committed, released or expired
+func (s *stockService) Reserve(ctx context.Context, req model.ReserveStockRequest, cakeId int) (*model.Reservation, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Reserve Stock Service",
+		"req":     req,
+		"cakeId":  cakeId,
+	})
+
+	if err := req.Validate(); err != nil {
+		log.Error(err)
+		return nil, constant.HttpValidationOrInternalErr(err)
+	}
+
+	if err := s.findItem(ctx, cakeId, req.VariantId); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	stock, err := s.stockRepository.FindByItem(ctx, cakeId, req.VariantId)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	if stock == nil {
+		log.Error(constant.ErrInsufficientStock)
+		return nil, constant.ErrInsufficientStock
+	}
+
+	id, err := helper.RandomToken()
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	ttl := config.StockReservationTTL()
+	if req.TTLSeconds > 0 {
+		ttl = time.Duration(req.TTLSeconds) * time.Second
+	}
+
+	reservation := &model.Reservation{
+		Id:        id,
+		CakeId:    cakeId,
+		VariantId: req.VariantId,
+		Quantity:  req.Quantity,
+		ExpiresAt: time.Now().Add(ttl),
+	}
+
+	if err = s.stockRepository.Reserve(ctx, reservation, stock.OnHand); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return reservation, nil
+}
+
+// Release give back the reserved quantity, an expired reservation is reported as not found
+func (s *stockService) Release(ctx context.Context, reservationId string) (*model.Reservation, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message":       "Release Stock Service",
+		"reservationId": reservationId,
+	})
+
+	reservation, err := s.release(ctx, reservationId)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return reservation, nil
+}
+
+// Commit turn the reservation into a sale, the reservation is claimed first so it is committed at most once,
+// it is held again when the sale can not be recorded
+func (s *stockService) Commit(ctx context.Context, reservationId string) (*model.Reservation, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message":       "Commit Stock Service",
+		"reservationId": reservationId,
+	})
+
+	reservation, err := s.release(ctx, reservationId)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	_, err = s.stockRepository.Adjust(ctx, &model.StockAdjustment{
+		CakeId:    reservation.CakeId,
+		VariantId: reservation.VariantId,
+		Delta:     -reservation.Quantity,
+		Reason:    model.StockReasonSale,
+		Note:      "reservation " + reservation.Id,
+		CreatedAt: time.Now(),
+	})
+	if err != nil {
+		log.Error(err)
+		s.rereserve(ctx, log, reservation)
+		return nil, err
+	}
+
+	return reservation, nil
+}
+
+// rereserve hold again the quantity of a claimed reservation until it expire, the on hand count is not checked
+// since the quantity was held before the claim
+func (s *stockService) rereserve(ctx context.Context, log *logrus.Entry, reservation *model.Reservation) {
+	if !time.Now().Before(reservation.ExpiresAt) {
+		return
+	}
+	if err := s.stockRepository.Reserve(ctx, reservation, math.MaxInt32); err != nil {
+		log.Error(err)
+	}
+}
+
+func (s *stockService) adjust(ctx context.Context, adjustment *model.StockAdjustment) (*model.Stock, error) {
+	adjustment.CreatedAt = time.Now()
+	stock, err := s.stockRepository.Adjust(ctx, adjustment)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.setReserved(ctx, stock); err != nil {
+		return nil, err
+	}
+
+	return stock, nil
+}
+
+func (s *stockService) release(ctx context.Context, reservationId string) (*model.Reservation, error) {
+	if reservationId == "" {
+		return nil, constant.ErrInvalidArgument
+	}
+
+	reservation, err := s.stockRepository.FindReservation(ctx, reservationId)
+	if err != nil {
+		return nil, err
+	}
+
+	if reservation == nil {
+		return nil, constant.ErrNotFound
+	}
+
+	if err = s.stockRepository.Release(ctx, reservation); err != nil {
+		return nil, err
+	}
+
+	return reservation, nil
+}
+
+// setReserved fill the live reservations of the stocks
+func (s *stockService) setReserved(ctx context.Context, stocks ...*model.Stock) error {
+	for _, stock := range stocks {
+		reserved, err := s.stockRepository.Reserved(ctx, stock.CakeId, stock.VariantId)
+		if err != nil {
+			return err
+		}
+		stock.SetReserved(reserved)
+	}
+	return nil
+}
+
+// findItem check the cake exist and is not deleted, and the variant when given belong to the cake
+func (s *stockService) findItem(ctx context.Context, cakeId int, variantId int) error {
+	if cakeId == 0 {
+		return constant.ErrInvalidArgument
+	}
+
+	cake, err := s.cakeRepository.FindById(ctx, cakeId)
+	if err != nil {
+		return err
+	}
+
+	if cake == nil {
+		return constant.ErrNotFound
+	}
+
+	if variantId == 0 {
+		return nil
+	}
+
+	variant, err := s.variantRepository.FindById(ctx, variantId)
+	if err != nil {
+		return err
+	}
+
+	if variant == nil || variant.CakeId != cakeId {
+		return constant.ErrNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,310 @@
+package service
+
+import (
+	"cake-store/src/constant"
+	"cake-store/src/model"
+	"cake-store/src/model/mock"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStockService_FindByCakeId(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.TODO()
+	mockStockRepo := mock.NewMockStockRepository(ctrl)
+	mockCakeRepo := mock.NewMockCakeRepository(ctrl)
+
+	stockService := &stockService{
+		stockRepository: mockStockRepo,
+		cakeRepository:  mockCakeRepo,
+	}
+
+	cake := &model.Cake{Id: 1, Title: "Kue Test", Version: 1}
+
+	t.Run("ok", func(t *testing.T) {
+		stocks := []*model.Stock{{Id: 1, CakeId: cake.Id, OnHand: 5, LowStockThreshold: 2}}
+		mockCakeRepo.EXPECT().FindById(gomock.Any(), cake.Id).Times(1).Return(cake, nil)
+		mockStockRepo.EXPECT().FindByCakeId(gomock.Any(), cake.Id).Times(1).Return(stocks, nil)
+		mockStockRepo.EXPECT().Reserved(gomock.Any(), cake.Id, 0).Times(1).Return(3, nil)
+
+		res, err := stockService.FindByCakeId(ctx, cake.Id)
+		require.NoError(t, err)
+		assert.Equal(t, 3, res[0].Reserved)
+		assert.Equal(t, 2, res[0].Available)
+		assert.True(t, res[0].LowStock)
+	})
+
+	t.Run("cake not found", func(t *testing.T) {
+		mockCakeRepo.EXPECT().FindById(gomock.Any(), 2).Times(1).Return(nil, nil)
+
+		res, err := stockService.FindByCakeId(ctx, 2)
+		assert.Equal(t, constant.ErrNotFound, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestStockService_Adjust(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.TODO()
+	mockStockRepo := mock.NewMockStockRepository(ctrl)
+	mockCakeRepo := mock.NewMockCakeRepository(ctrl)
+	mockVariantRepo := mock.NewMockVariantRepository(ctrl)
+
+	stockService := &stockService{
+		stockRepository:   mockStockRepo,
+		cakeRepository:    mockCakeRepo,
+		variantRepository: mockVariantRepo,
+	}
+
+	cake := &model.Cake{Id: 1, Title: "Kue Test", Version: 1}
+	req := model.AdjustStockRequest{VariantId: 5, Delta: 10, Reason: model.StockReasonRestock}
+
+	t.Run("ok", func(t *testing.T) {
+		mockCakeRepo.EXPECT().FindById(gomock.Any(), cake.Id).Times(1).Return(cake, nil)
+		mockVariantRepo.EXPECT().FindById(gomock.Any(), 5).Times(1).Return(&model.Variant{Id: 5, CakeId: cake.Id}, nil)
+		mockStockRepo.EXPECT().Adjust(gomock.Any(), gomock.Any()).Times(1).
+			DoAndReturn(func(_ context.Context, adjustment *model.StockAdjustment) (*model.Stock, error) {
+				assert.Equal(t, 10, adjustment.Delta)
+				assert.Equal(t, model.StockReasonRestock, adjustment.Reason)
+				return &model.Stock{CakeId: cake.Id, VariantId: 5, OnHand: 10}, nil
+			})
+		mockStockRepo.EXPECT().Reserved(gomock.Any(), cake.Id, 5).Times(1).Return(0, nil)
+
+		res, err := stockService.Adjust(ctx, req, cake.Id)
+		require.NoError(t, err)
+		assert.Equal(t, 10, res.Available)
+	})
+
+	t.Run("variant of another cake", func(t *testing.T) {
+		mockCakeRepo.EXPECT().FindById(gomock.Any(), cake.Id).Times(1).Return(cake, nil)
+		mockVariantRepo.EXPECT().FindById(gomock.Any(), 5).Times(1).Return(&model.Variant{Id: 5, CakeId: 3}, nil)
+		mockStockRepo.EXPECT().Adjust(gomock.Any(), gomock.Any()).Times(0)
+
+		res, err := stockService.Adjust(ctx, req, cake.Id)
+		assert.Equal(t, constant.ErrNotFound, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("validate error", func(t *testing.T) {
+		req := req
+		req.Reason = "gift"
+
+		res, err := stockService.Adjust(ctx, req, cake.Id)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("insufficient stock", func(t *testing.T) {
+		req := model.AdjustStockRequest{Delta: -10, Reason: model.StockReasonWaste}
+		mockCakeRepo.EXPECT().FindById(gomock.Any(), cake.Id).Times(1).Return(cake, nil)
+		mockStockRepo.EXPECT().Adjust(gomock.Any(), gomock.Any()).Times(1).Return(nil, constant.ErrInsufficientStock)
+
+		res, err := stockService.Adjust(ctx, req, cake.Id)
+		assert.Equal(t, constant.ErrInsufficientStock, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestStockService_SetThreshold(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.TODO()
+	mockStockRepo := mock.NewMockStockRepository(ctrl)
+	mockCakeRepo := mock.NewMockCakeRepository(ctrl)
+
+	stockService := &stockService{
+		stockRepository: mockStockRepo,
+		cakeRepository:  mockCakeRepo,
+	}
+
+	cake := &model.Cake{Id: 1, Title: "Kue Test", Version: 1}
+
+	t.Run("ok", func(t *testing.T) {
+		mockCakeRepo.EXPECT().FindById(gomock.Any(), cake.Id).Times(1).Return(cake, nil)
+		mockStockRepo.EXPECT().SetThreshold(gomock.Any(), cake.Id, 0, 4).Times(1).Return(nil)
+		mockStockRepo.EXPECT().FindByItem(gomock.Any(), cake.Id, 0).Times(1).Return(&model.Stock{CakeId: cake.Id, OnHand: 6, LowStockThreshold: 4}, nil)
+		mockStockRepo.EXPECT().Reserved(gomock.Any(), cake.Id, 0).Times(1).Return(2, nil)
+
+		res, err := stockService.SetThreshold(ctx, model.SetStockThresholdRequest{Threshold: 4}, cake.Id)
+		require.NoError(t, err)
+		assert.True(t, res.LowStock)
+	})
+
+	t.Run("error from repo", func(t *testing.T) {
+		mockCakeRepo.EXPECT().FindById(gomock.Any(), cake.Id).Times(1).Return(cake, nil)
+		mockStockRepo.EXPECT().SetThreshold(gomock.Any(), cake.Id, 0, 4).Times(1).Return(errors.New("err db"))
+
+		res, err := stockService.SetThreshold(ctx, model.SetStockThresholdRequest{Threshold: 4}, cake.Id)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestStockService_Reserve(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.TODO()
+	mockStockRepo := mock.NewMockStockRepository(ctrl)
+	mockCakeRepo := mock.NewMockCakeRepository(ctrl)
+
+	stockService := &stockService{
+		stockRepository: mockStockRepo,
+		cakeRepository:  mockCakeRepo,
+	}
+
+	cake := &model.Cake{Id: 1, Title: "Kue Test", Version: 1}
+	req := model.ReserveStockRequest{Quantity: 2, TTLSeconds: 60}
+
+	t.Run("ok", func(t *testing.T) {
+		mockCakeRepo.EXPECT().FindById(gomock.Any(), cake.Id).Times(1).Return(cake, nil)
+		mockStockRepo.EXPECT().FindByItem(gomock.Any(), cake.Id, 0).Times(1).Return(&model.Stock{CakeId: cake.Id, OnHand: 5}, nil)
+		mockStockRepo.EXPECT().Reserve(gomock.Any(), gomock.Any(), 5).Times(1).Return(nil)
+
+		res, err := stockService.Reserve(ctx, req, cake.Id)
+		require.NoError(t, err)
+		assert.Len(t, res.Id, 32)
+		assert.Equal(t, 2, res.Quantity)
+		assert.WithinDuration(t, time.Now().Add(time.Minute), res.ExpiresAt, time.Second)
+	})
+
+	t.Run("not tracked", func(t *testing.T) {
+		mockCakeRepo.EXPECT().FindById(gomock.Any(), cake.Id).Times(1).Return(cake, nil)
+		mockStockRepo.EXPECT().FindByItem(gomock.Any(), cake.Id, 0).Times(1).Return(nil, nil)
+		mockStockRepo.EXPECT().Reserve(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		res, err := stockService.Reserve(ctx, req, cake.Id)
+		assert.Equal(t, constant.ErrInsufficientStock, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("insufficient stock", func(t *testing.T) {
+		mockCakeRepo.EXPECT().FindById(gomock.Any(), cake.Id).Times(1).Return(cake, nil)
+		mockStockRepo.EXPECT().FindByItem(gomock.Any(), cake.Id, 0).Times(1).Return(&model.Stock{CakeId: cake.Id, OnHand: 1}, nil)
+		mockStockRepo.EXPECT().Reserve(gomock.Any(), gomock.Any(), 1).Times(1).Return(constant.ErrInsufficientStock)
+
+		res, err := stockService.Reserve(ctx, req, cake.Id)
+		assert.Equal(t, constant.ErrInsufficientStock, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("validate error", func(t *testing.T) {
+		res, err := stockService.Reserve(ctx, model.ReserveStockRequest{Quantity: 0}, cake.Id)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestStockService_Commit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.TODO()
+	mockStockRepo := mock.NewMockStockRepository(ctrl)
+
+	stockService := &stockService{
+		stockRepository: mockStockRepo,
+	}
+
+	reservation := &model.Reservation{Id: "abc", CakeId: 1, VariantId: 5, Quantity: 2}
+
+	t.Run("ok", func(t *testing.T) {
+		mockStockRepo.EXPECT().FindReservation(gomock.Any(), reservation.Id).Times(1).Return(reservation, nil)
+		mockStockRepo.EXPECT().Release(gomock.Any(), reservation).Times(1).Return(nil)
+		mockStockRepo.EXPECT().Adjust(gomock.Any(), gomock.Any()).Times(1).
+			DoAndReturn(func(_ context.Context, adjustment *model.StockAdjustment) (*model.Stock, error) {
+				assert.Equal(t, -2, adjustment.Delta)
+				assert.Equal(t, model.StockReasonSale, adjustment.Reason)
+				return &model.Stock{CakeId: 1, VariantId: 5, OnHand: 3}, nil
+			})
+
+		res, err := stockService.Commit(ctx, reservation.Id)
+		require.NoError(t, err)
+		assert.Equal(t, reservation, res)
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		mockStockRepo.EXPECT().FindReservation(gomock.Any(), "gone").Times(1).Return(nil, nil)
+		mockStockRepo.EXPECT().Adjust(gomock.Any(), gomock.Any()).Times(0)
+
+		res, err := stockService.Commit(ctx, "gone")
+		assert.Equal(t, constant.ErrNotFound, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("error from adjust - reservation held again", func(t *testing.T) {
+		live := &model.Reservation{Id: "def", CakeId: 1, VariantId: 5, Quantity: 2, ExpiresAt: time.Now().Add(time.Minute)}
+
+		gomock.InOrder(
+			mockStockRepo.EXPECT().FindReservation(gomock.Any(), live.Id).Times(1).Return(live, nil),
+			mockStockRepo.EXPECT().Release(gomock.Any(), live).Times(1).Return(nil),
+			mockStockRepo.EXPECT().Adjust(gomock.Any(), gomock.Any()).Times(1).Return(nil, errors.New("err db")),
+			mockStockRepo.EXPECT().Reserve(gomock.Any(), live, gomock.Any()).Times(1).Return(nil),
+		)
+
+		res, err := stockService.Commit(ctx, live.Id)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("error from adjust - reservation expired", func(t *testing.T) {
+		mockStockRepo.EXPECT().FindReservation(gomock.Any(), reservation.Id).Times(1).Return(reservation, nil)
+		mockStockRepo.EXPECT().Release(gomock.Any(), reservation).Times(1).Return(nil)
+		mockStockRepo.EXPECT().Adjust(gomock.Any(), gomock.Any()).Times(1).Return(nil, errors.New("err db"))
+		mockStockRepo.EXPECT().Reserve(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		res, err := stockService.Commit(ctx, reservation.Id)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("already claimed", func(t *testing.T) {
+		mockStockRepo.EXPECT().FindReservation(gomock.Any(), reservation.Id).Times(1).Return(reservation, nil)
+		mockStockRepo.EXPECT().Release(gomock.Any(), reservation).Times(1).Return(constant.ErrNotFound)
+		mockStockRepo.EXPECT().Adjust(gomock.Any(), gomock.Any()).Times(0)
+
+		res, err := stockService.Commit(ctx, reservation.Id)
+		assert.Equal(t, constant.ErrNotFound, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestStockService_Release(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.TODO()
+	mockStockRepo := mock.NewMockStockRepository(ctrl)
+
+	stockService := &stockService{
+		stockRepository: mockStockRepo,
+	}
+
+	reservation := &model.Reservation{Id: "abc", CakeId: 1, Quantity: 2}
+
+	t.Run("ok", func(t *testing.T) {
+		mockStockRepo.EXPECT().FindReservation(gomock.Any(), reservation.Id).Times(1).Return(reservation, nil)
+		mockStockRepo.EXPECT().Release(gomock.Any(), reservation).Times(1).Return(nil)
+
+		res, err := stockService.Release(ctx, reservation.Id)
+		require.NoError(t, err)
+		assert.Equal(t, reservation, res)
+	})
+
+	t.Run("empty id", func(t *testing.T) {
+		res, err := stockService.Release(ctx, "")
+		assert.Equal(t, constant.ErrInvalidArgument, err)
+		assert.Nil(t, res)
+	})
+}
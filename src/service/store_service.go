@@ -0,0 +1,355 @@
+package service
+
+import (
+	"cake-store/src/config"
+	"cake-store/src/constant"
+	"cake-store/src/model"
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+type storeService struct {
+	storeRepository   model.StoreRepository
+	cakeRepository    model.CakeRepository
+	variantRepository model.VariantRepository
+	deliveryPolicy    model.DeliveryFeePolicy
+	exchangeRate      model.ExchangeRateProvider
+}
+
+func NewStoreService(storeRepository model.StoreRepository, cakeRepository model.CakeRepository, variantRepository model.VariantRepository,
+	deliveryPolicy model.DeliveryFeePolicy, exchangeRate model.ExchangeRateProvider) model.StoreService {
+	return &storeService{
+		storeRepository:   storeRepository,
+		cakeRepository:    cakeRepository,
+		variantRepository: variantRepository,
+		deliveryPolicy:    deliveryPolicy,
+		exchangeRate:      exchangeRate,
+	}
+}
+
+func (s *storeService) Create(ctx context.Context, req model.CreateUpdateStoreRequest) (*model.Store, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Create Store Service",
+		"req":     req,
+	})
+
+	if err := req.Validate(); err != nil {
+		log.Error(err)
+		return nil, constant.HttpValidationOrInternalErr(err)
+	}
+
+	store := &model.Store{
+		Code:      req.Code,
+		Name:      req.Name,
+		Address:   req.Address,
+		Phone:     req.Phone,
+		Latitude:  req.Latitude,
+		Longitude: req.Longitude,
+		Active:    req.Active == nil || *req.Active,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	if err := s.storeRepository.Save(ctx, store); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return store, nil
+}
+
+func (s *storeService) Update(ctx context.Context, req model.CreateUpdateStoreRequest, storeId int) (*model.Store, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Update Store Service",
+		"req":     req,
+		"storeId": storeId,
+	})
+
+	store, err := s.FindById(ctx, storeId)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		log.Error(err)
+		return nil, constant.HttpValidationOrInternalErr(err)
+	}
+
+	store.Code = req.Code
+	store.Name = req.Name
+	store.Address = req.Address
+	store.Phone = req.Phone
+	store.Latitude = req.Latitude
+	store.Longitude = req.Longitude
+	if req.Active != nil {
+		store.Active = *req.Active
+	}
+	store.UpdatedAt = time.Now()
+
+	if err = s.storeRepository.Update(ctx, store); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return store, nil
+}
+
+func (s *storeService) FindById(ctx context.Context, storeId int) (*model.Store, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Find By ID Store Service",
+		"storeId": storeId,
+	})
+
+	if storeId == 0 {
+		log.Error(constant.ErrInvalidArgument)
+		return nil, constant.ErrInvalidArgument
+	}
+
+	store, err := s.storeRepository.FindById(ctx, storeId)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	if store == nil {
+		log.Error(constant.ErrNotFound)
+		return nil, constant.ErrNotFound
+	}
+
+	return store, nil
+}
+
+func (s *storeService) FindAll(ctx context.Context) ([]*model.Store, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Find All Store Service",
+	})
+
+	stores, err := s.storeRepository.FindAll(ctx)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return stores, nil
+}
+
+// SetCake replace the availability and the price overrides of the cake in the store, the prices are only accepted
+// for the variants of the cake
+func (s *storeService) SetCake(ctx context.Context, req model.SetStoreCakeRequest, storeId int, cakeId int) (*model.StoreCake, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Set Cake Store Service",
+		"req":     req,
+		"storeId": storeId,
+		"cakeId":  cakeId,
+	})
+
+	for idx := range req.Prices {
+		if req.Prices[idx].Price.Currency == "" {
+			req.Prices[idx].Price.Currency = config.BaseCurrency()
+		}
+	}
+
+	if err := req.Validate(); err != nil {
+		log.Error(err)
+		return nil, constant.HttpValidationOrInternalErr(err)
+	}
+
+	if reason := req.Inconsistency(); reason != "" {
+		log.Error(reason)
+		return nil, constant.ErrInvalidArgument
+	}
+
+	if _, err := s.FindById(ctx, storeId); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	if err := s.findCake(ctx, cakeId); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	variants, err := s.variantRepository.FindByCakeId(ctx, cakeId)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	ofCake := make(map[int]bool, len(variants))
+	for _, variant := range variants {
+		ofCake[variant.Id] = true
+	}
+
+	storeCake := &model.StoreCake{
+		StoreId:   storeId,
+		CakeId:    cakeId,
+		Available: req.Available == nil || *req.Available,
+		Prices:    make([]*model.StorePrice, 0, len(req.Prices)),
+		UpdatedAt: time.Now(),
+	}
+	for _, price := range req.Prices {
+		if !ofCake[price.VariantId] {
+			log.Error(constant.ErrInvalidArgument)
+			return nil, constant.ErrInvalidArgument
+		}
+		storeCake.Prices = append(storeCake.Prices, &model.StorePrice{VariantId: price.VariantId, Price: price.Price})
+	}
+
+	if err = s.storeRepository.SaveCake(ctx, storeCake); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	// the cached store views of the cake are stale
+	if err = s.cakeRepository.Touch(ctx, cakeId); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return storeCake, nil
+}
+
+// FindCake find the availability and the price overrides of the cake in the store, a cake the store has no setting
+// for is available at the variant prices
+func (s *storeService) FindCake(ctx context.Context, storeId int, cakeId int) (*model.StoreCake, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Find Cake Store Service",
+		"storeId": storeId,
+		"cakeId":  cakeId,
+	})
+
+	if _, err := s.FindById(ctx, storeId); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	if err := s.findCake(ctx, cakeId); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	storeCake, err := s.storeRepository.FindCake(ctx, storeId, cakeId)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	if storeCake == nil {
+		storeCake = &model.StoreCake{StoreId: storeId, CakeId: cakeId, Available: true, Prices: make([]*model.StorePrice, 0)}
+	}
+
+	return storeCake, nil
+}
+
+// FindNearest find the active stores nearest to the point, the stores without a location are left out
+func (s *storeService) FindNearest(ctx context.Context, query model.NearestStoreQuery) ([]*model.NearbyStore, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Find Nearest Store Service",
+		"query":   query,
+	})
+
+	query.SetDefault()
+	if err := query.Validate(); err != nil {
+		log.Error(err)
+		return nil, constant.HttpValidationOrInternalErr(err)
+	}
+
+	stores, err := s.storeRepository.FindNearest(ctx, *query.Latitude, *query.Longitude, query.Limit)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	for _, store := range stores {
+		store.DistanceKm = model.RoundKm(store.DistanceKm)
+	}
+
+	return stores, nil
+}
+
+// QuoteDelivery price the delivery of an order to the point with the delivery fee policy, from the store of the
+// request or the nearest store
+func (s *storeService) QuoteDelivery(ctx context.Context, req model.DeliveryQuoteRequest) (*model.DeliveryQuote, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Quote Delivery Store Service",
+		"req":     req,
+	})
+
+	if req.Subtotal.Currency == "" {
+		req.Subtotal.Currency = config.BaseCurrency()
+	}
+
+	if err := req.Validate(); err != nil {
+		log.Error(err)
+		return nil, constant.HttpValidationOrInternalErr(err)
+	}
+
+	lat, lng := *req.Latitude, *req.Longitude
+	quote := &model.DeliveryQuote{}
+	if req.StoreId != 0 {
+		store, err := s.FindById(ctx, req.StoreId)
+		if err != nil {
+			log.Error(err)
+			return nil, err
+		}
+
+		if !store.Active {
+			log.Error(constant.ErrNotFound)
+			return nil, constant.ErrNotFound
+		}
+
+		if !store.Located() {
+			log.Error(constant.ErrInvalidArgument)
+			return nil, constant.ErrInvalidArgument
+		}
+
+		quote.Store, quote.DistanceKm = store, store.DistanceKm(lat, lng)
+	} else {
+		nearby, err := s.storeRepository.FindNearest(ctx, lat, lng, 1)
+		if err != nil {
+			log.Error(err)
+			return nil, err
+		}
+
+		if len(nearby) == 0 {
+			log.Error(constant.ErrNotFound)
+			return nil, constant.ErrNotFound
+		}
+
+		quote.Store, quote.DistanceKm = nearby[0].Store, nearby[0].DistanceKm
+	}
+
+	subtotal, err := convertPrice(ctx, s.exchangeRate, req.Subtotal, config.BaseCurrency())
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	if quote.Fee, err = s.deliveryPolicy.Fee(ctx, quote.DistanceKm, subtotal); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	quote.DistanceKm = model.RoundKm(quote.DistanceKm)
+	return quote, nil
+}
+
+func (s *storeService) findCake(ctx context.Context, cakeId int) error {
+	if cakeId == 0 {
+		return constant.ErrInvalidArgument
+	}
+
+	cake, err := s.cakeRepository.FindById(ctx, cakeId)
+	if err != nil {
+		return err
+	}
+
+	if cake == nil {
+		return constant.ErrNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,318 @@
+package service
+
+import (
+	"cake-store/src/constant"
+	"cake-store/src/model"
+	"cake-store/src/model/mock"
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStoreService_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.TODO()
+	mockStoreRepo := mock.NewMockStoreRepository(ctrl)
+
+	storeService := &storeService{
+		storeRepository: mockStoreRepo,
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		mockStoreRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+
+		res, err := storeService.Create(ctx, model.CreateUpdateStoreRequest{Code: "north", Name: "North Branch"})
+		require.NoError(t, err)
+		assert.True(t, res.Active)
+	})
+
+	t.Run("ok - inactive", func(t *testing.T) {
+		active := false
+		mockStoreRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+
+		res, err := storeService.Create(ctx, model.CreateUpdateStoreRequest{Code: "north", Name: "North Branch", Active: &active})
+		require.NoError(t, err)
+		assert.False(t, res.Active)
+	})
+
+	t.Run("validate error", func(t *testing.T) {
+		res, err := storeService.Create(ctx, model.CreateUpdateStoreRequest{Code: "North Branch", Name: "North Branch"})
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("ok - located", func(t *testing.T) {
+		lat, lng := -6.2, 106.8
+		mockStoreRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+
+		res, err := storeService.Create(ctx, model.CreateUpdateStoreRequest{Code: "north", Name: "North Branch", Latitude: &lat, Longitude: &lng})
+		require.NoError(t, err)
+		assert.True(t, res.Located())
+	})
+
+	t.Run("polar latitude", func(t *testing.T) {
+		lat, lng := 88.0, 106.8
+		res, err := storeService.Create(ctx, model.CreateUpdateStoreRequest{Code: "north", Name: "North Branch", Latitude: &lat, Longitude: &lng})
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("latitude without longitude", func(t *testing.T) {
+		lat := -6.2
+		res, err := storeService.Create(ctx, model.CreateUpdateStoreRequest{Code: "north", Name: "North Branch", Latitude: &lat})
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("duplicate code", func(t *testing.T) {
+		mockStoreRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Times(1).Return(constant.ErrAlreadyExists)
+
+		res, err := storeService.Create(ctx, model.CreateUpdateStoreRequest{Code: "north", Name: "North Branch"})
+		assert.Equal(t, constant.ErrAlreadyExists, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestStoreService_SetCake(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.TODO()
+	mockStoreRepo := mock.NewMockStoreRepository(ctrl)
+	mockCakeRepo := mock.NewMockCakeRepository(ctrl)
+	mockVariantRepo := mock.NewMockVariantRepository(ctrl)
+
+	storeService := &storeService{
+		storeRepository:   mockStoreRepo,
+		cakeRepository:    mockCakeRepo,
+		variantRepository: mockVariantRepo,
+	}
+
+	store := &model.Store{Id: 2, Active: true}
+	cake := &model.Cake{Id: 1}
+	variants := []*model.Variant{{Id: 5, CakeId: 1}, {Id: 6, CakeId: 1}}
+	available := false
+
+	t.Run("ok", func(t *testing.T) {
+		req := model.SetStoreCakeRequest{Available: &available, Prices: []model.StorePriceRequest{{VariantId: 5, Price: model.Money{Amount: 300000}}}}
+
+		mockStoreRepo.EXPECT().FindById(gomock.Any(), 2).Times(1).Return(store, nil)
+		mockCakeRepo.EXPECT().FindById(gomock.Any(), 1).Times(1).Return(cake, nil)
+		mockVariantRepo.EXPECT().FindByCakeId(gomock.Any(), 1).Times(1).Return(variants, nil)
+		mockStoreRepo.EXPECT().SaveCake(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+		mockCakeRepo.EXPECT().Touch(gomock.Any(), 1).Times(1).Return(nil)
+
+		res, err := storeService.SetCake(ctx, req, 2, 1)
+		require.NoError(t, err)
+		assert.False(t, res.Available)
+		require.Len(t, res.Prices, 1)
+		assert.Equal(t, model.NewMoney(300000, "IDR"), res.Prices[0].Price)
+	})
+
+	t.Run("variant of another cake", func(t *testing.T) {
+		req := model.SetStoreCakeRequest{Prices: []model.StorePriceRequest{{VariantId: 9, Price: model.NewMoney(300000, "IDR")}}}
+
+		mockStoreRepo.EXPECT().FindById(gomock.Any(), 2).Times(1).Return(store, nil)
+		mockCakeRepo.EXPECT().FindById(gomock.Any(), 1).Times(1).Return(cake, nil)
+		mockVariantRepo.EXPECT().FindByCakeId(gomock.Any(), 1).Times(1).Return(variants, nil)
+		mockStoreRepo.EXPECT().SaveCake(gomock.Any(), gomock.Any()).Times(0)
+
+		res, err := storeService.SetCake(ctx, req, 2, 1)
+		assert.Equal(t, constant.ErrInvalidArgument, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("variant priced twice", func(t *testing.T) {
+		req := model.SetStoreCakeRequest{Prices: []model.StorePriceRequest{
+			{VariantId: 5, Price: model.NewMoney(300000, "IDR")},
+			{VariantId: 5, Price: model.NewMoney(310000, "IDR")},
+		}}
+
+		res, err := storeService.SetCake(ctx, req, 2, 1)
+		assert.Equal(t, constant.ErrInvalidArgument, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("store not found", func(t *testing.T) {
+		mockStoreRepo.EXPECT().FindById(gomock.Any(), 3).Times(1).Return(nil, nil)
+
+		res, err := storeService.SetCake(ctx, model.SetStoreCakeRequest{}, 3, 1)
+		assert.Equal(t, constant.ErrNotFound, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestStoreService_FindCake(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.TODO()
+	mockStoreRepo := mock.NewMockStoreRepository(ctrl)
+	mockCakeRepo := mock.NewMockCakeRepository(ctrl)
+
+	storeService := &storeService{
+		storeRepository: mockStoreRepo,
+		cakeRepository:  mockCakeRepo,
+	}
+
+	t.Run("ok - no setting", func(t *testing.T) {
+		mockStoreRepo.EXPECT().FindById(gomock.Any(), 2).Times(1).Return(&model.Store{Id: 2}, nil)
+		mockCakeRepo.EXPECT().FindById(gomock.Any(), 1).Times(1).Return(&model.Cake{Id: 1}, nil)
+		mockStoreRepo.EXPECT().FindCake(gomock.Any(), 2, 1).Times(1).Return(nil, nil)
+
+		res, err := storeService.FindCake(ctx, 2, 1)
+		require.NoError(t, err)
+		assert.True(t, res.Available)
+		assert.Empty(t, res.Prices)
+	})
+
+	t.Run("cake not found", func(t *testing.T) {
+		mockStoreRepo.EXPECT().FindById(gomock.Any(), 2).Times(1).Return(&model.Store{Id: 2}, nil)
+		mockCakeRepo.EXPECT().FindById(gomock.Any(), 9).Times(1).Return(nil, nil)
+
+		res, err := storeService.FindCake(ctx, 2, 9)
+		assert.Equal(t, constant.ErrNotFound, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestStoreService_FindNearest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.TODO()
+	mockStoreRepo := mock.NewMockStoreRepository(ctrl)
+
+	storeService := &storeService{
+		storeRepository: mockStoreRepo,
+	}
+
+	lat, lng := -6.2, 106.8
+
+	t.Run("ok", func(t *testing.T) {
+		mockStoreRepo.EXPECT().FindNearest(gomock.Any(), lat, lng, model.DefaultNearestLimit).Times(1).
+			Return([]*model.NearbyStore{{Store: &model.Store{Id: 1}, DistanceKm: 3.14159}}, nil)
+
+		res, err := storeService.FindNearest(ctx, model.NearestStoreQuery{Latitude: &lat, Longitude: &lng})
+		require.NoError(t, err)
+		require.Len(t, res, 1)
+		assert.Equal(t, 3.142, res[0].DistanceKm)
+	})
+
+	t.Run("ok - on the equator", func(t *testing.T) {
+		zero := 0.0
+		mockStoreRepo.EXPECT().FindNearest(gomock.Any(), zero, lng, 5).Times(1).Return([]*model.NearbyStore{}, nil)
+
+		res, err := storeService.FindNearest(ctx, model.NearestStoreQuery{Latitude: &zero, Longitude: &lng, Limit: 5})
+		require.NoError(t, err)
+		assert.Empty(t, res)
+	})
+
+	t.Run("missing longitude", func(t *testing.T) {
+		res, err := storeService.FindNearest(ctx, model.NearestStoreQuery{Latitude: &lat})
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("invalid latitude", func(t *testing.T) {
+		wrong := 91.0
+		res, err := storeService.FindNearest(ctx, model.NearestStoreQuery{Latitude: &wrong, Longitude: &lng})
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("polar latitude", func(t *testing.T) {
+		polar := 89.0
+		res, err := storeService.FindNearest(ctx, model.NearestStoreQuery{Latitude: &polar, Longitude: &lng})
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestStoreService_QuoteDelivery(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.TODO()
+	mockStoreRepo := mock.NewMockStoreRepository(ctrl)
+	mockDeliveryPolicy := mock.NewMockDeliveryFeePolicy(ctrl)
+	mockExchangeRate := mock.NewMockExchangeRateProvider(ctrl)
+
+	storeService := &storeService{
+		storeRepository: mockStoreRepo,
+		deliveryPolicy:  mockDeliveryPolicy,
+		exchangeRate:    mockExchangeRate,
+	}
+
+	// Monas, Jakarta and a customer about 2.5 km south
+	storeLat, storeLng := -6.1754, 106.8272
+	lat, lng := -6.1979, 106.8272
+	store := &model.Store{Id: 1, Latitude: &storeLat, Longitude: &storeLng, Active: true}
+	fee := model.NewMoney(1000000, "IDR")
+
+	t.Run("ok - nearest store", func(t *testing.T) {
+		mockStoreRepo.EXPECT().FindNearest(gomock.Any(), lat, lng, 1).Times(1).
+			Return([]*model.NearbyStore{{Store: store, DistanceKm: 2.50271}}, nil)
+		mockDeliveryPolicy.EXPECT().Fee(gomock.Any(), 2.50271, model.NewMoney(2000000, "IDR")).Times(1).Return(fee, nil)
+
+		res, err := storeService.QuoteDelivery(ctx, model.DeliveryQuoteRequest{Latitude: &lat, Longitude: &lng, Subtotal: model.Money{Amount: 2000000}})
+		require.NoError(t, err)
+		assert.Equal(t, 1, res.Store.Id)
+		assert.Equal(t, 2.503, res.DistanceKm)
+		assert.Equal(t, fee, res.Fee)
+	})
+
+	t.Run("ok - from the store", func(t *testing.T) {
+		mockStoreRepo.EXPECT().FindById(gomock.Any(), 1).Times(1).Return(store, nil)
+		mockExchangeRate.EXPECT().Rate(gomock.Any(), "USD", "IDR").Times(1).Return(big.NewRat(16000, 1), nil)
+		mockDeliveryPolicy.EXPECT().Fee(gomock.Any(), gomock.Any(), model.NewMoney(16000000, "IDR")).Times(1).
+			DoAndReturn(func(_ context.Context, distanceKm float64, _ model.Money) (model.Money, error) {
+				assert.InDelta(t, 2.5, distanceKm, 0.01)
+				return fee, nil
+			})
+
+		res, err := storeService.QuoteDelivery(ctx, model.DeliveryQuoteRequest{StoreId: 1, Latitude: &lat, Longitude: &lng, Subtotal: model.NewMoney(1000, "USD")})
+		require.NoError(t, err)
+		assert.Equal(t, fee, res.Fee)
+	})
+
+	t.Run("store without location", func(t *testing.T) {
+		mockStoreRepo.EXPECT().FindById(gomock.Any(), 2).Times(1).Return(&model.Store{Id: 2, Active: true}, nil)
+
+		res, err := storeService.QuoteDelivery(ctx, model.DeliveryQuoteRequest{StoreId: 2, Latitude: &lat, Longitude: &lng})
+		assert.Equal(t, constant.ErrInvalidArgument, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("inactive store", func(t *testing.T) {
+		mockStoreRepo.EXPECT().FindById(gomock.Any(), 3).Times(1).Return(&model.Store{Id: 3, Latitude: &storeLat, Longitude: &storeLng}, nil)
+
+		res, err := storeService.QuoteDelivery(ctx, model.DeliveryQuoteRequest{StoreId: 3, Latitude: &lat, Longitude: &lng})
+		assert.Equal(t, constant.ErrNotFound, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("no located store", func(t *testing.T) {
+		mockStoreRepo.EXPECT().FindNearest(gomock.Any(), lat, lng, 1).Times(1).Return([]*model.NearbyStore{}, nil)
+
+		res, err := storeService.QuoteDelivery(ctx, model.DeliveryQuoteRequest{Latitude: &lat, Longitude: &lng})
+		assert.Equal(t, constant.ErrNotFound, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("out of delivery range", func(t *testing.T) {
+		mockStoreRepo.EXPECT().FindNearest(gomock.Any(), lat, lng, 1).Times(1).
+			Return([]*model.NearbyStore{{Store: store, DistanceKm: 2.5}}, nil)
+		mockDeliveryPolicy.EXPECT().Fee(gomock.Any(), 2.5, gomock.Any()).Times(1).Return(model.Money{}, constant.ErrOutOfDeliveryRange)
+
+		res, err := storeService.QuoteDelivery(ctx, model.DeliveryQuoteRequest{Latitude: &lat, Longitude: &lng})
+		assert.Equal(t, constant.ErrOutOfDeliveryRange, err)
+		assert.Nil(t, res)
+	})
+}
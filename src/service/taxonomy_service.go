@@ -0,0 +1,242 @@
+package service
+
+import (
+	"cake-store/src/constant"
+	"cake-store/src/helper"
+	"cake-store/src/model"
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+type taxonomyService struct {
+	taxonomy           model.Taxonomy
+	taxonomyRepository model.TaxonomyRepository
+	cakeRepository     model.CakeRepository
+}
+
+func NewTaxonomyService(taxonomy model.Taxonomy, taxonomyRepository model.TaxonomyRepository, cakeRepository model.CakeRepository) model.TaxonomyService {
+	return &taxonomyService{
+		taxonomy:           taxonomy,
+		taxonomyRepository: taxonomyRepository,
+		cakeRepository:     cakeRepository,
+	}
+}
+
+func (t *taxonomyService) Create(ctx context.Context, req model.CreateUpdateTermRequest) (*model.Term, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message":  "Create Taxonomy Service",
+		"taxonomy": t.taxonomy.Name,
+		"req":      req,
+	})
+
+	if err := req.Validate(); err != nil {
+		log.Error(err)
+		return nil, constant.HttpValidationOrInternalErr(err)
+	}
+
+	term := &model.Term{
+		CreatedAt: time.Now(),
+	}
+	if err := t.apply(term, req); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	if err := t.taxonomyRepository.Save(ctx, term); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return term, nil
+}
+
+func (t *taxonomyService) Update(ctx context.Context, req model.CreateUpdateTermRequest, termId int) (*model.Term, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message":  "Update Taxonomy Service",
+		"taxonomy": t.taxonomy.Name,
+		"req":      req,
+		"termId":   termId,
+	})
+
+	term, err := t.FindById(ctx, termId)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		log.Error(err)
+		return nil, constant.HttpValidationOrInternalErr(err)
+	}
+
+	if err = t.apply(term, req); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	if err = t.taxonomyRepository.Update(ctx, term); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	if err = t.touchCakes(ctx, termId); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return term, nil
+}
+
+// apply set the requested name, slug and description on the term, the slug default to the slugified name
+func (t *taxonomyService) apply(term *model.Term, req model.CreateUpdateTermRequest) error {
+	term.Name = req.Name
+	term.Slug = req.Slug
+	term.UpdatedAt = time.Now()
+	if t.taxonomy.Described {
+		description := req.Description
+		term.Description = &description
+	}
+	if term.Slug == "" {
+		term.Slug = helper.Slugify(req.Name)
+	}
+	if term.Slug == "" {
+		return constant.ErrInvalidArgument
+	}
+	return nil
+}
+
+func (t *taxonomyService) Delete(ctx context.Context, termId int) (*model.Term, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message":  "Delete Taxonomy Service",
+		"taxonomy": t.taxonomy.Name,
+		"termId":   termId,
+	})
+
+	term, err := t.FindById(ctx, termId)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	// the cake ids must be read before the delete cascade to the join table
+	cakeIds, err := t.taxonomyRepository.FindCakeIds(ctx, termId)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	if err = t.taxonomyRepository.Delete(ctx, term); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	if err = t.cakeRepository.Touch(ctx, cakeIds...); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return term, nil
+}
+
+func (t *taxonomyService) FindById(ctx context.Context, termId int) (*model.Term, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message":  "Find By ID Taxonomy Service",
+		"taxonomy": t.taxonomy.Name,
+		"termId":   termId,
+	})
+
+	if termId == 0 {
+		log.Error(constant.ErrInvalidArgument)
+		return nil, constant.ErrInvalidArgument
+	}
+
+	term, err := t.taxonomyRepository.FindById(ctx, termId)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	if term == nil {
+		log.Error(constant.ErrNotFound)
+		return nil, constant.ErrNotFound
+	}
+
+	return term, nil
+}
+
+func (t *taxonomyService) FindAll(ctx context.Context) ([]*model.Term, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message":  "Find All Taxonomy Service",
+		"taxonomy": t.taxonomy.Name,
+	})
+
+	terms, err := t.taxonomyRepository.FindAll(ctx)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return terms, nil
+}
+
+// SetCakeTerms replace the terms of the cake, every term must exist
+func (t *taxonomyService) SetCakeTerms(ctx context.Context, req model.SetCakeRelationRequest, cakeId int) ([]*model.Term, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message":  "Set Cake Terms Taxonomy Service",
+		"taxonomy": t.taxonomy.Name,
+		"req":      req,
+		"cakeId":   cakeId,
+	})
+
+	if err := req.Validate(); err != nil {
+		log.Error(err)
+		return nil, constant.HttpValidationOrInternalErr(err)
+	}
+
+	cake, err := t.cakeRepository.FindById(ctx, cakeId)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	if cake == nil {
+		log.Error(constant.ErrNotFound)
+		return nil, constant.ErrNotFound
+	}
+
+	ids := req.UniqueIds()
+	terms, err := t.taxonomyRepository.FindByIds(ctx, ids)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	if len(terms) != len(ids) {
+		log.Error(constant.ErrInvalidArgument)
+		return nil, constant.ErrInvalidArgument
+	}
+
+	if err = t.taxonomyRepository.SetCakeTerms(ctx, cakeId, ids); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	if err = t.cakeRepository.Touch(ctx, cakeId); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return terms, nil
+}
+
+// touchCakes invalidate the cakes having the term, so their cached copy and entity tag reflect the change
+func (t *taxonomyService) touchCakes(ctx context.Context, termId int) error {
+	cakeIds, err := t.taxonomyRepository.FindCakeIds(ctx, termId)
+	if err != nil {
+		return err
+	}
+
+	return t.cakeRepository.Touch(ctx, cakeIds...)
+}
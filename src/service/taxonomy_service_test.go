@@ -0,0 +1,283 @@
+package service
+
+import (
+	"cake-store/src/constant"
+	"cake-store/src/model"
+	"cake-store/src/model/mock"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaxonomyService_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.TODO()
+	mockTaxonomyRepo := mock.NewMockTaxonomyRepository(ctrl)
+
+	categoryService := &taxonomyService{
+		taxonomy:           model.Categories,
+		taxonomyRepository: mockTaxonomyRepo,
+	}
+	tagService := &taxonomyService{
+		taxonomy:           model.Tags,
+		taxonomyRepository: mockTaxonomyRepo,
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		req := model.CreateUpdateTermRequest{Name: "Birthday Cake", Description: "Desc test"}
+
+		mockTaxonomyRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+		res, err := categoryService.Create(ctx, req)
+		require.NoError(t, err)
+		assert.Equal(t, "birthday-cake", res.Slug)
+		require.NotNil(t, res.Description)
+		assert.Equal(t, "Desc test", *res.Description)
+	})
+
+	t.Run("ok - given slug", func(t *testing.T) {
+		req := model.CreateUpdateTermRequest{Name: "Birthday Cake", Slug: "birthday"}
+
+		mockTaxonomyRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+		res, err := categoryService.Create(ctx, req)
+		assert.NoError(t, err)
+		assert.Equal(t, "birthday", res.Slug)
+	})
+
+	t.Run("ok - description ignored when not described", func(t *testing.T) {
+		req := model.CreateUpdateTermRequest{Name: "Chocolate", Description: "Desc test"}
+
+		mockTaxonomyRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+		res, err := tagService.Create(ctx, req)
+		require.NoError(t, err)
+		assert.Equal(t, "chocolate", res.Slug)
+		assert.Nil(t, res.Description)
+	})
+
+	t.Run("validate error", func(t *testing.T) {
+		req := model.CreateUpdateTermRequest{Name: "Birthday", Slug: "Not A Slug"}
+
+		mockTaxonomyRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Times(0)
+		res, err := categoryService.Create(ctx, req)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("empty slug", func(t *testing.T) {
+		req := model.CreateUpdateTermRequest{Name: "!!!"}
+
+		mockTaxonomyRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Times(0)
+		res, err := tagService.Create(ctx, req)
+		assert.Equal(t, constant.ErrInvalidArgument, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("error from repo", func(t *testing.T) {
+		req := model.CreateUpdateTermRequest{Name: "Birthday"}
+
+		mockTaxonomyRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Times(1).Return(constant.ErrAlreadyExists)
+		res, err := categoryService.Create(ctx, req)
+		assert.Equal(t, constant.ErrAlreadyExists, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestTaxonomyService_Update(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.TODO()
+	mockTaxonomyRepo := mock.NewMockTaxonomyRepository(ctrl)
+	mockCakeRepo := mock.NewMockCakeRepository(ctrl)
+
+	taxonomyService := &taxonomyService{
+		taxonomy:           model.Categories,
+		taxonomyRepository: mockTaxonomyRepo,
+		cakeRepository:     mockCakeRepo,
+	}
+
+	term := &model.Term{
+		Id:        1,
+		Name:      "Birthday",
+		Slug:      "birthday",
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		req := model.CreateUpdateTermRequest{Name: "Birthday Party"}
+
+		mockTaxonomyRepo.EXPECT().FindById(gomock.Any(), term.Id).Times(1).Return(term, nil)
+		mockTaxonomyRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+		mockTaxonomyRepo.EXPECT().FindCakeIds(gomock.Any(), term.Id).Times(1).Return([]int{2, 5}, nil)
+		mockCakeRepo.EXPECT().Touch(gomock.Any(), 2, 5).Times(1).Return(nil)
+
+		res, err := taxonomyService.Update(ctx, req, term.Id)
+		assert.NoError(t, err)
+		assert.Equal(t, "birthday-party", res.Slug)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mockTaxonomyRepo.EXPECT().FindById(gomock.Any(), 2).Times(1).Return(nil, nil)
+
+		res, err := taxonomyService.Update(ctx, model.CreateUpdateTermRequest{Name: "Birthday"}, 2)
+		assert.Equal(t, constant.ErrNotFound, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("validate error", func(t *testing.T) {
+		mockTaxonomyRepo.EXPECT().FindById(gomock.Any(), term.Id).Times(1).Return(term, nil)
+		mockTaxonomyRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Times(0)
+
+		res, err := taxonomyService.Update(ctx, model.CreateUpdateTermRequest{Name: "a"}, term.Id)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("error from repo", func(t *testing.T) {
+		mockTaxonomyRepo.EXPECT().FindById(gomock.Any(), term.Id).Times(1).Return(term, nil)
+		mockTaxonomyRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Times(1).Return(errors.New("err db"))
+
+		res, err := taxonomyService.Update(ctx, model.CreateUpdateTermRequest{Name: "Birthday"}, term.Id)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestTaxonomyService_Delete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.TODO()
+	mockTaxonomyRepo := mock.NewMockTaxonomyRepository(ctrl)
+	mockCakeRepo := mock.NewMockCakeRepository(ctrl)
+
+	taxonomyService := &taxonomyService{
+		taxonomy:           model.Tags,
+		taxonomyRepository: mockTaxonomyRepo,
+		cakeRepository:     mockCakeRepo,
+	}
+
+	term := &model.Term{Id: 1, Name: "Chocolate", Slug: "chocolate"}
+
+	t.Run("ok", func(t *testing.T) {
+		gomock.InOrder(
+			mockTaxonomyRepo.EXPECT().FindById(gomock.Any(), term.Id).Times(1).Return(term, nil),
+			mockTaxonomyRepo.EXPECT().FindCakeIds(gomock.Any(), term.Id).Times(1).Return([]int{3}, nil),
+			mockTaxonomyRepo.EXPECT().Delete(gomock.Any(), term).Times(1).Return(nil),
+			mockCakeRepo.EXPECT().Touch(gomock.Any(), 3).Times(1).Return(nil),
+		)
+
+		res, err := taxonomyService.Delete(ctx, term.Id)
+		assert.NoError(t, err)
+		assert.NotNil(t, res)
+	})
+
+	t.Run("invalid id", func(t *testing.T) {
+		res, err := taxonomyService.Delete(ctx, 0)
+		assert.Equal(t, constant.ErrInvalidArgument, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("error from repo", func(t *testing.T) {
+		mockTaxonomyRepo.EXPECT().FindById(gomock.Any(), term.Id).Times(1).Return(term, nil)
+		mockTaxonomyRepo.EXPECT().FindCakeIds(gomock.Any(), term.Id).Times(1).Return([]int{}, nil)
+		mockTaxonomyRepo.EXPECT().Delete(gomock.Any(), term).Times(1).Return(errors.New("err db"))
+
+		res, err := taxonomyService.Delete(ctx, term.Id)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestTaxonomyService_FindAll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.TODO()
+	mockTaxonomyRepo := mock.NewMockTaxonomyRepository(ctrl)
+
+	taxonomyService := &taxonomyService{
+		taxonomy:           model.Categories,
+		taxonomyRepository: mockTaxonomyRepo,
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		terms := []*model.Term{{Id: 1, Name: "Birthday", Slug: "birthday"}}
+		mockTaxonomyRepo.EXPECT().FindAll(gomock.Any()).Times(1).Return(terms, nil)
+
+		res, err := taxonomyService.FindAll(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, terms, res)
+	})
+
+	t.Run("error from repo", func(t *testing.T) {
+		mockTaxonomyRepo.EXPECT().FindAll(gomock.Any()).Times(1).Return(nil, errors.New("err db"))
+
+		res, err := taxonomyService.FindAll(ctx)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestTaxonomyService_SetCakeTerms(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.TODO()
+	mockTaxonomyRepo := mock.NewMockTaxonomyRepository(ctrl)
+	mockCakeRepo := mock.NewMockCakeRepository(ctrl)
+
+	taxonomyService := &taxonomyService{
+		taxonomy:           model.Categories,
+		taxonomyRepository: mockTaxonomyRepo,
+		cakeRepository:     mockCakeRepo,
+	}
+
+	cake := &model.Cake{Id: 1, Title: "Kue Test", Version: 1}
+	terms := []*model.Term{
+		{Id: 1, Name: "Birthday", Slug: "birthday"},
+		{Id: 2, Name: "Vegan", Slug: "vegan"},
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		mockCakeRepo.EXPECT().FindById(gomock.Any(), cake.Id).Times(1).Return(cake, nil)
+		mockTaxonomyRepo.EXPECT().FindByIds(gomock.Any(), []int{2, 1}).Times(1).Return(terms, nil)
+		mockTaxonomyRepo.EXPECT().SetCakeTerms(gomock.Any(), cake.Id, []int{2, 1}).Times(1).Return(nil)
+		mockCakeRepo.EXPECT().Touch(gomock.Any(), cake.Id).Times(1).Return(nil)
+
+		res, err := taxonomyService.SetCakeTerms(ctx, model.SetCakeRelationRequest{Ids: []int{2, 1, 2}}, cake.Id)
+		assert.NoError(t, err)
+		assert.Equal(t, terms, res)
+	})
+
+	t.Run("cake not found", func(t *testing.T) {
+		mockCakeRepo.EXPECT().FindById(gomock.Any(), 2).Times(1).Return(nil, nil)
+
+		res, err := taxonomyService.SetCakeTerms(ctx, model.SetCakeRelationRequest{Ids: []int{1}}, 2)
+		assert.Equal(t, constant.ErrNotFound, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("unknown term", func(t *testing.T) {
+		mockCakeRepo.EXPECT().FindById(gomock.Any(), cake.Id).Times(1).Return(cake, nil)
+		mockTaxonomyRepo.EXPECT().FindByIds(gomock.Any(), []int{1, 9}).Times(1).Return(terms[:1], nil)
+		mockTaxonomyRepo.EXPECT().SetCakeTerms(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		res, err := taxonomyService.SetCakeTerms(ctx, model.SetCakeRelationRequest{Ids: []int{1, 9}}, cake.Id)
+		assert.Equal(t, constant.ErrInvalidArgument, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("validate error", func(t *testing.T) {
+		res, err := taxonomyService.SetCakeTerms(ctx, model.SetCakeRelationRequest{Ids: []int{0}}, cake.Id)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+}
@@ -0,0 +1,210 @@
+package service
+
+import (
+	"cake-store/src/config"
+	"cake-store/src/constant"
+	"cake-store/src/model"
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+type variantService struct {
+	variantRepository model.VariantRepository
+	cakeRepository    model.CakeRepository
+}
+
+func NewVariantService(variantRepository model.VariantRepository, cakeRepository model.CakeRepository) model.VariantService {
+	return &variantService{
+		variantRepository: variantRepository,
+		cakeRepository:    cakeRepository,
+	}
+}
+
+func (v *variantService) Create(ctx context.Context, req model.CreateUpdateVariantRequest, cakeId int) (*model.Variant, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Create Variant Service",
+		"req":     req,
+		"cakeId":  cakeId,
+	})
+
+	if err := v.findCake(ctx, cakeId); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	setDefaultCurrency(&req)
+	if err := req.Validate(); err != nil {
+		log.Error(err)
+		return nil, constant.HttpValidationOrInternalErr(err)
+	}
+
+	variant := &model.Variant{
+		CakeId:    cakeId,
+		Size:      req.Size,
+		Servings:  req.Servings,
+		Price:     req.Price,
+		Sku:       req.Sku,
+		Active:    req.Active == nil || *req.Active,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	if err := v.variantRepository.Save(ctx, variant); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	if err := v.cakeRepository.Touch(ctx, cakeId); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return variant, nil
+}
+
+func (v *variantService) Update(ctx context.Context, req model.CreateUpdateVariantRequest, cakeId int, variantId int) (*model.Variant, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message":   "Update Variant Service",
+		"req":       req,
+		"cakeId":    cakeId,
+		"variantId": variantId,
+	})
+
+	variant, err := v.FindById(ctx, cakeId, variantId)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	setDefaultCurrency(&req)
+	if err := req.Validate(); err != nil {
+		log.Error(err)
+		return nil, constant.HttpValidationOrInternalErr(err)
+	}
+
+	variant.Size = req.Size
+	variant.Servings = req.Servings
+	variant.Price = req.Price
+	variant.Sku = req.Sku
+	if req.Active != nil {
+		variant.Active = *req.Active
+	}
+	variant.UpdatedAt = time.Now()
+
+	if err = v.variantRepository.Update(ctx, variant); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	if err = v.cakeRepository.Touch(ctx, cakeId); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return variant, nil
+}
+
+func (v *variantService) Delete(ctx context.Context, cakeId int, variantId int) (*model.Variant, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message":   "Delete Variant Service",
+		"cakeId":    cakeId,
+		"variantId": variantId,
+	})
+
+	variant, err := v.FindById(ctx, cakeId, variantId)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	if err = v.variantRepository.Delete(ctx, variant); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	if err = v.cakeRepository.Touch(ctx, cakeId); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return variant, nil
+}
+
+// FindById find the variant of the cake, a variant of another cake is reported as not found
+func (v *variantService) FindById(ctx context.Context, cakeId int, variantId int) (*model.Variant, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message":   "Find By ID Variant Service",
+		"cakeId":    cakeId,
+		"variantId": variantId,
+	})
+
+	if err := v.findCake(ctx, cakeId); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	if variantId == 0 {
+		log.Error(constant.ErrInvalidArgument)
+		return nil, constant.ErrInvalidArgument
+	}
+
+	variant, err := v.variantRepository.FindById(ctx, variantId)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	if variant == nil || variant.CakeId != cakeId {
+		log.Error(constant.ErrNotFound)
+		return nil, constant.ErrNotFound
+	}
+
+	return variant, nil
+}
+
+func (v *variantService) FindAll(ctx context.Context, cakeId int) ([]*model.Variant, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"message": "Find All Variant Service",
+		"cakeId":  cakeId,
+	})
+
+	if err := v.findCake(ctx, cakeId); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	variants, err := v.variantRepository.FindByCakeId(ctx, cakeId)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return variants, nil
+}
+
+// findCake check the cake exist and is not deleted
+func (v *variantService) findCake(ctx context.Context, cakeId int) error {
+	if cakeId == 0 {
+		return constant.ErrInvalidArgument
+	}
+
+	cake, err := v.cakeRepository.FindById(ctx, cakeId)
+	if err != nil {
+		return err
+	}
+
+	if cake == nil {
+		return constant.ErrNotFound
+	}
+
+	return nil
+}
+
+// setDefaultCurrency price the variant in the base currency when the request omit the currency
+func setDefaultCurrency(req *model.CreateUpdateVariantRequest) {
+	if req.Price.Currency == "" {
+		req.Price.Currency = config.BaseCurrency()
+	}
+}
@@ -0,0 +1,229 @@
+package service
+
+import (
+	"cake-store/src/constant"
+	"cake-store/src/model"
+	"cake-store/src/model/mock"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVariantService_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.TODO()
+	mockVariantRepo := mock.NewMockVariantRepository(ctrl)
+	mockCakeRepo := mock.NewMockCakeRepository(ctrl)
+
+	variantService := &variantService{
+		variantRepository: mockVariantRepo,
+		cakeRepository:    mockCakeRepo,
+	}
+
+	cake := &model.Cake{Id: 1, Title: "Kue Test", Version: 1}
+	req := model.CreateUpdateVariantRequest{
+		Size:     "20cm",
+		Servings: 8,
+		Price:    model.NewMoney(250000, "IDR"),
+		Sku:      "CHOCO-20",
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		mockCakeRepo.EXPECT().FindById(gomock.Any(), cake.Id).Times(1).Return(cake, nil)
+		mockVariantRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+		mockCakeRepo.EXPECT().Touch(gomock.Any(), cake.Id).Times(1).Return(nil)
+
+		res, err := variantService.Create(ctx, req, cake.Id)
+		assert.NoError(t, err)
+		assert.Equal(t, cake.Id, res.CakeId)
+		assert.True(t, res.Active)
+	})
+
+	t.Run("ok - inactive", func(t *testing.T) {
+		req := req
+		active := false
+		req.Active = &active
+
+		mockCakeRepo.EXPECT().FindById(gomock.Any(), cake.Id).Times(1).Return(cake, nil)
+		mockVariantRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+		mockCakeRepo.EXPECT().Touch(gomock.Any(), cake.Id).Times(1).Return(nil)
+
+		res, err := variantService.Create(ctx, req, cake.Id)
+		assert.NoError(t, err)
+		assert.False(t, res.Active)
+	})
+
+	t.Run("cake not found", func(t *testing.T) {
+		mockCakeRepo.EXPECT().FindById(gomock.Any(), 2).Times(1).Return(nil, nil)
+		mockVariantRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Times(0)
+
+		res, err := variantService.Create(ctx, req, 2)
+		assert.Equal(t, constant.ErrNotFound, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("validate error", func(t *testing.T) {
+		req := req
+		req.Sku = "choco 20"
+
+		mockCakeRepo.EXPECT().FindById(gomock.Any(), cake.Id).Times(1).Return(cake, nil)
+		mockVariantRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Times(0)
+
+		res, err := variantService.Create(ctx, req, cake.Id)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("error from repo", func(t *testing.T) {
+		mockCakeRepo.EXPECT().FindById(gomock.Any(), cake.Id).Times(1).Return(cake, nil)
+		mockVariantRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Times(1).Return(constant.ErrAlreadyExists)
+		mockCakeRepo.EXPECT().Touch(gomock.Any(), gomock.Any()).Times(0)
+
+		res, err := variantService.Create(ctx, req, cake.Id)
+		assert.Equal(t, constant.ErrAlreadyExists, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestVariantService_Update(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.TODO()
+	mockVariantRepo := mock.NewMockVariantRepository(ctrl)
+	mockCakeRepo := mock.NewMockCakeRepository(ctrl)
+
+	variantService := &variantService{
+		variantRepository: mockVariantRepo,
+		cakeRepository:    mockCakeRepo,
+	}
+
+	cake := &model.Cake{Id: 1, Title: "Kue Test", Version: 1}
+	req := model.CreateUpdateVariantRequest{
+		Size:     "24cm",
+		Servings: 12,
+		Price:    model.NewMoney(320000, "IDR"),
+		Sku:      "CHOCO-24",
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		variant := &model.Variant{Id: 5, CakeId: cake.Id, Size: "20cm", Active: false, CreatedAt: time.Now()}
+
+		mockCakeRepo.EXPECT().FindById(gomock.Any(), cake.Id).Times(1).Return(cake, nil)
+		mockVariantRepo.EXPECT().FindById(gomock.Any(), variant.Id).Times(1).Return(variant, nil)
+		mockVariantRepo.EXPECT().Update(gomock.Any(), variant).Times(1).Return(nil)
+		mockCakeRepo.EXPECT().Touch(gomock.Any(), cake.Id).Times(1).Return(nil)
+
+		res, err := variantService.Update(ctx, req, cake.Id, variant.Id)
+		assert.NoError(t, err)
+		assert.Equal(t, "24cm", res.Size)
+		assert.False(t, res.Active)
+	})
+
+	t.Run("variant of another cake", func(t *testing.T) {
+		variant := &model.Variant{Id: 6, CakeId: 3}
+
+		mockCakeRepo.EXPECT().FindById(gomock.Any(), cake.Id).Times(1).Return(cake, nil)
+		mockVariantRepo.EXPECT().FindById(gomock.Any(), variant.Id).Times(1).Return(variant, nil)
+		mockVariantRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Times(0)
+
+		res, err := variantService.Update(ctx, req, cake.Id, variant.Id)
+		assert.Equal(t, constant.ErrNotFound, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("error from repo", func(t *testing.T) {
+		variant := &model.Variant{Id: 5, CakeId: cake.Id}
+
+		mockCakeRepo.EXPECT().FindById(gomock.Any(), cake.Id).Times(1).Return(cake, nil)
+		mockVariantRepo.EXPECT().FindById(gomock.Any(), variant.Id).Times(1).Return(variant, nil)
+		mockVariantRepo.EXPECT().Update(gomock.Any(), variant).Times(1).Return(errors.New("err db"))
+
+		res, err := variantService.Update(ctx, req, cake.Id, variant.Id)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestVariantService_Delete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.TODO()
+	mockVariantRepo := mock.NewMockVariantRepository(ctrl)
+	mockCakeRepo := mock.NewMockCakeRepository(ctrl)
+
+	variantService := &variantService{
+		variantRepository: mockVariantRepo,
+		cakeRepository:    mockCakeRepo,
+	}
+
+	cake := &model.Cake{Id: 1, Title: "Kue Test", Version: 1}
+	variant := &model.Variant{Id: 5, CakeId: cake.Id}
+
+	t.Run("ok", func(t *testing.T) {
+		mockCakeRepo.EXPECT().FindById(gomock.Any(), cake.Id).Times(1).Return(cake, nil)
+		mockVariantRepo.EXPECT().FindById(gomock.Any(), variant.Id).Times(1).Return(variant, nil)
+		mockVariantRepo.EXPECT().Delete(gomock.Any(), variant).Times(1).Return(nil)
+		mockCakeRepo.EXPECT().Touch(gomock.Any(), cake.Id).Times(1).Return(nil)
+
+		res, err := variantService.Delete(ctx, cake.Id, variant.Id)
+		assert.NoError(t, err)
+		assert.NotNil(t, res)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mockCakeRepo.EXPECT().FindById(gomock.Any(), cake.Id).Times(1).Return(cake, nil)
+		mockVariantRepo.EXPECT().FindById(gomock.Any(), 9).Times(1).Return(nil, nil)
+
+		res, err := variantService.Delete(ctx, cake.Id, 9)
+		assert.Equal(t, constant.ErrNotFound, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestVariantService_FindAll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.TODO()
+	mockVariantRepo := mock.NewMockVariantRepository(ctrl)
+	mockCakeRepo := mock.NewMockCakeRepository(ctrl)
+
+	variantService := &variantService{
+		variantRepository: mockVariantRepo,
+		cakeRepository:    mockCakeRepo,
+	}
+
+	cake := &model.Cake{Id: 1, Title: "Kue Test", Version: 1}
+
+	t.Run("ok", func(t *testing.T) {
+		variants := []*model.Variant{{Id: 5, CakeId: cake.Id}}
+		mockCakeRepo.EXPECT().FindById(gomock.Any(), cake.Id).Times(1).Return(cake, nil)
+		mockVariantRepo.EXPECT().FindByCakeId(gomock.Any(), cake.Id).Times(1).Return(variants, nil)
+
+		res, err := variantService.FindAll(ctx, cake.Id)
+		assert.NoError(t, err)
+		assert.Equal(t, variants, res)
+	})
+
+	t.Run("invalid cake id", func(t *testing.T) {
+		res, err := variantService.FindAll(ctx, 0)
+		assert.Equal(t, constant.ErrInvalidArgument, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("error from repo", func(t *testing.T) {
+		mockCakeRepo.EXPECT().FindById(gomock.Any(), cake.Id).Times(1).Return(nil, errors.New("err db"))
+
+		res, err := variantService.FindAll(ctx, cake.Id)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+}